@@ -2,21 +2,32 @@ package bootstrap
 
 import (
 	"context"
-	"time"
+	"errors"
+	"fmt"
+	"log/slog"
 
-	"github.com/kelseyhightower/envconfig"
 	_ "github.com/lib/pq"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/config"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/di"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/metrics"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/outbox"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/scheduler"
+	"github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/poller"
 	"github.com/rubenbupe/recipe-video-parser/kit/command"
 )
 
-func Run() error {
-	var cfg config
-	err := envconfig.Process("APP", &cfg)
-	if err != nil {
-		return err
+func Run() (err error) {
+	// Valida toda la configuración antes de construir ningún componente
+	if _, err := config.Load(config.Flags); err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
 	}
+	cfg := di.Instance().Container.Get("shared.infrastructure.serverconfig").(*config.AppConfig)
+
+	logger := di.Instance().Container.Get("shared.infrastructure.logger").(*slog.Logger)
+	registry := di.Instance().Container.Get("shared.infrastructure.metrics").(*metrics.Registry)
+	tracer := di.Instance().Container.Get("shared.infrastructure.tracing").(*tracing.Provider)
 
 	server.ConfigureCommandBus()
 	server.ConfigureQueryBus()
@@ -24,13 +35,64 @@ func Run() error {
 
 	commandBus := di.Instance().Container.Get("shared.domain.commandbus").(command.Bus)
 
-	ctx, srv := server.New(context.Background(), cfg.Host, cfg.Port, cfg.ShutdownTimeout, commandBus)
+	relay := di.Instance().Container.Get("shared.infrastructure.outboxrelay").(*outbox.Relay)
+	subscriptionsScheduler := di.Instance().Container.Get("subscriptions.infrastructure.scheduler").(*scheduler.Scheduler)
+	webhooksPoller := di.Instance().Container.Get("webhooks.infrastructure.poller").(*poller.Poller)
+
+	ctx, srv := server.New(context.Background(), cfg.Host, cfg.Port, cfg.ShutdownTimeout, commandBus, logger, registry)
+
+	// El relay, el planificador y el poller se detienen también cuando el
+	// servidor falla
+	workersCtx, stopWorkers := context.WithCancel(ctx)
+
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		relay.Run(workersCtx)
+	}()
+
+	// Las comprobaciones en curso se interrumpen al detener el servidor; los
+	// vídeos que no se han extraído se extraen en la siguiente comprobación
+	schedulerDone := make(chan struct{})
+	go func() {
+		defer close(schedulerDone)
+		subscriptionsScheduler.Run(workersCtx)
+	}()
+
+	// Los envíos interrumpidos quedan pendientes y se reintentan al arrancar
+	pollerDone := make(chan struct{})
+	go func() {
+		defer close(pollerDone)
+		webhooksPoller.Run(workersCtx)
+	}()
+
+	defer func() {
+		stopWorkers()
+		<-relayDone
+		<-schedulerDone
+		<-pollerDone
+
+		err = errors.Join(err, shutdown(relay, tracer, cfg))
+	}()
+
 	return srv.Run(ctx)
 }
 
-type config struct {
-	// Server configuration
-	Host            string        `default:"0.0.0.0"`
-	Port            uint          `default:"8080"`
-	ShutdownTimeout time.Duration `default:"10s"`
+// shutdown drains the outbox and the event bus and exports the pending spans.
+func shutdown(relay *outbox.Relay, tracer *tracing.Provider, cfg *config.AppConfig) error {
+	ctxShutDown, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// Publica los eventos guardados mientras se detenía el servidor antes de
+	// vaciar el bus. Los que no se publiquen se enviarán en el próximo arranque.
+	if _, err := relay.Flush(ctxShutDown); err != nil {
+		return err
+	}
+
+	if err := server.ShutdownEventBus(ctxShutDown); err != nil {
+		return err
+	}
+
+	// Exporta los spans pendientes
+	return tracer.Shutdown(ctxShutDown)
 }
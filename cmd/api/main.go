@@ -1,12 +1,17 @@
 package main
 
 import (
+	"flag"
 	"log"
 
 	"github.com/rubenbupe/recipe-video-parser/cmd/api/bootstrap"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/config"
 )
 
 func main() {
+	config.Flags.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
 	if err := bootstrap.Run(); err != nil {
 		log.Fatal(err)
 	}
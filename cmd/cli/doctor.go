@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	recipesai "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/config"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/output"
+	statuscheck "github.com/rubenbupe/recipe-video-parser/internal/status/application/check"
+	"github.com/rubenbupe/recipe-video-parser/internal/status/platform/doctor"
+	"github.com/spf13/cobra"
+)
+
+// errChecksFailed es el error de doctor cuando alguna comprobación falla; el
+// detalle está en su salida.
+var errChecksFailed = errors.New("some checks failed")
+
+type doctorOutput struct {
+	OK            bool             `json:"ok"`
+	Configuration []doctor.Finding `json:"configuration"`
+	Components    []doctor.Finding `json:"components"`
+	Extraction    []doctor.Finding `json:"extraction,omitempty"`
+}
+
+// newDoctorCmd comprueba la configuración y los componentes de los que
+// depende la aplicación y, con --fixture, extrae la receta de un vídeo local
+// con el proveedor de IA. No es un comando de la aplicación: tiene que poder
+// ejecutarse con una configuración que no es válida.
+func newDoctorCmd() *cobra.Command {
+	var envFile, fixture string
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Validate the configuration and the components of a deployment",
+		Long: `Validate the configuration and the components of a deployment.
+
+Reports the errors of every section of the configuration, the unknown settings
+of the config file, the variables that the configuration does not read, the
+variables set but empty, which disables their default, and placeholder defaults
+such as AI_APIKEY=app. Then it runs the health checks: database schema,
+binaries and their versions, download directory, gallery-dl configuration and
+AI configuration.`,
+		GroupID: "operations",
+		Args:    usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if envFile != "" {
+				if err := doctor.LoadEnvFile(envFile); err != nil {
+					return fmt.Errorf("error loading %s: %w", envFile, err)
+				}
+			}
+
+			result := doctorOutput{Configuration: doctor.CheckEnv(config.Flags)}
+
+			// Los componentes no se pueden comprobar si la configuración no es válida
+			service, err := diContainer.Container.SafeGet("status.domain.check")
+			if err != nil {
+				result.Components = []doctor.Finding{{Severity: doctor.SeverityError, Subject: "components", Message: fmt.Sprintf("cannot be checked: %v", err)}}
+			} else {
+				result.Components = doctor.ComponentFindings(service.(statuscheck.StatusService).Check(cmd.Context()))
+			}
+
+			if fixture != "" && err == nil {
+				aiConfig := diContainer.Container.Get("shared.infrastructure.aiconfig").(*ai.Aiconfig)
+				prompts := diContainer.Container.Get("recipes.infrastructure.prompts").(*recipesai.PromptRegistry)
+				prompt, err := prompts.Latest(recipesai.ExtractRecipePromptID)
+				if err != nil {
+					return err
+				}
+				result.Extraction = []doctor.Finding{doctor.DryRunExtraction(cmd.Context(), fixture, prompt, *aiConfig)}
+			}
+
+			result.OK = !doctor.HasErrors(result.Configuration) && !doctor.HasErrors(result.Components) && !doctor.HasErrors(result.Extraction)
+			if err := write(result, output.FormatTable); err != nil {
+				return err
+			}
+			if !result.OK {
+				return errChecksFailed
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&envFile, "env-file", "", ".env file loaded before the checks")
+	cmd.Flags().StringVar(&fixture, "fixture", "", "local video to test an extraction with (consumes tokens)")
+	return cmd
+}
+
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "config",
+		Short:   "Inspect the configuration",
+		GroupID: "operations",
+	}
+
+	var format string
+	show := &cobra.Command{
+		Use:   "show",
+		Short: "Print the configuration without the secrets",
+		Long: `Print the configuration resolved from the config file, the environment
+variables and the flags, with the secrets redacted. The YAML output can be used
+as a config file.`,
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(config.Flags)
+			if cfg == nil {
+				return &configError{err: err}
+			}
+			if err := config.Write(os.Stdout, cfg, format); err != nil {
+				return &usageError{err: err}
+			}
+			if err != nil {
+				return &configError{err: err}
+			}
+			return nil
+		},
+	}
+	show.Flags().StringVar(&format, "format", config.FormatYAML, "format: yaml (config file) or env (environment variables)")
+	show.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{config.FormatYAML, config.FormatEnv}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	cmd.AddCommand(show)
+	return cmd
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	recipesai "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/i18n"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	"github.com/spf13/cobra"
+)
+
+// Códigos de salida por clase de error, documentados en el README.
+const (
+	exitOK = 0
+	// exitError es un error sin clasificar.
+	exitError = 1
+	// exitUsage es un comando, argumento u opción no válidos.
+	exitUsage = 2
+	// exitNotARecipe es el código de extract-recipe cuando el vídeo no es una
+	// receta, para distinguirlo de los errores.
+	exitNotARecipe = 3
+	exitNotFound   = 4
+	exitConflict   = 5
+	// exitInvalidInput son los datos rechazados por el dominio.
+	exitInvalidInput = 6
+	exitConfig       = 7
+	// exitExternal es un fallo de un servicio externo: la descarga o el
+	// proveedor de IA al extraer una receta, o el destino de un webhook.
+	exitExternal    = 8
+	exitInterrupted = 130
+)
+
+type errorClass struct {
+	name string
+	code int
+}
+
+var (
+	classError       = errorClass{name: "error", code: exitError}
+	classUsage       = errorClass{name: "usage", code: exitUsage}
+	classNotARecipe  = errorClass{name: "not_a_recipe", code: exitNotARecipe}
+	classNotFound    = errorClass{name: "not_found", code: exitNotFound}
+	classConflict    = errorClass{name: "conflict", code: exitConflict}
+	classInvalid     = errorClass{name: "invalid_input", code: exitInvalidInput}
+	classConfig      = errorClass{name: "config", code: exitConfig}
+	classExternal    = errorClass{name: "external", code: exitExternal}
+	classInterrupted = errorClass{name: "interrupted", code: exitInterrupted}
+)
+
+// domainErrors son los errores del dominio de cada clase.
+var domainErrors = []struct {
+	class errorClass
+	errs  []error
+}{
+	{classNotARecipe, []error{recipesdomain.ErrNotARecipe}},
+	{classNotFound, []error{
+		usersdomain.ErrUserNotFound,
+		webhooksdomain.ErrWebhookNotFound,
+		webhooksdomain.ErrDeliveryNotFound,
+		subscriptionsdomain.ErrSubscriptionNotFound,
+		organizationsdomain.ErrOrganizationNotFound,
+		organizationsdomain.ErrMemberNotFound,
+		recipesai.ErrPromptNotFound,
+	}},
+	{classConflict, []error{
+		usersdomain.ErrUserAlreadyExists,
+		webhooksdomain.ErrWebhookAlreadyExists,
+		subscriptionsdomain.ErrSubscriptionAlreadyExists,
+		subscriptionsdomain.ErrSubscriptionUserDisabled,
+		organizationsdomain.ErrOrganizationAlreadyExists,
+		organizationsdomain.ErrLastOwner,
+		recipesdomain.ErrExtractionAlreadyExists,
+	}},
+	{classInvalid, []error{
+		usersdomain.ErrInvalidUserID,
+		usersdomain.ErrInvalidUserName,
+		usersdomain.ErrEmptyUserName,
+		usersdomain.ErrInvalidUserLocale,
+		usersdomain.ErrInvalidUserRole,
+		usersdomain.ErrInvalidUserStatus,
+		usersdomain.ErrInvalidUserQuota,
+		webhooksdomain.ErrInvalidWebhookID,
+		webhooksdomain.ErrInvalidWebhookUserID,
+		webhooksdomain.ErrInvalidWebhookUrl,
+		webhooksdomain.ErrInvalidWebhookSecret,
+		webhooksdomain.ErrInvalidWebhookEventType,
+		webhooksdomain.ErrInvalidDeliveryID,
+		subscriptionsdomain.ErrInvalidSubscriptionID,
+		subscriptionsdomain.ErrInvalidSubscriptionUserID,
+		subscriptionsdomain.ErrInvalidSubscriptionUrl,
+		subscriptionsdomain.ErrInvalidSubscriptionInterval,
+		subscriptionsdomain.ErrInvalidSubscriptionMaxItems,
+		organizationsdomain.ErrInvalidOrganizationID,
+		organizationsdomain.ErrInvalidOrganizationName,
+		organizationsdomain.ErrInvalidMemberUserID,
+		organizationsdomain.ErrInvalidMemberRole,
+		organizationsdomain.ErrInvalidOrganizationQuota,
+		recipesdomain.ErrInvalidExtractionUserID,
+		recipesdomain.ErrInvalidExtractionOrganizationID,
+		recipesdomain.ErrInvalidModelPrice,
+		recipesdomain.ErrInvalidUsageGroup,
+		recipesdomain.ErrInvalidUsageSort,
+		recipesdomain.ErrInvalidUsagePeriod,
+		i18n.ErrUnsupportedLocale,
+	}},
+	{classExternal, []error{webhooksdomain.ErrDeliveryFailed}},
+}
+
+// usageError es un error en la forma de invocar un comando.
+type usageError struct {
+	err error
+}
+
+func usageErrorf(format string, a ...interface{}) error {
+	return &usageError{err: fmt.Errorf(format, a...)}
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+// usageArgs devuelve los errores de los argumentos como errores de uso.
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return &usageError{err: err}
+		}
+		return nil
+	}
+}
+
+// configError es una configuración que no es válida.
+type configError struct {
+	err error
+}
+
+func (e *configError) Error() string { return "invalid configuration:\n" + e.err.Error() }
+func (e *configError) Unwrap() error { return e.err }
+
+// errorOutput es un error impreso con --output json o yaml.
+type errorOutput struct {
+	Error string `json:"error"`
+	Class string `json:"class"`
+	Code  int    `json:"code"`
+}
+
+func classify(err error) errorClass {
+	var usageErr *usageError
+	var configErr *configError
+	var extractionErr *recipesdomain.ExtractionError
+	switch {
+	case errors.As(err, &usageErr):
+		return classUsage
+	case errors.As(err, &configErr):
+		return classConfig
+	case errors.Is(err, context.Canceled):
+		return classInterrupted
+	}
+
+	for _, domain := range domainErrors {
+		for _, target := range domain.errs {
+			if errors.Is(err, target) {
+				return domain.class
+			}
+		}
+	}
+
+	if errors.As(err, &extractionErr) {
+		return classExternal
+	}
+	return classError
+}
+
+// exitCodeOf devuelve el código de salida de la clase del error.
+func exitCodeOf(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	return classify(err).code
+}
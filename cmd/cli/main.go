@@ -2,7 +2,7 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 	"os/signal"
@@ -10,218 +10,268 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	extractionhandlers "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/cli/handler"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/config"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/di"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/logger"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/output"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server"
-	userhandlers "github.com/rubenbupe/recipe-video-parser/internal/users/platform/cli/handler"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var diContainer = di.Instance()
 
-func main() {
-	server.ConfigureCommandBus()
-	server.ConfigureQueryBus()
-	server.ConfigureEventBus()
+// tracer exporta los spans de la ejecución, agrupados bajo commandSpan.
+var (
+	tracer      *tracing.Provider
+	commandSpan trace.Span
+)
+
+// eventBusDrainTimeout es el tiempo máximo de espera a los eventos pendientes
+// antes de terminar.
+const eventBusDrainTimeout = 10 * time.Second
+
+// annotationApp marca los comandos que usan la aplicación: antes de
+// ejecutarlos se valida la configuración y se configuran el log, las trazas y
+// los buses.
+const annotationApp = "app"
 
-	ctx, cancel := context.WithCancel(context.Background())
+// globalOptions son las opciones comunes a todos los comandos.
+var globalOptions struct {
+	output string
+	quiet  bool
+	// format es el formato de --output, o vacío si no se ha indicado.
+	format output.Format
+}
+
+// started indica si se han configurado los buses y las trazas, que hay que
+// cerrar al terminar.
+var started bool
+
+func main() {
+	ctx, cancel := context.WithCancel(logger.WithRequestID(context.Background(), uuid.New().String()))
 	defer cancel()
 
-	// Cancelar el contexto si se recibe una señal de interrupción
+	// Cancelar el contexto si se recibe una señal de interrupción, para que el
+	// comando termine lo que está haciendo (p. ej. extract-batch guarda su
+	// estado), y salir sin esperar con la segunda
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		<-sigCh
-		fmt.Println("\nCancelando operación...")
+		fmt.Fprintln(os.Stderr, "\nCancelling... (interrupt again to exit now)")
 		cancel()
-		os.Exit(1)
+		<-sigCh
+		os.Exit(exitInterrupted)
 	}()
 
-	if len(os.Args) < 2 {
-		fmt.Println("Se requiere un comando: create-user, update-api-key, get-user, get-user-summary")
-		fmt.Println("Uso: cli <comando> [opciones]")
-		os.Exit(1)
-	}
-
-	switch os.Args[1] {
-	case "create-user":
-		createUserCmd(ctx, os.Args[2:])
-	case "update-api-key":
-		updateApiKeyCmd(ctx, os.Args[2:])
-	case "get-user":
-		getUserCmd(ctx, os.Args[2:])
-	case "get-user-summary":
-		getExtractionsSummaryCmd(ctx, os.Args[2:])
-	case "extract-recipe":
-		extractRecipeCmd(ctx, os.Args[2:])
-	default:
-		fmt.Printf("Comando desconocido: %s\n", os.Args[1])
-		os.Exit(1)
+	err := newRootCmd().ExecuteContext(ctx)
+	shutdown()
+	if err != nil {
+		printError(err)
 	}
+	os.Exit(exitCodeOf(err))
 }
 
-func createUserCmd(ctx context.Context, args []string) {
-	createHandler := diContainer.Container.Get("users.infrastructure.cli.create").(userhandlers.CreateUserHandler)
-
-	if len(args) < 1 {
-		fmt.Println("Uso: cli create-user <username>")
-		os.Exit(1)
-	}
-	userName := args[0]
-	userId := uuid.New().String()
-	apiKey := uuid.New().String()
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "cli",
+		Short: "Extract recipes from cooking videos and manage the users of the API",
+		Long: `Extract recipes from cooking videos and manage the users of the API.
 
-	err := createHandler(
-		ctx,
-		userhandlers.CreateUserInput{
-			ID:        userId,
-			Name:      userName,
-			ApiKey:    apiKey,
-			CreatedAt: time.Now().Format(time.RFC3339),
+Every setting of the configuration can be given as a flag before or after the
+command, named after its environment variable in lower case with dashes (e.g.
+--ai-model for AI_MODEL). Flags take precedence over the environment variables,
+and these over the --config file. See "cli config show".`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Args:          cobra.ArbitraryArgs,
+		// Sin comando se muestra la ayuda, pero se termina con error
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				cmd.SetOut(os.Stderr)
+				cmd.Help()
+				return usageErrorf("a command is required")
+			}
+			message := fmt.Sprintf("unknown command %q", args[0])
+			if suggestions := cmd.SuggestionsFor(args[0]); len(suggestions) > 0 {
+				message += fmt.Sprintf(" (did you mean %s?)", suggestions[0])
+			}
+			return usageErrorf("%s", message)
 		},
-	)
-	if err != nil {
-		fmt.Printf("Error al crear usuario: %v\n", err)
-		os.Exit(1)
+		PersistentPreRunE: setup,
 	}
-	fmt.Printf("Usuario creado: %s (%s)\n", userName, userId)
-	os.Exit(0)
-}
+	root.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &usageError{err: err}
+	})
 
-func updateApiKeyCmd(ctx context.Context, args []string) {
-	updateHandler := diContainer.Container.Get("users.infrastructure.cli.updateapikey").(userhandlers.UpdateApiKeyHandler)
+	// Las opciones de la configuración no se muestran en la ayuda, salvo
+	// --config, porque hay una por variable
+	configFlags := flag.NewFlagSet("config", flag.ContinueOnError)
+	config.Flags.RegisterFlags(configFlags)
+	root.PersistentFlags().AddGoFlagSet(configFlags)
+	root.PersistentFlags().VisitAll(func(f *pflag.Flag) {
+		f.Hidden = f.Name != "config"
+	})
+	root.MarkPersistentFlagFilename("config", "yaml", "yml", "toml")
 
-	if len(args) < 1 {
-		fmt.Println("Uso: cli update-api-key <username>")
-		os.Exit(1)
-	}
-	username := args[0]
-	apikey := uuid.New().String()
+	root.PersistentFlags().StringVarP(&globalOptions.output, "output", "o", "", "output format: "+output.FormatNames()+" (default: table, json for extract-recipe)")
+	root.PersistentFlags().BoolVarP(&globalOptions.quiet, "quiet", "q", false, "print nothing but the errors; check the exit code")
+	root.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		names := make([]string, 0, len(output.Formats))
+		for _, format := range output.Formats {
+			names = append(names, string(format))
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
 
-	err := updateHandler(
-		ctx,
-		userhandlers.UpdateApiKeyInput{
-			Name:   username,
-			ApiKey: apikey,
-		},
+	root.AddGroup(
+		&cobra.Group{ID: "users", Title: "Users:"},
+		&cobra.Group{ID: "recipes", Title: "Recipes:"},
+		&cobra.Group{ID: "webhooks", Title: "Webhooks:"},
+		&cobra.Group{ID: "subscriptions", Title: "Subscriptions:"},
+		&cobra.Group{ID: "organizations", Title: "Organizations:"},
+		&cobra.Group{ID: "usage", Title: "Usage:"},
+		&cobra.Group{ID: "operations", Title: "Operations:"},
 	)
-	if err != nil {
-		fmt.Printf("Error al actualizar apiKey: %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("apiKey actualizada para %s: %s\n", username, apikey)
-	os.Exit(0)
+	root.AddCommand(
+		newCreateUserCmd(),
+		newUpdateApiKeyCmd(),
+		newUpdateUserLocaleCmd(),
+		newGetUserCmd(),
+		newGetUserSummaryCmd(),
+		newListUsersCmd(),
+		newUpdateUserRoleCmd(),
+		newUpdateUserQuotaCmd(),
+		newDisableUserCmd(),
+		newEnableUserCmd(),
+		newDeleteUserCmd(),
+		newExportUserDataCmd(),
+		newExtractRecipeCmd(),
+		newExtractBatchCmd(),
+		newCreateWebhookCmd(),
+		newListWebhooksCmd(),
+		newReplayWebhookDeliveryCmd(),
+		newCreateSubscriptionCmd(),
+		newListSubscriptionsCmd(),
+		newGetSubscriptionCmd(),
+		newUpdateSubscriptionCmd(),
+		newDeleteSubscriptionCmd(),
+		newCheckSubscriptionsCmd(),
+		newCreateOrganizationCmd(),
+		newListOrganizationsCmd(),
+		newGetOrganizationCmd(),
+		newSetOrganizationMemberCmd(),
+		newRemoveOrganizationMemberCmd(),
+		newUpdateOrganizationQuotaCmd(),
+		newDeleteOrganizationCmd(),
+		newUsageReportCmd(),
+		newSetModelPriceCmd(),
+		newListModelPricesCmd(),
+		newDoctorCmd(),
+		newConfigCmd(),
+	)
+	return root
 }
 
-func getUserCmd(ctx context.Context, args []string) {
-	getHandler := diContainer.Container.Get("users.infrastructure.cli.get").(userhandlers.GetUserHandler)
-
-	if len(args) < 1 {
-		fmt.Println("Uso: cli get-user <username>")
-		os.Exit(1)
-	}
-	userName := args[0]
-
-	result, err := getHandler(
-		ctx,
-		userhandlers.GetUserInput{
-			Name: userName,
-		},
-	)
-	if err != nil {
-		fmt.Printf("Error al obtener usuario: %v\n", err)
-		os.Exit(1)
+// appCommand marca el comando como uno que usa la aplicación (ver
+// annotationApp).
+func appCommand(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
 	}
-	fmt.Printf("Usuario: %s\nNombre: %s\nApiKey: %s\nCreado: %s\n", result.ID, result.Name, result.ApiKey, result.CreatedAt)
-	os.Exit(0)
+	cmd.Annotations[annotationApp] = "true"
+	return cmd
 }
 
-func getExtractionsSummaryCmd(ctx context.Context, args []string) {
-	userGetHandler := diContainer.Container.Get("users.infrastructure.cli.get").(userhandlers.GetUserHandler)
-	extractionHandler := diContainer.Container.Get("recipes.infrastructure.cli.get").(extractionhandlers.GetExtractionHandler)
+// setup valida las opciones globales y, para los comandos de la aplicación,
+// la configuración, y configura el log, las trazas y los buses.
+func setup(cmd *cobra.Command, args []string) error {
+	if globalOptions.output != "" {
+		format, err := output.ParseFormat(globalOptions.output)
+		if err != nil {
+			return &usageError{err: err}
+		}
+		globalOptions.format = format
+	}
 
-	if len(args) < 1 {
-		fmt.Println("Uso: cli get-user-summary <user_name>")
-		os.Exit(1)
+	if cmd.Annotations[annotationApp] != "true" {
+		return nil
 	}
-	userName := args[0]
 
-	// Buscar usuario por nombre
-	userResult, err := userGetHandler(ctx, userhandlers.GetUserInput{Name: userName})
-	if err != nil {
-		fmt.Printf("Error al buscar usuario '%s': %v\n", userName, err)
-		os.Exit(1)
+	if _, err := config.Load(config.Flags); err != nil {
+		return &configError{err: err}
 	}
-	userId := userResult.ID
 
-	result, err := extractionHandler(ctx, extractionhandlers.GetExtractionInput{UserID: userId})
+	if _, err := diContainer.Container.SafeGet("shared.infrastructure.logger"); err != nil {
+		return &configError{err: fmt.Errorf("error configuring the logger: %v", err)}
+	}
+	provider, err := diContainer.Container.SafeGet("shared.infrastructure.tracing")
 	if err != nil {
-		fmt.Printf("Error al obtener extracciones: %v\n", err)
-		os.Exit(1)
+		return &configError{err: fmt.Errorf("error configuring the tracing: %v", err)}
 	}
+	tracer = provider.(*tracing.Provider)
 
-	type summary struct {
-		Month                string
-		Count                int
-		PromptTokenCount     int
-		CandidatesTokenCount int
-		TotalTokenCount      int
-	}
-	summaries := make(map[string]*summary)
+	server.ConfigureCommandBus()
+	server.ConfigureQueryBus()
+	server.ConfigureEventBus()
+	started = true
 
-	for _, extraction := range result {
-		createdAt, err := time.Parse(time.RFC3339, extraction.CreatedAt)
-		if err != nil {
-			continue // ignora fechas mal formateadas
-		}
-		monthYear := createdAt.Format("01-2006")
-
-		promptTokens := 0
-		candidateTokens := 0
-		if extraction.Metadata != "" {
-			var meta struct {
-				PromptTokenCount     int `json:"promptTokenCount"`
-				CandidatesTokenCount int `json:"candidatesTokenCount"`
-			}
-			if err := json.Unmarshal([]byte(extraction.Metadata), &meta); err == nil {
-				promptTokens = meta.PromptTokenCount
-				candidateTokens = meta.CandidatesTokenCount
-			}
-		}
+	ctx, span := tracing.Start(cmd.Context(), "cli "+cmd.Name())
+	commandSpan = span
+	cmd.SetContext(ctx)
+	return nil
+}
 
-		sum, ok := summaries[monthYear]
-		if !ok {
-			sum = &summary{Month: monthYear}
-			summaries[monthYear] = sum
-		}
-		sum.Count++
-		sum.PromptTokenCount += promptTokens
-		sum.CandidatesTokenCount += candidateTokens
-		sum.TotalTokenCount += promptTokens + candidateTokens
+// shutdown espera a que se entreguen los eventos pendientes y exporta las
+// trazas.
+func shutdown() {
+	if !started {
+		return
 	}
+	ctx, cancel := context.WithTimeout(context.Background(), eventBusDrainTimeout)
+	defer cancel()
 
-	fmt.Printf("Resumen de extracciones para el usuario '%s' (ID: %s) por mes-año:\n", userName, userId)
-	fmt.Printf("%-10s | %-12s | %-15s | %-18s | %-15s\n", "Mes-Año", "Extracciones", "PromptTokens", "CandidateTokens", "TotalTokens")
-	fmt.Println("------------------------------------------------------------------------------------------")
-	for _, sum := range summaries {
-		fmt.Printf("%-10s | %-12d | %-15d | %-18d | %-15d\n",
-			sum.Month, sum.Count, sum.PromptTokenCount, sum.CandidatesTokenCount, sum.TotalTokenCount)
+	if err := server.ShutdownEventBus(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error processing the pending events: %v\n", err)
+	}
+	if commandSpan != nil {
+		commandSpan.End()
+	}
+	if err := tracer.Shutdown(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error exporting the traces: %v\n", err)
 	}
-	os.Exit(0)
 }
 
-func extractRecipeCmd(ctx context.Context, args []string) {
-	extractHandler := diContainer.Container.Get("recipes.infrastructure.cli.extract").(extractionhandlers.ExtractRecipeHandler)
-
-	if len(args) < 1 {
-		fmt.Println("Uso: cli extract-recipe <url>")
-		os.Exit(1)
+// write imprime el resultado de un comando en el formato de --output o, si no
+// se ha indicado, en el formato por defecto del comando.
+func write(v interface{}, defaultFormat output.Format) error {
+	if globalOptions.quiet {
+		return nil
+	}
+	format := globalOptions.format
+	if format == "" {
+		format = defaultFormat
 	}
-	url := args[0]
+	return output.Write(os.Stdout, format, v)
+}
 
-	err := extractHandler(ctx, extractionhandlers.ExtractRecipeInput{Url: url})
-	if err != nil {
-		fmt.Printf("Error al extraer receta: %v\n", err)
-		os.Exit(1)
+// printError imprime el error en la salida de error: como un objeto con su
+// clase y su código de salida si se ha pedido una salida para scripts.
+func printError(err error) {
+	class := classify(err)
+	if globalOptions.format.Machine() {
+		output.Write(os.Stderr, globalOptions.format, errorOutput{
+			Error: err.Error(),
+			Class: class.name,
+			Code:  class.code,
+		})
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	if class.code == exitUsage {
+		fmt.Fprintln(os.Stderr, `Run "cli --help" for usage.`)
 	}
-	os.Exit(0)
 }
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	organizationhandlers "github.com/rubenbupe/recipe-video-parser/internal/organizations/platform/cli/handler"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/output"
+	userhandlers "github.com/rubenbupe/recipe-video-parser/internal/users/platform/cli/handler"
+	"github.com/spf13/cobra"
+)
+
+type organizationOutput struct {
+	ID     string `json:"id,omitempty"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+type memberOutput struct {
+	Organization string `json:"organization"`
+	User         string `json:"user"`
+	Role         string `json:"role,omitempty"`
+	Status       string `json:"status"`
+}
+
+func newCreateOrganizationCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:   "create-organization <name> <owner>",
+		Short: "Create an organization with a shared recipe library",
+		Long: "Create an organization, such as a household or a team, with the user as its owner. The name can have " +
+			"lowercase letters, digits and dashes. The members select it with the X-Organization header of the HTTP API.",
+		Example: "  cli create-organization casa ana",
+		GroupID: "organizations",
+		Args:    usageArgs(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userGetHandler := diContainer.Container.Get("users.infrastructure.cli.get").(userhandlers.GetUserHandler)
+			createHandler := diContainer.Container.Get("organizations.infrastructure.cli.create").(organizationhandlers.CreateOrganizationHandler)
+
+			owner, err := userGetHandler(cmd.Context(), userhandlers.GetUserInput{Name: args[1]})
+			if err != nil {
+				return err
+			}
+
+			id := uuid.New().String()
+			err = createHandler(cmd.Context(), organizationhandlers.CreateOrganizationInput{
+				ID:        id,
+				Name:      args[0],
+				OwnerID:   owner.ID,
+				CreatedAt: time.Now().UTC().Format(time.RFC3339),
+			})
+			if err != nil {
+				return err
+			}
+			return write(organizationOutput{ID: id, Name: args[0], Status: "created"}, output.FormatTable)
+		},
+	})
+}
+
+func newListOrganizationsCmd() *cobra.Command {
+	var username string
+	cmd := appCommand(&cobra.Command{
+		Use:     "list-organizations",
+		Short:   "List the organizations",
+		Long:    "List the organizations sorted by name, or the organizations of a user with their role in each one.",
+		Example: "  cli list-organizations --user ana",
+		GroupID: "organizations",
+		Args:    usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			listHandler := diContainer.Container.Get("organizations.infrastructure.cli.list").(organizationhandlers.GetOrganizationsHandler)
+
+			var input organizationhandlers.GetOrganizationsInput
+			if username != "" {
+				userGetHandler := diContainer.Container.Get("users.infrastructure.cli.get").(userhandlers.GetUserHandler)
+				user, err := userGetHandler(cmd.Context(), userhandlers.GetUserInput{Name: username})
+				if err != nil {
+					return err
+				}
+				input.UserID = user.ID
+			}
+
+			organizations, err := listHandler(cmd.Context(), input)
+			if err != nil {
+				return err
+			}
+			return write(organizations, output.FormatTable)
+		},
+	})
+	cmd.Flags().StringVar(&username, "user", "", "list only the organizations of this user")
+	return cmd
+}
+
+func newGetOrganizationCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:     "get-organization <name>",
+		Short:   "Show an organization and its members",
+		GroupID: "organizations",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			getHandler := diContainer.Container.Get("organizations.infrastructure.cli.get").(organizationhandlers.GetOrganizationHandler)
+
+			organization, err := getHandler(cmd.Context(), organizationhandlers.GetOrganizationInput{Name: args[0]})
+			if err != nil {
+				return err
+			}
+			return write(organization, output.FormatTable)
+		},
+	})
+}
+
+func newSetOrganizationMemberCmd() *cobra.Command {
+	var role string
+	cmd := appCommand(&cobra.Command{
+		Use:   "set-organization-member <name> <username>",
+		Short: "Add a user to an organization or change their role",
+		Long: "Add a user to an organization or change the role of a member. Owners manage the members, editors " +
+			"extract recipes into the library and viewers only read it. The last owner can not stop being an owner.\n\n" +
+			"Roles: " + strings.Join(organizationsdomain.MemberRoles, ", ") + ".",
+		Example: "  cli set-organization-member casa luis --role editor",
+		GroupID: "organizations",
+		Args:    usageArgs(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userGetHandler := diContainer.Container.Get("users.infrastructure.cli.get").(userhandlers.GetUserHandler)
+			setHandler := diContainer.Container.Get("organizations.infrastructure.cli.setmember").(organizationhandlers.SetMemberHandler)
+
+			user, err := userGetHandler(cmd.Context(), userhandlers.GetUserInput{Name: args[1]})
+			if err != nil {
+				return err
+			}
+
+			err = setHandler(cmd.Context(), organizationhandlers.SetMemberInput{
+				OrganizationName: args[0],
+				UserID:           user.ID,
+				Role:             role,
+				CreatedAt:        time.Now().UTC().Format(time.RFC3339),
+			})
+			if err != nil {
+				return err
+			}
+			return write(memberOutput{Organization: args[0], User: args[1], Role: role, Status: "updated"}, output.FormatTable)
+		},
+	})
+	cmd.Flags().StringVar(&role, "role", organizationsdomain.MemberRoleEditor, "role of the member ("+strings.Join(organizationsdomain.MemberRoles, ", ")+")")
+	_ = cmd.RegisterFlagCompletionFunc("role", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return organizationsdomain.MemberRoles, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+func newRemoveOrganizationMemberCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:   "remove-organization-member <name> <username>",
+		Short: "Remove a user from an organization",
+		Long: "Remove a user from an organization. The extractions they shared stay in the library of the " +
+			"organization. The last owner can not be removed.",
+		GroupID: "organizations",
+		Args:    usageArgs(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userGetHandler := diContainer.Container.Get("users.infrastructure.cli.get").(userhandlers.GetUserHandler)
+			removeHandler := diContainer.Container.Get("organizations.infrastructure.cli.removemember").(organizationhandlers.RemoveMemberHandler)
+
+			user, err := userGetHandler(cmd.Context(), userhandlers.GetUserInput{Name: args[1]})
+			if err != nil {
+				return err
+			}
+
+			err = removeHandler(cmd.Context(), organizationhandlers.RemoveMemberInput{OrganizationName: args[0], UserID: user.ID})
+			if err != nil {
+				return err
+			}
+			return write(memberOutput{Organization: args[0], User: args[1], Status: "removed"}, output.FormatTable)
+		},
+	})
+}
+
+func newUpdateOrganizationQuotaCmd() *cobra.Command {
+	var result quotaOutput
+	cmd := appCommand(&cobra.Command{
+		Use:   "update-organization-quota <name>",
+		Short: "Set the monthly extractions and tokens of an organization",
+		Long: "Set the extractions and tokens the members of an organization can use together every calendar month " +
+			"through the HTTP API. It applies instead of the quota of the user when the organization is active. " +
+			"Both limits are replaced; an omitted or 0 limit means no limit.",
+		Example: "  cli update-organization-quota casa --extractions 300",
+		GroupID: "organizations",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			updateHandler := diContainer.Container.Get("organizations.infrastructure.cli.updatequota").(organizationhandlers.UpdateQuotaHandler)
+
+			result.Name = args[0]
+			err := updateHandler(cmd.Context(), organizationhandlers.UpdateQuotaInput{
+				Name:               result.Name,
+				MonthlyExtractions: result.MonthlyExtractions,
+				MonthlyTokens:      result.MonthlyTokens,
+			})
+			if err != nil {
+				return err
+			}
+			return write(result, output.FormatTable)
+		},
+	})
+	cmd.Flags().IntVar(&result.MonthlyExtractions, "extractions", 0, "extractions per month (0: no limit)")
+	cmd.Flags().IntVar(&result.MonthlyTokens, "tokens", 0, "tokens per month (0: no limit)")
+	return cmd
+}
+
+func newDeleteOrganizationCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:   "delete-organization <name>",
+		Short: "Delete an organization",
+		Long: "Delete an organization and its memberships. The extractions of its library are kept and belong " +
+			"again only to the users that made them.",
+		GroupID: "organizations",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			deleteHandler := diContainer.Container.Get("organizations.infrastructure.cli.delete").(organizationhandlers.DeleteOrganizationHandler)
+
+			if err := deleteHandler(cmd.Context(), organizationhandlers.DeleteOrganizationInput{Name: args[0]}); err != nil {
+				return err
+			}
+			return write(organizationOutput{Name: args[0], Status: "deleted"}, output.FormatTable)
+		},
+	})
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	recipesai "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/batch"
+	extractionhandlers "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/cli/handler"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/i18n"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/output"
+	userhandlers "github.com/rubenbupe/recipe-video-parser/internal/users/platform/cli/handler"
+	"github.com/spf13/cobra"
+)
+
+// recipeOutput es la receta extraída. En JSON y YAML tiene los mismos campos
+// que la respuesta de la API.
+type recipeOutput struct {
+	recipesai.Recipe
+}
+
+// Tables implements the output.Tabler interface.
+func (r recipeOutput) Tables() []output.Table {
+	recipe := r.Recipe
+	tables := []output.Table{{
+		Headers: []string{"field", "value"},
+		Rows: [][]string{
+			{"title", recipe.Title},
+			{"description", recipe.Description},
+			{"servings", strconv.Itoa(recipe.Servings)},
+			{"prep_time", fmt.Sprintf("%d min", recipe.PrepTime)},
+			{"cook_time", fmt.Sprintf("%d min", recipe.CookTime)},
+			{"total_time", fmt.Sprintf("%d min", recipe.TotalTime)},
+			{"difficulty", strconv.Itoa(recipe.Difficulty)},
+			{"notes", recipe.Notes},
+			{"url", recipe.Url},
+		},
+	}}
+
+	ingredients := output.Table{Title: "ingredients", Headers: []string{"name", "quantity", "unit", "optional"}}
+	for _, ingredient := range recipe.Ingredients {
+		ingredients.Rows = append(ingredients.Rows, []string{ingredient.Name, ingredient.Quantity, ingredient.Unit, strconv.FormatBool(ingredient.Optional)})
+	}
+
+	steps := output.Table{Title: "steps", Headers: []string{"section", "step", "text", "optional"}}
+	for i, section := range recipe.Sections {
+		for j, instruction := range section.Instructions {
+			steps.Rows = append(steps.Rows, []string{strconv.Itoa(i + 1), strconv.Itoa(j + 1), instruction.Text, strconv.FormatBool(instruction.Optional)})
+		}
+	}
+
+	info := recipe.NutritionalInfo
+	nutrition := output.Table{
+		Title:   "nutritional_info (per 100g)",
+		Headers: []string{"nutrient", "amount"},
+		Rows: [][]string{
+			{"calories", fmt.Sprintf("%g kcal", info.Calories)},
+			{"protein", fmt.Sprintf("%g g", info.Protein)},
+			{"carbohydrates", fmt.Sprintf("%g g", info.Carbohydrates)},
+			{"fats", fmt.Sprintf("%g g", info.Fats)},
+			{"fiber", fmt.Sprintf("%g g", info.Fiber)},
+			{"sugar", fmt.Sprintf("%g g", info.Sugar)},
+		},
+	}
+
+	return append(tables, ingredients, steps, nutrition)
+}
+
+func newExtractRecipeCmd() *cobra.Command {
+	var lang string
+	cmd := appCommand(&cobra.Command{
+		Use:   "extract-recipe <url>",
+		Short: "Extract the recipe of a video",
+		Long: "Extract the recipe of a video from YouTube, TikTok, Instagram and other platforms, " +
+			"without storing it. The recipe is printed as JSON unless --output says otherwise.",
+		Example: "  cli extract-recipe https://www.youtube.com/watch?v=... --lang es-ES\n" +
+			"  cli extract-recipe https://www.tiktok.com/@user/video/... -o markdown",
+		GroupID: "recipes",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			extractHandler := diContainer.Container.Get("recipes.infrastructure.cli.extract").(extractionhandlers.ExtractRecipeHandler)
+
+			recipe, err := extractHandler(cmd.Context(), extractionhandlers.ExtractRecipeInput{Url: args[0], Lang: lang})
+			if err != nil {
+				return extractionError(err)
+			}
+			return write(recipeOutput{Recipe: *recipe}, output.FormatJSON)
+		},
+	})
+	cmd.Flags().StringVar(&lang, "lang", "", "language of the recipe ("+strings.Join(i18n.SupportedLocales(), ", ")+")")
+	cmd.RegisterFlagCompletionFunc("lang", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return i18n.SupportedLocales(), cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+// extractionError clasifica los errores de la extracción que no son de los
+// datos de entrada ni un vídeo que no es una receta, para que terminen con
+// el código de los fallos externos.
+func extractionError(err error) error {
+	var extractionErr *recipesdomain.ExtractionError
+	if errors.Is(err, recipesdomain.ErrNotARecipe) || errors.Is(err, i18n.ErrUnsupportedLocale) || errors.As(err, &extractionErr) {
+		return err
+	}
+	return recipesdomain.NewExtractionError(recipesdomain.ExtractionErrorCategoryOf(err), err)
+}
+
+// batchOptions son las opciones de extract-batch.
+type batchOptions struct {
+	user        string
+	lang        string
+	csv         bool
+	state       string
+	report      string
+	concurrency int
+}
+
+func newExtractBatchCmd() *cobra.Command {
+	var opts batchOptions
+	cmd := appCommand(&cobra.Command{
+		Use:   "extract-batch [file]",
+		Short: "Extract the recipes of a list of videos",
+		Long: `Extract the recipes of a list of videos and store them under a user.
+
+The list is read from the file, or from the standard input when it is "-" or
+not given: one URL per line (blank lines and lines starting with # are
+ignored) or, with --csv or a .csv file, a CSV with a header and a url column.
+The other columns are copied to the report.
+
+URLs repeated in the list or already extracted by the user are skipped, except
+the failed ones. The report has a JSON object per URL with its status
+(succeeded, not_a_recipe, failed or skipped), recipe ID, tokens and error, and
+is written to the standard output unless --report is given; then the totals
+are printed.
+
+With --state the results are also saved to a file, so that a batch that was
+interrupted can be resumed running the same command again: the URLs in the
+state file are not extracted again, except the failed ones.`,
+		Example: "  cli extract-batch urls.txt --user ana --state urls.state --report report.jsonl\n" +
+			"  cat videos.csv | cli extract-batch --csv --user ana --concurrency 8",
+		GroupID: "recipes",
+		Args:    usageArgs(cobra.MaximumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.user == "" {
+				return usageErrorf("the --user flag is required")
+			}
+			file := "-"
+			if len(args) == 1 {
+				file = args[0]
+			}
+			return runExtractBatch(cmd, file, opts)
+		},
+	})
+	cmd.Flags().StringVar(&opts.user, "user", "", "user the extractions are stored under")
+	cmd.Flags().StringVar(&opts.lang, "lang", "", "language of the recipes (default: the locale of the user)")
+	cmd.Flags().BoolVar(&opts.csv, "csv", false, "read the list as CSV (default for .csv files)")
+	cmd.Flags().StringVar(&opts.state, "state", "", "state file to resume the batch from")
+	cmd.Flags().StringVar(&opts.report, "report", "", "JSONL report file (default: standard output)")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 0, "extractions at the same time (default: BATCH_CONCURRENCY)")
+	cmd.MarkFlagFilename("state")
+	cmd.MarkFlagFilename("report", "jsonl")
+	cmd.RegisterFlagCompletionFunc("lang", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return i18n.SupportedLocales(), cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+func runExtractBatch(cmd *cobra.Command, file string, opts batchOptions) error {
+	userGetHandler := diContainer.Container.Get("users.infrastructure.cli.get").(userhandlers.GetUserHandler)
+	runner := diContainer.Container.Get("recipes.infrastructure.batch").(batch.Runner)
+	config := diContainer.Container.Get("recipes.infrastructure.batchconfig").(*batch.Config)
+
+	user, err := userGetHandler(cmd.Context(), userhandlers.GetUserInput{Name: opts.user})
+	if err != nil {
+		return err
+	}
+	locale, err := i18n.ResolveLocale(opts.lang, user.Locale)
+	if err != nil {
+		return err
+	}
+
+	var input io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return &usageError{err: err}
+		}
+		defer f.Close()
+		input = f
+	}
+	items, err := batch.Read(input, opts.csv || strings.HasSuffix(strings.ToLower(file), ".csv"))
+	if err != nil {
+		return &usageError{err: err}
+	}
+
+	runOptions := batch.Options{UserID: user.ID, Locale: locale, Concurrency: config.Concurrency}
+	if opts.concurrency > 0 {
+		runOptions.Concurrency = opts.concurrency
+	}
+	if opts.state != "" {
+		state, err := batch.OpenState(opts.state)
+		if err != nil {
+			return fmt.Errorf("error opening the state file: %w", err)
+		}
+		defer state.Close()
+		runOptions.State = state
+	}
+
+	// Con el informe en la salida estándar no se imprimen los totales
+	var report io.Writer = os.Stdout
+	if globalOptions.quiet {
+		report = io.Discard
+	}
+	if opts.report != "" {
+		f, err := os.Create(opts.report)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		report = f
+	}
+
+	summary, err := runner.Run(cmd.Context(), items, runOptions, batch.WriteJSONL(report))
+	if err != nil {
+		return err
+	}
+	if opts.report != "" {
+		if err := write(summary, output.FormatTable); err != nil {
+			return err
+		}
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d extractions failed", summary.Failed, summary.Total)
+	}
+	return nil
+}
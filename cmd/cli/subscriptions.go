@@ -0,0 +1,168 @@
+package main
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/output"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	subscriptionhandlers "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/cli/handler"
+	userhandlers "github.com/rubenbupe/recipe-video-parser/internal/users/platform/cli/handler"
+	"github.com/spf13/cobra"
+)
+
+type subscriptionOutput struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func newCreateSubscriptionCmd() *cobra.Command {
+	var interval time.Duration
+	var maxItems int
+	cmd := appCommand(&cobra.Command{
+		Use:   "create-subscription <username> <url>",
+		Short: "Watch a profile, channel or playlist for new recipes",
+		Long: "Watch a TikTok or Instagram profile, or a YouTube channel or playlist. The API checks it " +
+			"every interval and extracts the recipes of its new videos under the user.",
+		Example: "  cli create-subscription ana https://www.youtube.com/@chef --interval 12h --max-items 5",
+		GroupID: "subscriptions",
+		Args:    usageArgs(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userGetHandler := diContainer.Container.Get("users.infrastructure.cli.get").(userhandlers.GetUserHandler)
+			createHandler := diContainer.Container.Get("subscriptions.infrastructure.cli.create").(subscriptionhandlers.CreateSubscriptionHandler)
+
+			user, err := userGetHandler(cmd.Context(), userhandlers.GetUserInput{Name: args[0]})
+			if err != nil {
+				return err
+			}
+
+			id := uuid.New().String()
+			err = createHandler(cmd.Context(), subscriptionhandlers.CreateSubscriptionInput{
+				ID:        id,
+				UserID:    user.ID,
+				Url:       args[1],
+				Interval:  interval,
+				MaxItems:  maxItems,
+				CreatedAt: time.Now().UTC().Format(time.RFC3339),
+			})
+			if err != nil {
+				return err
+			}
+			return write(subscriptionOutput{ID: id, Status: "created"}, output.FormatTable)
+		},
+	})
+	cmd.Flags().DurationVar(&interval, "interval", subscriptionsdomain.DefaultSubscriptionInterval, "wait between two checks (at least "+subscriptionsdomain.MinSubscriptionInterval.String()+")")
+	cmd.Flags().IntVar(&maxItems, "max-items", subscriptionsdomain.DefaultSubscriptionMaxItems, "most recent videos listed on every check")
+	return cmd
+}
+
+func newListSubscriptionsCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:     "list-subscriptions <username>",
+		Short:   "List the subscriptions of a user",
+		GroupID: "subscriptions",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userGetHandler := diContainer.Container.Get("users.infrastructure.cli.get").(userhandlers.GetUserHandler)
+			listHandler := diContainer.Container.Get("subscriptions.infrastructure.cli.list").(subscriptionhandlers.GetSubscriptionsHandler)
+
+			user, err := userGetHandler(cmd.Context(), userhandlers.GetUserInput{Name: args[0]})
+			if err != nil {
+				return err
+			}
+
+			subscriptions, err := listHandler(cmd.Context(), subscriptionhandlers.GetSubscriptionsInput{UserID: user.ID})
+			if err != nil {
+				return err
+			}
+			return write(subscriptions, output.FormatTable)
+		},
+	})
+}
+
+func newGetSubscriptionCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:     "get-subscription <subscription_id>",
+		Short:   "Show a subscription and the videos extracted by it",
+		GroupID: "subscriptions",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			getHandler := diContainer.Container.Get("subscriptions.infrastructure.cli.get").(subscriptionhandlers.GetSubscriptionHandler)
+
+			subscription, err := getHandler(cmd.Context(), subscriptionhandlers.GetSubscriptionInput{ID: args[0]})
+			if err != nil {
+				return err
+			}
+			return write(subscription, output.FormatTable)
+		},
+	})
+}
+
+func newUpdateSubscriptionCmd() *cobra.Command {
+	var interval time.Duration
+	var maxItems int
+	cmd := appCommand(&cobra.Command{
+		Use:     "update-subscription <subscription_id>",
+		Short:   "Change the interval or the number of videos of a subscription",
+		Example: "  cli update-subscription 0b6f6a53-2a9e-4f7c-9d54-3b1c2f7a9e10 --interval 1h",
+		GroupID: "subscriptions",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval == 0 && maxItems == 0 {
+				return usageErrorf("set --interval or --max-items")
+			}
+			updateHandler := diContainer.Container.Get("subscriptions.infrastructure.cli.update").(subscriptionhandlers.UpdateSubscriptionHandler)
+
+			err := updateHandler(cmd.Context(), subscriptionhandlers.UpdateSubscriptionInput{
+				ID:       args[0],
+				Interval: interval,
+				MaxItems: maxItems,
+			})
+			if err != nil {
+				return err
+			}
+			return write(subscriptionOutput{ID: args[0], Status: "updated"}, output.FormatTable)
+		},
+	})
+	cmd.Flags().DurationVar(&interval, "interval", 0, "wait between two checks")
+	cmd.Flags().IntVar(&maxItems, "max-items", 0, "most recent videos listed on every check")
+	return cmd
+}
+
+func newDeleteSubscriptionCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:     "delete-subscription <subscription_id>",
+		Short:   "Stop watching a profile, channel or playlist",
+		Long:    "Delete a subscription. The recipes it extracted are kept.",
+		GroupID: "subscriptions",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			deleteHandler := diContainer.Container.Get("subscriptions.infrastructure.cli.delete").(subscriptionhandlers.DeleteSubscriptionHandler)
+
+			if err := deleteHandler(cmd.Context(), subscriptionhandlers.DeleteSubscriptionInput{ID: args[0]}); err != nil {
+				return err
+			}
+			return write(subscriptionOutput{ID: args[0], Status: "deleted"}, output.FormatTable)
+		},
+	})
+}
+
+func newCheckSubscriptionsCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:   "check-subscriptions [subscription_id]",
+		Short: "Extract the new videos of the subscriptions now",
+		Long: "Check a subscription now, whether its interval has passed or not, or every subscription " +
+			"whose interval has passed when no ID is given, as the scheduler of the API does.",
+		GroupID: "subscriptions",
+		Args:    usageArgs(cobra.MaximumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			checkHandler := diContainer.Container.Get("subscriptions.infrastructure.cli.check").(subscriptionhandlers.CheckSubscriptionsHandler)
+
+			var id string
+			if len(args) > 0 {
+				id = args[0]
+			}
+			return checkHandler(cmd.Context(), subscriptionhandlers.CheckSubscriptionsInput{ID: id})
+		},
+	})
+}
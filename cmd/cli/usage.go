@@ -0,0 +1,115 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	extractionhandlers "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/cli/handler"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/output"
+	userhandlers "github.com/rubenbupe/recipe-video-parser/internal/users/platform/cli/handler"
+	"github.com/spf13/cobra"
+)
+
+func newUsageReportCmd() *cobra.Command {
+	var userName string
+	var input extractionhandlers.UsageReportInput
+	groups := make([]string, 0, len(recipesdomain.UsageGroups))
+	for _, group := range recipesdomain.UsageGroups {
+		groups = append(groups, string(group))
+	}
+	sorts := make([]string, 0, len(recipesdomain.UsageSorts))
+	for _, sort := range recipesdomain.UsageSorts {
+		sorts = append(sorts, string(sort))
+	}
+
+	cmd := appCommand(&cobra.Command{
+		Use:   "usage-report",
+		Short: "Report the extractions, tokens and cost by user, period, model, platform, prompt or error",
+		Long: "Report the extractions, the tokens and their cost in US dollars, aggregated by user, day, week " +
+			"(from Monday), month, provider/model, platform, prompt (id@version) or error category. The cost uses " +
+			"the price of each model on the day of each extraction (see set-model-price); the extractions of " +
+			"models without a price are counted as " +
+			"unpriced, and those made before the model was recorded are grouped as \"" + recipesdomain.UnknownModel + "\".\n\n" +
+			"Use --output csv or json to export the report.",
+		Example: "  cli usage-report --by model --from 2024-05-01 --to 2024-05-31\n" +
+			"  cli usage-report --user ana --by week --output csv > ana.csv",
+		GroupID: "usage",
+		Args:    usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			usageHandler := diContainer.Container.Get("recipes.infrastructure.cli.usage").(extractionhandlers.UsageReportHandler)
+
+			if userName != "" {
+				getHandler := diContainer.Container.Get("users.infrastructure.cli.get").(userhandlers.GetUserHandler)
+				user, err := getHandler(cmd.Context(), userhandlers.GetUserInput{Name: userName})
+				if err != nil {
+					return err
+				}
+				input.UserID = user.ID
+			}
+
+			report, err := usageHandler(cmd.Context(), input)
+			if err != nil {
+				return err
+			}
+			return write(report, output.FormatTable)
+		},
+	})
+	cmd.Flags().StringVar(&userName, "user", "", "report only the extractions of the user")
+	cmd.Flags().StringVar(&input.By, "by", string(recipesdomain.UsageByMonth), "group by "+strings.Join(groups, "|"))
+	cmd.Flags().StringVar(&input.Sort, "sort", string(recipesdomain.UsageSortKey), "sort by "+strings.Join(sorts, "|")+" (all but key from the highest)")
+	cmd.Flags().StringVar(&input.From, "from", "", "first day of the extractions (2006-01-02)")
+	cmd.Flags().StringVar(&input.To, "to", "", "last day of the extractions (2006-01-02)")
+	cmd.RegisterFlagCompletionFunc("by", cobra.FixedCompletions(groups, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(sorts, cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+func newSetModelPriceCmd() *cobra.Command {
+	var input extractionhandlers.SetModelPriceInput
+	cmd := appCommand(&cobra.Command{
+		Use:   "set-model-price <provider> <model>",
+		Short: "Set the price of the tokens of a model",
+		Long: "Set the price in US dollars per million input (prompt) and output (candidates) tokens of a model, " +
+			"from a day on. The extractions made before that day keep the previous price of the model, and a " +
+			"price set again for the same day replaces it.",
+		Example: "  cli set-model-price google gemini-2.0-flash --input-price 0.10 --output-price 0.40 --from 2025-02-01",
+		GroupID: "usage",
+		Args:    usageArgs(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			setHandler := diContainer.Container.Get("recipes.infrastructure.cli.setprice").(extractionhandlers.SetModelPriceHandler)
+
+			input.Provider = args[0]
+			input.Model = args[1]
+			if err := setHandler(cmd.Context(), input); err != nil {
+				return err
+			}
+			return write(extractionhandlers.ModelPriceOutput(input), output.FormatTable)
+		},
+	})
+	cmd.Flags().Float64Var(&input.InputPrice, "input-price", 0, "US dollars per million input tokens")
+	cmd.Flags().Float64Var(&input.OutputPrice, "output-price", 0, "US dollars per million output tokens")
+	cmd.Flags().StringVar(&input.EffectiveFrom, "from", time.Now().Format(time.DateOnly), "first day of the price (2006-01-02)")
+	cmd.MarkFlagRequired("input-price")
+	cmd.MarkFlagRequired("output-price")
+	return cmd
+}
+
+func newListModelPricesCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:     "list-model-prices",
+		Short:   "List the prices of the models",
+		Long:    "List the current and past prices of the models, in US dollars per million tokens, sorted by provider, model and day.",
+		GroupID: "usage",
+		Args:    usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			listHandler := diContainer.Container.Get("recipes.infrastructure.cli.listprices").(extractionhandlers.ListModelPricesHandler)
+
+			prices, err := listHandler(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return write(prices, output.FormatTable)
+		},
+	})
+}
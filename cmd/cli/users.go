@@ -0,0 +1,364 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	extractionhandlers "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/cli/handler"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/i18n"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/output"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	userhandlers "github.com/rubenbupe/recipe-video-parser/internal/users/platform/cli/handler"
+	"github.com/spf13/cobra"
+)
+
+type createUserOutput struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	ApiKey    string `json:"apiKey"`
+	CreatedAt string `json:"createdAt"`
+}
+
+type apiKeyOutput struct {
+	Name   string `json:"name"`
+	ApiKey string `json:"apiKey"`
+}
+
+type localeOutput struct {
+	Name   string `json:"name"`
+	Locale string `json:"locale"`
+}
+
+type userSummaryOutput struct {
+	UserID   string `json:"userId"`
+	UserName string `json:"userName"`
+	extractionhandlers.ExtractionsSummary
+}
+
+func newCreateUserCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:     "create-user <username>",
+		Short:   "Create a user with a new API key",
+		Long:    "Create a user and generate its API key, needed to use the HTTP API.",
+		GroupID: "users",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			createHandler := diContainer.Container.Get("users.infrastructure.cli.create").(userhandlers.CreateUserHandler)
+
+			result := createUserOutput{
+				ID:        uuid.New().String(),
+				Name:      args[0],
+				ApiKey:    uuid.New().String(),
+				CreatedAt: time.Now().Format(time.RFC3339),
+			}
+			err := createHandler(
+				cmd.Context(),
+				userhandlers.CreateUserInput{
+					ID:        result.ID,
+					Name:      result.Name,
+					ApiKey:    result.ApiKey,
+					CreatedAt: result.CreatedAt,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return write(result, output.FormatTable)
+		},
+	})
+}
+
+func newUpdateApiKeyCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:     "update-api-key <username>",
+		Short:   "Generate a new API key for a user",
+		GroupID: "users",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			updateHandler := diContainer.Container.Get("users.infrastructure.cli.updateapikey").(userhandlers.UpdateApiKeyHandler)
+
+			result := apiKeyOutput{Name: args[0], ApiKey: uuid.New().String()}
+			err := updateHandler(
+				cmd.Context(),
+				userhandlers.UpdateApiKeyInput{
+					Name:   result.Name,
+					ApiKey: result.ApiKey,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return write(result, output.FormatTable)
+		},
+	})
+}
+
+func newUpdateUserLocaleCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:   "update-user-locale <username> <locale>",
+		Short: "Set the default language of the recipes of a user",
+		Long: "Set the locale used for the extractions of a user when the request does not specify one.\n\n" +
+			"Supported locales: " + strings.Join(i18n.SupportedLocales(), ", ") + ".",
+		GroupID: "users",
+		Args:    usageArgs(cobra.ExactArgs(2)),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 1 {
+				return i18n.SupportedLocales(), cobra.ShellCompDirectiveNoFileComp
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			updateHandler := diContainer.Container.Get("users.infrastructure.cli.updatelocale").(userhandlers.UpdateLocaleHandler)
+
+			result := localeOutput{Name: args[0], Locale: args[1]}
+			err := updateHandler(
+				cmd.Context(),
+				userhandlers.UpdateLocaleInput{
+					Name:   result.Name,
+					Locale: result.Locale,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return write(result, output.FormatTable)
+		},
+	})
+}
+
+func newGetUserCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:     "get-user <username>",
+		Short:   "Show a user",
+		GroupID: "users",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			getHandler := diContainer.Container.Get("users.infrastructure.cli.get").(userhandlers.GetUserHandler)
+
+			result, err := getHandler(cmd.Context(), userhandlers.GetUserInput{Name: args[0]})
+			if err != nil {
+				return err
+			}
+			return write(result, output.FormatTable)
+		},
+	})
+}
+
+func newGetUserSummaryCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:   "get-user-summary <username>",
+		Short: "Show the extractions and tokens used by a user",
+		Long: "Show the extractions and tokens used by a user by month, the failed extractions by category " +
+			"and the average tokens of every prompt version. See usage-report for their cost.",
+		GroupID: "users",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userGetHandler := diContainer.Container.Get("users.infrastructure.cli.get").(userhandlers.GetUserHandler)
+			summaryHandler := diContainer.Container.Get("recipes.infrastructure.cli.usagesummary").(extractionhandlers.UsageSummaryHandler)
+
+			user, err := userGetHandler(cmd.Context(), userhandlers.GetUserInput{Name: args[0]})
+			if err != nil {
+				return err
+			}
+
+			summary, err := summaryHandler(cmd.Context(), user.ID)
+			if err != nil {
+				return err
+			}
+
+			return write(userSummaryOutput{
+				UserID:             user.ID,
+				UserName:           user.Name,
+				ExtractionsSummary: summary,
+			}, output.FormatTable)
+		},
+	})
+}
+
+type userStatusOutput struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+type roleOutput struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+type exportOutput struct {
+	Name  string `json:"name"`
+	File  string `json:"file"`
+	Bytes int    `json:"bytes"`
+}
+
+type quotaOutput struct {
+	Name               string `json:"name"`
+	MonthlyExtractions int    `json:"monthlyExtractions"`
+	MonthlyTokens      int    `json:"monthlyTokens"`
+}
+
+func newListUsersCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:     "list-users",
+		Short:   "List the users",
+		Long:    "List the users sorted by name, with their role, status and quota. The API keys are not shown.",
+		GroupID: "users",
+		Args:    usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			listHandler := diContainer.Container.Get("users.infrastructure.cli.list").(userhandlers.ListUsersHandler)
+
+			users, err := listHandler(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return write(users, output.FormatTable)
+		},
+	})
+}
+
+func newUpdateUserRoleCmd() *cobra.Command {
+	roles := []string{usersdomain.UserRoleUser, usersdomain.UserRoleAdmin}
+	return appCommand(&cobra.Command{
+		Use:   "update-user-role <username> <role>",
+		Short: "Set the role of a user",
+		Long: "Set the role of a user. Admins can manage the users through the /admin endpoints of the HTTP API.\n\n" +
+			"Roles: " + strings.Join(roles, ", ") + ".",
+		GroupID: "users",
+		Args:    usageArgs(cobra.ExactArgs(2)),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 1 {
+				return roles, cobra.ShellCompDirectiveNoFileComp
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			updateHandler := diContainer.Container.Get("users.infrastructure.cli.updaterole").(userhandlers.UpdateRoleHandler)
+
+			result := roleOutput{Name: args[0], Role: args[1]}
+			if err := updateHandler(cmd.Context(), userhandlers.UpdateRoleInput{Name: result.Name, Role: result.Role}); err != nil {
+				return err
+			}
+			return write(result, output.FormatTable)
+		},
+	})
+}
+
+func newUpdateUserStatusCmd(use, short, long, status string) *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:     use + " <username>",
+		Short:   short,
+		Long:    long,
+		GroupID: "users",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			updateHandler := diContainer.Container.Get("users.infrastructure.cli.updatestatus").(userhandlers.UpdateStatusHandler)
+
+			if err := updateHandler(cmd.Context(), userhandlers.UpdateStatusInput{Name: args[0], Status: status}); err != nil {
+				return err
+			}
+			return write(userStatusOutput{Name: args[0], Status: status}, output.FormatTable)
+		},
+	})
+}
+
+func newDisableUserCmd() *cobra.Command {
+	return newUpdateUserStatusCmd(
+		"disable-user",
+		"Reject the API key of a user, keeping their data",
+		"Disable a user: the HTTP API rejects their API key, but their extractions, webhooks and subscriptions are kept.",
+		usersdomain.UserStatusDisabled,
+	)
+}
+
+func newEnableUserCmd() *cobra.Command {
+	return newUpdateUserStatusCmd(
+		"enable-user",
+		"Accept again the API key of a disabled user",
+		"Enable a disabled user.",
+		usersdomain.UserStatusActive,
+	)
+}
+
+func newDeleteUserCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:   "delete-user <username>",
+		Short: "Delete a user and all their data",
+		Long: "Delete a user with their extractions, webhooks, subscriptions and memberships, and the files their " +
+			"extractions left in the download directory or at the provider. The extractions shared with an " +
+			"organization stay in its library. The only owner of an organization can not be deleted until another " +
+			"member is made an owner or the organization is deleted. It can not be undone.",
+		GroupID: "users",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			deleteHandler := diContainer.Container.Get("users.infrastructure.cli.delete").(userhandlers.DeleteUserHandler)
+
+			if err := deleteHandler(cmd.Context(), userhandlers.DeleteUserInput{Name: args[0]}); err != nil {
+				return err
+			}
+			return write(userStatusOutput{Name: args[0], Status: "deleted"}, output.FormatTable)
+		},
+	})
+}
+
+func newUpdateUserQuotaCmd() *cobra.Command {
+	var result quotaOutput
+	cmd := appCommand(&cobra.Command{
+		Use:   "update-user-quota <username>",
+		Short: "Set the monthly extractions and tokens of a user",
+		Long: "Set the extractions and tokens a user can use every calendar month through the HTTP API. " +
+			"Both limits are replaced; an omitted or 0 limit means no limit.",
+		Example: "  cli update-user-quota ana --extractions 100 --tokens 500000",
+		GroupID: "users",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			updateHandler := diContainer.Container.Get("users.infrastructure.cli.updatequota").(userhandlers.UpdateQuotaHandler)
+
+			result.Name = args[0]
+			err := updateHandler(cmd.Context(), userhandlers.UpdateQuotaInput{
+				Name:               result.Name,
+				MonthlyExtractions: result.MonthlyExtractions,
+				MonthlyTokens:      result.MonthlyTokens,
+			})
+			if err != nil {
+				return err
+			}
+			return write(result, output.FormatTable)
+		},
+	})
+	cmd.Flags().IntVar(&result.MonthlyExtractions, "extractions", 0, "extractions per month (0: no limit)")
+	cmd.Flags().IntVar(&result.MonthlyTokens, "tokens", 0, "tokens per month (0: no limit)")
+	return cmd
+}
+
+func newExportUserDataCmd() *cobra.Command {
+	var file string
+	cmd := appCommand(&cobra.Command{
+		Use:   "export-user-data <username>",
+		Short: "Export the data of a user to a zip file",
+		Long: "Export the data of a user to a zip file with their profile, their extractions, the recipes in JSON " +
+			"and Markdown, and their usage by month. The API key is not exported.",
+		Example: "  cli export-user-data ana --file ana.zip",
+		GroupID: "users",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			exportHandler := diContainer.Container.Get("users.infrastructure.cli.export").(userhandlers.ExportUserDataHandler)
+
+			content, err := exportHandler(cmd.Context(), userhandlers.ExportUserDataInput{Name: args[0]})
+			if err != nil {
+				return err
+			}
+
+			if file == "" {
+				file = args[0] + "-data.zip"
+			}
+			if err := os.WriteFile(file, content, 0o600); err != nil {
+				return err
+			}
+			return write(exportOutput{Name: args[0], File: file, Bytes: len(content)}, output.FormatTable)
+		},
+	})
+	cmd.Flags().StringVar(&file, "file", "", "zip file to write (default: <username>-data.zip)")
+	return cmd
+}
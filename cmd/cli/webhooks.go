@@ -0,0 +1,127 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/output"
+	userhandlers "github.com/rubenbupe/recipe-video-parser/internal/users/platform/cli/handler"
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	webhookhandlers "github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/cli/handler"
+	"github.com/spf13/cobra"
+)
+
+type createWebhookOutput struct {
+	ID     string   `json:"id"`
+	Url    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret"`
+}
+
+type deliveryOutput struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func newCreateWebhookCmd() *cobra.Command {
+	var events []string
+	var secret string
+	cmd := appCommand(&cobra.Command{
+		Use:   "create-webhook <username> <url>",
+		Short: "Subscribe a URL to the extractions of a user",
+		Long: "Subscribe a URL to the events of the extractions of a user. The deliveries are signed " +
+			"with the secret, which is generated when not given and only shown here.",
+		Example: "  cli create-webhook ana https://example.com/hooks --events extraction.succeeded,extraction.failed",
+		GroupID: "webhooks",
+		Args:    usageArgs(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(events) == 0 {
+				return usageErrorf("the --events flag is required")
+			}
+			userGetHandler := diContainer.Container.Get("users.infrastructure.cli.get").(userhandlers.GetUserHandler)
+			createHandler := diContainer.Container.Get("webhooks.infrastructure.cli.create").(webhookhandlers.CreateWebhookHandler)
+
+			user, err := userGetHandler(cmd.Context(), userhandlers.GetUserInput{Name: args[0]})
+			if err != nil {
+				return err
+			}
+
+			if secret == "" {
+				secret, err = webhookhandlers.GenerateSecret()
+				if err != nil {
+					return err
+				}
+			}
+
+			result := createWebhookOutput{
+				ID:     uuid.New().String(),
+				Url:    args[1],
+				Events: events,
+				Secret: secret,
+			}
+			err = createHandler(
+				cmd.Context(),
+				webhookhandlers.CreateWebhookInput{
+					ID:         result.ID,
+					UserID:     user.ID,
+					Url:        result.Url,
+					Secret:     result.Secret,
+					EventTypes: result.Events,
+					CreatedAt:  time.Now().Format(time.RFC3339),
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return write(result, output.FormatTable)
+		},
+	})
+	cmd.Flags().StringSliceVar(&events, "events", nil, "comma separated events ("+strings.Join(webhooksdomain.SupportedWebhookEventTypes(), ", ")+")")
+	cmd.Flags().StringVar(&secret, "secret", "", "secret that signs the deliveries (generated when not given)")
+	cmd.RegisterFlagCompletionFunc("events", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return webhooksdomain.SupportedWebhookEventTypes(), cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+func newListWebhooksCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:     "list-webhooks <username>",
+		Short:   "List the webhooks of a user",
+		GroupID: "webhooks",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userGetHandler := diContainer.Container.Get("users.infrastructure.cli.get").(userhandlers.GetUserHandler)
+			listHandler := diContainer.Container.Get("webhooks.infrastructure.cli.list").(webhookhandlers.GetWebhooksHandler)
+
+			user, err := userGetHandler(cmd.Context(), userhandlers.GetUserInput{Name: args[0]})
+			if err != nil {
+				return err
+			}
+
+			webhooks, err := listHandler(cmd.Context(), webhookhandlers.GetWebhooksInput{UserID: user.ID})
+			if err != nil {
+				return err
+			}
+			return write(webhooks, output.FormatTable)
+		},
+	})
+}
+
+func newReplayWebhookDeliveryCmd() *cobra.Command {
+	return appCommand(&cobra.Command{
+		Use:     "replay-webhook-delivery <delivery_id>",
+		Short:   "Send a webhook delivery again",
+		GroupID: "webhooks",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			replayHandler := diContainer.Container.Get("webhooks.infrastructure.cli.replay").(webhookhandlers.ReplayDeliveryHandler)
+
+			if err := replayHandler(cmd.Context(), webhookhandlers.ReplayDeliveryInput{ID: args[0]}); err != nil {
+				return err
+			}
+			return write(deliveryOutput{ID: args[0], Status: "delivered"}, output.FormatTable)
+		},
+	})
+}
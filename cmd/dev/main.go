@@ -8,8 +8,8 @@ import (
 	"path/filepath"
 	"syscall"
 
-	_ "modernc.org/sqlite"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	_ "modernc.org/sqlite"
 )
 
 func main() {
@@ -26,14 +26,16 @@ func main() {
 	}()
 
 	if len(os.Args) < 3 {
-		fmt.Println("Se requiere un comando y el nombre de la base de datos: db-create <ruta/nombre.db>")
-		fmt.Println("Uso: dev db-create <ruta/nombre.db>")
+		fmt.Println("Se requiere un comando y el nombre de la base de datos: db-create <ruta/nombre.db>, db-migrate <ruta/nombre.db>")
+		fmt.Println("Uso: dev <db-create|db-migrate> <ruta/nombre.db>")
 		os.Exit(1)
 	}
 
 	switch os.Args[1] {
 	case "db-create":
 		dbCreateCmd(ctx, os.Args[2])
+	case "db-migrate":
+		dbMigrateCmd(ctx, os.Args[2])
 	default:
 		fmt.Printf("Comando desconocido: %s\n", os.Args[1])
 		os.Exit(1)
@@ -70,17 +72,43 @@ func dbCreateCmd(ctx context.Context, database string) {
 	}
 	defer conn.Db.Close()
 
-	// Leer el esquema desde el archivo sql/schema.sql
-	schemaBytes, err := os.ReadFile("sql/schema.sql")
+	// El esquema (sql/schema.sql) va embebido en el binario
+	err = storage.CreateSchema(ctx, conn)
 	if err != nil {
-		fmt.Printf("Error leyendo el archivo de esquema: %v\n", err)
+		fmt.Printf("Error creando la base de datos: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Base de datos SQLite inicializada en %s\n", dbFile)
+}
+
+func dbMigrateCmd(ctx context.Context, database string) {
+	if len(database) > 3 && database[len(database)-3:] == ".db" {
+		database = database[:len(database)-3]
+	}
+
+	dbFile := database + ".db"
+	if _, err := os.Stat(dbFile); err != nil {
+		fmt.Printf("Error: la base de datos '%s' no existe.\n", dbFile)
 		os.Exit(1)
 	}
 
-	_, err = conn.Db.ExecContext(ctx, string(schemaBytes))
+	cfg := &storage.Dbconfig{Database: database}
+	conn, err := storage.CreateConnection("cli-dev", cfg)
 	if err != nil {
-		fmt.Printf("Error creando la base de datos: %v\n", err)
+		fmt.Printf("Error abriendo la base de datos: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Printf("Base de datos SQLite inicializada en %s\n", dbFile)
+	defer conn.Db.Close()
+
+	applied, err := storage.Migrate(ctx, conn)
+	for _, version := range applied {
+		fmt.Printf("Migración aplicada: %s\n", version)
+	}
+	if err != nil {
+		fmt.Printf("Error migrando la base de datos: %v\n", err)
+		os.Exit(1)
+	}
+	if len(applied) == 0 {
+		fmt.Println("La base de datos ya está actualizada")
+	}
 }
@@ -0,0 +1,60 @@
+package create
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const OrganizationCommandType command.Type = "command.organization.create"
+
+type OrganizationCommand struct {
+	id        string
+	name      string
+	ownerId   string
+	createdAt string
+}
+
+func NewOrganizationCommand(id, name, ownerId, createdAt string) OrganizationCommand {
+	return OrganizationCommand{
+		id:        id,
+		name:      name,
+		ownerId:   ownerId,
+		createdAt: createdAt,
+	}
+}
+
+func (c OrganizationCommand) Type() command.Type {
+	return OrganizationCommandType
+}
+
+type OrganizationCommandHandler struct {
+	service OrganizationService
+}
+
+func NewOrganizationCommandHandler(service OrganizationService) OrganizationCommandHandler {
+	return OrganizationCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h OrganizationCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	createOrganizationCmd, ok := cmd.(OrganizationCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.CreateOrganization(
+		ctx,
+		createOrganizationCmd.id,
+		createOrganizationCmd.name,
+		createOrganizationCmd.ownerId,
+		createOrganizationCmd.createdAt,
+	)
+}
+
+func (h OrganizationCommandHandler) SubscribedTo() command.Type {
+	return OrganizationCommandType
+}
@@ -0,0 +1,36 @@
+package create
+
+import (
+	"context"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+)
+
+type OrganizationService struct {
+	organizationRepository organizationsdomain.OrganizationRepository
+}
+
+func NewOrganizationService(organizationRepository organizationsdomain.OrganizationRepository) OrganizationService {
+	return OrganizationService{
+		organizationRepository: organizationRepository,
+	}
+}
+
+// CreateOrganization registers an organization with the user as its owner.
+// The names of the organizations are unique.
+func (s OrganizationService) CreateOrganization(ctx context.Context, id, name, ownerId, createdAt string) error {
+	organization, err := organizationsdomain.NewOrganization(id, name, ownerId, createdAt)
+	if err != nil {
+		return err
+	}
+
+	exists, err := s.organizationRepository.ExistsByName(ctx, organization.Name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return organizationsdomain.ErrOrganizationAlreadyExists
+	}
+
+	return s.organizationRepository.Save(ctx, organization)
+}
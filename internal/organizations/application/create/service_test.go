@@ -0,0 +1,56 @@
+package create
+
+import (
+	"context"
+	"testing"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/organizations/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	organizationID = "5d1c7a9e-3f2b-4e8d-a6c0-9b7e1f3d5a2c"
+	ownerID        = "37a0f027-15e6-47cc-a5d2-64183281087e"
+	createdAt      = "2024-05-01T10:00:00Z"
+)
+
+func Test_OrganizationService_CreateOrganization_Succeed(t *testing.T) {
+	organizationRepositoryMock := new(storagemocks.OrganizationRepository)
+	organizationRepositoryMock.On("ExistsByName", mock.Anything, mock.AnythingOfType("domain.OrganizationName")).Return(false, nil)
+	organizationRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(organization organizationsdomain.Organization) bool {
+		owner, ok := organization.Member(ownerID)
+		return len(organization.Members) == 1 && ok && owner.Role.String() == organizationsdomain.MemberRoleOwner
+	})).Return(nil)
+
+	organizationService := NewOrganizationService(organizationRepositoryMock)
+
+	err := organizationService.CreateOrganization(context.Background(), organizationID, "casa", ownerID, createdAt)
+
+	organizationRepositoryMock.AssertExpectations(t)
+	assert.NoError(t, err)
+}
+
+func Test_OrganizationService_CreateOrganization_AlreadyExists(t *testing.T) {
+	organizationRepositoryMock := new(storagemocks.OrganizationRepository)
+	organizationRepositoryMock.On("ExistsByName", mock.Anything, mock.AnythingOfType("domain.OrganizationName")).Return(true, nil)
+
+	organizationService := NewOrganizationService(organizationRepositoryMock)
+
+	err := organizationService.CreateOrganization(context.Background(), organizationID, "casa", ownerID, createdAt)
+
+	organizationRepositoryMock.AssertExpectations(t)
+	assert.ErrorIs(t, err, organizationsdomain.ErrOrganizationAlreadyExists)
+}
+
+func Test_OrganizationService_CreateOrganization_InvalidName(t *testing.T) {
+	organizationRepositoryMock := new(storagemocks.OrganizationRepository)
+
+	organizationService := NewOrganizationService(organizationRepositoryMock)
+
+	err := organizationService.CreateOrganization(context.Background(), organizationID, "Mi Casa", ownerID, createdAt)
+
+	organizationRepositoryMock.AssertExpectations(t)
+	assert.ErrorIs(t, err, organizationsdomain.ErrInvalidOrganizationName)
+}
@@ -0,0 +1,48 @@
+package delete
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const OrganizationCommandType command.Type = "command.organization.delete"
+
+type OrganizationCommand struct {
+	name string
+}
+
+func NewOrganizationCommand(name string) OrganizationCommand {
+	return OrganizationCommand{
+		name: name,
+	}
+}
+
+func (c OrganizationCommand) Type() command.Type {
+	return OrganizationCommandType
+}
+
+type OrganizationCommandHandler struct {
+	service OrganizationService
+}
+
+func NewOrganizationCommandHandler(service OrganizationService) OrganizationCommandHandler {
+	return OrganizationCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h OrganizationCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	deleteOrganizationCmd, ok := cmd.(OrganizationCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.DeleteOrganization(ctx, deleteOrganizationCmd.name)
+}
+
+func (h OrganizationCommandHandler) SubscribedTo() command.Type {
+	return OrganizationCommandType
+}
@@ -0,0 +1,36 @@
+package delete
+
+import (
+	"context"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+)
+
+type OrganizationService struct {
+	organizationRepository organizationsdomain.OrganizationRepository
+}
+
+func NewOrganizationService(organizationRepository organizationsdomain.OrganizationRepository) OrganizationService {
+	return OrganizationService{
+		organizationRepository: organizationRepository,
+	}
+}
+
+// DeleteOrganization deletes the organization. Its extractions go back to the
+// members that made them.
+func (s OrganizationService) DeleteOrganization(ctx context.Context, name string) error {
+	nameVO, err := organizationsdomain.NewOrganizationName(name)
+	if err != nil {
+		return err
+	}
+
+	organization, err := s.organizationRepository.GetByName(ctx, nameVO)
+	if err != nil {
+		return err
+	}
+	if organization == nil {
+		return organizationsdomain.ErrOrganizationNotFound
+	}
+
+	return s.organizationRepository.Delete(ctx, organization.Id)
+}
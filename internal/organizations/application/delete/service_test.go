@@ -0,0 +1,41 @@
+package delete
+
+import (
+	"context"
+	"testing"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/organizations/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const organizationID = "5d1c7a9e-3f2b-4e8d-a6c0-9b7e1f3d5a2c"
+
+func Test_OrganizationService_DeleteOrganization_Succeed(t *testing.T) {
+	organization, err := organizationsdomain.NewOrganization(organizationID, "casa", "37a0f027-15e6-47cc-a5d2-64183281087e", "2024-05-01T10:00:00Z")
+	require.NoError(t, err)
+	organizationRepositoryMock := new(storagemocks.OrganizationRepository)
+	organizationRepositoryMock.On("GetByName", mock.Anything, organization.Name).Return(&organization, nil)
+	organizationRepositoryMock.On("Delete", mock.Anything, organization.Id).Return(nil)
+
+	organizationService := NewOrganizationService(organizationRepositoryMock)
+
+	err = organizationService.DeleteOrganization(context.Background(), "casa")
+
+	organizationRepositoryMock.AssertExpectations(t)
+	assert.NoError(t, err)
+}
+
+func Test_OrganizationService_DeleteOrganization_NotFound(t *testing.T) {
+	organizationRepositoryMock := new(storagemocks.OrganizationRepository)
+	organizationRepositoryMock.On("GetByName", mock.Anything, mock.AnythingOfType("domain.OrganizationName")).Return(nil, nil)
+
+	organizationService := NewOrganizationService(organizationRepositoryMock)
+
+	err := organizationService.DeleteOrganization(context.Background(), "casa")
+
+	organizationRepositoryMock.AssertExpectations(t)
+	assert.ErrorIs(t, err, organizationsdomain.ErrOrganizationNotFound)
+}
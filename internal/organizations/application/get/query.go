@@ -0,0 +1,48 @@
+package get
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+const OrganizationQueryType query.Type = "query.organization.get"
+
+type OrganizationQuery struct {
+	name string
+}
+
+func NewOrganizationQuery(name string) OrganizationQuery {
+	return OrganizationQuery{
+		name: name,
+	}
+}
+
+func (c OrganizationQuery) Type() query.Type {
+	return OrganizationQueryType
+}
+
+type OrganizationQueryHandler struct {
+	service OrganizationService
+}
+
+func NewOrganizationQueryHandler(service OrganizationService) OrganizationQueryHandler {
+	return OrganizationQueryHandler{
+		service: service,
+	}
+}
+
+// Handle implements the query.Handler interface.
+func (h OrganizationQueryHandler) Handle(ctx context.Context, qry query.Query) (interface{}, error) {
+	organizationQuery, ok := qry.(OrganizationQuery)
+	if !ok {
+		return nil, errors.New("unexpected query")
+	}
+
+	return h.service.GetOrganization(ctx, organizationQuery.name)
+}
+
+func (h OrganizationQueryHandler) SubscribedTo() query.Type {
+	return OrganizationQueryType
+}
@@ -0,0 +1,71 @@
+package get
+
+import (
+	"context"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+)
+
+// MemberDetail is a member of an organization with the name of the user.
+type MemberDetail struct {
+	Member organizationsdomain.Member
+	// UserName is empty if the user no longer exists.
+	UserName string
+}
+
+// OrganizationDetail is an organization with the names of its members.
+type OrganizationDetail struct {
+	Organization organizationsdomain.Organization
+	Members      []MemberDetail
+}
+
+type OrganizationService struct {
+	organizationRepository organizationsdomain.OrganizationRepository
+	userRepository         usersdomain.UserRepository
+}
+
+func NewOrganizationService(organizationRepository organizationsdomain.OrganizationRepository, userRepository usersdomain.UserRepository) OrganizationService {
+	return OrganizationService{
+		organizationRepository: organizationRepository,
+		userRepository:         userRepository,
+	}
+}
+
+func (s OrganizationService) GetOrganization(ctx context.Context, name string) (*OrganizationDetail, error) {
+	nameVO, err := organizationsdomain.NewOrganizationName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	organization, err := s.organizationRepository.GetByName(ctx, nameVO)
+	if err != nil {
+		return nil, err
+	}
+	if organization == nil {
+		return nil, organizationsdomain.ErrOrganizationNotFound
+	}
+
+	detail := &OrganizationDetail{
+		Organization: *organization,
+		Members:      make([]MemberDetail, 0, len(organization.Members)),
+	}
+	for _, member := range organization.Members {
+		userId, err := usersdomain.NewUserID(member.UserId.String())
+		if err != nil {
+			return nil, err
+		}
+		user, err := s.userRepository.Get(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+
+		memberDetail := MemberDetail{Member: member}
+		if user != nil {
+			memberDetail.UserName = user.Name.String()
+		}
+		detail.Members = append(detail.Members, memberDetail)
+	}
+
+	return detail, nil
+}
@@ -0,0 +1,50 @@
+package list
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+const OrganizationsQueryType query.Type = "query.organization.list"
+
+type OrganizationsQuery struct {
+	userId string
+}
+
+// NewOrganizationsQuery returns the query of the organizations of the user,
+// or of all the organizations if userId is empty.
+func NewOrganizationsQuery(userId string) OrganizationsQuery {
+	return OrganizationsQuery{
+		userId: userId,
+	}
+}
+
+func (c OrganizationsQuery) Type() query.Type {
+	return OrganizationsQueryType
+}
+
+type OrganizationsQueryHandler struct {
+	service OrganizationsService
+}
+
+func NewOrganizationsQueryHandler(service OrganizationsService) OrganizationsQueryHandler {
+	return OrganizationsQueryHandler{
+		service: service,
+	}
+}
+
+// Handle implements the query.Handler interface.
+func (h OrganizationsQueryHandler) Handle(ctx context.Context, qry query.Query) (interface{}, error) {
+	organizationsQuery, ok := qry.(OrganizationsQuery)
+	if !ok {
+		return nil, errors.New("unexpected query")
+	}
+
+	return h.service.GetOrganizations(ctx, organizationsQuery.userId)
+}
+
+func (h OrganizationsQueryHandler) SubscribedTo() query.Type {
+	return OrganizationsQueryType
+}
@@ -0,0 +1,30 @@
+package list
+
+import (
+	"context"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+)
+
+type OrganizationsService struct {
+	organizationRepository organizationsdomain.OrganizationRepository
+}
+
+func NewOrganizationsService(organizationRepository organizationsdomain.OrganizationRepository) OrganizationsService {
+	return OrganizationsService{
+		organizationRepository: organizationRepository,
+	}
+}
+
+func (s OrganizationsService) GetOrganizations(ctx context.Context, userId string) ([]organizationsdomain.Organization, error) {
+	if userId == "" {
+		return s.organizationRepository.All(ctx)
+	}
+
+	userIdVO, err := organizationsdomain.NewMemberUserID(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.organizationRepository.GetByUserID(ctx, userIdVO)
+}
@@ -0,0 +1,50 @@
+package removemember
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const MemberCommandType command.Type = "command.organization.removemember"
+
+type MemberCommand struct {
+	organizationName string
+	userId           string
+}
+
+func NewMemberCommand(organizationName, userId string) MemberCommand {
+	return MemberCommand{
+		organizationName: organizationName,
+		userId:           userId,
+	}
+}
+
+func (c MemberCommand) Type() command.Type {
+	return MemberCommandType
+}
+
+type MemberCommandHandler struct {
+	service MemberService
+}
+
+func NewMemberCommandHandler(service MemberService) MemberCommandHandler {
+	return MemberCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h MemberCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	removeMemberCmd, ok := cmd.(MemberCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.RemoveMember(ctx, removeMemberCmd.organizationName, removeMemberCmd.userId)
+}
+
+func (h MemberCommandHandler) SubscribedTo() command.Type {
+	return MemberCommandType
+}
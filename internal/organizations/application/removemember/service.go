@@ -0,0 +1,40 @@
+package removemember
+
+import (
+	"context"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+)
+
+type MemberService struct {
+	organizationRepository organizationsdomain.OrganizationRepository
+}
+
+func NewMemberService(organizationRepository organizationsdomain.OrganizationRepository) MemberService {
+	return MemberService{
+		organizationRepository: organizationRepository,
+	}
+}
+
+// RemoveMember removes the user from the organization. The extractions they
+// made in it stay in the library of the organization.
+func (s MemberService) RemoveMember(ctx context.Context, organizationName, userId string) error {
+	nameVO, err := organizationsdomain.NewOrganizationName(organizationName)
+	if err != nil {
+		return err
+	}
+
+	organization, err := s.organizationRepository.GetByName(ctx, nameVO)
+	if err != nil {
+		return err
+	}
+	if organization == nil {
+		return organizationsdomain.ErrOrganizationNotFound
+	}
+
+	if err := organization.RemoveMember(userId); err != nil {
+		return err
+	}
+
+	return s.organizationRepository.Update(ctx, *organization)
+}
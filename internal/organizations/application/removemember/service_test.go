@@ -0,0 +1,67 @@
+package removemember
+
+import (
+	"context"
+	"testing"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/organizations/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	organizationID = "5d1c7a9e-3f2b-4e8d-a6c0-9b7e1f3d5a2c"
+	ownerID        = "37a0f027-15e6-47cc-a5d2-64183281087e"
+	userID         = "4a0c8d2e-6b1f-4c3a-9e5d-7f2b1a0c9d8e"
+	createdAt      = "2024-05-01T10:00:00Z"
+)
+
+func newOrganization(t *testing.T) *organizationsdomain.Organization {
+	t.Helper()
+	organization, err := organizationsdomain.NewOrganization(organizationID, "casa", ownerID, createdAt)
+	require.NoError(t, err)
+	require.NoError(t, organization.SetMember(userID, organizationsdomain.MemberRoleEditor, createdAt))
+	return &organization
+}
+
+func Test_MemberService_RemoveMember_Succeed(t *testing.T) {
+	organizationRepositoryMock := new(storagemocks.OrganizationRepository)
+	organizationRepositoryMock.On("GetByName", mock.Anything, mock.AnythingOfType("domain.OrganizationName")).Return(newOrganization(t), nil)
+	organizationRepositoryMock.On("Update", mock.Anything, mock.MatchedBy(func(organization organizationsdomain.Organization) bool {
+		_, ok := organization.Member(userID)
+		return len(organization.Members) == 1 && !ok
+	})).Return(nil)
+
+	memberService := NewMemberService(organizationRepositoryMock)
+
+	err := memberService.RemoveMember(context.Background(), "casa", userID)
+
+	organizationRepositoryMock.AssertExpectations(t)
+	assert.NoError(t, err)
+}
+
+func Test_MemberService_RemoveMember_KeepsLastOwner(t *testing.T) {
+	organizationRepositoryMock := new(storagemocks.OrganizationRepository)
+	organizationRepositoryMock.On("GetByName", mock.Anything, mock.AnythingOfType("domain.OrganizationName")).Return(newOrganization(t), nil)
+
+	memberService := NewMemberService(organizationRepositoryMock)
+
+	err := memberService.RemoveMember(context.Background(), "casa", ownerID)
+
+	organizationRepositoryMock.AssertExpectations(t)
+	assert.ErrorIs(t, err, organizationsdomain.ErrLastOwner)
+}
+
+func Test_MemberService_RemoveMember_NotAMember(t *testing.T) {
+	organizationRepositoryMock := new(storagemocks.OrganizationRepository)
+	organizationRepositoryMock.On("GetByName", mock.Anything, mock.AnythingOfType("domain.OrganizationName")).Return(newOrganization(t), nil)
+
+	memberService := NewMemberService(organizationRepositoryMock)
+
+	err := memberService.RemoveMember(context.Background(), "casa", "6e2d8b0f-4a3c-4f9e-b7d1-0c8f2a4e6b3d")
+
+	organizationRepositoryMock.AssertExpectations(t)
+	assert.ErrorIs(t, err, organizationsdomain.ErrMemberNotFound)
+}
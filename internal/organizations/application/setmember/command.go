@@ -0,0 +1,60 @@
+package setmember
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const MemberCommandType command.Type = "command.organization.setmember"
+
+type MemberCommand struct {
+	organizationName string
+	userId           string
+	role             string
+	createdAt        string
+}
+
+func NewMemberCommand(organizationName, userId, role, createdAt string) MemberCommand {
+	return MemberCommand{
+		organizationName: organizationName,
+		userId:           userId,
+		role:             role,
+		createdAt:        createdAt,
+	}
+}
+
+func (c MemberCommand) Type() command.Type {
+	return MemberCommandType
+}
+
+type MemberCommandHandler struct {
+	service MemberService
+}
+
+func NewMemberCommandHandler(service MemberService) MemberCommandHandler {
+	return MemberCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h MemberCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	setMemberCmd, ok := cmd.(MemberCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.SetMember(
+		ctx,
+		setMemberCmd.organizationName,
+		setMemberCmd.userId,
+		setMemberCmd.role,
+		setMemberCmd.createdAt,
+	)
+}
+
+func (h MemberCommandHandler) SubscribedTo() command.Type {
+	return MemberCommandType
+}
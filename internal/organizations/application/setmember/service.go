@@ -0,0 +1,40 @@
+package setmember
+
+import (
+	"context"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+)
+
+type MemberService struct {
+	organizationRepository organizationsdomain.OrganizationRepository
+}
+
+func NewMemberService(organizationRepository organizationsdomain.OrganizationRepository) MemberService {
+	return MemberService{
+		organizationRepository: organizationRepository,
+	}
+}
+
+// SetMember adds the user to the organization with the role, or changes the
+// role of a member.
+func (s MemberService) SetMember(ctx context.Context, organizationName, userId, role, createdAt string) error {
+	nameVO, err := organizationsdomain.NewOrganizationName(organizationName)
+	if err != nil {
+		return err
+	}
+
+	organization, err := s.organizationRepository.GetByName(ctx, nameVO)
+	if err != nil {
+		return err
+	}
+	if organization == nil {
+		return organizationsdomain.ErrOrganizationNotFound
+	}
+
+	if err := organization.SetMember(userId, role, createdAt); err != nil {
+		return err
+	}
+
+	return s.organizationRepository.Update(ctx, *organization)
+}
@@ -0,0 +1,97 @@
+package setmember
+
+import (
+	"context"
+	"testing"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/organizations/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	organizationID = "5d1c7a9e-3f2b-4e8d-a6c0-9b7e1f3d5a2c"
+	ownerID        = "37a0f027-15e6-47cc-a5d2-64183281087e"
+	userID         = "4a0c8d2e-6b1f-4c3a-9e5d-7f2b1a0c9d8e"
+	createdAt      = "2024-05-01T10:00:00Z"
+)
+
+func newOrganization(t *testing.T) *organizationsdomain.Organization {
+	t.Helper()
+	organization, err := organizationsdomain.NewOrganization(organizationID, "casa", ownerID, createdAt)
+	require.NoError(t, err)
+	return &organization
+}
+
+func Test_MemberService_SetMember_AddsMember(t *testing.T) {
+	organizationRepositoryMock := new(storagemocks.OrganizationRepository)
+	organizationRepositoryMock.On("GetByName", mock.Anything, mock.AnythingOfType("domain.OrganizationName")).Return(newOrganization(t), nil)
+	organizationRepositoryMock.On("Update", mock.Anything, mock.MatchedBy(func(organization organizationsdomain.Organization) bool {
+		member, ok := organization.Member(userID)
+		return len(organization.Members) == 2 && ok && member.Role.String() == organizationsdomain.MemberRoleViewer
+	})).Return(nil)
+
+	memberService := NewMemberService(organizationRepositoryMock)
+
+	err := memberService.SetMember(context.Background(), "casa", userID, organizationsdomain.MemberRoleViewer, "2024-05-02T10:00:00Z")
+
+	organizationRepositoryMock.AssertExpectations(t)
+	assert.NoError(t, err)
+}
+
+func Test_MemberService_SetMember_ChangesRole(t *testing.T) {
+	organization := newOrganization(t)
+	require.NoError(t, organization.SetMember(userID, organizationsdomain.MemberRoleViewer, "2024-05-02T10:00:00Z"))
+	organizationRepositoryMock := new(storagemocks.OrganizationRepository)
+	organizationRepositoryMock.On("GetByName", mock.Anything, mock.AnythingOfType("domain.OrganizationName")).Return(organization, nil)
+	organizationRepositoryMock.On("Update", mock.Anything, mock.MatchedBy(func(organization organizationsdomain.Organization) bool {
+		member, ok := organization.Member(userID)
+		return len(organization.Members) == 2 && ok && member.Role.String() == organizationsdomain.MemberRoleEditor &&
+			member.CreatedAt.String() == "2024-05-02T10:00:00Z"
+	})).Return(nil)
+
+	memberService := NewMemberService(organizationRepositoryMock)
+
+	err := memberService.SetMember(context.Background(), "casa", userID, organizationsdomain.MemberRoleEditor, "2024-05-03T10:00:00Z")
+
+	organizationRepositoryMock.AssertExpectations(t)
+	assert.NoError(t, err)
+}
+
+func Test_MemberService_SetMember_KeepsLastOwner(t *testing.T) {
+	organizationRepositoryMock := new(storagemocks.OrganizationRepository)
+	organizationRepositoryMock.On("GetByName", mock.Anything, mock.AnythingOfType("domain.OrganizationName")).Return(newOrganization(t), nil)
+
+	memberService := NewMemberService(organizationRepositoryMock)
+
+	err := memberService.SetMember(context.Background(), "casa", ownerID, organizationsdomain.MemberRoleEditor, createdAt)
+
+	organizationRepositoryMock.AssertExpectations(t)
+	assert.ErrorIs(t, err, organizationsdomain.ErrLastOwner)
+}
+
+func Test_MemberService_SetMember_InvalidRole(t *testing.T) {
+	organizationRepositoryMock := new(storagemocks.OrganizationRepository)
+	organizationRepositoryMock.On("GetByName", mock.Anything, mock.AnythingOfType("domain.OrganizationName")).Return(newOrganization(t), nil)
+
+	memberService := NewMemberService(organizationRepositoryMock)
+
+	err := memberService.SetMember(context.Background(), "casa", userID, "admin", createdAt)
+
+	organizationRepositoryMock.AssertExpectations(t)
+	assert.ErrorIs(t, err, organizationsdomain.ErrInvalidMemberRole)
+}
+
+func Test_MemberService_SetMember_OrganizationNotFound(t *testing.T) {
+	organizationRepositoryMock := new(storagemocks.OrganizationRepository)
+	organizationRepositoryMock.On("GetByName", mock.Anything, mock.AnythingOfType("domain.OrganizationName")).Return(nil, nil)
+
+	memberService := NewMemberService(organizationRepositoryMock)
+
+	err := memberService.SetMember(context.Background(), "casa", userID, organizationsdomain.MemberRoleEditor, createdAt)
+
+	organizationRepositoryMock.AssertExpectations(t)
+	assert.ErrorIs(t, err, organizationsdomain.ErrOrganizationNotFound)
+}
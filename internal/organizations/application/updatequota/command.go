@@ -0,0 +1,57 @@
+package updatequota
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const OrganizationQuotaCommandType command.Type = "command.organization.updatequota"
+
+type OrganizationQuotaCommand struct {
+	name               string
+	monthlyExtractions int
+	monthlyTokens      int
+}
+
+func NewOrganizationQuotaCommand(name string, monthlyExtractions, monthlyTokens int) OrganizationQuotaCommand {
+	return OrganizationQuotaCommand{
+		name:               name,
+		monthlyExtractions: monthlyExtractions,
+		monthlyTokens:      monthlyTokens,
+	}
+}
+
+func (c OrganizationQuotaCommand) Type() command.Type {
+	return OrganizationQuotaCommandType
+}
+
+type OrganizationQuotaCommandHandler struct {
+	service OrganizationQuotaService
+}
+
+func NewOrganizationQuotaCommandHandler(service OrganizationQuotaService) OrganizationQuotaCommandHandler {
+	return OrganizationQuotaCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h OrganizationQuotaCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	updateQuotaCmd, ok := cmd.(OrganizationQuotaCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.UpdateQuota(
+		ctx,
+		updateQuotaCmd.name,
+		updateQuotaCmd.monthlyExtractions,
+		updateQuotaCmd.monthlyTokens,
+	)
+}
+
+func (h OrganizationQuotaCommandHandler) SubscribedTo() command.Type {
+	return OrganizationQuotaCommandType
+}
@@ -0,0 +1,40 @@
+package updatequota
+
+import (
+	"context"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+)
+
+type OrganizationQuotaService struct {
+	organizationRepository organizationsdomain.OrganizationRepository
+}
+
+func NewOrganizationQuotaService(organizationRepository organizationsdomain.OrganizationRepository) OrganizationQuotaService {
+	return OrganizationQuotaService{
+		organizationRepository: organizationRepository,
+	}
+}
+
+// UpdateQuota sets the monthly limits of the organization. Zero removes a
+// limit.
+func (s OrganizationQuotaService) UpdateQuota(ctx context.Context, name string, monthlyExtractions, monthlyTokens int) error {
+	nameVO, err := organizationsdomain.NewOrganizationName(name)
+	if err != nil {
+		return err
+	}
+
+	organization, err := s.organizationRepository.GetByName(ctx, nameVO)
+	if err != nil {
+		return err
+	}
+	if organization == nil {
+		return organizationsdomain.ErrOrganizationNotFound
+	}
+
+	if err := organization.SetQuota(monthlyExtractions, monthlyTokens); err != nil {
+		return err
+	}
+
+	return s.organizationRepository.Update(ctx, *organization)
+}
@@ -0,0 +1,361 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidOrganizationID = errors.New("invalid Organization ID")
+var ErrInvalidOrganizationName = errors.New("invalid Organization Name")
+var ErrInvalidMemberUserID = errors.New("invalid Member User ID")
+var ErrInvalidMemberRole = errors.New("invalid Member Role")
+var ErrInvalidOrganizationQuota = errors.New("invalid Organization Quota")
+var ErrOrganizationAlreadyExists = errors.New("organization already exists")
+var ErrOrganizationNotFound = errors.New("organization not found")
+var ErrMemberNotFound = errors.New("member not found")
+var ErrLastOwner = errors.New("the organization must keep at least one owner")
+var ErrOrganizationQuotaExceeded = errors.New("organization quota exceeded")
+
+type OrganizationID struct {
+	value string
+}
+
+func NewOrganizationID(value string) (OrganizationID, error) {
+	v, err := uuid.Parse(value)
+	if err != nil {
+		return OrganizationID{}, fmt.Errorf("%w: %s", ErrInvalidOrganizationID, value)
+	}
+
+	return OrganizationID{
+		value: v.String(),
+	}, nil
+}
+
+func (id OrganizationID) String() string {
+	return id.value
+}
+
+var organizationNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,62}$`)
+
+// OrganizationName is the unique name of an organization: lowercase letters,
+// digits and dashes, so it can be used in URLs and in the X-Organization
+// header.
+type OrganizationName struct {
+	value string
+}
+
+func NewOrganizationName(value string) (OrganizationName, error) {
+	if !organizationNameRegexp.MatchString(value) {
+		return OrganizationName{}, fmt.Errorf("%w: %q must have 2 to 63 lowercase letters, digits or dashes", ErrInvalidOrganizationName, value)
+	}
+
+	return OrganizationName{
+		value: value,
+	}, nil
+}
+
+func (name OrganizationName) String() string {
+	return name.value
+}
+
+type MemberUserID struct {
+	value string
+}
+
+func NewMemberUserID(value string) (MemberUserID, error) {
+	v, err := uuid.Parse(value)
+	if err != nil {
+		return MemberUserID{}, fmt.Errorf("%w: %s", ErrInvalidMemberUserID, value)
+	}
+
+	return MemberUserID{
+		value: v.String(),
+	}, nil
+}
+
+func (id MemberUserID) String() string {
+	return id.value
+}
+
+const (
+	// MemberRoleOwner manages the members of the organization and can do
+	// everything an editor can.
+	MemberRoleOwner = "owner"
+	// MemberRoleEditor extracts recipes into the library of the organization.
+	MemberRoleEditor = "editor"
+	// MemberRoleViewer reads the library of the organization.
+	MemberRoleViewer = "viewer"
+)
+
+// MemberRoles are the supported roles, from the most to the least privileged.
+var MemberRoles = []string{MemberRoleOwner, MemberRoleEditor, MemberRoleViewer}
+
+type MemberRole struct {
+	value string
+}
+
+func NewMemberRole(value string) (MemberRole, error) {
+	switch value {
+	case MemberRoleOwner, MemberRoleEditor, MemberRoleViewer:
+		return MemberRole{value: value}, nil
+	default:
+		return MemberRole{}, fmt.Errorf("%w: %s", ErrInvalidMemberRole, value)
+	}
+}
+
+func (role MemberRole) String() string {
+	return role.value
+}
+
+// CanManage reports whether the role can add and remove members.
+func (role MemberRole) CanManage() bool {
+	return role.value == MemberRoleOwner
+}
+
+// CanExtract reports whether the role can extract recipes into the library.
+func (role MemberRole) CanExtract() bool {
+	return role.value == MemberRoleOwner || role.value == MemberRoleEditor
+}
+
+type MemberCreatedAt struct {
+	value string
+}
+
+func NewMemberCreatedAt(value string) (MemberCreatedAt, error) {
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		return MemberCreatedAt{}, errors.New("the field Member Created At must be a valid RFC3339 date")
+	}
+
+	return MemberCreatedAt{
+		value: value,
+	}, nil
+}
+
+func (createdAt MemberCreatedAt) String() string {
+	return createdAt.value
+}
+
+// Member is a user of an organization with their role in it.
+type Member struct {
+	UserId    MemberUserID
+	Role      MemberRole
+	CreatedAt MemberCreatedAt
+}
+
+func NewMember(userId, role, createdAt string) (Member, error) {
+	userIdVO, err := NewMemberUserID(userId)
+	if err != nil {
+		return Member{}, err
+	}
+
+	roleVO, err := NewMemberRole(role)
+	if err != nil {
+		return Member{}, err
+	}
+
+	createdAtVO, err := NewMemberCreatedAt(createdAt)
+	if err != nil {
+		return Member{}, err
+	}
+
+	return Member{
+		UserId:    userIdVO,
+		Role:      roleVO,
+		CreatedAt: createdAtVO,
+	}, nil
+}
+
+// OrganizationQuota is the maximum number of extractions and of tokens the
+// members can use every calendar month in the organization. Zero means no
+// limit.
+type OrganizationQuota struct {
+	monthlyExtractions int
+	monthlyTokens      int
+}
+
+func NewOrganizationQuota(monthlyExtractions, monthlyTokens int) (OrganizationQuota, error) {
+	if monthlyExtractions < 0 || monthlyTokens < 0 {
+		return OrganizationQuota{}, fmt.Errorf("%w: the limits can not be negative", ErrInvalidOrganizationQuota)
+	}
+
+	return OrganizationQuota{
+		monthlyExtractions: monthlyExtractions,
+		monthlyTokens:      monthlyTokens,
+	}, nil
+}
+
+func (quota OrganizationQuota) MonthlyExtractions() int {
+	return quota.monthlyExtractions
+}
+
+func (quota OrganizationQuota) MonthlyTokens() int {
+	return quota.monthlyTokens
+}
+
+// Limited reports whether the quota has any limit.
+func (quota OrganizationQuota) Limited() bool {
+	return quota.monthlyExtractions > 0 || quota.monthlyTokens > 0
+}
+
+// Check returns ErrOrganizationQuotaExceeded if the extractions or the tokens
+// used in the month have reached one of the limits.
+func (quota OrganizationQuota) Check(extractions, tokens int) error {
+	if quota.monthlyExtractions > 0 && extractions >= quota.monthlyExtractions {
+		return fmt.Errorf("%w: %d of %d extractions used this month", ErrOrganizationQuotaExceeded, extractions, quota.monthlyExtractions)
+	}
+	if quota.monthlyTokens > 0 && tokens >= quota.monthlyTokens {
+		return fmt.Errorf("%w: %d of %d tokens used this month", ErrOrganizationQuotaExceeded, tokens, quota.monthlyTokens)
+	}
+	return nil
+}
+
+type OrganizationCreatedAt struct {
+	value string
+}
+
+func NewOrganizationCreatedAt(value string) (OrganizationCreatedAt, error) {
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		return OrganizationCreatedAt{}, errors.New("the field Organization Created At must be a valid RFC3339 date")
+	}
+
+	return OrganizationCreatedAt{
+		value: value,
+	}, nil
+}
+
+func (createdAt OrganizationCreatedAt) String() string {
+	return createdAt.value
+}
+
+// Organization is a household or a team whose members share a library of
+// recipes and a quota.
+type Organization struct {
+	Id        OrganizationID
+	Name      OrganizationName
+	Quota     OrganizationQuota
+	Members   []Member
+	CreatedAt OrganizationCreatedAt
+}
+
+type OrganizationRepository interface {
+	// Save stores a new organization with its members.
+	Save(ctx context.Context, organization Organization) error
+	// Update saves the quota and the members of the organization.
+	Update(ctx context.Context, organization Organization) error
+	// Delete removes the organization and its members. Its extractions go
+	// back to the users that made them.
+	Delete(ctx context.Context, id OrganizationID) error
+	ExistsByName(ctx context.Context, name OrganizationName) (bool, error)
+	Get(ctx context.Context, id OrganizationID) (*Organization, error)
+	GetByName(ctx context.Context, name OrganizationName) (*Organization, error)
+	// GetByUserID returns the organizations the user is a member of.
+	GetByUserID(ctx context.Context, userId MemberUserID) ([]Organization, error)
+	All(ctx context.Context) ([]Organization, error)
+}
+
+//mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=OrganizationRepository
+
+// NewOrganization returns an organization with the user as its only owner.
+func NewOrganization(id, name, ownerId, createdAt string) (Organization, error) {
+	idVO, err := NewOrganizationID(id)
+	if err != nil {
+		return Organization{}, err
+	}
+
+	nameVO, err := NewOrganizationName(name)
+	if err != nil {
+		return Organization{}, err
+	}
+
+	owner, err := NewMember(ownerId, MemberRoleOwner, createdAt)
+	if err != nil {
+		return Organization{}, err
+	}
+
+	createdAtVO, err := NewOrganizationCreatedAt(createdAt)
+	if err != nil {
+		return Organization{}, err
+	}
+
+	return Organization{
+		Id:        idVO,
+		Name:      nameVO,
+		Members:   []Member{owner},
+		CreatedAt: createdAtVO,
+	}, nil
+}
+
+// Member returns the membership of the user, if they are a member.
+func (o Organization) Member(userId string) (Member, bool) {
+	for _, member := range o.Members {
+		if member.UserId.String() == userId {
+			return member, true
+		}
+	}
+	return Member{}, false
+}
+
+// SetMember adds the user to the organization or changes their role. The
+// last owner can not stop being an owner.
+func (o *Organization) SetMember(userId, role, createdAt string) error {
+	member, err := NewMember(userId, role, createdAt)
+	if err != nil {
+		return err
+	}
+
+	for i, current := range o.Members {
+		if current.UserId.String() != member.UserId.String() {
+			continue
+		}
+		if current.Role.CanManage() && !member.Role.CanManage() && o.owners() == 1 {
+			return ErrLastOwner
+		}
+		// Se conserva la fecha en la que el usuario entró en la organización
+		o.Members[i].Role = member.Role
+		return nil
+	}
+
+	o.Members = append(o.Members, member)
+	return nil
+}
+
+// RemoveMember removes the user from the organization. The last owner can not
+// be removed.
+func (o *Organization) RemoveMember(userId string) error {
+	for i, member := range o.Members {
+		if member.UserId.String() != userId {
+			continue
+		}
+		if member.Role.CanManage() && o.owners() == 1 {
+			return ErrLastOwner
+		}
+		o.Members = append(o.Members[:i], o.Members[i+1:]...)
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrMemberNotFound, userId)
+}
+
+func (o *Organization) SetQuota(monthlyExtractions, monthlyTokens int) error {
+	quotaVO, err := NewOrganizationQuota(monthlyExtractions, monthlyTokens)
+	if err != nil {
+		return err
+	}
+
+	o.Quota = quotaVO
+	return nil
+}
+
+func (o Organization) owners() int {
+	owners := 0
+	for _, member := range o.Members {
+		if member.Role.CanManage() {
+			owners++
+		}
+	}
+	return owners
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/organizations/application/create"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+type CreateOrganizationInput struct {
+	ID   string
+	Name string
+	// OwnerID es el usuario que crea la organización, su primer propietario
+	OwnerID   string
+	CreatedAt string
+}
+
+type CreateOrganizationHandler func(context.Context, CreateOrganizationInput) error
+
+func CreateCreateOrganizationHandler(commandBus command.Bus) CreateOrganizationHandler {
+	return func(ctx context.Context, input CreateOrganizationInput) error {
+		if input.ID == "" || input.Name == "" || input.OwnerID == "" || input.CreatedAt == "" {
+			return fmt.Errorf("todos los campos son obligatorios")
+		}
+
+		err := commandBus.Dispatch(ctx, create.NewOrganizationCommand(
+			input.ID,
+			input.Name,
+			input.OwnerID,
+			input.CreatedAt,
+		))
+
+		if err != nil {
+			if isDomainError(err) {
+				return fmt.Errorf("error de dominio: %w", err)
+			}
+			return fmt.Errorf("error interno: %w", err)
+		}
+
+		return nil
+	}
+}
+
+func isDomainError(err error) bool {
+	return errors.Is(err, organizationsdomain.ErrInvalidOrganizationID) ||
+		errors.Is(err, organizationsdomain.ErrInvalidOrganizationName) ||
+		errors.Is(err, organizationsdomain.ErrInvalidMemberUserID) ||
+		errors.Is(err, organizationsdomain.ErrInvalidMemberRole) ||
+		errors.Is(err, organizationsdomain.ErrInvalidOrganizationQuota) ||
+		errors.Is(err, organizationsdomain.ErrOrganizationAlreadyExists) ||
+		errors.Is(err, organizationsdomain.ErrOrganizationNotFound) ||
+		errors.Is(err, organizationsdomain.ErrMemberNotFound) ||
+		errors.Is(err, organizationsdomain.ErrLastOwner)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/organizations/application/create"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newCreateOrganizationInput() CreateOrganizationInput {
+	return CreateOrganizationInput{
+		ID:        "5d1c7a9e-3f2b-4e8d-a6c0-9b7e1f3d5a2c",
+		Name:      "casa",
+		OwnerID:   "37a0f027-15e6-47cc-a5d2-64183281087e",
+		CreatedAt: "2024-05-01T10:00:00Z",
+	}
+}
+
+func TestCreateOrganizationHandler_Success(t *testing.T) {
+	input := newCreateOrganizationInput()
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, create.NewOrganizationCommand(input.ID, input.Name, input.OwnerID, input.CreatedAt)).Return(nil)
+	handler := CreateCreateOrganizationHandler(bus)
+	err := handler(context.Background(), input)
+	assert.NoError(t, err)
+	bus.AssertExpectations(t)
+}
+
+func TestCreateOrganizationHandler_MissingFields(t *testing.T) {
+	handler := CreateCreateOrganizationHandler(new(commandmocks.Bus))
+	err := handler(context.Background(), CreateOrganizationInput{Name: "casa"})
+	assert.EqualError(t, err, "todos los campos son obligatorios")
+}
+
+func TestCreateOrganizationHandler_ErrorDominio(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("create.OrganizationCommand")).Return(organizationsdomain.ErrOrganizationAlreadyExists)
+	handler := CreateCreateOrganizationHandler(bus)
+	err := handler(context.Background(), newCreateOrganizationInput())
+	assert.ErrorIs(t, err, organizationsdomain.ErrOrganizationAlreadyExists)
+	assert.Contains(t, err.Error(), "error de dominio")
+}
+
+func TestCreateOrganizationHandler_ErrorInterno(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("create.OrganizationCommand")).Return(errors.New("fallo"))
+	handler := CreateCreateOrganizationHandler(bus)
+	err := handler(context.Background(), newCreateOrganizationInput())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error interno")
+}
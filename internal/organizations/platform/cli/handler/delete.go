@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/organizations/application/delete"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+type DeleteOrganizationInput struct {
+	Name string
+}
+
+type DeleteOrganizationHandler func(context.Context, DeleteOrganizationInput) error
+
+func CreateDeleteOrganizationHandler(commandBus command.Bus) DeleteOrganizationHandler {
+	return func(ctx context.Context, input DeleteOrganizationInput) error {
+		if input.Name == "" {
+			return fmt.Errorf("el campo Name es obligatorio")
+		}
+
+		err := commandBus.Dispatch(ctx, delete.NewOrganizationCommand(input.Name))
+		if err != nil {
+			if isDomainError(err) {
+				return fmt.Errorf("error de dominio: %w", err)
+			}
+			return fmt.Errorf("error interno: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/organizations/application/get"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+type GetOrganizationInput struct {
+	Name string
+}
+
+type GetMemberOutput struct {
+	UserID string `json:"userId"`
+	// UserName está vacío si el usuario ya no existe
+	UserName  string `json:"userName"`
+	Role      string `json:"role"`
+	CreatedAt string `json:"createdAt"`
+}
+
+type GetOrganizationDetailOutput struct {
+	GetOrganizationOutput
+	Members []GetMemberOutput `json:"members"`
+}
+
+type GetOrganizationHandler func(context.Context, GetOrganizationInput) (GetOrganizationDetailOutput, error)
+
+func CreateGetOrganizationHandler(queryBus query.Bus) GetOrganizationHandler {
+	return func(ctx context.Context, input GetOrganizationInput) (GetOrganizationDetailOutput, error) {
+		if input.Name == "" {
+			return GetOrganizationDetailOutput{}, fmt.Errorf("el campo Name es obligatorio")
+		}
+
+		result, err := queryBus.Ask(ctx, get.NewOrganizationQuery(input.Name))
+		if err != nil {
+			if isDomainError(err) {
+				return GetOrganizationDetailOutput{}, fmt.Errorf("error de dominio: %w", err)
+			}
+			return GetOrganizationDetailOutput{}, fmt.Errorf("error al buscar la organización: %w", err)
+		}
+
+		detail, ok := result.(*get.OrganizationDetail)
+		if !ok || detail == nil {
+			return GetOrganizationDetailOutput{}, fmt.Errorf("respuesta inesperada del query")
+		}
+
+		output := GetOrganizationDetailOutput{
+			GetOrganizationOutput: toOrganizationOutput(detail.Organization),
+			Members:               make([]GetMemberOutput, 0, len(detail.Members)),
+		}
+		for _, member := range detail.Members {
+			output.Members = append(output.Members, GetMemberOutput{
+				UserID:    member.Member.UserId.String(),
+				UserName:  member.UserName,
+				Role:      member.Member.Role.String(),
+				CreatedAt: member.Member.CreatedAt.String(),
+			})
+		}
+		return output, nil
+	}
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/organizations/application/list"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+// GetOrganizationsInput busca las organizaciones del usuario o, si UserID
+// está vacío, todas.
+type GetOrganizationsInput struct {
+	UserID string
+}
+
+type GetOrganizationOutput struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Role es el rol del usuario de la búsqueda en la organización
+	Role               string `json:"role,omitempty"`
+	MemberCount        int    `json:"memberCount"`
+	MonthlyExtractions int    `json:"monthlyExtractions"`
+	MonthlyTokens      int    `json:"monthlyTokens"`
+	CreatedAt          string `json:"createdAt"`
+}
+
+type GetOrganizationsHandler func(context.Context, GetOrganizationsInput) ([]GetOrganizationOutput, error)
+
+func CreateGetOrganizationsHandler(queryBus query.Bus) GetOrganizationsHandler {
+	return func(ctx context.Context, input GetOrganizationsInput) ([]GetOrganizationOutput, error) {
+		result, err := queryBus.Ask(ctx, list.NewOrganizationsQuery(input.UserID))
+		if err != nil {
+			if isDomainError(err) {
+				return nil, fmt.Errorf("error de dominio: %w", err)
+			}
+			return nil, fmt.Errorf("error al buscar organizaciones: %w", err)
+		}
+
+		organizations, ok := result.([]organizationsdomain.Organization)
+		if !ok {
+			return nil, fmt.Errorf("respuesta inesperada del query")
+		}
+
+		output := make([]GetOrganizationOutput, 0, len(organizations))
+		for _, organization := range organizations {
+			item := toOrganizationOutput(organization)
+			if member, ok := organization.Member(input.UserID); ok {
+				item.Role = member.Role.String()
+			}
+			output = append(output, item)
+		}
+		return output, nil
+	}
+}
+
+func toOrganizationOutput(organization organizationsdomain.Organization) GetOrganizationOutput {
+	return GetOrganizationOutput{
+		ID:                 organization.Id.String(),
+		Name:               organization.Name.String(),
+		MemberCount:        len(organization.Members),
+		MonthlyExtractions: organization.Quota.MonthlyExtractions(),
+		MonthlyTokens:      organization.Quota.MonthlyTokens(),
+		CreatedAt:          organization.CreatedAt.String(),
+	}
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/organizations/application/list"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/query/querymocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrganizationsHandler_RoleOfTheUser(t *testing.T) {
+	organization, err := organizationsdomain.NewOrganization("5d1c7a9e-3f2b-4e8d-a6c0-9b7e1f3d5a2c", "casa", "37a0f027-15e6-47cc-a5d2-64183281087e", "2024-05-01T10:00:00Z")
+	require.NoError(t, err)
+	userID := "4a0c8d2e-6b1f-4c3a-9e5d-7f2b1a0c9d8e"
+	require.NoError(t, organization.SetMember(userID, organizationsdomain.MemberRoleViewer, "2024-05-02T10:00:00Z"))
+	bus := new(querymocks.Bus)
+	bus.On("Ask", mock.Anything, list.NewOrganizationsQuery(userID)).Return([]organizationsdomain.Organization{organization}, nil)
+	handler := CreateGetOrganizationsHandler(bus)
+
+	organizations, err := handler(context.Background(), GetOrganizationsInput{UserID: userID})
+
+	require.NoError(t, err)
+	require.Len(t, organizations, 1)
+	assert.Equal(t, "casa", organizations[0].Name)
+	assert.Equal(t, organizationsdomain.MemberRoleViewer, organizations[0].Role)
+	assert.Equal(t, 2, organizations[0].MemberCount)
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/organizations/application/removemember"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+type RemoveMemberInput struct {
+	OrganizationName string
+	UserID           string
+}
+
+type RemoveMemberHandler func(context.Context, RemoveMemberInput) error
+
+func CreateRemoveMemberHandler(commandBus command.Bus) RemoveMemberHandler {
+	return func(ctx context.Context, input RemoveMemberInput) error {
+		if input.OrganizationName == "" || input.UserID == "" {
+			return fmt.Errorf("todos los campos son obligatorios")
+		}
+
+		err := commandBus.Dispatch(ctx, removemember.NewMemberCommand(input.OrganizationName, input.UserID))
+		if err != nil {
+			if isDomainError(err) {
+				return fmt.Errorf("error de dominio: %w", err)
+			}
+			return fmt.Errorf("error interno: %w", err)
+		}
+
+		return nil
+	}
+}
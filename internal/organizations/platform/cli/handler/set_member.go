@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/organizations/application/setmember"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+type SetMemberInput struct {
+	OrganizationName string
+	UserID           string
+	Role             string
+	// CreatedAt es la fecha en la que el usuario entra en la organización; no
+	// cambia si ya era miembro
+	CreatedAt string
+}
+
+type SetMemberHandler func(context.Context, SetMemberInput) error
+
+func CreateSetMemberHandler(commandBus command.Bus) SetMemberHandler {
+	return func(ctx context.Context, input SetMemberInput) error {
+		if input.OrganizationName == "" || input.UserID == "" || input.Role == "" || input.CreatedAt == "" {
+			return fmt.Errorf("todos los campos son obligatorios")
+		}
+
+		err := commandBus.Dispatch(ctx, setmember.NewMemberCommand(
+			input.OrganizationName,
+			input.UserID,
+			input.Role,
+			input.CreatedAt,
+		))
+
+		if err != nil {
+			if isDomainError(err) {
+				return fmt.Errorf("error de dominio: %w", err)
+			}
+			return fmt.Errorf("error interno: %w", err)
+		}
+
+		return nil
+	}
+}
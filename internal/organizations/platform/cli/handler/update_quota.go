@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/organizations/application/updatequota"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+type UpdateQuotaInput struct {
+	Name string
+	// MonthlyExtractions y MonthlyTokens son los límites de cada mes para
+	// todos los miembros juntos; 0 es sin límite
+	MonthlyExtractions int
+	MonthlyTokens      int
+}
+
+type UpdateQuotaHandler func(context.Context, UpdateQuotaInput) error
+
+func CreateUpdateQuotaHandler(commandBus command.Bus) UpdateQuotaHandler {
+	return func(ctx context.Context, input UpdateQuotaInput) error {
+		if input.Name == "" {
+			return fmt.Errorf("todos los campos son obligatorios")
+		}
+
+		err := commandBus.Dispatch(ctx, updatequota.NewOrganizationQuotaCommand(
+			input.Name,
+			input.MonthlyExtractions,
+			input.MonthlyTokens,
+		))
+
+		if err != nil {
+			if isDomainError(err) {
+				return fmt.Errorf("error de dominio: %w", err)
+			}
+			return fmt.Errorf("error interno: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	clihandlers "github.com/rubenbupe/recipe-video-parser/internal/organizations/platform/cli/handler"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	userclihandlers "github.com/rubenbupe/recipe-video-parser/internal/users/platform/cli/handler"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+type createOrganizationRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type setMemberRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// CreateHandler crea una organización con el usuario autenticado como
+// propietario.
+func CreateHandler(commandBus command.Bus) gin.HandlerFunc {
+	createOrganization := clihandlers.CreateCreateOrganizationHandler(commandBus)
+
+	return func(ctx *gin.Context) {
+		user, ok := middleware.GetUserFromContext(ctx)
+		if !ok || user == nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+			return
+		}
+
+		var req createOrganizationRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		input := clihandlers.CreateOrganizationInput{
+			ID:        uuid.New().String(),
+			Name:      req.Name,
+			OwnerID:   user.Id.String(),
+			CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		}
+		if err := createOrganization(ctx, input); err != nil {
+			ctx.JSON(organizationErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.Header("Location", "/organizations/"+input.Name)
+		ctx.JSON(http.StatusCreated, gin.H{"id": input.ID, "name": input.Name})
+	}
+}
+
+// ListHandler devuelve las organizaciones del usuario autenticado con su rol
+// en cada una.
+func ListHandler(queryBus query.Bus) gin.HandlerFunc {
+	getOrganizations := clihandlers.CreateGetOrganizationsHandler(queryBus)
+
+	return func(ctx *gin.Context) {
+		user, ok := middleware.GetUserFromContext(ctx)
+		if !ok || user == nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+			return
+		}
+
+		organizations, err := getOrganizations(ctx, clihandlers.GetOrganizationsInput{UserID: user.Id.String()})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"organizations": organizations})
+	}
+}
+
+// GetHandler devuelve una organización del usuario autenticado con sus
+// miembros.
+func GetHandler(queryBus query.Bus) gin.HandlerFunc {
+	getOrganization := clihandlers.CreateGetOrganizationHandler(queryBus)
+
+	return func(ctx *gin.Context) {
+		organization, _, ok := membership(ctx, getOrganization)
+		if !ok {
+			return
+		}
+
+		ctx.JSON(http.StatusOK, organization)
+	}
+}
+
+// SetMemberHandler añade el usuario del parámetro user a la organización o
+// cambia su rol. Solo los propietarios gestionan los miembros.
+func SetMemberHandler(commandBus command.Bus, queryBus query.Bus) gin.HandlerFunc {
+	getOrganization := clihandlers.CreateGetOrganizationHandler(queryBus)
+	getUser := userclihandlers.CreateGetUserHandler(queryBus)
+	setMember := clihandlers.CreateSetMemberHandler(commandBus)
+
+	return func(ctx *gin.Context) {
+		var req setMemberRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		organization, member, ok := membership(ctx, getOrganization)
+		if !ok {
+			return
+		}
+		if member.Role != organizationsdomain.MemberRoleOwner {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": "only the owners can manage the members"})
+			return
+		}
+
+		user, err := getUser(ctx, userclihandlers.GetUserInput{Name: ctx.Param("user")})
+		if err != nil {
+			ctx.JSON(organizationErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		err = setMember(ctx, clihandlers.SetMemberInput{
+			OrganizationName: organization.Name,
+			UserID:           user.ID,
+			Role:             req.Role,
+			CreatedAt:        time.Now().UTC().Format(time.RFC3339),
+		})
+		if err != nil {
+			ctx.JSON(organizationErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// RemoveMemberHandler quita el usuario del parámetro user de la organización.
+// Los propietarios quitan a cualquier miembro y el resto solo a sí mismos.
+func RemoveMemberHandler(commandBus command.Bus, queryBus query.Bus) gin.HandlerFunc {
+	getOrganization := clihandlers.CreateGetOrganizationHandler(queryBus)
+	removeMember := clihandlers.CreateRemoveMemberHandler(commandBus)
+
+	return func(ctx *gin.Context) {
+		organization, member, ok := membership(ctx, getOrganization)
+		if !ok {
+			return
+		}
+
+		var removed *clihandlers.GetMemberOutput
+		for i := range organization.Members {
+			if organization.Members[i].UserName == ctx.Param("user") {
+				removed = &organization.Members[i]
+			}
+		}
+		if removed == nil {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": organizationsdomain.ErrMemberNotFound.Error()})
+			return
+		}
+		if member.Role != organizationsdomain.MemberRoleOwner && removed.UserID != member.UserID {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": "only the owners can manage the members"})
+			return
+		}
+
+		err := removeMember(ctx, clihandlers.RemoveMemberInput{OrganizationName: organization.Name, UserID: removed.UserID})
+		if err != nil {
+			ctx.JSON(organizationErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// DeleteHandler elimina una organización del usuario autenticado, que tiene
+// que ser propietario. Sus extracciones vuelven a ser de quien las hizo.
+func DeleteHandler(commandBus command.Bus, queryBus query.Bus) gin.HandlerFunc {
+	getOrganization := clihandlers.CreateGetOrganizationHandler(queryBus)
+	deleteOrganization := clihandlers.CreateDeleteOrganizationHandler(commandBus)
+
+	return func(ctx *gin.Context) {
+		organization, member, ok := membership(ctx, getOrganization)
+		if !ok {
+			return
+		}
+		if member.Role != organizationsdomain.MemberRoleOwner {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": "only the owners can delete the organization"})
+			return
+		}
+
+		if err := deleteOrganization(ctx, clihandlers.DeleteOrganizationInput{Name: organization.Name}); err != nil {
+			ctx.JSON(organizationErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// membership busca la organización del parámetro name y la membresía del
+// usuario autenticado en ella. Responde con 404 si no existe o el usuario no
+// es miembro, para no revelar las organizaciones ajenas.
+func membership(ctx *gin.Context, getOrganization clihandlers.GetOrganizationHandler) (clihandlers.GetOrganizationDetailOutput, clihandlers.GetMemberOutput, bool) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok || user == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+		return clihandlers.GetOrganizationDetailOutput{}, clihandlers.GetMemberOutput{}, false
+	}
+
+	organization, err := getOrganization(ctx, clihandlers.GetOrganizationInput{Name: ctx.Param("name")})
+	if err != nil {
+		ctx.JSON(organizationErrorStatus(err), gin.H{"error": err.Error()})
+		return clihandlers.GetOrganizationDetailOutput{}, clihandlers.GetMemberOutput{}, false
+	}
+	for _, member := range organization.Members {
+		if member.UserID == user.Id.String() {
+			return organization, member, true
+		}
+	}
+
+	ctx.JSON(http.StatusNotFound, gin.H{"error": organizationsdomain.ErrOrganizationNotFound.Error()})
+	return clihandlers.GetOrganizationDetailOutput{}, clihandlers.GetMemberOutput{}, false
+}
+
+func organizationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, organizationsdomain.ErrInvalidOrganizationName),
+		errors.Is(err, organizationsdomain.ErrInvalidMemberRole):
+		return http.StatusBadRequest
+	case errors.Is(err, organizationsdomain.ErrOrganizationNotFound),
+		errors.Is(err, organizationsdomain.ErrMemberNotFound),
+		errors.Is(err, usersdomain.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, organizationsdomain.ErrOrganizationAlreadyExists),
+		errors.Is(err, organizationsdomain.ErrLastOwner):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
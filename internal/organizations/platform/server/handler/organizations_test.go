@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenbupe/recipe-video-parser/internal/organizations/application/get"
+	"github.com/rubenbupe/recipe-video-parser/internal/organizations/application/removemember"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	usersget "github.com/rubenbupe/recipe-video-parser/internal/users/application/get"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/rubenbupe/recipe-video-parser/kit/query/querymocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ownerID        = "37a0f027-15e6-47cc-a5d2-64183281087e"
+	viewerID       = "4a0c8d2e-6b1f-4c3a-9e5d-7f2b1a0c9d8e"
+	otherUserID    = "5d3b8c0e-7a41-4f4b-9a55-1c2d3e4f5a6b"
+	organizationID = "5d1c7a9e-3f2b-4e8d-a6c0-9b7e1f3d5a2c"
+)
+
+// newRouter devuelve un router autenticado como el usuario userID.
+func newRouter(t *testing.T, userID string, register func(r *gin.Engine)) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	user, err := usersdomain.NewUser(userID, "name", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(func(ctx *gin.Context) {
+		ctx.Set("user", &user)
+	})
+	register(r)
+	return r
+}
+
+// newQueryBus devuelve un bus con la organización casa, con ana de
+// propietaria y luis de lector, y con el usuario luis.
+func newQueryBus(t *testing.T) *querymocks.Bus {
+	t.Helper()
+	organization, err := organizationsdomain.NewOrganization(organizationID, "casa", ownerID, "2024-05-01T10:00:00Z")
+	require.NoError(t, err)
+	require.NoError(t, organization.SetMember(viewerID, organizationsdomain.MemberRoleViewer, "2024-05-02T10:00:00Z"))
+	luis, err := usersdomain.NewUser(viewerID, "luis", "otherkey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+
+	bus := new(querymocks.Bus)
+	bus.On("Ask", mock.Anything, get.NewOrganizationQuery("casa")).Return(&get.OrganizationDetail{
+		Organization: organization,
+		Members: []get.MemberDetail{
+			{Member: organization.Members[0], UserName: "ana"},
+			{Member: organization.Members[1], UserName: "luis"},
+		},
+	}, nil)
+	bus.On("Ask", mock.Anything, usersget.NewUserQuery("luis")).Return(&luis, nil)
+	return bus
+}
+
+func serve(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_Create(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("create.OrganizationCommand")).Return(nil)
+	r := newRouter(t, ownerID, func(r *gin.Engine) { r.POST("/organizations", CreateHandler(bus)) })
+
+	t.Run("it returns 201", func(t *testing.T) {
+		rec := serve(r, http.MethodPost, "/organizations", `{"name": "casa"}`)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "/organizations/casa", rec.Header().Get("Location"))
+	})
+
+	t.Run("it returns 400 without a name", func(t *testing.T) {
+		rec := serve(r, http.MethodPost, "/organizations", `{}`)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestHandler_Create_AlreadyExists(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("create.OrganizationCommand")).Return(organizationsdomain.ErrOrganizationAlreadyExists)
+	r := newRouter(t, ownerID, func(r *gin.Engine) { r.POST("/organizations", CreateHandler(bus)) })
+
+	rec := serve(r, http.MethodPost, "/organizations", `{"name": "casa"}`)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestHandler_Get(t *testing.T) {
+	t.Run("it returns the organization to its members", func(t *testing.T) {
+		r := newRouter(t, viewerID, func(r *gin.Engine) { r.GET("/organizations/:name", GetHandler(newQueryBus(t))) })
+
+		rec := serve(r, http.MethodGet, "/organizations/casa", "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var organization struct {
+			Name    string `json:"name"`
+			Members []struct {
+				UserName string `json:"userName"`
+				Role     string `json:"role"`
+			} `json:"members"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &organization))
+		assert.Equal(t, "casa", organization.Name)
+		require.Len(t, organization.Members, 2)
+		assert.Equal(t, "luis", organization.Members[1].UserName)
+	})
+
+	t.Run("it returns 404 to the users that are not members", func(t *testing.T) {
+		r := newRouter(t, otherUserID, func(r *gin.Engine) { r.GET("/organizations/:name", GetHandler(newQueryBus(t))) })
+
+		rec := serve(r, http.MethodGet, "/organizations/casa", "")
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestHandler_SetMember(t *testing.T) {
+	t.Run("it lets the owners change the role of a member", func(t *testing.T) {
+		bus := new(commandmocks.Bus)
+		bus.On("Dispatch", mock.Anything, mock.AnythingOfType("setmember.MemberCommand")).Return(nil)
+		r := newRouter(t, ownerID, func(r *gin.Engine) {
+			r.PUT("/organizations/:name/members/:user", SetMemberHandler(bus, newQueryBus(t)))
+		})
+
+		rec := serve(r, http.MethodPut, "/organizations/casa/members/luis", `{"role": "editor"}`)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		bus.AssertExpectations(t)
+	})
+
+	t.Run("it returns 403 to the members that are not owners", func(t *testing.T) {
+		bus := new(commandmocks.Bus)
+		r := newRouter(t, viewerID, func(r *gin.Engine) {
+			r.PUT("/organizations/:name/members/:user", SetMemberHandler(bus, newQueryBus(t)))
+		})
+
+		rec := serve(r, http.MethodPut, "/organizations/casa/members/luis", `{"role": "owner"}`)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		bus.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_RemoveMember(t *testing.T) {
+	t.Run("it lets the members leave the organization", func(t *testing.T) {
+		bus := new(commandmocks.Bus)
+		bus.On("Dispatch", mock.Anything, removemember.NewMemberCommand("casa", viewerID)).Return(nil)
+		r := newRouter(t, viewerID, func(r *gin.Engine) {
+			r.DELETE("/organizations/:name/members/:user", RemoveMemberHandler(bus, newQueryBus(t)))
+		})
+
+		rec := serve(r, http.MethodDelete, "/organizations/casa/members/luis", "")
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		bus.AssertExpectations(t)
+	})
+
+	t.Run("it returns 403 when a member that is not an owner removes another one", func(t *testing.T) {
+		bus := new(commandmocks.Bus)
+		r := newRouter(t, viewerID, func(r *gin.Engine) {
+			r.DELETE("/organizations/:name/members/:user", RemoveMemberHandler(bus, newQueryBus(t)))
+		})
+
+		rec := serve(r, http.MethodDelete, "/organizations/casa/members/ana", "")
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("it returns 409 when the last owner leaves", func(t *testing.T) {
+		bus := new(commandmocks.Bus)
+		bus.On("Dispatch", mock.Anything, removemember.NewMemberCommand("casa", ownerID)).Return(organizationsdomain.ErrLastOwner)
+		r := newRouter(t, ownerID, func(r *gin.Engine) {
+			r.DELETE("/organizations/:name/members/:user", RemoveMemberHandler(bus, newQueryBus(t)))
+		})
+
+		rec := serve(r, http.MethodDelete, "/organizations/casa/members/ana", "")
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/di"
+	handlers "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/handler"
+	middleware "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+)
+
+func Register(router *gin.RouterGroup) {
+	diContainer := di.Instance()
+
+	createController := diContainer.Container.Get("organizations.infrastructure.controller.create").(handlers.Handler)
+	listController := diContainer.Container.Get("organizations.infrastructure.controller.list").(handlers.Handler)
+	getController := diContainer.Container.Get("organizations.infrastructure.controller.get").(handlers.Handler)
+	setMemberController := diContainer.Container.Get("organizations.infrastructure.controller.setmember").(handlers.Handler)
+	removeMemberController := diContainer.Container.Get("organizations.infrastructure.controller.removemember").(handlers.Handler)
+	deleteController := diContainer.Container.Get("organizations.infrastructure.controller.delete").(handlers.Handler)
+	userRepo := diContainer.Container.Get("users.domain.repository").(usersdomain.UserRepository)
+	organizationRepo := diContainer.Container.Get("organizations.domain.repository").(organizationsdomain.OrganizationRepository)
+
+	router.Use(middleware.AuthMiddleware(userRepo, organizationRepo))
+
+	router.POST("", createController)
+	router.GET("", listController)
+	router.GET("/:name", getController)
+	router.DELETE("/:name", deleteController)
+	router.PUT("/:name/members/:user", setMemberController)
+	router.DELETE("/:name/members/:user", removeMemberController)
+}
@@ -0,0 +1,24 @@
+package sql
+
+const (
+	sqlOrganizationTable       = "organizations"
+	sqlOrganizationMemberTable = "organization_members"
+	// sqlExtractionTable es la tabla de las extracciones, que pueden ser de
+	// una organización
+	sqlExtractionTable = "recipe_extractions"
+)
+
+type sqlOrganization struct {
+	ID                 string `db:"id"`
+	Name               string `db:"name"`
+	MonthlyExtractions int    `db:"monthly_extractions"`
+	MonthlyTokens      int    `db:"monthly_tokens"`
+	CreatedAt          string `db:"created_at"`
+}
+
+type sqlOrganizationMember struct {
+	OrganizationID string `db:"organization_id"`
+	UserID         string `db:"user_id"`
+	Role           string `db:"role"`
+	CreatedAt      string `db:"created_at"`
+}
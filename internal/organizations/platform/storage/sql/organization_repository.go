@@ -0,0 +1,326 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+)
+
+var (
+	organizationColumns = []string{"id", "name", "monthly_extractions", "monthly_tokens", "created_at"}
+	memberColumns       = []string{"organization_id", "user_id", "role", "created_at"}
+)
+
+type OrganizationRepository struct {
+	connection *storage.Connection
+	dbconfig   *storage.Dbconfig
+}
+
+func NewOrganizationRepository(connection *storage.Connection, dbconfig *storage.Dbconfig) *OrganizationRepository {
+	return &OrganizationRepository{
+		connection: connection,
+		dbconfig:   dbconfig,
+	}
+}
+
+// Save inserts the organization and its members in a transaction.
+func (r *OrganizationRepository) Save(ctx context.Context, organization organizationsdomain.Organization) error {
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	return r.connection.Transaction(ctxTimeout, func(ctx context.Context) error {
+		organizationSQLStruct := sqlbuilder.NewStruct(new(sqlOrganization)).For(sqlbuilder.SQLite)
+		query, args := organizationSQLStruct.InsertInto(sqlOrganizationTable, toSQLOrganization(organization)).Build()
+		if _, err := r.connection.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("error trying to persist organization on database: %v", err)
+		}
+
+		return r.insertMembers(ctx, organization)
+	})
+}
+
+// Update saves the quota of the organization and replaces its members in a
+// transaction.
+func (r *OrganizationRepository) Update(ctx context.Context, organization organizationsdomain.Organization) error {
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	return r.connection.Transaction(ctxTimeout, func(ctx context.Context) error {
+		row := toSQLOrganization(organization)
+		ub := sqlbuilder.Update(sqlOrganizationTable)
+		ub.Set(
+			ub.Assign("monthly_extractions", row.MonthlyExtractions),
+			ub.Assign("monthly_tokens", row.MonthlyTokens),
+		)
+		ub.Where(ub.Equal("id", row.ID))
+		ub.SetFlavor(sqlbuilder.SQLite)
+		query, args := ub.Build()
+		if _, err := r.connection.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("error trying to update organization on database: %v", err)
+		}
+
+		if err := r.deleteMembers(ctx, organization.Id); err != nil {
+			return err
+		}
+		return r.insertMembers(ctx, organization)
+	})
+}
+
+// Delete deletes the organization and its members in a transaction, since the
+// foreign keys are not enforced. The extractions of the organization are kept
+// as extractions of the users that made them.
+func (r *OrganizationRepository) Delete(ctx context.Context, id organizationsdomain.OrganizationID) error {
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	return r.connection.Transaction(ctxTimeout, func(ctx context.Context) error {
+		if err := r.deleteMembers(ctx, id); err != nil {
+			return err
+		}
+
+		extractionsUpdate := sqlbuilder.Update(sqlExtractionTable)
+		extractionsUpdate.Set(extractionsUpdate.Assign("organization_id", ""))
+		extractionsUpdate.Where(extractionsUpdate.Equal("organization_id", id.String()))
+		extractionsUpdate.SetFlavor(sqlbuilder.SQLite)
+		query, args := extractionsUpdate.Build()
+		if _, err := r.connection.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("error trying to detach the extractions of the organization on database: %v", err)
+		}
+
+		organizationDelete := sqlbuilder.DeleteFrom(sqlOrganizationTable)
+		organizationDelete.Where(organizationDelete.Equal("id", id.String()))
+		organizationDelete.SetFlavor(sqlbuilder.SQLite)
+		query, args = organizationDelete.Build()
+		if _, err := r.connection.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("error trying to delete organization from database: %v", err)
+		}
+		return nil
+	})
+}
+
+func (r *OrganizationRepository) ExistsByName(ctx context.Context, name organizationsdomain.OrganizationName) (bool, error) {
+	sb := sqlbuilder.Select("1").From(sqlOrganizationTable)
+	sb.Where(sb.Equal("name", name.String()))
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	rows, err := r.connection.Executor(ctxTimeout).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("error trying to check if organization exists on database: %v", err)
+	}
+	defer rows.Close()
+
+	return rows.Next(), nil
+}
+
+func (r *OrganizationRepository) Get(ctx context.Context, id organizationsdomain.OrganizationID) (*organizationsdomain.Organization, error) {
+	sb := sqlbuilder.Select(organizationColumns...).From(sqlOrganizationTable)
+	sb.Where(sb.Equal("id", id.String()))
+	return r.get(ctx, sb)
+}
+
+func (r *OrganizationRepository) GetByName(ctx context.Context, name organizationsdomain.OrganizationName) (*organizationsdomain.Organization, error) {
+	sb := sqlbuilder.Select(organizationColumns...).From(sqlOrganizationTable)
+	sb.Where(sb.Equal("name", name.String()))
+	return r.get(ctx, sb)
+}
+
+func (r *OrganizationRepository) GetByUserID(ctx context.Context, userId organizationsdomain.MemberUserID) ([]organizationsdomain.Organization, error) {
+	members := sqlbuilder.Select("organization_id").From(sqlOrganizationMemberTable)
+	members.Where(members.Equal("user_id", userId.String()))
+
+	sb := sqlbuilder.Select(organizationColumns...).From(sqlOrganizationTable)
+	sb.Where(sb.In("id", members))
+	sb.OrderBy("name").Asc()
+	return r.list(ctx, sb)
+}
+
+func (r *OrganizationRepository) All(ctx context.Context) ([]organizationsdomain.Organization, error) {
+	sb := sqlbuilder.Select(organizationColumns...).From(sqlOrganizationTable)
+	sb.OrderBy("name").Asc()
+	return r.list(ctx, sb)
+}
+
+func (r *OrganizationRepository) get(ctx context.Context, sb *sqlbuilder.SelectBuilder) (*organizationsdomain.Organization, error) {
+	organizationSQLStruct := sqlbuilder.NewStruct(new(sqlOrganization))
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	organization := new(sqlOrganization)
+	err := r.connection.Executor(ctxTimeout).QueryRowContext(ctxTimeout, query, args...).Scan(organizationSQLStruct.Addr(organization)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error trying to get organization from database: %v", err)
+	}
+
+	members, err := r.members(ctxTimeout, organization.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationVO, err := toDomainOrganization(organization, members)
+	return &organizationVO, err
+}
+
+func (r *OrganizationRepository) list(ctx context.Context, sb *sqlbuilder.SelectBuilder) ([]organizationsdomain.Organization, error) {
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	rows, err := r.connection.Executor(ctxTimeout).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to get organizations from database: %v", err)
+	}
+
+	var rowsRead []*sqlOrganization
+	for rows.Next() {
+		organizationSQLStruct := sqlbuilder.NewStruct(new(sqlOrganization))
+		organization := new(sqlOrganization)
+		if err := rows.Scan(organizationSQLStruct.Addr(organization)...); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("error scanning organization row: %v", err)
+		}
+		rowsRead = append(rowsRead, organization)
+	}
+	// Los miembros se leen después de cerrar las filas, porque una transacción
+	// solo tiene una conexión
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading organizations from database: %v", err)
+	}
+
+	organizations := make([]organizationsdomain.Organization, 0, len(rowsRead))
+	for _, organization := range rowsRead {
+		members, err := r.members(ctxTimeout, organization.ID)
+		if err != nil {
+			return nil, err
+		}
+		organizationVO, err := toDomainOrganization(organization, members)
+		if err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, organizationVO)
+	}
+
+	return organizations, nil
+}
+
+// members devuelve los miembros de la organización por orden de entrada.
+func (r *OrganizationRepository) members(ctx context.Context, organizationId string) ([]sqlOrganizationMember, error) {
+	sb := sqlbuilder.Select(memberColumns...).From(sqlOrganizationMemberTable)
+	sb.Where(sb.Equal("organization_id", organizationId))
+	sb.OrderBy("created_at", "user_id").Asc()
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	rows, err := r.connection.Executor(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to get organization members from database: %v", err)
+	}
+	defer rows.Close()
+
+	var members []sqlOrganizationMember
+	for rows.Next() {
+		memberSQLStruct := sqlbuilder.NewStruct(new(sqlOrganizationMember))
+		var member sqlOrganizationMember
+		if err := rows.Scan(memberSQLStruct.Addr(&member)...); err != nil {
+			return nil, fmt.Errorf("error scanning organization member row: %v", err)
+		}
+		members = append(members, member)
+	}
+
+	return members, rows.Err()
+}
+
+func (r *OrganizationRepository) insertMembers(ctx context.Context, organization organizationsdomain.Organization) error {
+	if len(organization.Members) == 0 {
+		return nil
+	}
+
+	rows := make([]interface{}, 0, len(organization.Members))
+	for _, member := range organization.Members {
+		rows = append(rows, sqlOrganizationMember{
+			OrganizationID: organization.Id.String(),
+			UserID:         member.UserId.String(),
+			Role:           member.Role.String(),
+			CreatedAt:      member.CreatedAt.String(),
+		})
+	}
+	memberSQLStruct := sqlbuilder.NewStruct(new(sqlOrganizationMember)).For(sqlbuilder.SQLite)
+	query, args := memberSQLStruct.InsertInto(sqlOrganizationMemberTable, rows...).Build()
+	if _, err := r.connection.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("error trying to persist organization members on database: %v", err)
+	}
+	return nil
+}
+
+func (r *OrganizationRepository) deleteMembers(ctx context.Context, id organizationsdomain.OrganizationID) error {
+	membersDelete := sqlbuilder.DeleteFrom(sqlOrganizationMemberTable)
+	membersDelete.Where(membersDelete.Equal("organization_id", id.String()))
+	membersDelete.SetFlavor(sqlbuilder.SQLite)
+	query, args := membersDelete.Build()
+	if _, err := r.connection.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("error trying to delete organization members from database: %v", err)
+	}
+	return nil
+}
+
+func toSQLOrganization(organization organizationsdomain.Organization) sqlOrganization {
+	return sqlOrganization{
+		ID:                 organization.Id.String(),
+		Name:               organization.Name.String(),
+		MonthlyExtractions: organization.Quota.MonthlyExtractions(),
+		MonthlyTokens:      organization.Quota.MonthlyTokens(),
+		CreatedAt:          organization.CreatedAt.String(),
+	}
+}
+
+func toDomainOrganization(organization *sqlOrganization, members []sqlOrganizationMember) (organizationsdomain.Organization, error) {
+	idVO, err := organizationsdomain.NewOrganizationID(organization.ID)
+	if err != nil {
+		return organizationsdomain.Organization{}, err
+	}
+	nameVO, err := organizationsdomain.NewOrganizationName(organization.Name)
+	if err != nil {
+		return organizationsdomain.Organization{}, err
+	}
+	quotaVO, err := organizationsdomain.NewOrganizationQuota(organization.MonthlyExtractions, organization.MonthlyTokens)
+	if err != nil {
+		return organizationsdomain.Organization{}, err
+	}
+	createdAtVO, err := organizationsdomain.NewOrganizationCreatedAt(organization.CreatedAt)
+	if err != nil {
+		return organizationsdomain.Organization{}, err
+	}
+
+	organizationVO := organizationsdomain.Organization{
+		Id:        idVO,
+		Name:      nameVO,
+		Quota:     quotaVO,
+		Members:   make([]organizationsdomain.Member, 0, len(members)),
+		CreatedAt: createdAtVO,
+	}
+	for _, member := range members {
+		memberVO, err := organizationsdomain.NewMember(member.UserID, member.Role, member.CreatedAt)
+		if err != nil {
+			return organizationsdomain.Organization{}, err
+		}
+		organizationVO.Members = append(organizationVO.Members, memberVO)
+	}
+	return organizationVO, nil
+}
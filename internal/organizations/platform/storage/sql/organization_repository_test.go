@@ -0,0 +1,181 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	organizationID = "5d1c7a9e-3f2b-4e8d-a6c0-9b7e1f3d5a2c"
+	ownerID        = "37a0f027-15e6-47cc-a5d2-64183281087e"
+	editorID       = "4a0c8d2e-6b1f-4c3a-9e5d-7f2b1a0c9d8e"
+	createdAt      = "2024-05-01T10:00:00Z"
+)
+
+func newTestDatabase(t *testing.T) (*storage.Connection, *storage.Dbconfig) {
+	t.Helper()
+	config := storage.Dbconfig{
+		Database: filepath.Join(t.TempDir(), "app"),
+		Timeout:  5 * time.Second,
+	}
+	connection, err := storage.CreateConnection(fmt.Sprintf("test-%s", t.Name()), &config)
+	require.NoError(t, err)
+	t.Cleanup(func() { connection.Db.Close() })
+	require.NoError(t, storage.CreateSchema(context.Background(), connection))
+	return connection, &config
+}
+
+// newTestOrganization devuelve la organización casa con un propietario y un
+// editor.
+func newTestOrganization(t *testing.T) organizationsdomain.Organization {
+	t.Helper()
+	organization, err := organizationsdomain.NewOrganization(organizationID, "casa", ownerID, createdAt)
+	require.NoError(t, err)
+	require.NoError(t, organization.SetMember(editorID, organizationsdomain.MemberRoleEditor, "2024-05-02T10:00:00Z"))
+	return organization
+}
+
+func Test_OrganizationRepository_SaveAndGet(t *testing.T) {
+	ctx := context.Background()
+	connection, config := newTestDatabase(t)
+	repo := NewOrganizationRepository(connection, config)
+	organization := newTestOrganization(t)
+
+	require.NoError(t, repo.Save(ctx, organization))
+
+	got, err := repo.GetByName(ctx, organization.Name)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, organizationID, got.Id.String())
+	assert.Equal(t, createdAt, got.CreatedAt.String())
+	require.Len(t, got.Members, 2)
+	assert.Equal(t, ownerID, got.Members[0].UserId.String())
+	assert.Equal(t, organizationsdomain.MemberRoleOwner, got.Members[0].Role.String())
+	assert.Equal(t, organizationsdomain.MemberRoleEditor, got.Members[1].Role.String())
+
+	got, err = repo.Get(ctx, organization.Id)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "casa", got.Name.String())
+
+	exists, err := repo.ExistsByName(ctx, organization.Name)
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func Test_OrganizationRepository_Get_NotFound(t *testing.T) {
+	ctx := context.Background()
+	connection, config := newTestDatabase(t)
+	repo := NewOrganizationRepository(connection, config)
+	name, err := organizationsdomain.NewOrganizationName("nadie")
+	require.NoError(t, err)
+
+	got, err := repo.GetByName(ctx, name)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	exists, err := repo.ExistsByName(ctx, name)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func Test_OrganizationRepository_Update_ReplacesMembers(t *testing.T) {
+	ctx := context.Background()
+	connection, config := newTestDatabase(t)
+	repo := NewOrganizationRepository(connection, config)
+	organization := newTestOrganization(t)
+	require.NoError(t, repo.Save(ctx, organization))
+
+	require.NoError(t, organization.RemoveMember(editorID))
+	require.NoError(t, organization.SetQuota(100, 5000))
+	require.NoError(t, repo.Update(ctx, organization))
+
+	got, err := repo.Get(ctx, organization.Id)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, 100, got.Quota.MonthlyExtractions())
+	assert.Equal(t, 5000, got.Quota.MonthlyTokens())
+	require.Len(t, got.Members, 1)
+	assert.Equal(t, ownerID, got.Members[0].UserId.String())
+}
+
+func Test_OrganizationRepository_GetByUserID(t *testing.T) {
+	ctx := context.Background()
+	connection, config := newTestDatabase(t)
+	repo := NewOrganizationRepository(connection, config)
+	require.NoError(t, repo.Save(ctx, newTestOrganization(t)))
+	other, err := organizationsdomain.NewOrganization("6e2d8b0f-4a3c-4f9e-b7d1-0c8f2a4e6b3d", "bar", editorID, createdAt)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, other))
+
+	ownerId, err := organizationsdomain.NewMemberUserID(ownerID)
+	require.NoError(t, err)
+	organizations, err := repo.GetByUserID(ctx, ownerId)
+	require.NoError(t, err)
+	require.Len(t, organizations, 1)
+	assert.Equal(t, "casa", organizations[0].Name.String())
+
+	editorId, err := organizationsdomain.NewMemberUserID(editorID)
+	require.NoError(t, err)
+	organizations, err = repo.GetByUserID(ctx, editorId)
+	require.NoError(t, err)
+	require.Len(t, organizations, 2)
+	assert.Equal(t, "bar", organizations[0].Name.String())
+	assert.Equal(t, "casa", organizations[1].Name.String())
+
+	all, err := repo.All(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func Test_OrganizationRepository_Delete_DetachesExtractions(t *testing.T) {
+	ctx := context.Background()
+	connection, config := newTestDatabase(t)
+	repo := NewOrganizationRepository(connection, config)
+	organization := newTestOrganization(t)
+	require.NoError(t, repo.Save(ctx, organization))
+	_, err := connection.Db.ExecContext(ctx, "INSERT INTO recipe_extractions (id, user_id, organization_id, metadata) VALUES (?, ?, ?, ?)",
+		"0b6f6a53-2a9e-4f7c-9d54-3b1c2f7a9e10", editorID, organizationID, "{}")
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Delete(ctx, organization.Id))
+
+	got, err := repo.Get(ctx, organization.Id)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	var members int
+	require.NoError(t, connection.Db.QueryRowContext(ctx, "SELECT COUNT(*) FROM organization_members").Scan(&members))
+	assert.Zero(t, members)
+	var extractionOrganization, extractionUser string
+	require.NoError(t, connection.Db.QueryRowContext(ctx, "SELECT organization_id, user_id FROM recipe_extractions").Scan(&extractionOrganization, &extractionUser))
+	assert.Empty(t, extractionOrganization)
+	assert.Equal(t, editorID, extractionUser)
+}
+
+func Test_OrganizationRepository_Save_RepositoryError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(
+		"INSERT INTO organizations (id, name, monthly_extractions, monthly_tokens, created_at) VALUES (?, ?, ?, ?, ?)").
+		WithArgs(organizationID, "casa", 0, 0, createdAt).
+		WillReturnError(errors.New("something-failed"))
+	sqlMock.ExpectRollback()
+
+	repo := NewOrganizationRepository(&storage.Connection{Db: db}, &storage.Dbconfig{Timeout: time.Second})
+
+	err = repo.Save(context.Background(), newTestOrganization(t))
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
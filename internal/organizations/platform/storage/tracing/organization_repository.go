@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	sharedtracing "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+)
+
+// OrganizationRepository decorates an organizationsdomain.OrganizationRepository with a
+// span for every call.
+type OrganizationRepository struct {
+	next organizationsdomain.OrganizationRepository
+}
+
+// NewOrganizationRepository returns an OrganizationRepository that traces the calls to
+// next.
+func NewOrganizationRepository(next organizationsdomain.OrganizationRepository) *OrganizationRepository {
+	return &OrganizationRepository{
+		next: next,
+	}
+}
+
+// Save implements the organizationsdomain.OrganizationRepository interface.
+func (r *OrganizationRepository) Save(ctx context.Context, organization organizationsdomain.Organization) error {
+	return sharedtracing.Run(ctx, "organizations.repository.Save", func(ctx context.Context) error {
+		return r.next.Save(ctx, organization)
+	})
+}
+
+// Update implements the organizationsdomain.OrganizationRepository interface.
+func (r *OrganizationRepository) Update(ctx context.Context, organization organizationsdomain.Organization) error {
+	return sharedtracing.Run(ctx, "organizations.repository.Update", func(ctx context.Context) error {
+		return r.next.Update(ctx, organization)
+	})
+}
+
+// Delete implements the organizationsdomain.OrganizationRepository interface.
+func (r *OrganizationRepository) Delete(ctx context.Context, id organizationsdomain.OrganizationID) error {
+	return sharedtracing.Run(ctx, "organizations.repository.Delete", func(ctx context.Context) error {
+		return r.next.Delete(ctx, id)
+	})
+}
+
+// ExistsByName implements the organizationsdomain.OrganizationRepository interface.
+func (r *OrganizationRepository) ExistsByName(ctx context.Context, name organizationsdomain.OrganizationName) (bool, error) {
+	return sharedtracing.Call(ctx, "organizations.repository.ExistsByName", func(ctx context.Context) (bool, error) {
+		return r.next.ExistsByName(ctx, name)
+	})
+}
+
+// Get implements the organizationsdomain.OrganizationRepository interface.
+func (r *OrganizationRepository) Get(ctx context.Context, id organizationsdomain.OrganizationID) (*organizationsdomain.Organization, error) {
+	return sharedtracing.Call(ctx, "organizations.repository.Get", func(ctx context.Context) (*organizationsdomain.Organization, error) {
+		return r.next.Get(ctx, id)
+	})
+}
+
+// GetByName implements the organizationsdomain.OrganizationRepository interface.
+func (r *OrganizationRepository) GetByName(ctx context.Context, name organizationsdomain.OrganizationName) (*organizationsdomain.Organization, error) {
+	return sharedtracing.Call(ctx, "organizations.repository.GetByName", func(ctx context.Context) (*organizationsdomain.Organization, error) {
+		return r.next.GetByName(ctx, name)
+	})
+}
+
+// GetByUserID implements the organizationsdomain.OrganizationRepository interface.
+func (r *OrganizationRepository) GetByUserID(ctx context.Context, userId organizationsdomain.MemberUserID) ([]organizationsdomain.Organization, error) {
+	return sharedtracing.Call(ctx, "organizations.repository.GetByUserID", func(ctx context.Context) ([]organizationsdomain.Organization, error) {
+		return r.next.GetByUserID(ctx, userId)
+	})
+}
+
+// All implements the organizationsdomain.OrganizationRepository interface.
+func (r *OrganizationRepository) All(ctx context.Context) ([]organizationsdomain.Organization, error) {
+	return sharedtracing.Call(ctx, "organizations.repository.All", func(ctx context.Context) ([]organizationsdomain.Organization, error) {
+		return r.next.All(ctx)
+	})
+}
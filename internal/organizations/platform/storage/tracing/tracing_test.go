@@ -0,0 +1,36 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/organizations/platform/storage/storagemocks"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing/tracingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func Test_OrganizationRepository_TracesCalls(t *testing.T) {
+	exporter := tracingtest.Record(t)
+	name, err := organizationsdomain.NewOrganizationName("casa")
+	require.NoError(t, err)
+
+	next := new(storagemocks.OrganizationRepository)
+	next.On("ExistsByName", mock.Anything, name).Return(true, nil)
+	next.On("All", mock.Anything).Return(nil, errors.New("something unexpected happened"))
+	repo := NewOrganizationRepository(next)
+
+	exists, err := repo.ExistsByName(context.Background(), name)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	_, err = repo.All(context.Background())
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"organizations.repository.ExistsByName", "organizations.repository.All"}, tracingtest.Names(exporter))
+	assert.Equal(t, codes.Error, tracingtest.Span(t, exporter, "organizations.repository.All").Status.Code)
+	next.AssertExpectations(t)
+}
@@ -0,0 +1,52 @@
+package cleanup
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const FilesCommandType command.Type = "command.extraction.cleanupfiles"
+
+type FilesCommand struct {
+	userId string
+}
+
+func NewFilesCommand(userId string) FilesCommand {
+	return FilesCommand{
+		userId: userId,
+	}
+}
+
+func (c FilesCommand) Type() command.Type {
+	return FilesCommandType
+}
+
+// NonTransactional keeps the command out of the bus transaction: it only
+// reads the extractions and waits on the provider.
+func (c FilesCommand) NonTransactional() {}
+
+type FilesCommandHandler struct {
+	service FilesService
+}
+
+func NewFilesCommandHandler(service FilesService) FilesCommandHandler {
+	return FilesCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h FilesCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	filesCmd, ok := cmd.(FilesCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.CleanUserFiles(ctx, filesCmd.userId)
+}
+
+func (h FilesCommandHandler) SubscribedTo() command.Type {
+	return FilesCommandType
+}
@@ -0,0 +1,44 @@
+package cleanup
+
+import (
+	"context"
+	"errors"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+)
+
+// FilesService deletes the files left behind by the extractions of a user,
+// before the user is deleted.
+type FilesService struct {
+	extractionRepository recipesdomain.ExtractionRepository
+	cleaner              recipesdomain.FileCleaner
+}
+
+func NewFilesService(extractionRepository recipesdomain.ExtractionRepository, cleaner recipesdomain.FileCleaner) FilesService {
+	return FilesService{
+		extractionRepository: extractionRepository,
+		cleaner:              cleaner,
+	}
+}
+
+// CleanUserFiles cleans the files of every extraction of the user, even if
+// some of them fail, and returns the errors joined.
+func (s FilesService) CleanUserFiles(ctx context.Context, userId string) error {
+	userID, err := recipesdomain.NewExtractionUserID(userId)
+	if err != nil {
+		return err
+	}
+
+	extractions, err := s.extractionRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, extraction := range extractions {
+		if err := s.cleaner.Clean(ctx, extraction); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
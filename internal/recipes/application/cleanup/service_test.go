@@ -0,0 +1,48 @@
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/files/filesmocks"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FilesService_CleanUserFiles_RepositoryError(t *testing.T) {
+	repo := new(storagemocks.ExtractionRepository)
+	repo.On("GetByUserID", mock.Anything, mock.Anything).Return(nil, errors.New("something unexpected happened"))
+	cleaner := new(filesmocks.FileCleaner)
+
+	service := NewFilesService(repo, cleaner)
+	err := service.CleanUserFiles(context.Background(), "37a0f027-15e6-47cc-a5d2-64183281087e")
+
+	repo.AssertExpectations(t)
+	cleaner.AssertExpectations(t)
+	assert.Error(t, err)
+}
+
+func Test_FilesService_CleanUserFiles_CleansEveryExtraction(t *testing.T) {
+	userID := "37a0f027-15e6-47cc-a5d2-64183281087e"
+	first, err := recipesdomain.NewExtraction("8c5e5d2b-8d0a-4c0b-9f0a-6a6f4c1d2e3f", userID, "https://example.com/a", recipesdomain.ExtractionStatusSucceeded, "", "", "{}", "{}", "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+	second, err := recipesdomain.NewExtraction("9d6f6e3c-9e1b-4d1c-8a1b-7b7f5d2e3f4a", userID, "https://example.com/b", recipesdomain.ExtractionStatusSucceeded, "", "", "{}", "{}", "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+
+	repo := new(storagemocks.ExtractionRepository)
+	repo.On("GetByUserID", mock.Anything, first.UserId).Return([]recipesdomain.Extraction{first, second}, nil)
+	cleaner := new(filesmocks.FileCleaner)
+	cleaner.On("Clean", mock.Anything, mock.MatchedBy(func(e recipesdomain.Extraction) bool { return e.Id == first.Id })).Return(errors.New("permission denied"))
+	cleaner.On("Clean", mock.Anything, mock.MatchedBy(func(e recipesdomain.Extraction) bool { return e.Id == second.Id })).Return(nil)
+
+	service := NewFilesService(repo, cleaner)
+	err = service.CleanUserFiles(context.Background(), userID)
+
+	repo.AssertExpectations(t)
+	cleaner.AssertExpectations(t)
+	assert.ErrorContains(t, err, "permission denied")
+}
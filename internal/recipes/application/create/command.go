@@ -12,15 +12,27 @@ const ExtractionCommandType command.Type = "command.extraction.create"
 type ExtractionCommand struct {
 	id        string
 	userId    string
+	organizationId string
+	sourceUrl string
+	status    string
+	errorCategory string
+	errorMessage  string
 	data      string
 	metadata  string
 	createdAt string
 }
 
-func NewExtractionCommand(id, userId, data, metadata, createdAt string) ExtractionCommand {
+// NewExtractionCommand returns the command that records an extraction of the
+// user. organizationId is the organization whose library holds it, or empty.
+func NewExtractionCommand(id, userId, organizationId, sourceUrl, status, errorCategory, errorMessage, data, metadata, createdAt string) ExtractionCommand {
 	return ExtractionCommand{
 		id:        id,
 		userId:    userId,
+		organizationId: organizationId,
+		sourceUrl: sourceUrl,
+		status:    status,
+		errorCategory: errorCategory,
+		errorMessage:  errorMessage,
 		data:      data,
 		metadata:  metadata,
 		createdAt: createdAt,
@@ -52,6 +64,11 @@ func (h ExtractionCommandHandler) Handle(ctx context.Context, cmd command.Comman
 		ctx,
 		createExtractionCmd.id,
     createExtractionCmd.userId,
+    createExtractionCmd.organizationId,
+    createExtractionCmd.sourceUrl,
+    createExtractionCmd.status,
+    createExtractionCmd.errorCategory,
+    createExtractionCmd.errorMessage,
     createExtractionCmd.data,
     createExtractionCmd.metadata,
     createExtractionCmd.createdAt,
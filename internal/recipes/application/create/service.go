@@ -4,22 +4,22 @@ import (
 	"context"
 
 	extractionsdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
-	"github.com/rubenbupe/recipe-video-parser/kit/event"
 )
 
 type ExtractionService struct {
 	extractionRepository extractionsdomain.ExtractionRepository
-	eventBus       event.Bus
 }
 
-func NewExtractionService(extractionRepository extractionsdomain.ExtractionRepository, eventBus event.Bus) ExtractionService {
+// NewExtractionService returns the service that creates extractions. The
+// repository stores the recorded events in the outbox, from where they are
+// relayed to the event bus.
+func NewExtractionService(extractionRepository extractionsdomain.ExtractionRepository) ExtractionService {
 	return ExtractionService{
 		extractionRepository: extractionRepository,
-		eventBus:       eventBus,
 	}
 }
 
-func (s ExtractionService) CreateExtraction(ctx context.Context, id, userId, data, metadata, createdAt string) error {
+func (s ExtractionService) CreateExtraction(ctx context.Context, id, userId, organizationId, sourceUrl, status, errorCategory, errorMessage, data, metadata, createdAt string) error {
 	extractionId, err := extractionsdomain.NewExtractionID(id)
 	if err != nil {
 		return err
@@ -35,14 +35,13 @@ func (s ExtractionService) CreateExtraction(ctx context.Context, id, userId, dat
 		return extractionsdomain.ErrExtractionAlreadyExists
 	}
 
-	extraction, err := extractionsdomain.NewExtraction(id, userId, data, metadata, createdAt)
+	extraction, err := extractionsdomain.NewExtraction(id, userId, sourceUrl, status, errorCategory, errorMessage, data, metadata, createdAt)
 	if err != nil {
 		return err
 	}
-
-	if err := s.extractionRepository.Save(ctx, extraction); err != nil {
+	if err := extraction.ShareWith(organizationId); err != nil {
 		return err
 	}
 
-	return s.eventBus.Publish(ctx, extraction.PullEvents())
+	return s.extractionRepository.Save(ctx, extraction)
 }
@@ -7,8 +7,6 @@ import (
 
 	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
 	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/storagemocks"
-	"github.com/rubenbupe/recipe-video-parser/kit/event"
-	"github.com/rubenbupe/recipe-video-parser/kit/event/eventmocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -16,6 +14,8 @@ import (
 func Test_ExtractionService_CreateExtraction_RepositoryError(t *testing.T) {
 	extractionID := "37a0f027-15e6-47cc-a5d2-64183281087e"
 	userID := "37a0f027-15e6-47cc-a5d2-64183281087e"
+	sourceUrl := "https://example.com/video"
+	status := recipesdomain.ExtractionStatusSucceeded
 	data := "{\"field\":\"value\"}"
 	metadata := "{\"meta\":\"value\"}"
 	createdAt := "2023-10-01T00:00:00Z"
@@ -24,84 +24,93 @@ func Test_ExtractionService_CreateExtraction_RepositoryError(t *testing.T) {
 	extractionRepositoryMock.On("Exists", mock.Anything, mock.Anything).Return(false, nil)
 	extractionRepositoryMock.On("Save", mock.Anything, mock.Anything).Return(errors.New("something unexpected happened"))
 
-	eventBusMock := new(eventmocks.Bus)
+	extractionService := NewExtractionService(extractionRepositoryMock)
 
-	extractionService := NewExtractionService(extractionRepositoryMock, eventBusMock)
-
-	err := extractionService.CreateExtraction(context.Background(), extractionID, userID, data, metadata, createdAt)
+	err := extractionService.CreateExtraction(context.Background(), extractionID, userID, "", sourceUrl, status, "", "", data, metadata, createdAt)
 
 	extractionRepositoryMock.AssertExpectations(t)
-	eventBusMock.AssertExpectations(t)
 	assert.Error(t, err)
 }
 
-func Test_ExtractionService_CreateExtraction_EventsBusError(t *testing.T) {
+func Test_ExtractionService_CreateExtraction_Succeed(t *testing.T) {
 	extractionID := "37a0f027-15e6-47cc-a5d2-64183281087e"
 	userID := "37a0f027-15e6-47cc-a5d2-64183281087e"
+	sourceUrl := "https://example.com/video"
+	status := recipesdomain.ExtractionStatusSucceeded
 	data := "{\"field\":\"value\"}"
 	metadata := "{\"meta\":\"value\"}"
 	createdAt := "2023-10-01T00:00:00Z"
 
 	extractionRepositoryMock := new(storagemocks.ExtractionRepository)
 	extractionRepositoryMock.On("Exists", mock.Anything, mock.Anything).Return(false, nil)
-	extractionRepositoryMock.On("Save", mock.Anything, mock.Anything).Return(nil)
-
-	eventBusMock := new(eventmocks.Bus)
-	eventBusMock.On("Publish", mock.Anything, mock.AnythingOfType("[]event.Event")).Return(errors.New("something unexpected happened"))
+	extractionRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(extraction recipesdomain.Extraction) bool {
+		events := extraction.PullEvents()
+		if len(events) != 1 {
+			return false
+		}
+		evt, ok := events[0].(recipesdomain.ExtractionCreatedEvent)
+		return ok && evt.ExtractionSourceUrl() == sourceUrl
+	})).Return(nil)
 
-	extractionService := NewExtractionService(extractionRepositoryMock, eventBusMock)
+	extractionService := NewExtractionService(extractionRepositoryMock)
 
-	err := extractionService.CreateExtraction(context.Background(), extractionID, userID, data, metadata, createdAt)
+	err := extractionService.CreateExtraction(context.Background(), extractionID, userID, "", sourceUrl, status, "", "", data, metadata, createdAt)
 
 	extractionRepositoryMock.AssertExpectations(t)
-	eventBusMock.AssertExpectations(t)
-	assert.Error(t, err)
+	assert.NoError(t, err)
 }
 
-func Test_ExtractionService_CreateExtraction_Succeed(t *testing.T) {
+func Test_ExtractionService_CreateExtraction_AlreadyExists(t *testing.T) {
 	extractionID := "37a0f027-15e6-47cc-a5d2-64183281087e"
 	userID := "37a0f027-15e6-47cc-a5d2-64183281087e"
+	sourceUrl := "https://example.com/video"
+	status := recipesdomain.ExtractionStatusSucceeded
 	data := "{\"field\":\"value\"}"
 	metadata := "{\"meta\":\"value\"}"
 	createdAt := "2023-10-01T00:00:00Z"
 
 	extractionRepositoryMock := new(storagemocks.ExtractionRepository)
-	extractionRepositoryMock.On("Exists", mock.Anything, mock.Anything).Return(false, nil)
-	extractionRepositoryMock.On("Save", mock.Anything, mock.Anything).Return(nil)
+	extractionRepositoryMock.On("Exists", mock.Anything, mock.Anything).Return(true, nil)
+
+	extractionService := NewExtractionService(extractionRepositoryMock)
+
+	err := extractionService.CreateExtraction(context.Background(), extractionID, userID, "", sourceUrl, status, "", "", data, metadata, createdAt)
 
-	eventBusMock := new(eventmocks.Bus)
-	eventBusMock.On("Publish", mock.Anything, mock.MatchedBy(func(events []event.Event) bool {
-		return len(events) > 0
+	extractionRepositoryMock.AssertExpectations(t)
+	assert.Error(t, err)
+	assert.Equal(t, err, recipesdomain.ErrExtractionAlreadyExists)
+}
+
+func Test_ExtractionService_CreateExtraction_SharedWithOrganization(t *testing.T) {
+	extractionID := "37a0f027-15e6-47cc-a5d2-64183281087e"
+	userID := "37a0f027-15e6-47cc-a5d2-64183281087e"
+	organizationID := "5d1c7a9e-3f2b-4e8d-a6c0-9b7e1f3d5a2c"
+
+	extractionRepositoryMock := new(storagemocks.ExtractionRepository)
+	extractionRepositoryMock.On("Exists", mock.Anything, mock.Anything).Return(false, nil)
+	extractionRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(extraction recipesdomain.Extraction) bool {
+		return extraction.OrganizationId.String() == organizationID
 	})).Return(nil)
-	eventBusMock.On("Publish", mock.Anything, mock.AnythingOfType("[]event.Event")).Return(nil)
 
-	extractionService := NewExtractionService(extractionRepositoryMock, eventBusMock)
+	extractionService := NewExtractionService(extractionRepositoryMock)
 
-	err := extractionService.CreateExtraction(context.Background(), extractionID, userID, data, metadata, createdAt)
+	err := extractionService.CreateExtraction(context.Background(), extractionID, userID, organizationID, "https://example.com/video", recipesdomain.ExtractionStatusSucceeded, "", "", "{\"field\":\"value\"}", "{\"meta\":\"value\"}", "2023-10-01T00:00:00Z")
 
 	extractionRepositoryMock.AssertExpectations(t)
-	eventBusMock.AssertExpectations(t)
 	assert.NoError(t, err)
 }
 
-func Test_ExtractionService_CreateExtraction_AlreadyExists(t *testing.T) {
+func Test_ExtractionService_CreateExtraction_InvalidOrganization(t *testing.T) {
 	extractionID := "37a0f027-15e6-47cc-a5d2-64183281087e"
 	userID := "37a0f027-15e6-47cc-a5d2-64183281087e"
-	data := "{\"field\":\"value\"}"
-	metadata := "{\"meta\":\"value\"}"
-	createdAt := "2023-10-01T00:00:00Z"
 
 	extractionRepositoryMock := new(storagemocks.ExtractionRepository)
-	extractionRepositoryMock.On("Exists", mock.Anything, mock.Anything).Return(true, nil)
-
-	eventBusMock := new(eventmocks.Bus)
+	extractionRepositoryMock.On("Exists", mock.Anything, mock.Anything).Return(false, nil)
 
-	extractionService := NewExtractionService(extractionRepositoryMock, eventBusMock)
+	extractionService := NewExtractionService(extractionRepositoryMock)
 
-	err := extractionService.CreateExtraction(context.Background(), extractionID, userID, data, metadata, createdAt)
+	err := extractionService.CreateExtraction(context.Background(), extractionID, userID, "casa", "https://example.com/video", recipesdomain.ExtractionStatusSucceeded, "", "", "{\"field\":\"value\"}", "{\"meta\":\"value\"}", "2023-10-01T00:00:00Z")
 
 	extractionRepositoryMock.AssertExpectations(t)
-	eventBusMock.AssertExpectations(t)
-	assert.Error(t, err)
-	assert.Equal(t, err, recipesdomain.ErrExtractionAlreadyExists)
+	assert.ErrorIs(t, err, recipesdomain.ErrInvalidExtractionOrganizationID)
 }
@@ -10,15 +10,26 @@ import (
 const ExtractionQueryType query.Type = "query.extraction.get"
 
 type ExtractionQuery struct {
-	userId string
+	userId         string
+	organizationId string
 }
 
+// NewExtractionQuery returns the query of all the extractions of the user,
+// also the ones in the libraries of their organizations.
 func NewExtractionQuery(userId string) ExtractionQuery {
 	return ExtractionQuery{
 		userId: userId,
 	}
 }
 
+// NewOrganizationExtractionQuery returns the query of the library of the
+// organization.
+func NewOrganizationExtractionQuery(organizationId string) ExtractionQuery {
+	return ExtractionQuery{
+		organizationId: organizationId,
+	}
+}
+
 func (c ExtractionQuery) Type() query.Type {
 	return ExtractionQueryType
 }
@@ -40,6 +51,10 @@ func (h ExtractionQueryHandler) Handle(ctx context.Context, cmd query.Query) (in
 		return nil, errors.New("unexpected query")
 	}
 
+	if getExtractionCmd.organizationId != "" {
+		return h.service.GetOrganizationExtractions(ctx, getExtractionCmd.organizationId)
+	}
+
 	return h.service.GetExtraction(
 		ctx,
 		getExtractionCmd.userId,
@@ -29,3 +29,12 @@ func (s ExtractionService) GetExtraction(ctx context.Context, userId string) ([]
 
 	return extraction, nil
 }
+
+func (s ExtractionService) GetOrganizationExtractions(ctx context.Context, organizationId string) ([]extractionsdomain.Extraction, error) {
+	organizationID, err := extractionsdomain.NewExtractionOrganizationID(organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.extractionRepository.GetByOrganizationID(ctx, organizationID)
+}
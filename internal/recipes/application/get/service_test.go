@@ -32,7 +32,7 @@ func Test_ExtractionService_GetExtraction_Succeed(t *testing.T) {
 	metadata := "{\"meta\":\"value\"}"
 	createdAt := "2023-10-01T00:00:00Z"
 
-	extraction, err := recipesdomain.NewExtraction(id, userID, data, metadata, createdAt)
+	extraction, err := recipesdomain.NewExtraction(id, userID, "https://example.com/video", recipesdomain.ExtractionStatusSucceeded, "", "", data, metadata, createdAt)
 	assert.NoError(t, err)
 
 	extractionRepositoryMock := new(storagemocks.ExtractionRepository)
@@ -47,3 +47,22 @@ func Test_ExtractionService_GetExtraction_Succeed(t *testing.T) {
 	extractionRepositoryMock.AssertExpectations(t)
 	assert.NoError(t, err)
 }
+
+func Test_ExtractionService_GetOrganizationExtractions_Succeed(t *testing.T) {
+	organizationID := "5d1c7a9e-3f2b-4e8d-a6c0-9b7e1f3d5a2c"
+
+	extraction, err := recipesdomain.NewExtraction("37a0f027-15e6-47cc-a5d2-64183281087e", "4a0c8d2e-6b1f-4c3a-9e5d-7f2b1a0c9d8e", "https://example.com/video", recipesdomain.ExtractionStatusSucceeded, "", "", "{\"field\":\"value\"}", "{\"meta\":\"value\"}", "2023-10-01T00:00:00Z")
+	assert.NoError(t, err)
+	assert.NoError(t, extraction.ShareWith(organizationID))
+
+	extractionRepositoryMock := new(storagemocks.ExtractionRepository)
+	extractionRepositoryMock.On("GetByOrganizationID", mock.Anything, extraction.OrganizationId).Return([]recipesdomain.Extraction{extraction}, nil)
+
+	extractionService := NewExtractionService(extractionRepositoryMock)
+
+	foundExtractions, err := extractionService.GetOrganizationExtractions(context.Background(), organizationID)
+
+	assert.Equal(t, 1, len(foundExtractions))
+	extractionRepositoryMock.AssertExpectations(t)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,43 @@
+package listprices
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+const ModelPricesQueryType query.Type = "query.modelprice.list"
+
+type ModelPricesQuery struct{}
+
+func NewModelPricesQuery() ModelPricesQuery {
+	return ModelPricesQuery{}
+}
+
+func (c ModelPricesQuery) Type() query.Type {
+	return ModelPricesQueryType
+}
+
+type ModelPricesQueryHandler struct {
+	service ModelPricesService
+}
+
+func NewModelPricesQueryHandler(service ModelPricesService) ModelPricesQueryHandler {
+	return ModelPricesQueryHandler{
+		service: service,
+	}
+}
+
+// Handle implements the query.Handler interface.
+func (h ModelPricesQueryHandler) Handle(ctx context.Context, qry query.Query) (interface{}, error) {
+	if _, ok := qry.(ModelPricesQuery); !ok {
+		return nil, errors.New("unexpected query")
+	}
+
+	return h.service.GetPrices(ctx)
+}
+
+func (h ModelPricesQueryHandler) SubscribedTo() query.Type {
+	return ModelPricesQueryType
+}
@@ -0,0 +1,23 @@
+package listprices
+
+import (
+	"context"
+
+	extractionsdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+)
+
+type ModelPricesService struct {
+	modelPriceRepository extractionsdomain.ModelPriceRepository
+}
+
+func NewModelPricesService(modelPriceRepository extractionsdomain.ModelPriceRepository) ModelPricesService {
+	return ModelPricesService{
+		modelPriceRepository: modelPriceRepository,
+	}
+}
+
+// GetPrices returns every price of every model, the current and the past
+// ones, sorted by provider, model and day.
+func (s ModelPricesService) GetPrices(ctx context.Context) ([]extractionsdomain.ModelPrice, error) {
+	return s.modelPriceRepository.All(ctx)
+}
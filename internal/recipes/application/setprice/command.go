@@ -0,0 +1,63 @@
+package setprice
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const ModelPriceCommandType command.Type = "command.modelprice.set"
+
+type ModelPriceCommand struct {
+	provider      string
+	model         string
+	inputPrice    float64
+	outputPrice   float64
+	effectiveFrom string
+}
+
+func NewModelPriceCommand(provider, model string, inputPrice, outputPrice float64, effectiveFrom string) ModelPriceCommand {
+	return ModelPriceCommand{
+		provider:      provider,
+		model:         model,
+		inputPrice:    inputPrice,
+		outputPrice:   outputPrice,
+		effectiveFrom: effectiveFrom,
+	}
+}
+
+func (c ModelPriceCommand) Type() command.Type {
+	return ModelPriceCommandType
+}
+
+type ModelPriceCommandHandler struct {
+	service ModelPriceService
+}
+
+func NewModelPriceCommandHandler(service ModelPriceService) ModelPriceCommandHandler {
+	return ModelPriceCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h ModelPriceCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	setPriceCmd, ok := cmd.(ModelPriceCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.SetPrice(
+		ctx,
+		setPriceCmd.provider,
+		setPriceCmd.model,
+		setPriceCmd.inputPrice,
+		setPriceCmd.outputPrice,
+		setPriceCmd.effectiveFrom,
+	)
+}
+
+func (h ModelPriceCommandHandler) SubscribedTo() command.Type {
+	return ModelPriceCommandType
+}
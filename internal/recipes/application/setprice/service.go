@@ -0,0 +1,28 @@
+package setprice
+
+import (
+	"context"
+
+	extractionsdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+)
+
+type ModelPriceService struct {
+	modelPriceRepository extractionsdomain.ModelPriceRepository
+}
+
+func NewModelPriceService(modelPriceRepository extractionsdomain.ModelPriceRepository) ModelPriceService {
+	return ModelPriceService{
+		modelPriceRepository: modelPriceRepository,
+	}
+}
+
+// SetPrice sets the price per million tokens of the model from the given
+// day on. The extractions made before keep their previous price.
+func (s ModelPriceService) SetPrice(ctx context.Context, provider, model string, inputPrice, outputPrice float64, effectiveFrom string) error {
+	price, err := extractionsdomain.NewModelPrice(provider, model, inputPrice, outputPrice, effectiveFrom)
+	if err != nil {
+		return err
+	}
+
+	return s.modelPriceRepository.Save(ctx, price)
+}
@@ -0,0 +1,63 @@
+package setprice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ModelPriceService_SetPrice_Succeed(t *testing.T) {
+	price, err := recipesdomain.NewModelPrice("google", "gemini-2.0-flash", 0.1, 0.4, "2024-05-01")
+	require.NoError(t, err)
+
+	modelPriceRepositoryMock := new(storagemocks.ModelPriceRepository)
+	modelPriceRepositoryMock.On("Save", mock.Anything, price).Return(nil)
+
+	modelPriceService := NewModelPriceService(modelPriceRepositoryMock)
+
+	err = modelPriceService.SetPrice(context.Background(), "google", "gemini-2.0-flash", 0.1, 0.4, "2024-05-01")
+
+	modelPriceRepositoryMock.AssertExpectations(t)
+	assert.NoError(t, err)
+}
+
+func Test_ModelPriceService_SetPrice_InvalidPrice(t *testing.T) {
+	modelPriceRepositoryMock := new(storagemocks.ModelPriceRepository)
+
+	modelPriceService := NewModelPriceService(modelPriceRepositoryMock)
+
+	for name, price := range map[string]struct {
+		model         string
+		inputPrice    float64
+		effectiveFrom string
+	}{
+		"negative price": {"gemini-2.0-flash", -1, "2024-05-01"},
+		"invalid day":    {"gemini-2.0-flash", 0.1, "01/05/2024"},
+		"unknown model":  {recipesdomain.UnknownModel, 0.1, "2024-05-01"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := modelPriceService.SetPrice(context.Background(), "google", price.model, price.inputPrice, 0.4, price.effectiveFrom)
+
+			assert.ErrorIs(t, err, recipesdomain.ErrInvalidModelPrice)
+		})
+	}
+	modelPriceRepositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func Test_ModelPriceService_SetPrice_RepositoryError(t *testing.T) {
+	modelPriceRepositoryMock := new(storagemocks.ModelPriceRepository)
+	modelPriceRepositoryMock.On("Save", mock.Anything, mock.Anything).Return(errors.New("something unexpected happened"))
+
+	modelPriceService := NewModelPriceService(modelPriceRepositoryMock)
+
+	err := modelPriceService.SetPrice(context.Background(), "google", "gemini-2.0-flash", 0.1, 0.4, "2024-05-01")
+
+	modelPriceRepositoryMock.AssertExpectations(t)
+	assert.Error(t, err)
+}
@@ -0,0 +1,66 @@
+package usage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+const ReportQueryType query.Type = "query.extraction.usage"
+
+type ReportQuery struct {
+	userId string
+	group  string
+	sort   string
+	from   string
+	to     string
+}
+
+// NewReportQuery returns the query of the usage report of the user, or of all
+// the users if userId is empty, between two days (2006-01-02) that may be
+// empty.
+func NewReportQuery(userId, group, sort, from, to string) ReportQuery {
+	return ReportQuery{
+		userId: userId,
+		group:  group,
+		sort:   sort,
+		from:   from,
+		to:     to,
+	}
+}
+
+func (c ReportQuery) Type() query.Type {
+	return ReportQueryType
+}
+
+type ReportQueryHandler struct {
+	service ReportService
+}
+
+func NewReportQueryHandler(service ReportService) ReportQueryHandler {
+	return ReportQueryHandler{
+		service: service,
+	}
+}
+
+// Handle implements the query.Handler interface.
+func (h ReportQueryHandler) Handle(ctx context.Context, qry query.Query) (interface{}, error) {
+	reportQuery, ok := qry.(ReportQuery)
+	if !ok {
+		return nil, errors.New("unexpected query")
+	}
+
+	return h.service.Report(
+		ctx,
+		reportQuery.userId,
+		reportQuery.group,
+		reportQuery.sort,
+		reportQuery.from,
+		reportQuery.to,
+	)
+}
+
+func (h ReportQueryHandler) SubscribedTo() query.Type {
+	return ReportQueryType
+}
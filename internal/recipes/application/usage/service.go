@@ -0,0 +1,44 @@
+package usage
+
+import (
+	"context"
+
+	extractionsdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+)
+
+type ReportService struct {
+	usageRepository extractionsdomain.UsageRepository
+}
+
+func NewReportService(usageRepository extractionsdomain.UsageRepository) ReportService {
+	return ReportService{
+		usageRepository: usageRepository,
+	}
+}
+
+// Report aggregates the usage and the cost of the extractions by group. The
+// group defaults to the month and the order to the key.
+func (s ReportService) Report(ctx context.Context, userId, group, sort, from, to string) ([]extractionsdomain.UsageRow, error) {
+	if group == "" {
+		group = string(extractionsdomain.UsageByMonth)
+	}
+	groupVO, err := extractionsdomain.NewUsageGroup(group)
+	if err != nil {
+		return nil, err
+	}
+
+	if sort == "" {
+		sort = string(extractionsdomain.UsageSortKey)
+	}
+	sortVO, err := extractionsdomain.NewUsageSort(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := extractionsdomain.NewUsageFilter(userId, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.usageRepository.Report(ctx, filter, groupVO, sortVO)
+}
@@ -0,0 +1,79 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const userID = "37a0f027-15e6-47cc-a5d2-64183281087e"
+
+func Test_ReportService_Report_Succeed(t *testing.T) {
+	report := []recipesdomain.UsageRow{{Key: "2024-05", Extractions: 2, TotalTokens: 1100, Cost: 0.2}}
+	filter := recipesdomain.UsageFilter{UserId: userID, From: "2024-05-01", To: "2024-05-31"}
+
+	usageRepositoryMock := new(storagemocks.UsageRepository)
+	usageRepositoryMock.On("Report", mock.Anything, filter, recipesdomain.UsageByModel, recipesdomain.UsageSortCost).Return(report, nil)
+
+	reportService := NewReportService(usageRepositoryMock)
+
+	result, err := reportService.Report(context.Background(), userID, "model", "cost", "2024-05-01", "2024-05-31")
+
+	usageRepositoryMock.AssertExpectations(t)
+	assert.NoError(t, err)
+	assert.Equal(t, report, result)
+}
+
+func Test_ReportService_Report_Defaults(t *testing.T) {
+	usageRepositoryMock := new(storagemocks.UsageRepository)
+	usageRepositoryMock.On("Report", mock.Anything, recipesdomain.UsageFilter{}, recipesdomain.UsageByMonth, recipesdomain.UsageSortKey).Return(nil, nil)
+
+	reportService := NewReportService(usageRepositoryMock)
+
+	_, err := reportService.Report(context.Background(), "", "", "", "", "")
+
+	usageRepositoryMock.AssertExpectations(t)
+	assert.NoError(t, err)
+}
+
+func Test_ReportService_Report_InvalidArguments(t *testing.T) {
+	usageRepositoryMock := new(storagemocks.UsageRepository)
+
+	reportService := NewReportService(usageRepositoryMock)
+
+	tests := map[string]struct {
+		userId, group, sort, from, to string
+		err                           error
+	}{
+		"unknown group": {group: "year", err: recipesdomain.ErrInvalidUsageGroup},
+		"unknown sort":  {sort: "name", err: recipesdomain.ErrInvalidUsageSort},
+		"invalid day":   {from: "2024-5-1", err: recipesdomain.ErrInvalidUsagePeriod},
+		"empty period":  {from: "2024-05-31", to: "2024-05-01", err: recipesdomain.ErrInvalidUsagePeriod},
+		"invalid user":  {userId: "ana", err: recipesdomain.ErrInvalidExtractionUserID},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := reportService.Report(context.Background(), tt.userId, tt.group, tt.sort, tt.from, tt.to)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+	usageRepositoryMock.AssertNotCalled(t, "Report", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_ReportService_Report_RepositoryError(t *testing.T) {
+	usageRepositoryMock := new(storagemocks.UsageRepository)
+	usageRepositoryMock.On("Report", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("something unexpected happened"))
+
+	reportService := NewReportService(usageRepositoryMock)
+
+	_, err := reportService.Report(context.Background(), userID, "", "", "", "")
+
+	usageRepositoryMock.AssertExpectations(t)
+	assert.Error(t, err)
+}
@@ -1,27 +1,35 @@
 package domain
 
 import (
+	"encoding/json"
+
 	"github.com/rubenbupe/recipe-video-parser/kit/event"
 )
 
-const ExtractionCreatedEventType event.Type = "events.user.created"
+const ExtractionCreatedEventType event.Type = "events.extraction.created"
 
 type ExtractionCreatedEvent struct {
 	event.BaseEvent
-	id        string
-	userId    string
-	data      string
-	metadata  string
-	createdAt string
+	id            string
+	userId        string
+	sourceUrl     string
+	status        string
+	errorCategory string
+	data          string
+	metadata      string
+	createdAt     string
 }
 
-func NewExtractionCreatedEvent(id, userId, data, metadata, createdAt string) ExtractionCreatedEvent {
+func NewExtractionCreatedEvent(id, userId, sourceUrl, status, errorCategory, data, metadata, createdAt string) ExtractionCreatedEvent {
 	return ExtractionCreatedEvent{
-		id:        id,
-		userId:    userId,
-		data:      data,
-		metadata:  metadata,
-		createdAt: createdAt,
+		id:            id,
+		userId:        userId,
+		sourceUrl:     sourceUrl,
+		status:        status,
+		errorCategory: errorCategory,
+		data:          data,
+		metadata:      metadata,
+		createdAt:     createdAt,
 
 		BaseEvent: event.NewBaseEvent(id),
 	}
@@ -39,6 +47,18 @@ func (e ExtractionCreatedEvent) ExtractionUserID() string {
 	return e.userId
 }
 
+func (e ExtractionCreatedEvent) ExtractionSourceUrl() string {
+	return e.sourceUrl
+}
+
+func (e ExtractionCreatedEvent) ExtractionStatus() string {
+	return e.status
+}
+
+func (e ExtractionCreatedEvent) ExtractionErrorCategory() string {
+	return e.errorCategory
+}
+
 func (e ExtractionCreatedEvent) ExtractionData() string {
 	return e.data
 }
@@ -50,3 +70,49 @@ func (e ExtractionCreatedEvent) ExtractionMetadata() string {
 func (e ExtractionCreatedEvent) ExtractionCreatedAt() string {
 	return e.createdAt
 }
+
+type extractionCreatedEventPayload struct {
+	ID            string `json:"id"`
+	UserID        string `json:"userId"`
+	SourceUrl     string `json:"sourceUrl"`
+	Status        string `json:"status"`
+	ErrorCategory string `json:"errorCategory"`
+	Data          string `json:"data"`
+	Metadata      string `json:"metadata"`
+	CreatedAt     string `json:"createdAt"`
+}
+
+func (e ExtractionCreatedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(extractionCreatedEventPayload{
+		ID:            e.id,
+		UserID:        e.userId,
+		SourceUrl:     e.sourceUrl,
+		Status:        e.status,
+		ErrorCategory: e.errorCategory,
+		Data:          e.data,
+		Metadata:      e.metadata,
+		CreatedAt:     e.createdAt,
+	})
+}
+
+// DecodeExtractionCreatedEvent rebuilds an ExtractionCreatedEvent from its
+// JSON payload.
+func DecodeExtractionCreatedEvent(base event.BaseEvent, payload []byte) (event.Event, error) {
+	var p extractionCreatedEventPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, err
+	}
+
+	return ExtractionCreatedEvent{
+		id:            p.ID,
+		userId:        p.UserID,
+		sourceUrl:     p.SourceUrl,
+		status:        p.Status,
+		errorCategory: p.ErrorCategory,
+		data:          p.Data,
+		metadata:      p.Metadata,
+		createdAt:     p.CreatedAt,
+
+		BaseEvent: base,
+	}, nil
+}
@@ -13,7 +13,10 @@ import (
 
 var ErrInvalidExtractionID = errors.New("invalid Extraction ID")
 var ErrInvalidExtractionUserID = errors.New("invalid Extraction User ID")
+var ErrInvalidExtractionOrganizationID = errors.New("invalid Extraction Organization ID")
 var ErrExtractionAlreadyExists = errors.New("extraction already exists")
+var ErrInvalidExtractionStatus = errors.New("invalid Extraction Status")
+var ErrInvalidExtractionErrorCategory = errors.New("invalid Extraction Error Category")
 
 type ExtractionID struct {
 	value string
@@ -57,6 +60,79 @@ func (id ExtractionUserID) String() string {
 	return id.value
 }
 
+// ExtractionOrganizationID is the organization whose library holds the
+// extraction. It is empty for the extractions of a user alone.
+type ExtractionOrganizationID struct {
+	value string
+}
+
+func NewExtractionOrganizationID(value string) (ExtractionOrganizationID, error) {
+	if value == "" {
+		return ExtractionOrganizationID{}, nil
+	}
+
+	v, err := uuid.Parse(value)
+	if err != nil {
+		return ExtractionOrganizationID{}, fmt.Errorf("%w: %s", ErrInvalidExtractionOrganizationID, value)
+	}
+
+	return ExtractionOrganizationID{
+		value: v.String(),
+	}, nil
+}
+
+func (id ExtractionOrganizationID) String() string {
+	return id.value
+}
+
+const (
+	ExtractionStatusSucceeded  = "succeeded"
+	ExtractionStatusNotARecipe = "not_a_recipe"
+	ExtractionStatusFailed     = "failed"
+)
+
+type ExtractionStatus struct {
+	value string
+}
+
+func NewExtractionStatus(value string) (ExtractionStatus, error) {
+	switch value {
+	case ExtractionStatusSucceeded, ExtractionStatusNotARecipe, ExtractionStatusFailed:
+		return ExtractionStatus{value: value}, nil
+	default:
+		return ExtractionStatus{}, fmt.Errorf("%w: %s", ErrInvalidExtractionStatus, value)
+	}
+}
+
+func (status ExtractionStatus) String() string {
+	return status.value
+}
+
+// HasData reports whether extractions with this status must include the
+// extracted recipe.
+func (status ExtractionStatus) HasData() bool {
+	return status.value == ExtractionStatusSucceeded
+}
+
+// ExtractionErrorCategory classifies why an extraction did not produce a
+// recipe. It is empty for succeeded extractions.
+type ExtractionErrorCategory struct {
+	value string
+}
+
+func NewExtractionErrorCategory(value string) (ExtractionErrorCategory, error) {
+	switch value {
+	case "", ExtractionErrorDownload, ExtractionErrorProvider, ExtractionErrorValidation, ExtractionErrorTimeout, ExtractionErrorNotARecipe:
+		return ExtractionErrorCategory{value: value}, nil
+	default:
+		return ExtractionErrorCategory{}, fmt.Errorf("%w: %s", ErrInvalidExtractionErrorCategory, value)
+	}
+}
+
+func (category ExtractionErrorCategory) String() string {
+	return category.value
+}
+
 type ExtractionData struct {
 	value json.RawMessage
 }
@@ -127,11 +203,16 @@ func (createdAt ExtractionCreatedAt) String() string {
 }
 
 type Extraction struct {
-	Id        ExtractionID
-	UserId    ExtractionUserID
-	Data      string
-	Metadata  string
-	CreatedAt ExtractionCreatedAt
+	Id             ExtractionID
+	UserId         ExtractionUserID
+	OrganizationId ExtractionOrganizationID
+	SourceUrl      string
+	Status         ExtractionStatus
+	ErrorCategory  ExtractionErrorCategory
+	ErrorMessage   string
+	Data           string
+	Metadata       string
+	CreatedAt      ExtractionCreatedAt
 
 	events []event.Event
 }
@@ -141,11 +222,14 @@ type ExtractionRepository interface {
 	Exists(ctx context.Context, id ExtractionID) (bool, error)
 	Get(ctx context.Context, id ExtractionID) (*Extraction, error)
 	GetByUserID(ctx context.Context, extractionId ExtractionUserID) ([]Extraction, error)
+	// GetByOrganizationID returns the library of the organization: the
+	// extractions its members made in it.
+	GetByOrganizationID(ctx context.Context, organizationId ExtractionOrganizationID) ([]Extraction, error)
 }
 
 //mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=ExtractionRepository
 
-func NewExtraction(id, userId, data, metadata, createdAt string) (Extraction, error) {
+func NewExtraction(id, userId, sourceUrl, status, errorCategory, errorMessage, data, metadata, createdAt string) (Extraction, error) {
 	idVO, err := NewExtractionID(id)
 	if err != nil {
 		return Extraction{}, err
@@ -156,11 +240,28 @@ func NewExtraction(id, userId, data, metadata, createdAt string) (Extraction, er
 		return Extraction{}, err
 	}
 
-	dataVO, err := NewExtractionData(data)
+	statusVO, err := NewExtractionStatus(status)
 	if err != nil {
 		return Extraction{}, err
 	}
 
+	errorCategoryVO, err := NewExtractionErrorCategory(errorCategory)
+	if err != nil {
+		return Extraction{}, err
+	}
+	if !statusVO.HasData() && errorCategoryVO.String() == "" {
+		return Extraction{}, errors.New("the field Extraction Error Category can not be empty for extractions without a recipe")
+	}
+
+	// Las extracciones fallidas o que no son recetas no tienen datos
+	var dataVO ExtractionData
+	if statusVO.HasData() || data != "" {
+		dataVO, err = NewExtractionData(data)
+		if err != nil {
+			return Extraction{}, err
+		}
+	}
+
 	metadataVO, err := NewExtractionMetadata(metadata)
 	if err != nil {
 		return Extraction{}, err
@@ -172,18 +273,34 @@ func NewExtraction(id, userId, data, metadata, createdAt string) (Extraction, er
 	}
 
 	extraction := Extraction{
-		Id:        idVO,
-		UserId:    userIdVO,
-		Data:      dataVO.String(),
-		Metadata:  metadataVO.String(),
-		CreatedAt: createdAtVO,
+		Id:            idVO,
+		UserId:        userIdVO,
+		SourceUrl:     sourceUrl,
+		Status:        statusVO,
+		ErrorCategory: errorCategoryVO,
+		ErrorMessage:  errorMessage,
+		Data:          dataVO.String(),
+		Metadata:      metadataVO.String(),
+		CreatedAt:     createdAtVO,
 	}
 
-	extraction.Record(NewExtractionCreatedEvent(idVO.String(), userIdVO.String(), dataVO.String(), metadataVO.String(), createdAtVO.String()))
+	extraction.Record(NewExtractionCreatedEvent(idVO.String(), userIdVO.String(), sourceUrl, statusVO.String(), errorCategoryVO.String(), dataVO.String(), metadataVO.String(), createdAtVO.String()))
 
 	return extraction, nil
 }
 
+// ShareWith puts the extraction in the library of the organization. An empty
+// organizationId keeps it as an extraction of the user alone.
+func (c *Extraction) ShareWith(organizationId string) error {
+	organizationIdVO, err := NewExtractionOrganizationID(organizationId)
+	if err != nil {
+		return err
+	}
+
+	c.OrganizationId = organizationIdVO
+	return nil
+}
+
 func (c *Extraction) Record(evt event.Event) {
 	c.events = append(c.events, evt)
 }
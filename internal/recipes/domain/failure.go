@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Categories of the extractions that did not produce a recipe.
+const (
+	ExtractionErrorDownload   = "download"
+	ExtractionErrorProvider   = "provider"
+	ExtractionErrorValidation = "validation"
+	ExtractionErrorTimeout    = "timeout"
+	ExtractionErrorNotARecipe = "not_a_recipe"
+)
+
+var ErrNotARecipe = errors.New("the video is not a recipe")
+
+// NotARecipeError is returned when the model determines that the video does
+// not contain a recipe. It is an expected outcome, not a failure: the
+// extraction is still persisted (without data) so its token usage is
+// accounted for.
+type NotARecipeError struct {
+	Reason string
+}
+
+func (e *NotARecipeError) Error() string {
+	if e.Reason == "" {
+		return ErrNotARecipe.Error()
+	}
+	return fmt.Sprintf("%s: %s", ErrNotARecipe, e.Reason)
+}
+
+func (e *NotARecipeError) Is(target error) bool {
+	return target == ErrNotARecipe
+}
+
+// ExtractionError is an extraction failure classified by the stage in which it
+// happened.
+type ExtractionError struct {
+	Category string
+	Err      error
+}
+
+func NewExtractionError(category string, err error) *ExtractionError {
+	return &ExtractionError{Category: category, Err: err}
+}
+
+func (e *ExtractionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExtractionError) Unwrap() error {
+	return e.Err
+}
+
+// ExtractionErrorCategoryOf returns the category of an extraction error.
+// Timeouts are detected anywhere in the chain, since they can happen in any
+// stage; unclassified errors are attributed to the provider.
+func ExtractionErrorCategoryOf(err error) string {
+	var timeout interface{ Timeout() bool }
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrNotARecipe):
+		return ExtractionErrorNotARecipe
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded),
+		errors.As(err, &timeout) && timeout.Timeout():
+		return ExtractionErrorTimeout
+	}
+
+	var extractionErr *ExtractionError
+	if errors.As(err, &extractionErr) {
+		return extractionErr.Category
+	}
+	return ExtractionErrorProvider
+}
+
+// ExtractionStatusOf returns the status of an extraction that ended with err.
+func ExtractionStatusOf(err error) string {
+	switch {
+	case err == nil:
+		return ExtractionStatusSucceeded
+	case errors.Is(err, ErrNotARecipe):
+		return ExtractionStatusNotARecipe
+	default:
+		return ExtractionStatusFailed
+	}
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "context"
+
+// FileCleaner deletes the files an extraction may have left behind: the
+// downloaded video and the video uploaded to the provider. Both are temporary
+// copies, so deleting them never loses data.
+type FileCleaner interface {
+	Clean(ctx context.Context, extraction Extraction) error
+}
+
+//mockery --case=snake --outpkg=filesmocks --output=platform/files/filesmocks --name=FileCleaner
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Platforms of the videos. Videos from other sites are labelled PlatformOther.
+const (
+	PlatformInstagram = "instagram"
+	PlatformTikTok    = "tiktok"
+	PlatformYouTube   = "youtube"
+	PlatformFacebook  = "facebook"
+	PlatformX         = "x"
+	PlatformOther     = "other"
+)
+
+// PlatformDomain is a domain of a platform. Its subdomains belong to the
+// platform too.
+type PlatformDomain struct {
+	Platform string
+	Domain   string
+}
+
+// PlatformDomains are the domains of the known platforms.
+var PlatformDomains = []PlatformDomain{
+	{PlatformInstagram, "instagram.com"},
+	{PlatformTikTok, "tiktok.com"},
+	{PlatformYouTube, "youtube.com"},
+	{PlatformYouTube, "youtu.be"},
+	{PlatformFacebook, "facebook.com"},
+	{PlatformFacebook, "fb.watch"},
+	{PlatformX, "x.com"},
+	{PlatformX, "twitter.com"},
+}
+
+// PlatformOf returns the platform of a video from its url.
+func PlatformOf(videoURL string) string {
+	u, err := url.Parse(videoURL)
+	if err != nil {
+		return PlatformOther
+	}
+	host := strings.ToLower(u.Hostname())
+
+	for _, domain := range PlatformDomains {
+		if host == domain.Domain || strings.HasSuffix(host, "."+domain.Domain) {
+			return domain.Platform
+		}
+	}
+	return PlatformOther
+}
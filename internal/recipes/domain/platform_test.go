@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PlatformOf(t *testing.T) {
+	tests := map[string]string{
+		"https://www.instagram.com/reel/abc": PlatformInstagram,
+		"https://vm.tiktok.com/abc":          PlatformTikTok,
+		"https://m.youtube.com/watch?v=abc":  PlatformYouTube,
+		"https://youtu.be/abc":               PlatformYouTube,
+		"https://fb.watch/abc":               PlatformFacebook,
+		"https://twitter.com/chef/status/1":  PlatformX,
+		"https://notinstagram.com/reel/abc":  PlatformOther,
+		"https://example.com/video.mp4":      PlatformOther,
+		"::not a url":                        PlatformOther,
+	}
+
+	for videoURL, expected := range tests {
+		t.Run(videoURL, func(t *testing.T) {
+			assert.Equal(t, expected, PlatformOf(videoURL))
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var ErrInvalidModelPrice = errors.New("invalid Model Price")
+var ErrInvalidUsageGroup = errors.New("invalid Usage Group")
+var ErrInvalidUsageSort = errors.New("invalid Usage Sort")
+var ErrInvalidUsagePeriod = errors.New("invalid Usage Period")
+
+// UnknownModel is the model of the extractions made before the model was
+// recorded in their metadata, which have no price.
+const UnknownModel = "unknown"
+
+// UnknownPrompt is the prompt of the extractions made before the prompt was
+// recorded in their metadata.
+const UnknownPrompt = "unknown"
+
+// ModelPrice is the price of the tokens of a model of an AI provider, in US
+// dollars per million tokens, from a day on. An extraction is priced with the
+// price of its model with the latest EffectiveFrom not after the extraction.
+type ModelPrice struct {
+	Provider    string
+	Model       string
+	InputPrice  float64
+	OutputPrice float64
+	// EffectiveFrom is the first day of the price, formatted as 2006-01-02.
+	EffectiveFrom string
+}
+
+type ModelPriceRepository interface {
+	// Save stores the price, replacing the one of the model from the same day.
+	Save(ctx context.Context, price ModelPrice) error
+	// All returns the prices sorted by provider, model and day.
+	All(ctx context.Context) ([]ModelPrice, error)
+}
+
+//mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=ModelPriceRepository
+
+func NewModelPrice(provider, model string, inputPrice, outputPrice float64, effectiveFrom string) (ModelPrice, error) {
+	if provider == "" {
+		return ModelPrice{}, fmt.Errorf("%w: the provider can not be empty", ErrInvalidModelPrice)
+	}
+	if model == "" || model == UnknownModel {
+		return ModelPrice{}, fmt.Errorf("%w: invalid model %q", ErrInvalidModelPrice, model)
+	}
+	if inputPrice < 0 || outputPrice < 0 {
+		return ModelPrice{}, fmt.Errorf("%w: the prices can not be negative", ErrInvalidModelPrice)
+	}
+	if _, err := time.Parse(time.DateOnly, effectiveFrom); err != nil {
+		return ModelPrice{}, fmt.Errorf("%w: the effective date must be formatted as 2006-01-02", ErrInvalidModelPrice)
+	}
+
+	return ModelPrice{
+		Provider:      provider,
+		Model:         model,
+		InputPrice:    inputPrice,
+		OutputPrice:   outputPrice,
+		EffectiveFrom: effectiveFrom,
+	}, nil
+}
+
+// UsageGroup is how the extractions are grouped in a usage report.
+type UsageGroup string
+
+const (
+	UsageByUser     UsageGroup = "user"
+	UsageByDay      UsageGroup = "day"
+	UsageByWeek     UsageGroup = "week"
+	UsageByMonth    UsageGroup = "month"
+	UsageByModel    UsageGroup = "model"
+	UsageByPlatform UsageGroup = "platform"
+	UsageByPrompt   UsageGroup = "prompt"
+	UsageByError    UsageGroup = "error"
+)
+
+// UsageGroups are the supported groups, in the order they are documented.
+var UsageGroups = []UsageGroup{UsageByUser, UsageByDay, UsageByWeek, UsageByMonth, UsageByModel, UsageByPlatform, UsageByPrompt, UsageByError}
+
+func NewUsageGroup(value string) (UsageGroup, error) {
+	group := UsageGroup(value)
+	if !slices.Contains(UsageGroups, group) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidUsageGroup, value)
+	}
+	return group, nil
+}
+
+// UsageSort is the order of the rows of a usage report: by key, or from the
+// highest to the lowest number of extractions, tokens or cost.
+type UsageSort string
+
+const (
+	UsageSortKey         UsageSort = "key"
+	UsageSortExtractions UsageSort = "extractions"
+	UsageSortTokens      UsageSort = "tokens"
+	UsageSortCost        UsageSort = "cost"
+)
+
+// UsageSorts are the supported orders, in the order they are documented.
+var UsageSorts = []UsageSort{UsageSortKey, UsageSortExtractions, UsageSortTokens, UsageSortCost}
+
+func NewUsageSort(value string) (UsageSort, error) {
+	sort := UsageSort(value)
+	if !slices.Contains(UsageSorts, sort) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidUsageSort, value)
+	}
+	return sort, nil
+}
+
+// UsageFilter selects the extractions of a usage report.
+type UsageFilter struct {
+	// UserId is the user of the extractions, or empty for all the users.
+	UserId string
+	// OrganizationId is the organization of the extractions, or empty for
+	// the extractions of any organization or of none.
+	OrganizationId string
+	// Personal selects only the extractions outside an organization.
+	Personal bool
+	// From and To are the first and the last day of the extractions, formatted
+	// as 2006-01-02, or empty for no limit.
+	From string
+	To   string
+}
+
+func NewUsageFilter(userId, from, to string) (UsageFilter, error) {
+	if userId != "" {
+		if _, err := NewExtractionUserID(userId); err != nil {
+			return UsageFilter{}, err
+		}
+	}
+	for _, day := range []string{from, to} {
+		if day == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, day); err != nil {
+			return UsageFilter{}, fmt.Errorf("%w: %s must be formatted as 2006-01-02", ErrInvalidUsagePeriod, day)
+		}
+	}
+	if from != "" && to != "" && from > to {
+		return UsageFilter{}, fmt.Errorf("%w: %s is after %s", ErrInvalidUsagePeriod, from, to)
+	}
+
+	return UsageFilter{
+		UserId: userId,
+		From:   from,
+		To:     to,
+	}, nil
+}
+
+// UsageRow is the usage of a group of extractions.
+type UsageRow struct {
+	// Key identifies the group: the user name, the day (2006-01-02), the
+	// Monday of the week, the month (2006-01), the provider/model, the
+	// platform, the prompt as id@version or the error category, which is
+	// empty for the extractions without an error.
+	Key              string
+	Extractions      int
+	Succeeded        int
+	NotARecipe       int
+	Failed           int
+	PromptTokens     int
+	CandidatesTokens int
+	TotalTokens      int
+	// Cost is the cost in US dollars of the tokens of the priced extractions.
+	Cost float64
+	// Unpriced is the number of extractions that used tokens of a model
+	// without a price, which are not included in Cost.
+	Unpriced int
+}
+
+type UsageRepository interface {
+	// Report aggregates the usage of the extractions of the filter.
+	Report(ctx context.Context, filter UsageFilter, group UsageGroup, sort UsageSort) ([]UsageRow, error)
+}
+
+//mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=UsageRepository
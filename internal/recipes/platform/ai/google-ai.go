@@ -2,9 +2,12 @@ package ai
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -12,11 +15,16 @@ import (
 	"time"
 
 	"github.com/go-playground/validator/v10"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
 	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/gallery"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/i18n"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func uploadFileToGoogleAI(filePath string, config ai.Aiconfig) (string, error) {
+func uploadFileToGoogleAI(ctx context.Context, filePath string, config ai.Aiconfig) (string, error) {
 	// Obtener el mime type y tamaño del archivo
 	fileInfo, err := os.Stat(filePath)
 	if err != nil {
@@ -34,6 +42,10 @@ func uploadFileToGoogleAI(filePath string, config ai.Aiconfig) (string, error) {
 	n, _ := file.Read(buf)
 	mimeType := http.DetectContentType(buf[:n])
 	file.Seek(0, 0)
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.Int64("file.size", numBytes),
+		attribute.String("file.mime_type", mimeType),
+	)
 
 	displayName := filepath.Base(filePath)
 
@@ -45,7 +57,7 @@ func uploadFileToGoogleAI(filePath string, config ai.Aiconfig) (string, error) {
 	}
 	startPayloadBytes, _ := json.Marshal(startPayload)
 
-	req, err := http.NewRequest("POST", "https://generativelanguage.googleapis.com/upload/v1beta/files?key="+config.ApiKey, bytes.NewBuffer(startPayloadBytes))
+	req, err := http.NewRequestWithContext(ctx, "POST", baseUrl(config)+"/upload/v1beta/files?key="+config.ApiKey, bytes.NewBuffer(startPayloadBytes))
 	if err != nil {
 		return "", fmt.Errorf("could not create start upload request: %w", err)
 	}
@@ -73,7 +85,7 @@ func uploadFileToGoogleAI(filePath string, config ai.Aiconfig) (string, error) {
 	}
 
 	// Paso 2: Subir el archivo binario
-	uploadReq, err := http.NewRequest("POST", uploadURL, file)
+	uploadReq, err := http.NewRequestWithContext(ctx, "POST", uploadURL, file)
 	if err != nil {
 		return "", fmt.Errorf("could not create upload request: %w", err)
 	}
@@ -113,10 +125,31 @@ func uploadFileToGoogleAI(filePath string, config ai.Aiconfig) (string, error) {
 	return fileURI, nil
 }
 
-func ensureFileActive(fileUrl string, config ai.Aiconfig) error {
+// DeleteFile deletes a file uploaded to the provider. Files that do not exist,
+// e.g. because the provider already expired them, are ignored.
+func DeleteFile(ctx context.Context, fileURI string, config ai.Aiconfig) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fileURI+"?key="+config.ApiKey, nil)
+	if err != nil {
+		return fmt.Errorf("could not create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not delete file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 && resp.StatusCode != 404 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("delete failed: %s, body: %s", resp.Status, string(body))
+	}
+	return nil
+}
+
+func ensureFileActive(ctx context.Context, fileUrl string, config ai.Aiconfig) error {
 	client := &http.Client{}
 	for i := 0; i < 120; i++ { // hasta 120 intentos (~60s)
-		req, err := http.NewRequest("GET", fileUrl+"?key="+config.ApiKey, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", fileUrl+"?key="+config.ApiKey, nil)
 		if err != nil {
 			return fmt.Errorf("could not create request: %w", err)
 		}
@@ -137,163 +170,267 @@ func ensureFileActive(fileUrl string, config ai.Aiconfig) error {
 			return fmt.Errorf("could not parse response: %w", err)
 		}
 		if state, ok := res["state"].(string); ok && state == "ACTIVE" {
+			trace.SpanFromContext(ctx).SetAttributes(attribute.Int("google_ai.file.polls", i+1))
 			return nil
 		}
 
-		time.Sleep(500 * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
 	}
-	return fmt.Errorf("file did not become ACTIVE after waiting")
+	return recipesdomain.NewExtractionError(recipesdomain.ExtractionErrorTimeout, errors.New("file did not become ACTIVE after waiting"))
+}
+
+// StageDurations is the time spent in each stage of an extraction, in
+// milliseconds. Activation is the wait until the provider has processed the
+// uploaded file.
+type StageDurations struct {
+	Download   int64 `json:"download,omitempty"`
+	Upload     int64 `json:"upload,omitempty"`
+	Activation int64 `json:"activation,omitempty"`
+	Generation int64 `json:"generation,omitempty"`
+	Total      int64 `json:"total,omitempty"`
 }
 
 type AiResponse struct {
 	Recipe   Recipe `json:"recipe"`
 	Metadata struct {
-		PromptTokenCount     int `json:"promptTokenCount"`
-		CandidatesTokenCount int `json:"candidatesTokenCount"`
+		PromptTokenCount     int    `json:"promptTokenCount"`
+		CandidatesTokenCount int    `json:"candidatesTokenCount"`
+		Locale               string `json:"locale"`
+		PromptID             string `json:"promptId"`
+		PromptVersion        string `json:"promptVersion"`
+		// Reason explains why the video is not a recipe, if that is the case.
+		Reason string `json:"reason,omitempty"`
+		// RepairAttempts is the number of follow-up requests needed to get a
+		// valid answer. Token counts include those requests.
+		RepairAttempts int            `json:"repairAttempts"`
+		Durations      StageDurations `json:"durations"`
+		// ProviderFile is the URI of the video uploaded to the provider when it
+		// could not be deleted after the extraction, so it can be deleted later.
+		ProviderFile string `json:"providerFile,omitempty"`
+		// Provider and Model are the AI provider and model asked for the
+		// recipe, which set the price of its tokens.
+		Provider string `json:"provider,omitempty"`
+		Model    string `json:"model,omitempty"`
 	} `json:"metadata"`
 }
 
+// Recipe is the recipe extracted by the model. Its struct tags also generate
+// the response schema sent to the provider (see ai.SchemaFor), so any change
+// here is automatically reflected in the structured output.
 type Recipe struct {
-	Title           string          `json:"title" validate:"required"`
-	Description     string          `json:"description" validate:"required"`
-	Servings        int             `json:"servings"`
-	PrepTime        int             `json:"prep_time"`
-	CookTime        int             `json:"cook_time"`
-	TotalTime       int             `json:"total_time"`
-	Difficulty      int             `json:"difficulty" validate:"required"`
-	Ingredients     []Ingredient    `json:"ingredients" validate:"required"`
-	Sections        []Section       `json:"sections" validate:"required"`
-	Notes           string          `json:"notes"`
-	NutritionalInfo NutritionalInfo `json:"nutritional_info"`
-	Url             string          `json:"url"`
+	Title           string          `json:"title" validate:"required" jsonschema_description:"Recipe title"`
+	Description     string          `json:"description" validate:"required" jsonschema_description:"Short description of the recipe"`
+	Servings        int             `json:"servings" jsonschema:"minimum=1" jsonschema_description:"Number of servings the recipe makes"`
+	PrepTime        int             `json:"prep_time" jsonschema:"minimum=0" jsonschema_description:"Preparation time in minutes"`
+	CookTime        int             `json:"cook_time" jsonschema:"minimum=0" jsonschema_description:"Cooking time in minutes"`
+	TotalTime       int             `json:"total_time" jsonschema:"minimum=0" jsonschema_description:"Total recipe time in minutes"`
+	Difficulty      int             `json:"difficulty" validate:"required" jsonschema:"minimum=1,maximum=3" jsonschema_description:"Recipe difficulty from 1 (easy) to 3 (hard)"`
+	Ingredients     []Ingredient    `json:"ingredients" validate:"required" jsonschema_description:"Ingredients sorted by importance"`
+	Sections        []Section       `json:"sections" validate:"required" jsonschema_description:"Instructions, split in sections when the recipe has several preparations or stages"`
+	Notes           string          `json:"notes" jsonschema_description:"Additional notes (side dishes, storage, plating...). Empty when there are none"`
+	NutritionalInfo NutritionalInfo `json:"nutritional_info" jsonschema_description:"Estimated nutritional information per 100g"`
+	Url             string          `json:"url" jsonschema:"-"`
 }
 type Ingredient struct {
-	Name     string `json:"name" validate:"required"`
-	Quantity string `json:"quantity" validate:"required"`
-	Unit     string `json:"unit" validate:"required"`
-  Optional bool   `json:"optional"`
+	Name     string `json:"name" validate:"required" jsonschema_description:"Ingredient name"`
+	Quantity string `json:"quantity" validate:"required" jsonschema_description:"Quantity (1, 2, 3, 1/2, 1/4, etc.). Use fractions whenever possible"`
+	Unit     string `json:"unit" validate:"required" jsonschema_description:"Unit of measurement, as a symbol or abbreviation whenever possible"`
+	Optional bool   `json:"optional" jsonschema_description:"Whether the ingredient is optional"`
 }
 type Section struct {
 	Instructions []Instruction `json:"instructions" validate:"required"`
 }
 type Instruction struct {
-	Optional bool   `json:"optional" validate:"required"`
-	Text     string `json:"text" validate:"required"`
+	Optional bool   `json:"optional" validate:"required" jsonschema_description:"Whether the step is optional"`
+	Text     string `json:"text" validate:"required" jsonschema_description:"Detailed instruction referencing the ingredients by name, understandable on its own"`
 }
 type NutritionalInfo struct {
-	Calories      float64 `json:"calories"`
-	Protein       float64 `json:"protein"`
-	Carbohydrates float64 `json:"carbohydrates"`
-	Fats          float64 `json:"fats"`
-	Fiber         float64 `json:"fiber"`
-	Sugar         float64 `json:"sugar"`
+	Calories      float64 `json:"calories" jsonschema_description:"Calories (kcal) per 100g"`
+	Protein       float64 `json:"protein" jsonschema_description:"Protein (g) per 100g"`
+	Carbohydrates float64 `json:"carbohydrates" jsonschema_description:"Carbohydrates (g) per 100g"`
+	Fats          float64 `json:"fats" jsonschema_description:"Fats (g) per 100g"`
+	Fiber         float64 `json:"fiber" jsonschema_description:"Fibre (g) per 100g"`
+	Sugar         float64 `json:"sugar" jsonschema_description:"Sugars (g) per 100g"`
+}
+
+// extractionAnswer is the answer requested to the model: the recipe, or the
+// reason why the video is not a recipe.
+type extractionAnswer struct {
+	IsRecipe bool    `json:"is_recipe" jsonschema_description:"Whether the video contains a cooking recipe"`
+	Reason   string  `json:"reason" jsonschema:"optional" jsonschema_description:"When the video is not a recipe, a short explanation of why"`
+	Recipe   *Recipe `json:"recipe" jsonschema:"optional" jsonschema_description:"The recipe, only when the video contains one"`
+}
+
+var recipeSchema = mustSchema(Recipe{})
+var answerSchema = mustSchema(extractionAnswer{})
+
+// responseSchema returns the schema of the answer the prompt describes: the
+// recipe alone for the versions of extract-recipe before v3, which do not ask
+// whether the video is a recipe, and the extractionAnswer for the rest.
+func responseSchema(prompt Prompt) *ai.Schema {
+	if prompt.ID == ExtractRecipePromptID && (prompt.Version == "v1" || prompt.Version == "v2") {
+		return recipeSchema
+	}
+	return answerSchema
+}
+
+func mustSchema(v interface{}) *ai.Schema {
+	schema, err := ai.SchemaFor(v)
+	if err != nil {
+		panic(err)
+	}
+	return schema
 }
 
-func parseGoogleAIResponse(apiResponse string) (AiResponse, error) {
+// modelAnswer is the text generated by the model and the tokens it used.
+type modelAnswer struct {
+	Text                 string
+	PromptTokenCount     int
+	CandidatesTokenCount int
+}
+
+func parseGoogleAIResponse(apiResponse string) (modelAnswer, error) {
 	var res map[string]interface{}
 	if err := json.Unmarshal([]byte(apiResponse), &res); err != nil {
-		return AiResponse{}, fmt.Errorf("could not parse response: %w", err)
+		return modelAnswer{}, fmt.Errorf("could not parse response: %w", err)
 	}
 	// Extraer el texto JSON de la receta
 	candidates, ok := res["candidates"].([]interface{})
 	if !ok || len(candidates) == 0 {
-		return AiResponse{}, fmt.Errorf("no candidates in response")
+		return modelAnswer{}, fmt.Errorf("no candidates in response")
 	}
 	candidate, ok := candidates[0].(map[string]interface{})
 	if !ok {
-		return AiResponse{}, fmt.Errorf("invalid candidate format")
+		return modelAnswer{}, fmt.Errorf("invalid candidate format")
 	}
 	content, ok := candidate["content"].(map[string]interface{})
 	if !ok {
-		return AiResponse{}, fmt.Errorf("invalid content format")
+		return modelAnswer{}, fmt.Errorf("invalid content format")
 	}
 	parts, ok := content["parts"].([]interface{})
 	if !ok || len(parts) == 0 {
-		return AiResponse{}, fmt.Errorf("no parts in content")
+		return modelAnswer{}, fmt.Errorf("no parts in content")
 	}
-	var recipeJson string
+	var answer modelAnswer
 	for _, p := range parts {
 		if partMap, ok := p.(map[string]interface{}); ok {
 			if text, ok := partMap["text"].(string); ok {
-				recipeJson = text
+				answer.Text = text
 				break
 			}
 		}
 	}
-	if recipeJson == "" {
-		return AiResponse{}, fmt.Errorf("no recipe JSON found in response")
+	if answer.Text == "" {
+		return modelAnswer{}, fmt.Errorf("no recipe JSON found in response")
 	}
 
 	// Extraer metadatos
-	metadata := make(map[string]interface{})
 	if usage, ok := res["usageMetadata"].(map[string]interface{}); ok {
-		metadata = usage
+		if v, ok := usage["promptTokenCount"].(float64); ok {
+			answer.PromptTokenCount = int(v)
+		}
+		if v, ok := usage["candidatesTokenCount"].(float64); ok {
+			answer.CandidatesTokenCount = int(v)
+		}
 	}
+	return answer, nil
+}
 
-	// Parsear y validar el JSON de la receta y los metadatos
-	var aiResponse AiResponse
-	if err := json.Unmarshal([]byte(recipeJson), &aiResponse.Recipe); err != nil {
-		return AiResponse{}, fmt.Errorf("error parsing AI response JSON: %w", err)
+// decodeAnswer decodes the answer of the model. Besides the answer envelope,
+// it accepts the bare recipe requested by the prompts before v3, where an
+// empty object means that the video is not a recipe. It returns a
+// *recipesdomain.NotARecipeError when the video is not a recipe.
+func decodeAnswer(text string) (Recipe, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(text), &fields); err != nil {
+		return Recipe{}, fmt.Errorf("error parsing AI response JSON: %w", err)
 	}
-	if metadata != nil {
-		if v, ok := metadata["promptTokenCount"].(float64); ok {
-			aiResponse.Metadata.PromptTokenCount = int(v)
-		}
-		if v, ok := metadata["candidatesTokenCount"].(float64); ok {
-			aiResponse.Metadata.CandidatesTokenCount = int(v)
-		}
+	if len(fields) == 0 {
+		return Recipe{}, &recipesdomain.NotARecipeError{}
+	}
+	if _, ok := fields["is_recipe"]; !ok {
+		return decodeRecipe(text)
+	}
+
+	var answer extractionAnswer
+	if err := json.Unmarshal([]byte(text), &answer); err != nil {
+		return Recipe{}, fmt.Errorf("error parsing AI response JSON: %w", err)
+	}
+	if !answer.IsRecipe {
+		return Recipe{}, &recipesdomain.NotARecipeError{Reason: answer.Reason}
+	}
+	if answer.Recipe == nil {
+		return Recipe{}, fmt.Errorf("validation error: the answer has no recipe")
+	}
+	return decodeRecipe(string(fields["recipe"]))
+}
+
+// decodeRecipe parses and validates the recipe JSON generated by the model.
+func decodeRecipe(recipeJson string) (Recipe, error) {
+	var recipe Recipe
+	if err := json.Unmarshal([]byte(recipeJson), &recipe); err != nil {
+		return Recipe{}, fmt.Errorf("error parsing AI response JSON: %w", err)
 	}
 	validate := validator.New()
-	if err := validate.Struct(aiResponse); err != nil {
-		return AiResponse{}, fmt.Errorf("validation error: %w", err)
+	if err := validate.Struct(recipe); err != nil {
+		return Recipe{}, fmt.Errorf("validation error: %w", err)
 	}
-	for i, sec := range aiResponse.Recipe.Sections {
+	for i, sec := range recipe.Sections {
 		if len(sec.Instructions) == 0 {
-			return AiResponse{}, fmt.Errorf("la sección %d no tiene instrucciones", i+1)
+			return Recipe{}, fmt.Errorf("la sección %d no tiene instrucciones", i+1)
 		}
 		for j, inst := range sec.Instructions {
 			if inst.Text == "" {
-				return AiResponse{}, fmt.Errorf("la instrucción %d de la sección %d está vacía", j+1, i+1)
+				return Recipe{}, fmt.Errorf("la instrucción %d de la sección %d está vacía", j+1, i+1)
 			}
 		}
 	}
-	return aiResponse, nil
+	return recipe, nil
 }
 
 func FormatToMarkdown(aiResponse AiResponse) string {
 	recipe := aiResponse.Recipe
+	locale, err := i18n.ResolveLocale(aiResponse.Metadata.Locale)
+	if err != nil {
+		locale = i18n.DefaultLocale()
+	}
+	minutes := locale.T(i18n.MsgMinutes)
 	var b strings.Builder
 
 	b.WriteString("# " + recipe.Title + "\n\n")
 	if recipe.Description != "" {
-		b.WriteString("## Descripción\n")
+		b.WriteString("## " + locale.T(i18n.MsgDescription) + "\n")
 		b.WriteString(recipe.Description + "\n\n")
 	}
-	b.WriteString(fmt.Sprintf("**Porciones:** %d\n", recipe.Servings))
-	b.WriteString(fmt.Sprintf("**Dificultad:** %d\n", recipe.Difficulty))
-	b.WriteString(fmt.Sprintf("**Tiempo de preparación:** %d min\n", recipe.PrepTime))
-	b.WriteString(fmt.Sprintf("**Tiempo de cocción:** %d min\n", recipe.CookTime))
-	b.WriteString(fmt.Sprintf("**Tiempo total:** %d min\n\n", recipe.TotalTime))
+	b.WriteString(fmt.Sprintf("**%s:** %d\n", locale.T(i18n.MsgServings), recipe.Servings))
+	b.WriteString(fmt.Sprintf("**%s:** %d\n", locale.T(i18n.MsgDifficulty), recipe.Difficulty))
+	b.WriteString(fmt.Sprintf("**%s:** %d %s\n", locale.T(i18n.MsgPrepTime), recipe.PrepTime, minutes))
+	b.WriteString(fmt.Sprintf("**%s:** %d %s\n", locale.T(i18n.MsgCookTime), recipe.CookTime, minutes))
+	b.WriteString(fmt.Sprintf("**%s:** %d %s\n\n", locale.T(i18n.MsgTotalTime), recipe.TotalTime, minutes))
 
-	b.WriteString("## Ingredientes\n")
+	b.WriteString("## " + locale.T(i18n.MsgIngredients) + "\n")
 	for _, ing := range recipe.Ingredients {
 		if ing.Optional {
-			b.WriteString(fmt.Sprintf("- %s %s %s (Opcional)\n", ing.Quantity, ing.Unit, ing.Name))
+			b.WriteString(fmt.Sprintf("- %s %s %s (%s)\n", ing.Quantity, ing.Unit, ing.Name, locale.T(i18n.MsgOptional)))
 		} else {
 			b.WriteString(fmt.Sprintf("- %s %s %s\n", ing.Quantity, ing.Unit, ing.Name))
 		}
 	}
 	b.WriteString("\n")
 
-	b.WriteString("## Instrucciones\n")
+	b.WriteString("## " + locale.T(i18n.MsgInstructions) + "\n")
 	for i, sec := range recipe.Sections {
 		if len(recipe.Sections) > 1 {
-			b.WriteString(fmt.Sprintf("### Sección %d\n", i+1))
+			b.WriteString(fmt.Sprintf("### %s %d\n", locale.T(i18n.MsgSection), i+1))
 		}
 		for j, inst := range sec.Instructions {
 			b.WriteString(fmt.Sprintf("%d. %s%s\n", j+1, inst.Text, func() string {
 				if inst.Optional {
-					return " _(opcional)_"
+					return " _(" + locale.T(i18n.MsgOptionalStep) + ")_"
 				} else {
 					return ""
 				}
@@ -303,84 +440,221 @@ func FormatToMarkdown(aiResponse AiResponse) string {
 	}
 
 	if recipe.Notes != "" {
-		b.WriteString("**Notas:** " + recipe.Notes + "\n\n")
+		b.WriteString("**" + locale.T(i18n.MsgNotes) + ":** " + recipe.Notes + "\n\n")
 	}
 
-	b.WriteString("## Información nutricional (por cada 100g)\n")
-	b.WriteString(fmt.Sprintf("- Calorías: %.0f kcal\n", recipe.NutritionalInfo.Calories))
-	b.WriteString(fmt.Sprintf("- Proteínas: %.0f g\n", recipe.NutritionalInfo.Protein))
-	b.WriteString(fmt.Sprintf("- Carbohidratos: %.0f g\n", recipe.NutritionalInfo.Carbohydrates))
-	b.WriteString(fmt.Sprintf("- Grasas: %.0f g\n", recipe.NutritionalInfo.Fats))
-	b.WriteString(fmt.Sprintf("- Fibra: %.0f g\n", recipe.NutritionalInfo.Fiber))
-	b.WriteString(fmt.Sprintf("- Azúcares: %.0f g\n", recipe.NutritionalInfo.Sugar))
+	b.WriteString("## " + locale.T(i18n.MsgNutritionalInfo) + "\n")
+	b.WriteString(fmt.Sprintf("- %s: %.0f kcal\n", locale.T(i18n.MsgCalories), recipe.NutritionalInfo.Calories))
+	b.WriteString(fmt.Sprintf("- %s: %.0f g\n", locale.T(i18n.MsgProtein), recipe.NutritionalInfo.Protein))
+	b.WriteString(fmt.Sprintf("- %s: %.0f g\n", locale.T(i18n.MsgCarbohydrates), recipe.NutritionalInfo.Carbohydrates))
+	b.WriteString(fmt.Sprintf("- %s: %.0f g\n", locale.T(i18n.MsgFats), recipe.NutritionalInfo.Fats))
+	b.WriteString(fmt.Sprintf("- %s: %.0f g\n", locale.T(i18n.MsgFiber), recipe.NutritionalInfo.Fiber))
+	b.WriteString(fmt.Sprintf("- %s: %.0f g\n", locale.T(i18n.MsgSugar), recipe.NutritionalInfo.Sugar))
 
 	if recipe.Url != "" {
-		b.WriteString("\n[Ver receta original](" + recipe.Url + ")\n")
+		b.WriteString("\n[" + locale.T(i18n.MsgOriginalRecipe) + "](" + recipe.Url + ")\n")
 	}
 
 	return b.String()
 }
 
-func askModelRequest(payload map[string]interface{}, config ai.Aiconfig) (AiResponse, error) {
+// generateContent sends a generation request to the provider, inside a span
+// with the model and the tokens used.
+func generateContent(ctx context.Context, payload map[string]interface{}, config ai.Aiconfig) (modelAnswer, error) {
+	ctx, span := tracing.Start(ctx, "google-ai.generate",
+		attribute.String("gen_ai.system", config.Provider),
+		attribute.String("gen_ai.request.model", config.Model),
+	)
+	answer, err := requestContent(ctx, payload, config)
+	span.SetAttributes(
+		attribute.Int("gen_ai.usage.input_tokens", answer.PromptTokenCount),
+		attribute.Int("gen_ai.usage.output_tokens", answer.CandidatesTokenCount),
+	)
+	tracing.End(span, err)
+	return answer, err
+}
+
+func requestContent(ctx context.Context, payload map[string]interface{}, config ai.Aiconfig) (modelAnswer, error) {
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		return AiResponse{}, fmt.Errorf("could not marshal payload: %w", err)
+		return modelAnswer{}, fmt.Errorf("could not marshal payload: %w", err)
+	}
+
+	url := baseUrl(config) + "/v1beta/models/" + config.Model + ":generateContent?key=" + config.ApiKey
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return modelAnswer{}, fmt.Errorf("could not create request: %w", err)
 	}
+	req.Header.Set("Content-Type", "application/json")
 
-	url := "https://generativelanguage.googleapis.com/v1beta/models/" + config.Model + ":generateContent?key=" + config.ApiKey
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonPayload))
+	started := time.Now()
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return AiResponse{}, fmt.Errorf("request failed: %w", err)
+		return modelAnswer{}, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	slog.DebugContext(ctx, "model request",
+		slog.String("model", config.Model),
+		slog.Int("status", resp.StatusCode),
+		slog.Duration("duration", time.Since(started)),
+	)
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return AiResponse{}, fmt.Errorf("could not read response: %w", err)
+		return modelAnswer{}, fmt.Errorf("could not read response: %w", err)
 	}
 
 	if resp.StatusCode != 200 {
-		return AiResponse{}, fmt.Errorf("error: %s, body: %s", resp.Status, string(body))
+		return modelAnswer{}, fmt.Errorf("error: %s, body: %s", resp.Status, string(body))
 	}
 
-	parsedResponse, err := parseGoogleAIResponse(string(body))
+	answer, err := parseGoogleAIResponse(string(body))
 	if err != nil {
-		return AiResponse{}, fmt.Errorf("error parsing AI response: %w", err)
+		return modelAnswer{}, fmt.Errorf("error parsing AI response: %w", err)
 	}
-	return parsedResponse, nil
+	return answer, nil
 }
 
-func AskModelWithFile(download gallery.DownloadResult, config ai.Aiconfig) (AiResponse, error) {
+// askModelRequest sends the payload and decodes the recipe, repairing the
+// answer when it is not valid (see repair.go). The metadata of the response is
+// returned even when there is an error (e.g. the video is not a recipe or the
+// answer could not be repaired), so the tokens spent can still be accounted for.
+func askModelRequest(ctx context.Context, payload map[string]interface{}, config ai.Aiconfig) (AiResponse, error) {
+	var res AiResponse
+	answer, err := generateContent(ctx, payload, config)
+	if err != nil {
+		return res, err
+	}
+
+	res.Metadata.PromptTokenCount = answer.PromptTokenCount
+	res.Metadata.CandidatesTokenCount = answer.CandidatesTokenCount
+
+	recipe, err := decodeRecipeWithFixes(answer.Text)
+	for err != nil && !errors.Is(err, recipesdomain.ErrNotARecipe) && res.Metadata.RepairAttempts < config.RepairAttempts {
+		res.Metadata.RepairAttempts++
+		slog.WarnContext(ctx, "repairing invalid model output",
+			slog.Int("attempt", res.Metadata.RepairAttempts),
+			slog.String("error", err.Error()),
+		)
+
+		answer, err = generateContent(ctx, withRepairRequest(payload, answer.Text, err), config)
+		if err != nil {
+			return res, fmt.Errorf("repair attempt %d failed: %w", res.Metadata.RepairAttempts, err)
+		}
+		res.Metadata.PromptTokenCount += answer.PromptTokenCount
+		res.Metadata.CandidatesTokenCount += answer.CandidatesTokenCount
+
+		recipe, err = decodeRecipeWithFixes(answer.Text)
+	}
+	var notARecipe *recipesdomain.NotARecipeError
+	if errors.As(err, &notARecipe) {
+		res.Metadata.Reason = notARecipe.Reason
+		return res, err
+	}
+	if err != nil {
+		return res, recipesdomain.NewExtractionError(
+			recipesdomain.ExtractionErrorValidation,
+			fmt.Errorf("invalid model output after %d repair attempts: %w", res.Metadata.RepairAttempts, err),
+		)
+	}
+
+	res.Recipe = recipe
+	return res, nil
+}
+
+func baseUrl(config ai.Aiconfig) string {
+	if config.BaseUrl == "" {
+		return "https://generativelanguage.googleapis.com"
+	}
+	return strings.TrimSuffix(config.BaseUrl, "/")
+}
+
+// CheckModel requests the configured model from the provider, to verify that
+// it is reachable and that the API key has access to the model. It does not
+// consume tokens.
+func CheckModel(ctx context.Context, config ai.Aiconfig) error {
+	url := baseUrl(config) + "/v1beta/models/" + config.Model + "?key=" + config.ApiKey
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("could not create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("error: %s, body: %s", resp.Status, string(body))
+	}
+	return nil
+}
+
+func AskModelWithFile(ctx context.Context, download gallery.DownloadResult, prompt Prompt, locale i18n.Locale, config ai.Aiconfig) (AiResponse, error) {
 	filePath := download.FilePath
 	if !filepath.IsAbs(filePath) {
 		filePath = filepath.Join("tmp/dl", filePath)
 	}
 
-	fileURI, err := uploadFileToGoogleAI(filePath, config)
+	res := newAiResponse(prompt, locale)
+
+	started := time.Now()
+	fileURI, err := tracing.Call(ctx, "google-ai.upload", func(ctx context.Context) (string, error) {
+		return uploadFileToGoogleAI(ctx, filePath, config)
+	})
+	res.Metadata.Durations.Upload = time.Since(started).Milliseconds()
 	if err != nil {
-		return AiResponse{}, fmt.Errorf("could not upload file: %w", err)
+		return res, fmt.Errorf("could not upload file: %w", err)
+	}
+
+	// El vídeo subido solo se necesita durante la extracción
+	resp, err := askModelWithUploadedFile(ctx, download, fileURI, res, prompt, locale, config)
+	errDelete := tracing.Run(ctx, "google-ai.delete", func(ctx context.Context) error {
+		return DeleteFile(context.WithoutCancel(ctx), fileURI, config)
+	})
+	if errDelete != nil {
+		slog.WarnContext(ctx, "could not delete uploaded file", slog.String("file", fileURI), slog.String("error", errDelete.Error()))
+		resp.Metadata.ProviderFile = fileURI
 	}
+	return resp, err
+}
 
-	if err := ensureFileActive(fileURI, config); err != nil {
-		return AiResponse{}, fmt.Errorf("file not ACTIVE: %w", err)
+// askModelWithUploadedFile waits until the provider has processed the uploaded
+// video and asks the model for its recipe.
+func askModelWithUploadedFile(ctx context.Context, download gallery.DownloadResult, fileURI string, res AiResponse, prompt Prompt, locale i18n.Locale, config ai.Aiconfig) (AiResponse, error) {
+	started := time.Now()
+	err := tracing.Run(ctx, "google-ai.activation", func(ctx context.Context) error {
+		return ensureFileActive(ctx, fileURI, config)
+	})
+	res.Metadata.Durations.Activation = time.Since(started).Milliseconds()
+	if err != nil {
+		return res, fmt.Errorf("file not ACTIVE: %w", err)
 	}
 
-	prompt := ExtractRecipePrompt()
+	promptText, err := prompt.Render(locale)
+	if err != nil {
+		return res, err
+	}
 
 	payload := map[string]interface{}{
 		"generation_config": map[string]interface{}{
 			"response_mime_type": "application/json",
+			"response_schema":    responseSchema(prompt).Gemini(),
 			"temperature":        config.Temperature,
 		},
 		"system_instruction": map[string]interface{}{
 			"parts": []interface{}{
 				map[string]interface{}{
-					"text": prompt,
+					"text": promptText,
 				},
 			},
 		},
 		"contents": []interface{}{
 			map[string]interface{}{
+				"role": "user",
 				"parts": []interface{}{
 					map[string]interface{}{
 						"file_data": map[string]interface{}{
@@ -394,29 +668,51 @@ func AskModelWithFile(download gallery.DownloadResult, config ai.Aiconfig) (AiRe
 		},
 	}
 
-	resp, err := askModelRequest(payload, config)
+	started = time.Now()
+	resp, err := askModelRequest(ctx, payload, config)
+	resp.Metadata.Durations = res.Metadata.Durations
+	resp.Metadata.Durations.Generation = time.Since(started).Milliseconds()
+	withRequestMetadata(&resp, prompt, locale)
 	if err == nil {
 		resp.Recipe.Url = download.Url
 	}
 	return resp, err
 }
 
-func AskModelWithUrl(urlStr string, config ai.Aiconfig) (AiResponse, error) {
-	prompt := ExtractRecipePrompt()
+func newAiResponse(prompt Prompt, locale i18n.Locale) AiResponse {
+	var res AiResponse
+	withRequestMetadata(&res, prompt, locale)
+	return res
+}
+
+// withRequestMetadata records the prompt and locale used for the request.
+func withRequestMetadata(res *AiResponse, prompt Prompt, locale i18n.Locale) {
+	res.Metadata.Locale = locale.Tag
+	res.Metadata.PromptID = prompt.ID
+	res.Metadata.PromptVersion = prompt.Version
+}
+
+func AskModelWithUrl(ctx context.Context, urlStr string, prompt Prompt, locale i18n.Locale, config ai.Aiconfig) (AiResponse, error) {
+	promptText, err := prompt.Render(locale)
+	if err != nil {
+		return newAiResponse(prompt, locale), err
+	}
 	payload := map[string]interface{}{
 		"generation_config": map[string]interface{}{
 			"response_mime_type": "application/json",
+			"response_schema":    responseSchema(prompt).Gemini(),
 			"temperature":        config.Temperature,
 		},
 		"system_instruction": map[string]interface{}{
 			"parts": []interface{}{
 				map[string]interface{}{
-					"text": prompt,
+					"text": promptText,
 				},
 			},
 		},
 		"contents": []interface{}{
 			map[string]interface{}{
+				"role": "user",
 				"parts": []interface{}{
 					map[string]interface{}{
 						"file_data": map[string]interface{}{
@@ -427,7 +723,10 @@ func AskModelWithUrl(urlStr string, config ai.Aiconfig) (AiResponse, error) {
 			},
 		},
 	}
-	resp, err := askModelRequest(payload, config)
+	started := time.Now()
+	resp, err := askModelRequest(ctx, payload, config)
+	resp.Metadata.Durations.Generation = time.Since(started).Milliseconds()
+	withRequestMetadata(&resp, prompt, locale)
 	if err == nil {
 		resp.Recipe.Url = urlStr
 	}
@@ -0,0 +1,241 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/gallery"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/i18n"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing/tracingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAiResponse(locale string) AiResponse {
+	var res AiResponse
+	res.Recipe = Recipe{
+		Title:       "Tortilla",
+		Description: "Tortilla de patatas",
+		Servings:    4,
+		Ingredients: []Ingredient{
+			{Name: "huevos", Quantity: "6", Unit: "ud"},
+			{Name: "cebolla", Quantity: "1", Unit: "ud", Optional: true},
+		},
+		Sections: []Section{
+			{Instructions: []Instruction{{Text: "Batir los huevos"}}},
+			{Instructions: []Instruction{{Text: "Cuajar la tortilla", Optional: true}}},
+		},
+		Url: "https://example.com/video",
+	}
+	res.Metadata.Locale = locale
+	return res
+}
+
+func Test_FormatToMarkdown_Locales(t *testing.T) {
+	tests := map[string][]string{
+		"es-ES": {"## Ingredientes", "**Porciones:** 4", "(Opcional)", "### Sección 2", "_(opcional)_", "- Fibra: 0 g", "[Ver receta original]"},
+		"en-US": {"## Ingredients", "**Servings:** 4", "(Optional)", "## Instructions", "### Section 2", "- Fiber: 0 g", "**Cook time:** 0 min"},
+		"en-GB": {"## Ingredients", "**Serves:** 4", "## Method", "### Part 2", "- Fibre: 0 g", "**Cooking time:** 0 mins"},
+	}
+
+	for locale, expected := range tests {
+		t.Run(locale, func(t *testing.T) {
+			markdown := FormatToMarkdown(newTestAiResponse(locale))
+
+			assert.Contains(t, markdown, "# Tortilla")
+			for _, text := range expected {
+				assert.Contains(t, markdown, text)
+			}
+		})
+	}
+}
+
+func Test_FormatToMarkdown_DefaultsToSpanish(t *testing.T) {
+	markdown := FormatToMarkdown(newTestAiResponse(""))
+
+	assert.Contains(t, markdown, "## Ingredientes")
+}
+
+func Test_RecipeSchema(t *testing.T) {
+	schema := recipeSchema.Gemini()
+	properties := schema["properties"].(map[string]interface{})
+
+	assert.NotContains(t, properties, "url", "the url is filled by the application, not by the model")
+	assert.Equal(t, "INTEGER", properties["servings"].(map[string]interface{})["type"])
+	assert.Equal(t, "STRING", properties["notes"].(map[string]interface{})["type"])
+
+	difficulty := properties["difficulty"].(map[string]interface{})
+	assert.Equal(t, 1.0, difficulty["minimum"])
+	assert.Equal(t, 3.0, difficulty["maximum"])
+
+	ingredients := properties["ingredients"].(map[string]interface{})
+	ingredient := ingredients["items"].(map[string]interface{})
+	assert.Equal(t, []string{"name", "quantity", "unit", "optional"}, ingredient["required"])
+}
+
+// fakeFileAPI implements the upload, state, generation and delete endpoints
+// used by AskModelWithFile. The file becomes ACTIVE on the second state
+// request, and deleting it answers with deleteStatus.
+func fakeFileAPI(t *testing.T, deleteStatus int) *httptest.Server {
+	var server *httptest.Server
+	polls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /upload/v1beta/files", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Goog-Upload-URL", server.URL+"/upload")
+	})
+	mux.HandleFunc("POST /upload", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"file": map[string]interface{}{"uri": server.URL + "/files/video"}})
+	})
+	mux.HandleFunc("GET /files/video", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		state := "PROCESSING"
+		if polls > 1 {
+			state = "ACTIVE"
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"state": state})
+	})
+	mux.HandleFunc("DELETE /files/video", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(deleteStatus)
+	})
+	mux.HandleFunc("POST /v1beta/models/", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"candidates": []interface{}{
+				map[string]interface{}{
+					"content": map[string]interface{}{
+						"parts": []interface{}{map[string]interface{}{"text": validRecipeJson}},
+					},
+				},
+			},
+			"usageMetadata": map[string]interface{}{"promptTokenCount": 100, "candidatesTokenCount": 10},
+		})
+	})
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func Test_AskModelWithFile_TracesStages(t *testing.T) {
+	exporter := tracingtest.Record(t)
+	server := fakeFileAPI(t, http.StatusOK)
+
+	filePath := filepath.Join(t.TempDir(), "video.mp4")
+	require.NoError(t, os.WriteFile(filePath, []byte("fake video"), 0o600))
+
+	registry, err := NewPromptRegistry("")
+	require.NoError(t, err)
+	prompt, err := registry.Latest(ExtractRecipePromptID)
+	require.NoError(t, err)
+
+	res, err := AskModelWithFile(context.Background(), gallery.DownloadResult{FilePath: filePath, MimeType: "video/mp4", Url: "https://example.com/video"},
+		prompt, i18n.DefaultLocale(), ai.Aiconfig{Provider: "google", Model: "gemini-test", BaseUrl: server.URL})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Tortilla", res.Recipe.Title)
+	assert.Equal(t, []string{"google-ai.upload", "google-ai.activation", "google-ai.generate", "google-ai.delete"}, tracingtest.Names(exporter))
+	assert.Empty(t, res.Metadata.ProviderFile)
+
+	upload := tracingtest.Attributes(tracingtest.Span(t, exporter, "google-ai.upload"))
+	assert.Equal(t, int64(len("fake video")), upload["file.size"].AsInt64())
+	activation := tracingtest.Attributes(tracingtest.Span(t, exporter, "google-ai.activation"))
+	assert.Equal(t, int64(2), activation["google_ai.file.polls"].AsInt64())
+	generate := tracingtest.Attributes(tracingtest.Span(t, exporter, "google-ai.generate"))
+	assert.Equal(t, "gemini-test", generate["gen_ai.request.model"].AsString())
+	assert.Equal(t, int64(100), generate["gen_ai.usage.input_tokens"].AsInt64())
+	assert.Equal(t, int64(10), generate["gen_ai.usage.output_tokens"].AsInt64())
+	assert.Equal(t, int64(http.StatusOK), generate["http.response.status_code"].AsInt64())
+}
+
+func Test_AskModelWithFile_RecordsUndeletedFile(t *testing.T) {
+	server := fakeFileAPI(t, http.StatusInternalServerError)
+
+	filePath := filepath.Join(t.TempDir(), "video.mp4")
+	require.NoError(t, os.WriteFile(filePath, []byte("fake video"), 0o600))
+
+	registry, err := NewPromptRegistry("")
+	require.NoError(t, err)
+	prompt, err := registry.Latest(ExtractRecipePromptID)
+	require.NoError(t, err)
+
+	res, err := AskModelWithFile(context.Background(), gallery.DownloadResult{FilePath: filePath, MimeType: "video/mp4"},
+		prompt, i18n.DefaultLocale(), ai.Aiconfig{Provider: "google", Model: "gemini-test", BaseUrl: server.URL})
+
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/files/video", res.Metadata.ProviderFile)
+	assert.NoError(t, DeleteFile(context.Background(), server.URL+"/files/missing", ai.Aiconfig{}), "missing files are ignored")
+}
+
+func Test_AskModelWithFile_RecordsDurationsOfFailedStages(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "video.mp4")
+	require.NoError(t, os.WriteFile(filePath, []byte("fake video"), 0o600))
+	registry, err := NewPromptRegistry("")
+	require.NoError(t, err)
+	prompt, err := registry.Latest(ExtractRecipePromptID)
+	require.NoError(t, err)
+	download := gallery.DownloadResult{FilePath: filePath, MimeType: "video/mp4"}
+
+	t.Run("upload", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(5 * time.Millisecond)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		t.Cleanup(server.Close)
+
+		res, err := AskModelWithFile(context.Background(), download, prompt, i18n.DefaultLocale(), ai.Aiconfig{Provider: "google", Model: "gemini-test", BaseUrl: server.URL})
+
+		assert.ErrorContains(t, err, "could not upload file")
+		assert.Positive(t, res.Metadata.Durations.Upload)
+	})
+
+	t.Run("activation", func(t *testing.T) {
+		var server *httptest.Server
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /upload/v1beta/files", func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(5 * time.Millisecond)
+			w.Header().Set("X-Goog-Upload-URL", server.URL+"/upload")
+		})
+		mux.HandleFunc("POST /upload", func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{"file": map[string]interface{}{"uri": server.URL + "/files/video"}})
+		})
+		mux.HandleFunc("GET /files/video", func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(5 * time.Millisecond)
+			json.NewEncoder(w).Encode(map[string]interface{}{"state": "FAILED"})
+		})
+		mux.HandleFunc("DELETE /files/video", func(w http.ResponseWriter, r *http.Request) {})
+		server = httptest.NewServer(mux)
+		t.Cleanup(server.Close)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		res, err := AskModelWithFile(ctx, download, prompt, i18n.DefaultLocale(), ai.Aiconfig{Provider: "google", Model: "gemini-test", BaseUrl: server.URL})
+
+		assert.ErrorContains(t, err, "file not ACTIVE")
+		assert.Positive(t, res.Metadata.Durations.Upload)
+		assert.Positive(t, res.Metadata.Durations.Activation)
+	})
+}
+
+func Test_CheckModel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1beta/models/gemini-test" || r.URL.Query().Get("key") != "secret" {
+			http.Error(w, `{"error": {"status": "PERMISSION_DENIED"}}`, http.StatusForbidden)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"name": "models/gemini-test"})
+	}))
+	defer server.Close()
+
+	config := ai.Aiconfig{Provider: "google", ApiKey: "secret", Model: "gemini-test", BaseUrl: server.URL + "/"}
+	assert.NoError(t, CheckModel(context.Background(), config))
+
+	config.ApiKey = "wrong"
+	err := CheckModel(context.Background(), config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+}
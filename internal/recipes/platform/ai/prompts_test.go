@@ -0,0 +1,112 @@
+package ai
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PromptRegistry_RendersEmbeddedPromptForLocales(t *testing.T) {
+	registry, err := NewPromptRegistry("")
+	require.NoError(t, err)
+
+	prompt, err := registry.Get(ExtractRecipePromptID, "v1")
+	require.NoError(t, err)
+
+	tests := map[string][]string{
+		"es-ES": {"español de España", "sistema métrico", "cdta"},
+		"en-US": {"American English", "US customary units", "cup, tbsp"},
+		"en-GB": {"British English", "metric units", "millilitres"},
+	}
+
+	for tag, expected := range tests {
+		t.Run(tag, func(t *testing.T) {
+			locale, err := i18n.ParseLocale(tag)
+			require.NoError(t, err)
+
+			text, err := prompt.Render(locale)
+			require.NoError(t, err)
+
+			for _, s := range expected {
+				assert.Contains(t, text, s)
+			}
+			assert.NotContains(t, text, "{{")
+			assert.NotRegexp(t, "(?m)^[ \t]+", text)
+		})
+	}
+}
+
+func Test_Prompt_Render_UnknownLanguage(t *testing.T) {
+	registry, err := NewPromptRegistry("")
+	require.NoError(t, err)
+	prompt, err := registry.Latest(ExtractRecipePromptID)
+	require.NoError(t, err)
+
+	_, err = prompt.Render(i18n.Locale{Tag: "fr-FR", Language: "fr"})
+
+	assert.Error(t, err)
+}
+
+func Test_PromptRegistry_OverrideDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ExtractRecipePromptID, "v1"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ExtractRecipePromptID, "v10"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ExtractRecipePromptID, "v1", "en.tmpl"), []byte("overridden {{.Name}}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ExtractRecipePromptID, "v10", "en.tmpl"), []byte("new {{.Name}}"), 0644))
+
+	registry, err := NewPromptRegistry(dir)
+	require.NoError(t, err)
+	locale, _ := i18n.ParseLocale("en-GB")
+
+	v1, err := registry.Get(ExtractRecipePromptID, "v1")
+	require.NoError(t, err)
+	text, err := v1.Render(locale)
+	require.NoError(t, err)
+	assert.Equal(t, "overridden British English", text)
+
+	// El resto de idiomas de la versión siguen viniendo de los prompts embebidos
+	es, _ := i18n.ParseLocale("es-ES")
+	text, err = v1.Render(es)
+	require.NoError(t, err)
+	assert.Contains(t, text, "español de España")
+
+	latest, err := registry.Latest(ExtractRecipePromptID)
+	require.NoError(t, err)
+	assert.Equal(t, "v10", latest.Version)
+}
+
+func Test_PromptRegistry_Select(t *testing.T) {
+	registry := &PromptRegistry{prompts: map[string]map[string]Prompt{}, variants: map[string][]PromptVariant{}}
+	require.NoError(t, registry.Load(fstest.MapFS{
+		"test/v1/en.tmpl": {Data: []byte("v1")},
+		"test/v2/en.tmpl": {Data: []byte("v2")},
+	}))
+
+	prompt, err := registry.Select("test", "any")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", prompt.Version, "latest version is used without variants")
+
+	assert.ErrorIs(t, registry.SetVariants("test", map[string]int{"v3": 10}), ErrPromptNotFound)
+
+	require.NoError(t, registry.SetVariants("test", map[string]int{"v1": 75, "v2": 25}))
+
+	counts := map[string]int{}
+	for i := 0; i < 2000; i++ {
+		key := fmt.Sprintf("extraction-%d", i)
+		prompt, err := registry.Select("test", key)
+		require.NoError(t, err)
+		counts[prompt.Version]++
+
+		again, _ := registry.Select("test", key)
+		assert.Equal(t, prompt.Version, again.Version, "assignment must be stable for the same key")
+	}
+
+	assert.InDelta(t, 1500, counts["v1"], 150)
+	assert.InDelta(t, 500, counts["v2"], 150)
+}
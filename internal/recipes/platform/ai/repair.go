@@ -0,0 +1,169 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/ai"
+)
+
+// leadingNumber matches numbers written as text, optionally followed by a unit
+// ("4", "4.5", "4,5", "30 min").
+var leadingNumber = regexp.MustCompile(`^\s*(-?\d+(?:[.,]\d+)?)\b`)
+
+// decodeRecipeWithFixes decodes the answer and, when it is not valid, retries
+// after applying the deterministic fixes. The returned error is the one of the
+// fixed answer, which is the one the model has to correct.
+func decodeRecipeWithFixes(text string) (Recipe, error) {
+	recipe, err := decodeAnswer(text)
+	if err != nil && !errors.Is(err, recipesdomain.ErrNotARecipe) {
+		fixed, fixErr := fixRecipeJSON(text)
+		if fixErr != nil {
+			return Recipe{}, err
+		}
+		recipe, err = decodeAnswer(fixed)
+	}
+	if err != nil {
+		return Recipe{}, err
+	}
+
+	if recipe.TotalTime == 0 {
+		recipe.TotalTime = recipe.PrepTime + recipe.CookTime
+	}
+	return recipe, nil
+}
+
+// fixRecipeJSON applies the fixes that do not need the model: it removes
+// Markdown code fences and text around the JSON object and coerces the values
+// whose type does not match the answer schema (numbers written as strings and
+// vice versa).
+func fixRecipeJSON(text string) (string, error) {
+	text = stripCodeFences(text)
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return "", err
+	}
+
+	// Las respuestas de los prompts anteriores a v3 son la receta sin envolver
+	schema := recipeSchema
+	if object, ok := value.(map[string]interface{}); ok {
+		if _, ok := object["is_recipe"]; ok {
+			schema = answerSchema
+		}
+	}
+
+	fixed, err := json.Marshal(coerceToSchema(value, schema))
+	if err != nil {
+		return "", err
+	}
+	return string(fixed), nil
+}
+
+func stripCodeFences(text string) string {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "```") {
+		// Elimina la primera línea (```json) y el cierre
+		if i := strings.Index(text, "\n"); i >= 0 {
+			text = text[i+1:]
+		}
+		text = strings.TrimSuffix(strings.TrimSpace(text), "```")
+	}
+
+	start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+	if start >= 0 && end > start {
+		text = text[start : end+1]
+	}
+	return strings.TrimSpace(text)
+}
+
+func coerceToSchema(value interface{}, schema *ai.Schema) interface{} {
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		for name, property := range schema.Properties {
+			if v, ok := object[name]; ok {
+				object[name] = coerceToSchema(v, property)
+			}
+		}
+		return object
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return value
+		}
+		for i, item := range array {
+			array[i] = coerceToSchema(item, schema.Items)
+		}
+		return array
+	case "integer", "number":
+		text, ok := value.(string)
+		if !ok {
+			return value
+		}
+		match := leadingNumber.FindStringSubmatch(text)
+		if match == nil {
+			return value
+		}
+		n, err := strconv.ParseFloat(strings.Replace(match[1], ",", ".", 1), 64)
+		if err != nil {
+			return value
+		}
+		if schema.Type == "integer" {
+			return math.Round(n)
+		}
+		return n
+	case "string":
+		if n, ok := value.(float64); ok {
+			return strconv.FormatFloat(n, 'f', -1, 64)
+		}
+		return value
+	case "boolean":
+		if text, ok := value.(string); ok {
+			if b, err := strconv.ParseBool(strings.TrimSpace(text)); err == nil {
+				return b
+			}
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// withRepairRequest returns a copy of the payload that continues the
+// conversation with the invalid answer and asks the model to correct it.
+func withRepairRequest(payload map[string]interface{}, previous string, cause error) map[string]interface{} {
+	request := make(map[string]interface{}, len(payload))
+	for k, v := range payload {
+		request[k] = v
+	}
+
+	contents, _ := payload["contents"].([]interface{})
+	request["contents"] = append(append([]interface{}{}, contents...),
+		map[string]interface{}{
+			"role": "model",
+			"parts": []interface{}{
+				map[string]interface{}{"text": previous},
+			},
+		},
+		map[string]interface{}{
+			"role": "user",
+			"parts": []interface{}{
+				map[string]interface{}{"text": fmt.Sprintf(
+					"Your previous answer is not a valid recipe: %s. Answer again with the complete corrected JSON only, following the response schema.",
+					cause,
+				)},
+			},
+		},
+	)
+	return request
+}
@@ -0,0 +1,204 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validRecipeJson = `{
+	"title": "Tortilla",
+	"description": "Tortilla de patatas",
+	"servings": 4,
+	"prep_time": 10,
+	"cook_time": 20,
+	"total_time": 30,
+	"difficulty": 2,
+	"ingredients": [{"name": "huevos", "quantity": "6", "unit": "ud", "optional": false}],
+	"sections": [{"instructions": [{"optional": false, "text": "Batir los huevos"}]}],
+	"notes": "",
+	"nutritional_info": {"calories": 150, "protein": 7, "carbohydrates": 12, "fats": 8, "fiber": 1, "sugar": 1}
+}`
+
+func Test_DecodeRecipeWithFixes(t *testing.T) {
+	fenced := "```json\n" + strings.NewReplacer(
+		`"servings": 4`, `"servings": "4 personas"`,
+		`"total_time": 30`, `"total_time": "0"`,
+		`"calories": 150`, `"calories": "150,5"`,
+		`"quantity": "6"`, `"quantity": 6`,
+	).Replace(validRecipeJson) + "\n```"
+
+	recipe, err := decodeRecipeWithFixes(fenced)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, recipe.Servings)
+	assert.Equal(t, 30, recipe.TotalTime, "total time is filled from prep and cook time")
+	assert.Equal(t, 150.5, recipe.NutritionalInfo.Calories)
+	assert.Equal(t, "6", recipe.Ingredients[0].Quantity)
+}
+
+func Test_DecodeRecipeWithFixes_Invalid(t *testing.T) {
+	_, err := decodeRecipeWithFixes(strings.Replace(validRecipeJson, `"title": "Tortilla"`, `"title": ""`, 1))
+
+	assert.ErrorContains(t, err, "Title")
+}
+
+// fakeModel answers each generateContent request with the next text and
+// records the requests received.
+func fakeModel(t *testing.T, answers ...string) (*httptest.Server, *[]map[string]interface{}) {
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &payload))
+		requests = append(requests, payload)
+
+		answer := answers[0]
+		if len(requests) <= len(answers) {
+			answer = answers[len(requests)-1]
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"candidates": []interface{}{
+				map[string]interface{}{
+					"content": map[string]interface{}{
+						"parts": []interface{}{map[string]interface{}{"text": answer}},
+					},
+				},
+			},
+			"usageMetadata": map[string]interface{}{"promptTokenCount": 100, "candidatesTokenCount": 10},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func askFakeModel(t *testing.T, config ai.Aiconfig) (AiResponse, error) {
+	registry, err := NewPromptRegistry("")
+	require.NoError(t, err)
+	prompt, err := registry.Latest(ExtractRecipePromptID)
+	require.NoError(t, err)
+
+	return AskModelWithUrl(context.Background(), "https://example.com/video", prompt, i18n.DefaultLocale(), config)
+}
+
+func Test_AskModel_RepairsInvalidAnswer(t *testing.T) {
+	invalid := strings.Replace(validRecipeJson, `"difficulty": 2,`, ``, 1)
+	server, requests := fakeModel(t, invalid, validRecipeJson)
+
+	res, err := askFakeModel(t, ai.Aiconfig{BaseUrl: server.URL, RepairAttempts: 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Tortilla", res.Recipe.Title)
+	assert.Equal(t, 1, res.Metadata.RepairAttempts)
+	assert.Equal(t, 200, res.Metadata.PromptTokenCount)
+	assert.Equal(t, 20, res.Metadata.CandidatesTokenCount)
+
+	require.Len(t, *requests, 2)
+	contents := (*requests)[1]["contents"].([]interface{})
+	require.Len(t, contents, 3)
+	assert.Equal(t, "model", contents[1].(map[string]interface{})["role"])
+	repair, _ := json.Marshal(contents[2])
+	assert.Contains(t, string(repair), "Difficulty")
+}
+
+func Test_AskModel_GivesUpAfterRepairAttempts(t *testing.T) {
+	server, requests := fakeModel(t, "not a recipe")
+
+	res, err := askFakeModel(t, ai.Aiconfig{BaseUrl: server.URL, RepairAttempts: 2})
+
+	assert.ErrorContains(t, err, "after 2 repair attempts")
+	assert.Equal(t, recipesdomain.ExtractionErrorValidation, recipesdomain.ExtractionErrorCategoryOf(err))
+	assert.Len(t, *requests, 3)
+	assert.Equal(t, 300, res.Metadata.PromptTokenCount, "the tokens of failed answers are accounted for")
+	assert.Equal(t, ExtractRecipePromptID, res.Metadata.PromptID)
+}
+
+func Test_AskModel_ProviderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "quota exceeded", http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := askFakeModel(t, ai.Aiconfig{BaseUrl: server.URL, RepairAttempts: 2})
+
+	assert.Error(t, err)
+	assert.Equal(t, recipesdomain.ExtractionErrorProvider, recipesdomain.ExtractionErrorCategoryOf(err))
+}
+
+func Test_AskModel_DeterministicFixesNeedNoRequest(t *testing.T) {
+	server, requests := fakeModel(t, "```json\n"+validRecipeJson+"\n```")
+
+	res, err := askFakeModel(t, ai.Aiconfig{BaseUrl: server.URL, RepairAttempts: 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Metadata.RepairAttempts)
+	assert.Len(t, *requests, 1)
+}
+
+func Test_DecodeAnswer(t *testing.T) {
+	recipe, err := decodeAnswer(`{"is_recipe": true, "recipe": ` + validRecipeJson + `}`)
+	require.NoError(t, err)
+	assert.Equal(t, "Tortilla", recipe.Title)
+
+	recipe, err = decodeAnswer(validRecipeJson)
+	require.NoError(t, err, "bare recipes of the prompts before v3 are accepted")
+	assert.Equal(t, "Tortilla", recipe.Title)
+
+	_, err = decodeAnswer(`{"is_recipe": true}`)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, recipesdomain.ErrNotARecipe)
+}
+
+func Test_DecodeAnswer_NotARecipe(t *testing.T) {
+	_, err := decodeAnswer(`{"is_recipe": false, "reason": "es un vídeo de música"}`)
+	var notARecipe *recipesdomain.NotARecipeError
+	require.ErrorAs(t, err, &notARecipe)
+	assert.Equal(t, "es un vídeo de música", notARecipe.Reason)
+
+	_, err = decodeAnswer(`{}`)
+	assert.ErrorIs(t, err, recipesdomain.ErrNotARecipe, "older prompts return an empty object")
+}
+
+func Test_AskModel_NotARecipe(t *testing.T) {
+	server, requests := fakeModel(t, `{"is_recipe": false, "reason": "es un vídeo de música"}`)
+
+	res, err := askFakeModel(t, ai.Aiconfig{BaseUrl: server.URL, RepairAttempts: 2})
+
+	assert.ErrorIs(t, err, recipesdomain.ErrNotARecipe)
+	assert.Len(t, *requests, 1, "not a recipe is not repaired")
+	assert.Equal(t, "es un vídeo de música", res.Metadata.Reason)
+	assert.Equal(t, 100, res.Metadata.PromptTokenCount)
+	assert.Equal(t, i18n.DefaultLocaleTag, res.Metadata.Locale)
+}
+
+func Test_AskModel_SendsTheSchemaOfThePromptVersion(t *testing.T) {
+	registry, err := NewPromptRegistry("")
+	require.NoError(t, err)
+
+	for version, isRecipe := range map[string]bool{"v1": false, "v2": false, "v3": true} {
+		t.Run(version, func(t *testing.T) {
+			server, requests := fakeModel(t, validRecipeJson)
+			prompt, err := registry.Get(ExtractRecipePromptID, version)
+			require.NoError(t, err)
+
+			_, err = AskModelWithUrl(context.Background(), "https://example.com/video", prompt, i18n.DefaultLocale(), ai.Aiconfig{BaseUrl: server.URL})
+			require.NoError(t, err)
+
+			require.Len(t, *requests, 1)
+			schema := (*requests)[0]["generation_config"].(map[string]interface{})["response_schema"].(map[string]interface{})
+			properties := schema["properties"].(map[string]interface{})
+			assert.Equal(t, isRecipe, properties["is_recipe"] != nil)
+			assert.Equal(t, !isRecipe, properties["title"] != nil)
+		})
+	}
+}
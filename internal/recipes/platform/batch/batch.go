@@ -0,0 +1,371 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/create"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/get"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/quota"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/i18n"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+// Estados de los resultados de un lote, además de los de las extracciones.
+const (
+	StatusSkipped = "skipped"
+	// StatusQuotaExceeded es el de las URLs que no se extraen porque se ha
+	// agotado la cuota durante el lote.
+	StatusQuotaExceeded = "quota_exceeded"
+)
+
+// Motivos por los que se salta una URL.
+const (
+	ReasonAlreadyExtracted = "already extracted"
+	ReasonDuplicated       = "duplicated"
+)
+
+// Result es una línea del informe de un lote.
+type Result struct {
+	Line int    `json:"line"`
+	Url  string `json:"url"`
+	// Status es el estado de la extracción (succeeded, not_a_recipe o failed),
+	// skipped o quota_exceeded.
+	Status string `json:"status"`
+	// RecipeID es el ID de la extracción registrada, también la existente de
+	// una URL que se salta.
+	RecipeID      string            `json:"recipeId,omitempty"`
+	Tokens        int               `json:"tokens"`
+	ErrorCategory string            `json:"errorCategory,omitempty"`
+	Error         string            `json:"error,omitempty"`
+	Reason        string            `json:"reason,omitempty"`
+	Fields        map[string]string `json:"fields,omitempty"`
+}
+
+// Summary cuenta los resultados de un lote por estado.
+type Summary struct {
+	Total         int `json:"total"`
+	Succeeded     int `json:"succeeded"`
+	NotARecipe    int `json:"notARecipe"`
+	Failed        int `json:"failed"`
+	Skipped       int `json:"skipped"`
+	QuotaExceeded int `json:"quotaExceeded"`
+	Tokens        int `json:"tokens"`
+}
+
+func (s *Summary) add(res Result) {
+	s.Total++
+	s.Tokens += res.Tokens
+	switch res.Status {
+	case recipesdomain.ExtractionStatusSucceeded:
+		s.Succeeded++
+	case recipesdomain.ExtractionStatusNotARecipe:
+		s.NotARecipe++
+	case recipesdomain.ExtractionStatusFailed:
+		s.Failed++
+	case StatusSkipped:
+		s.Skipped++
+	case StatusQuotaExceeded:
+		s.QuotaExceeded++
+	}
+}
+
+// Extractor extrae la receta de una URL. Devuelve la respuesta también cuando
+// hay un error, con el ID de la extracción, que está vacío si no ha empezado
+// (ver handlers.ExtractRecipe).
+type Extractor func(ctx context.Context, url string, locale i18n.Locale) (ai.AiResponse, string, error)
+
+type Options struct {
+	// UserID es el usuario bajo el que se registran las extracciones.
+	UserID string
+	Locale i18n.Locale
+	// OrganizationID es la organización en cuya biblioteca se registran las
+	// extracciones, o vacío. Con organización se saltan las URLs que ya están
+	// en su biblioteca en lugar de las del usuario.
+	OrganizationID string
+	// Concurrency es el número de extracciones simultáneas; 1 si es menor.
+	Concurrency int
+	// State es el estado de una ejecución anterior del lote, que se reanuda, y
+	// donde se registran los nuevos resultados. Es opcional (nil).
+	State *State
+	// Quota comprueba la cuota antes de cada extracción (ver quota.Checker).
+	// Una vez agotada no se empiezan más extracciones y las URLs pendientes
+	// se informan como quota_exceeded, sin registrarlas en el estado para
+	// extraerlas al reanudar el lote. Es opcional (nil).
+	Quota func(ctx context.Context) error
+}
+
+type Runner struct {
+	extract    Extractor
+	commandBus command.Bus
+	queryBus   query.Bus
+}
+
+// NewRunner returns the runner of the batches, which stores the extractions
+// with the command bus and finds the URLs already extracted with the query
+// bus.
+func NewRunner(extract Extractor, commandBus command.Bus, queryBus query.Bus) Runner {
+	return Runner{
+		extract:    extract,
+		commandBus: commandBus,
+		queryBus:   queryBus,
+	}
+}
+
+// Run extrae las recetas de las URLs y llama a report con el resultado de
+// cada una, desde una sola goroutine, en el orden en que terminan. Se saltan
+// las URLs repetidas y las que el usuario ya ha extraído, salvo las que
+// fallaron; al reanudar un lote se repiten los resultados de la ejecución
+// anterior, también salvo los fallidos, que se vuelven a intentar.
+//
+// Si el contexto se cancela no se empiezan más extracciones y las que estaban
+// en curso no se registran ni se informan, para repetirlas al reanudar el
+// lote; Run devuelve entonces el error del contexto.
+func (r Runner) Run(ctx context.Context, items []Item, opts Options, report func(Result) error) (Summary, error) {
+	var summary Summary
+	emit := func(res Result, record bool) error {
+		if record && opts.State != nil {
+			if err := opts.State.Record(res); err != nil {
+				return err
+			}
+		}
+		summary.add(res)
+		return report(res)
+	}
+
+	extracted, err := r.extracted(ctx, opts)
+	if err != nil {
+		return summary, err
+	}
+
+	var pending []Item
+	seen := map[string]bool{}
+	for _, item := range items {
+		skipped := Result{Line: item.Line, Url: item.Url, Status: StatusSkipped, Fields: item.Fields}
+		previous, resumed := opts.State.previous(item.Url)
+		switch {
+		case seen[item.Url]:
+			skipped.Reason = ReasonDuplicated
+			err = emit(skipped, false)
+		case resumed:
+			previous.Line, previous.Fields = item.Line, item.Fields
+			err = emit(previous, false)
+		case extracted[item.Url] != "":
+			skipped.Reason = ReasonAlreadyExtracted
+			skipped.RecipeID = extracted[item.Url]
+			err = emit(skipped, true)
+		default:
+			pending = append(pending, item)
+		}
+		if err != nil {
+			return summary, err
+		}
+		seen[item.Url] = true
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan Item)
+	go func() {
+		defer close(jobs)
+		for _, item := range pending {
+			select {
+			case jobs <- item:
+			case <-runCtx.Done():
+				return
+			}
+		}
+	}()
+
+	gate := &quotaGate{check: opts.Quota}
+	concurrency := max(opts.Concurrency, 1)
+	results := make(chan Result)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range jobs {
+				if res, ok := r.run(runCtx, item, opts, gate); ok {
+					results <- res
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var reportErr error
+	for res := range results {
+		// Tras un error se siguen leyendo los resultados hasta que terminan
+		// las extracciones en curso
+		if reportErr != nil {
+			continue
+		}
+		if err := emit(res, res.Status != StatusQuotaExceeded); err != nil {
+			reportErr = err
+			cancel()
+		}
+	}
+	if reportErr != nil {
+		return summary, reportErr
+	}
+	return summary, ctx.Err()
+}
+
+// run extrae y registra la receta de una URL si queda cuota. Devuelve false si
+// la extracción se ha interrumpido.
+func (r Runner) run(ctx context.Context, item Item, opts Options, gate *quotaGate) (Result, bool) {
+	if err := gate.allow(ctx); err != nil {
+		if ctx.Err() != nil {
+			return Result{}, false
+		}
+		result := Result{Line: item.Line, Url: item.Url, Status: StatusQuotaExceeded, Error: err.Error(), Fields: item.Fields}
+		if !quota.Exceeded(err) {
+			result.Status = recipesdomain.ExtractionStatusFailed
+			result.Error = fmt.Sprintf("error checking the quota: %v", err)
+		}
+		return result, true
+	}
+
+	res, id, extractErr := r.extract(ctx, item.Url, opts.Locale)
+	if extractErr != nil && ctx.Err() != nil {
+		return Result{}, false
+	}
+
+	result := Result{
+		Line:          item.Line,
+		Url:           item.Url,
+		Status:        recipesdomain.ExtractionStatusOf(extractErr),
+		Tokens:        res.Metadata.PromptTokenCount + res.Metadata.CandidatesTokenCount,
+		ErrorCategory: recipesdomain.ExtractionErrorCategoryOf(extractErr),
+		Fields:        item.Fields,
+	}
+	if extractErr != nil {
+		result.Error = extractErr.Error()
+	}
+	// La extracción no ha empezado y no hay nada que registrar
+	if id == "" {
+		return result, true
+	}
+
+	if err := r.save(ctx, opts, item.Url, res, id, extractErr); err != nil {
+		result.Status = recipesdomain.ExtractionStatusFailed
+		result.Error = fmt.Sprintf("error saving the extraction: %v", err)
+		return result, true
+	}
+	result.RecipeID = id
+	return result, true
+}
+
+// quotaGate comprueba la cuota de un lote antes de cada extracción. Una vez
+// agotada no se vuelve a comprobar.
+type quotaGate struct {
+	check func(ctx context.Context) error
+
+	mu       sync.Mutex
+	exceeded error
+}
+
+func (g *quotaGate) allow(ctx context.Context) error {
+	if g.check == nil {
+		return nil
+	}
+
+	g.mu.Lock()
+	exceeded := g.exceeded
+	g.mu.Unlock()
+	if exceeded != nil {
+		return exceeded
+	}
+
+	err := g.check(ctx)
+	if quota.Exceeded(err) {
+		g.mu.Lock()
+		g.exceeded = err
+		g.mu.Unlock()
+	}
+	return err
+}
+
+// save registra la extracción, también cuando ha fallado o el vídeo no es una
+// receta, como el endpoint de extracción.
+func (r Runner) save(ctx context.Context, opts Options, url string, res ai.AiResponse, id string, extractErr error) error {
+	data := ""
+	if extractErr == nil {
+		recipe, err := json.Marshal(res.Recipe)
+		if err != nil {
+			return err
+		}
+		data = string(recipe)
+	}
+	metadata, err := json.Marshal(res.Metadata)
+	if err != nil {
+		return err
+	}
+	errorMessage := ""
+	if extractErr != nil {
+		errorMessage = extractErr.Error()
+	}
+
+	// La extracción se registra aunque el lote se haya interrumpido
+	return r.commandBus.Dispatch(
+		context.WithoutCancel(ctx),
+		create.NewExtractionCommand(
+			id,
+			opts.UserID,
+			opts.OrganizationID,
+			url,
+			recipesdomain.ExtractionStatusOf(extractErr),
+			recipesdomain.ExtractionErrorCategoryOf(extractErr),
+			errorMessage,
+			data,
+			string(metadata),
+			time.Now().Format(time.RFC3339),
+		),
+	)
+}
+
+// extracted devuelve el ID de la última extracción de cada URL del usuario, o
+// de la biblioteca de la organización, que no ha fallado.
+func (r Runner) extracted(ctx context.Context, opts Options) (map[string]string, error) {
+	qry := get.NewExtractionQuery(opts.UserID)
+	if opts.OrganizationID != "" {
+		qry = get.NewOrganizationExtractionQuery(opts.OrganizationID)
+	}
+	result, err := r.queryBus.Ask(ctx, qry)
+	if err != nil {
+		return nil, fmt.Errorf("error finding the extractions of the user: %w", err)
+	}
+	extractions, ok := result.([]recipesdomain.Extraction)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result of the extractions query: %T", result)
+	}
+
+	urls := map[string]string{}
+	for _, extraction := range extractions {
+		if extraction.Status.String() != recipesdomain.ExtractionStatusFailed {
+			urls[extraction.SourceUrl] = extraction.Id.String()
+		}
+	}
+	return urls, nil
+}
+
+// WriteJSONL devuelve una función de informe que escribe cada resultado como
+// una línea JSON.
+func WriteJSONL(w io.Writer) func(Result) error {
+	encoder := json.NewEncoder(w)
+	return func(res Result) error {
+		return encoder.Encode(res)
+	}
+}
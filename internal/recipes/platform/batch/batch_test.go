@@ -0,0 +1,228 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/create"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/i18n"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/rubenbupe/recipe-video-parser/kit/query/querymocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const userID = "37a0f027-15e6-47cc-a5d2-64183281087e"
+
+// fakeExtractor extrae las URLs con el resultado indicado para cada una, o
+// una receta si no hay ninguno.
+type fakeExtractor struct {
+	mu     sync.Mutex
+	errs   map[string]error
+	called []string
+}
+
+func (f *fakeExtractor) extract(ctx context.Context, url string, locale i18n.Locale) (ai.AiResponse, string, error) {
+	f.mu.Lock()
+	f.called = append(f.called, url)
+	f.mu.Unlock()
+
+	var res ai.AiResponse
+	res.Recipe.Title = "Tortilla"
+	res.Metadata.PromptTokenCount = 100
+	res.Metadata.CandidatesTokenCount = 20
+	return res, "8c5e5d2b-8d0a-4c0b-9f0a-6a6f4c1d2e3f", f.errs[url]
+}
+
+func newQueryBus(t *testing.T, extractions ...recipesdomain.Extraction) *querymocks.Bus {
+	t.Helper()
+	bus := new(querymocks.Bus)
+	bus.On("Ask", mock.Anything, mock.Anything).Return(extractions, nil)
+	return bus
+}
+
+func newExtraction(t *testing.T, id, url, status string) recipesdomain.Extraction {
+	t.Helper()
+	category, data := "", `{"title":"Tortilla"}`
+	if status != recipesdomain.ExtractionStatusSucceeded {
+		category, data = recipesdomain.ExtractionErrorProvider, ""
+	}
+	extraction, err := recipesdomain.NewExtraction(id, userID, url, status, category, "", data, "{}", "2024-01-01T00:00:00Z")
+	require.NoError(t, err)
+	return extraction
+}
+
+func run(t *testing.T, runner Runner, items []Item, opts Options) ([]Result, Summary, error) {
+	t.Helper()
+	var results []Result
+	summary, err := runner.Run(context.Background(), items, opts, func(res Result) error {
+		results = append(results, res)
+		return nil
+	})
+	sort.Slice(results, func(i, j int) bool { return results[i].Line < results[j].Line })
+	return results, summary, err
+}
+
+func TestRunner_Run(t *testing.T) {
+	extractor := &fakeExtractor{errs: map[string]error{
+		"https://example.com/not-a-recipe": &recipesdomain.NotARecipeError{Reason: "no food"},
+		"https://example.com/failed":       recipesdomain.NewExtractionError(recipesdomain.ExtractionErrorDownload, errors.New("404")),
+	}}
+	commandBus := new(commandmocks.Bus)
+	commandBus.On("Dispatch", mock.Anything, mock.AnythingOfType("create.ExtractionCommand")).Return(nil)
+	queryBus := newQueryBus(t,
+		newExtraction(t, "11111111-1111-4111-8111-111111111111", "https://example.com/extracted", recipesdomain.ExtractionStatusSucceeded),
+		newExtraction(t, "22222222-2222-4222-8222-222222222222", "https://example.com/failed", recipesdomain.ExtractionStatusFailed),
+	)
+	runner := NewRunner(extractor.extract, commandBus, queryBus)
+
+	items := FromUrls([]string{
+		"https://example.com/recipe",
+		"https://example.com/not-a-recipe",
+		"https://example.com/failed",
+		"https://example.com/extracted",
+		"https://example.com/recipe",
+	})
+	results, summary, err := run(t, runner, items, Options{UserID: userID, Concurrency: 2})
+	require.NoError(t, err)
+
+	require.Len(t, results, 5)
+	assert.Equal(t, recipesdomain.ExtractionStatusSucceeded, results[0].Status)
+	assert.NotEmpty(t, results[0].RecipeID)
+	assert.Equal(t, 120, results[0].Tokens)
+	assert.Equal(t, recipesdomain.ExtractionStatusNotARecipe, results[1].Status)
+	assert.Equal(t, recipesdomain.ExtractionStatusFailed, results[2].Status)
+	assert.Equal(t, recipesdomain.ExtractionErrorDownload, results[2].ErrorCategory)
+	assert.Equal(t, "404", results[2].Error)
+	assert.Equal(t, Result{Line: 4, Url: "https://example.com/extracted", Status: StatusSkipped, RecipeID: "11111111-1111-4111-8111-111111111111", Reason: ReasonAlreadyExtracted}, results[3])
+	assert.Equal(t, ReasonDuplicated, results[4].Reason)
+
+	assert.Equal(t, Summary{Total: 5, Succeeded: 1, NotARecipe: 1, Failed: 1, Skipped: 2, Tokens: 360}, summary)
+	assert.ElementsMatch(t, []string{"https://example.com/recipe", "https://example.com/not-a-recipe", "https://example.com/failed"}, extractor.called)
+	commandBus.AssertNumberOfCalls(t, "Dispatch", 3)
+	commandBus.AssertCalled(t, "Dispatch", mock.Anything, mock.MatchedBy(func(cmd create.ExtractionCommand) bool {
+		return cmd.Type() == create.ExtractionCommandType
+	}))
+}
+
+func TestRunner_Run_Resume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch.state")
+	items := FromUrls([]string{"https://example.com/a", "https://example.com/b"})
+
+	commandBus := new(commandmocks.Bus)
+	commandBus.On("Dispatch", mock.Anything, mock.Anything).Return(nil)
+
+	// La primera ejecución falla en la segunda URL
+	first := &fakeExtractor{errs: map[string]error{"https://example.com/b": errors.New("quota exceeded")}}
+	state, err := OpenState(path)
+	require.NoError(t, err)
+	_, summary, err := run(t, NewRunner(first.extract, commandBus, newQueryBus(t)), items, Options{UserID: userID, State: state})
+	require.NoError(t, err)
+	require.NoError(t, state.Close())
+	assert.Equal(t, 1, summary.Failed)
+
+	// Al reanudarla solo se repite la que falló y el informe está completo
+	second := &fakeExtractor{}
+	state, err = OpenState(path)
+	require.NoError(t, err)
+	defer state.Close()
+	results, summary, err := run(t, NewRunner(second.extract, commandBus, newQueryBus(t)), items, Options{UserID: userID, State: state})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"https://example.com/b"}, second.called)
+	assert.Equal(t, Summary{Total: 2, Succeeded: 2, Tokens: 240}, summary)
+	assert.Equal(t, recipesdomain.ExtractionStatusSucceeded, results[0].Status)
+	assert.Equal(t, recipesdomain.ExtractionStatusSucceeded, results[1].Status)
+}
+
+func TestRunner_Run_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	extract := func(ctx context.Context, url string, locale i18n.Locale) (ai.AiResponse, string, error) {
+		cancel()
+		<-ctx.Done()
+		return ai.AiResponse{}, "8c5e5d2b-8d0a-4c0b-9f0a-6a6f4c1d2e3f", ctx.Err()
+	}
+	commandBus := new(commandmocks.Bus)
+	runner := NewRunner(extract, commandBus, newQueryBus(t))
+
+	var results []Result
+	_, err := runner.Run(ctx, FromUrls([]string{"https://example.com/a", "https://example.com/b"}), Options{UserID: userID}, func(res Result) error {
+		results = append(results, res)
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, results)
+	commandBus.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+}
+
+func TestRunner_Run_QueryError(t *testing.T) {
+	queryBus := new(querymocks.Bus)
+	queryBus.On("Ask", mock.Anything, mock.Anything).Return(nil, errors.New("database is locked"))
+	runner := NewRunner((&fakeExtractor{}).extract, new(commandmocks.Bus), queryBus)
+
+	_, _, err := run(t, runner, FromUrls([]string{"https://example.com/a"}), Options{UserID: userID})
+
+	assert.ErrorContains(t, err, "database is locked")
+}
+
+func TestRunner_Run_QuotaExceeded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch.state")
+	items := FromUrls([]string{"https://example.com/a", "https://example.com/b", "https://example.com/c"})
+	commandBus := new(commandmocks.Bus)
+	commandBus.On("Dispatch", mock.Anything, mock.Anything).Return(nil)
+
+	// La cuota solo alcanza para una extracción
+	var checks int
+	limited := func(ctx context.Context) error {
+		checks++
+		if checks > 1 {
+			return usersdomain.ErrUserQuotaExceeded
+		}
+		return nil
+	}
+	first := &fakeExtractor{}
+	state, err := OpenState(path)
+	require.NoError(t, err)
+	results, summary, err := run(t, NewRunner(first.extract, commandBus, newQueryBus(t)), items, Options{UserID: userID, State: state, Quota: limited})
+	require.NoError(t, err)
+	require.NoError(t, state.Close())
+
+	assert.Equal(t, []string{"https://example.com/a"}, first.called)
+	assert.Equal(t, 2, checks, "the quota is not checked again once exceeded")
+	assert.Equal(t, Summary{Total: 3, Succeeded: 1, QuotaExceeded: 2, Tokens: 120}, summary)
+	assert.Equal(t, Result{Line: 2, Url: "https://example.com/b", Status: StatusQuotaExceeded, Error: usersdomain.ErrUserQuotaExceeded.Error()}, results[1])
+	assert.Equal(t, StatusQuotaExceeded, results[2].Status)
+
+	// Las URLs sin extraer por la cuota se extraen al reanudar el lote
+	second := &fakeExtractor{}
+	state, err = OpenState(path)
+	require.NoError(t, err)
+	defer state.Close()
+	_, summary, err = run(t, NewRunner(second.extract, commandBus, newQueryBus(t)), items, Options{UserID: userID, State: state})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"https://example.com/b", "https://example.com/c"}, second.called)
+	assert.Equal(t, Summary{Total: 3, Succeeded: 3, Tokens: 360}, summary)
+}
+
+func TestRunner_Run_QuotaError(t *testing.T) {
+	extractor := &fakeExtractor{}
+	runner := NewRunner(extractor.extract, new(commandmocks.Bus), newQueryBus(t))
+	failing := func(ctx context.Context) error { return errors.New("database is locked") }
+
+	results, summary, err := run(t, runner, FromUrls([]string{"https://example.com/a"}), Options{UserID: userID, Quota: failing})
+	require.NoError(t, err)
+
+	assert.Empty(t, extractor.called)
+	assert.Equal(t, Summary{Total: 1, Failed: 1}, summary)
+	assert.Equal(t, "error checking the quota: database is locked", results[0].Error)
+}
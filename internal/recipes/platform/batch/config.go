@@ -0,0 +1,23 @@
+package batch
+
+import "errors"
+
+type Config struct {
+	// Concurrency is the number of extractions of a batch run at the same
+	// time.
+	Concurrency int `default:"4"`
+	// MaxUrls is the maximum number of URLs of a batch sent to the API.
+	MaxUrls int `default:"500"`
+}
+
+// Validate checks the values of the config.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Concurrency < 1 {
+		errs = append(errs, errors.New("concurrency must be at least 1"))
+	}
+	if c.MaxUrls < 1 {
+		errs = append(errs, errors.New("at least one URL per batch is required"))
+	}
+	return errors.Join(errs...)
+}
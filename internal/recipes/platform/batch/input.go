@@ -0,0 +1,122 @@
+package batch
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var ErrNoUrlColumn = errors.New("the CSV has no url column")
+
+// Item es una URL de la entrada de un lote.
+type Item struct {
+	// Line es la línea de la entrada, empezando en 1.
+	Line int
+	Url  string
+	// Fields son las demás columnas de un CSV, por nombre de columna.
+	Fields map[string]string
+}
+
+// ReadLines lee una URL por línea. Las líneas vacías y las que empiezan por #
+// se ignoran.
+func ReadLines(r io.Reader) ([]Item, error) {
+	var items []Item
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		url := strings.TrimSpace(scanner.Text())
+		if url == "" || strings.HasPrefix(url, "#") {
+			continue
+		}
+		items = append(items, Item{Line: line, Url: url})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ReadCSV lee un CSV con cabecera y una columna "url". Las demás columnas se
+// conservan en los campos de cada URL y las filas sin URL se ignoran.
+func ReadCSV(r io.Reader) ([]Item, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	urlColumn := -1
+	for i, name := range header {
+		header[i] = strings.TrimSpace(name)
+		if strings.EqualFold(header[i], "url") {
+			urlColumn = i
+		}
+	}
+	if urlColumn == -1 {
+		return nil, ErrNoUrlColumn
+	}
+
+	var items []Item
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if urlColumn >= len(record) || strings.TrimSpace(record[urlColumn]) == "" {
+			continue
+		}
+
+		item := Item{Line: line, Url: strings.TrimSpace(record[urlColumn])}
+		for i, value := range record {
+			if i == urlColumn || i >= len(header) {
+				continue
+			}
+			if item.Fields == nil {
+				item.Fields = map[string]string{}
+			}
+			item.Fields[header[i]] = value
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// FromUrls convierte una lista de URLs en los elementos de un lote.
+func FromUrls(urls []string) []Item {
+	items := make([]Item, 0, len(urls))
+	for i, url := range urls {
+		if url = strings.TrimSpace(url); url != "" {
+			items = append(items, Item{Line: i + 1, Url: url})
+		}
+	}
+	return items
+}
+
+// Read lee la entrada de un lote como CSV o como una URL por línea.
+func Read(r io.Reader, isCSV bool) ([]Item, error) {
+	var items []Item
+	var err error
+	if isCSV {
+		items, err = ReadCSV(r)
+	} else {
+		items, err = ReadLines(r)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading the URLs: %w", err)
+	}
+	return items, nil
+}
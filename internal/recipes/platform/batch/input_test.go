@@ -0,0 +1,64 @@
+package batch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadLines(t *testing.T) {
+	input := "https://example.com/a\n\n# comentario\n  https://example.com/b  \n"
+
+	items, err := Read(strings.NewReader(input), false)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Item{
+		{Line: 1, Url: "https://example.com/a"},
+		{Line: 4, Url: "https://example.com/b"},
+	}, items)
+}
+
+func TestReadCSV(t *testing.T) {
+	t.Run("it keeps the other columns", func(t *testing.T) {
+		input := "creator,URL,notes\nana,https://example.com/a,\"con, coma\"\nana,,sin url\n"
+
+		items, err := Read(strings.NewReader(input), true)
+
+		require.NoError(t, err)
+		assert.Equal(t, []Item{
+			{Line: 2, Url: "https://example.com/a", Fields: map[string]string{"creator": "ana", "notes": "con, coma"}},
+		}, items)
+	})
+
+	t.Run("it fails without url column", func(t *testing.T) {
+		_, err := Read(strings.NewReader("creator,link\nana,https://example.com/a\n"), true)
+
+		assert.ErrorIs(t, err, ErrNoUrlColumn)
+	})
+}
+
+func TestOpenState_TruncatedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch.state")
+	content := `{"line":1,"url":"https://example.com/a","status":"succeeded","tokens":10}` + "\n" + `{"line":2,"url":"https://exa`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	state, err := OpenState(path)
+	require.NoError(t, err)
+	require.NoError(t, state.Record(Result{Line: 3, Url: "https://example.com/c", Status: StatusSkipped}))
+	require.NoError(t, state.Close())
+
+	state, err = OpenState(path)
+	require.NoError(t, err)
+	defer state.Close()
+
+	_, ok := state.previous("https://example.com/a")
+	assert.True(t, ok)
+	_, ok = state.previous("https://example.com/c")
+	assert.True(t, ok)
+	_, ok = state.previous("https://example.com/b")
+	assert.False(t, ok)
+}
@@ -0,0 +1,80 @@
+package batch
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+)
+
+// State es el fichero de estado de un lote, con un resultado JSON por línea,
+// que permite reanudarlo tras una interrupción.
+type State struct {
+	file    *os.File
+	encoder *json.Encoder
+	results map[string]Result
+}
+
+// OpenState lee los resultados del fichero de estado, si existe, y lo abre
+// para añadir los nuevos. Las líneas que no se pueden leer, como la última
+// si el proceso terminó mientras la escribía, se ignoran.
+func OpenState(path string) (*State, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &State{file: file, encoder: json.NewEncoder(file), results: map[string]Result{}}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	last := ""
+	for scanner.Scan() {
+		last = scanner.Text()
+		var res Result
+		if err := json.Unmarshal(scanner.Bytes(), &res); err != nil || res.Url == "" {
+			continue
+		}
+		state.results[res.Url] = res
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	// Una línea a medias se termina para que no se mezcle con la siguiente
+	end, err := file.Seek(0, io.SeekEnd)
+	if err == nil && end > 0 && last != "" && !json.Valid([]byte(last)) {
+		_, err = file.Write([]byte("\n"))
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return state, nil
+}
+
+// previous devuelve el resultado de la URL en una ejecución anterior, salvo
+// si falló o no se extrajo por la cuota.
+func (s *State) previous(url string) (Result, bool) {
+	if s == nil {
+		return Result{}, false
+	}
+	res, ok := s.results[url]
+	if !ok || res.Status == recipesdomain.ExtractionStatusFailed || res.Status == StatusQuotaExceeded {
+		return Result{}, false
+	}
+	return res, true
+}
+
+// Record añade un resultado al fichero.
+func (s *State) Record(res Result) error {
+	s.results[res.Url] = res
+	return s.encoder.Encode(res)
+}
+
+func (s *State) Close() error {
+	return errors.Join(s.file.Sync(), s.file.Close())
+}
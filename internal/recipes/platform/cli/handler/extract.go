@@ -2,58 +2,121 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
 	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
 	gallerydl "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/gallery"
+	recipesmetrics "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/metrics"
 	sharedai "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/ai"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/gallery"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/i18n"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ExtractRecipeInput struct {
-	Url string
+	Url  string
+	Lang string
 }
 
-type ExtractRecipeHandler func(context.Context, ExtractRecipeInput) error
+type ExtractRecipeHandler func(context.Context, ExtractRecipeInput) (*ai.Recipe, error)
 
-// Lógica compartida para extracción de receta
-func ExtractRecipe(url string, galleryConfig *gallery.Galleryconfig, aiConfig *sharedai.Aiconfig) (ai.AiResponse, string, error) {
+// Lógica compartida para extracción de receta. La respuesta se devuelve también
+// cuando hay un error, con los metadatos de la extracción (tokens usados,
+// duración de cada etapa...) para poder registrarla. Los errores se pueden
+// clasificar con recipesdomain.ExtractionErrorCategoryOf. Las métricas son
+// opcionales (nil).
+func ExtractRecipe(ctx context.Context, url string, locale i18n.Locale, prompts *ai.PromptRegistry, galleryConfig *gallery.Galleryconfig, aiConfig *sharedai.Aiconfig, metrics *recipesmetrics.ExtractionMetrics) (ai.AiResponse, string, error) {
 	if url == "" {
 		return ai.AiResponse{}, "", fmt.Errorf("url is required")
 	}
 	id := uuid.New().String()
+	prompt, err := prompts.Select(ai.ExtractRecipePromptID, id)
+	if err != nil {
+		return ai.AiResponse{}, id, err
+	}
+
+	done := metrics.Start()
+	defer done()
+
+	ctx, span := tracing.Start(ctx, "recipes.extract",
+		attribute.String("extraction.id", id),
+		attribute.String("url.full", url),
+		attribute.String("recipe.platform", recipesdomain.PlatformOf(url)),
+		attribute.String("recipe.locale", locale.Tag),
+		attribute.String("recipe.prompt", prompt.ID+"/"+prompt.Version),
+	)
+
+	started := time.Now()
 	var res ai.AiResponse
-	var err error
+	var durationDownload int64
 	if needsDownload(url) {
-		downloaded, errDownload := gallerydl.DownloadFile(url, id, galleryConfig.DownloadDir, galleryConfig.ConfigFile)
+		downloaded, errDownload := gallerydl.DownloadFile(ctx, url, id, galleryConfig.DownloadDir, galleryConfig.ConfigFile)
+		durationDownload = time.Since(started).Milliseconds()
+		metrics.ObserveDownload(errDownload)
 		if errDownload != nil {
-			return ai.AiResponse{}, id, fmt.Errorf("failed to download file: %w", errDownload)
+			res.Metadata.Locale = locale.Tag
+			res.Metadata.PromptID = prompt.ID
+			res.Metadata.PromptVersion = prompt.Version
+			res.Metadata.Provider = aiConfig.Provider
+			res.Metadata.Model = aiConfig.Model
+			res.Metadata.Durations.Download = durationDownload
+			res.Metadata.Durations.Total = durationDownload
+			err = recipesdomain.NewExtractionError(recipesdomain.ExtractionErrorDownload, fmt.Errorf("failed to download file: %w", errDownload))
+			metrics.ObserveExtraction(url, aiConfig.Provider, res, err)
+			endExtractionSpan(span, res, err)
+			return res, id, err
 		}
-		res, err = ai.AskModelWithFile(downloaded, *aiConfig)
+		res, err = ai.AskModelWithFile(ctx, downloaded, prompt, locale, *aiConfig)
 		gallerydl.RemoveFile(downloaded.FilePath)
 	} else {
-		res, err = ai.AskModelWithUrl(url, *aiConfig)
+		res, err = ai.AskModelWithUrl(ctx, url, prompt, locale, *aiConfig)
 	}
-	if err != nil {
-		return ai.AiResponse{}, id, fmt.Errorf("failed to extract recipe: %w", err)
+	res.Metadata.Provider = aiConfig.Provider
+	res.Metadata.Model = aiConfig.Model
+	res.Metadata.Durations.Download = durationDownload
+	res.Metadata.Durations.Total = time.Since(started).Milliseconds()
+	metrics.ObserveExtraction(url, aiConfig.Provider, res, err)
+	endExtractionSpan(span, res, err)
+
+	if err != nil && !errors.Is(err, recipesdomain.ErrNotARecipe) {
+		err = fmt.Errorf("failed to extract recipe: %w", err)
 	}
-	return res, id, nil
+	return res, id, err
 }
 
-func NewExtractRecipeHandler(galleryConfig *gallery.Galleryconfig, aiConfig *sharedai.Aiconfig) ExtractRecipeHandler {
-	return func(ctx context.Context, input ExtractRecipeInput) error {
-		res, _, err := ExtractRecipe(input.Url, galleryConfig, aiConfig)
+// endExtractionSpan añade al span el resultado de la extracción. Que el vídeo
+// no sea una receta no se marca como error.
+func endExtractionSpan(span trace.Span, res ai.AiResponse, err error) {
+	span.SetAttributes(
+		attribute.String("recipe.status", recipesdomain.ExtractionStatusOf(err)),
+		attribute.String("recipe.error_category", recipesdomain.ExtractionErrorCategoryOf(err)),
+		attribute.Int("recipe.repair_attempts", res.Metadata.RepairAttempts),
+		attribute.Int("gen_ai.usage.input_tokens", res.Metadata.PromptTokenCount),
+		attribute.Int("gen_ai.usage.output_tokens", res.Metadata.CandidatesTokenCount),
+	)
+	if errors.Is(err, recipesdomain.ErrNotARecipe) {
+		err = nil
+	}
+	tracing.End(span, err)
+}
+
+func NewExtractRecipeHandler(galleryConfig *gallery.Galleryconfig, aiConfig *sharedai.Aiconfig, prompts *ai.PromptRegistry) ExtractRecipeHandler {
+	return func(ctx context.Context, input ExtractRecipeInput) (*ai.Recipe, error) {
+		locale, err := i18n.ResolveLocale(input.Lang)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		jsonRecipe, err := toJSONString(res.Recipe)
+		res, _, err := ExtractRecipe(ctx, input.Url, locale, prompts, galleryConfig, aiConfig, nil)
 		if err != nil {
-			return fmt.Errorf("failed to serialize recipe to JSON: %w", err)
+			return nil, err
 		}
-		fmt.Println(jsonRecipe)
-		return nil
+		return &res.Recipe, nil
 	}
 }
 
@@ -64,11 +127,3 @@ func needsDownload(url string) bool {
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && (s[:len(substr)] == substr || contains(s[1:], substr)))
 }
-
-func toJSONString(v interface{}) (string, error) {
-	b, err := json.MarshalIndent(v, "", "  ")
-	if err != nil {
-		return "", err
-	}
-	return string(b), nil
-}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/get"
@@ -9,27 +10,44 @@ import (
 	"github.com/rubenbupe/recipe-video-parser/kit/query"
 )
 
+// ErrNoExtractions es el error de un usuario sin extracciones.
+var ErrNoExtractions = errors.New("no se encontraron extracciones")
+
+// GetExtractionInput busca las extracciones del usuario o, si se indica
+// OrganizationID, la biblioteca de la organización.
 type GetExtractionInput struct {
-	UserID string
+	UserID         string
+	OrganizationID string
 }
 
 type GetExtractionOutput struct {
-	ID        string
-	UserID    string
-	Data      string
-	Metadata  string
-	CreatedAt string
+	ID     string
+	UserID string
+	// OrganizationID es la organización de la extracción; vacío si es solo
+	// del usuario
+	OrganizationID string
+	SourceUrl      string
+	Status         string
+	ErrorCategory  string
+	ErrorMessage   string
+	Data           string
+	Metadata       string
+	CreatedAt      string
 }
 
 type GetExtractionHandler func(context.Context, GetExtractionInput) ([]GetExtractionOutput, error)
 
 func CreateGetExtractionsHandler(queryBus query.Bus) GetExtractionHandler {
 	return func(ctx context.Context, input GetExtractionInput) ([]GetExtractionOutput, error) {
-		if input.UserID == "" {
+		if input.UserID == "" && input.OrganizationID == "" {
 			return nil, fmt.Errorf("el campo ID es obligatorio")
 		}
 
-		result, err := queryBus.Ask(ctx, get.NewExtractionQuery(input.UserID))
+		qry := get.NewExtractionQuery(input.UserID)
+		if input.OrganizationID != "" {
+			qry = get.NewOrganizationExtractionQuery(input.OrganizationID)
+		}
+		result, err := queryBus.Ask(ctx, qry)
 		if err != nil {
 			return nil, fmt.Errorf("error al buscar extracciones: %w", err)
 		}
@@ -40,17 +58,25 @@ func CreateGetExtractionsHandler(queryBus query.Bus) GetExtractionHandler {
 		}
 
 		if len(extractions) == 0 {
-			return nil, fmt.Errorf("no se encontraron extracciones para el usuario con ID: %s", input.UserID)
+			if input.OrganizationID != "" {
+				return nil, fmt.Errorf("%w para la organización con ID: %s", ErrNoExtractions, input.OrganizationID)
+			}
+			return nil, fmt.Errorf("%w para el usuario con ID: %s", ErrNoExtractions, input.UserID)
 		}
 
 		outputs := make([]GetExtractionOutput, 0, len(extractions))
 		for _, e := range extractions {
 			outputs = append(outputs, GetExtractionOutput{
-				ID:        e.Id.String(),
-				UserID:    e.UserId.String(),
-				Data:      e.Data,
-				Metadata:  e.Metadata,
-				CreatedAt: e.CreatedAt.String(),
+				ID:             e.Id.String(),
+				UserID:         e.UserId.String(),
+				OrganizationID: e.OrganizationId.String(),
+				SourceUrl:      e.SourceUrl,
+				Status:         e.Status.String(),
+				ErrorCategory:  e.ErrorCategory.String(),
+				ErrorMessage:   e.ErrorMessage,
+				Data:           e.Data,
+				Metadata:       e.Metadata,
+				CreatedAt:      e.CreatedAt.String(),
 			})
 		}
 		return outputs, nil
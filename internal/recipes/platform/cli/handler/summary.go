@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"context"
+	"math"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+// ExtractionsSummary is the usage of a user: the extractions and tokens by
+// month, the failed extractions by category, and the average tokens and the
+// failed and not_a_recipe rates of every prompt version, sorted by month,
+// category and prompt.
+type ExtractionsSummary struct {
+	Months          []MonthSummary    `json:"months"`
+	ErrorCategories []CategorySummary `json:"errorCategories"`
+	Prompts         []PromptSummary   `json:"prompts"`
+}
+
+type MonthSummary struct {
+	// Month is formatted as 2006-01.
+	Month            string `json:"month"`
+	Extractions      int    `json:"extractions"`
+	NotARecipe       int    `json:"notARecipe"`
+	Failed           int    `json:"failed"`
+	PromptTokens     int    `json:"promptTokens"`
+	CandidatesTokens int    `json:"candidatesTokens"`
+	TotalTokens      int    `json:"totalTokens"`
+}
+
+type CategorySummary struct {
+	Category string `json:"category"`
+	Failed   int    `json:"failed"`
+}
+
+type PromptSummary struct {
+	// Prompt is the ID and the version of the prompt, as id@version.
+	Prompt                  string  `json:"prompt"`
+	Extractions             int     `json:"extractions"`
+	AveragePromptTokens     float64 `json:"averagePromptTokens"`
+	AverageCandidatesTokens float64 `json:"averageCandidatesTokens"`
+	// FailedRate and NotARecipeRate are the fractions of the extractions
+	// that failed or were not recipes, between 0 and 1.
+	FailedRate     float64 `json:"failedRate"`
+	NotARecipeRate float64 `json:"notARecipeRate"`
+}
+
+// UsageSummaryHandler devuelve el resumen del uso del usuario.
+type UsageSummaryHandler func(ctx context.Context, userID string) (ExtractionsSummary, error)
+
+// CreateUsageSummaryHandler resume el uso con los informes de uso por mes, por
+// categoría de error y por prompt, que se agregan en la base de datos.
+func CreateUsageSummaryHandler(queryBus query.Bus) UsageSummaryHandler {
+	usageReport := CreateUsageReportHandler(queryBus)
+
+	return func(ctx context.Context, userID string) (ExtractionsSummary, error) {
+		report := func(group recipesdomain.UsageGroup) ([]UsageRowOutput, error) {
+			return usageReport(ctx, UsageReportInput{UserID: userID, By: string(group), Sort: string(recipesdomain.UsageSortKey)})
+		}
+		months, err := report(recipesdomain.UsageByMonth)
+		if err != nil {
+			return ExtractionsSummary{}, err
+		}
+		categories, err := report(recipesdomain.UsageByError)
+		if err != nil {
+			return ExtractionsSummary{}, err
+		}
+		prompts, err := report(recipesdomain.UsageByPrompt)
+		if err != nil {
+			return ExtractionsSummary{}, err
+		}
+
+		summary := ExtractionsSummary{
+			Months:          make([]MonthSummary, 0, len(months)),
+			ErrorCategories: make([]CategorySummary, 0, len(categories)),
+			Prompts:         make([]PromptSummary, 0, len(prompts)),
+		}
+		for _, row := range months {
+			summary.Months = append(summary.Months, MonthSummary{
+				Month:            row.Key,
+				Extractions:      row.Extractions,
+				NotARecipe:       row.NotARecipe,
+				Failed:           row.Failed,
+				PromptTokens:     row.PromptTokens,
+				CandidatesTokens: row.CandidatesTokens,
+				TotalTokens:      row.TotalTokens,
+			})
+		}
+		// Las categorías de las extracciones que no fallaron no se cuentan
+		for _, row := range categories {
+			if row.Failed > 0 {
+				summary.ErrorCategories = append(summary.ErrorCategories, CategorySummary{Category: row.Key, Failed: row.Failed})
+			}
+		}
+		for _, row := range prompts {
+			summary.Prompts = append(summary.Prompts, PromptSummary{
+				Prompt:                  row.Key,
+				Extractions:             row.Extractions,
+				AveragePromptTokens:     average(row.PromptTokens, row.Extractions),
+				AverageCandidatesTokens: average(row.CandidatesTokens, row.Extractions),
+				FailedRate:              rate(row.Failed, row.Extractions),
+				NotARecipeRate:          rate(row.NotARecipe, row.Extractions),
+			})
+		}
+		return summary, nil
+	}
+}
+
+// Month returns the summary of the month, formatted as 2006-01, which is
+// empty if there are no extractions in it.
+func (s ExtractionsSummary) Month(month string) MonthSummary {
+	for _, summary := range s.Months {
+		if summary.Month == month {
+			return summary
+		}
+	}
+	return MonthSummary{Month: month}
+}
+
+// average returns the average rounded to one decimal.
+func average(total, count int) float64 {
+	return math.Round(float64(total)/float64(count)*10) / 10
+}
+
+// rate returns the fraction of the count rounded to three decimals.
+func rate(part, count int) float64 {
+	return math.Round(float64(part)/float64(count)*1000) / 1000
+}
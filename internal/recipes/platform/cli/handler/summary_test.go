@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/usage"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/query/querymocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const summaryUserID = "37a0f027-15e6-47cc-a5d2-64183281087e"
+
+func Test_UsageSummaryHandler(t *testing.T) {
+	queryBus := new(querymocks.Bus)
+	queryBus.On("Ask", mock.Anything, usage.NewReportQuery(summaryUserID, "month", "key", "", "")).Return([]recipesdomain.UsageRow{
+		{Key: "2025-05", Extractions: 1, Failed: 1},
+		{Key: "2025-06", Extractions: 3, Succeeded: 2, NotARecipe: 1, PromptTokens: 150, CandidatesTokens: 25, TotalTokens: 175, Cost: 0.1},
+	}, nil)
+	queryBus.On("Ask", mock.Anything, usage.NewReportQuery(summaryUserID, "error", "key", "", "")).Return([]recipesdomain.UsageRow{
+		{Key: "", Extractions: 2, Succeeded: 2},
+		{Key: recipesdomain.ExtractionErrorDownload, Extractions: 1, Failed: 1},
+		{Key: recipesdomain.ExtractionErrorNotARecipe, Extractions: 1, NotARecipe: 1},
+	}, nil)
+	queryBus.On("Ask", mock.Anything, usage.NewReportQuery(summaryUserID, "prompt", "key", "", "")).Return([]recipesdomain.UsageRow{
+		{Key: "extract-recipe@v2", Extractions: 2, Succeeded: 1, NotARecipe: 1, PromptTokens: 150, CandidatesTokens: 25, TotalTokens: 175},
+		{Key: recipesdomain.UnknownPrompt, Extractions: 2, Succeeded: 1, Failed: 1},
+	}, nil)
+
+	summary, err := CreateUsageSummaryHandler(queryBus)(context.Background(), summaryUserID)
+
+	require.NoError(t, err)
+	assert.Equal(t, []MonthSummary{
+		{Month: "2025-05", Extractions: 1, Failed: 1},
+		{Month: "2025-06", Extractions: 3, NotARecipe: 1, PromptTokens: 150, CandidatesTokens: 25, TotalTokens: 175},
+	}, summary.Months)
+	assert.Equal(t, []CategorySummary{{Category: recipesdomain.ExtractionErrorDownload, Failed: 1}}, summary.ErrorCategories)
+	assert.Equal(t, []PromptSummary{
+		{Prompt: "extract-recipe@v2", Extractions: 2, AveragePromptTokens: 75, AverageCandidatesTokens: 12.5, NotARecipeRate: 0.5},
+		{Prompt: recipesdomain.UnknownPrompt, Extractions: 2, FailedRate: 0.5},
+	}, summary.Prompts)
+}
+
+func Test_UsageSummaryHandler_Empty(t *testing.T) {
+	queryBus := new(querymocks.Bus)
+	queryBus.On("Ask", mock.Anything, mock.AnythingOfType("usage.ReportQuery")).Return([]recipesdomain.UsageRow(nil), nil)
+
+	summary, err := CreateUsageSummaryHandler(queryBus)(context.Background(), summaryUserID)
+
+	require.NoError(t, err)
+	assert.Empty(t, summary.Months)
+	assert.NotNil(t, summary.Months, "empty lists are encoded as [] rather than null")
+}
+
+func Test_UsageSummaryHandler_Error(t *testing.T) {
+	queryBus := new(querymocks.Bus)
+	queryBus.On("Ask", mock.Anything, mock.AnythingOfType("usage.ReportQuery")).Return(nil, errors.New("something unexpected happened"))
+
+	_, err := CreateUsageSummaryHandler(queryBus)(context.Background(), summaryUserID)
+
+	assert.ErrorContains(t, err, "error interno")
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/listprices"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/setprice"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/usage"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+// UsageReportInput selecciona las extracciones del informe y cómo se agrupan
+// y ordenan. Los campos vacíos toman los valores por defecto del informe:
+// todos los usuarios, por mes y ordenado por clave.
+type UsageReportInput struct {
+	UserID string
+	By     string
+	Sort   string
+	From   string
+	To     string
+}
+
+// UsageRowOutput es una fila del informe de uso. El coste está en dólares.
+type UsageRowOutput struct {
+	Key              string  `json:"key"`
+	Extractions      int     `json:"extractions"`
+	Succeeded        int     `json:"succeeded"`
+	NotARecipe       int     `json:"notARecipe"`
+	Failed           int     `json:"failed"`
+	PromptTokens     int     `json:"promptTokens"`
+	CandidatesTokens int     `json:"candidatesTokens"`
+	TotalTokens      int     `json:"totalTokens"`
+	Cost             float64 `json:"cost"`
+	Unpriced         int     `json:"unpriced"`
+}
+
+type UsageReportHandler func(context.Context, UsageReportInput) ([]UsageRowOutput, error)
+
+func CreateUsageReportHandler(queryBus query.Bus) UsageReportHandler {
+	return func(ctx context.Context, input UsageReportInput) ([]UsageRowOutput, error) {
+		result, err := queryBus.Ask(ctx, usage.NewReportQuery(input.UserID, input.By, input.Sort, input.From, input.To))
+		if err != nil {
+			if isUsageDomainError(err) {
+				return nil, fmt.Errorf("error de dominio: %w", err)
+			}
+			return nil, fmt.Errorf("error interno: %w", err)
+		}
+
+		report, ok := result.([]recipesdomain.UsageRow)
+		if !ok {
+			return nil, fmt.Errorf("error al convertir el resultado a tipo []UsageRow")
+		}
+
+		// Sin extracciones el informe es una lista vacía, no nula
+		outputs := make([]UsageRowOutput, 0, len(report))
+		for _, row := range report {
+			outputs = append(outputs, UsageRowOutput(row))
+		}
+		return outputs, nil
+	}
+}
+
+func isUsageDomainError(err error) bool {
+	for _, target := range []error{
+		recipesdomain.ErrInvalidUsageGroup,
+		recipesdomain.ErrInvalidUsageSort,
+		recipesdomain.ErrInvalidUsagePeriod,
+		recipesdomain.ErrInvalidExtractionUserID,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// ModelPriceOutput es el precio de un modelo en dólares por millón de tokens.
+type ModelPriceOutput struct {
+	Provider      string  `json:"provider"`
+	Model         string  `json:"model"`
+	InputPrice    float64 `json:"inputPrice"`
+	OutputPrice   float64 `json:"outputPrice"`
+	EffectiveFrom string  `json:"effectiveFrom"`
+}
+
+// SetModelPriceInput es el precio de un modelo en dólares por millón de
+// tokens desde el día EffectiveFrom (2006-01-02).
+type SetModelPriceInput struct {
+	Provider      string
+	Model         string
+	InputPrice    float64
+	OutputPrice   float64
+	EffectiveFrom string
+}
+
+type SetModelPriceHandler func(context.Context, SetModelPriceInput) error
+
+func CreateSetModelPriceHandler(commandBus command.Bus) SetModelPriceHandler {
+	return func(ctx context.Context, input SetModelPriceInput) error {
+		if input.Provider == "" || input.Model == "" || input.EffectiveFrom == "" {
+			return fmt.Errorf("todos los campos son obligatorios")
+		}
+
+		err := commandBus.Dispatch(ctx, setprice.NewModelPriceCommand(input.Provider, input.Model, input.InputPrice, input.OutputPrice, input.EffectiveFrom))
+		if err != nil {
+			switch {
+			case errors.Is(err, recipesdomain.ErrInvalidModelPrice):
+				return fmt.Errorf("error de dominio: %w", err)
+			default:
+				return fmt.Errorf("error interno: %w", err)
+			}
+		}
+		return nil
+	}
+}
+
+type ListModelPricesHandler func(context.Context) ([]ModelPriceOutput, error)
+
+func CreateListModelPricesHandler(queryBus query.Bus) ListModelPricesHandler {
+	return func(ctx context.Context) ([]ModelPriceOutput, error) {
+		result, err := queryBus.Ask(ctx, listprices.NewModelPricesQuery())
+		if err != nil {
+			return nil, fmt.Errorf("error al buscar los precios: %w", err)
+		}
+
+		prices, ok := result.([]recipesdomain.ModelPrice)
+		if !ok {
+			return nil, fmt.Errorf("error al convertir el resultado a tipo []ModelPrice")
+		}
+
+		outputs := make([]ModelPriceOutput, 0, len(prices))
+		for _, price := range prices {
+			outputs = append(outputs, ModelPriceOutput(price))
+		}
+		return outputs, nil
+	}
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/listprices"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/setprice"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/usage"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/rubenbupe/recipe-video-parser/kit/query/querymocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UsageReportHandler(t *testing.T) {
+	queryBus := new(querymocks.Bus)
+	queryBus.On("Ask", mock.Anything, usage.NewReportQuery("", "model", "cost", "", "")).
+		Return([]recipesdomain.UsageRow{{Key: "google/gemini-2.0-flash", Extractions: 3, TotalTokens: 1100, Cost: 0.2, Unpriced: 1}}, nil)
+	queryBus.On("Ask", mock.Anything, usage.NewReportQuery("", "day", "", "", "")).Return([]recipesdomain.UsageRow(nil), nil)
+	queryBus.On("Ask", mock.Anything, usage.NewReportQuery("", "year", "", "", "")).
+		Return(nil, fmt.Errorf("%w: year", recipesdomain.ErrInvalidUsageGroup))
+	queryBus.On("Ask", mock.Anything, usage.NewReportQuery("", "", "", "", "")).Return(nil, errors.New("something unexpected happened"))
+
+	usageReport := CreateUsageReportHandler(queryBus)
+
+	t.Run("it returns the rows of the report", func(t *testing.T) {
+		report, err := usageReport(context.Background(), UsageReportInput{By: "model", Sort: "cost"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []UsageRowOutput{{Key: "google/gemini-2.0-flash", Extractions: 3, TotalTokens: 1100, Cost: 0.2, Unpriced: 1}}, report)
+	})
+
+	t.Run("it returns an empty report without extractions", func(t *testing.T) {
+		report, err := usageReport(context.Background(), UsageReportInput{By: "day"})
+
+		require.NoError(t, err)
+		assert.NotNil(t, report)
+		assert.Empty(t, report)
+	})
+
+	t.Run("it returns the domain errors", func(t *testing.T) {
+		_, err := usageReport(context.Background(), UsageReportInput{By: "year"})
+
+		assert.ErrorIs(t, err, recipesdomain.ErrInvalidUsageGroup)
+		assert.ErrorContains(t, err, "error de dominio")
+	})
+
+	t.Run("it returns the internal errors", func(t *testing.T) {
+		_, err := usageReport(context.Background(), UsageReportInput{})
+
+		assert.ErrorContains(t, err, "error interno")
+	})
+}
+
+func Test_SetModelPriceHandler(t *testing.T) {
+	commandBus := new(commandmocks.Bus)
+	commandBus.On("Dispatch", mock.Anything, setprice.NewModelPriceCommand("google", "gemini-2.0-flash", 0.1, 0.4, "2024-05-01")).Return(nil)
+
+	setModelPrice := CreateSetModelPriceHandler(commandBus)
+
+	err := setModelPrice(context.Background(), SetModelPriceInput{Provider: "google", Model: "gemini-2.0-flash", InputPrice: 0.1, OutputPrice: 0.4, EffectiveFrom: "2024-05-01"})
+	require.NoError(t, err)
+
+	err = setModelPrice(context.Background(), SetModelPriceInput{Provider: "google", InputPrice: 0.1, OutputPrice: 0.4, EffectiveFrom: "2024-05-01"})
+	assert.ErrorContains(t, err, "todos los campos son obligatorios")
+
+	commandBus.AssertNumberOfCalls(t, "Dispatch", 1)
+}
+
+func Test_ListModelPricesHandler(t *testing.T) {
+	price, err := recipesdomain.NewModelPrice("google", "gemini-2.0-flash", 0.1, 0.4, "2024-05-01")
+	require.NoError(t, err)
+
+	queryBus := new(querymocks.Bus)
+	queryBus.On("Ask", mock.Anything, listprices.NewModelPricesQuery()).Return([]recipesdomain.ModelPrice{price}, nil)
+
+	prices, err := CreateListModelPricesHandler(queryBus)(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []ModelPriceOutput{{Provider: "google", Model: "gemini-2.0-flash", InputPrice: 0.1, OutputPrice: 0.4, EffectiveFrom: "2024-05-01"}}, prices)
+}
@@ -0,0 +1,51 @@
+package files
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	recipesai "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
+	gallerydl "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/gallery"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/ai"
+)
+
+// Cleaner deletes the downloads of the extractions left in the download
+// directory and the videos whose deletion from the provider failed after the
+// extraction, recorded in its metadata.
+type Cleaner struct {
+	downloadDir string
+	config      ai.Aiconfig
+}
+
+func NewCleaner(downloadDir string, config ai.Aiconfig) Cleaner {
+	return Cleaner{
+		downloadDir: downloadDir,
+		config:      config,
+	}
+}
+
+// Clean implements the recipesdomain.FileCleaner interface. The provider
+// deletes the uploaded videos after two days, so an error deleting one is
+// logged instead of returned.
+func (c Cleaner) Clean(ctx context.Context, extraction recipesdomain.Extraction) error {
+	if err := gallerydl.RemoveDownloads(c.downloadDir, extraction.Id.String()); err != nil {
+		return err
+	}
+
+	var metadata struct {
+		ProviderFile string `json:"providerFile"`
+	}
+	if err := json.Unmarshal([]byte(extraction.Metadata), &metadata); err != nil || metadata.ProviderFile == "" {
+		return nil
+	}
+	if err := recipesai.DeleteFile(ctx, metadata.ProviderFile, c.config); err != nil {
+		slog.WarnContext(ctx, "could not delete uploaded file",
+			slog.String("extraction_id", extraction.Id.String()),
+			slog.String("file", metadata.ProviderFile),
+			slog.String("error", err.Error()),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package files
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/ai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Cleaner_Clean(t *testing.T) {
+	id := "8c5e5d2b-8d0a-4c0b-9f0a-6a6f4c1d2e3f"
+	dir := t.TempDir()
+	for _, name := range []string{id + ".mp4", id + ".mp4.json", "other.mp4"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("video"), 0o600))
+	}
+
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deleted = append(deleted, r.Method+" "+r.URL.Path)
+	}))
+	defer server.Close()
+
+	metadata := `{"promptTokenCount":10,"providerFile":"` + server.URL + `/v1beta/files/abc"}`
+	extraction, err := recipesdomain.NewExtraction(id, "37a0f027-15e6-47cc-a5d2-64183281087e", "https://example.com/video",
+		recipesdomain.ExtractionStatusFailed, recipesdomain.ExtractionErrorTimeout, "timeout", "", metadata, "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+
+	err = NewCleaner(dir, ai.Aiconfig{}).Clean(context.Background(), extraction)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"DELETE /v1beta/files/abc"}, deleted)
+	remaining, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "other.mp4", remaining[0].Name())
+}
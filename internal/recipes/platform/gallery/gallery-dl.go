@@ -1,11 +1,19 @@
 package gallery
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type DownloadResult struct {
@@ -16,15 +24,41 @@ type DownloadResult struct {
 	Description string
 }
 
-func DownloadFile(url, id, downloadDir, configFile string) (DownloadResult, error) {
+// DownloadFile descarga el vídeo con gallery-dl. El proceso se detiene si se
+// cancela el contexto.
+func DownloadFile(ctx context.Context, url, id, downloadDir, configFile string) (DownloadResult, error) {
+	ctx, span := tracing.Start(ctx, "gallery-dl", attribute.String("url.full", url))
+	result, err := downloadFile(ctx, url, id, downloadDir, configFile)
+	if err == nil {
+		if info, statErr := os.Stat(result.FilePath); statErr == nil {
+			span.SetAttributes(attribute.Int64("file.size", info.Size()))
+		}
+		span.SetAttributes(attribute.String("file.mime_type", result.MimeType))
+	}
+	tracing.End(span, err)
+	return result, err
+}
+
+func downloadFile(ctx context.Context, url, id, downloadDir, configFile string) (DownloadResult, error) {
 	args := []string{"--write-metadata", "-D", downloadDir, "-f", fmt.Sprintf("%s.{extension}", id)}
 	if configFile != "" {
 		args = append(args, "-c", configFile)
 	}
 	args = append(args, url)
-	cmd := exec.Command("gallery-dl", args...)
+	slog.DebugContext(ctx, "downloading video", slog.String("url", url), slog.String("extraction_id", id))
+	cmd := exec.CommandContext(ctx, "gallery-dl", args...)
 	output, err := cmd.CombinedOutput()
+	if cmd.ProcessState != nil {
+		trace.SpanFromContext(ctx).SetAttributes(attribute.Int("process.exit.code", cmd.ProcessState.ExitCode()))
+	}
 	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			slog.WarnContext(ctx, "gallery-dl failed", slog.String("url", url), slog.Int("exit_code", exitErr.ExitCode()))
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
 		return DownloadResult{}, fmt.Errorf("failed to download video: %w, details: %s", err, output)
 	}
 
@@ -91,3 +125,19 @@ func RemoveFile(filePath string) error {
 	}
 	return nil
 }
+
+// RemoveDownloads borra los ficheros de la extracción que queden en el
+// directorio de descargas: el vídeo y sus metadatos se llaman como la
+// extracción, y solo quedan si el proceso terminó antes de borrarlos.
+func RemoveDownloads(downloadDir, id string) error {
+	matches, err := filepath.Glob(filepath.Join(downloadDir, id+".*"))
+	if err != nil {
+		return fmt.Errorf("failed to list downloads of %s: %w", id, err)
+	}
+	for _, match := range matches {
+		if err := os.Remove(match); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove file %s: %w", match, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strconv"
+	"time"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
+	sharedmetrics "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/metrics"
+)
+
+// stageBuckets are the histogram buckets of the extraction stages, in
+// seconds: downloads and generations take from a few seconds to minutes.
+var stageBuckets = []float64{0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 60, 120, 300}
+
+// ExtractionMetrics records the outcome and the cost of the extractions. Its
+// methods do nothing on a nil receiver, so the CLI can extract recipes
+// without metrics.
+type ExtractionMetrics struct {
+	extractions *sharedmetrics.CounterVec
+	stages      *sharedmetrics.HistogramVec
+	tokens      *sharedmetrics.CounterVec
+	inFlight    *sharedmetrics.GaugeVec
+	exitCodes   *sharedmetrics.CounterVec
+}
+
+// NewExtractionMetrics registers the extraction metrics.
+func NewExtractionMetrics(registry *sharedmetrics.Registry) *ExtractionMetrics {
+	return &ExtractionMetrics{
+		extractions: registry.NewCounter("recipe_extractions_total",
+			"Finished extractions by platform, status and error category.", "platform", "status", "error_category"),
+		stages: registry.NewHistogram("recipe_extraction_stage_duration_seconds",
+			"Duration of the stages of the extractions (download, upload, activation, generation and total).", stageBuckets, "stage"),
+		tokens: registry.NewCounter("recipe_provider_tokens_total",
+			"Tokens consumed from the AI provider by type (prompt or candidates).", "provider", "type"),
+		inFlight: registry.NewGauge("recipe_extractions_in_flight",
+			"Extractions in progress."),
+		exitCodes: registry.NewCounter("gallerydl_exit_codes_total",
+			"Exit codes of gallery-dl; -1 means it was killed and not_started that it could not be run.", "code"),
+	}
+}
+
+// Start counts an extraction as in flight until the returned function is
+// called.
+func (m *ExtractionMetrics) Start() func() {
+	if m == nil {
+		return func() {}
+	}
+	m.inFlight.Inc()
+	return func() { m.inFlight.Dec() }
+}
+
+// ObserveDownload records the exit code of gallery-dl from the error returned
+// by the download.
+func (m *ExtractionMetrics) ObserveDownload(err error) {
+	if m == nil {
+		return
+	}
+	m.exitCodes.Inc(exitCodeOf(err))
+}
+
+// ObserveExtraction records the outcome, the duration of each stage and the
+// tokens of a finished extraction.
+func (m *ExtractionMetrics) ObserveExtraction(videoURL, provider string, res ai.AiResponse, err error) {
+	if m == nil {
+		return
+	}
+
+	category := recipesdomain.ExtractionErrorCategoryOf(err)
+	if category == "" {
+		category = "none"
+	}
+	m.extractions.Inc(recipesdomain.PlatformOf(videoURL), recipesdomain.ExtractionStatusOf(err), category)
+
+	durations := res.Metadata.Durations
+	for _, stage := range []struct {
+		name     string
+		duration int64
+	}{
+		{"download", durations.Download},
+		{"upload", durations.Upload},
+		{"activation", durations.Activation},
+		{"generation", durations.Generation},
+		{"total", durations.Total},
+	} {
+		// Las etapas que no se han ejecutado no tienen duración
+		if stage.duration > 0 {
+			m.stages.Observe((time.Duration(stage.duration) * time.Millisecond).Seconds(), stage.name)
+		}
+	}
+
+	m.tokens.Add(float64(res.Metadata.PromptTokenCount), provider, "prompt")
+	m.tokens.Add(float64(res.Metadata.CandidatesTokenCount), provider, "candidates")
+}
+
+// exitCodeOf returns the exit code of gallery-dl. Downloads canceled by the
+// context report -1, like the processes killed by a signal.
+func exitCodeOf(err error) string {
+	var exitErr *exec.ExitError
+	var execErr *exec.Error
+	switch {
+	case err == nil:
+		return "0"
+	case errors.As(err, &exitErr):
+		return strconv.Itoa(exitErr.ExitCode())
+	case errors.As(err, &execErr):
+		return "not_started"
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "-1"
+	default:
+		// gallery-dl terminó bien pero no se encontró el vídeo o sus metadatos
+		return "0"
+	}
+}
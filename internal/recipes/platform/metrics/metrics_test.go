@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"testing"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
+	sharedmetrics "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, registry *sharedmetrics.Registry) string {
+	t.Helper()
+
+	srv := httptest.NewServer(registry.Handler())
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func Test_ExtractionMetrics_ObserveExtraction(t *testing.T) {
+	registry := sharedmetrics.NewRegistry()
+	metrics := NewExtractionMetrics(registry)
+
+	var res ai.AiResponse
+	res.Metadata.PromptTokenCount = 1200
+	res.Metadata.CandidatesTokenCount = 300
+	res.Metadata.Durations = ai.StageDurations{Download: 2000, Upload: 500, Activation: 1500, Generation: 8000, Total: 12000}
+
+	metrics.ObserveExtraction("https://www.instagram.com/reel/abc", "google", res, nil)
+	metrics.ObserveExtraction("https://youtu.be/abc", "google", ai.AiResponse{},
+		recipesdomain.NewExtractionError(recipesdomain.ExtractionErrorValidation, errors.New("invalid")))
+
+	body := scrape(t, registry)
+	assert.Contains(t, body, `recipe_extractions_total{platform="instagram",status="succeeded",error_category="none"} 1`)
+	assert.Contains(t, body, `recipe_extractions_total{platform="youtube",status="failed",error_category="validation"} 1`)
+	assert.Contains(t, body, `recipe_extraction_stage_duration_seconds_sum{stage="activation"} 1.5`)
+	assert.Contains(t, body, `recipe_extraction_stage_duration_seconds_count{stage="generation"} 1`)
+	assert.Contains(t, body, `recipe_provider_tokens_total{provider="google",type="prompt"} 1200`)
+	assert.Contains(t, body, `recipe_provider_tokens_total{provider="google",type="candidates"} 300`)
+}
+
+func Test_ExtractionMetrics_Start(t *testing.T) {
+	registry := sharedmetrics.NewRegistry()
+	metrics := NewExtractionMetrics(registry)
+
+	done := metrics.Start()
+	metrics.Start()
+	assert.Contains(t, scrape(t, registry), "recipe_extractions_in_flight 2\n")
+
+	done()
+	assert.Contains(t, scrape(t, registry), "recipe_extractions_in_flight 1\n")
+}
+
+func Test_ExtractionMetrics_ObserveDownload(t *testing.T) {
+	registry := sharedmetrics.NewRegistry()
+	metrics := NewExtractionMetrics(registry)
+
+	exitErr := exec.Command("sh", "-c", "exit 4").Run()
+	require.Error(t, exitErr)
+
+	metrics.ObserveDownload(nil)
+	metrics.ObserveDownload(fmt.Errorf("failed to download video: %w", exitErr))
+	metrics.ObserveDownload(&exec.Error{Name: "gallery-dl", Err: exec.ErrNotFound})
+	metrics.ObserveDownload(context.DeadlineExceeded)
+
+	body := scrape(t, registry)
+	assert.Contains(t, body, `gallerydl_exit_codes_total{code="0"} 1`)
+	assert.Contains(t, body, `gallerydl_exit_codes_total{code="4"} 1`)
+	assert.Contains(t, body, `gallerydl_exit_codes_total{code="not_started"} 1`)
+	assert.Contains(t, body, `gallerydl_exit_codes_total{code="-1"} 1`)
+}
+
+func Test_ExtractionMetrics_Nil(t *testing.T) {
+	var metrics *ExtractionMetrics
+
+	assert.NotPanics(t, func() {
+		metrics.Start()()
+		metrics.ObserveDownload(nil)
+		metrics.ObserveExtraction("https://www.tiktok.com/@chef/video/1", "google", ai.AiResponse{}, nil)
+	})
+}
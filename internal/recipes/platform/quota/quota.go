@@ -0,0 +1,88 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+)
+
+// Checker checks the monthly quota of the extractions against the usage of
+// the current calendar month. The extractions in the library of an
+// organization count towards the quota of the organization instead of the
+// quota of the user, which only counts the personal ones.
+type Checker struct {
+	usageRepository recipesdomain.UsageRepository
+	now             func() time.Time
+}
+
+// NewChecker returns a Checker that reads the usage from the repository. now
+// returns the current time; time.Now when nil.
+func NewChecker(usageRepository recipesdomain.UsageRepository, now func() time.Time) Checker {
+	if now == nil {
+		now = time.Now
+	}
+	return Checker{
+		usageRepository: usageRepository,
+		now:             now,
+	}
+}
+
+// Check returns ErrOrganizationQuotaExceeded if the organization is not nil
+// and has used its quota, or ErrUserQuotaExceeded if there is no organization
+// and the user has used theirs.
+func (c Checker) Check(ctx context.Context, user usersdomain.User, organization *organizationsdomain.Organization) error {
+	if organization != nil {
+		if !organization.Quota.Limited() {
+			return nil
+		}
+		used, err := c.used(ctx, recipesdomain.UsageFilter{OrganizationId: organization.Id.String()})
+		if err != nil {
+			return err
+		}
+		return organization.Quota.Check(used.Extractions, used.TotalTokens)
+	}
+
+	if !user.Quota.Limited() {
+		return nil
+	}
+	used, err := c.used(ctx, recipesdomain.UsageFilter{UserId: user.Id.String(), Personal: true})
+	if err != nil {
+		return err
+	}
+	return user.Quota.Check(used.Extractions, used.TotalTokens)
+}
+
+// For returns a function that checks the quota of the user or the
+// organization, to check it again before every extraction of a batch.
+func (c Checker) For(user usersdomain.User, organization *organizationsdomain.Organization) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return c.Check(ctx, user, organization)
+	}
+}
+
+// Exceeded reports whether the error of Check means that the quota has been
+// used, and not that it could not be checked.
+func Exceeded(err error) bool {
+	return errors.Is(err, usersdomain.ErrUserQuotaExceeded) || errors.Is(err, organizationsdomain.ErrOrganizationQuotaExceeded)
+}
+
+// used suma el uso de las extracciones del filtro desde el primer día del
+// mes. Las fechas de las extracciones están en la hora local del servidor.
+func (c Checker) used(ctx context.Context, filter recipesdomain.UsageFilter) (recipesdomain.UsageRow, error) {
+	filter.From = c.now().Format("2006-01") + "-01"
+	report, err := c.usageRepository.Report(ctx, filter, recipesdomain.UsageByMonth, recipesdomain.UsageSortKey)
+	if err != nil {
+		return recipesdomain.UsageRow{}, err
+	}
+
+	var used recipesdomain.UsageRow
+	for _, row := range report {
+		used.Extractions += row.Extractions
+		used.TotalTokens += row.TotalTokens
+	}
+	return used, nil
+}
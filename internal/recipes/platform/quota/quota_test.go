@@ -0,0 +1,83 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/storagemocks"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userID         = "37a0f027-15e6-47cc-a5d2-64183281087e"
+	organizationID = "5d1c7a9e-3f2b-4e8d-a6c0-9b7e1f3d5a2c"
+)
+
+func now() time.Time {
+	return time.Date(2024, 5, 17, 10, 0, 0, 0, time.Local)
+}
+
+func newUser(t *testing.T, monthlyExtractions int) usersdomain.User {
+	t.Helper()
+	user, err := usersdomain.NewUser(userID, "name", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+	require.NoError(t, user.SetQuota(monthlyExtractions, 0))
+	return user
+}
+
+func TestChecker_Check(t *testing.T) {
+	t.Run("it does not read the usage without limits", func(t *testing.T) {
+		usageRepositoryMock := new(storagemocks.UsageRepository)
+
+		err := NewChecker(usageRepositoryMock, now).Check(context.Background(), newUser(t, 0), nil)
+
+		assert.NoError(t, err)
+		usageRepositoryMock.AssertNotCalled(t, "Report", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("it counts the personal extractions of the month in the user quota", func(t *testing.T) {
+		filter := recipesdomain.UsageFilter{UserId: userID, Personal: true, From: "2024-05-01"}
+		usageRepositoryMock := new(storagemocks.UsageRepository)
+		usageRepositoryMock.On("Report", mock.Anything, filter, recipesdomain.UsageByMonth, recipesdomain.UsageSortKey).
+			Return([]recipesdomain.UsageRow{{Key: "2024-05", Extractions: 2}}, nil)
+		checker := NewChecker(usageRepositoryMock, now)
+
+		assert.NoError(t, checker.Check(context.Background(), newUser(t, 3), nil))
+		err := checker.Check(context.Background(), newUser(t, 2), nil)
+
+		assert.ErrorIs(t, err, usersdomain.ErrUserQuotaExceeded)
+		assert.True(t, Exceeded(err))
+	})
+
+	t.Run("it applies the quota of the organization", func(t *testing.T) {
+		organization, err := organizationsdomain.NewOrganization(organizationID, "casa", userID, "2024-05-01T10:00:00Z")
+		require.NoError(t, err)
+		require.NoError(t, organization.SetQuota(1, 0))
+
+		filter := recipesdomain.UsageFilter{OrganizationId: organizationID, From: "2024-05-01"}
+		usageRepositoryMock := new(storagemocks.UsageRepository)
+		usageRepositoryMock.On("Report", mock.Anything, filter, recipesdomain.UsageByMonth, recipesdomain.UsageSortKey).
+			Return([]recipesdomain.UsageRow{{Key: "2024-05", Extractions: 1}}, nil)
+
+		err = NewChecker(usageRepositoryMock, now).For(newUser(t, 0), &organization)(context.Background())
+
+		assert.ErrorIs(t, err, organizationsdomain.ErrOrganizationQuotaExceeded)
+	})
+
+	t.Run("it returns the errors of the repository", func(t *testing.T) {
+		usageRepositoryMock := new(storagemocks.UsageRepository)
+		usageRepositoryMock.On("Report", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("something unexpected happened"))
+
+		err := NewChecker(usageRepositoryMock, now).Check(context.Background(), newUser(t, 1), nil)
+
+		assert.Error(t, err)
+		assert.False(t, Exceeded(err))
+	})
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/batch"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/quota"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware"
+)
+
+type batchRequest struct {
+	Urls []string `json:"urls" binding:"required"`
+}
+
+// BatchHandler extrae las recetas de una lista de URLs y las registra bajo el
+// usuario autenticado, o en la biblioteca de su organización activa. La lista
+// es un JSON ({"urls": [...]}), un CSV con una columna url (text/csv) o una
+// URL por línea (text/plain). La respuesta es el informe del lote, con un
+// resultado JSON por línea (application/x-ndjson) que se envía al terminar
+// cada extracción.
+//
+// La cuota se comprueba antes de cada extracción; una vez agotada, el resto de
+// URLs se informan como quota_exceeded.
+//
+// Si el cliente cierra la conexión el lote se detiene; al enviarlo de nuevo
+// se saltan las URLs ya extraídas.
+func BatchHandler(runner batch.Runner, checker quota.Checker, config batch.Config) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		user, ok := middleware.GetUserFromContext(ctx)
+		if !ok || user == nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+			return
+		}
+
+		if !canExtract(ctx) {
+			return
+		}
+		organization, _ := middleware.GetOrganizationFromContext(ctx)
+		organizationID := ""
+		if organization != nil {
+			organizationID = organization.Id.String()
+		}
+
+		items, err := readBatch(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if len(items) == 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "urls are required"})
+			return
+		}
+		if len(items) > config.MaxUrls {
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("too many urls: %d (max %d)", len(items), config.MaxUrls)})
+			return
+		}
+		locale, ok := resolveLocale(ctx)
+		if !ok {
+			return
+		}
+
+		ctx.Header("Content-Type", "application/x-ndjson")
+		writeResult := batch.WriteJSONL(ctx.Writer)
+		_, err = runner.Run(ctx, items, batch.Options{
+			UserID:         user.Id.String(),
+			Locale:         locale,
+			OrganizationID: organizationID,
+			Concurrency:    config.Concurrency,
+			Quota:          checker.For(*user, organization),
+		}, func(res batch.Result) error {
+			if err := writeResult(res); err != nil {
+				return err
+			}
+			ctx.Writer.Flush()
+			return nil
+		})
+		// Una vez enviado el primer resultado ya no se puede responder con un error
+		if err != nil && !ctx.Writer.Written() && !errors.Is(err, ctx.Request.Context().Err()) {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+	}
+}
+
+func readBatch(ctx *gin.Context) ([]batch.Item, error) {
+	switch ctx.ContentType() {
+	case "text/csv":
+		return batch.Read(ctx.Request.Body, true)
+	case "text/plain":
+		return batch.Read(ctx.Request.Body, false)
+	}
+
+	var req batchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, err
+	}
+	return batch.FromUrls(req.Urls), nil
+}
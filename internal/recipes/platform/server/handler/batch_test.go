@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/batch"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/quota"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/storagemocks"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/i18n"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/rubenbupe/recipe-video-parser/kit/query/querymocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newBatchRouter devuelve un router con un usuario con cuota de
+// userExtractions extracciones, cuyo uso se lee del repositorio.
+func newBatchRouter(t *testing.T, config batch.Config, userExtractions int, usageRepository recipesdomain.UsageRepository) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	user, err := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "name", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+	require.NoError(t, user.SetQuota(userExtractions, 0))
+
+	extract := func(ctx context.Context, url string, locale i18n.Locale) (ai.AiResponse, string, error) {
+		return ai.AiResponse{}, "8c5e5d2b-8d0a-4c0b-9f0a-6a6f4c1d2e3f", nil
+	}
+	commandBus := new(commandmocks.Bus)
+	commandBus.On("Dispatch", mock.Anything, mock.Anything).Return(nil)
+	queryBus := new(querymocks.Bus)
+	queryBus.On("Ask", mock.Anything, mock.Anything).Return([]recipesdomain.Extraction{}, nil)
+
+	r := gin.New()
+	r.Use(func(ctx *gin.Context) {
+		ctx.Set("user", &user)
+	})
+	r.POST("/recipes/extractions/batch", BatchHandler(batch.NewRunner(extract, commandBus, queryBus), quota.NewChecker(usageRepository, nil), config))
+	return r
+}
+
+func readBatchResults(t *testing.T, rec *httptest.ResponseRecorder) []batch.Result {
+	t.Helper()
+	var results []batch.Result
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var res batch.Result
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &res))
+		results = append(results, res)
+	}
+	return results
+}
+
+func TestBatchHandler(t *testing.T) {
+	r := newBatchRouter(t, batch.Config{Concurrency: 2, MaxUrls: 2}, 0, new(storagemocks.UsageRepository))
+
+	t.Run("it streams a result per url", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/recipes/extractions/batch", strings.NewReader("url,creator\nhttps://example.com/a,ana\nhttps://example.com/b,ana\n"))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "text/csv")
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+		results := readBatchResults(t, rec)
+		require.Len(t, results, 2)
+		assert.Equal(t, recipesdomain.ExtractionStatusSucceeded, results[0].Status)
+		assert.Equal(t, map[string]string{"creator": "ana"}, results[0].Fields)
+	})
+
+	t.Run("it returns 400 without urls", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/recipes/extractions/batch", strings.NewReader(`{"urls": []}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("it returns 413 with too many urls", func(t *testing.T) {
+		body := `{"urls": ["https://example.com/a", "https://example.com/b", "https://example.com/c"]}`
+		req, err := http.NewRequest(http.MethodPost, "/recipes/extractions/batch", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+}
+
+func TestBatchHandler_QuotaExceeded(t *testing.T) {
+	// El usuario tiene cuota para una sola extracción más
+	usageRepositoryMock := new(storagemocks.UsageRepository)
+	filter := thisMonth(recipesdomain.UsageFilter{UserId: "37a0f027-15e6-47cc-a5d2-64183281087e", Personal: true})
+	usageRepositoryMock.On("Report", mock.Anything, filter, recipesdomain.UsageByMonth, recipesdomain.UsageSortKey).
+		Return([]recipesdomain.UsageRow{{Extractions: 1}}, nil).Once()
+	usageRepositoryMock.On("Report", mock.Anything, filter, recipesdomain.UsageByMonth, recipesdomain.UsageSortKey).
+		Return([]recipesdomain.UsageRow{{Extractions: 2}}, nil)
+	r := newBatchRouter(t, batch.Config{Concurrency: 1, MaxUrls: 3}, 2, usageRepositoryMock)
+
+	body := `{"urls": ["https://example.com/a", "https://example.com/b", "https://example.com/c"]}`
+	req, err := http.NewRequest(http.MethodPost, "/recipes/extractions/batch", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	results := readBatchResults(t, rec)
+	require.Len(t, results, 3)
+	assert.Equal(t, recipesdomain.ExtractionStatusSucceeded, results[0].Status)
+	assert.Equal(t, batch.StatusQuotaExceeded, results[1].Status)
+	assert.Equal(t, batch.StatusQuotaExceeded, results[2].Status)
+	usageRepositoryMock.AssertNumberOfCalls(t, "Report", 2)
+}
+
+func TestBatchHandler_OrganizationViewer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	user, err := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "name", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+	organization, err := organizationsdomain.NewOrganization("5d1c7a9e-3f2b-4e8d-a6c0-9b7e1f3d5a2c", "casa", "4a0c8d2e-6b1f-4c3a-9e5d-7f2b1a0c9d8e", "2024-05-01T10:00:00Z")
+	require.NoError(t, err)
+	require.NoError(t, organization.SetMember(user.Id.String(), organizationsdomain.MemberRoleViewer, "2024-05-02T10:00:00Z"))
+
+	r := gin.New()
+	r.Use(func(ctx *gin.Context) {
+		ctx.Set("user", &user)
+		ctx.Set("organization", &organization)
+	})
+	r.POST("/recipes/extractions/batch", BatchHandler(batch.NewRunner(nil, new(commandmocks.Bus), new(querymocks.Bus)), quota.NewChecker(new(storagemocks.UsageRepository), nil), batch.Config{Concurrency: 1, MaxUrls: 2}))
+	req, err := http.NewRequest(http.MethodPost, "/recipes/extractions/batch", strings.NewReader(`{"urls": ["https://example.com/a"]}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
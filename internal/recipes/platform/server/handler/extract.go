@@ -1,26 +1,39 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/create"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
 	googleai "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
 	clihandlers "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/cli/handler"
+	recipesmetrics "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/metrics"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/ai"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/gallery"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/i18n"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware"
 	"github.com/rubenbupe/recipe-video-parser/internal/users/domain"
 	"github.com/rubenbupe/recipe-video-parser/kit/command"
 )
 
-func handleExtractionResult(ctx *gin.Context, res googleai.AiResponse, id string, commandBus command.Bus) {
-	jsonRecipe, err := toJSONString(res.Recipe)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to serialize recipe to JSON"})
-		return
+// handleExtractionResult registra la extracción, también cuando ha fallado o el
+// vídeo no es una receta, y responde con la receta o con el error. Los errores
+// incluyen un código con su categoría (download, provider, validation, timeout,
+// not_a_recipe).
+func handleExtractionResult(ctx *gin.Context, url string, res googleai.AiResponse, id string, extractErr error, commandBus command.Bus) {
+	jsonRecipe := ""
+	if extractErr == nil {
+		var err error
+		jsonRecipe, err = toJSONString(res.Recipe)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to serialize recipe to JSON"})
+			return
+		}
 	}
 	jsonMetadata, err := toJSONString(res.Metadata)
 	if err != nil {
@@ -40,17 +53,51 @@ func handleExtractionResult(ctx *gin.Context, res googleai.AiResponse, id string
 		return
 	}
 
+	organizationID := ""
+	if organization, ok := middleware.GetOrganizationFromContext(ctx); ok && organization != nil {
+		organizationID = organization.Id.String()
+	}
+
+	errorCategory := recipesdomain.ExtractionErrorCategoryOf(extractErr)
+	errorMessage := ""
+	if extractErr != nil {
+		errorMessage = extractErr.Error()
+	}
+
+	// La extracción se registra aunque el cliente haya cerrado la conexión
 	commandBus.Dispatch(
-		ctx,
+		context.WithoutCancel(ctx),
 		create.NewExtractionCommand(
 			id,
 			user.Id.String(),
+			organizationID,
+			url,
+			recipesdomain.ExtractionStatusOf(extractErr),
+			errorCategory,
+			errorMessage,
 			jsonRecipe,
 			jsonMetadata,
 			time.Now().Format(time.RFC3339),
 		),
 	)
 
+	var notARecipe *recipesdomain.NotARecipeError
+	switch {
+	case errors.As(extractErr, &notARecipe):
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":  notARecipe.Error(),
+			"code":   errorCategory,
+			"reason": notARecipe.Reason,
+		})
+		return
+	case errorCategory == recipesdomain.ExtractionErrorTimeout:
+		ctx.JSON(http.StatusGatewayTimeout, gin.H{"error": errorMessage, "code": errorCategory})
+		return
+	case extractErr != nil:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": errorMessage, "code": errorCategory})
+		return
+	}
+
 	if ctx.GetHeader("Accept") == "text/markdown" {
 		ctx.Header("Content-Type", "text/markdown")
 		markdownResponse := googleai.FormatToMarkdown(res)
@@ -61,44 +108,58 @@ func handleExtractionResult(ctx *gin.Context, res googleai.AiResponse, id string
 	ctx.JSON(http.StatusOK, res)
 }
 
-func extractWithUrl(galleryconfig *gallery.Galleryconfig, aiconfig *ai.Aiconfig, commandBus command.Bus) gin.HandlerFunc {
+// ExtractHandler extrae la receta del vídeo. ExtractRecipe decide si el vídeo
+// se descarga o se envía la url directamente al modelo.
+func ExtractHandler(galleryconfig *gallery.Galleryconfig, aiconfig *ai.Aiconfig, prompts *googleai.PromptRegistry, commandBus command.Bus, metrics *recipesmetrics.ExtractionMetrics) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		url := ctx.Query("url")
-		res, id, err := clihandlers.ExtractRecipe(url, galleryconfig, aiconfig)
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if url == "" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "url is required"})
 			return
 		}
-		handleExtractionResult(ctx, res, id, commandBus)
-	}
-}
-
-func extractWithDownloadedFile(galleryconfig *gallery.Galleryconfig, aiconfig *ai.Aiconfig, commandBus command.Bus) gin.HandlerFunc {
-	return func(ctx *gin.Context) {
-		url := ctx.Query("url")
-		res, id, err := clihandlers.ExtractRecipe(url, galleryconfig, aiconfig)
-		if err != nil {
+		if !canExtract(ctx) {
+			return
+		}
+		locale, ok := resolveLocale(ctx)
+		if !ok {
+			return
+		}
+		res, id, err := clihandlers.ExtractRecipe(ctx, url, locale, prompts, galleryconfig, aiconfig, metrics)
+		if id == "" {
 			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		handleExtractionResult(ctx, res, id, commandBus)
+		handleExtractionResult(ctx, url, res, id, err, commandBus)
 	}
 }
 
-func needsDownload(url string) bool {
-	// Considera que si la url contiene "youtube.com" o "youtu.be" no necesita descarga
-	return !(strings.Contains(url, "youtube.com") || strings.Contains(url, "youtu.be"))
+// canExtract responde con 403 si hay una organización activa en la que el
+// usuario no puede extraer recetas, porque solo puede leer su biblioteca.
+func canExtract(ctx *gin.Context) bool {
+	if _, ok := middleware.GetOrganizationFromContext(ctx); !ok {
+		return true
+	}
+	if _, member, ok := middleware.GetMemberFromContext(ctx); !ok || !member.Role.CanExtract() {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "the role in the organization can not extract recipes"})
+		return false
+	}
+	return true
 }
 
-func ExtractHandler(galleryconfig *gallery.Galleryconfig, aiconfig *ai.Aiconfig, commandBus command.Bus) gin.HandlerFunc {
-	return func(ctx *gin.Context) {
-		url := ctx.Query("url")
-		if needsDownload(url) {
-			extractWithDownloadedFile(galleryconfig, aiconfig, commandBus)(ctx)
-		} else {
-			extractWithUrl(galleryconfig, aiconfig, commandBus)(ctx)
-		}
+// resolveLocale elige el idioma de la receta: el parámetro "lang" de la
+// petición, el idioma por defecto del usuario o el idioma por defecto de la app.
+func resolveLocale(ctx *gin.Context) (i18n.Locale, bool) {
+	userLocale := ""
+	if user, ok := middleware.GetUserFromContext(ctx); ok && user != nil {
+		userLocale = user.Locale.String()
+	}
+
+	locale, err := i18n.ResolveLocale(ctx.Query("lang"), userLocale)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return i18n.Locale{}, false
 	}
+	return locale, true
 }
 
 // Helper para serializar a string JSON
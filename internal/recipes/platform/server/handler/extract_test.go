@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	googleai "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// serveExtractionResult responde a una extracción terminada con extractErr y
+// devuelve la respuesta.
+func serveExtractionResult(t *testing.T, extractErr error) (*httptest.ResponseRecorder, *commandmocks.Bus) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	user, err := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "name", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+	commandBus := new(commandmocks.Bus)
+	commandBus.On("Dispatch", mock.Anything, mock.AnythingOfType("create.ExtractionCommand")).Return(nil)
+
+	r := gin.New()
+	r.Use(func(ctx *gin.Context) {
+		ctx.Set("user", &user)
+	})
+	r.GET("/recipes/extract", func(ctx *gin.Context) {
+		handleExtractionResult(ctx, "https://example.com/video", googleai.AiResponse{}, "8c5e5d2b-8d0a-4c0b-9f0a-6a6f4c1d2e3f", extractErr, commandBus)
+	})
+	req, err := http.NewRequest(http.MethodGet, "/recipes/extract", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec, commandBus
+}
+
+func TestHandleExtractionResult_NotARecipe(t *testing.T) {
+	rec, commandBus := serveExtractionResult(t, &recipesdomain.NotARecipeError{Reason: "the video shows a landscape"})
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, map[string]string{
+		"error":  "the video is not a recipe: the video shows a landscape",
+		"code":   recipesdomain.ExtractionErrorNotARecipe,
+		"reason": "the video shows a landscape",
+	}, body)
+	commandBus.AssertNumberOfCalls(t, "Dispatch", 1)
+}
+
+func TestHandleExtractionResult_Failed(t *testing.T) {
+	rec, _ := serveExtractionResult(t, recipesdomain.NewExtractionError(recipesdomain.ExtractionErrorDownload, errors.New("404")))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.JSONEq(t, `{"error": "404", "code": "download"}`, rec.Body.String())
+}
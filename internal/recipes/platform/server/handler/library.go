@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	clihandlers "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/cli/handler"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+// libraryExtraction es una extracción de la biblioteca, con su receta y sus
+// metadatos como JSON.
+type libraryExtraction struct {
+	ID             string          `json:"id"`
+	UserID         string          `json:"userId"`
+	OrganizationID string          `json:"organizationId,omitempty"`
+	SourceUrl      string          `json:"sourceUrl"`
+	Status         string          `json:"status"`
+	ErrorCategory  string          `json:"errorCategory,omitempty"`
+	ErrorMessage   string          `json:"errorMessage,omitempty"`
+	Recipe         json.RawMessage `json:"recipe,omitempty"`
+	Metadata       json.RawMessage `json:"metadata,omitempty"`
+	CreatedAt      string          `json:"createdAt"`
+}
+
+// LibraryHandler devuelve la biblioteca de la organización activa o, sin
+// organización, las extracciones del usuario autenticado. Todos los miembros
+// de la organización, también los lectores, pueden verla.
+func LibraryHandler(queryBus query.Bus) gin.HandlerFunc {
+	getExtractions := clihandlers.CreateGetExtractionsHandler(queryBus)
+
+	return func(ctx *gin.Context) {
+		user, ok := middleware.GetUserFromContext(ctx)
+		if !ok || user == nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+			return
+		}
+
+		input := clihandlers.GetExtractionInput{UserID: user.Id.String()}
+		if organization, ok := middleware.GetOrganizationFromContext(ctx); ok && organization != nil {
+			input = clihandlers.GetExtractionInput{OrganizationID: organization.Id.String()}
+		}
+
+		extractions, err := getExtractions(ctx, input)
+		if err != nil && !errors.Is(err, clihandlers.ErrNoExtractions) {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		library := make([]libraryExtraction, 0, len(extractions))
+		for _, extraction := range extractions {
+			item := libraryExtraction{
+				ID:             extraction.ID,
+				UserID:         extraction.UserID,
+				OrganizationID: extraction.OrganizationID,
+				SourceUrl:      extraction.SourceUrl,
+				Status:         extraction.Status,
+				ErrorCategory:  extraction.ErrorCategory,
+				ErrorMessage:   extraction.ErrorMessage,
+				CreatedAt:      extraction.CreatedAt,
+			}
+			if json.Valid([]byte(extraction.Data)) {
+				item.Recipe = json.RawMessage(extraction.Data)
+			}
+			if json.Valid([]byte(extraction.Metadata)) {
+				item.Metadata = json.RawMessage(extraction.Metadata)
+			}
+			library = append(library, item)
+		}
+		ctx.JSON(http.StatusOK, library)
+	}
+}
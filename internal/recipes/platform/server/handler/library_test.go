@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/get"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/query/querymocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const libraryOrganizationID = "5d1c7a9e-3f2b-4e8d-a6c0-9b7e1f3d5a2c"
+
+func newExtraction(t *testing.T, createdAt time.Time) recipesdomain.Extraction {
+	t.Helper()
+	extraction, err := recipesdomain.NewExtraction(
+		"8c5e5d2b-8d0a-4c0b-9f0a-6a6f4c1d2e3f",
+		"37a0f027-15e6-47cc-a5d2-64183281087e",
+		"https://example.com/a",
+		recipesdomain.ExtractionStatusSucceeded,
+		"",
+		"",
+		"{}",
+		"{}",
+		createdAt.Format(time.RFC3339),
+	)
+	require.NoError(t, err)
+	return extraction
+}
+
+func newSharedExtraction(t *testing.T, createdAt time.Time) recipesdomain.Extraction {
+	t.Helper()
+	extraction := newExtraction(t, createdAt)
+	require.NoError(t, extraction.ShareWith(libraryOrganizationID))
+	return extraction
+}
+
+func newLibraryRouter(t *testing.T, organization *organizationsdomain.Organization, queryBus *querymocks.Bus) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	user, err := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "name", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(func(ctx *gin.Context) {
+		ctx.Set("user", &user)
+		if organization != nil {
+			ctx.Set("organization", organization)
+		}
+	})
+	r.GET("/recipes/extractions", LibraryHandler(queryBus))
+	return r
+}
+
+func TestLibraryHandler(t *testing.T) {
+	t.Run("it returns the extractions of the user", func(t *testing.T) {
+		queryBus := new(querymocks.Bus)
+		queryBus.On("Ask", mock.Anything, get.NewExtractionQuery("37a0f027-15e6-47cc-a5d2-64183281087e")).
+			Return([]recipesdomain.Extraction{newExtraction(t, time.Now())}, nil)
+		r := newLibraryRouter(t, nil, queryBus)
+		req, err := http.NewRequest(http.MethodGet, "/recipes/extractions", nil)
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var library []libraryExtraction
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &library))
+		require.Len(t, library, 1)
+		assert.Empty(t, library[0].OrganizationID)
+	})
+
+	t.Run("it returns the library of the active organization", func(t *testing.T) {
+		organization, err := organizationsdomain.NewOrganization(libraryOrganizationID, "casa", "4a0c8d2e-6b1f-4c3a-9e5d-7f2b1a0c9d8e", "2024-05-01T10:00:00Z")
+		require.NoError(t, err)
+		queryBus := new(querymocks.Bus)
+		queryBus.On("Ask", mock.Anything, get.NewOrganizationExtractionQuery(libraryOrganizationID)).
+			Return([]recipesdomain.Extraction{newSharedExtraction(t, time.Now())}, nil)
+		r := newLibraryRouter(t, &organization, queryBus)
+		req, err := http.NewRequest(http.MethodGet, "/recipes/extractions", nil)
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var library []libraryExtraction
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &library))
+		require.Len(t, library, 1)
+		assert.Equal(t, libraryOrganizationID, library[0].OrganizationID)
+	})
+
+	t.Run("it returns an empty library", func(t *testing.T) {
+		queryBus := new(querymocks.Bus)
+		queryBus.On("Ask", mock.Anything, mock.Anything).Return([]recipesdomain.Extraction{}, nil)
+		r := newLibraryRouter(t, nil, queryBus)
+		req, err := http.NewRequest(http.MethodGet, "/recipes/extractions", nil)
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, "[]", rec.Body.String())
+	})
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/quota"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware"
+)
+
+// QuotaMiddleware rechaza con 429 las extracciones de los usuarios que ya han
+// usado en el mes las extracciones o los tokens de su cuota. Con una
+// organización activa se aplica la cuota de la organización a su biblioteca
+// en lugar de la del usuario, que solo cuenta sus extracciones personales. Va
+// después de AuthMiddleware.
+func QuotaMiddleware(checker quota.Checker) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		user, ok := middleware.GetUserFromContext(ctx)
+		if !ok || user == nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+			return
+		}
+		organization, _ := middleware.GetOrganizationFromContext(ctx)
+
+		if err := checker.Check(ctx, *user, organization); err != nil {
+			if quota.Exceeded(err) {
+				ctx.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": err.Error(), "code": "quota_exceeded"})
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/quota"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/storagemocks"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const quotaOrganizationID = "5d1c7a9e-3f2b-4e8d-a6c0-9b7e1f3d5a2c"
+
+// thisMonth es el filtro del uso del mes actual, que empieza el día 1.
+func thisMonth(filter recipesdomain.UsageFilter) recipesdomain.UsageFilter {
+	filter.From = time.Now().Format("2006-01") + "-01"
+	return filter
+}
+
+// newQuotaRouter devuelve un router con un usuario con cuota de
+// userExtractions extracciones y, si organizationExtractions no es negativo,
+// una organización activa con esa cuota. El repositorio de uso devuelve las
+// extracciones usadas con el filtro.
+func newQuotaRouter(t *testing.T, userExtractions, organizationExtractions int, filter recipesdomain.UsageFilter, used int) (*gin.Engine, *storagemocks.UsageRepository) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	user, err := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "name", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+	require.NoError(t, user.SetQuota(userExtractions, 0))
+
+	var organization *organizationsdomain.Organization
+	if organizationExtractions >= 0 {
+		org, err := organizationsdomain.NewOrganization(quotaOrganizationID, "casa", user.Id.String(), "2024-05-01T10:00:00Z")
+		require.NoError(t, err)
+		require.NoError(t, org.SetQuota(organizationExtractions, 0))
+		organization = &org
+	}
+
+	usageRepositoryMock := new(storagemocks.UsageRepository)
+	usageRepositoryMock.On("Report", mock.Anything, thisMonth(filter), recipesdomain.UsageByMonth, recipesdomain.UsageSortKey).
+		Return([]recipesdomain.UsageRow{{Key: time.Now().Format("2006-01"), Extractions: used}}, nil)
+
+	r := gin.New()
+	r.Use(func(ctx *gin.Context) {
+		ctx.Set("user", &user)
+		if organization != nil {
+			ctx.Set("organization", organization)
+		}
+	})
+	r.GET("/recipes/extract", QuotaMiddleware(quota.NewChecker(usageRepositoryMock, nil)), func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+	return r, usageRepositoryMock
+}
+
+func serveQuota(t *testing.T, r *gin.Engine) *httptest.ResponseRecorder {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, "/recipes/extract", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestQuotaMiddleware(t *testing.T) {
+	personal := recipesdomain.UsageFilter{UserId: "37a0f027-15e6-47cc-a5d2-64183281087e", Personal: true}
+
+	t.Run("it lets the users without quota through", func(t *testing.T) {
+		r, usageRepositoryMock := newQuotaRouter(t, 0, -1, personal, 5)
+
+		rec := serveQuota(t, r)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		usageRepositoryMock.AssertNotCalled(t, "Report", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("it counts the personal extractions of this month", func(t *testing.T) {
+		r, usageRepositoryMock := newQuotaRouter(t, 2, -1, personal, 1)
+
+		rec := serveQuota(t, r)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		usageRepositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("it returns 429 when the quota is used", func(t *testing.T) {
+		r, _ := newQuotaRouter(t, 1, -1, personal, 1)
+
+		rec := serveQuota(t, r)
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Contains(t, rec.Body.String(), "quota_exceeded")
+	})
+
+	t.Run("it returns 500 when the usage can not be read", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		user, err := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "name", "apikey", "2023-01-01T00:00:00Z")
+		require.NoError(t, err)
+		require.NoError(t, user.SetQuota(1, 0))
+		usageRepositoryMock := new(storagemocks.UsageRepository)
+		usageRepositoryMock.On("Report", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("something unexpected happened"))
+
+		r := gin.New()
+		r.Use(func(ctx *gin.Context) {
+			ctx.Set("user", &user)
+		})
+		r.GET("/recipes/extract", QuotaMiddleware(quota.NewChecker(usageRepositoryMock, nil)), func(ctx *gin.Context) {
+			ctx.Status(http.StatusOK)
+		})
+
+		rec := serveQuota(t, r)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestQuotaMiddleware_Organization(t *testing.T) {
+	library := recipesdomain.UsageFilter{OrganizationId: quotaOrganizationID}
+
+	t.Run("it applies the quota of the active organization instead of the user quota", func(t *testing.T) {
+		r, _ := newQuotaRouter(t, 1, 2, library, 1)
+
+		rec := serveQuota(t, r)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("it returns 429 when the organization quota is used", func(t *testing.T) {
+		r, _ := newQuotaRouter(t, 0, 1, library, 1)
+
+		rec := serveQuota(t, r)
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Contains(t, rec.Body.String(), "organization quota exceeded")
+	})
+}
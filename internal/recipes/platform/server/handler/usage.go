@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	clihandlers "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/cli/handler"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/output"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+// UsageHandler devuelve el informe de uso y coste de las extracciones del
+// usuario autenticado. Acepta los parámetros by, sort, from y to de
+// usage-report, y format=csv para descargarlo como CSV en vez de JSON.
+func UsageHandler(queryBus query.Bus) gin.HandlerFunc {
+	usageReport := clihandlers.CreateUsageReportHandler(queryBus)
+
+	return func(ctx *gin.Context) {
+		user, ok := middleware.GetUserFromContext(ctx)
+		if !ok || user == nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+			return
+		}
+
+		format := ctx.DefaultQuery("format", string(output.FormatJSON))
+		if format != string(output.FormatJSON) && format != string(output.FormatCSV) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or csv"})
+			return
+		}
+
+		report, err := usageReport(ctx, clihandlers.UsageReportInput{
+			UserID: user.Id.String(),
+			By:     ctx.Query("by"),
+			Sort:   ctx.Query("sort"),
+			From:   ctx.Query("from"),
+			To:     ctx.Query("to"),
+		})
+		if err != nil {
+			ctx.JSON(usageErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		if format == string(output.FormatCSV) {
+			ctx.Header("Content-Disposition", `attachment; filename="usage.csv"`)
+			ctx.Header("Content-Type", "text/csv; charset=utf-8")
+			ctx.Status(http.StatusOK)
+			output.Write(ctx.Writer, output.FormatCSV, report)
+			return
+		}
+		ctx.JSON(http.StatusOK, report)
+	}
+}
+
+func usageErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, recipesdomain.ErrInvalidUsageGroup),
+		errors.Is(err, recipesdomain.ErrInvalidUsageSort),
+		errors.Is(err, recipesdomain.ErrInvalidUsagePeriod):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/usage"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/query/querymocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	user, err := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "name", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+
+	report := []recipesdomain.UsageRow{
+		{Key: "youtube", Extractions: 2, Succeeded: 2, PromptTokens: 1000, CandidatesTokens: 100, TotalTokens: 1100, Cost: 0.2},
+	}
+	queryBus := new(querymocks.Bus)
+	queryBus.On("Ask", mock.Anything, usage.NewReportQuery(user.Id.String(), "platform", "", "2024-05-01", "")).Return(report, nil)
+	queryBus.On("Ask", mock.Anything, usage.NewReportQuery(user.Id.String(), "year", "", "", "")).
+		Return(nil, fmt.Errorf("%w: year", recipesdomain.ErrInvalidUsageGroup))
+	queryBus.On("Ask", mock.Anything, usage.NewReportQuery(user.Id.String(), "", "", "", "")).Return(nil, errors.New("something unexpected happened"))
+
+	r := gin.New()
+	r.GET("/anonymous/usage", UsageHandler(queryBus))
+	r.Use(func(ctx *gin.Context) {
+		ctx.Set("user", &user)
+	})
+	r.GET("/account/usage", UsageHandler(queryBus))
+
+	get := func(t *testing.T, path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("it returns the usage of the user as JSON", func(t *testing.T) {
+		rec := get(t, "/account/usage?by=platform&from=2024-05-01")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var body []map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Len(t, body, 1)
+		assert.Equal(t, "youtube", body[0]["key"])
+		assert.Equal(t, 0.2, body[0]["cost"])
+	})
+
+	t.Run("it returns the usage of the user as CSV", func(t *testing.T) {
+		rec := get(t, "/account/usage?by=platform&from=2024-05-01&format=csv")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "key,extractions,succeeded,notARecipe,failed,promptTokens,candidatesTokens,totalTokens,cost,unpriced\n"+
+			"youtube,2,2,0,0,1000,100,1100,0.2,0\n", rec.Body.String())
+	})
+
+	t.Run("it returns 400 with an unknown format", func(t *testing.T) {
+		rec := get(t, "/account/usage?format=xml")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("it returns 400 with an unknown group", func(t *testing.T) {
+		rec := get(t, "/account/usage?by=year")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("it returns 500 when the report fails", func(t *testing.T) {
+		rec := get(t, "/account/usage")
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("it returns 401 without user", func(t *testing.T) {
+		rec := get(t, "/anonymous/usage")
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
@@ -3,17 +3,24 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/di"
 	handlers "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/handler"
 	middleware "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware"
-	userssql "github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/sql"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
 )
 
 func Register(router *gin.RouterGroup) {
 	diContainer := di.Instance()
 
 	extractController := diContainer.Container.Get("recipes.infrastructure.controller.extract").(handlers.Handler)
-	userRepo := diContainer.Container.Get("users.domain.repository").(*userssql.UserRepository)
+	batchController := diContainer.Container.Get("recipes.infrastructure.controller.batch").(handlers.Handler)
+	libraryController := diContainer.Container.Get("recipes.infrastructure.controller.library").(handlers.Handler)
+	quotaController := diContainer.Container.Get("recipes.infrastructure.controller.quota").(handlers.Handler)
+	userRepo := diContainer.Container.Get("users.domain.repository").(usersdomain.UserRepository)
+	organizationRepo := diContainer.Container.Get("organizations.domain.repository").(organizationsdomain.OrganizationRepository)
 
-	router.GET("/extract", middleware.AuthMiddleware(*userRepo), extractController)
+	router.GET("/extract", middleware.AuthMiddleware(userRepo, organizationRepo), quotaController, extractController)
+	router.GET("/extractions", middleware.AuthMiddleware(userRepo, organizationRepo), libraryController)
+	router.POST("/extractions/batch", middleware.AuthMiddleware(userRepo, organizationRepo), quotaController, batchController)
 }
@@ -0,0 +1,105 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/event"
+)
+
+// ExtractionRepository is a thread-safe in-memory implementation of the
+// recipesdomain.ExtractionRepository. It does not take part in transactions,
+// and publishes the events of the saved extractions straight to the event
+// bus.
+type ExtractionRepository struct {
+	mu          sync.RWMutex
+	extractions []recipesdomain.Extraction
+	eventBus    event.Bus
+}
+
+// NewExtractionRepository initializes an empty ExtractionRepository. The
+// event bus can be nil to discard the events.
+func NewExtractionRepository(eventBus event.Bus) *ExtractionRepository {
+	return &ExtractionRepository{
+		eventBus: eventBus,
+	}
+}
+
+// Save stores the extraction. As the database does, it fails if there is
+// already an extraction with the same ID.
+func (r *ExtractionRepository) Save(ctx context.Context, extraction recipesdomain.Extraction) error {
+	events := extraction.PullEvents()
+
+	r.mu.Lock()
+	if r.indexOf(extraction.Id) >= 0 {
+		r.mu.Unlock()
+		return fmt.Errorf("error trying to persist extraction: extraction %s already exists", extraction.Id.String())
+	}
+	r.extractions = append(r.extractions, extraction)
+	r.mu.Unlock()
+
+	if r.eventBus == nil {
+		return nil
+	}
+	return r.eventBus.Publish(ctx, events)
+}
+
+func (r *ExtractionRepository) Exists(_ context.Context, id recipesdomain.ExtractionID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.indexOf(id) >= 0, nil
+}
+
+func (r *ExtractionRepository) Get(_ context.Context, id recipesdomain.ExtractionID) (*recipesdomain.Extraction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := r.indexOf(id)
+	if i < 0 {
+		return nil, nil
+	}
+	extraction := r.extractions[i]
+	return &extraction, nil
+}
+
+// GetByUserID returns the extractions of the user in the order they were
+// saved.
+func (r *ExtractionRepository) GetByUserID(_ context.Context, userId recipesdomain.ExtractionUserID) ([]recipesdomain.Extraction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var extractions []recipesdomain.Extraction
+	for _, extraction := range r.extractions {
+		if extraction.UserId.String() == userId.String() {
+			extractions = append(extractions, extraction)
+		}
+	}
+	return extractions, nil
+}
+
+// GetByOrganizationID returns the extractions of the organization in the
+// order they were saved.
+func (r *ExtractionRepository) GetByOrganizationID(_ context.Context, organizationId recipesdomain.ExtractionOrganizationID) ([]recipesdomain.Extraction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var extractions []recipesdomain.Extraction
+	for _, extraction := range r.extractions {
+		if extraction.OrganizationId.String() == organizationId.String() {
+			extractions = append(extractions, extraction)
+		}
+	}
+	return extractions, nil
+}
+
+func (r *ExtractionRepository) indexOf(id recipesdomain.ExtractionID) int {
+	for i, extraction := range r.extractions {
+		if extraction.Id.String() == id.String() {
+			return i
+		}
+	}
+	return -1
+}
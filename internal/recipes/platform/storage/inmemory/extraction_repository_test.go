@@ -0,0 +1,39 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/storagetest"
+	"github.com/rubenbupe/recipe-video-parser/kit/event"
+	"github.com/rubenbupe/recipe-video-parser/kit/event/eventmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExtractionRepository_Contract(t *testing.T) {
+	storagetest.ExtractionRepositoryContract{
+		New: func(t *testing.T) recipesdomain.ExtractionRepository {
+			return NewExtractionRepository(nil)
+		},
+	}.Run(t)
+}
+
+func Test_ExtractionRepository_Save_PublishesEvents(t *testing.T) {
+	extraction := storagetest.NewExtraction(t, "37a0f027-15e6-47cc-a5d2-64183281087e")
+
+	eventBus := new(eventmocks.Bus)
+	eventBus.On("Publish", mock.Anything, mock.MatchedBy(func(events []event.Event) bool {
+		return len(events) == 1 && events[0].Type() == recipesdomain.ExtractionCreatedEventType
+	})).Return(nil).Once()
+
+	repo := NewExtractionRepository(eventBus)
+	require.NoError(t, repo.Save(context.Background(), extraction))
+
+	stored, err := repo.Get(context.Background(), extraction.Id)
+	require.NoError(t, err)
+	assert.Empty(t, stored.PullEvents(), "the stored extraction must not keep its events")
+	eventBus.AssertExpectations(t)
+}
@@ -1,13 +1,20 @@
 package sql
 
+import "database/sql"
+
 const (
 	sqlExtractionTable = "recipe_extractions"
 )
 
 type sqlExtraction struct {
-	ID        string `db:"id"`
-	UserID    string `db:"user_id"`
-	Data      string `db:"data"`
-	Metadata  string `db:"metadata"`
-	CreatedAt string `db:"created_at"`
+	ID             string         `db:"id"`
+	UserID         string         `db:"user_id"`
+	OrganizationID string         `db:"organization_id"`
+	SourceUrl      string         `db:"source_url"`
+	Status         string         `db:"status"`
+	ErrorCategory  string         `db:"error_category"`
+	ErrorMessage   string         `db:"error_message"`
+	Data           sql.NullString `db:"data"`
+	Metadata       string         `db:"metadata"`
+	CreatedAt      string         `db:"created_at"`
 }
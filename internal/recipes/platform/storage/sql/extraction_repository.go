@@ -2,44 +2,59 @@ package sql
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	"github.com/huandu/go-sqlbuilder"
 	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/outbox"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
 )
 
+var extractionColumns = []string{"id", "user_id", "organization_id", "source_url", "status", "error_category", "error_message", "data", "metadata", "created_at"}
+
 type ExtractionRepository struct {
 	connection *storage.Connection
 	dbconfig   *storage.Dbconfig
+	outbox     *outbox.Store
 }
 
-func NewExtractionRepository(connection *storage.Connection, dbconfig *storage.Dbconfig) *ExtractionRepository {
+func NewExtractionRepository(connection *storage.Connection, dbconfig *storage.Dbconfig, outbox *outbox.Store) *ExtractionRepository {
 	return &ExtractionRepository{
 		connection: connection,
 		dbconfig:   dbconfig,
+		outbox:     outbox,
 	}
 }
 
+// Save persists the extraction and the events it recorded in the same
+// transaction, which is the one of the context if there is any.
 func (r *ExtractionRepository) Save(ctx context.Context, extraction recipesdomain.Extraction) error {
 	extractionSQLStruct := sqlbuilder.NewStruct(new(sqlExtraction)).For(sqlbuilder.SQLite)
 	query, args := extractionSQLStruct.InsertInto(sqlExtractionTable, sqlExtraction{
-		ID:        extraction.Id.String(),
-		UserID:    extraction.UserId.String(),
-		Data:      extraction.Data,
-		Metadata:  extraction.Metadata,
-		CreatedAt: extraction.CreatedAt.String(),
+		ID:             extraction.Id.String(),
+		UserID:         extraction.UserId.String(),
+		OrganizationID: extraction.OrganizationId.String(),
+		SourceUrl:      extraction.SourceUrl,
+		Status:         extraction.Status.String(),
+		ErrorCategory:  extraction.ErrorCategory.String(),
+		ErrorMessage:   extraction.ErrorMessage,
+		Data:           sql.NullString{String: extraction.Data, Valid: extraction.Data != ""},
+		Metadata:       extraction.Metadata,
+		CreatedAt:      extraction.CreatedAt.String(),
 	}).Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
 	defer cancel()
 
-	_, err := r.connection.Db.ExecContext(ctxTimeout, query, args...)
-	if err != nil {
-		return fmt.Errorf("error trying to persist extraction on database: %v", err)
-	}
+	return r.connection.Transaction(ctxTimeout, func(ctx context.Context) error {
+		executor := r.connection.Executor(ctx)
+		if _, err := executor.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("error trying to persist extraction on database: %v", err)
+		}
 
-	return nil
+		return r.outbox.Add(ctx, executor, extraction.PullEvents())
+	})
 }
 
 func (r *ExtractionRepository) Exists(ctx context.Context, id recipesdomain.ExtractionID) (bool, error) {
@@ -51,7 +66,7 @@ func (r *ExtractionRepository) Exists(ctx context.Context, id recipesdomain.Extr
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
 	defer cancel()
 
-	rows, err := r.connection.Db.QueryContext(ctxTimeout, query, args...)
+	rows, err := r.connection.Executor(ctxTimeout).QueryContext(ctxTimeout, query, args...)
 	if err != nil {
 		return false, fmt.Errorf("error trying to check if extraction exists on database: %v", err)
 	}
@@ -62,7 +77,7 @@ func (r *ExtractionRepository) Exists(ctx context.Context, id recipesdomain.Extr
 
 func (r *ExtractionRepository) Get(ctx context.Context, id recipesdomain.ExtractionID) (*recipesdomain.Extraction, error) {
 	extractionSQLStruct := sqlbuilder.NewStruct(new(sqlExtraction))
-	sb := sqlbuilder.Select("id", "user_id", "data", "metadata", "created_at").From(sqlExtractionTable)
+	sb := sqlbuilder.Select(extractionColumns...).From(sqlExtractionTable)
 	sb.Where(sb.Equal("id", id.String()))
 	sb.SetFlavor(sqlbuilder.SQLite)
 	query, args := sb.Build()
@@ -70,7 +85,7 @@ func (r *ExtractionRepository) Get(ctx context.Context, id recipesdomain.Extract
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
 	defer cancel()
 
-	row := r.connection.Db.QueryRowContext(ctxTimeout, query, args...)
+	row := r.connection.Executor(ctxTimeout).QueryRowContext(ctxTimeout, query, args...)
 	if err := row.Err(); err != nil {
 		return nil, fmt.Errorf("error trying to get user from database: %v", err)
 	}
@@ -80,22 +95,33 @@ func (r *ExtractionRepository) Get(ctx context.Context, id recipesdomain.Extract
 		return nil, nil
 	}
 
-	extractionVO, err := recipesdomain.NewExtraction(extraction.ID, extraction.UserID, extraction.Data, extraction.Metadata, extraction.CreatedAt)
+	extractionVO, err := toDomainExtraction(extraction)
 	return &extractionVO, err
 }
 
 func (r *ExtractionRepository) GetByUserID(ctx context.Context, userId recipesdomain.ExtractionUserID) ([]recipesdomain.Extraction, error) {
-	sb := sqlbuilder.Select("id", "user_id", "data", "metadata", "created_at").From(sqlExtractionTable)
+	sb := sqlbuilder.Select(extractionColumns...).From(sqlExtractionTable)
 	sb.Where(sb.Equal("user_id", userId.String()))
+	return r.list(ctx, sb, "user id")
+}
+
+func (r *ExtractionRepository) GetByOrganizationID(ctx context.Context, organizationId recipesdomain.ExtractionOrganizationID) ([]recipesdomain.Extraction, error) {
+	sb := sqlbuilder.Select(extractionColumns...).From(sqlExtractionTable)
+	sb.Where(sb.Equal("organization_id", organizationId.String()))
+	sb.OrderBy("created_at").Asc()
+	return r.list(ctx, sb, "organization id")
+}
+
+func (r *ExtractionRepository) list(ctx context.Context, sb *sqlbuilder.SelectBuilder, by string) ([]recipesdomain.Extraction, error) {
 	sb.SetFlavor(sqlbuilder.SQLite)
 	query, args := sb.Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
 	defer cancel()
 
-	rows, err := r.connection.Db.QueryContext(ctxTimeout, query, args...)
+	rows, err := r.connection.Executor(ctxTimeout).QueryContext(ctxTimeout, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error trying to get extractions by user id from database: %v", err)
+		return nil, fmt.Errorf("error trying to get extractions by %s from database: %v", by, err)
 	}
 	defer rows.Close()
 
@@ -107,7 +133,7 @@ func (r *ExtractionRepository) GetByUserID(ctx context.Context, userId recipesdo
 			return nil, fmt.Errorf("error scanning extraction row: %v", err)
 		}
 
-		extractionVO, err := recipesdomain.NewExtraction(extraction.ID, extraction.UserID, extraction.Data, extraction.Metadata, extraction.CreatedAt)
+		extractionVO, err := toDomainExtraction(extraction)
 		if err != nil {
 			return nil, err
 		}
@@ -116,3 +142,25 @@ func (r *ExtractionRepository) GetByUserID(ctx context.Context, userId recipesdo
 
 	return extractions, nil
 }
+
+// toDomainExtraction discards the events recorded while rebuilding the
+// extraction, since they were already stored when it was created.
+func toDomainExtraction(extraction *sqlExtraction) (recipesdomain.Extraction, error) {
+	domainExtraction, err := recipesdomain.NewExtraction(
+		extraction.ID,
+		extraction.UserID,
+		extraction.SourceUrl,
+		extraction.Status,
+		extraction.ErrorCategory,
+		extraction.ErrorMessage,
+		extraction.Data.String,
+		extraction.Metadata,
+		extraction.CreatedAt,
+	)
+	domainExtraction.PullEvents()
+	if err != nil {
+		return domainExtraction, err
+	}
+	err = domainExtraction.ShareWith(extraction.OrganizationID)
+	return domainExtraction, err
+}
@@ -0,0 +1,48 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/storagetest"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExtractionRepository_Contract(t *testing.T) {
+	storagetest.ExtractionRepositoryContract{
+		New: func(t *testing.T) recipesdomain.ExtractionRepository {
+			config := storage.Dbconfig{
+				Database: filepath.Join(t.TempDir(), "app"),
+				Timeout:  5 * time.Second,
+			}
+			connection, err := storage.CreateConnection(fmt.Sprintf("test-%s", t.Name()), &config)
+			require.NoError(t, err)
+			t.Cleanup(func() { connection.Db.Close() })
+			require.NoError(t, storage.CreateSchema(context.Background(), connection))
+
+			return NewExtractionRepository(connection, &config, newTestOutbox(connection, &config))
+		},
+		NewFailing: func(t *testing.T) recipesdomain.ExtractionRepository {
+			// sqlmock solo cubre los errores del almacenamiento: sin
+			// expectativas falla en cada consulta. El comportamiento se
+			// prueba contra el fichero SQLite de New.
+			db, _, err := sqlmock.New()
+			require.NoError(t, err)
+			t.Cleanup(func() { db.Close() })
+			connection := storage.Connection{
+				Db: db,
+			}
+			config := storage.Dbconfig{
+				Timeout: 1 * time.Second,
+			}
+
+			return NewExtractionRepository(&connection, &config, newTestOutbox(&connection, &config))
+		},
+	}.Run(t)
+}
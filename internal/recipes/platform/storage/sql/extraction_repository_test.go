@@ -8,14 +8,16 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/outbox"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	"github.com/rubenbupe/recipe-video-parser/kit/event"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func Test_ExtractionRepository_Save_RepositoryError(t *testing.T) {
-	extractionID, userID, data, metadata, createdAt := "37a0f027-15e6-47cc-a5d2-64183281087e", "37a0f027-15e6-47cc-a5d2-64183281087e", "{\"field\":\"value\"}", "{\"meta\":\"value\"}", "2023-10-01T00:00:00Z"
-	extraction, err := recipesdomain.NewExtraction(extractionID, userID, data, metadata, createdAt)
+	extractionID, userID, sourceUrl, status, data, metadata, createdAt := "37a0f027-15e6-47cc-a5d2-64183281087e", "37a0f027-15e6-47cc-a5d2-64183281087e", "https://example.com/video", recipesdomain.ExtractionStatusSucceeded, "{\"field\":\"value\"}", "{\"meta\":\"value\"}", "2023-10-01T00:00:00Z"
+	extraction, err := recipesdomain.NewExtraction(extractionID, userID, sourceUrl, status, "", "", data, metadata, createdAt)
 	require.NoError(t, err)
 
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
@@ -27,12 +29,14 @@ func Test_ExtractionRepository_Save_RepositoryError(t *testing.T) {
 	}
 	require.NoError(t, err)
 
+	sqlMock.ExpectBegin()
 	sqlMock.ExpectExec(
-		"INSERT INTO recipe_extractions (id, user_id, data, metadata, created_at) VALUES (?, ?, ?, ?, ?)").
-		WithArgs(extractionID, userID, data, metadata, createdAt).
+		"INSERT INTO recipe_extractions (id, user_id, organization_id, source_url, status, error_category, error_message, data, metadata, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)").
+		WithArgs(extractionID, userID, "", sourceUrl, status, "", "", data, metadata, createdAt).
 		WillReturnError(errors.New("something-failed"))
+	sqlMock.ExpectRollback()
 
-	repo := NewExtractionRepository(&connection, &config)
+	repo := NewExtractionRepository(&connection, &config, newTestOutbox(&connection, &config))
 
 	err = repo.Save(context.Background(), extraction)
 
@@ -41,9 +45,9 @@ func Test_ExtractionRepository_Save_RepositoryError(t *testing.T) {
 }
 
 func Test_ExtractionRepository_Save_Succeed(t *testing.T) {
-	extractionID, userID, data, metadata, createdAt := "37a0f027-15e6-47cc-a5d2-64183281087e", "37a0f027-15e6-47cc-a5d2-64183281087e", "{\"field\":\"value\"}", "{\"meta\":\"value\"}", "2023-10-01T00:00:00Z"
+	extractionID, userID, sourceUrl, status, data, metadata, createdAt := "37a0f027-15e6-47cc-a5d2-64183281087e", "37a0f027-15e6-47cc-a5d2-64183281087e", "https://example.com/video", recipesdomain.ExtractionStatusSucceeded, "{\"field\":\"value\"}", "{\"meta\":\"value\"}", "2023-10-01T00:00:00Z"
 
-	extraction, err := recipesdomain.NewExtraction(extractionID, userID, data, metadata, createdAt)
+	extraction, err := recipesdomain.NewExtraction(extractionID, userID, sourceUrl, status, "", "", data, metadata, createdAt)
 	require.NoError(t, err)
 
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
@@ -55,12 +59,53 @@ func Test_ExtractionRepository_Save_Succeed(t *testing.T) {
 	}
 	require.NoError(t, err)
 
+	sqlMock.ExpectBegin()
 	sqlMock.ExpectExec(
-		"INSERT INTO recipe_extractions (id, user_id, data, metadata, created_at) VALUES (?, ?, ?, ?, ?)").
-		WithArgs(extractionID, userID, data, metadata, createdAt).
+		"INSERT INTO recipe_extractions (id, user_id, organization_id, source_url, status, error_category, error_message, data, metadata, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)").
+		WithArgs(extractionID, userID, "", sourceUrl, status, "", "", data, metadata, createdAt).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(
+		"INSERT INTO event_outbox (id, type, aggregate_id, payload, occurred_on) VALUES (?, ?, ?, ?, ?)").
+		WithArgs(sqlmock.AnyArg(), "events.extraction.created", extractionID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+
+	repo := NewExtractionRepository(&connection, &config, newTestOutbox(&connection, &config))
+
+	err = repo.Save(context.Background(), extraction)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func Test_ExtractionRepository_Save_Failed(t *testing.T) {
+	extractionID, userID, sourceUrl, metadata, createdAt := "37a0f027-15e6-47cc-a5d2-64183281087e", "37a0f027-15e6-47cc-a5d2-64183281087e", "https://example.com/video", "{\"meta\":\"value\"}", "2023-10-01T00:00:00Z"
+	status, errorCategory, errorMessage := recipesdomain.ExtractionStatusFailed, recipesdomain.ExtractionErrorDownload, "failed to download file"
+
+	extraction, err := recipesdomain.NewExtraction(extractionID, userID, sourceUrl, status, errorCategory, errorMessage, "", metadata, createdAt)
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	connection := storage.Connection{
+		Db: db,
+	}
+	config := storage.Dbconfig{
+		Timeout: 1 * time.Millisecond,
+	}
+	require.NoError(t, err)
 
-	repo := NewExtractionRepository(&connection, &config)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(
+		"INSERT INTO recipe_extractions (id, user_id, organization_id, source_url, status, error_category, error_message, data, metadata, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)").
+		WithArgs(extractionID, userID, "", sourceUrl, status, errorCategory, errorMessage, nil, metadata, createdAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(
+		"INSERT INTO event_outbox (id, type, aggregate_id, payload, occurred_on) VALUES (?, ?, ?, ?, ?)").
+		WithArgs(sqlmock.AnyArg(), "events.extraction.created", extractionID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+
+	repo := NewExtractionRepository(&connection, &config, newTestOutbox(&connection, &config))
 
 	err = repo.Save(context.Background(), extraction)
 
@@ -85,7 +130,7 @@ func Test_ExtractionRepository_Exists_RepositoryError(t *testing.T) {
 		WithArgs(id).
 		WillReturnError(errors.New("something-failed"))
 
-	repo := NewExtractionRepository(&connection, &config)
+	repo := NewExtractionRepository(&connection, &config, newTestOutbox(&connection, &config))
 
 	extractionID, err := recipesdomain.NewExtractionID(id)
 	require.NoError(t, err)
@@ -113,7 +158,7 @@ func Test_ExtractionRepository_Exists_Succeed(t *testing.T) {
 		WithArgs(id).
 		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
 
-	repo := NewExtractionRepository(&connection, &config)
+	repo := NewExtractionRepository(&connection, &config, newTestOutbox(&connection, &config))
 
 	extractionID, err := recipesdomain.NewExtractionID(id)
 	require.NoError(t, err)
@@ -137,11 +182,11 @@ func Test_ExtractionRepository_Get_RepositoryError(t *testing.T) {
 	require.NoError(t, err)
 
 	sqlMock.ExpectQuery(
-		"SELECT id, user_id, data, metadata, created_at FROM recipe_extractions WHERE id = ?").
+		"SELECT id, user_id, organization_id, source_url, status, error_category, error_message, data, metadata, created_at FROM recipe_extractions WHERE id = ?").
 		WithArgs(id).
 		WillReturnError(errors.New("something-failed"))
 
-	repo := NewExtractionRepository(&connection, &config)
+	repo := NewExtractionRepository(&connection, &config, newTestOutbox(&connection, &config))
 
 	extractionID, err := recipesdomain.NewExtractionID(id)
 	require.NoError(t, err)
@@ -165,11 +210,11 @@ func Test_ExtractionRepository_Get_NotFound(t *testing.T) {
 	require.NoError(t, err)
 
 	sqlMock.ExpectQuery(
-		"SELECT id, user_id, data, metadata, created_at FROM recipe_extractions WHERE id = ?").
+		"SELECT id, user_id, organization_id, source_url, status, error_category, error_message, data, metadata, created_at FROM recipe_extractions WHERE id = ?").
 		WithArgs(id).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "data", "metadata", "created_at"}))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "organization_id", "source_url", "status", "error_category", "error_message", "data", "metadata", "created_at"}))
 
-	repo := NewExtractionRepository(&connection, &config)
+	repo := NewExtractionRepository(&connection, &config, newTestOutbox(&connection, &config))
 
 	extractionID, err := recipesdomain.NewExtractionID(id)
 	require.NoError(t, err)
@@ -197,11 +242,11 @@ func Test_ExtractionRepository_Get_Succeed(t *testing.T) {
 	require.NoError(t, err)
 
 	sqlMock.ExpectQuery(
-		"SELECT id, user_id, data, metadata, created_at FROM recipe_extractions WHERE id = ?").
+		"SELECT id, user_id, organization_id, source_url, status, error_category, error_message, data, metadata, created_at FROM recipe_extractions WHERE id = ?").
 		WithArgs(id).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "data", "metadata", "created_at"}).AddRow(id, userID, data, metadata, createdAt))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "organization_id", "source_url", "status", "error_category", "error_message", "data", "metadata", "created_at"}).AddRow(id, userID, "", "https://example.com/video", recipesdomain.ExtractionStatusSucceeded, "", "", data, metadata, createdAt))
 
-	repo := NewExtractionRepository(&connection, &config)
+	repo := NewExtractionRepository(&connection, &config, newTestOutbox(&connection, &config))
 
 	extractionID, err := recipesdomain.NewExtractionID(id)
 	require.NoError(t, err)
@@ -225,11 +270,11 @@ func Test_ExtractionRepository_GetByUserID_RepositoryError(t *testing.T) {
 	require.NoError(t, err)
 
 	sqlMock.ExpectQuery(
-		"SELECT id, user_id, data, metadata, created_at FROM recipe_extractions WHERE user_id = ?").
+		"SELECT id, user_id, organization_id, source_url, status, error_category, error_message, data, metadata, created_at FROM recipe_extractions WHERE user_id = ?").
 		WithArgs(userID).
 		WillReturnError(errors.New("something-failed"))
 
-	repo := NewExtractionRepository(&connection, &config)
+	repo := NewExtractionRepository(&connection, &config, newTestOutbox(&connection, &config))
 
 	extractionUserID, err := recipesdomain.NewExtractionUserID(userID)
 	require.NoError(t, err)
@@ -253,11 +298,11 @@ func Test_ExtractionRepository_GetByUserID_NotFound(t *testing.T) {
 	require.NoError(t, err)
 
 	sqlMock.ExpectQuery(
-		"SELECT id, user_id, data, metadata, created_at FROM recipe_extractions WHERE user_id = ?").
+		"SELECT id, user_id, organization_id, source_url, status, error_category, error_message, data, metadata, created_at FROM recipe_extractions WHERE user_id = ?").
 		WithArgs(userID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "data", "metadata", "created_at"}))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "organization_id", "source_url", "status", "error_category", "error_message", "data", "metadata", "created_at"}))
 
-	repo := NewExtractionRepository(&connection, &config)
+	repo := NewExtractionRepository(&connection, &config, newTestOutbox(&connection, &config))
 
 	extractionUserID, err := recipesdomain.NewExtractionUserID(userID)
 	require.NoError(t, err)
@@ -285,11 +330,11 @@ func Test_ExtractionRepository_GetByUserID_Succeed(t *testing.T) {
 	require.NoError(t, err)
 
 	sqlMock.ExpectQuery(
-		"SELECT id, user_id, data, metadata, created_at FROM recipe_extractions WHERE user_id = ?").
+		"SELECT id, user_id, organization_id, source_url, status, error_category, error_message, data, metadata, created_at FROM recipe_extractions WHERE user_id = ?").
 		WithArgs(userID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "data", "metadata", "created_at"}).AddRow(id, userID, data, metadata, createdAt))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "organization_id", "source_url", "status", "error_category", "error_message", "data", "metadata", "created_at"}).AddRow(id, userID, "", "https://example.com/video", recipesdomain.ExtractionStatusSucceeded, "", "", data, metadata, createdAt))
 
-	repo := NewExtractionRepository(&connection, &config)
+	repo := NewExtractionRepository(&connection, &config, newTestOutbox(&connection, &config))
 
 	extractionUserID, err := recipesdomain.NewExtractionUserID(userID)
 	require.NoError(t, err)
@@ -304,3 +349,9 @@ func Test_ExtractionRepository_GetByUserID_Succeed(t *testing.T) {
 	assert.Equal(t, metadata, extractions[0].Metadata)
 	assert.Equal(t, createdAt, extractions[0].CreatedAt.String())
 }
+
+func newTestOutbox(connection *storage.Connection, config *storage.Dbconfig) *outbox.Store {
+	registry := event.NewRegistry()
+	registry.Register(recipesdomain.ExtractionCreatedEventType, recipesdomain.DecodeExtractionCreatedEvent)
+	return outbox.NewStore(connection, config, registry)
+}
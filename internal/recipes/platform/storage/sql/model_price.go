@@ -0,0 +1,13 @@
+package sql
+
+const (
+	sqlModelPriceTable = "model_prices"
+)
+
+type sqlModelPrice struct {
+	Provider      string  `db:"provider"`
+	Model         string  `db:"model"`
+	InputPrice    float64 `db:"input_price"`
+	OutputPrice   float64 `db:"output_price"`
+	EffectiveFrom string  `db:"effective_from"`
+}
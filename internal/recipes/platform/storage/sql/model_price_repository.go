@@ -0,0 +1,80 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+)
+
+var modelPriceColumns = []string{"provider", "model", "input_price", "output_price", "effective_from"}
+
+type ModelPriceRepository struct {
+	connection *storage.Connection
+	dbconfig   *storage.Dbconfig
+}
+
+func NewModelPriceRepository(connection *storage.Connection, dbconfig *storage.Dbconfig) *ModelPriceRepository {
+	return &ModelPriceRepository{
+		connection: connection,
+		dbconfig:   dbconfig,
+	}
+}
+
+// Save inserts the price or replaces the one of the model from the same day.
+func (r *ModelPriceRepository) Save(ctx context.Context, price recipesdomain.ModelPrice) error {
+	query := "INSERT INTO " + sqlModelPriceTable + " (provider, model, input_price, output_price, effective_from) VALUES (?, ?, ?, ?, ?) " +
+		"ON CONFLICT(provider, model, effective_from) DO UPDATE SET input_price=excluded.input_price, output_price=excluded.output_price"
+	args := []interface{}{
+		price.Provider,
+		price.Model,
+		price.InputPrice,
+		price.OutputPrice,
+		price.EffectiveFrom,
+	}
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	_, err := r.connection.Executor(ctxTimeout).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("error trying to persist model price on database: %v", err)
+	}
+
+	return nil
+}
+
+func (r *ModelPriceRepository) All(ctx context.Context) ([]recipesdomain.ModelPrice, error) {
+	sb := sqlbuilder.Select(modelPriceColumns...).From(sqlModelPriceTable)
+	sb.OrderBy("provider", "model", "effective_from")
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	rows, err := r.connection.Executor(ctxTimeout).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to get model prices from database: %v", err)
+	}
+	defer rows.Close()
+
+	var prices []recipesdomain.ModelPrice
+	for rows.Next() {
+		modelPriceSQLStruct := sqlbuilder.NewStruct(new(sqlModelPrice))
+		price := new(sqlModelPrice)
+		if err := rows.Scan(modelPriceSQLStruct.Addr(price)...); err != nil {
+			return nil, fmt.Errorf("error scanning model price row: %v", err)
+		}
+
+		priceVO, err := recipesdomain.NewModelPrice(price.Provider, price.Model, price.InputPrice, price.OutputPrice, price.EffectiveFrom)
+		if err != nil {
+			return nil, err
+		}
+		prices = append(prices, priceVO)
+	}
+
+	return prices, nil
+}
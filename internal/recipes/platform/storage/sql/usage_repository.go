@@ -0,0 +1,158 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+)
+
+// Expresiones sobre las columnas de la subconsulta de usageQuery. Las fechas
+// se guardan en la hora local del servidor, así que el día son sus diez
+// primeros caracteres.
+const (
+	usageDay              = "substr(e.created_at, 1, 10)"
+	usagePromptTokens     = "COALESCE(json_extract(e.metadata, '$.promptTokenCount'), 0)"
+	usageCandidatesTokens = "COALESCE(json_extract(e.metadata, '$.candidatesTokenCount'), 0)"
+	// usageAuthority es el host de source_url con el puerto, y usageHost el
+	// host sin el puerto
+	usageAuthority = "substr(source_url, instr(source_url, '://') + 3, instr(substr(source_url, instr(source_url, '://') + 3) || '/', '/') - 1)"
+	usageHost      = "lower(substr(authority, 1, instr(authority || ':', ':') - 1))"
+)
+
+// usageKeys son las expresiones de la clave de cada grupo.
+var usageKeys = map[recipesdomain.UsageGroup]string{
+	recipesdomain.UsageByUser:     "COALESCE(u.name, e.user_id)",
+	recipesdomain.UsageByDay:      usageDay,
+	recipesdomain.UsageByWeek:     "date(" + usageDay + ", 'weekday 0', '-6 days')",
+	recipesdomain.UsageByMonth:    "substr(e.created_at, 1, 7)",
+	recipesdomain.UsageByModel:    "COALESCE(json_extract(e.metadata, '$.provider') || '/' || json_extract(e.metadata, '$.model'), '" + recipesdomain.UnknownModel + "')",
+	recipesdomain.UsageByPlatform: platformCase(),
+	recipesdomain.UsageByPrompt:   "COALESCE(NULLIF(json_extract(e.metadata, '$.promptId'), '') || '@' || COALESCE(json_extract(e.metadata, '$.promptVersion'), ''), '" + recipesdomain.UnknownPrompt + "')",
+	recipesdomain.UsageByError:    "e.error_category",
+}
+
+// usageOrders son los ORDER BY de cada orden. Los empates se ordenan por
+// clave para que el resultado sea estable.
+var usageOrders = map[recipesdomain.UsageSort]string{
+	recipesdomain.UsageSortKey:         "usage_key",
+	recipesdomain.UsageSortExtractions: "extractions DESC, usage_key",
+	recipesdomain.UsageSortTokens:      "total_tokens DESC, usage_key",
+	recipesdomain.UsageSortCost:        "cost DESC, usage_key",
+}
+
+// platformCase devuelve el CASE que calcula la plataforma de e.host como
+// recipesdomain.PlatformOf.
+func platformCase() string {
+	var b strings.Builder
+	b.WriteString("CASE")
+	for _, domain := range recipesdomain.PlatformDomains {
+		fmt.Fprintf(&b, " WHEN e.host = '%s' OR e.host LIKE '%%.%s' THEN '%s'", domain.Domain, domain.Domain, domain.Platform)
+	}
+	fmt.Fprintf(&b, " ELSE '%s' END", recipesdomain.PlatformOther)
+	return b.String()
+}
+
+// usageQuery agrupa las extracciones por la clave %[1]s y les aplica el
+// precio de su modelo vigente el día de la extracción. El filtro %[2]s y el
+// orden %[3]s se añaden al construir la consulta.
+const usageQuery = `SELECT %[1]s AS usage_key,
+	COUNT(*) AS extractions,
+	SUM(CASE WHEN e.status = '` + recipesdomain.ExtractionStatusSucceeded + `' THEN 1 ELSE 0 END),
+	SUM(CASE WHEN e.status = '` + recipesdomain.ExtractionStatusNotARecipe + `' THEN 1 ELSE 0 END),
+	SUM(CASE WHEN e.status = '` + recipesdomain.ExtractionStatusFailed + `' THEN 1 ELSE 0 END),
+	SUM(` + usagePromptTokens + `),
+	SUM(` + usageCandidatesTokens + `),
+	SUM(` + usagePromptTokens + ` + ` + usageCandidatesTokens + `) AS total_tokens,
+	COALESCE(SUM(` + usagePromptTokens + ` * p.input_price + ` + usageCandidatesTokens + ` * p.output_price), 0) / 1000000.0 AS cost,
+	SUM(CASE WHEN p.model IS NULL AND ` + usagePromptTokens + ` + ` + usageCandidatesTokens + ` > 0 THEN 1 ELSE 0 END)
+FROM (SELECT *, ` + usageHost + ` AS host FROM (SELECT *, ` + usageAuthority + ` AS authority FROM ` + sqlExtractionTable + `)) e
+LEFT JOIN users u ON u.id = e.user_id
+LEFT JOIN ` + sqlModelPriceTable + ` p ON p.provider = json_extract(e.metadata, '$.provider')
+	AND p.model = json_extract(e.metadata, '$.model')
+	AND p.effective_from = (
+		SELECT MAX(effective_from) FROM ` + sqlModelPriceTable + `
+		WHERE provider = p.provider AND model = p.model AND effective_from <= ` + usageDay + `
+	)
+WHERE 1 = 1%[2]s
+GROUP BY usage_key
+ORDER BY %[3]s`
+
+type UsageRepository struct {
+	connection *storage.Connection
+	dbconfig   *storage.Dbconfig
+}
+
+func NewUsageRepository(connection *storage.Connection, dbconfig *storage.Dbconfig) *UsageRepository {
+	return &UsageRepository{
+		connection: connection,
+		dbconfig:   dbconfig,
+	}
+}
+
+// Report aggregates the extractions in the database, reading the tokens, the
+// model and the prompt of each one from its metadata. The platform of the extractions is
+// calculated from the host of their url like recipesdomain.PlatformOf.
+func (r *UsageRepository) Report(ctx context.Context, filter recipesdomain.UsageFilter, group recipesdomain.UsageGroup, sort recipesdomain.UsageSort) ([]recipesdomain.UsageRow, error) {
+	key, ok := usageKeys[group]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", recipesdomain.ErrInvalidUsageGroup, group)
+	}
+	order, ok := usageOrders[sort]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", recipesdomain.ErrInvalidUsageSort, sort)
+	}
+
+	var where strings.Builder
+	var args []interface{}
+	if filter.UserId != "" {
+		where.WriteString(" AND e.user_id = ?")
+		args = append(args, filter.UserId)
+	}
+	if filter.OrganizationId != "" {
+		where.WriteString(" AND e.organization_id = ?")
+		args = append(args, filter.OrganizationId)
+	}
+	if filter.Personal {
+		where.WriteString(" AND e.organization_id = ''")
+	}
+	if filter.From != "" {
+		where.WriteString(" AND " + usageDay + " >= ?")
+		args = append(args, filter.From)
+	}
+	if filter.To != "" {
+		where.WriteString(" AND " + usageDay + " <= ?")
+		args = append(args, filter.To)
+	}
+	query := fmt.Sprintf(usageQuery, key, where.String(), order)
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	rows, err := r.connection.Executor(ctxTimeout).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to get the usage of the extractions from database: %v", err)
+	}
+	defer rows.Close()
+
+	var report []recipesdomain.UsageRow
+	for rows.Next() {
+		var row recipesdomain.UsageRow
+		err := rows.Scan(&row.Key, &row.Extractions, &row.Succeeded, &row.NotARecipe, &row.Failed,
+			&row.PromptTokens, &row.CandidatesTokens, &row.TotalTokens, &row.Cost, &row.Unpriced)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning usage row: %v", err)
+		}
+		// Los precios son por millón de tokens: se redondea a la millonésima
+		row.Cost = math.Round(row.Cost*1e6) / 1e6
+		report = append(report, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading the usage of the extractions from database: %v", err)
+	}
+
+	return report, nil
+}
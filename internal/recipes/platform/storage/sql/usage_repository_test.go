@@ -0,0 +1,300 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	usageUserID = "37a0f027-15e6-47cc-a5d2-64183281087e"
+	otherUserID = "4a0c8d2e-6b1f-4c3a-9e5d-7f2b1a0c9d8e"
+	geminiFlash = `"provider":"google","model":"gemini-2.0-flash"`
+	geminiPro   = `"provider":"google","model":"gemini-2.5-pro"`
+	oldTokens   = `"promptTokenCount":100,"candidatesTokenCount":10`
+	promptV3    = `"promptId":"extract-recipe","promptVersion":"v3"`
+)
+
+// newUsageDatabase crea una base de datos con dos usuarios, los precios de
+// gemini-2.0-flash y estas extracciones:
+//
+//   - ana, 2024-04-30 (martes), tiktok, flash al primer precio: 1000 + 100 tokens.
+//   - ana, 2024-05-02 (jueves), youtube, flash al segundo precio: 2000 + 200
+//     tokens, con el prompt extract-recipe@v3, no es una receta.
+//   - ana, 2024-05-06 (lunes), instagram, pro sin precio: 500 + 50 tokens.
+//   - ana, 2024-05-06, web, antigua sin modelo ni tokens, fallida.
+//   - bob, 2024-05-03, youtu.be con puerto, antigua sin modelo: 100 + 10 tokens.
+func newUsageDatabase(t *testing.T) (*storage.Connection, *storage.Dbconfig) {
+	t.Helper()
+	config := storage.Dbconfig{
+		Database: filepath.Join(t.TempDir(), "app"),
+		Timeout:  5 * time.Second,
+	}
+	connection, err := storage.CreateConnection(fmt.Sprintf("test-%s", t.Name()), &config)
+	require.NoError(t, err)
+	t.Cleanup(func() { connection.Db.Close() })
+	require.NoError(t, storage.CreateSchema(context.Background(), connection))
+
+	ctx := context.Background()
+	for id, name := range map[string]string{usageUserID: "ana", otherUserID: "bob"} {
+		_, err := connection.Db.ExecContext(ctx, "INSERT INTO users (id, name, api_key) VALUES (?, ?, ?)", id, name, "apikey-"+name)
+		require.NoError(t, err)
+	}
+
+	prices := NewModelPriceRepository(connection, &config)
+	for _, price := range []recipesdomain.ModelPrice{
+		newModelPrice(t, "gemini-2.0-flash", 100, 1000, "2024-01-01"),
+		newModelPrice(t, "gemini-2.0-flash", 200, 2000, "2024-05-01"),
+	} {
+		require.NoError(t, prices.Save(ctx, price))
+	}
+
+	extractions := NewExtractionRepository(connection, &config, newTestOutbox(connection, &config))
+	for _, extraction := range []struct {
+		userId, url, status, metadata, createdAt string
+	}{
+		{usageUserID, "https://www.tiktok.com/@chef/video/1", recipesdomain.ExtractionStatusSucceeded, `{"promptTokenCount":1000,"candidatesTokenCount":100,` + geminiFlash + `}`, "2024-04-30T10:00:00+02:00"},
+		{usageUserID, "https://m.youtube.com/watch?v=1", recipesdomain.ExtractionStatusNotARecipe, `{"promptTokenCount":2000,"candidatesTokenCount":200,` + geminiFlash + `,` + promptV3 + `}`, "2024-05-02T10:00:00+02:00"},
+		{usageUserID, "https://instagram.com/reel/1", recipesdomain.ExtractionStatusSucceeded, `{"promptTokenCount":500,"candidatesTokenCount":50,` + geminiPro + `}`, "2024-05-06T10:00:00+02:00"},
+		{usageUserID, "https://example.com/video.mp4", recipesdomain.ExtractionStatusFailed, `{"locale":"es"}`, "2024-05-06T11:00:00+02:00"},
+		{otherUserID, "https://youtu.be:443/1", recipesdomain.ExtractionStatusSucceeded, `{` + oldTokens + `}`, "2024-05-03T10:00:00+02:00"},
+	} {
+		data := ""
+		if extraction.status == recipesdomain.ExtractionStatusSucceeded {
+			data = `{"title":"Tortilla"}`
+		}
+		errorCategory := ""
+		switch extraction.status {
+		case recipesdomain.ExtractionStatusNotARecipe:
+			errorCategory = recipesdomain.ExtractionErrorNotARecipe
+		case recipesdomain.ExtractionStatusFailed:
+			errorCategory = recipesdomain.ExtractionErrorDownload
+		}
+		e, err := recipesdomain.NewExtraction(uuid.New().String(), extraction.userId, extraction.url, extraction.status, errorCategory, "", data, extraction.metadata, extraction.createdAt)
+		require.NoError(t, err)
+		require.NoError(t, extractions.Save(ctx, e))
+	}
+
+	return connection, &config
+}
+
+func newModelPrice(t *testing.T, model string, inputPrice, outputPrice float64, effectiveFrom string) recipesdomain.ModelPrice {
+	t.Helper()
+	price, err := recipesdomain.NewModelPrice("google", model, inputPrice, outputPrice, effectiveFrom)
+	require.NoError(t, err)
+	return price
+}
+
+func keysOf(report []recipesdomain.UsageRow) []string {
+	keys := make([]string, 0, len(report))
+	for _, row := range report {
+		keys = append(keys, row.Key)
+	}
+	return keys
+}
+
+func Test_UsageRepository_Report(t *testing.T) {
+	connection, config := newUsageDatabase(t)
+	repo := NewUsageRepository(connection, config)
+	ctx := context.Background()
+
+	t.Run("by month with the price of each day", func(t *testing.T) {
+		report, err := repo.Report(ctx, recipesdomain.UsageFilter{}, recipesdomain.UsageByMonth, recipesdomain.UsageSortKey)
+		require.NoError(t, err)
+
+		assert.Equal(t, []recipesdomain.UsageRow{
+			{Key: "2024-04", Extractions: 1, Succeeded: 1, PromptTokens: 1000, CandidatesTokens: 100, TotalTokens: 1100, Cost: 0.2},
+			{Key: "2024-05", Extractions: 4, Succeeded: 2, NotARecipe: 1, Failed: 1, PromptTokens: 2600, CandidatesTokens: 260, TotalTokens: 2860, Cost: 0.8, Unpriced: 2},
+		}, report)
+	})
+
+	t.Run("by week from monday", func(t *testing.T) {
+		report, err := repo.Report(ctx, recipesdomain.UsageFilter{}, recipesdomain.UsageByWeek, recipesdomain.UsageSortKey)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"2024-04-29", "2024-05-06"}, keysOf(report))
+		assert.Equal(t, 3, report[0].Extractions)
+	})
+
+	t.Run("by day", func(t *testing.T) {
+		report, err := repo.Report(ctx, recipesdomain.UsageFilter{}, recipesdomain.UsageByDay, recipesdomain.UsageSortKey)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"2024-04-30", "2024-05-02", "2024-05-03", "2024-05-06"}, keysOf(report))
+	})
+
+	t.Run("by model", func(t *testing.T) {
+		report, err := repo.Report(ctx, recipesdomain.UsageFilter{}, recipesdomain.UsageByModel, recipesdomain.UsageSortKey)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"google/gemini-2.0-flash", "google/gemini-2.5-pro", recipesdomain.UnknownModel}, keysOf(report))
+		assert.Equal(t, 1.0, report[0].Cost)
+		assert.Equal(t, 1, report[1].Unpriced)
+		assert.Equal(t, 2, report[2].Extractions)
+		assert.Equal(t, 1, report[2].Unpriced)
+	})
+
+	t.Run("by platform", func(t *testing.T) {
+		report, err := repo.Report(ctx, recipesdomain.UsageFilter{}, recipesdomain.UsageByPlatform, recipesdomain.UsageSortKey)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{recipesdomain.PlatformInstagram, recipesdomain.PlatformOther, recipesdomain.PlatformTikTok, recipesdomain.PlatformYouTube}, keysOf(report))
+		assert.Equal(t, 2, report[3].Extractions)
+	})
+
+	t.Run("by prompt", func(t *testing.T) {
+		report, err := repo.Report(ctx, recipesdomain.UsageFilter{}, recipesdomain.UsageByPrompt, recipesdomain.UsageSortKey)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"extract-recipe@v3", recipesdomain.UnknownPrompt}, keysOf(report))
+		assert.Equal(t, 1, report[0].NotARecipe)
+		assert.Equal(t, 4, report[1].Extractions)
+	})
+
+	t.Run("by error category", func(t *testing.T) {
+		report, err := repo.Report(ctx, recipesdomain.UsageFilter{}, recipesdomain.UsageByError, recipesdomain.UsageSortKey)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"", recipesdomain.ExtractionErrorDownload, recipesdomain.ExtractionErrorNotARecipe}, keysOf(report))
+		assert.Equal(t, 1, report[1].Failed)
+	})
+
+	t.Run("by user sorted by tokens", func(t *testing.T) {
+		report, err := repo.Report(ctx, recipesdomain.UsageFilter{}, recipesdomain.UsageByUser, recipesdomain.UsageSortTokens)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"ana", "bob"}, keysOf(report))
+		assert.Equal(t, 3850, report[0].TotalTokens)
+	})
+
+	t.Run("sorted by cost", func(t *testing.T) {
+		report, err := repo.Report(ctx, recipesdomain.UsageFilter{}, recipesdomain.UsageByDay, recipesdomain.UsageSortCost)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"2024-05-02", "2024-04-30", "2024-05-03", "2024-05-06"}, keysOf(report))
+	})
+
+	t.Run("filtered by user and days", func(t *testing.T) {
+		filter, err := recipesdomain.NewUsageFilter(usageUserID, "2024-05-01", "2024-05-02")
+		require.NoError(t, err)
+
+		report, err := repo.Report(ctx, filter, recipesdomain.UsageByMonth, recipesdomain.UsageSortKey)
+		require.NoError(t, err)
+
+		assert.Equal(t, []recipesdomain.UsageRow{
+			{Key: "2024-05", Extractions: 1, NotARecipe: 1, PromptTokens: 2000, CandidatesTokens: 200, TotalTokens: 2200, Cost: 0.8},
+		}, report)
+	})
+
+	t.Run("without extractions", func(t *testing.T) {
+		filter, err := recipesdomain.NewUsageFilter(usageUserID, "2025-01-01", "")
+		require.NoError(t, err)
+
+		report, err := repo.Report(ctx, filter, recipesdomain.UsageByMonth, recipesdomain.UsageSortKey)
+		require.NoError(t, err)
+
+		assert.Empty(t, report)
+	})
+
+	t.Run("with an unknown group", func(t *testing.T) {
+		_, err := repo.Report(ctx, recipesdomain.UsageFilter{}, recipesdomain.UsageGroup("year"), recipesdomain.UsageSortKey)
+
+		assert.ErrorIs(t, err, recipesdomain.ErrInvalidUsageGroup)
+	})
+}
+
+func Test_UsageRepository_Report_Organizations(t *testing.T) {
+	connection, config := newUsageDatabase(t)
+	repo := NewUsageRepository(connection, config)
+	ctx := context.Background()
+	organizationID := "5d1c7a9e-3f2b-4e8d-a6c0-9b7e1f3d5a2c"
+	_, err := connection.Db.ExecContext(ctx, "UPDATE recipe_extractions SET organization_id = ? WHERE source_url = ?", organizationID, "https://m.youtube.com/watch?v=1")
+	require.NoError(t, err)
+
+	t.Run("of an organization", func(t *testing.T) {
+		report, err := repo.Report(ctx, recipesdomain.UsageFilter{OrganizationId: organizationID}, recipesdomain.UsageByMonth, recipesdomain.UsageSortKey)
+		require.NoError(t, err)
+
+		require.Len(t, report, 1)
+		assert.Equal(t, 1, report[0].Extractions)
+		assert.Equal(t, 2200, report[0].TotalTokens)
+	})
+
+	t.Run("personal", func(t *testing.T) {
+		report, err := repo.Report(ctx, recipesdomain.UsageFilter{UserId: usageUserID, Personal: true, From: "2024-05-01"}, recipesdomain.UsageByMonth, recipesdomain.UsageSortKey)
+		require.NoError(t, err)
+
+		require.Len(t, report, 1)
+		assert.Equal(t, 2, report[0].Extractions, "the extraction of the organization is not counted")
+		assert.Equal(t, 550, report[0].TotalTokens)
+	})
+}
+
+func Test_UsageRepository_Report_RepositoryError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	connection := storage.Connection{
+		Db: db,
+	}
+	config := storage.Dbconfig{
+		Timeout: 1 * time.Millisecond,
+	}
+
+	sqlMock.ExpectQuery("SELECT").WillReturnError(errors.New("something-failed"))
+
+	repo := NewUsageRepository(&connection, &config)
+
+	_, err = repo.Report(context.Background(), recipesdomain.UsageFilter{}, recipesdomain.UsageByMonth, recipesdomain.UsageSortKey)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func Test_ModelPriceRepository_Save_ReplacesThePriceOfTheDay(t *testing.T) {
+	connection, config := newUsageDatabase(t)
+	repo := NewModelPriceRepository(connection, config)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Save(ctx, newModelPrice(t, "gemini-2.0-flash", 150, 1500, "2024-05-01")))
+	require.NoError(t, repo.Save(ctx, newModelPrice(t, "gemini-2.5-pro", 1250, 10000, "2024-05-01")))
+
+	prices, err := repo.All(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []recipesdomain.ModelPrice{
+		newModelPrice(t, "gemini-2.0-flash", 100, 1000, "2024-01-01"),
+		newModelPrice(t, "gemini-2.0-flash", 150, 1500, "2024-05-01"),
+		newModelPrice(t, "gemini-2.5-pro", 1250, 10000, "2024-05-01"),
+	}, prices)
+}
+
+func Test_ModelPriceRepository_All_RepositoryError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	connection := storage.Connection{
+		Db: db,
+	}
+	config := storage.Dbconfig{
+		Timeout: 1 * time.Millisecond,
+	}
+
+	sqlMock.ExpectQuery("SELECT provider, model, input_price, output_price, effective_from FROM model_prices ORDER BY provider, model, effective_from").
+		WillReturnError(errors.New("something-failed"))
+
+	repo := NewModelPriceRepository(&connection, &config)
+
+	_, err = repo.All(context.Background())
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
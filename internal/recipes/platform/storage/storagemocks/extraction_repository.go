@@ -72,6 +72,36 @@ func (_m *ExtractionRepository) Get(ctx context.Context, id domain.ExtractionID)
 	return r0, r1
 }
 
+// GetByOrganizationID provides a mock function with given fields: ctx, organizationId
+func (_m *ExtractionRepository) GetByOrganizationID(ctx context.Context, organizationId domain.ExtractionOrganizationID) ([]domain.Extraction, error) {
+	ret := _m.Called(ctx, organizationId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByOrganizationID")
+	}
+
+	var r0 []domain.Extraction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ExtractionOrganizationID) ([]domain.Extraction, error)); ok {
+		return rf(ctx, organizationId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ExtractionOrganizationID) []domain.Extraction); ok {
+		r0 = rf(ctx, organizationId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Extraction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ExtractionOrganizationID) error); ok {
+		r1 = rf(ctx, organizationId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByUserID provides a mock function with given fields: ctx, extractionId
 func (_m *ExtractionRepository) GetByUserID(ctx context.Context, extractionId domain.ExtractionUserID) ([]domain.Extraction, error) {
 	ret := _m.Called(ctx, extractionId)
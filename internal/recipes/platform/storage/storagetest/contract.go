@@ -0,0 +1,234 @@
+// Package storagetest holds the behaviour every
+// recipesdomain.ExtractionRepository implementation must have, so the same
+// tests run against all of them.
+package storagetest
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ExtractionRepositoryContract runs the repository tests against the
+// repositories built by its factories.
+type ExtractionRepositoryContract struct {
+	// New returns an empty repository.
+	New func(t *testing.T) recipesdomain.ExtractionRepository
+	// NewFailing returns a repository whose storage fails on every call. It
+	// is optional; implementations that can not fail leave it nil.
+	NewFailing func(t *testing.T) recipesdomain.ExtractionRepository
+}
+
+// Run runs the contract tests as subtests of t.
+func (c ExtractionRepositoryContract) Run(t *testing.T) {
+	if c.New != nil {
+		t.Run("it returns nothing for unknown extractions", c.testUnknownExtraction)
+		t.Run("it gets a saved extraction", c.testSaveAndGet)
+		t.Run("it gets a failed extraction without data", c.testSaveFailed)
+		t.Run("it rejects an extraction with the id of another one", c.testDuplicatedID)
+		t.Run("it gets the extractions of a user", c.testGetByUserID)
+		t.Run("it gets the extractions of an organization", c.testGetByOrganizationID)
+		t.Run("it saves extractions concurrently", c.testConcurrentSaves)
+	}
+	if c.NewFailing != nil {
+		t.Run("it returns the errors of the storage", c.testFailingStorage)
+	}
+}
+
+func (c ExtractionRepositoryContract) testUnknownExtraction(t *testing.T) {
+	ctx := context.Background()
+	repo := c.New(t)
+	extraction := NewExtraction(t, uuid.New().String())
+
+	exists, err := repo.Exists(ctx, extraction.Id)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	got, err := repo.Get(ctx, extraction.Id)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	extractions, err := repo.GetByUserID(ctx, extraction.UserId)
+	require.NoError(t, err)
+	assert.Empty(t, extractions)
+}
+
+func (c ExtractionRepositoryContract) testSaveAndGet(t *testing.T) {
+	ctx := context.Background()
+	repo := c.New(t)
+	extraction := NewExtraction(t, uuid.New().String())
+
+	require.NoError(t, repo.Save(ctx, extraction))
+
+	exists, err := repo.Exists(ctx, extraction.Id)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	got, err := repo.Get(ctx, extraction.Id)
+	require.NoError(t, err)
+	AssertSameExtraction(t, extraction, got)
+}
+
+func (c ExtractionRepositoryContract) testSaveFailed(t *testing.T) {
+	ctx := context.Background()
+	repo := c.New(t)
+	extraction, err := recipesdomain.NewExtraction(uuid.New().String(), uuid.New().String(), "https://example.com/video", "failed", "download", "something-failed", "", `{"totalTokenCount": 0}`, "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Save(ctx, extraction))
+
+	got, err := repo.Get(ctx, extraction.Id)
+	require.NoError(t, err)
+	AssertSameExtraction(t, extraction, got)
+}
+
+func (c ExtractionRepositoryContract) testDuplicatedID(t *testing.T) {
+	ctx := context.Background()
+	repo := c.New(t)
+	extraction := NewExtraction(t, uuid.New().String())
+	require.NoError(t, repo.Save(ctx, extraction))
+
+	err := repo.Save(ctx, NewExtractionWithID(t, extraction.Id.String(), uuid.New().String()))
+
+	assert.Error(t, err)
+}
+
+func (c ExtractionRepositoryContract) testGetByUserID(t *testing.T) {
+	ctx := context.Background()
+	repo := c.New(t)
+	userID := uuid.New().String()
+	first := NewExtraction(t, userID)
+	second := NewExtraction(t, userID)
+	other := NewExtraction(t, uuid.New().String())
+	for _, extraction := range []recipesdomain.Extraction{first, other, second} {
+		require.NoError(t, repo.Save(ctx, extraction))
+	}
+
+	extractions, err := repo.GetByUserID(ctx, first.UserId)
+
+	require.NoError(t, err)
+	var ids []string
+	for _, extraction := range extractions {
+		ids = append(ids, extraction.Id.String())
+	}
+	assert.ElementsMatch(t, []string{first.Id.String(), second.Id.String()}, ids)
+}
+
+func (c ExtractionRepositoryContract) testGetByOrganizationID(t *testing.T) {
+	ctx := context.Background()
+	repo := c.New(t)
+	organizationID := uuid.New().String()
+	first := NewExtraction(t, uuid.New().String())
+	require.NoError(t, first.ShareWith(organizationID))
+	second := NewExtraction(t, uuid.New().String())
+	require.NoError(t, second.ShareWith(organizationID))
+	personal := NewExtraction(t, first.UserId.String())
+	for _, extraction := range []recipesdomain.Extraction{first, personal, second} {
+		require.NoError(t, repo.Save(ctx, extraction))
+	}
+
+	extractions, err := repo.GetByOrganizationID(ctx, first.OrganizationId)
+
+	require.NoError(t, err)
+	var ids []string
+	for _, extraction := range extractions {
+		assert.Equal(t, organizationID, extraction.OrganizationId.String())
+		ids = append(ids, extraction.Id.String())
+	}
+	assert.ElementsMatch(t, []string{first.Id.String(), second.Id.String()}, ids)
+
+	got, err := repo.Get(ctx, personal.Id)
+	require.NoError(t, err)
+	AssertSameExtraction(t, personal, got)
+}
+
+func (c ExtractionRepositoryContract) testConcurrentSaves(t *testing.T) {
+	ctx := context.Background()
+	repo := c.New(t)
+	userID := uuid.New().String()
+
+	extractions := make([]recipesdomain.Extraction, 10)
+	for i := range extractions {
+		extractions[i] = NewExtraction(t, userID)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(extractions))
+	for _, extraction := range extractions {
+		wg.Add(1)
+		go func(extraction recipesdomain.Extraction) {
+			defer wg.Done()
+			errs <- repo.Save(ctx, extraction)
+		}(extraction)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	got, err := repo.GetByUserID(ctx, extractions[0].UserId)
+	require.NoError(t, err)
+	assert.Len(t, got, len(extractions))
+}
+
+func (c ExtractionRepositoryContract) testFailingStorage(t *testing.T) {
+	ctx := context.Background()
+	repo := c.NewFailing(t)
+	extraction := NewExtraction(t, uuid.New().String())
+
+	assert.Error(t, repo.Save(ctx, extraction))
+
+	_, err := repo.Exists(ctx, extraction.Id)
+	assert.Error(t, err)
+
+	_, err = repo.Get(ctx, extraction.Id)
+	assert.Error(t, err)
+
+	_, err = repo.GetByUserID(ctx, extraction.UserId)
+	assert.Error(t, err)
+
+	_, err = repo.GetByOrganizationID(ctx, extraction.OrganizationId)
+	assert.Error(t, err)
+}
+
+// NewExtraction returns a valid succeeded extraction of the given user with a
+// random ID.
+func NewExtraction(t *testing.T, userID string) recipesdomain.Extraction {
+	t.Helper()
+	return NewExtractionWithID(t, uuid.New().String(), userID)
+}
+
+// NewExtractionWithID returns a valid succeeded extraction with the given ID
+// and user.
+func NewExtractionWithID(t *testing.T, id, userID string) recipesdomain.Extraction {
+	t.Helper()
+	extraction, err := recipesdomain.NewExtraction(id, userID, "https://example.com/video", "succeeded", "", "", `{"title": "Tortilla"}`, `{"totalTokenCount": 100}`, "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+	return extraction
+}
+
+// AssertSameExtraction checks that got holds the same data as want.
+func AssertSameExtraction(t *testing.T, want recipesdomain.Extraction, got *recipesdomain.Extraction) {
+	t.Helper()
+	require.NotNil(t, got)
+	assert.Equal(t, want.Id.String(), got.Id.String())
+	assert.Equal(t, want.UserId.String(), got.UserId.String())
+	assert.Equal(t, want.OrganizationId.String(), got.OrganizationId.String())
+	assert.Equal(t, want.SourceUrl, got.SourceUrl)
+	assert.Equal(t, want.Status.String(), got.Status.String())
+	assert.Equal(t, want.ErrorCategory.String(), got.ErrorCategory.String())
+	assert.Equal(t, want.ErrorMessage, got.ErrorMessage)
+	if want.Data == "" {
+		assert.Empty(t, got.Data)
+	} else {
+		assert.JSONEq(t, want.Data, got.Data)
+	}
+	assert.JSONEq(t, want.Metadata, got.Metadata)
+	assert.Equal(t, want.CreatedAt.String(), got.CreatedAt.String())
+}
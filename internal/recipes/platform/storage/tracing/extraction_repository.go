@@ -0,0 +1,57 @@
+package tracing
+
+import (
+	"context"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	sharedtracing "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+)
+
+// ExtractionRepository decorates a recipesdomain.ExtractionRepository with a
+// span for every call.
+type ExtractionRepository struct {
+	next recipesdomain.ExtractionRepository
+}
+
+// NewExtractionRepository returns an ExtractionRepository that traces the
+// calls to next.
+func NewExtractionRepository(next recipesdomain.ExtractionRepository) *ExtractionRepository {
+	return &ExtractionRepository{
+		next: next,
+	}
+}
+
+// Save implements the recipesdomain.ExtractionRepository interface.
+func (r *ExtractionRepository) Save(ctx context.Context, extraction recipesdomain.Extraction) error {
+	return sharedtracing.Run(ctx, "extractions.repository.Save", func(ctx context.Context) error {
+		return r.next.Save(ctx, extraction)
+	})
+}
+
+// Exists implements the recipesdomain.ExtractionRepository interface.
+func (r *ExtractionRepository) Exists(ctx context.Context, id recipesdomain.ExtractionID) (bool, error) {
+	return sharedtracing.Call(ctx, "extractions.repository.Exists", func(ctx context.Context) (bool, error) {
+		return r.next.Exists(ctx, id)
+	})
+}
+
+// Get implements the recipesdomain.ExtractionRepository interface.
+func (r *ExtractionRepository) Get(ctx context.Context, id recipesdomain.ExtractionID) (*recipesdomain.Extraction, error) {
+	return sharedtracing.Call(ctx, "extractions.repository.Get", func(ctx context.Context) (*recipesdomain.Extraction, error) {
+		return r.next.Get(ctx, id)
+	})
+}
+
+// GetByUserID implements the recipesdomain.ExtractionRepository interface.
+func (r *ExtractionRepository) GetByUserID(ctx context.Context, userId recipesdomain.ExtractionUserID) ([]recipesdomain.Extraction, error) {
+	return sharedtracing.Call(ctx, "extractions.repository.GetByUserID", func(ctx context.Context) ([]recipesdomain.Extraction, error) {
+		return r.next.GetByUserID(ctx, userId)
+	})
+}
+
+// GetByOrganizationID implements the recipesdomain.ExtractionRepository interface.
+func (r *ExtractionRepository) GetByOrganizationID(ctx context.Context, organizationId recipesdomain.ExtractionOrganizationID) ([]recipesdomain.Extraction, error) {
+	return sharedtracing.Call(ctx, "extractions.repository.GetByOrganizationID", func(ctx context.Context) ([]recipesdomain.Extraction, error) {
+		return r.next.GetByOrganizationID(ctx, organizationId)
+	})
+}
@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/inmemory"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/storagemocks"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/storagetest"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing/tracingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func Test_ExtractionRepository_Contract(t *testing.T) {
+	storagetest.ExtractionRepositoryContract{
+		New: func(t *testing.T) recipesdomain.ExtractionRepository {
+			return NewExtractionRepository(inmemory.NewExtractionRepository(nil))
+		},
+	}.Run(t)
+}
+
+func Test_ExtractionRepository_TracesCalls(t *testing.T) {
+	exporter := tracingtest.Record(t)
+	extraction := storagetest.NewExtraction(t, uuid.New().String())
+
+	next := new(storagemocks.ExtractionRepository)
+	next.On("Save", mock.Anything, extraction).Return(nil)
+	next.On("Exists", mock.Anything, extraction.Id).Return(false, errors.New("something unexpected happened"))
+	repo := NewExtractionRepository(next)
+
+	require.NoError(t, repo.Save(context.Background(), extraction))
+	_, err := repo.Exists(context.Background(), extraction.Id)
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"extractions.repository.Save", "extractions.repository.Exists"}, tracingtest.Names(exporter))
+	assert.Equal(t, codes.Error, tracingtest.Span(t, exporter, "extractions.repository.Exists").Status.Code)
+	next.AssertExpectations(t)
+}
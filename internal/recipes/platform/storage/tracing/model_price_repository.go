@@ -0,0 +1,36 @@
+package tracing
+
+import (
+	"context"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	sharedtracing "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+)
+
+// ModelPriceRepository decorates a recipesdomain.ModelPriceRepository with a
+// span for every call.
+type ModelPriceRepository struct {
+	next recipesdomain.ModelPriceRepository
+}
+
+// NewModelPriceRepository returns a ModelPriceRepository that traces the calls
+// to next.
+func NewModelPriceRepository(next recipesdomain.ModelPriceRepository) *ModelPriceRepository {
+	return &ModelPriceRepository{
+		next: next,
+	}
+}
+
+// Save implements the recipesdomain.ModelPriceRepository interface.
+func (r *ModelPriceRepository) Save(ctx context.Context, price recipesdomain.ModelPrice) error {
+	return sharedtracing.Run(ctx, "modelprices.repository.Save", func(ctx context.Context) error {
+		return r.next.Save(ctx, price)
+	})
+}
+
+// All implements the recipesdomain.ModelPriceRepository interface.
+func (r *ModelPriceRepository) All(ctx context.Context) ([]recipesdomain.ModelPrice, error) {
+	return sharedtracing.Call(ctx, "modelprices.repository.All", func(ctx context.Context) ([]recipesdomain.ModelPrice, error) {
+		return r.next.All(ctx)
+	})
+}
@@ -0,0 +1,28 @@
+package tracing
+
+import (
+	"context"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	sharedtracing "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+)
+
+// UsageRepository decorates a recipesdomain.UsageRepository with a span for
+// every call.
+type UsageRepository struct {
+	next recipesdomain.UsageRepository
+}
+
+// NewUsageRepository returns a UsageRepository that traces the calls to next.
+func NewUsageRepository(next recipesdomain.UsageRepository) *UsageRepository {
+	return &UsageRepository{
+		next: next,
+	}
+}
+
+// Report implements the recipesdomain.UsageRepository interface.
+func (r *UsageRepository) Report(ctx context.Context, filter recipesdomain.UsageFilter, group recipesdomain.UsageGroup, sort recipesdomain.UsageSort) ([]recipesdomain.UsageRow, error) {
+	return sharedtracing.Call(ctx, "usage.repository.Report", func(ctx context.Context) ([]recipesdomain.UsageRow, error) {
+		return r.next.Report(ctx, filter, group, sort)
+	})
+}
@@ -0,0 +1,35 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/storagemocks"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing/tracingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func Test_UsageRepositories_TraceCalls(t *testing.T) {
+	exporter := tracingtest.Record(t)
+
+	prices := new(storagemocks.ModelPriceRepository)
+	prices.On("All", mock.Anything).Return([]recipesdomain.ModelPrice{}, nil)
+	usage := new(storagemocks.UsageRepository)
+	usage.On("Report", mock.Anything, recipesdomain.UsageFilter{}, recipesdomain.UsageByMonth, recipesdomain.UsageSortKey).
+		Return(nil, errors.New("something unexpected happened"))
+
+	_, err := NewModelPriceRepository(prices).All(context.Background())
+	require.NoError(t, err)
+	_, err = NewUsageRepository(usage).Report(context.Background(), recipesdomain.UsageFilter{}, recipesdomain.UsageByMonth, recipesdomain.UsageSortKey)
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"modelprices.repository.All", "usage.repository.Report"}, tracingtest.Names(exporter))
+	assert.Equal(t, codes.Error, tracingtest.Span(t, exporter, "usage.repository.Report").Status.Code)
+	prices.AssertExpectations(t)
+	usage.AssertExpectations(t)
+}
@@ -1,25 +1,60 @@
 package ai
 
 import (
-	"github.com/kelseyhightower/envconfig"
+	"errors"
+	"fmt"
+	"net/url"
 )
 
-func CreateConfig() (*Aiconfig, error) {
-	var cfg Aiconfig
-	err := envconfig.Process("AI", &cfg)
-	if err != nil {
-		return nil, err
-	}
-	if cfg.Provider != "google" {
-		return nil, envconfig.ErrInvalidSpecification
-	}
-
-	return &cfg, nil
-}
+// placeholderApiKey is the default API key, which only keeps the config
+// loadable: the provider rejects it.
+const placeholderApiKey = "app"
 
 type Aiconfig struct {
 	Provider    string  `default:"google"`
-	ApiKey      string  `default:"app"`
+	ApiKey      string  `default:"app" secret:"true"`
 	Model       string  `default:"gemini-2.0-flash"`
 	Temperature float64 `default:"0.2"`
+	// BaseUrl is the root of the provider API. It can be changed to use a
+	// proxy or a fake server in tests.
+	BaseUrl string `default:"https://generativelanguage.googleapis.com"`
+	// RepairAttempts is the number of follow-up requests sent to the model
+	// asking it to correct an answer that could not be parsed or validated.
+	RepairAttempts int `default:"2"`
+	// PromptsDir is an optional directory with prompts that override or extend
+	// the embedded ones (<id>/<version>/<language>.tmpl).
+	PromptsDir string ``
+	// PromptVariants is an optional weighted split between versions of the
+	// extraction prompt (e.g. "v1:80,v2:20"). The latest version is used when empty.
+	PromptVariants map[string]int ``
+}
+
+// Validate checks the values that parsing cannot: the presence of an API key
+// other than the placeholder and the ranges of the generation parameters.
+func (c Aiconfig) Validate() error {
+	var errs []error
+	if c.Provider != "google" {
+		errs = append(errs, fmt.Errorf("unsupported provider %q", c.Provider))
+	}
+	switch c.ApiKey {
+	case "":
+		errs = append(errs, errors.New("missing API key"))
+	case placeholderApiKey:
+		errs = append(errs, fmt.Errorf("the API key is the placeholder %q: set AI_APIKEY", placeholderApiKey))
+	}
+	if c.Model == "" {
+		errs = append(errs, errors.New("missing model"))
+	}
+	if c.Temperature < 0 || c.Temperature > 2 {
+		errs = append(errs, fmt.Errorf("temperature %v out of range [0, 2]", c.Temperature))
+	}
+	if c.RepairAttempts < 0 {
+		errs = append(errs, fmt.Errorf("negative repair attempts %d", c.RepairAttempts))
+	}
+	if c.BaseUrl != "" {
+		if u, err := url.Parse(c.BaseUrl); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("invalid base url %q", c.BaseUrl))
+		}
+	}
+	return errors.Join(errs...)
 }
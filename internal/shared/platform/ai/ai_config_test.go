@@ -0,0 +1,26 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Aiconfig_Validate(t *testing.T) {
+	valid := Aiconfig{Provider: "google", ApiKey: "key", Model: "gemini-2.0-flash", Temperature: 0.2, BaseUrl: "https://generativelanguage.googleapis.com"}
+	assert.NoError(t, valid.Validate())
+
+	invalid := valid
+	invalid.ApiKey = ""
+	invalid.Temperature = 3
+	invalid.BaseUrl = "generativelanguage"
+
+	err := invalid.Validate()
+	assert.ErrorContains(t, err, "missing API key")
+	assert.ErrorContains(t, err, "temperature 3 out of range")
+	assert.ErrorContains(t, err, `invalid base url "generativelanguage"`)
+
+	placeholder := valid
+	placeholder.ApiKey = "app"
+	assert.ErrorContains(t, placeholder.Validate(), `the API key is the placeholder "app"`)
+}
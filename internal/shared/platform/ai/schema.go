@@ -0,0 +1,167 @@
+package ai
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Schema is a JSON schema generated from a Go type. It is used to constrain
+// the output of the models that support structured output, so the expected
+// shape never drifts from the structs that decode it.
+//
+// The schema is built from the struct tags:
+//   - json: property name. Fields tagged "-" are skipped and fields with
+//     "omitempty" are not required.
+//   - jsonschema_description: description of the property for the model.
+//   - jsonschema: comma separated options: "-" (skip the field), "optional",
+//     "minimum=N", "maximum=N" and "enum=a|b|c".
+type Schema struct {
+	Type        string             `json:"type"`
+	Description string             `json:"description,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	Maximum     *float64           `json:"maximum,omitempty"`
+	// AdditionalProperties is always false for objects, as required by the
+	// providers that validate the schema strictly.
+	AdditionalProperties *bool `json:"additionalProperties,omitempty"`
+	// Order keeps the declaration order of the struct fields.
+	Order []string `json:"-"`
+}
+
+// SchemaFor generates the schema of the type of v.
+func SchemaFor(v interface{}) (*Schema, error) {
+	return schemaForType(reflect.TypeOf(v))
+}
+
+func schemaForType(t reflect.Type) (*Schema, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := schemaForType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Struct:
+		return schemaForStruct(t)
+	default:
+		return nil, fmt.Errorf("unsupported type for schema: %s", t)
+	}
+}
+
+func schemaForStruct(t reflect.Type) (*Schema, error) {
+	additionalProperties := false
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}, AdditionalProperties: &additionalProperties}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omitempty := jsonName(field)
+		options := strings.Split(field.Tag.Get("jsonschema"), ",")
+		if name == "-" || options[0] == "-" {
+			continue
+		}
+
+		property, err := schemaForType(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		property.Description = field.Tag.Get("jsonschema_description")
+
+		required := !omitempty
+		for _, option := range options {
+			key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+			switch key {
+			case "optional":
+				required = false
+			case "minimum", "maximum":
+				n, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return nil, fmt.Errorf("field %s: invalid %s %q", field.Name, key, value)
+				}
+				if key == "minimum" {
+					property.Minimum = &n
+				} else {
+					property.Maximum = &n
+				}
+			case "enum":
+				property.Enum = strings.Split(value, "|")
+			}
+		}
+
+		schema.Properties[name] = property
+		schema.Order = append(schema.Order, name)
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema, nil
+}
+
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, strings.Contains(options, "omitempty")
+}
+
+// Gemini returns the schema in the OpenAPI subset accepted by Gemini as
+// generation_config.response_schema.
+func (s *Schema) Gemini() map[string]interface{} {
+	out := map[string]interface{}{
+		"type": strings.ToUpper(s.Type),
+	}
+	if s.Description != "" {
+		out["description"] = s.Description
+	}
+	if len(s.Enum) > 0 {
+		// Gemini only supports enums of strings
+		out["type"] = "STRING"
+		out["format"] = "enum"
+		out["enum"] = s.Enum
+	}
+	if s.Minimum != nil {
+		out["minimum"] = *s.Minimum
+	}
+	if s.Maximum != nil {
+		out["maximum"] = *s.Maximum
+	}
+	if s.Items != nil {
+		out["items"] = s.Items.Gemini()
+	}
+	if len(s.Properties) > 0 {
+		properties := map[string]interface{}{}
+		for name, property := range s.Properties {
+			properties[name] = property.Gemini()
+		}
+		out["properties"] = properties
+		out["propertyOrdering"] = s.Order
+	}
+	if len(s.Required) > 0 {
+		out["required"] = s.Required
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaChild struct {
+	Name string `json:"name"`
+}
+
+type schemaTest struct {
+	Title    string        `json:"title" jsonschema_description:"The title"`
+	Count    int           `json:"count" jsonschema:"minimum=1,maximum=3"`
+	Ratio    float64       `json:"ratio"`
+	Enabled  bool          `json:"enabled"`
+	Kind     string        `json:"kind" jsonschema:"enum=a|b"`
+	Notes    string        `json:"notes,omitempty"`
+	Extra    string        `json:"extra" jsonschema:"optional"`
+	Children []schemaChild `json:"children"`
+	Skipped  string        `json:"skipped" jsonschema:"-"`
+	Ignored  string        `json:"-"`
+	private  string
+}
+
+func Test_SchemaFor(t *testing.T) {
+	schema, err := SchemaFor(schemaTest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "object", schema.Type)
+	assert.False(t, *schema.AdditionalProperties)
+	assert.Equal(t, []string{"title", "count", "ratio", "enabled", "kind", "notes", "extra", "children"}, schema.Order)
+	assert.Equal(t, []string{"title", "count", "ratio", "enabled", "kind", "children"}, schema.Required)
+
+	assert.Equal(t, "The title", schema.Properties["title"].Description)
+	assert.Equal(t, "integer", schema.Properties["count"].Type)
+	assert.Equal(t, 1.0, *schema.Properties["count"].Minimum)
+	assert.Equal(t, 3.0, *schema.Properties["count"].Maximum)
+	assert.Equal(t, "number", schema.Properties["ratio"].Type)
+	assert.Equal(t, "boolean", schema.Properties["enabled"].Type)
+	assert.Equal(t, []string{"a", "b"}, schema.Properties["kind"].Enum)
+
+	children := schema.Properties["children"]
+	assert.Equal(t, "array", children.Type)
+	assert.Equal(t, "object", children.Items.Type)
+	assert.Equal(t, []string{"name"}, children.Items.Required)
+
+	assert.NotContains(t, schema.Properties, "skipped")
+	assert.NotContains(t, schema.Properties, "Ignored")
+	assert.NotContains(t, schema.Properties, "private")
+}
+
+func Test_SchemaFor_UnsupportedType(t *testing.T) {
+	_, err := SchemaFor(struct {
+		Data map[string]string `json:"data"`
+	}{})
+
+	assert.Error(t, err)
+}
+
+func Test_Schema_Gemini(t *testing.T) {
+	schema, err := SchemaFor(schemaTest{})
+	require.NoError(t, err)
+
+	gemini := schema.Gemini()
+
+	assert.Equal(t, "OBJECT", gemini["type"])
+	assert.Equal(t, schema.Order, gemini["propertyOrdering"])
+	properties := gemini["properties"].(map[string]interface{})
+	assert.Equal(t, "INTEGER", properties["count"].(map[string]interface{})["type"])
+	assert.Equal(t, "enum", properties["kind"].(map[string]interface{})["format"])
+	children := properties["children"].(map[string]interface{})
+	assert.Equal(t, "ARRAY", children["type"])
+	assert.Equal(t, "OBJECT", children["items"].(map[string]interface{})["type"])
+	assert.NotContains(t, gemini, "additionalProperties", "Gemini does not accept additionalProperties")
+}
@@ -8,7 +8,8 @@ import (
 
 // CommandBus is an in-memory implementation of the command.Bus.
 type CommandBus struct {
-	handlers map[command.Type]command.Handler
+	handlers    map[command.Type]command.Handler
+	middlewares []command.Middleware
 }
 
 // NewCommandBus initializes a new instance of CommandBus.
@@ -26,10 +27,15 @@ func (b *CommandBus) Dispatch(ctx context.Context, cmd command.Command) error {
 		return command.ErrHandlerNotFound
 	}
 
-	return handler.Handle(ctx, cmd)
+	return command.Chain(handler.Handle, b.middlewares...)(ctx, cmd)
 }
 
 // Register implements the command.Bus interface.
 func (b *CommandBus) Register(cmdType command.Type, handler command.Handler) {
 	b.handlers[cmdType] = handler
 }
+
+// Use implements the command.Bus interface.
+func (b *CommandBus) Use(middlewares ...command.Middleware) {
+	b.middlewares = append(b.middlewares, middlewares...)
+}
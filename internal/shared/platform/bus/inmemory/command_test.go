@@ -0,0 +1,66 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCommandType command.Type = "command.test.do"
+
+type testCommand struct{}
+
+func (testCommand) Type() command.Type { return testCommandType }
+
+type testCommandHandler struct {
+	calls *[]string
+}
+
+func (h testCommandHandler) Handle(context.Context, command.Command) error {
+	*h.calls = append(*h.calls, "handler")
+	return nil
+}
+
+func (h testCommandHandler) SubscribedTo() command.Type {
+	return testCommandType
+}
+
+func recordingMiddleware(name string, calls *[]string) command.Middleware {
+	return func(next command.HandlerFunc) command.HandlerFunc {
+		return func(ctx context.Context, cmd command.Command) error {
+			*calls = append(*calls, name+" before")
+			err := next(ctx, cmd)
+			*calls = append(*calls, name+" after")
+			return err
+		}
+	}
+}
+
+func Test_CommandBus_Dispatch(t *testing.T) {
+	t.Run("it runs the middlewares in order around the handler", func(t *testing.T) {
+		var calls []string
+		bus := NewCommandBus()
+		bus.Register(testCommandType, testCommandHandler{calls: &calls})
+		bus.Use(recordingMiddleware("first", &calls))
+		bus.Use(recordingMiddleware("second", &calls))
+
+		err := bus.Dispatch(context.Background(), testCommand{})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first before", "second before", "handler", "second after", "first after"}, calls)
+	})
+
+	t.Run("it fails without running the middlewares when there is no handler", func(t *testing.T) {
+		var calls []string
+		bus := NewCommandBus()
+		bus.Use(recordingMiddleware("first", &calls))
+
+		err := bus.Dispatch(context.Background(), testCommand{})
+
+		assert.ErrorIs(t, err, command.ErrHandlerNotFound)
+		assert.Empty(t, calls)
+	})
+}
@@ -2,44 +2,273 @@ package inmemory
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
 
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
 	"github.com/rubenbupe/recipe-video-parser/kit/event"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+var ErrEventBusClosed = errors.New("event bus is closed")
+
 // EventBus is an in-memory implementation of the event.Bus.
+//
+// Events are delivered asynchronously by a bounded pool of workers: every
+// subscriber of an event type receives each event independently, a failing or
+// panicking handler is retried with exponential backoff without affecting the
+// others, and Shutdown waits for the pending deliveries. Retries still waiting
+// for their backoff when the Shutdown context is done are abandoned.
 type EventBus struct {
+	config EventBusConfig
+
+	mu       sync.RWMutex
 	handlers map[event.Type][]event.Handler
+	closed   bool
+
+	// closing se cierra al empezar Shutdown para despertar a los publicadores
+	// bloqueados; aborting, cuando vence su contexto, para abandonar los
+	// reintentos en espera
+	closing    chan struct{}
+	closeOnce  sync.Once
+	publishers sync.WaitGroup
+	aborting   chan struct{}
+	abortOnce  sync.Once
+
+	deliveries chan delivery
+	workers    sync.WaitGroup
 }
 
-// NewEventBus initializes a new EventBus.
-func NewEventBus() *EventBus {
-	return &EventBus{
-		handlers: make(map[event.Type][]event.Handler),
+type delivery struct {
+	ctx     context.Context
+	evt     event.Event
+	handler event.Handler
+	// done recibe el resultado de la entrega cuando el publicador lo espera
+	done chan<- error
+}
+
+// NewEventBus initializes a new EventBus and starts its workers.
+func NewEventBus(config EventBusConfig) *EventBus {
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+	if config.QueueSize < 0 {
+		config.QueueSize = 0
+	}
+
+	b := &EventBus{
+		config:     config,
+		handlers:   make(map[event.Type][]event.Handler),
+		closing:    make(chan struct{}),
+		aborting:   make(chan struct{}),
+		deliveries: make(chan delivery, config.QueueSize),
+	}
+
+	b.workers.Add(config.Workers)
+	for i := 0; i < config.Workers; i++ {
+		go b.work()
 	}
+
+	return b
 }
 
-// Publish implements the event.Bus interface.
+// Publish implements the event.Bus interface. It enqueues a delivery for every
+// subscriber of each event and returns without waiting for the handlers.
+// Events without subscribers are discarded.
 func (b *EventBus) Publish(ctx context.Context, events []event.Event) error {
+	_, _, err := b.enqueue(ctx, events, false)
+	return err
+}
+
+// PublishAndWait enqueues the events like Publish and waits until every
+// subscriber has handled them. A handler that still fails after its retries
+// counts as finished, as it is only logged; an error means some delivery was
+// abandoned by a shutdown or the context ended before the handlers finished.
+func (b *EventBus) PublishAndWait(ctx context.Context, events []event.Event) error {
+	done, enqueued, err := b.enqueue(ctx, events, true)
+
+	var errs []error
+	for i := 0; i < enqueued; i++ {
+		select {
+		case err := <-done:
+			errs = append(errs, err)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return errors.Join(append(errs, err)...)
+}
+
+// enqueue sends a delivery for every subscriber of each event and returns how
+// many were enqueued. When wait is set, the returned channel receives the
+// result of each of them.
+func (b *EventBus) enqueue(ctx context.Context, events []event.Event, wait bool) (<-chan error, int, error) {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return nil, 0, ErrEventBusClosed
+	}
+	// Shutdown no cierra la cola hasta que terminan los publicadores
+	// registrados, así que el envío no puede coincidir con el cierre
+	b.publishers.Add(1)
+	deliveries := make([]delivery, 0, len(events))
+	// Los manejadores no deben cancelarse cuando termina la petición que
+	// publicó el evento
+	handlerCtx := context.WithoutCancel(ctx)
 	for _, evt := range events {
-		handlers, ok := b.handlers[evt.Type()]
-		if !ok {
-			return nil
+		for _, handler := range b.handlers[evt.Type()] {
+			deliveries = append(deliveries, delivery{ctx: handlerCtx, evt: evt, handler: handler})
 		}
+	}
+	b.mu.RUnlock()
+	defer b.publishers.Done()
 
-		for _, handler := range handlers {
-			handler.Handle(ctx, evt)
+	var done chan error
+	if wait {
+		// Con capacidad para todas las entregas, los workers nunca se bloquean
+		// aunque el publicador deje de esperar
+		done = make(chan error, len(deliveries))
+		for i := range deliveries {
+			deliveries[i].done = done
 		}
 	}
 
-	return nil
+	for i, d := range deliveries {
+		select {
+		case b.deliveries <- d:
+		case <-ctx.Done():
+			return done, i, ctx.Err()
+		case <-b.closing:
+			return done, i, ErrEventBusClosed
+		}
+	}
+
+	return done, len(deliveries), nil
 }
 
 // Subscribe implements the event.Bus interface.
 func (b *EventBus) Subscribe(evtType event.Type, handler event.Handler) {
-	subscribersForType, ok := b.handlers[evtType]
-	if !ok {
-		b.handlers[evtType] = []event.Handler{handler}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[evtType] = append(b.handlers[evtType], handler)
+}
+
+// QueueLength returns the number of deliveries waiting for a worker.
+func (b *EventBus) QueueLength() int {
+	return len(b.deliveries)
+}
+
+// Shutdown stops accepting events and waits until the pending deliveries are
+// handled or the context is done.
+func (b *EventBus) Shutdown(ctx context.Context) error {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.closeOnce.Do(func() {
+			close(b.closing)
+			b.publishers.Wait()
+			close(b.deliveries)
+		})
+		b.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		b.abortOnce.Do(func() { close(b.aborting) })
+		return fmt.Errorf("event bus did not drain: %w", ctx.Err())
+	}
+}
+
+func (b *EventBus) work() {
+	defer b.workers.Done()
+
+	for d := range b.deliveries {
+		b.deliver(d)
 	}
+}
+
+func (b *EventBus) deliver(d delivery) {
+	ctx, span := tracing.Start(d.ctx, string(d.evt.Type()),
+		attribute.String("event.id", d.evt.ID()),
+		attribute.String("event.handler", fmt.Sprintf("%T", d.handler)),
+	)
+	backoff := b.config.RetryBackoff
+
+	var err error
+	for attempt := 0; attempt <= b.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if !b.wait(backoff) {
+				err = fmt.Errorf("retry abandoned on shutdown: %w", err)
+				span.SetAttributes(attribute.Int("event.attempts", attempt))
+				tracing.End(span, err)
+				b.logFailure(d, attempt, err)
+				d.finish(err)
+				return
+			}
+			backoff *= 2
+		}
+
+		if err = handle(ctx, d.handler, d.evt); err == nil {
+			span.SetAttributes(attribute.Int("event.attempts", attempt+1))
+			tracing.End(span, nil)
+			d.finish(nil)
+			return
+		}
+	}
+	span.SetAttributes(attribute.Int("event.attempts", b.config.MaxRetries+1))
+	tracing.End(span, err)
+	b.logFailure(d, b.config.MaxRetries+1, err)
+	d.finish(nil)
+}
+
+// finish reports the result of the delivery to a waiting publisher.
+func (d delivery) finish(err error) {
+	if d.done != nil {
+		d.done <- err
+	}
+}
+
+// wait sleeps for the backoff and reports false if a shutdown times out in the
+// meantime.
+func (b *EventBus) wait(backoff time.Duration) bool {
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-b.aborting:
+		return false
+	}
+}
+
+func (b *EventBus) logFailure(d delivery, attempts int, err error) {
+	slog.ErrorContext(d.ctx, "event could not be handled",
+		slog.String("event_id", d.evt.ID()),
+		slog.String("type", string(d.evt.Type())),
+		slog.String("handler", fmt.Sprintf("%T", d.handler)),
+		slog.Int("attempts", attempts),
+		slog.String("error", err.Error()),
+	)
+}
+
+// handle calls the handler, turning a panic into an error.
+func handle(ctx context.Context, handler event.Handler, evt event.Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
 
-	subscribersForType = append(subscribersForType, handler)
+	return handler.Handle(ctx, evt)
 }
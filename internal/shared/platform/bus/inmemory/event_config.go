@@ -0,0 +1,31 @@
+package inmemory
+
+import (
+	"errors"
+	"time"
+)
+
+type EventBusConfig struct {
+	// Workers is the number of goroutines delivering events to the handlers.
+	Workers int `default:"4"`
+	// QueueSize is the number of pending deliveries. Publish blocks while the
+	// queue is full.
+	QueueSize int `default:"100"`
+	// MaxRetries is the number of times a failed handler is retried.
+	MaxRetries int `default:"3"`
+	// RetryBackoff is the wait before the first retry. It doubles on every
+	// retry.
+	RetryBackoff time.Duration `default:"100ms"`
+}
+
+// Validate checks the values of the config.
+func (c EventBusConfig) Validate() error {
+	var errs []error
+	if c.Workers < 1 {
+		errs = append(errs, errors.New("at least one worker is required"))
+	}
+	if c.QueueSize < 0 || c.MaxRetries < 0 || c.RetryBackoff < 0 {
+		errs = append(errs, errors.New("queue size, retries and backoff must not be negative"))
+	}
+	return errors.Join(errs...)
+}
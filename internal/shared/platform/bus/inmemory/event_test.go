@@ -0,0 +1,322 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing/tracingtest"
+	"github.com/rubenbupe/recipe-video-parser/kit/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testEventType  event.Type = "events.test.happened"
+	otherEventType event.Type = "events.test.other"
+)
+
+type testEvent struct {
+	event.BaseEvent
+	eventType event.Type
+}
+
+func newTestEvent(eventType event.Type) testEvent {
+	return testEvent{BaseEvent: event.NewBaseEvent("aggregate"), eventType: eventType}
+}
+
+func (e testEvent) Type() event.Type {
+	return e.eventType
+}
+
+// testHandler fails the first failures calls (panicking if panics is set) and
+// records the events it handles.
+type testHandler struct {
+	mu       sync.Mutex
+	calls    int
+	failures int
+	panics   bool
+	handled  []event.Event
+	delay    time.Duration
+}
+
+func (h *testHandler) Handle(ctx context.Context, evt event.Event) error {
+	time.Sleep(h.delay)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.calls++
+	if h.calls <= h.failures {
+		if h.panics {
+			panic("boom")
+		}
+		return errors.New("something unexpected happened")
+	}
+	h.handled = append(h.handled, evt)
+	return nil
+}
+
+func (h *testHandler) SubscribedTo() event.Type {
+	return testEventType
+}
+
+func (h *testHandler) Calls() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.calls
+}
+
+func (h *testHandler) Handled() []event.Event {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]event.Event(nil), h.handled...)
+}
+
+func newTestEventBus() *EventBus {
+	return NewEventBus(EventBusConfig{Workers: 2, QueueSize: 10, MaxRetries: 2, RetryBackoff: time.Millisecond})
+}
+
+func shutdown(t *testing.T, bus *EventBus) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, bus.Shutdown(ctx))
+}
+
+func Test_EventBus_Publish_MultipleSubscribers(t *testing.T) {
+	bus := newTestEventBus()
+	first, second, other := &testHandler{}, &testHandler{}, &testHandler{}
+	bus.Subscribe(testEventType, first)
+	bus.Subscribe(testEventType, second)
+	bus.Subscribe(otherEventType, other)
+
+	events := []event.Event{newTestEvent("events.test.unsubscribed"), newTestEvent(testEventType), newTestEvent(otherEventType)}
+	require.NoError(t, bus.Publish(context.Background(), events))
+	shutdown(t, bus)
+
+	assert.Equal(t, []event.Event{events[1]}, first.Handled())
+	assert.Equal(t, []event.Event{events[1]}, second.Handled())
+	assert.Equal(t, []event.Event{events[2]}, other.Handled(), "events without subscribers do not stop the rest")
+}
+
+func Test_EventBus_Publish_RetriesFailedHandler(t *testing.T) {
+	bus := newTestEventBus()
+	failing, healthy := &testHandler{failures: 2}, &testHandler{}
+	bus.Subscribe(testEventType, failing)
+	bus.Subscribe(testEventType, healthy)
+
+	require.NoError(t, bus.Publish(context.Background(), []event.Event{newTestEvent(testEventType)}))
+	shutdown(t, bus)
+
+	assert.Equal(t, 3, failing.Calls())
+	assert.Len(t, failing.Handled(), 1)
+	assert.Equal(t, 1, healthy.Calls(), "the other subscribers are not retried")
+}
+
+func Test_EventBus_Publish_GivesUpAfterMaxRetries(t *testing.T) {
+	bus := newTestEventBus()
+	failing := &testHandler{failures: 10}
+	bus.Subscribe(testEventType, failing)
+
+	require.NoError(t, bus.Publish(context.Background(), []event.Event{newTestEvent(testEventType)}))
+	shutdown(t, bus)
+
+	assert.Equal(t, 3, failing.Calls())
+	assert.Empty(t, failing.Handled())
+}
+
+func Test_EventBus_Publish_IsolatesPanics(t *testing.T) {
+	bus := newTestEventBus()
+	panicking, healthy := &testHandler{failures: 1, panics: true}, &testHandler{}
+	bus.Subscribe(testEventType, panicking)
+	bus.Subscribe(testEventType, healthy)
+
+	require.NoError(t, bus.Publish(context.Background(), []event.Event{newTestEvent(testEventType)}))
+	shutdown(t, bus)
+
+	assert.Len(t, panicking.Handled(), 1, "the handler is retried after panicking")
+	assert.Len(t, healthy.Handled(), 1)
+}
+
+func Test_EventBus_Publish_IsAsynchronous(t *testing.T) {
+	bus := newTestEventBus()
+	slow := &testHandler{delay: 50 * time.Millisecond}
+	bus.Subscribe(testEventType, slow)
+
+	started := time.Now()
+	require.NoError(t, bus.Publish(context.Background(), []event.Event{newTestEvent(testEventType)}))
+
+	assert.Less(t, time.Since(started), 50*time.Millisecond)
+	shutdown(t, bus)
+	assert.Len(t, slow.Handled(), 1)
+}
+
+func Test_EventBus_QueueLength(t *testing.T) {
+	bus := NewEventBus(EventBusConfig{Workers: 1, QueueSize: 10})
+	release := make(chan struct{})
+	bus.Subscribe(testEventType, handlerFunc(func(ctx context.Context, evt event.Event) error {
+		<-release
+		return nil
+	}))
+
+	events := make([]event.Event, 3)
+	for i := range events {
+		events[i] = newTestEvent(testEventType)
+	}
+	require.NoError(t, bus.Publish(context.Background(), events))
+
+	// El único worker se queda con la primera entrega
+	assert.Eventually(t, func() bool { return bus.QueueLength() == 2 }, time.Second, time.Millisecond)
+	close(release)
+	shutdown(t, bus)
+	assert.Equal(t, 0, bus.QueueLength())
+}
+
+func Test_EventBus_Publish_HandlersOutliveThePublisherContext(t *testing.T) {
+	bus := newTestEventBus()
+	var canceled atomic.Bool
+	bus.Subscribe(testEventType, handlerFunc(func(ctx context.Context, evt event.Event) error {
+		time.Sleep(10 * time.Millisecond)
+		canceled.Store(ctx.Err() != nil)
+		return nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, bus.Publish(ctx, []event.Event{newTestEvent(testEventType)}))
+	cancel()
+	shutdown(t, bus)
+
+	assert.False(t, canceled.Load())
+}
+
+func Test_EventBus_Publish_TracesDeliveries(t *testing.T) {
+	exporter := tracingtest.Record(t)
+	bus := newTestEventBus()
+	bus.Subscribe(testEventType, &testHandler{failures: 1})
+
+	ctx, parent := tracing.Start(context.Background(), "publisher")
+	require.NoError(t, bus.Publish(ctx, []event.Event{newTestEvent(testEventType)}))
+	parent.End()
+	shutdown(t, bus)
+
+	span := tracingtest.Span(t, exporter, string(testEventType))
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+	assert.Equal(t, int64(2), tracingtest.Attributes(span)["event.attempts"].AsInt64())
+}
+
+func Test_EventBus_Shutdown_DrainsPendingEvents(t *testing.T) {
+	bus := NewEventBus(EventBusConfig{Workers: 1, QueueSize: 10})
+	handler := &testHandler{delay: 5 * time.Millisecond}
+	bus.Subscribe(testEventType, handler)
+
+	events := make([]event.Event, 5)
+	for i := range events {
+		events[i] = newTestEvent(testEventType)
+	}
+	require.NoError(t, bus.Publish(context.Background(), events))
+	shutdown(t, bus)
+
+	assert.Len(t, handler.Handled(), 5)
+	assert.ErrorIs(t, bus.Publish(context.Background(), events), ErrEventBusClosed)
+}
+
+func Test_EventBus_Shutdown_Timeout(t *testing.T) {
+	bus := NewEventBus(EventBusConfig{Workers: 1})
+	bus.Subscribe(testEventType, &testHandler{delay: 100 * time.Millisecond})
+	require.NoError(t, bus.Publish(context.Background(), []event.Event{newTestEvent(testEventType)}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, bus.Shutdown(ctx), context.DeadlineExceeded)
+}
+
+type handlerFunc func(context.Context, event.Event) error
+
+func (f handlerFunc) Handle(ctx context.Context, evt event.Event) error {
+	return f(ctx, evt)
+}
+
+func (f handlerFunc) SubscribedTo() event.Type {
+	return testEventType
+}
+
+func Test_EventBus_Shutdown_UnblocksPublishers(t *testing.T) {
+	bus := NewEventBus(EventBusConfig{Workers: 1, QueueSize: 0})
+	release := make(chan struct{})
+	bus.Subscribe(testEventType, handlerFunc(func(context.Context, event.Event) error {
+		<-release
+		return nil
+	}))
+	require.NoError(t, bus.Publish(context.Background(), []event.Event{newTestEvent(testEventType)}))
+
+	published := make(chan error, 1)
+	go func() {
+		published <- bus.Publish(context.Background(), []event.Event{newTestEvent(testEventType), newTestEvent(testEventType)})
+	}()
+
+	shutdownDone := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		shutdownDone <- bus.Shutdown(ctx)
+	}()
+
+	select {
+	case err := <-published:
+		assert.ErrorIs(t, err, ErrEventBusClosed)
+	case <-time.After(time.Second):
+		t.Fatal("publish stayed blocked during shutdown")
+	}
+	close(release)
+	assert.NoError(t, <-shutdownDone)
+}
+
+func Test_EventBus_Shutdown_InterruptsBackoff(t *testing.T) {
+	bus := NewEventBus(EventBusConfig{Workers: 1, MaxRetries: 1, RetryBackoff: time.Hour})
+	handler := &testHandler{failures: 1}
+	bus.Subscribe(testEventType, handler)
+	require.NoError(t, bus.Publish(context.Background(), []event.Event{newTestEvent(testEventType)}))
+
+	assert.Eventually(t, func() bool { return handler.Calls() == 1 }, time.Second, time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, bus.Shutdown(ctx), context.DeadlineExceeded)
+
+	// El worker abandona la espera y termina en lugar de dormir una hora
+	shutdown(t, bus)
+	assert.Equal(t, 1, handler.Calls(), "the retry is abandoned")
+}
+
+func Test_EventBus_PublishAndWait_WaitsForTheHandlers(t *testing.T) {
+	bus := newTestEventBus()
+	defer shutdown(t, bus)
+	handler := &testHandler{delay: 10 * time.Millisecond, failures: 1}
+	bus.Subscribe(testEventType, handler)
+
+	require.NoError(t, bus.PublishAndWait(context.Background(), []event.Event{newTestEvent(testEventType)}))
+
+	assert.Len(t, handler.Handled(), 1)
+}
+
+func Test_EventBus_PublishAndWait_AbandonedOnShutdown(t *testing.T) {
+	bus := NewEventBus(EventBusConfig{Workers: 1, MaxRetries: 1, RetryBackoff: time.Hour})
+	handler := &testHandler{failures: 1}
+	bus.Subscribe(testEventType, handler)
+
+	published := make(chan error, 1)
+	go func() {
+		published <- bus.PublishAndWait(context.Background(), []event.Event{newTestEvent(testEventType)})
+	}()
+	assert.Eventually(t, func() bool { return handler.Calls() == 1 }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Error(t, bus.Shutdown(ctx))
+
+	assert.Error(t, <-published, "the retry was abandoned")
+}
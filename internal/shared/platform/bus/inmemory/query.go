@@ -8,7 +8,8 @@ import (
 
 // QueryBus is an in-memory implementation of the query.Bus.
 type QueryBus struct {
-	handlers map[query.Type]query.Handler
+	handlers    map[query.Type]query.Handler
+	middlewares []query.Middleware
 }
 
 // NewQueryBus initializes a new instance of QueryBus.
@@ -25,10 +26,15 @@ func (b *QueryBus) Ask(ctx context.Context, cmd query.Query) (interface{}, error
 		return nil, query.ErrHandlerNotFound
 	}
 
-	return handler.Handle(ctx, cmd)
+	return query.Chain(handler.Handle, b.middlewares...)(ctx, cmd)
 }
 
 // Register implements the query.Bus interface.
 func (b *QueryBus) Register(cmdType query.Type, handler query.Handler) {
 	b.handlers[cmdType] = handler
 }
+
+// Use implements the query.Bus interface.
+func (b *QueryBus) Use(middlewares ...query.Middleware) {
+	b.middlewares = append(b.middlewares, middlewares...)
+}
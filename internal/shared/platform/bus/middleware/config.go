@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type Config struct {
+	// CommandTimeout is the maximum duration of a command. Zero disables it.
+	CommandTimeout time.Duration `default:"0"`
+	// QueryTimeout is the maximum duration of a query. Zero disables it.
+	QueryTimeout time.Duration `default:"0"`
+	// Timeouts overrides the timeout of single commands or queries, by type
+	// (e.g. "command.webhook.replaydelivery:2m").
+	Timeouts map[string]time.Duration
+}
+
+// timeoutFor returns the timeout of the given command or query type.
+func (c Config) timeoutFor(msgType string, fallback time.Duration) time.Duration {
+	if timeout, ok := c.Timeouts[msgType]; ok {
+		return timeout
+	}
+	return fallback
+}
+
+// Validate checks the values of the config.
+func (c Config) Validate() error {
+	var errs []error
+	if c.CommandTimeout < 0 || c.QueryTimeout < 0 {
+		errs = append(errs, errors.New("timeouts must not be negative"))
+	}
+	for msgType, timeout := range c.Timeouts {
+		if timeout <= 0 {
+			errs = append(errs, fmt.Errorf("timeout of %s must be positive", msgType))
+		}
+	}
+	return errors.Join(errs...)
+}
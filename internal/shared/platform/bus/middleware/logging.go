@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+// CommandLogging logs every command with its type, duration and error. Failed
+// commands are logged as errors and the rest at debug level.
+func CommandLogging(logger *slog.Logger) command.Middleware {
+	return func(next command.HandlerFunc) command.HandlerFunc {
+		return func(ctx context.Context, cmd command.Command) error {
+			start := time.Now()
+			err := next(ctx, cmd)
+			logMessage(ctx, logger, "command", string(cmd.Type()), time.Since(start), err)
+			return err
+		}
+	}
+}
+
+// QueryLogging logs every query with its type, duration and error. Failed
+// queries are logged as errors and the rest at debug level.
+func QueryLogging(logger *slog.Logger) query.Middleware {
+	return func(next query.HandlerFunc) query.HandlerFunc {
+		return func(ctx context.Context, qry query.Query) (interface{}, error) {
+			start := time.Now()
+			res, err := next(ctx, qry)
+			logMessage(ctx, logger, "query", string(qry.Type()), time.Since(start), err)
+			return res, err
+		}
+	}
+}
+
+func logMessage(ctx context.Context, logger *slog.Logger, kind, msgType string, duration time.Duration, err error) {
+	attrs := []slog.Attr{
+		slog.String("type", msgType),
+		slog.Duration("duration", duration),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		logger.LogAttrs(ctx, slog.LevelError, kind+" failed", attrs...)
+		return
+	}
+	logger.LogAttrs(ctx, slog.LevelDebug, kind+" handled", attrs...)
+}
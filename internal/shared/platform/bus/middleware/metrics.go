@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/metrics"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+// DurationRecorder receives the duration of every command and query.
+type DurationRecorder interface {
+	RecordDuration(kind, msgType string, duration time.Duration, err error)
+}
+
+// CommandDuration records the duration of every command.
+func CommandDuration(recorder DurationRecorder) command.Middleware {
+	return func(next command.HandlerFunc) command.HandlerFunc {
+		return func(ctx context.Context, cmd command.Command) error {
+			start := time.Now()
+			err := next(ctx, cmd)
+			recorder.RecordDuration("command", string(cmd.Type()), time.Since(start), err)
+			return err
+		}
+	}
+}
+
+// QueryDuration records the duration of every query.
+func QueryDuration(recorder DurationRecorder) query.Middleware {
+	return func(next query.HandlerFunc) query.HandlerFunc {
+		return func(ctx context.Context, qry query.Query) (interface{}, error) {
+			start := time.Now()
+			res, err := next(ctx, qry)
+			recorder.RecordDuration("query", string(qry.Type()), time.Since(start), err)
+			return res, err
+		}
+	}
+}
+
+// DurationStat aggregates the durations of a command or query type.
+type DurationStat struct {
+	Count  int
+	Errors int
+	Total  time.Duration
+	Max    time.Duration
+}
+
+// DurationStats is an in-memory DurationRecorder.
+type DurationStats struct {
+	mu    sync.Mutex
+	stats map[string]DurationStat
+}
+
+// NewDurationStats initializes a new empty DurationStats.
+func NewDurationStats() *DurationStats {
+	return &DurationStats{
+		stats: make(map[string]DurationStat),
+	}
+}
+
+// RecordDuration implements the DurationRecorder interface.
+func (s *DurationStats) RecordDuration(_ string, msgType string, duration time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stat := s.stats[msgType]
+	stat.Count++
+	if err != nil {
+		stat.Errors++
+	}
+	stat.Total += duration
+	stat.Max = max(stat.Max, duration)
+	s.stats[msgType] = stat
+}
+
+// Snapshot returns a copy of the stats by command or query type.
+func (s *DurationStats) Snapshot() map[string]DurationStat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := make(map[string]DurationStat, len(s.stats))
+	for msgType, stat := range s.stats {
+		snapshot[msgType] = stat
+	}
+	return snapshot
+}
+
+// DurationHistogram is a DurationRecorder that exposes the durations as a
+// Prometheus histogram by kind (command or query), type and outcome.
+type DurationHistogram struct {
+	durations *metrics.HistogramVec
+}
+
+// NewDurationHistogram registers the histogram of the bus durations.
+func NewDurationHistogram(registry *metrics.Registry) *DurationHistogram {
+	return &DurationHistogram{
+		durations: registry.NewHistogram("bus_message_duration_seconds",
+			"Duration of the commands and queries by kind, type and outcome.", nil, "kind", "type", "outcome"),
+	}
+}
+
+// RecordDuration implements the DurationRecorder interface.
+func (h *DurationHistogram) RecordDuration(kind, msgType string, duration time.Duration, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	h.durations.Observe(duration.Seconds(), kind, msgType, outcome)
+}
@@ -0,0 +1,248 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/metrics"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing/tracingtest"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	testCommandType command.Type = "command.test.do"
+	testQueryType   query.Type   = "query.test.get"
+)
+
+type testCommand struct{}
+
+func (testCommand) Type() command.Type { return testCommandType }
+
+type nonTransactionalCommand struct{}
+
+func (nonTransactionalCommand) Type() command.Type { return testCommandType }
+
+func (nonTransactionalCommand) NonTransactional() {}
+
+type testQuery struct{}
+
+func (testQuery) Type() query.Type { return testQueryType }
+
+func newTestLogger() (*slog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	return slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})), &buf
+}
+
+func Test_CommandLogging(t *testing.T) {
+	logger, buf := newTestLogger()
+	handlerErr := errors.New("something unexpected happened")
+
+	handler := CommandLogging(logger)(func(context.Context, command.Command) error {
+		return handlerErr
+	})
+	err := handler(context.Background(), testCommand{})
+
+	assert.ErrorIs(t, err, handlerErr)
+	assert.Contains(t, buf.String(), `"msg":"command failed"`)
+	assert.Contains(t, buf.String(), `"type":"command.test.do"`)
+	assert.Contains(t, buf.String(), `"error":"something unexpected happened"`)
+}
+
+func Test_QueryLogging(t *testing.T) {
+	logger, buf := newTestLogger()
+
+	handler := QueryLogging(logger)(func(context.Context, query.Query) (interface{}, error) {
+		return "result", nil
+	})
+	res, err := handler(context.Background(), testQuery{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "result", res)
+	assert.Contains(t, buf.String(), `"msg":"query handled"`)
+	assert.Contains(t, buf.String(), `"type":"query.test.get"`)
+}
+
+func Test_CommandDuration(t *testing.T) {
+	stats := NewDurationStats()
+	calls := 0
+
+	handler := CommandDuration(stats)(func(context.Context, command.Command) error {
+		calls++
+		if calls == 2 {
+			return errors.New("something unexpected happened")
+		}
+		return nil
+	})
+	_ = handler(context.Background(), testCommand{})
+	_ = handler(context.Background(), testCommand{})
+
+	stat := stats.Snapshot()[string(testCommandType)]
+	assert.Equal(t, 2, stat.Count)
+	assert.Equal(t, 1, stat.Errors)
+	assert.GreaterOrEqual(t, stat.Total, stat.Max)
+}
+
+func Test_DurationHistogram(t *testing.T) {
+	registry := metrics.NewRegistry()
+	recorder := NewDurationHistogram(registry)
+
+	handler := QueryDuration(recorder)(func(context.Context, query.Query) (interface{}, error) {
+		return nil, errors.New("something unexpected happened")
+	})
+	_, _ = handler(context.Background(), testQuery{})
+
+	var buf bytes.Buffer
+	_, err := registry.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `bus_message_duration_seconds_count{kind="query",type="query.test.get",outcome="error"} 1`)
+}
+
+func Test_CommandTracing(t *testing.T) {
+	exporter := tracingtest.Record(t)
+
+	handler := CommandTracing()(func(ctx context.Context, cmd command.Command) error {
+		_, span := tracing.Start(ctx, "repository")
+		span.End()
+		return errors.New("something unexpected happened")
+	})
+	err := handler(context.Background(), testCommand{})
+
+	require.Error(t, err)
+	span := tracingtest.Span(t, exporter, string(testCommandType))
+	assert.Equal(t, "command", tracingtest.Attributes(span)["bus.kind"].AsString())
+	assert.Equal(t, codes.Error, span.Status.Code)
+	assert.Equal(t, span.SpanContext.SpanID(), tracingtest.Span(t, exporter, "repository").Parent.SpanID())
+}
+
+func Test_QueryTracing(t *testing.T) {
+	exporter := tracingtest.Record(t)
+
+	handler := QueryTracing()(func(context.Context, query.Query) (interface{}, error) {
+		return "result", nil
+	})
+	_, err := handler(context.Background(), testQuery{})
+
+	require.NoError(t, err)
+	span := tracingtest.Span(t, exporter, string(testQueryType))
+	assert.Equal(t, "query", tracingtest.Attributes(span)["bus.kind"].AsString())
+	assert.Equal(t, codes.Unset, span.Status.Code)
+}
+
+func Test_CommandRecovery(t *testing.T) {
+	logger, buf := newTestLogger()
+
+	handler := CommandRecovery(logger)(func(context.Context, command.Command) error {
+		panic("boom")
+	})
+	err := handler(context.Background(), testCommand{})
+
+	assert.ErrorIs(t, err, ErrHandlerPanic)
+	assert.Contains(t, buf.String(), `"panic":"boom"`)
+	assert.Contains(t, buf.String(), `"stack":`)
+}
+
+func Test_QueryRecovery(t *testing.T) {
+	logger, _ := newTestLogger()
+
+	handler := QueryRecovery(logger)(func(context.Context, query.Query) (interface{}, error) {
+		panic("boom")
+	})
+	res, err := handler(context.Background(), testQuery{})
+
+	assert.ErrorIs(t, err, ErrHandlerPanic)
+	assert.Nil(t, res)
+}
+
+func Test_CommandTimeout(t *testing.T) {
+	waitForCancel := func(ctx context.Context, _ command.Command) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+			return nil
+		}
+	}
+
+	t.Run("it cancels the command after the timeout of its type", func(t *testing.T) {
+		cfg := Config{
+			CommandTimeout: time.Minute,
+			Timeouts:       map[string]time.Duration{string(testCommandType): 10 * time.Millisecond},
+		}
+
+		err := CommandTimeout(cfg)(waitForCancel)(context.Background(), testCommand{})
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("it does not set a deadline when the timeout is zero", func(t *testing.T) {
+		handler := CommandTimeout(Config{})(func(ctx context.Context, _ command.Command) error {
+			_, ok := ctx.Deadline()
+			assert.False(t, ok)
+			return nil
+		})
+
+		assert.NoError(t, handler(context.Background(), testCommand{}))
+	})
+}
+
+type testUnitOfWork struct {
+	committed  bool
+	rolledBack bool
+}
+
+func (u *testUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		u.rolledBack = true
+		return err
+	}
+	u.committed = true
+	return nil
+}
+
+func Test_CommandTransaction(t *testing.T) {
+	t.Run("it commits when the command succeeds", func(t *testing.T) {
+		uow := &testUnitOfWork{}
+
+		err := CommandTransaction(uow)(func(context.Context, command.Command) error {
+			return nil
+		})(context.Background(), testCommand{})
+
+		require.NoError(t, err)
+		assert.True(t, uow.committed)
+	})
+
+	t.Run("it rolls back when the command fails", func(t *testing.T) {
+		uow := &testUnitOfWork{}
+		handlerErr := errors.New("something unexpected happened")
+
+		err := CommandTransaction(uow)(func(context.Context, command.Command) error {
+			return handlerErr
+		})(context.Background(), testCommand{})
+
+		assert.ErrorIs(t, err, handlerErr)
+		assert.True(t, uow.rolledBack)
+		assert.False(t, uow.committed)
+	})
+	t.Run("it skips the non transactional commands", func(t *testing.T) {
+		uow := &testUnitOfWork{}
+		called := false
+
+		err := CommandTransaction(uow)(func(context.Context, command.Command) error {
+			called = true
+			return nil
+		})(context.Background(), nonTransactionalCommand{})
+
+		require.NoError(t, err)
+		assert.True(t, called)
+		assert.False(t, uow.committed)
+	})
+}
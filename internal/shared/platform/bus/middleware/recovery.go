@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+// ErrHandlerPanic is returned when a handler panics.
+var ErrHandlerPanic = errors.New("handler panicked")
+
+// CommandRecovery turns a panic of the handler into an error, logging the
+// stack trace.
+func CommandRecovery(logger *slog.Logger) command.Middleware {
+	return func(next command.HandlerFunc) command.HandlerFunc {
+		return func(ctx context.Context, cmd command.Command) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = recovered(ctx, logger, string(cmd.Type()), r)
+				}
+			}()
+			return next(ctx, cmd)
+		}
+	}
+}
+
+// QueryRecovery turns a panic of the handler into an error, logging the stack
+// trace.
+func QueryRecovery(logger *slog.Logger) query.Middleware {
+	return func(next query.HandlerFunc) query.HandlerFunc {
+		return func(ctx context.Context, qry query.Query) (res interface{}, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					res, err = nil, recovered(ctx, logger, string(qry.Type()), r)
+				}
+			}()
+			return next(ctx, qry)
+		}
+	}
+}
+
+func recovered(ctx context.Context, logger *slog.Logger, msgType string, r interface{}) error {
+	logger.ErrorContext(ctx, "handler panic recovered",
+		slog.String("type", msgType),
+		slog.Any("panic", r),
+		slog.String("stack", string(debug.Stack())),
+	)
+	return fmt.Errorf("%w: %s: %v", ErrHandlerPanic, msgType, r)
+}
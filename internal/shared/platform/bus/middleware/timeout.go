@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+// CommandTimeout cancels the context of the commands that take longer than
+// their timeout. Handlers must honour the context for it to take effect.
+func CommandTimeout(cfg Config) command.Middleware {
+	return func(next command.HandlerFunc) command.HandlerFunc {
+		return func(ctx context.Context, cmd command.Command) error {
+			timeout := cfg.timeoutFor(string(cmd.Type()), cfg.CommandTimeout)
+			if timeout <= 0 {
+				return next(ctx, cmd)
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return next(ctx, cmd)
+		}
+	}
+}
+
+// QueryTimeout cancels the context of the queries that take longer than their
+// timeout. Handlers must honour the context for it to take effect.
+func QueryTimeout(cfg Config) query.Middleware {
+	return func(next query.HandlerFunc) query.HandlerFunc {
+		return func(ctx context.Context, qry query.Query) (interface{}, error) {
+			timeout := cfg.timeoutFor(string(qry.Type()), cfg.QueryTimeout)
+			if timeout <= 0 {
+				return next(ctx, qry)
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return next(ctx, qry)
+		}
+	}
+}
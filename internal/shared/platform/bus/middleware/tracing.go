@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CommandTracing starts a span for every command, so the spans of its handler
+// and repositories are grouped under it.
+func CommandTracing() command.Middleware {
+	return func(next command.HandlerFunc) command.HandlerFunc {
+		return func(ctx context.Context, cmd command.Command) error {
+			ctx, span := tracing.Start(ctx, string(cmd.Type()),
+				attribute.String("bus.kind", "command"),
+				attribute.String("bus.type", string(cmd.Type())),
+			)
+			err := next(ctx, cmd)
+			tracing.End(span, err)
+			return err
+		}
+	}
+}
+
+// QueryTracing starts a span for every query.
+func QueryTracing() query.Middleware {
+	return func(next query.HandlerFunc) query.HandlerFunc {
+		return func(ctx context.Context, qry query.Query) (interface{}, error) {
+			ctx, span := tracing.Start(ctx, string(qry.Type()),
+				attribute.String("bus.kind", "query"),
+				attribute.String("bus.type", string(qry.Type())),
+			)
+			res, err := next(ctx, qry)
+			tracing.End(span, err)
+			return res, err
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/rubenbupe/recipe-video-parser/kit/uow"
+)
+
+// NonTransactional is implemented by the commands that must not run inside a
+// unit of work, such as those waiting on external services, which would keep
+// the database locked.
+type NonTransactional interface {
+	NonTransactional()
+}
+
+// CommandTransaction runs every command inside a unit of work, so the changes
+// of all the repositories it uses are committed or discarded together.
+func CommandTransaction(unitOfWork uow.UnitOfWork) command.Middleware {
+	return func(next command.HandlerFunc) command.HandlerFunc {
+		return func(ctx context.Context, cmd command.Command) error {
+			if _, ok := cmd.(NonTransactional); ok {
+				return next(ctx, cmd)
+			}
+
+			return unitOfWork.Do(ctx, func(ctx context.Context) error {
+				return next(ctx, cmd)
+			})
+		}
+	}
+}
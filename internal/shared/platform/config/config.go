@@ -0,0 +1,77 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/batch"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/bus/inmemory"
+	busmiddleware "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/bus/middleware"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/gallery"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/logger"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/outbox"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	statuschecks "github.com/rubenbupe/recipe-video-parser/internal/status/platform/checks"
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/scheduler"
+	"github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/sender"
+)
+
+// Config is the configuration of the CLI and the API. Every section is read
+// from the environment variables with its prefix (e.g. AI_MODEL) and from the
+// key of the config file with the prefix in lower case (e.g. ai.model).
+type Config struct {
+	App           AppConfig               `prefix:"APP"`
+	DB            storage.Dbconfig        `prefix:"DB"`
+	Gallery       gallery.Galleryconfig   `prefix:"GALLERY"`
+	AI            ai.Aiconfig             `prefix:"AI"`
+	Log           logger.Config           `prefix:"LOG"`
+	Tracing       tracing.Config          `prefix:"TRACING"`
+	Bus           busmiddleware.Config    `prefix:"BUS"`
+	EventBus      inmemory.EventBusConfig `prefix:"EVENTBUS"`
+	Outbox        outbox.Config           `prefix:"OUTBOX"`
+	Webhook       sender.Config           `prefix:"WEBHOOK"`
+	Status        statuschecks.Config     `prefix:"STATUS"`
+	Batch         batch.Config            `prefix:"BATCH"`
+	Subscriptions scheduler.Config        `prefix:"SUBSCRIPTIONS"`
+}
+
+// AppConfig is the configuration of the HTTP server.
+type AppConfig struct {
+	Host            string        `default:"0.0.0.0"`
+	Port            uint          `default:"8080"`
+	ShutdownTimeout time.Duration `default:"10s"`
+}
+
+func (c AppConfig) Validate() error {
+	var errs []error
+	if c.Port > 65535 {
+		errs = append(errs, fmt.Errorf("port %d out of range", c.Port))
+	}
+	if c.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("shutdown timeout must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+// FileVariable is the environment variable with the path of the config file,
+// used when Options.File is empty.
+const FileVariable = "CONFIG_FILE"
+
+// Options are the sources of the config besides the defaults. The values of
+// the flags take precedence over the environment variables, and these over
+// the config file.
+type Options struct {
+	// File is a YAML (.yaml or .yml) or TOML (.toml) config file.
+	File string
+	// Overrides are the values set by flags, by variable (e.g. "AI_MODEL").
+	Overrides map[string]string
+	// LookupEnv reads the environment variables; os.LookupEnv when nil.
+	LookupEnv func(key string) (string, bool)
+}
+
+// Flags are the options set in the command line of the main function, which
+// the DI container loads the config with.
+var Flags = Options{}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"flag"
+	"strings"
+)
+
+// RegisterFlags registers on fs the -config flag and a flag for every
+// variable, named after it in lower case with dashes (e.g. -ai-model for
+// AI_MODEL), which sets the overrides of the options.
+func (o *Options) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.File, "config", "", "YAML or TOML config file (or "+FileVariable+")")
+	for _, v := range Variables() {
+		key := v.Key
+		name := strings.ToLower(strings.ReplaceAll(key, "_", "-"))
+		fs.Func(name, "overrides "+key, func(value string) error {
+			if o.Overrides == nil {
+				o.Overrides = make(map[string]string)
+			}
+			o.Overrides[key] = value
+			return nil
+		})
+	}
+}
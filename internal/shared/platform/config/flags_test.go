@@ -0,0 +1,23 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Options_RegisterFlags(t *testing.T) {
+	var opts Options
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	opts.RegisterFlags(fs)
+
+	require.NoError(t, fs.Parse([]string{"-config", "config.toml", "-ai-model", "gemini-test", "-app-port", "9000", "extract-recipe"}))
+
+	assert.Equal(t, "config.toml", opts.File)
+	assert.Equal(t, map[string]string{"AI_MODEL": "gemini-test", "APP_PORT": "9000"}, opts.Overrides)
+	assert.Equal(t, []string{"extract-recipe"}, fs.Args())
+}
@@ -0,0 +1,360 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Variable is a setting of the config.
+type Variable struct {
+	// Section is the prefix of its section, e.g. AI.
+	Section string
+	// Key is the name of the environment variable, e.g. AI_APIKEY.
+	Key string
+	// FileKey is the key in the config file, e.g. ai.apiKey.
+	FileKey string
+	Default string
+	// Secret values are redacted when the config is printed.
+	Secret bool
+
+	section int
+	field   int
+}
+
+// Error is an error in a setting or in a section of the config.
+type Error struct {
+	// Section is the prefix of the section, e.g. AI.
+	Section string
+	// Key is the variable of the setting, or empty when the error is about
+	// the whole section.
+	Key string
+	// Source is where the value of the setting was read from: flag, env,
+	// default or the path of the config file.
+	Source string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("%s: %v", strings.ToLower(e.Section), e.Err)
+	}
+	return fmt.Sprintf("%s (%s): %v", e.Key, e.Source, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+type validator interface {
+	Validate() error
+}
+
+// Variables returns the settings of the config in the order of the sections
+// and their fields.
+func Variables() []Variable {
+	var variables []Variable
+	configType := reflect.TypeOf(Config{})
+	for i := 0; i < configType.NumField(); i++ {
+		section := configType.Field(i)
+		prefix := section.Tag.Get("prefix")
+		for j := 0; j < section.Type.NumField(); j++ {
+			field := section.Type.Field(j)
+			if !field.IsExported() {
+				continue
+			}
+			variables = append(variables, Variable{
+				Section: prefix,
+				Key:     prefix + "_" + strings.ToUpper(field.Name),
+				FileKey: strings.ToLower(prefix) + "." + strings.ToLower(field.Name[:1]) + field.Name[1:],
+				Default: field.Tag.Get("default"),
+				Secret:  field.Tag.Get("secret") == "true",
+				section: i,
+				field:   j,
+			})
+		}
+	}
+	return variables
+}
+
+// Prefixes returns the prefixes of the environment variables of the sections.
+func Prefixes() []string {
+	var prefixes []string
+	configType := reflect.TypeOf(Config{})
+	for i := 0; i < configType.NumField(); i++ {
+		prefixes = append(prefixes, configType.Field(i).Tag.Get("prefix"))
+	}
+	return prefixes
+}
+
+// Load reads the config from the defaults, the config file, the environment
+// variables and the overrides, in increasing order of precedence, and
+// validates every section. A variable that is set but empty replaces its
+// default.
+//
+// Every error is reported at once. The config is returned even when it is
+// not valid, so it can be inspected.
+func Load(opts Options) (*Config, error) {
+	lookupEnv := opts.LookupEnv
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+	file := opts.File
+	if file == "" {
+		file, _ = lookupEnv(FileVariable)
+	}
+
+	variables := Variables()
+	var errs []error
+
+	fileValues := map[string]interface{}{}
+	if file != "" {
+		var err error
+		fileValues, err = readFile(file, variables)
+		if fileValues == nil {
+			return nil, err
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	cfg := &Config{}
+	sections := reflect.ValueOf(cfg).Elem()
+	for _, v := range variables {
+		field := sections.Field(v.section).Field(v.field)
+
+		var err error
+		var source string
+		if value, ok := opts.Overrides[v.Key]; ok {
+			source, err = "flag", setString(field, value)
+		} else if value, ok := lookupEnv(v.Key); ok {
+			source, err = "env", setString(field, value)
+		} else if value, ok := fileValues[v.Key]; ok {
+			source, err = file, setFileValue(field, value)
+		} else if v.Default != "" {
+			source, err = "default", setString(field, v.Default)
+		}
+		if err != nil {
+			errs = append(errs, &Error{Section: v.Section, Key: v.Key, Source: source, Err: err})
+		}
+	}
+
+	for i := 0; i < sections.NumField(); i++ {
+		section, ok := sections.Field(i).Interface().(validator)
+		if !ok {
+			continue
+		}
+		prefix := sections.Type().Field(i).Tag.Get("prefix")
+		errs = append(errs, sectionErrors(prefix, section.Validate())...)
+	}
+
+	return cfg, errors.Join(errs...)
+}
+
+// sectionErrors returns an Error of the section for every error joined in
+// err.
+func sectionErrors(prefix string, err error) []error {
+	var errs []error
+	for _, err := range Errors(err) {
+		errs = append(errs, &Error{Section: prefix, Err: err})
+	}
+	return errs
+}
+
+// Errors returns the errors joined in err, e.g. the errors of Load.
+func Errors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+	var errs []error
+	for _, err := range joined.Unwrap() {
+		errs = append(errs, Errors(err)...)
+	}
+	return errs
+}
+
+// readFile decodes the config file and returns its values by variable.
+func readFile(path string, variables []Variable) (map[string]interface{}, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	var document map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &document)
+	case ".toml":
+		err = toml.Unmarshal(content, &document)
+	default:
+		return nil, fmt.Errorf("unsupported config file %s: use .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error decoding config file %s: %w", path, err)
+	}
+
+	// Las claves no distinguen mayúsculas, guiones ni guiones bajos
+	keys := make(map[string]string, len(variables))
+	fileKeys := make([]string, 0, len(variables))
+	for _, v := range variables {
+		keys[normalizeKey(v.FileKey)] = v.Key
+		fileKeys = append(fileKeys, v.FileKey)
+	}
+
+	values := make(map[string]interface{})
+	var errs []error
+	for sectionName, section := range document {
+		fields, ok := section.(map[string]interface{})
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s must be a table of settings", path, sectionName))
+			continue
+		}
+		for fieldName, value := range fields {
+			fileKey := sectionName + "." + fieldName
+			key, ok := keys[normalizeKey(fileKey)]
+			if !ok {
+				message := fmt.Sprintf("%s: unknown setting %s", path, fileKey)
+				if suggestion := Suggest(fileKey, fileKeys); suggestion != "" {
+					message += fmt.Sprintf(" (did you mean %s?)", suggestion)
+				}
+				errs = append(errs, errors.New(message))
+				continue
+			}
+			values[key] = value
+		}
+	}
+	slices.SortFunc(errs, func(a, b error) int { return strings.Compare(a.Error(), b.Error()) })
+	return values, errors.Join(errs...)
+}
+
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setString sets the field from its value in an environment variable. Maps
+// are written as "key:value,key:value" and slices as "value,value".
+func setString(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.Map:
+		m := reflect.MakeMap(field.Type())
+		if strings.TrimSpace(value) != "" {
+			for _, pair := range strings.Split(value, ",") {
+				k, v, ok := strings.Cut(pair, ":")
+				if !ok {
+					return fmt.Errorf("invalid map item %q", pair)
+				}
+				elem := reflect.New(field.Type().Elem()).Elem()
+				if err := setString(elem, strings.TrimSpace(v)); err != nil {
+					return err
+				}
+				m.SetMapIndex(reflect.ValueOf(strings.TrimSpace(k)), elem)
+			}
+		}
+		field.Set(m)
+		return nil
+	case reflect.Slice:
+		s := reflect.MakeSlice(field.Type(), 0, 0)
+		if strings.TrimSpace(value) != "" {
+			for _, item := range strings.Split(value, ",") {
+				elem := reflect.New(field.Type().Elem()).Elem()
+				if err := setString(elem, strings.TrimSpace(item)); err != nil {
+					return err
+				}
+				s = reflect.Append(s, elem)
+			}
+		}
+		field.Set(s)
+		return nil
+	}
+	return setScalar(field, value)
+}
+
+func setScalar(field reflect.Value, value string) error {
+	var err error
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(value)
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.Type() == durationType {
+			var d time.Duration
+			d, err = time.ParseDuration(value)
+			field.SetInt(int64(d))
+			break
+		}
+		var i int64
+		i, err = strconv.ParseInt(value, 0, field.Type().Bits())
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		u, err = strconv.ParseUint(value, 0, field.Type().Bits())
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(value, field.Type().Bits())
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s %q", field.Type(), value)
+	}
+	return nil
+}
+
+// setFileValue sets the field from its value in the config file, where maps
+// and lists can also be written as tables and arrays.
+func setFileValue(field reflect.Value, value interface{}) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if field.Kind() != reflect.Map {
+			return fmt.Errorf("expected a %s, not a table", field.Type())
+		}
+		m := reflect.MakeMap(field.Type())
+		for key, item := range v {
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if err := setScalar(elem, fmt.Sprint(item)); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			m.SetMapIndex(reflect.ValueOf(key), elem)
+		}
+		field.Set(m)
+		return nil
+	case []interface{}:
+		if field.Kind() != reflect.Slice {
+			return fmt.Errorf("expected a %s, not a list", field.Type())
+		}
+		s := reflect.MakeSlice(field.Type(), 0, len(v))
+		for _, item := range v {
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if err := setScalar(elem, fmt.Sprint(item)); err != nil {
+				return err
+			}
+			s = reflect.Append(s, elem)
+		}
+		field.Set(s)
+		return nil
+	case nil:
+		return setString(field, "")
+	}
+	return setString(field, fmt.Sprint(value))
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func env(values map[string]string) func(key string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func Test_Load_Defaults(t *testing.T) {
+	cfg, err := Load(Options{LookupEnv: env(map[string]string{"AI_APIKEY": "secret"})})
+
+	require.NoError(t, err)
+	assert.Equal(t, "0.0.0.0", cfg.App.Host)
+	assert.Equal(t, uint(8080), cfg.App.Port)
+	assert.Equal(t, 10*time.Second, cfg.App.ShutdownTimeout)
+	assert.Equal(t, "google", cfg.AI.Provider)
+	assert.Equal(t, "secret", cfg.AI.ApiKey)
+}
+
+func Test_Load_Precedence(t *testing.T) {
+	file := writeFile(t, "config.yaml", `
+app:
+  port: 9000
+  host: 127.0.0.1
+ai:
+  apiKey: from-file
+  model: file-model
+log:
+  level: debug
+`)
+
+	cfg, err := Load(Options{
+		File:      file,
+		Overrides: map[string]string{"AI_MODEL": "flag-model"},
+		LookupEnv: env(map[string]string{"AI_MODEL": "env-model", "APP_PORT": "9100"}),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "flag-model", cfg.AI.Model, "flags take precedence over env")
+	assert.Equal(t, uint(9100), cfg.App.Port, "env takes precedence over the file")
+	assert.Equal(t, "127.0.0.1", cfg.App.Host, "the file takes precedence over the defaults")
+	assert.Equal(t, "from-file", cfg.AI.ApiKey)
+	assert.Equal(t, "debug", cfg.Log.Level)
+}
+
+func Test_Load_FileFromEnv(t *testing.T) {
+	file := writeFile(t, "config.yaml", "ai:\n  apiKey: from-file\n")
+
+	cfg, err := Load(Options{LookupEnv: env(map[string]string{FileVariable: file})})
+
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", cfg.AI.ApiKey)
+}
+
+func Test_Load_TOML(t *testing.T) {
+	file := writeFile(t, "config.toml", `
+[ai]
+api_key = "from-file"
+temperature = 0.5
+
+[tracing]
+headers = { authorization = "Bearer token" }
+
+[outbox]
+pollInterval = "2s"
+`)
+
+	cfg, err := Load(Options{File: file, LookupEnv: env(nil)})
+
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", cfg.AI.ApiKey)
+	assert.Equal(t, 0.5, cfg.AI.Temperature)
+	assert.Equal(t, map[string]string{"authorization": "Bearer token"}, cfg.Tracing.Headers)
+	assert.Equal(t, 2*time.Second, cfg.Outbox.PollInterval)
+}
+
+func Test_Load_EnvMaps(t *testing.T) {
+	cfg, err := Load(Options{LookupEnv: env(map[string]string{
+		"AI_APIKEY":       "secret",
+		"TRACING_HEADERS": "authorization:Bearer token, x-team:recipes",
+	})})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer token", "x-team": "recipes"}, cfg.Tracing.Headers)
+}
+
+func Test_Load_EmptyVariableReplacesDefault(t *testing.T) {
+	cfg, _ := Load(Options{LookupEnv: env(map[string]string{"AI_APIKEY": "secret", "GALLERY_DOWNLOADDIR": ""})})
+
+	assert.Equal(t, "", cfg.Gallery.DownloadDir)
+}
+
+func Test_Load_UnknownFileSettings(t *testing.T) {
+	file := writeFile(t, "config.yaml", "ai:\n  apiKey: secret\n  modle: gemini\nlog: debug\n")
+
+	cfg, err := Load(Options{File: file, LookupEnv: env(nil)})
+
+	require.NotNil(t, cfg)
+	assert.ErrorContains(t, err, "unknown setting ai.modle (did you mean ai.model?)")
+	assert.ErrorContains(t, err, "log must be a table of settings")
+}
+
+func Test_Load_UnsupportedFile(t *testing.T) {
+	cfg, err := Load(Options{File: writeFile(t, "config.json", "{}"), LookupEnv: env(nil)})
+
+	assert.Nil(t, cfg)
+	assert.ErrorContains(t, err, "unsupported config file")
+}
+
+func Test_Load_AggregatesErrors(t *testing.T) {
+	cfg, err := Load(Options{
+		Overrides: map[string]string{"APP_PORT": "eighty"},
+		LookupEnv: env(map[string]string{
+			"AI_APIKEY":   "secret",
+			"AI_PROVIDER": "openai",
+			"LOG_LEVEL":   "verbose",
+			"DB_TIMEOUT":  "5 seconds",
+		}),
+	})
+
+	require.NotNil(t, cfg)
+	errs := Errors(err)
+	require.Len(t, errs, 4)
+	assert.EqualError(t, errs[0], `APP_PORT (flag): invalid uint "eighty"`)
+	assert.EqualError(t, errs[1], `DB_TIMEOUT (env): invalid time.Duration "5 seconds"`)
+
+	sections := make([]string, 0, len(errs))
+	for _, err := range errs {
+		var configErr *Error
+		require.True(t, errors.As(err, &configErr))
+		sections = append(sections, configErr.Section)
+	}
+	assert.Equal(t, []string{"APP", "DB", "AI", "LOG"}, sections)
+	assert.ErrorContains(t, errs[2], `ai: `)
+	assert.ErrorContains(t, errs[2], `unsupported provider "openai"`)
+}
+
+func Test_Variables(t *testing.T) {
+	variables := Variables()
+
+	var apiKey Variable
+	for _, v := range variables {
+		if v.Key == "AI_APIKEY" {
+			apiKey = v
+		}
+	}
+	assert.Equal(t, "AI", apiKey.Section)
+	assert.Equal(t, "ai.apiKey", apiKey.FileKey)
+	assert.Equal(t, "app", apiKey.Default)
+	assert.True(t, apiKey.Secret)
+	assert.Equal(t, "APP_HOST", variables[0].Key)
+}
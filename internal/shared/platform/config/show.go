@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Redacted replaces the secret values when the config is printed.
+const Redacted = "<redacted>"
+
+// Formats of Write.
+const (
+	FormatYAML = "yaml"
+	FormatEnv  = "env"
+)
+
+// Write prints the config as a YAML config file or as environment variables,
+// with the secret values redacted.
+func Write(w io.Writer, cfg *Config, format string) error {
+	sections := reflect.ValueOf(cfg).Elem()
+
+	switch format {
+	case FormatEnv:
+		for _, v := range Variables() {
+			value := printable(v, sections.Field(v.section).Field(v.field))
+			if _, err := fmt.Fprintf(w, "%s=%s\n", v.Key, formatEnvValue(value)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatYAML:
+		document := &yaml.Node{Kind: yaml.MappingNode}
+		var current *yaml.Node
+		currentSection := ""
+		for _, v := range Variables() {
+			section, field, _ := strings.Cut(v.FileKey, ".")
+			if section != currentSection {
+				current = &yaml.Node{Kind: yaml.MappingNode}
+				document.Content = append(document.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: section}, current)
+				currentSection = section
+			}
+
+			var value yaml.Node
+			if err := value.Encode(printable(v, sections.Field(v.section).Field(v.field))); err != nil {
+				return err
+			}
+			current.Content = append(current.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: field}, &value)
+		}
+
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(document); err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		return fmt.Errorf("unsupported format %q (supported: %s, %s)", format, FormatYAML, FormatEnv)
+	}
+}
+
+// printable returns the value of the field as it is written in a config
+// file, with durations as strings and the secrets redacted.
+func printable(v Variable, field reflect.Value) interface{} {
+	if v.Secret && !field.IsZero() && !(field.Kind() == reflect.Map && field.Len() == 0) {
+		return Redacted
+	}
+
+	if field.Kind() == reflect.Map {
+		m := make(map[string]interface{}, field.Len())
+		iter := field.MapRange()
+		for iter.Next() {
+			m[fmt.Sprint(iter.Key().Interface())] = printableScalar(iter.Value())
+		}
+		return m
+	}
+	return printableScalar(field)
+}
+
+func printableScalar(value reflect.Value) interface{} {
+	if d, ok := value.Interface().(time.Duration); ok {
+		return d.String()
+	}
+	return value.Interface()
+}
+
+func formatEnvValue(value interface{}) string {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Sprint(value)
+	}
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s:%v", key, m[key]))
+	}
+	return strings.Join(pairs, ",")
+}
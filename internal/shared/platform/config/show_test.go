@@ -0,0 +1,51 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Write_YAML(t *testing.T) {
+	cfg, err := Load(Options{LookupEnv: env(map[string]string{"AI_APIKEY": "secret"})})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, cfg, FormatYAML))
+
+	assert.NotContains(t, buf.String(), "secret")
+	assert.Contains(t, buf.String(), "apiKey: "+Redacted)
+	assert.Contains(t, buf.String(), "shutdownTimeout: 10s")
+
+	// La salida se puede usar como fichero de configuración
+	file := writeFile(t, "config.yaml", buf.String())
+	reloaded, err := Load(Options{File: file, LookupEnv: env(nil)})
+	require.NoError(t, err)
+	assert.Equal(t, cfg.App, reloaded.App)
+	assert.Equal(t, cfg.Outbox, reloaded.Outbox)
+}
+
+func Test_Write_Env(t *testing.T) {
+	cfg, err := Load(Options{LookupEnv: env(map[string]string{
+		"AI_APIKEY":       "secret",
+		"TRACING_HEADERS": "authorization:Bearer token",
+		"WEBHOOK_TIMEOUT": "3s",
+	})})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, cfg, FormatEnv))
+
+	assert.NotContains(t, buf.String(), "Bearer token")
+	assert.Contains(t, buf.String(), "AI_APIKEY="+Redacted+"\n")
+	assert.Contains(t, buf.String(), "TRACING_HEADERS="+Redacted+"\n")
+	assert.Contains(t, buf.String(), "WEBHOOK_TIMEOUT=3s\n")
+}
+
+func Test_Write_UnsupportedFormat(t *testing.T) {
+	err := Write(&bytes.Buffer{}, &Config{}, "json")
+
+	assert.ErrorContains(t, err, `unsupported format "json"`)
+}
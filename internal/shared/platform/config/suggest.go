@@ -0,0 +1,40 @@
+package config
+
+// maxSuggestionDistance is the maximum edit distance between a misspelled
+// setting and its suggestion.
+const maxSuggestionDistance = 2
+
+// Suggest returns the known setting most similar to key, ignoring the case,
+// the dashes and the underscores, or an empty string when none is similar
+// enough.
+func Suggest(key string, known []string) string {
+	best, bestDistance := "", maxSuggestionDistance+1
+	normalized := normalizeKey(key)
+	for _, candidate := range known {
+		distance := levenshtein(normalized, normalizeKey(candidate))
+		if distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,16 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Suggest(t *testing.T) {
+	known := []string{"AI_APIKEY", "AI_MODEL", "GALLERY_CONFIGFILE", "GALLERY_DOWNLOADDIR", "ai.apiKey"}
+
+	assert.Equal(t, "GALLERY_CONFIGFILE", Suggest("GALLERY_CONFIG_FILE", known))
+	assert.Equal(t, "AI_APIKEY", Suggest("AI_API_KEYS", known))
+	assert.Equal(t, "ai.apiKey", Suggest("ai.api-key", known))
+	assert.Equal(t, "", Suggest("AI_TOKEN", known))
+}
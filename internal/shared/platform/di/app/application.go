@@ -1,12 +1,35 @@
 package app
 
 import (
+	"context"
+
+	organizationsclihandlers "github.com/rubenbupe/recipe-video-parser/internal/organizations/platform/cli/handler"
+	organizationshandlers "github.com/rubenbupe/recipe-video-parser/internal/organizations/platform/server/handler"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	recipesai "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
+	recipesbatch "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/batch"
 	recipesclihandlers "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/cli/handler"
+	recipesmetrics "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/metrics"
+	recipesquota "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/quota"
 	recipeshandlers "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/server/handler"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/config"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/gallery"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/i18n"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/metrics"
 	statushandlers "github.com/rubenbupe/recipe-video-parser/internal/status/platform/server/handler"
+	subscriptionsclihandlers "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/cli/handler"
+	subscriptionsextractor "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/extractor"
+	subscriptionslister "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/lister"
+	subscriptionsscheduler "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/scheduler"
+	subscriptionshandlers "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/server/handler"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
 	usershandlers "github.com/rubenbupe/recipe-video-parser/internal/users/platform/cli/handler"
+	usersserverhandlers "github.com/rubenbupe/recipe-video-parser/internal/users/platform/server/handler"
+	webhooksclihandlers "github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/cli/handler"
+	webhookspoller "github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/poller"
+	webhookssender "github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/sender"
+	webhookshandlers "github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/server/handler"
 	"github.com/rubenbupe/recipe-video-parser/kit/command"
 	"github.com/rubenbupe/recipe-video-parser/kit/query"
 	"github.com/sarulabs/di/v2"
@@ -20,6 +43,108 @@ var Defs = []di.Def{
 			return statushandlers.CheckHandler(), nil
 		},
 	},
+	{
+		Name: "status.infrastructure.controller.live",
+		Build: func(ctn di.Container) (interface{}, error) {
+			return statushandlers.LiveHandler(), nil
+		},
+	},
+	{
+		Name: "status.infrastructure.controller.ready",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return statushandlers.ReadyHandler(queryBus), nil
+		},
+	},
+	// USERS (ADMIN)
+	{
+		Name: "users.infrastructure.controller.create",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return usersserverhandlers.CreateHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "users.infrastructure.controller.list",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return usersserverhandlers.ListHandler(queryBus), nil
+		},
+	},
+	{
+		Name: "users.infrastructure.controller.get",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return usersserverhandlers.GetHandler(queryBus), nil
+		},
+	},
+	{
+		Name: "users.infrastructure.controller.disable",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return usersserverhandlers.UpdateStatusHandler(commandBus, usersdomain.UserStatusDisabled), nil
+		},
+	},
+	{
+		Name: "users.infrastructure.controller.enable",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return usersserverhandlers.UpdateStatusHandler(commandBus, usersdomain.UserStatusActive), nil
+		},
+	},
+	{
+		Name: "users.infrastructure.controller.delete",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return usersserverhandlers.DeleteHandler(commandBus, queryBus), nil
+		},
+	},
+	{
+		Name: "users.infrastructure.controller.rotateapikey",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return usersserverhandlers.RotateApiKeyHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "users.infrastructure.controller.updaterole",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return usersserverhandlers.UpdateRoleHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "users.infrastructure.controller.updatequota",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return usersserverhandlers.UpdateQuotaHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "users.infrastructure.controller.usage",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return usersserverhandlers.UsageHandler(queryBus), nil
+		},
+	},
+	{
+		Name: "users.infrastructure.controller.export",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return usersserverhandlers.ExportHandler(commandBus, queryBus), nil
+		},
+	},
+	{
+		Name: "users.infrastructure.controller.exportown",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return usersserverhandlers.ExportOwnHandler(commandBus, queryBus), nil
+		},
+	},
+
 	// USERS (CLI)
 	{
 		Name: "users.infrastructure.cli.create",
@@ -42,6 +167,107 @@ var Defs = []di.Def{
 			return usershandlers.CreateUpdateApiKeyHandler(commandBus), nil
 		},
 	},
+	{
+		Name: "users.infrastructure.cli.updatelocale",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return usershandlers.CreateUpdateLocaleHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "users.infrastructure.cli.list",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return usershandlers.CreateListUsersHandler(queryBus), nil
+		},
+	},
+	{
+		Name: "users.infrastructure.cli.updaterole",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return usershandlers.CreateUpdateRoleHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "users.infrastructure.cli.updatestatus",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return usershandlers.CreateUpdateStatusHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "users.infrastructure.cli.updatequota",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return usershandlers.CreateUpdateQuotaHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "users.infrastructure.cli.delete",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return usershandlers.CreateDeleteUserHandler(commandBus, queryBus), nil
+		},
+	},
+	{
+		Name: "users.infrastructure.cli.export",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return usershandlers.CreateExportUserDataHandler(commandBus, queryBus), nil
+		},
+	},
+
+	// RECIPES (PROMPTS)
+	{
+		Name: "recipes.infrastructure.prompts",
+		Build: func(ctn di.Container) (interface{}, error) {
+			aiConfig := ctn.Get("shared.infrastructure.aiconfig").(*ai.Aiconfig)
+			prompts, err := recipesai.NewPromptRegistry(aiConfig.PromptsDir)
+			if err != nil {
+				return nil, err
+			}
+			if err := prompts.SetVariants(recipesai.ExtractRecipePromptID, aiConfig.PromptVariants); err != nil {
+				return nil, err
+			}
+			return prompts, nil
+		},
+	},
+
+	// RECIPES (METRICS)
+	{
+		Name: "recipes.infrastructure.metrics",
+		Build: func(ctn di.Container) (interface{}, error) {
+			registry := ctn.Get("shared.infrastructure.metrics").(*metrics.Registry)
+			return recipesmetrics.NewExtractionMetrics(registry), nil
+		},
+	},
+
+	// RECIPES (BATCH)
+	{
+		Name: "recipes.infrastructure.batchconfig",
+		Build: func(ctn di.Container) (interface{}, error) {
+			cfg := ctn.Get("shared.infrastructure.config").(*config.Config)
+			return &cfg.Batch, nil
+		},
+	},
+	{
+		Name: "recipes.infrastructure.batch",
+		Build: func(ctn di.Container) (interface{}, error) {
+			galleryConfig := ctn.Get("shared.infrastructure.galleryconfig").(*gallery.Galleryconfig)
+			aiConfig := ctn.Get("shared.infrastructure.aiconfig").(*ai.Aiconfig)
+			prompts := ctn.Get("recipes.infrastructure.prompts").(*recipesai.PromptRegistry)
+			extractionMetrics := ctn.Get("recipes.infrastructure.metrics").(*recipesmetrics.ExtractionMetrics)
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+
+			extract := func(ctx context.Context, url string, locale i18n.Locale) (recipesai.AiResponse, string, error) {
+				return recipesclihandlers.ExtractRecipe(ctx, url, locale, prompts, galleryConfig, aiConfig, extractionMetrics)
+			}
+			return recipesbatch.NewRunner(extract, commandBus, queryBus), nil
+		},
+	},
 
 	// RECIPES (HTTP)
 	{
@@ -50,9 +276,51 @@ var Defs = []di.Def{
 			galleryConfig := ctn.Get("shared.infrastructure.galleryconfig").(*gallery.Galleryconfig)
 			aiConfig := ctn.Get("shared.infrastructure.aiconfig").(*ai.Aiconfig)
 
+			prompts := ctn.Get("recipes.infrastructure.prompts").(*recipesai.PromptRegistry)
+
 			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
 
-			return recipeshandlers.ExtractHandler(galleryConfig, aiConfig, commandBus), nil
+			extractionMetrics := ctn.Get("recipes.infrastructure.metrics").(*recipesmetrics.ExtractionMetrics)
+
+			return recipeshandlers.ExtractHandler(galleryConfig, aiConfig, prompts, commandBus, extractionMetrics), nil
+		},
+	},
+
+	{
+		Name: "recipes.infrastructure.quota",
+		Build: func(ctn di.Container) (interface{}, error) {
+			usageRepo := ctn.Get("extractions.domain.usagerepository").(recipesdomain.UsageRepository)
+			return recipesquota.NewChecker(usageRepo, nil), nil
+		},
+	},
+	{
+		Name: "recipes.infrastructure.controller.batch",
+		Build: func(ctn di.Container) (interface{}, error) {
+			runner := ctn.Get("recipes.infrastructure.batch").(recipesbatch.Runner)
+			batchConfig := ctn.Get("recipes.infrastructure.batchconfig").(*recipesbatch.Config)
+			checker := ctn.Get("recipes.infrastructure.quota").(recipesquota.Checker)
+			return recipeshandlers.BatchHandler(runner, checker, *batchConfig), nil
+		},
+	},
+	{
+		Name: "recipes.infrastructure.controller.usage",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return recipeshandlers.UsageHandler(queryBus), nil
+		},
+	},
+	{
+		Name: "recipes.infrastructure.controller.library",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return recipeshandlers.LibraryHandler(queryBus), nil
+		},
+	},
+	{
+		Name: "recipes.infrastructure.controller.quota",
+		Build: func(ctn di.Container) (interface{}, error) {
+			checker := ctn.Get("recipes.infrastructure.quota").(recipesquota.Checker)
+			return recipeshandlers.QuotaMiddleware(checker), nil
 		},
 	},
 
@@ -64,12 +332,303 @@ var Defs = []di.Def{
 			return recipesclihandlers.CreateGetExtractionsHandler(queryBus), nil
 		},
 	},
+	{
+		Name: "recipes.infrastructure.cli.usage",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return recipesclihandlers.CreateUsageReportHandler(queryBus), nil
+		},
+	},
+	{
+		Name: "recipes.infrastructure.cli.usagesummary",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return recipesclihandlers.CreateUsageSummaryHandler(queryBus), nil
+		},
+	},
+	{
+		Name: "recipes.infrastructure.cli.setprice",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return recipesclihandlers.CreateSetModelPriceHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "recipes.infrastructure.cli.listprices",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return recipesclihandlers.CreateListModelPricesHandler(queryBus), nil
+		},
+	},
 	{
 		Name: "recipes.infrastructure.cli.extract",
 		Build: func(ctn di.Container) (interface{}, error) {
 			galleryConfig := ctn.Get("shared.infrastructure.galleryconfig").(*gallery.Galleryconfig)
 			aiConfig := ctn.Get("shared.infrastructure.aiconfig").(*ai.Aiconfig)
-			return recipesclihandlers.NewExtractRecipeHandler(galleryConfig, aiConfig), nil
+			prompts := ctn.Get("recipes.infrastructure.prompts").(*recipesai.PromptRegistry)
+			return recipesclihandlers.NewExtractRecipeHandler(galleryConfig, aiConfig, prompts), nil
+		},
+	},
+
+	// WEBHOOKS (HTTP)
+	{
+		Name: "webhooks.infrastructure.controller.create",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return webhookshandlers.CreateHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "webhooks.infrastructure.controller.list",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return webhookshandlers.ListHandler(queryBus), nil
+		},
+	},
+
+	// WEBHOOKS (CLI)
+	{
+		Name: "webhooks.infrastructure.cli.create",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return webhooksclihandlers.CreateCreateWebhookHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "webhooks.infrastructure.cli.list",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return webhooksclihandlers.CreateGetWebhooksHandler(queryBus), nil
+		},
+	},
+	{
+		Name: "webhooks.infrastructure.cli.replay",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return webhooksclihandlers.CreateReplayDeliveryHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "webhooks.infrastructure.poller",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			config := ctn.Get("webhooks.infrastructure.senderconfig").(*webhookssender.Config)
+			return webhookspoller.NewPoller(commandBus, config.PollInterval), nil
+		},
+	},
+
+	// SUBSCRIPTIONS
+	{
+		Name: "subscriptions.infrastructure.config",
+		Build: func(ctn di.Container) (interface{}, error) {
+			cfg := ctn.Get("shared.infrastructure.config").(*config.Config)
+			return &cfg.Subscriptions, nil
+		},
+	},
+	{
+		Name: "subscriptions.infrastructure.lister",
+		Build: func(ctn di.Container) (interface{}, error) {
+			galleryConfig := ctn.Get("shared.infrastructure.galleryconfig").(*gallery.Galleryconfig)
+			return subscriptionslister.NewCommandLister(galleryConfig.ConfigFile), nil
+		},
+	},
+	{
+		Name: "subscriptions.infrastructure.extractor",
+		Build: func(ctn di.Container) (interface{}, error) {
+			runner := ctn.Get("recipes.infrastructure.batch").(recipesbatch.Runner)
+			batchConfig := ctn.Get("recipes.infrastructure.batchconfig").(*recipesbatch.Config)
+			userRepo := ctn.Get("users.domain.repository").(usersdomain.UserRepository)
+			checker := ctn.Get("recipes.infrastructure.quota").(recipesquota.Checker)
+			return subscriptionsextractor.NewBatchExtractor(runner, userRepo, checker, batchConfig.Concurrency), nil
+		},
+	},
+	{
+		Name: "subscriptions.infrastructure.scheduler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			config := ctn.Get("subscriptions.infrastructure.config").(*subscriptionsscheduler.Config)
+			return subscriptionsscheduler.NewScheduler(commandBus, *config), nil
+		},
+	},
+
+	// SUBSCRIPTIONS (HTTP)
+	{
+		Name: "subscriptions.infrastructure.controller.create",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return subscriptionshandlers.CreateHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "subscriptions.infrastructure.controller.list",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return subscriptionshandlers.ListHandler(queryBus), nil
+		},
+	},
+	{
+		Name: "subscriptions.infrastructure.controller.get",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return subscriptionshandlers.GetHandler(queryBus), nil
+		},
+	},
+	{
+		Name: "subscriptions.infrastructure.controller.update",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return subscriptionshandlers.UpdateHandler(commandBus, queryBus), nil
+		},
+	},
+	{
+		Name: "subscriptions.infrastructure.controller.delete",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return subscriptionshandlers.DeleteHandler(commandBus, queryBus), nil
+		},
+	},
+
+	// SUBSCRIPTIONS (CLI)
+	{
+		Name: "subscriptions.infrastructure.cli.create",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return subscriptionsclihandlers.CreateCreateSubscriptionHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "subscriptions.infrastructure.cli.list",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return subscriptionsclihandlers.CreateGetSubscriptionsHandler(queryBus), nil
+		},
+	},
+	{
+		Name: "subscriptions.infrastructure.cli.get",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return subscriptionsclihandlers.CreateGetSubscriptionHandler(queryBus), nil
+		},
+	},
+	{
+		Name: "subscriptions.infrastructure.cli.update",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return subscriptionsclihandlers.CreateUpdateSubscriptionHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "subscriptions.infrastructure.cli.delete",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return subscriptionsclihandlers.CreateDeleteSubscriptionHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "subscriptions.infrastructure.cli.check",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return subscriptionsclihandlers.CreateCheckSubscriptionsHandler(commandBus), nil
+		},
+	},
+
+	// ORGANIZATIONS (HTTP)
+	{
+		Name: "organizations.infrastructure.controller.create",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return organizationshandlers.CreateHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "organizations.infrastructure.controller.list",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return organizationshandlers.ListHandler(queryBus), nil
+		},
+	},
+	{
+		Name: "organizations.infrastructure.controller.get",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return organizationshandlers.GetHandler(queryBus), nil
+		},
+	},
+	{
+		Name: "organizations.infrastructure.controller.setmember",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return organizationshandlers.SetMemberHandler(commandBus, queryBus), nil
+		},
+	},
+	{
+		Name: "organizations.infrastructure.controller.removemember",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return organizationshandlers.RemoveMemberHandler(commandBus, queryBus), nil
+		},
+	},
+	{
+		Name: "organizations.infrastructure.controller.delete",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return organizationshandlers.DeleteHandler(commandBus, queryBus), nil
+		},
+	},
+
+	// ORGANIZATIONS (CLI)
+	{
+		Name: "organizations.infrastructure.cli.create",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return organizationsclihandlers.CreateCreateOrganizationHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "organizations.infrastructure.cli.list",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return organizationsclihandlers.CreateGetOrganizationsHandler(queryBus), nil
+		},
+	},
+	{
+		Name: "organizations.infrastructure.cli.get",
+		Build: func(ctn di.Container) (interface{}, error) {
+			queryBus := ctn.Get("shared.domain.querybus").(query.Bus)
+			return organizationsclihandlers.CreateGetOrganizationHandler(queryBus), nil
+		},
+	},
+	{
+		Name: "organizations.infrastructure.cli.setmember",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return organizationsclihandlers.CreateSetMemberHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "organizations.infrastructure.cli.removemember",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return organizationsclihandlers.CreateRemoveMemberHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "organizations.infrastructure.cli.updatequota",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return organizationsclihandlers.CreateUpdateQuotaHandler(commandBus), nil
+		},
+	},
+	{
+		Name: "organizations.infrastructure.cli.delete",
+		Build: func(ctn di.Container) (interface{}, error) {
+			commandBus := ctn.Get("shared.domain.commandbus").(command.Bus)
+			return organizationsclihandlers.CreateDeleteOrganizationHandler(commandBus), nil
 		},
 	},
 }
@@ -2,6 +2,8 @@ package di
 
 import (
 	"os"
+	"sort"
+	"strconv"
 
 	di "github.com/sarulabs/di/v2"
 )
@@ -9,7 +11,8 @@ import (
 var env = os.Getenv("ENV")
 
 var envDefs = map[string][]di.Def{
-	"dev": devDefs,
+	"dev":  devDefs,
+	"test": testDefs,
 }
 
 type DiContainer struct {
@@ -73,3 +76,31 @@ func (container DiContainer) GetByTag(tag string) []di.Def {
 	}
 	return result
 }
+
+// PriorityArg is the tag argument that orders the definitions returned by
+// GetByTagOrdered. Lower priorities come first; definitions without it have
+// priority 0.
+const PriorityArg = "priority"
+
+// GetByTagOrdered returns the definitions with the given tag sorted by the
+// priority argument of the tag, and by name when priorities are equal.
+func (container DiContainer) GetByTagOrdered(tag string) []di.Def {
+	defs := container.GetByTag(tag)
+	priorities := make(map[string]int, len(defs))
+	for _, def := range defs {
+		for _, t := range def.Tags {
+			if t.Name == tag {
+				priorities[def.Name], _ = strconv.Atoi(t.Args[PriorityArg])
+			}
+		}
+	}
+
+	sort.SliceStable(defs, func(i, j int) bool {
+		pi, pj := priorities[defs[i].Name], priorities[defs[j].Name]
+		if pi != pj {
+			return pi < pj
+		}
+		return defs[i].Name < defs[j].Name
+	})
+	return defs
+}
@@ -0,0 +1,64 @@
+package di
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	extractionsinmemory "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/inmemory"
+	statusdomain "github.com/rubenbupe/recipe-video-parser/internal/status/domain"
+	usersinmemory "github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BuildContainer_TestEnvironment(t *testing.T) {
+	previous := env
+	env = "test"
+	t.Cleanup(func() { env = previous })
+	t.Setenv("AI_APIKEY", "secret")
+
+	container, err := buildContainer()
+	require.NoError(t, err)
+	defer container.Delete()
+
+	assert.IsType(t, &usersinmemory.UserRepository{}, container.Get("users.domain.repository"))
+	assert.IsType(t, &extractionsinmemory.ExtractionRepository{}, container.Get("extractions.domain.repository"))
+}
+
+func Test_GetByTagOrdered(t *testing.T) {
+	container, err := buildContainer()
+	require.NoError(t, err)
+	defer container.Delete()
+
+	defs := DiContainer{Container: container}.GetByTagOrdered("command-middleware")
+
+	var names []string
+	for _, def := range defs {
+		names = append(names, def.Name)
+	}
+	assert.Equal(t, []string{
+		"shared.infrastructure.commandmiddleware.tracing",
+		"shared.infrastructure.commandmiddleware.logging",
+		"shared.infrastructure.commandmiddleware.duration",
+		"shared.infrastructure.commandmiddleware.recovery",
+		"shared.infrastructure.commandmiddleware.timeout",
+		"shared.infrastructure.commandmiddleware.transaction",
+	}, names)
+}
+
+func Test_BuildContainer_YtDlpRequiredBySubscriptions(t *testing.T) {
+	t.Setenv("AI_APIKEY", "secret")
+	for _, enabled := range []bool{true, false} {
+		t.Setenv("SUBSCRIPTIONS_ENABLED", strconv.FormatBool(enabled))
+		container, err := buildContainer()
+		require.NoError(t, err)
+
+		checkers := container.Get("status.infrastructure.checkers").([]statusdomain.Checker)
+		container.Delete()
+
+		index := slices.IndexFunc(checkers, func(checker statusdomain.Checker) bool { return checker.Name() == "yt-dlp" })
+		require.NotEqual(t, -1, index)
+		assert.Equal(t, enabled, checkers[index].Required())
+	}
+}
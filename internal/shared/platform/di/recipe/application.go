@@ -1,30 +1,100 @@
 package recipe
 
 import (
+	"context"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/outbox"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
 	"github.com/rubenbupe/recipe-video-parser/kit/event"
 	"github.com/sarulabs/di/v2"
 
 	usercreate "github.com/rubenbupe/recipe-video-parser/internal/users/application/create"
+	userdelete "github.com/rubenbupe/recipe-video-parser/internal/users/application/delete"
+	userexportdata "github.com/rubenbupe/recipe-video-parser/internal/users/application/exportdata"
 	userget "github.com/rubenbupe/recipe-video-parser/internal/users/application/get"
+	userlist "github.com/rubenbupe/recipe-video-parser/internal/users/application/list"
 	userupdateapikey "github.com/rubenbupe/recipe-video-parser/internal/users/application/updateapikey"
+	userupdatelocale "github.com/rubenbupe/recipe-video-parser/internal/users/application/updatelocale"
+	userupdatequota "github.com/rubenbupe/recipe-video-parser/internal/users/application/updatequota"
+	userupdaterole "github.com/rubenbupe/recipe-video-parser/internal/users/application/updaterole"
+	userupdatestatus "github.com/rubenbupe/recipe-video-parser/internal/users/application/updatestatus"
 	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
 	userssql "github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/sql"
+	userstracing "github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/tracing"
 
+	extractioncleanup "github.com/rubenbupe/recipe-video-parser/internal/recipes/application/cleanup"
 	extractioncreate "github.com/rubenbupe/recipe-video-parser/internal/recipes/application/create"
 	extractionget "github.com/rubenbupe/recipe-video-parser/internal/recipes/application/get"
+	extractionlistprices "github.com/rubenbupe/recipe-video-parser/internal/recipes/application/listprices"
+	extractionsetprice "github.com/rubenbupe/recipe-video-parser/internal/recipes/application/setprice"
+	extractionusage "github.com/rubenbupe/recipe-video-parser/internal/recipes/application/usage"
 	extractionsdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	extractionfiles "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/files"
 	extractionsql "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/sql"
+	extractiontracing "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/tracing"
+
+	webhookcreate "github.com/rubenbupe/recipe-video-parser/internal/webhooks/application/create"
+	webhookdeliver "github.com/rubenbupe/recipe-video-parser/internal/webhooks/application/deliver"
+	webhooklist "github.com/rubenbupe/recipe-video-parser/internal/webhooks/application/list"
+	webhookreplay "github.com/rubenbupe/recipe-video-parser/internal/webhooks/application/replay"
+	webhookretry "github.com/rubenbupe/recipe-video-parser/internal/webhooks/application/retry"
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	webhookssender "github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/sender"
+	webhookssql "github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/storage/sql"
+	webhookstracing "github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/storage/tracing"
+
+	organizationcreate "github.com/rubenbupe/recipe-video-parser/internal/organizations/application/create"
+	organizationdelete "github.com/rubenbupe/recipe-video-parser/internal/organizations/application/delete"
+	organizationget "github.com/rubenbupe/recipe-video-parser/internal/organizations/application/get"
+	organizationlist "github.com/rubenbupe/recipe-video-parser/internal/organizations/application/list"
+	organizationremovemember "github.com/rubenbupe/recipe-video-parser/internal/organizations/application/removemember"
+	organizationsetmember "github.com/rubenbupe/recipe-video-parser/internal/organizations/application/setmember"
+	organizationupdatequota "github.com/rubenbupe/recipe-video-parser/internal/organizations/application/updatequota"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	organizationssql "github.com/rubenbupe/recipe-video-parser/internal/organizations/platform/storage/sql"
+	organizationstracing "github.com/rubenbupe/recipe-video-parser/internal/organizations/platform/storage/tracing"
+	subscriptioncheck "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/application/check"
+	subscriptioncreate "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/application/create"
+	subscriptiondelete "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/application/delete"
+	subscriptionget "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/application/get"
+	subscriptionlist "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/application/list"
+	subscriptionupdate "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/application/update"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	subscriptionssql "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/storage/sql"
+	subscriptionstracing "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/storage/tracing"
+
+	recipesai "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/config"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/gallery"
+	statuscheck "github.com/rubenbupe/recipe-video-parser/internal/status/application/check"
+	statusdomain "github.com/rubenbupe/recipe-video-parser/internal/status/domain"
+	statuschecks "github.com/rubenbupe/recipe-video-parser/internal/status/platform/checks"
 )
 
 var Defs = []di.Def{
+	// EVENTS
+	{
+		Name: "shared.domain.eventregistry",
+		Build: func(ctn di.Container) (interface{}, error) {
+			registry := event.NewRegistry()
+			registry.Register(usersdomain.UserCreatedEventType, usersdomain.DecodeUserCreatedEvent)
+			registry.Register(usersdomain.UserDisabledEventType, usersdomain.DecodeUserDisabledEvent)
+			registry.Register(usersdomain.UserEnabledEventType, usersdomain.DecodeUserEnabledEvent)
+			registry.Register(usersdomain.UserDeletedEventType, usersdomain.DecodeUserDeletedEvent)
+			registry.Register(usersdomain.UserDataExportedEventType, usersdomain.DecodeUserDataExportedEvent)
+			registry.Register(extractionsdomain.ExtractionCreatedEventType, extractionsdomain.DecodeExtractionCreatedEvent)
+			return registry, nil
+		},
+	},
 	// REPOSITORIES
 	{
 		Name: "users.domain.repository",
 		Build: func(ctn di.Container) (interface{}, error) {
 			conn := ctn.Get("shared.infrastructure.sqlconnection").(*storage.Connection)
 			dbconfig := ctn.Get("shared.infrastructure.sqlconfig").(*storage.Dbconfig)
-			return userssql.NewUserRepository(conn, dbconfig), nil
+			outboxStore := ctn.Get("shared.infrastructure.outbox").(*outbox.Store)
+			return userstracing.NewUserRepository(userssql.NewUserRepository(conn, dbconfig, outboxStore)), nil
 		},
 	},
 	{
@@ -32,7 +102,79 @@ var Defs = []di.Def{
 		Build: func(ctn di.Container) (interface{}, error) {
 			conn := ctn.Get("shared.infrastructure.sqlconnection").(*storage.Connection)
 			dbconfig := ctn.Get("shared.infrastructure.sqlconfig").(*storage.Dbconfig)
-			return extractionsql.NewExtractionRepository(conn, dbconfig), nil
+			outboxStore := ctn.Get("shared.infrastructure.outbox").(*outbox.Store)
+			return extractiontracing.NewExtractionRepository(extractionsql.NewExtractionRepository(conn, dbconfig, outboxStore)), nil
+		},
+	},
+	{
+		Name: "extractions.domain.pricerepository",
+		Build: func(ctn di.Container) (interface{}, error) {
+			conn := ctn.Get("shared.infrastructure.sqlconnection").(*storage.Connection)
+			dbconfig := ctn.Get("shared.infrastructure.sqlconfig").(*storage.Dbconfig)
+			return extractiontracing.NewModelPriceRepository(extractionsql.NewModelPriceRepository(conn, dbconfig)), nil
+		},
+	},
+	{
+		Name: "extractions.domain.usagerepository",
+		Build: func(ctn di.Container) (interface{}, error) {
+			conn := ctn.Get("shared.infrastructure.sqlconnection").(*storage.Connection)
+			dbconfig := ctn.Get("shared.infrastructure.sqlconfig").(*storage.Dbconfig)
+			return extractiontracing.NewUsageRepository(extractionsql.NewUsageRepository(conn, dbconfig)), nil
+		},
+	},
+	{
+		Name: "webhooks.domain.repository",
+		Build: func(ctn di.Container) (interface{}, error) {
+			conn := ctn.Get("shared.infrastructure.sqlconnection").(*storage.Connection)
+			dbconfig := ctn.Get("shared.infrastructure.sqlconfig").(*storage.Dbconfig)
+			return webhookstracing.NewWebhookRepository(webhookssql.NewWebhookRepository(conn, dbconfig)), nil
+		},
+	},
+	{
+		Name: "webhooks.domain.deliveryrepository",
+		Build: func(ctn di.Container) (interface{}, error) {
+			conn := ctn.Get("shared.infrastructure.sqlconnection").(*storage.Connection)
+			dbconfig := ctn.Get("shared.infrastructure.sqlconfig").(*storage.Dbconfig)
+			return webhookstracing.NewDeliveryRepository(webhookssql.NewDeliveryRepository(conn, dbconfig)), nil
+		},
+	},
+	{
+		Name: "subscriptions.domain.repository",
+		Build: func(ctn di.Container) (interface{}, error) {
+			conn := ctn.Get("shared.infrastructure.sqlconnection").(*storage.Connection)
+			dbconfig := ctn.Get("shared.infrastructure.sqlconfig").(*storage.Dbconfig)
+			return subscriptionstracing.NewSubscriptionRepository(subscriptionssql.NewSubscriptionRepository(conn, dbconfig)), nil
+		},
+	},
+	{
+		Name: "subscriptions.domain.itemrepository",
+		Build: func(ctn di.Container) (interface{}, error) {
+			conn := ctn.Get("shared.infrastructure.sqlconnection").(*storage.Connection)
+			dbconfig := ctn.Get("shared.infrastructure.sqlconfig").(*storage.Dbconfig)
+			return subscriptionstracing.NewSubscriptionItemRepository(subscriptionssql.NewSubscriptionItemRepository(conn, dbconfig)), nil
+		},
+	},
+	{
+		Name: "organizations.domain.repository",
+		Build: func(ctn di.Container) (interface{}, error) {
+			conn := ctn.Get("shared.infrastructure.sqlconnection").(*storage.Connection)
+			dbconfig := ctn.Get("shared.infrastructure.sqlconfig").(*storage.Dbconfig)
+			return organizationstracing.NewOrganizationRepository(organizationssql.NewOrganizationRepository(conn, dbconfig)), nil
+		},
+	},
+	// WEBHOOK SENDER
+	{
+		Name: "webhooks.infrastructure.senderconfig",
+		Build: func(ctn di.Container) (interface{}, error) {
+			cfg := ctn.Get("shared.infrastructure.config").(*config.Config)
+			return &cfg.Webhook, nil
+		},
+	},
+	{
+		Name: "webhooks.infrastructure.sender",
+		Build: func(ctn di.Container) (interface{}, error) {
+			config := ctn.Get("webhooks.infrastructure.senderconfig").(*webhookssender.Config)
+			return webhookssender.NewHTTPSender(*config), nil
 		},
 	},
 	// USE CASES, COMMAND HANDLERS, AND EVENT HANDLERS
@@ -40,8 +182,7 @@ var Defs = []di.Def{
 		Name: "users.domain.create",
 		Build: func(ctn di.Container) (interface{}, error) {
 			repo := ctn.Get("users.domain.repository").(usersdomain.UserRepository)
-			eventBus := ctn.Get("shared.domain.eventbus").(event.Bus)
-			return usercreate.NewUserService(repo, eventBus), nil
+			return usercreate.NewUserService(repo), nil
 		},
 	},
 	{
@@ -89,13 +230,131 @@ var Defs = []di.Def{
 			{Name: "command-handler"},
 		},
 	},
+	{
+		Name: "users.domain.updatelocale",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("users.domain.repository").(usersdomain.UserRepository)
+			return userupdatelocale.NewUserLocaleService(repo, nil), nil
+		},
+	},
+	{
+		Name: "users.domain.updatelocalecommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("users.domain.updatelocale").(userupdatelocale.UserLocaleService)
+			return userupdatelocale.NewUserLocaleCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+	{
+		Name: "users.domain.list",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("users.domain.repository").(usersdomain.UserRepository)
+			return userlist.NewUsersService(repo), nil
+		},
+	},
+	{
+		Name: "users.domain.listqueryhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("users.domain.list").(userlist.UsersService)
+			return userlist.NewUsersQueryHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "query-handler"},
+		},
+	},
+	{
+		Name: "users.domain.updaterole",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("users.domain.repository").(usersdomain.UserRepository)
+			return userupdaterole.NewUserRoleService(repo), nil
+		},
+	},
+	{
+		Name: "users.domain.updaterolecommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("users.domain.updaterole").(userupdaterole.UserRoleService)
+			return userupdaterole.NewUserRoleCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+	{
+		Name: "users.domain.updatestatus",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("users.domain.repository").(usersdomain.UserRepository)
+			return userupdatestatus.NewUserStatusService(repo), nil
+		},
+	},
+	{
+		Name: "users.domain.updatestatuscommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("users.domain.updatestatus").(userupdatestatus.UserStatusService)
+			return userupdatestatus.NewUserStatusCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+	{
+		Name: "users.domain.updatequota",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("users.domain.repository").(usersdomain.UserRepository)
+			return userupdatequota.NewUserQuotaService(repo), nil
+		},
+	},
+	{
+		Name: "users.domain.updatequotacommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("users.domain.updatequota").(userupdatequota.UserQuotaService)
+			return userupdatequota.NewUserQuotaCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+	{
+		Name: "users.domain.delete",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("users.domain.repository").(usersdomain.UserRepository)
+			return userdelete.NewUserService(repo), nil
+		},
+	},
+	{
+		Name: "users.domain.deletecommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("users.domain.delete").(userdelete.UserService)
+			return userdelete.NewUserCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+	{
+		Name: "users.domain.exportdata",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("users.domain.repository").(usersdomain.UserRepository)
+			return userexportdata.NewUserDataService(repo), nil
+		},
+	},
+	{
+		Name: "users.domain.exportdatacommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("users.domain.exportdata").(userexportdata.UserDataService)
+			return userexportdata.NewUserDataCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
 
 	{
 		Name: "extractions.domain.create",
 		Build: func(ctn di.Container) (interface{}, error) {
 			repo := ctn.Get("extractions.domain.repository").(extractionsdomain.ExtractionRepository)
-			eventBus := ctn.Get("shared.domain.eventbus").(event.Bus)
-			return extractioncreate.NewExtractionService(repo, eventBus), nil
+			return extractioncreate.NewExtractionService(repo), nil
 		},
 	},
 	{
@@ -125,4 +384,458 @@ var Defs = []di.Def{
 			{Name: "query-handler"},
 		},
 	},
+	{
+		Name: "extractions.domain.usage",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("extractions.domain.usagerepository").(extractionsdomain.UsageRepository)
+			return extractionusage.NewReportService(repo), nil
+		},
+	},
+	{
+		Name: "extractions.domain.usagequeryhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("extractions.domain.usage").(extractionusage.ReportService)
+			return extractionusage.NewReportQueryHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "query-handler"},
+		},
+	},
+	{
+		Name: "extractions.domain.setprice",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("extractions.domain.pricerepository").(extractionsdomain.ModelPriceRepository)
+			return extractionsetprice.NewModelPriceService(repo), nil
+		},
+	},
+	{
+		Name: "extractions.domain.setpricecommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("extractions.domain.setprice").(extractionsetprice.ModelPriceService)
+			return extractionsetprice.NewModelPriceCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+	{
+		Name: "extractions.domain.listprices",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("extractions.domain.pricerepository").(extractionsdomain.ModelPriceRepository)
+			return extractionlistprices.NewModelPricesService(repo), nil
+		},
+	},
+	{
+		Name: "extractions.domain.listpricesqueryhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("extractions.domain.listprices").(extractionlistprices.ModelPricesService)
+			return extractionlistprices.NewModelPricesQueryHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "query-handler"},
+		},
+	},
+	{
+		Name: "extractions.infrastructure.filecleaner",
+		Build: func(ctn di.Container) (interface{}, error) {
+			galleryConfig := ctn.Get("shared.infrastructure.galleryconfig").(*gallery.Galleryconfig)
+			aiConfig := ctn.Get("shared.infrastructure.aiconfig").(*ai.Aiconfig)
+			return extractionfiles.NewCleaner(galleryConfig.DownloadDir, *aiConfig), nil
+		},
+	},
+	{
+		Name: "extractions.domain.cleanup",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("extractions.domain.repository").(extractionsdomain.ExtractionRepository)
+			cleaner := ctn.Get("extractions.infrastructure.filecleaner").(extractionsdomain.FileCleaner)
+			return extractioncleanup.NewFilesService(repo, cleaner), nil
+		},
+	},
+	{
+		Name: "extractions.domain.cleanupcommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("extractions.domain.cleanup").(extractioncleanup.FilesService)
+			return extractioncleanup.NewFilesCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+
+	{
+		Name: "webhooks.domain.create",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("webhooks.domain.repository").(webhooksdomain.WebhookRepository)
+			return webhookcreate.NewWebhookService(repo), nil
+		},
+	},
+	{
+		Name: "webhooks.domain.createcommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("webhooks.domain.create").(webhookcreate.WebhookService)
+			return webhookcreate.NewWebhookCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+	{
+		Name: "webhooks.domain.list",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("webhooks.domain.repository").(webhooksdomain.WebhookRepository)
+			return webhooklist.NewWebhooksService(repo), nil
+		},
+	},
+	{
+		Name: "webhooks.domain.listqueryhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("webhooks.domain.list").(webhooklist.WebhooksService)
+			return webhooklist.NewWebhooksQueryHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "query-handler"},
+		},
+	},
+	{
+		Name: "webhooks.domain.deliver",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("webhooks.domain.repository").(webhooksdomain.WebhookRepository)
+			deliveryRepo := ctn.Get("webhooks.domain.deliveryrepository").(webhooksdomain.DeliveryRepository)
+			sender := ctn.Get("webhooks.infrastructure.sender").(webhooksdomain.Sender)
+			config := ctn.Get("webhooks.infrastructure.senderconfig").(*webhookssender.Config)
+			return webhookdeliver.NewDeliveryService(repo, deliveryRepo, sender, config.RetryPolicy()), nil
+		},
+	},
+	{
+		Name: "webhooks.domain.delivereventhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("webhooks.domain.deliver").(webhookdeliver.DeliveryService)
+			return webhookdeliver.NewExtractionCreatedEventHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "event-handler"},
+		},
+	},
+	{
+		Name: "webhooks.domain.replay",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("webhooks.domain.repository").(webhooksdomain.WebhookRepository)
+			deliveryRepo := ctn.Get("webhooks.domain.deliveryrepository").(webhooksdomain.DeliveryRepository)
+			sender := ctn.Get("webhooks.infrastructure.sender").(webhooksdomain.Sender)
+			config := ctn.Get("webhooks.infrastructure.senderconfig").(*webhookssender.Config)
+			return webhookreplay.NewDeliveryService(repo, deliveryRepo, sender, config.RetryPolicy()), nil
+		},
+	},
+	{
+		Name: "webhooks.domain.replaycommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("webhooks.domain.replay").(webhookreplay.DeliveryService)
+			return webhookreplay.NewDeliveryCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+	{
+		Name: "webhooks.domain.retry",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("webhooks.domain.repository").(webhooksdomain.WebhookRepository)
+			deliveryRepo := ctn.Get("webhooks.domain.deliveryrepository").(webhooksdomain.DeliveryRepository)
+			sender := ctn.Get("webhooks.infrastructure.sender").(webhooksdomain.Sender)
+			config := ctn.Get("webhooks.infrastructure.senderconfig").(*webhookssender.Config)
+			return webhookretry.NewDeliveriesService(repo, deliveryRepo, sender, config.RetryPolicy(), config.BatchSize), nil
+		},
+	},
+	{
+		Name: "webhooks.domain.retrycommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("webhooks.domain.retry").(webhookretry.DeliveriesService)
+			return webhookretry.NewDeliveriesCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+
+	{
+		Name: "subscriptions.domain.create",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("subscriptions.domain.repository").(subscriptionsdomain.SubscriptionRepository)
+			return subscriptioncreate.NewSubscriptionService(repo), nil
+		},
+	},
+	{
+		Name: "subscriptions.domain.createcommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("subscriptions.domain.create").(subscriptioncreate.SubscriptionService)
+			return subscriptioncreate.NewSubscriptionCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+	{
+		Name: "subscriptions.domain.list",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("subscriptions.domain.repository").(subscriptionsdomain.SubscriptionRepository)
+			return subscriptionlist.NewSubscriptionsService(repo), nil
+		},
+	},
+	{
+		Name: "subscriptions.domain.listqueryhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("subscriptions.domain.list").(subscriptionlist.SubscriptionsService)
+			return subscriptionlist.NewSubscriptionsQueryHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "query-handler"},
+		},
+	},
+	{
+		Name: "subscriptions.domain.get",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("subscriptions.domain.repository").(subscriptionsdomain.SubscriptionRepository)
+			itemRepo := ctn.Get("subscriptions.domain.itemrepository").(subscriptionsdomain.SubscriptionItemRepository)
+			return subscriptionget.NewSubscriptionService(repo, itemRepo), nil
+		},
+	},
+	{
+		Name: "subscriptions.domain.getqueryhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("subscriptions.domain.get").(subscriptionget.SubscriptionService)
+			return subscriptionget.NewSubscriptionQueryHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "query-handler"},
+		},
+	},
+	{
+		Name: "subscriptions.domain.update",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("subscriptions.domain.repository").(subscriptionsdomain.SubscriptionRepository)
+			return subscriptionupdate.NewSubscriptionService(repo), nil
+		},
+	},
+	{
+		Name: "subscriptions.domain.updatecommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("subscriptions.domain.update").(subscriptionupdate.SubscriptionService)
+			return subscriptionupdate.NewSubscriptionCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+	{
+		Name: "subscriptions.domain.delete",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("subscriptions.domain.repository").(subscriptionsdomain.SubscriptionRepository)
+			return subscriptiondelete.NewSubscriptionService(repo), nil
+		},
+	},
+	{
+		Name: "subscriptions.domain.deletecommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("subscriptions.domain.delete").(subscriptiondelete.SubscriptionService)
+			return subscriptiondelete.NewSubscriptionCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+	{
+		Name: "subscriptions.domain.check",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("subscriptions.domain.repository").(subscriptionsdomain.SubscriptionRepository)
+			itemRepo := ctn.Get("subscriptions.domain.itemrepository").(subscriptionsdomain.SubscriptionItemRepository)
+			lister := ctn.Get("subscriptions.infrastructure.lister").(subscriptionsdomain.Lister)
+			userRepo := ctn.Get("users.domain.repository").(usersdomain.UserRepository)
+			extractor := ctn.Get("subscriptions.infrastructure.extractor").(subscriptionsdomain.Extractor)
+			return subscriptioncheck.NewSubscriptionsService(repo, itemRepo, userRepo, lister, extractor, nil), nil
+		},
+	},
+	{
+		Name: "subscriptions.domain.checkcommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("subscriptions.domain.check").(subscriptioncheck.SubscriptionsService)
+			return subscriptioncheck.NewSubscriptionsCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+
+	{
+		Name: "organizations.domain.create",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("organizations.domain.repository").(organizationsdomain.OrganizationRepository)
+			return organizationcreate.NewOrganizationService(repo), nil
+		},
+	},
+	{
+		Name: "organizations.domain.createcommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("organizations.domain.create").(organizationcreate.OrganizationService)
+			return organizationcreate.NewOrganizationCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+	{
+		Name: "organizations.domain.list",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("organizations.domain.repository").(organizationsdomain.OrganizationRepository)
+			return organizationlist.NewOrganizationsService(repo), nil
+		},
+	},
+	{
+		Name: "organizations.domain.listqueryhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("organizations.domain.list").(organizationlist.OrganizationsService)
+			return organizationlist.NewOrganizationsQueryHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "query-handler"},
+		},
+	},
+	{
+		Name: "organizations.domain.get",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("organizations.domain.repository").(organizationsdomain.OrganizationRepository)
+			userRepo := ctn.Get("users.domain.repository").(usersdomain.UserRepository)
+			return organizationget.NewOrganizationService(repo, userRepo), nil
+		},
+	},
+	{
+		Name: "organizations.domain.getqueryhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("organizations.domain.get").(organizationget.OrganizationService)
+			return organizationget.NewOrganizationQueryHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "query-handler"},
+		},
+	},
+	{
+		Name: "organizations.domain.setmember",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("organizations.domain.repository").(organizationsdomain.OrganizationRepository)
+			return organizationsetmember.NewMemberService(repo), nil
+		},
+	},
+	{
+		Name: "organizations.domain.setmembercommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("organizations.domain.setmember").(organizationsetmember.MemberService)
+			return organizationsetmember.NewMemberCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+	{
+		Name: "organizations.domain.removemember",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("organizations.domain.repository").(organizationsdomain.OrganizationRepository)
+			return organizationremovemember.NewMemberService(repo), nil
+		},
+	},
+	{
+		Name: "organizations.domain.removemembercommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("organizations.domain.removemember").(organizationremovemember.MemberService)
+			return organizationremovemember.NewMemberCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+	{
+		Name: "organizations.domain.updatequota",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("organizations.domain.repository").(organizationsdomain.OrganizationRepository)
+			return organizationupdatequota.NewOrganizationQuotaService(repo), nil
+		},
+	},
+	{
+		Name: "organizations.domain.updatequotacommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("organizations.domain.updatequota").(organizationupdatequota.OrganizationQuotaService)
+			return organizationupdatequota.NewOrganizationQuotaCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+	{
+		Name: "organizations.domain.delete",
+		Build: func(ctn di.Container) (interface{}, error) {
+			repo := ctn.Get("organizations.domain.repository").(organizationsdomain.OrganizationRepository)
+			return organizationdelete.NewOrganizationService(repo), nil
+		},
+	},
+	{
+		Name: "organizations.domain.deletecommandhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("organizations.domain.delete").(organizationdelete.OrganizationService)
+			return organizationdelete.NewOrganizationCommandHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-handler"},
+		},
+	},
+
+	// STATUS
+	{
+		Name: "status.infrastructure.config",
+		Build: func(ctn di.Container) (interface{}, error) {
+			cfg := ctn.Get("shared.infrastructure.config").(*config.Config)
+			return &cfg.Status, nil
+		},
+	},
+	{
+		Name: "status.infrastructure.checkers",
+		Build: func(ctn di.Container) (interface{}, error) {
+			cfg := ctn.Get("shared.infrastructure.config").(*config.Config)
+			config := ctn.Get("status.infrastructure.config").(*statuschecks.Config)
+			conn := ctn.Get("shared.infrastructure.sqlconnection").(*storage.Connection)
+			galleryConfig := ctn.Get("shared.infrastructure.galleryconfig").(*gallery.Galleryconfig)
+			aiConfig := ctn.Get("shared.infrastructure.aiconfig").(*ai.Aiconfig)
+
+			var probe func(ctx context.Context) error
+			if config.ProbeProvider {
+				probe = func(ctx context.Context) error { return recipesai.CheckModel(ctx, *aiConfig) }
+			}
+
+			return []statusdomain.Checker{
+				statuschecks.NewDatabaseChecker(conn),
+				statuschecks.NewBinaryChecker("gallery-dl", true),
+				// El planificador de suscripciones lista los vídeos con yt-dlp
+				statuschecks.NewBinaryChecker("yt-dlp", cfg.Subscriptions.Enabled),
+				statuschecks.NewBinaryChecker("ffmpeg", false, "-version"),
+				statuschecks.NewDownloadDirChecker(galleryConfig.DownloadDir, config.MinFreeMB),
+				statuschecks.NewConfigFileChecker(galleryConfig.ConfigFile),
+				statuschecks.NewAIChecker(*aiConfig, probe),
+			}, nil
+		},
+	},
+	{
+		Name: "status.domain.check",
+		Build: func(ctn di.Container) (interface{}, error) {
+			config := ctn.Get("status.infrastructure.config").(*statuschecks.Config)
+			checkers := ctn.Get("status.infrastructure.checkers").([]statusdomain.Checker)
+			return statuscheck.NewStatusService(checkers, config.Timeout), nil
+		},
+	},
+	{
+		Name: "status.domain.checkqueryhandler",
+		Build: func(ctn di.Container) (interface{}, error) {
+			service := ctn.Get("status.domain.check").(statuscheck.StatusService)
+			return statuscheck.NewStatusQueryHandler(service), nil
+		},
+		Tags: []di.Tag{
+			{Name: "query-handler"},
+		},
+	},
 }
@@ -1,14 +1,80 @@
 package shared
 
 import (
-	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/ai"
+	"context"
+	"log/slog"
+
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/bus/inmemory"
-	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/gallery"
+	busmiddleware "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/bus/middleware"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/config"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/logger"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/metrics"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/outbox"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/rubenbupe/recipe-video-parser/kit/event"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+	"github.com/rubenbupe/recipe-video-parser/kit/uow"
 	"github.com/sarulabs/di/v2"
 )
 
 var Defs = []di.Def{
+	// CONFIG
+	{
+		Name: "shared.infrastructure.config",
+		Build: func(ctn di.Container) (interface{}, error) {
+			return config.Load(config.Flags)
+		},
+	},
+	{
+		Name: "shared.infrastructure.serverconfig",
+		Build: func(ctn di.Container) (interface{}, error) {
+			cfg := ctn.Get("shared.infrastructure.config").(*config.Config)
+			return &cfg.App, nil
+		},
+	},
+
+	// LOGGER
+	{
+		Name: "shared.infrastructure.loggerconfig",
+		Build: func(ctn di.Container) (interface{}, error) {
+			cfg := ctn.Get("shared.infrastructure.config").(*config.Config)
+			return &cfg.Log, nil
+		},
+	},
+	{
+		Name: "shared.infrastructure.logger",
+		Build: func(ctn di.Container) (interface{}, error) {
+			cfg := ctn.Get("shared.infrastructure.loggerconfig").(*logger.Config)
+			return logger.Configure(*cfg)
+		},
+	},
+
+	// METRICS
+	{
+		Name: "shared.infrastructure.metrics",
+		Build: func(ctn di.Container) (interface{}, error) {
+			return metrics.NewRegistry(), nil
+		},
+	},
+
+	// TRACING
+	{
+		Name: "shared.infrastructure.tracingconfig",
+		Build: func(ctn di.Container) (interface{}, error) {
+			cfg := ctn.Get("shared.infrastructure.config").(*config.Config)
+			return &cfg.Tracing, nil
+		},
+	},
+	{
+		Name: "shared.infrastructure.tracing",
+		Build: func(ctn di.Container) (interface{}, error) {
+			cfg := ctn.Get("shared.infrastructure.tracingconfig").(*tracing.Config)
+			return tracing.Configure(context.Background(), *cfg)
+		},
+	},
+
 	// BUSES
 	{
 		Name: "shared.domain.commandbus",
@@ -22,10 +88,148 @@ var Defs = []di.Def{
 			return inmemory.NewQueryBus(), nil
 		},
 	},
+	{
+		Name: "shared.infrastructure.eventbusconfig",
+		Build: func(ctn di.Container) (interface{}, error) {
+			cfg := ctn.Get("shared.infrastructure.config").(*config.Config)
+			return &cfg.EventBus, nil
+		},
+	},
 	{
 		Name: "shared.domain.eventbus",
 		Build: func(ctn di.Container) (interface{}, error) {
-			return inmemory.NewEventBus(), nil
+			cfg := ctn.Get("shared.infrastructure.eventbusconfig").(*inmemory.EventBusConfig)
+			registry := ctn.Get("shared.infrastructure.metrics").(*metrics.Registry)
+			eventBus := inmemory.NewEventBus(*cfg)
+			registry.NewGaugeFunc("eventbus_queue_depth", "Event deliveries waiting for a worker.", func() float64 {
+				return float64(eventBus.QueueLength())
+			})
+			return eventBus, nil
+		},
+	},
+
+	// BUS MIDDLEWARES (outermost first, by priority)
+	{
+		Name: "shared.infrastructure.busconfig",
+		Build: func(ctn di.Container) (interface{}, error) {
+			cfg := ctn.Get("shared.infrastructure.config").(*config.Config)
+			return &cfg.Bus, nil
+		},
+	},
+	{
+		Name: "shared.infrastructure.busdurations",
+		Build: func(ctn di.Container) (interface{}, error) {
+			registry := ctn.Get("shared.infrastructure.metrics").(*metrics.Registry)
+			return busmiddleware.NewDurationHistogram(registry), nil
+		},
+	},
+	{
+		Name: "shared.infrastructure.commandmiddleware.tracing",
+		Build: func(ctn di.Container) (interface{}, error) {
+			return command.Middleware(busmiddleware.CommandTracing()), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-middleware", Args: map[string]string{"priority": "5"}},
+		},
+	},
+	{
+		Name: "shared.infrastructure.commandmiddleware.logging",
+		Build: func(ctn di.Container) (interface{}, error) {
+			log := ctn.Get("shared.infrastructure.logger").(*slog.Logger)
+			return command.Middleware(busmiddleware.CommandLogging(log)), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-middleware", Args: map[string]string{"priority": "10"}},
+		},
+	},
+	{
+		Name: "shared.infrastructure.commandmiddleware.duration",
+		Build: func(ctn di.Container) (interface{}, error) {
+			recorder := ctn.Get("shared.infrastructure.busdurations").(busmiddleware.DurationRecorder)
+			return command.Middleware(busmiddleware.CommandDuration(recorder)), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-middleware", Args: map[string]string{"priority": "20"}},
+		},
+	},
+	{
+		Name: "shared.infrastructure.commandmiddleware.recovery",
+		Build: func(ctn di.Container) (interface{}, error) {
+			log := ctn.Get("shared.infrastructure.logger").(*slog.Logger)
+			return command.Middleware(busmiddleware.CommandRecovery(log)), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-middleware", Args: map[string]string{"priority": "30"}},
+		},
+	},
+	{
+		Name: "shared.infrastructure.commandmiddleware.timeout",
+		Build: func(ctn di.Container) (interface{}, error) {
+			cfg := ctn.Get("shared.infrastructure.busconfig").(*busmiddleware.Config)
+			return command.Middleware(busmiddleware.CommandTimeout(*cfg)), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-middleware", Args: map[string]string{"priority": "40"}},
+		},
+	},
+	{
+		Name: "shared.infrastructure.querymiddleware.tracing",
+		Build: func(ctn di.Container) (interface{}, error) {
+			return query.Middleware(busmiddleware.QueryTracing()), nil
+		},
+		Tags: []di.Tag{
+			{Name: "query-middleware", Args: map[string]string{"priority": "5"}},
+		},
+	},
+	{
+		Name: "shared.infrastructure.querymiddleware.logging",
+		Build: func(ctn di.Container) (interface{}, error) {
+			log := ctn.Get("shared.infrastructure.logger").(*slog.Logger)
+			return query.Middleware(busmiddleware.QueryLogging(log)), nil
+		},
+		Tags: []di.Tag{
+			{Name: "query-middleware", Args: map[string]string{"priority": "10"}},
+		},
+	},
+	{
+		Name: "shared.infrastructure.querymiddleware.duration",
+		Build: func(ctn di.Container) (interface{}, error) {
+			recorder := ctn.Get("shared.infrastructure.busdurations").(busmiddleware.DurationRecorder)
+			return query.Middleware(busmiddleware.QueryDuration(recorder)), nil
+		},
+		Tags: []di.Tag{
+			{Name: "query-middleware", Args: map[string]string{"priority": "20"}},
+		},
+	},
+	{
+		Name: "shared.infrastructure.querymiddleware.recovery",
+		Build: func(ctn di.Container) (interface{}, error) {
+			log := ctn.Get("shared.infrastructure.logger").(*slog.Logger)
+			return query.Middleware(busmiddleware.QueryRecovery(log)), nil
+		},
+		Tags: []di.Tag{
+			{Name: "query-middleware", Args: map[string]string{"priority": "30"}},
+		},
+	},
+	{
+		Name: "shared.infrastructure.querymiddleware.timeout",
+		Build: func(ctn di.Container) (interface{}, error) {
+			cfg := ctn.Get("shared.infrastructure.busconfig").(*busmiddleware.Config)
+			return query.Middleware(busmiddleware.QueryTimeout(*cfg)), nil
+		},
+		Tags: []di.Tag{
+			{Name: "query-middleware", Args: map[string]string{"priority": "40"}},
+		},
+	},
+
+	{
+		Name: "shared.infrastructure.commandmiddleware.transaction",
+		Build: func(ctn di.Container) (interface{}, error) {
+			unitOfWork := ctn.Get("shared.infrastructure.unitofwork").(uow.UnitOfWork)
+			return command.Middleware(busmiddleware.CommandTransaction(unitOfWork)), nil
+		},
+		Tags: []di.Tag{
+			{Name: "command-middleware", Args: map[string]string{"priority": "50"}},
 		},
 	},
 
@@ -33,7 +237,8 @@ var Defs = []di.Def{
 	{
 		Name: "shared.infrastructure.sqlconfig",
 		Build: func(ctn di.Container) (interface{}, error) {
-			return storage.CreateConfig()
+			cfg := ctn.Get("shared.infrastructure.config").(*config.Config)
+			return &cfg.DB, nil
 		},
 	},
 	{
@@ -43,12 +248,47 @@ var Defs = []di.Def{
 			return storage.CreateConnection("shared", cfg)
 		},
 	},
+	{
+		Name: "shared.infrastructure.unitofwork",
+		Build: func(ctn di.Container) (interface{}, error) {
+			conn := ctn.Get("shared.infrastructure.sqlconnection").(*storage.Connection)
+			return storage.NewUnitOfWork(conn), nil
+		},
+	},
+
+	// OUTBOX
+	{
+		Name: "shared.infrastructure.outboxconfig",
+		Build: func(ctn di.Container) (interface{}, error) {
+			cfg := ctn.Get("shared.infrastructure.config").(*config.Config)
+			return &cfg.Outbox, nil
+		},
+	},
+	{
+		Name: "shared.infrastructure.outbox",
+		Build: func(ctn di.Container) (interface{}, error) {
+			conn := ctn.Get("shared.infrastructure.sqlconnection").(*storage.Connection)
+			dbconfig := ctn.Get("shared.infrastructure.sqlconfig").(*storage.Dbconfig)
+			registry := ctn.Get("shared.domain.eventregistry").(*event.Registry)
+			return outbox.NewStore(conn, dbconfig, registry), nil
+		},
+	},
+	{
+		Name: "shared.infrastructure.outboxrelay",
+		Build: func(ctn di.Container) (interface{}, error) {
+			store := ctn.Get("shared.infrastructure.outbox").(*outbox.Store)
+			eventBus := ctn.Get("shared.domain.eventbus").(event.Bus)
+			cfg := ctn.Get("shared.infrastructure.outboxconfig").(*outbox.Config)
+			return outbox.NewRelay(store, eventBus, *cfg), nil
+		},
+	},
 
 	// GALLERY
 	{
 		Name: "shared.infrastructure.galleryconfig",
 		Build: func(ctn di.Container) (interface{}, error) {
-			return gallery.CreateConfig()
+			cfg := ctn.Get("shared.infrastructure.config").(*config.Config)
+			return &cfg.Gallery, nil
 		},
 	},
 
@@ -56,7 +296,8 @@ var Defs = []di.Def{
 	{
 		Name: "shared.infrastructure.aiconfig",
 		Build: func(ctn di.Container) (interface{}, error) {
-			return ai.CreateConfig()
+			cfg := ctn.Get("shared.infrastructure.config").(*config.Config)
+			return &cfg.AI, nil
 		},
 	},
 }
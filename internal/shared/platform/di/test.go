@@ -0,0 +1,28 @@
+package di
+
+import (
+	"github.com/rubenbupe/recipe-video-parser/kit/event"
+	di "github.com/sarulabs/di/v2"
+
+	extractionsinmemory "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/inmemory"
+	usersinmemory "github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/inmemory"
+)
+
+// testDefs replace the SQL repositories with in-memory ones, which publish
+// their events straight to the event bus instead of the outbox.
+var testDefs = []di.Def{
+	{
+		Name: "users.domain.repository",
+		Build: func(ctn di.Container) (interface{}, error) {
+			eventBus := ctn.Get("shared.domain.eventbus").(event.Bus)
+			return usersinmemory.NewUserRepository(eventBus), nil
+		},
+	},
+	{
+		Name: "extractions.domain.repository",
+		Build: func(ctn di.Container) (interface{}, error) {
+			eventBus := ctn.Get("shared.domain.eventbus").(event.Bus)
+			return extractionsinmemory.NewExtractionRepository(eventBus), nil
+		},
+	},
+}
@@ -1,20 +1,18 @@
 package gallery
 
 import (
-	"github.com/kelseyhightower/envconfig"
+	"errors"
 )
 
-func CreateConfig() (*Galleryconfig, error) {
-	var cfg Galleryconfig
-	err := envconfig.Process("GALLERY", &cfg)
-	if err != nil {
-		return nil, err
-	}
-
-	return &cfg, nil
-}
-
 type Galleryconfig struct {
 	DownloadDir string `default:"./tmp"`
 	ConfigFile  string ``
 }
+
+// Validate checks the values of the config.
+func (c Galleryconfig) Validate() error {
+	if c.DownloadDir == "" {
+		return errors.New("missing download directory")
+	}
+	return nil
+}
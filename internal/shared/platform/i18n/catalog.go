@@ -0,0 +1,110 @@
+package i18n
+
+// Message keys used when rendering recipes for humans (Markdown, exports...).
+const (
+	MsgDescription     = "recipe.description"
+	MsgServings        = "recipe.servings"
+	MsgDifficulty      = "recipe.difficulty"
+	MsgPrepTime        = "recipe.prep_time"
+	MsgCookTime        = "recipe.cook_time"
+	MsgTotalTime       = "recipe.total_time"
+	MsgMinutes         = "recipe.minutes"
+	MsgIngredients     = "recipe.ingredients"
+	MsgOptional        = "recipe.optional"
+	MsgInstructions    = "recipe.instructions"
+	MsgSection         = "recipe.section"
+	MsgOptionalStep    = "recipe.optional_step"
+	MsgNotes           = "recipe.notes"
+	MsgNutritionalInfo = "recipe.nutritional_info"
+	MsgCalories        = "recipe.calories"
+	MsgProtein         = "recipe.protein"
+	MsgCarbohydrates   = "recipe.carbohydrates"
+	MsgFats            = "recipe.fats"
+	MsgFiber           = "recipe.fiber"
+	MsgSugar           = "recipe.sugar"
+	MsgOriginalRecipe  = "recipe.original"
+)
+
+var catalogs = map[string]map[string]string{
+	"es-ES": {
+		MsgDescription:     "Descripción",
+		MsgServings:        "Porciones",
+		MsgDifficulty:      "Dificultad",
+		MsgPrepTime:        "Tiempo de preparación",
+		MsgCookTime:        "Tiempo de cocción",
+		MsgTotalTime:       "Tiempo total",
+		MsgMinutes:         "min",
+		MsgIngredients:     "Ingredientes",
+		MsgOptional:        "Opcional",
+		MsgInstructions:    "Instrucciones",
+		MsgSection:         "Sección",
+		MsgOptionalStep:    "opcional",
+		MsgNotes:           "Notas",
+		MsgNutritionalInfo: "Información nutricional (por cada 100g)",
+		MsgCalories:        "Calorías",
+		MsgProtein:         "Proteínas",
+		MsgCarbohydrates:   "Carbohidratos",
+		MsgFats:            "Grasas",
+		MsgFiber:           "Fibra",
+		MsgSugar:           "Azúcares",
+		MsgOriginalRecipe:  "Ver receta original",
+	},
+	"en-US": {
+		MsgDescription:     "Description",
+		MsgServings:        "Servings",
+		MsgDifficulty:      "Difficulty",
+		MsgPrepTime:        "Prep time",
+		MsgCookTime:        "Cook time",
+		MsgTotalTime:       "Total time",
+		MsgMinutes:         "min",
+		MsgIngredients:     "Ingredients",
+		MsgOptional:        "Optional",
+		MsgInstructions:    "Instructions",
+		MsgSection:         "Section",
+		MsgOptionalStep:    "optional",
+		MsgNotes:           "Notes",
+		MsgNutritionalInfo: "Nutrition facts (per 100g)",
+		MsgCalories:        "Calories",
+		MsgProtein:         "Protein",
+		MsgCarbohydrates:   "Carbohydrates",
+		MsgFats:            "Fat",
+		MsgFiber:           "Fiber",
+		MsgSugar:           "Sugar",
+		MsgOriginalRecipe:  "View original recipe",
+	},
+	"en-GB": {
+		MsgDescription:     "Description",
+		MsgServings:        "Serves",
+		MsgDifficulty:      "Difficulty",
+		MsgPrepTime:        "Preparation time",
+		MsgCookTime:        "Cooking time",
+		MsgTotalTime:       "Total time",
+		MsgMinutes:         "mins",
+		MsgIngredients:     "Ingredients",
+		MsgOptional:        "Optional",
+		MsgInstructions:    "Method",
+		MsgSection:         "Part",
+		MsgOptionalStep:    "optional",
+		MsgNotes:           "Notes",
+		MsgNutritionalInfo: "Nutritional information (per 100g)",
+		MsgCalories:        "Energy",
+		MsgProtein:         "Protein",
+		MsgCarbohydrates:   "Carbohydrate",
+		MsgFats:            "Fat",
+		MsgFiber:           "Fibre",
+		MsgSugar:           "Sugars",
+		MsgOriginalRecipe:  "View original recipe",
+	},
+}
+
+// T returns the message for the given key in the locale, falling back to the
+// default locale and finally to the key itself when it is not translated.
+func (l Locale) T(key string) string {
+	if msg, ok := catalogs[l.Tag][key]; ok {
+		return msg
+	}
+	if msg, ok := catalogs[DefaultLocaleTag][key]; ok {
+		return msg
+	}
+	return key
+}
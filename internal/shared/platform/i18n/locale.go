@@ -0,0 +1,99 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var ErrUnsupportedLocale = errors.New("unsupported locale")
+
+// DefaultLocaleTag is the locale used when neither the request nor the user choose one.
+const DefaultLocaleTag = "es-ES"
+
+// Locale describes the language and regional style used to write a recipe.
+type Locale struct {
+	// Tag is the BCP 47 tag of the locale (e.g. es-ES, en-GB).
+	Tag string
+	// Language is the base language, used to pick the prompt template.
+	Language string
+	// Name is how the locale is referred to inside the prompt.
+	Name string
+	// Units describes the measurement system the recipe must use.
+	Units string
+	// UnitSymbols lists example unit symbols for the ingredients.
+	UnitSymbols string
+}
+
+var locales = map[string]Locale{
+	"es-ES": {
+		Tag:         "es-ES",
+		Language:    "es",
+		Name:        "español de España",
+		Units:       "unidades de medida del sistema métrico (gramos, mililitros, etc.) y grados Celsius",
+		UnitSymbols: "g, ml, l, kg, ud, cdta, cda, etc.",
+	},
+	"en-US": {
+		Tag:         "en-US",
+		Language:    "en",
+		Name:        "American English",
+		Units:       "US customary units (cups, tablespoons, teaspoons, ounces, pounds, fluid ounces) and degrees Fahrenheit",
+		UnitSymbols: "cup, tbsp, tsp, oz, lb, fl oz, pc, etc.",
+	},
+	"en-GB": {
+		Tag:         "en-GB",
+		Language:    "en",
+		Name:        "British English",
+		Units:       "metric units (grams, millilitres, etc.) and degrees Celsius",
+		UnitSymbols: "g, ml, l, kg, pc, tsp, tbsp, etc.",
+	},
+}
+
+// ParseLocale returns the supported locale matching the given tag. Tags are
+// matched case-insensitively and accept "_" as separator (es_es, EN-gb...).
+func ParseLocale(tag string) (Locale, error) {
+	normalized := normalizeTag(tag)
+	locale, ok := locales[normalized]
+	if !ok {
+		return Locale{}, fmt.Errorf("%w: %s (supported: %s)", ErrUnsupportedLocale, tag, strings.Join(SupportedLocales(), ", "))
+	}
+
+	return locale, nil
+}
+
+// ResolveLocale returns the first non-empty tag parsed as a locale, falling
+// back to the default locale when all of them are empty.
+func ResolveLocale(tags ...string) (Locale, error) {
+	for _, tag := range tags {
+		if strings.TrimSpace(tag) != "" {
+			return ParseLocale(tag)
+		}
+	}
+
+	return DefaultLocale(), nil
+}
+
+// DefaultLocale returns the locale used when none is specified.
+func DefaultLocale() Locale {
+	return locales[DefaultLocaleTag]
+}
+
+// SupportedLocales returns the sorted list of supported locale tags.
+func SupportedLocales() []string {
+	tags := make([]string, 0, len(locales))
+	for tag := range locales {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+func normalizeTag(tag string) string {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	parts := strings.SplitN(tag, "-", 2)
+	if len(parts) == 1 {
+		return strings.ToLower(parts[0])
+	}
+	return strings.ToLower(parts[0]) + "-" + strings.ToUpper(parts[1])
+}
@@ -0,0 +1,63 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseLocale_Supported(t *testing.T) {
+	tests := map[string]struct {
+		tag      string
+		want     string
+		language string
+	}{
+		"es-ES":           {tag: "es-ES", want: "es-ES", language: "es"},
+		"en-US":           {tag: "en-US", want: "en-US", language: "en"},
+		"en-GB":           {tag: "en-GB", want: "en-GB", language: "en"},
+		"lowercase":       {tag: "en-gb", want: "en-GB", language: "en"},
+		"underscore":      {tag: "es_es", want: "es-ES", language: "es"},
+		"surrounding gap": {tag: " en-US ", want: "en-US", language: "en"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			locale, err := ParseLocale(tt.tag)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, locale.Tag)
+			assert.Equal(t, tt.language, locale.Language)
+		})
+	}
+}
+
+func Test_ParseLocale_Unsupported(t *testing.T) {
+	_, err := ParseLocale("fr-FR")
+
+	assert.ErrorIs(t, err, ErrUnsupportedLocale)
+}
+
+func Test_ResolveLocale(t *testing.T) {
+	locale, err := ResolveLocale("", "en-GB", "en-US")
+	require.NoError(t, err)
+	assert.Equal(t, "en-GB", locale.Tag)
+
+	locale, err = ResolveLocale("", "")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultLocaleTag, locale.Tag)
+
+	_, err = ResolveLocale("xx-XX", "en-GB")
+	assert.ErrorIs(t, err, ErrUnsupportedLocale)
+}
+
+func Test_Locale_T(t *testing.T) {
+	es, _ := ParseLocale("es-ES")
+	us, _ := ParseLocale("en-US")
+	gb, _ := ParseLocale("en-GB")
+
+	assert.Equal(t, "Ingredientes", es.T(MsgIngredients))
+	assert.Equal(t, "Ingredients", us.T(MsgIngredients))
+	assert.Equal(t, "Fiber", us.T(MsgFiber))
+	assert.Equal(t, "Fibre", gb.T(MsgFiber))
+	assert.Equal(t, "unknown.key", gb.T("unknown.key"))
+}
@@ -0,0 +1,16 @@
+package logger
+
+import "io"
+
+type Config struct {
+	// Level is the minimum level logged: debug, info, warn or error.
+	Level string `default:"info"`
+	// Format is the format of the records: text or json.
+	Format string `default:"text"`
+}
+
+// Validate checks the values of the config.
+func (c Config) Validate() error {
+	_, err := New(c, io.Discard)
+	return err
+}
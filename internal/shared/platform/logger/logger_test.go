@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_New(t *testing.T) {
+	t.Run("it logs JSON records with the request ID of the context", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := New(Config{Level: "info", Format: "json"}, &buf)
+		require.NoError(t, err)
+
+		ctx := WithRequestID(context.Background(), "req-1")
+		logger.With("component", "test").InfoContext(ctx, "something happened")
+
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		assert.Equal(t, "something happened", record["msg"])
+		assert.Equal(t, "req-1", record[RequestIDKey])
+		assert.Equal(t, "test", record["component"])
+	})
+
+	t.Run("it discards the records below the level", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := New(Config{Level: "warn", Format: "text"}, &buf)
+		require.NoError(t, err)
+
+		logger.Info("ignored")
+		logger.Warn("logged")
+
+		assert.NotContains(t, buf.String(), "ignored")
+		assert.Contains(t, buf.String(), "logged")
+	})
+
+	t.Run("it fails with an unknown level or format", func(t *testing.T) {
+		_, err := New(Config{Level: "verbose", Format: "text"}, &bytes.Buffer{})
+		assert.Error(t, err)
+
+		_, err = New(Config{Level: "info", Format: "xml"}, &bytes.Buffer{})
+		assert.Error(t, err)
+	})
+}
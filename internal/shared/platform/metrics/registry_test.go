@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, registry *Registry) string {
+	t.Helper()
+
+	srv := httptest.NewServer(registry.Handler())
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, ContentType, res.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func Test_Registry_Counter(t *testing.T) {
+	registry := NewRegistry()
+	counter := registry.NewCounter("jobs_total", "Jobs processed.", "queue", "status")
+
+	counter.Inc("default", "ok")
+	counter.Add(2, "default", "ok")
+	counter.Inc("default", "failed")
+	counter.Add(-1, "default", "failed")
+
+	assert.Equal(t, `# HELP jobs_total Jobs processed.
+# TYPE jobs_total counter
+jobs_total{queue="default",status="failed"} 1
+jobs_total{queue="default",status="ok"} 3
+`, scrape(t, registry))
+}
+
+func Test_Registry_Gauge(t *testing.T) {
+	registry := NewRegistry()
+	gauge := registry.NewGauge("in_flight", "Jobs in flight.")
+	registry.NewGaugeFunc("queue_depth", "Pending jobs.", func() float64 { return 7 })
+
+	gauge.Inc()
+	gauge.Inc()
+	gauge.Dec()
+	gauge.Add(0.5)
+
+	assert.Equal(t, `# HELP in_flight Jobs in flight.
+# TYPE in_flight gauge
+in_flight 1.5
+# HELP queue_depth Pending jobs.
+# TYPE queue_depth gauge
+queue_depth 7
+`, scrape(t, registry))
+}
+
+func Test_Registry_Histogram(t *testing.T) {
+	registry := NewRegistry()
+	histogram := registry.NewHistogram("job_duration_seconds", "Job duration.", []float64{0.1, 1}, "queue")
+
+	histogram.Observe(0.05, "default")
+	histogram.Observe(0.5, "default")
+	histogram.Observe(3, "default")
+
+	assert.Equal(t, `# HELP job_duration_seconds Job duration.
+# TYPE job_duration_seconds histogram
+job_duration_seconds_bucket{queue="default",le="0.1"} 1
+job_duration_seconds_bucket{queue="default",le="1"} 2
+job_duration_seconds_bucket{queue="default",le="+Inf"} 3
+job_duration_seconds_sum{queue="default"} 3.55
+job_duration_seconds_count{queue="default"} 3
+`, scrape(t, registry))
+}
+
+func Test_Registry_EscapesHelpAndLabelValues(t *testing.T) {
+	registry := NewRegistry()
+	counter := registry.NewCounter("errors_total", "Errors\nby \\ message.", "message")
+
+	counter.Inc("say \"hi\"\n")
+
+	assert.Equal(t, `# HELP errors_total Errors\nby \\ message.
+# TYPE errors_total counter
+errors_total{message="say \"hi\"\n"} 1
+`, scrape(t, registry))
+}
+
+func Test_Registry_PanicsOnDuplicateMetric(t *testing.T) {
+	registry := NewRegistry()
+	registry.NewCounter("jobs_total", "Jobs processed.")
+
+	assert.Panics(t, func() {
+		registry.NewGauge("jobs_total", "Jobs processed.")
+	})
+}
+
+func Test_Registry_PanicsOnWrongLabelCount(t *testing.T) {
+	registry := NewRegistry()
+	counter := registry.NewCounter("jobs_total", "Jobs processed.", "queue")
+
+	assert.Panics(t, func() {
+		counter.Inc()
+	})
+}
+
+func Test_Registry_ConcurrentUpdates(t *testing.T) {
+	registry := NewRegistry()
+	counter := registry.NewCounter("jobs_total", "Jobs processed.")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			counter.Inc()
+			registry.WriteTo(io.Discard)
+		}()
+	}
+	wg.Wait()
+
+	assert.Contains(t, scrape(t, registry), "jobs_total 50\n")
+}
@@ -0,0 +1,28 @@
+package outbox
+
+import (
+	"errors"
+	"time"
+)
+
+type Config struct {
+	// PollInterval is the wait between two reads of the pending events.
+	PollInterval time.Duration `default:"1s"`
+	// BatchSize is the maximum number of events published on every read.
+	BatchSize int `default:"100"`
+	// MaxAttempts is the number of failed publications after which an event
+	// is no longer relayed.
+	MaxAttempts int `default:"10"`
+}
+
+// Validate checks the values of the config.
+func (c Config) Validate() error {
+	var errs []error
+	if c.PollInterval <= 0 {
+		errs = append(errs, errors.New("poll interval must be positive"))
+	}
+	if c.BatchSize < 1 || c.MaxAttempts < 1 {
+		errs = append(errs, errors.New("batch size and max attempts must be at least 1"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,212 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/bus/inmemory"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	"github.com/rubenbupe/recipe-video-parser/kit/event"
+	"github.com/rubenbupe/recipe-video-parser/kit/event/eventmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testEventType event.Type = "events.test.happened"
+
+type testEvent struct {
+	event.BaseEvent
+	Name string `json:"name"`
+}
+
+func (e testEvent) Type() event.Type {
+	return testEventType
+}
+
+func decodeTestEvent(base event.BaseEvent, payload []byte) (event.Event, error) {
+	evt := testEvent{BaseEvent: base}
+	if err := json.Unmarshal(payload, &evt); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+func newTestStore(t *testing.T) (*Store, *storage.Connection) {
+	t.Helper()
+	ctx := context.Background()
+	conn, err := storage.CreateConnection("test-outbox-"+t.Name(), &storage.Dbconfig{Database: filepath.Join(t.TempDir(), "app")})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Db.Close() })
+	require.NoError(t, storage.CreateSchema(ctx, conn))
+
+	registry := event.NewRegistry()
+	registry.Register(testEventType, decodeTestEvent)
+
+	return NewStore(conn, &storage.Dbconfig{Timeout: time.Second}, registry), conn
+}
+
+func addEvents(t *testing.T, store *Store, conn *storage.Connection, events ...event.Event) {
+	t.Helper()
+	ctx := context.Background()
+	tx, err := conn.Db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.Add(ctx, tx, events))
+	require.NoError(t, tx.Commit())
+}
+
+func Test_Store_Add_RolledBack(t *testing.T) {
+	ctx := context.Background()
+	store, conn := newTestStore(t)
+
+	tx, err := conn.Db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.Add(ctx, tx, []event.Event{testEvent{BaseEvent: event.NewBaseEvent("aggregate"), Name: "lost"}}))
+	require.NoError(t, tx.Rollback())
+
+	pending, err := store.Pending(ctx, 10, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func Test_Store_Add_UnknownType(t *testing.T) {
+	ctx := context.Background()
+	store, conn := newTestStore(t)
+
+	err := store.Add(ctx, conn.Db, []event.Event{unregisteredEvent{event.NewBaseEvent("aggregate")}})
+
+	assert.ErrorIs(t, err, event.ErrUnknownType)
+}
+
+func Test_Relay_Flush_PublishesAndMarksDelivered(t *testing.T) {
+	ctx := context.Background()
+	store, conn := newTestStore(t)
+	original := testEvent{BaseEvent: event.NewBaseEvent("aggregate"), Name: "first"}
+	addEvents(t, store, conn, original)
+
+	var published []event.Event
+	bus := new(eventmocks.Bus)
+	bus.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		published = append(published, args.Get(1).([]event.Event)...)
+	}).Return(nil)
+
+	relay := NewRelay(store, bus, Config{BatchSize: 10, MaxAttempts: 3})
+
+	delivered, err := relay.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+
+	require.Len(t, published, 1)
+	evt, ok := published[0].(testEvent)
+	require.True(t, ok)
+	assert.Equal(t, original.ID(), evt.ID())
+	assert.Equal(t, original.AggregateID(), evt.AggregateID())
+	assert.True(t, original.OccurredOn().Equal(evt.OccurredOn()))
+	assert.Equal(t, "first", evt.Name)
+
+	delivered, err = relay.Flush(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, delivered, "delivered events are not published again")
+	bus.AssertNumberOfCalls(t, "Publish", 1)
+}
+
+func Test_Relay_Flush_KeepsFailedEvents(t *testing.T) {
+	ctx := context.Background()
+	store, conn := newTestStore(t)
+	addEvents(t, store, conn, testEvent{BaseEvent: event.NewBaseEvent("aggregate"), Name: "retried"})
+
+	bus := new(eventmocks.Bus)
+	bus.On("Publish", mock.Anything, mock.Anything).Return(errors.New("bus closed"))
+
+	relay := NewRelay(store, bus, Config{BatchSize: 10, MaxAttempts: 2})
+
+	for i := 0; i < 3; i++ {
+		delivered, err := relay.Flush(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, delivered)
+	}
+	bus.AssertNumberOfCalls(t, "Publish", 2)
+
+	var attempts int
+	var lastError string
+	row := conn.Db.QueryRowContext(ctx, "SELECT attempts, last_error FROM event_outbox")
+	require.NoError(t, row.Scan(&attempts, &lastError))
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, "bus closed", lastError)
+}
+
+func Test_Relay_Flush_WaitsForTheHandlers(t *testing.T) {
+	ctx := context.Background()
+	store, conn := newTestStore(t)
+	addEvents(t, store, conn, testEvent{BaseEvent: event.NewBaseEvent("aggregate"), Name: "slow"})
+
+	bus := inmemory.NewEventBus(inmemory.EventBusConfig{Workers: 1})
+	started, release := make(chan struct{}), make(chan struct{})
+	bus.Subscribe(testEventType, handlerFunc(func(context.Context, event.Event) error {
+		close(started)
+		<-release
+		return nil
+	}))
+	relay := NewRelay(store, bus, Config{BatchSize: 10, MaxAttempts: 3})
+
+	flushed := make(chan int)
+	go func() {
+		delivered, err := relay.Flush(ctx)
+		assert.NoError(t, err)
+		flushed <- delivered
+	}()
+
+	<-started
+	pending, err := store.Pending(ctx, 10, 3)
+	require.NoError(t, err)
+	assert.Len(t, pending, 1, "the event is not delivered while its handler runs")
+
+	close(release)
+	assert.Equal(t, 1, <-flushed)
+	pending, err = store.Pending(ctx, 10, 3)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+	require.NoError(t, bus.Shutdown(ctx))
+}
+
+func Test_Relay_Flush_UndecodableEvent(t *testing.T) {
+	ctx := context.Background()
+	store, conn := newTestStore(t)
+	_, err := conn.Db.ExecContext(ctx, "INSERT INTO event_outbox (id, type, aggregate_id, payload, occurred_on) VALUES ('1', 'events.unknown', 'aggregate', '{}', ?)", time.Now().UTC())
+	require.NoError(t, err)
+
+	bus := new(eventmocks.Bus)
+	relay := NewRelay(store, bus, Config{BatchSize: 10, MaxAttempts: 3})
+
+	delivered, err := relay.Flush(ctx)
+
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+	bus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+
+	var lastError string
+	require.NoError(t, conn.Db.QueryRowContext(ctx, "SELECT last_error FROM event_outbox").Scan(&lastError))
+	assert.Contains(t, lastError, event.ErrUnknownType.Error())
+}
+
+type unregisteredEvent struct {
+	event.BaseEvent
+}
+
+func (e unregisteredEvent) Type() event.Type {
+	return "events.test.unregistered"
+}
+
+type handlerFunc func(context.Context, event.Event) error
+
+func (f handlerFunc) Handle(ctx context.Context, evt event.Event) error {
+	return f(ctx, evt)
+}
+
+func (f handlerFunc) SubscribedTo() event.Type {
+	return testEventType
+}
@@ -0,0 +1,88 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/event"
+)
+
+// Relay publishes the events written to the outbox to the event bus and
+// marks them as delivered.
+type Relay struct {
+	store  *Store
+	bus    event.Bus
+	config Config
+}
+
+// waitingBus is implemented by the buses that can wait for the handlers of the
+// published events, like the in-memory one.
+type waitingBus interface {
+	PublishAndWait(ctx context.Context, events []event.Event) error
+}
+
+func NewRelay(store *Store, bus event.Bus, config Config) *Relay {
+	return &Relay{
+		store:  store,
+		bus:    bus,
+		config: config,
+	}
+}
+
+// Run relays the pending events every poll interval until the context is
+// cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.Flush(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "outbox relay failed", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush publishes one batch of pending events and returns how many of them
+// were delivered. When the bus supports it, an event is only marked as
+// delivered once its handlers have finished, so an event interrupted by a
+// shutdown is published again. Events that can not be decoded or published
+// are kept in the outbox with their attempt count increased.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	messages, err := r.store.Pending(ctx, r.config.BatchSize, r.config.MaxAttempts)
+	if err != nil {
+		return 0, err
+	}
+
+	publish := r.bus.Publish
+	if bus, ok := r.bus.(waitingBus); ok {
+		publish = bus.PublishAndWait
+	}
+
+	delivered := 0
+	for _, msg := range messages {
+		evt, err := r.store.Decode(msg)
+		if err == nil {
+			err = publish(ctx, []event.Event{evt})
+		}
+		if err != nil {
+			if err := r.store.MarkFailed(ctx, msg.ID, err); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+
+		if err := r.store.MarkDelivered(ctx, msg.ID); err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+
+	return delivered, nil
+}
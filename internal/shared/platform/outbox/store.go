@@ -0,0 +1,138 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	"github.com/rubenbupe/recipe-video-parser/kit/event"
+)
+
+const sqlOutboxTable = "event_outbox"
+
+// Execer is satisfied by *sql.DB and *sql.Tx, so events can be written in
+// the same transaction as the aggregate that recorded them.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Message is an event stored in the outbox.
+type Message struct {
+	ID          string
+	Type        event.Type
+	AggregateID string
+	Payload     []byte
+	OccurredOn  time.Time
+	Attempts    int
+}
+
+type Store struct {
+	connection *storage.Connection
+	dbconfig   *storage.Dbconfig
+	registry   *event.Registry
+}
+
+func NewStore(connection *storage.Connection, dbconfig *storage.Dbconfig, registry *event.Registry) *Store {
+	return &Store{
+		connection: connection,
+		dbconfig:   dbconfig,
+		registry:   registry,
+	}
+}
+
+// Add writes the events to the outbox using the given transaction.
+func (s *Store) Add(ctx context.Context, tx Execer, events []event.Event) error {
+	for _, evt := range events {
+		payload, err := s.registry.Marshal(evt)
+		if err != nil {
+			return fmt.Errorf("error trying to serialise event %s: %w", evt.Type(), err)
+		}
+
+		ib := sqlbuilder.InsertInto(sqlOutboxTable)
+		ib.Cols("id", "type", "aggregate_id", "payload", "occurred_on")
+		ib.Values(evt.ID(), string(evt.Type()), evt.AggregateID(), string(payload), evt.OccurredOn().UTC())
+		ib.SetFlavor(sqlbuilder.SQLite)
+		query, args := ib.Build()
+
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("error trying to persist event on outbox: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// Pending returns the oldest events not delivered yet that have failed
+// fewer than maxAttempts times.
+func (s *Store) Pending(ctx context.Context, limit, maxAttempts int) ([]Message, error) {
+	sb := sqlbuilder.Select("id", "type", "aggregate_id", "payload", "occurred_on", "attempts").From(sqlOutboxTable)
+	sb.Where(sb.IsNull("delivered_at"), sb.LessThan("attempts", maxAttempts))
+	sb.OrderBy("occurred_on").Asc()
+	sb.Limit(limit)
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, s.dbconfig.Timeout)
+	defer cancel()
+
+	rows, err := s.connection.Db.QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to get pending events from outbox: %v", err)
+	}
+	defer rows.Close()
+
+	var messages []Message
+	for rows.Next() {
+		var msg Message
+		var payload string
+		if err := rows.Scan(&msg.ID, &msg.Type, &msg.AggregateID, &payload, &msg.OccurredOn, &msg.Attempts); err != nil {
+			return nil, fmt.Errorf("error trying to read pending event from outbox: %v", err)
+		}
+		msg.Payload = []byte(payload)
+		messages = append(messages, msg)
+	}
+
+	return messages, rows.Err()
+}
+
+// Decode rebuilds the event stored in the message.
+func (s *Store) Decode(msg Message) (event.Event, error) {
+	base := event.RestoreBaseEvent(msg.ID, msg.AggregateID, msg.OccurredOn)
+	return s.registry.Unmarshal(msg.Type, base, msg.Payload)
+}
+
+// MarkDelivered records that the event was published.
+func (s *Store) MarkDelivered(ctx context.Context, id string) error {
+	ub := sqlbuilder.Update(sqlOutboxTable)
+	ub.Set(ub.Assign("delivered_at", time.Now().UTC()))
+	ub.Where(ub.Equal("id", id))
+	ub.SetFlavor(sqlbuilder.SQLite)
+	query, args := ub.Build()
+
+	return s.exec(ctx, query, args, "mark event as delivered")
+}
+
+// MarkFailed records a failed attempt to publish the event.
+func (s *Store) MarkFailed(ctx context.Context, id string, cause error) error {
+	ub := sqlbuilder.Update(sqlOutboxTable)
+	ub.Set(ub.Incr("attempts"), ub.Assign("last_error", cause.Error()))
+	ub.Where(ub.Equal("id", id))
+	ub.SetFlavor(sqlbuilder.SQLite)
+	query, args := ub.Build()
+
+	return s.exec(ctx, query, args, "mark event as failed")
+}
+
+func (s *Store) exec(ctx context.Context, query string, args []interface{}, action string) error {
+	ctxTimeout, cancel := context.WithTimeout(ctx, s.dbconfig.Timeout)
+	defer cancel()
+
+	if _, err := s.connection.Db.ExecContext(ctxTimeout, query, args...); err != nil {
+		return fmt.Errorf("error trying to %s on outbox: %v", action, err)
+	}
+
+	return nil
+}
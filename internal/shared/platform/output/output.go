@@ -0,0 +1,121 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the format in which the CLI prints the result of a command.
+type Format string
+
+const (
+	FormatTable    Format = "table"
+	FormatJSON     Format = "json"
+	FormatYAML     Format = "yaml"
+	FormatMarkdown Format = "markdown"
+	FormatCSV      Format = "csv"
+)
+
+// Formats are the supported formats, in the order they are documented.
+var Formats = []Format{FormatTable, FormatJSON, FormatYAML, FormatMarkdown, FormatCSV}
+
+// ParseFormat returns the format with the given name.
+func ParseFormat(name string) (Format, error) {
+	format := Format(strings.ToLower(name))
+	if !slices.Contains(Formats, format) {
+		return "", fmt.Errorf("unsupported output format %q (supported: %s)", name, FormatNames())
+	}
+	return format, nil
+}
+
+// FormatNames returns the names of the supported formats separated by "|".
+func FormatNames() string {
+	names := make([]string, 0, len(Formats))
+	for _, format := range Formats {
+		names = append(names, string(format))
+	}
+	return strings.Join(names, "|")
+}
+
+// Machine reports whether the format is meant to be parsed by scripts.
+func (f Format) Machine() bool {
+	return f == FormatJSON || f == FormatYAML
+}
+
+// Table is a table of a result printed as a table, as markdown or as CSV.
+type Table struct {
+	// Title is printed above the table, when it is not empty.
+	Title   string
+	Headers []string
+	Rows    [][]string
+}
+
+// Tabler is implemented by the results that choose how they are printed as
+// tables. Any other struct, or slice of structs, is printed as a single table
+// of its fields.
+type Tabler interface {
+	Tables() []Table
+}
+
+// Write prints v in the format. JSON and YAML use the json tags of v, so
+// both have the same keys; tables, markdown and CSV use its Tables.
+func Write(w io.Writer, format Format, v interface{}) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	case FormatYAML:
+		return writeYAML(w, v)
+	case FormatTable:
+		return writeTables(w, tablesOf(v))
+	case FormatMarkdown:
+		return writeMarkdown(w, tablesOf(v))
+	case FormatCSV:
+		return writeCSV(w, tablesOf(v))
+	default:
+		return fmt.Errorf("unsupported output format %q (supported: %s)", format, FormatNames())
+	}
+}
+
+// writeYAML prints v as YAML with the keys and the order of its JSON
+// encoding, which is also a YAML document.
+func writeYAML(w io.Writer, v interface{}) error {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return err
+	}
+	plainStyle(&document)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// plainStyle removes the JSON quotes and brackets, so the encoder chooses
+// the usual YAML style.
+func plainStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		plainStyle(child)
+	}
+}
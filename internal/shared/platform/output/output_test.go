@@ -0,0 +1,125 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type webhook struct {
+	ID     string   `json:"id"`
+	Url    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+type user struct {
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Version  string            `json:"version"`
+	Labels   map[string]string `json:"labels"`
+	Limits   limits            `json:"limits"`
+	Webhooks []webhook         `json:"webhooks"`
+	Internal string            `json:"-"`
+}
+
+type limits struct {
+	Daily int `json:"daily"`
+}
+
+var example = user{
+	ID:      "1",
+	Name:    "ana",
+	Version: "0003",
+	Labels:  map[string]string{"team": "recipes", "env": "prod"},
+	Limits:  limits{Daily: 10},
+	Webhooks: []webhook{
+		{ID: "w1", Url: "https://example.com/hook", Events: []string{"extraction.succeeded", "extraction.failed"}},
+	},
+	Internal: "hidden",
+}
+
+func Test_ParseFormat(t *testing.T) {
+	format, err := ParseFormat("JSON")
+	require.NoError(t, err)
+	assert.Equal(t, FormatJSON, format)
+
+	_, err = ParseFormat("xml")
+	assert.ErrorContains(t, err, "supported: table|json|yaml|markdown|csv")
+}
+
+func Test_Write_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatJSON, example))
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "ana", decoded["name"])
+	assert.NotContains(t, decoded, "Internal")
+}
+
+func Test_Write_YAML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatYAML, example))
+
+	assert.Contains(t, buf.String(), "id: \"1\"\nname: ana\nversion: \"0003\"\n")
+	var decoded user
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "0003", decoded.Version)
+}
+
+func Test_Write_Table(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatTable, example))
+
+	assert.Equal(t, `FIELD         VALUE
+id            1
+name          ana
+version       0003
+labels        env=prod, team=recipes
+limits.daily  10
+
+webhooks:
+ID  URL                       EVENTS
+w1  https://example.com/hook  extraction.succeeded, extraction.failed
+`, buf.String())
+}
+
+func Test_Write_TableOfSlice(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatTable, []*webhook{{ID: "w1", Url: "https://a"}, {ID: "w2", Url: "https://b"}}))
+
+	assert.Equal(t, "ID  URL        EVENTS\nw1  https://a  \nw2  https://b  \n", buf.String())
+}
+
+func Test_Write_Markdown(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatMarkdown, tables{}))
+	assert.Empty(t, buf.String())
+
+	buf.Reset()
+	require.NoError(t, Write(&buf, FormatMarkdown, tables{{Title: "Steps", Headers: []string{"#", "text"}, Rows: [][]string{{"1", "mix a|b"}}}}))
+
+	assert.Equal(t, "### Steps\n\n| # | text |\n| --- | --- |\n| 1 | mix a\\|b |\n", buf.String())
+}
+
+func Test_Write_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatCSV, []webhook{{ID: "w1", Url: "https://a", Events: []string{"a", "b"}}}))
+
+	assert.Equal(t, "id,url,events\nw1,https://a,\"a, b\"\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, Write(&buf, FormatCSV, tables{{Title: "One", Headers: []string{"a"}, Rows: [][]string{{"1"}}}, {Headers: []string{"b"}}}))
+
+	assert.Equal(t, "a\n1\n\nb\n", buf.String())
+}
+
+type tables []Table
+
+func (t tables) Tables() []Table {
+	return t
+}
@@ -0,0 +1,262 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// tablesOf returns the tables of v: its own when it is a Tabler, a row per
+// item for slices, and a row per field for structs, with a table more for
+// every field that is a slice of structs.
+func tablesOf(v interface{}) []Table {
+	if tabler, ok := v.(Tabler); ok {
+		return tabler.Tables()
+	}
+
+	value := indirect(reflect.ValueOf(v))
+	if !value.IsValid() {
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		return []Table{sliceTable("", value)}
+	case reflect.Struct:
+		fields := Table{Headers: []string{"field", "value"}}
+		var tables []Table
+		for _, field := range fieldsOf(value.Type()) {
+			fieldValue := indirect(value.FieldByIndex(field.index))
+			if isStructSlice(fieldValue) {
+				tables = append(tables, sliceTable(field.name, fieldValue))
+				continue
+			}
+			fields.Rows = append(fields.Rows, keyValueRows(field.name, fieldValue)...)
+		}
+		return append([]Table{fields}, tables...)
+	default:
+		return []Table{{Headers: []string{"value"}, Rows: [][]string{{cell(value)}}}}
+	}
+}
+
+// sliceTable returns a table with a row per item of the slice.
+func sliceTable(title string, value reflect.Value) Table {
+	table := Table{Title: title}
+	elemType := value.Type().Elem()
+	for elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+
+	if elemType.Kind() != reflect.Struct {
+		table.Headers = []string{"value"}
+		for i := 0; i < value.Len(); i++ {
+			table.Rows = append(table.Rows, []string{cell(value.Index(i))})
+		}
+		return table
+	}
+
+	fields := fieldsOf(elemType)
+	for _, field := range fields {
+		table.Headers = append(table.Headers, field.name)
+	}
+	for i := 0; i < value.Len(); i++ {
+		item := indirect(value.Index(i))
+		row := make([]string, 0, len(fields))
+		for _, field := range fields {
+			if !item.IsValid() {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, cell(item.FieldByIndex(field.index)))
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+// keyValueRows returns the rows of a field of a struct, flattening the
+// nested structs as parent.child.
+func keyValueRows(name string, value reflect.Value) [][]string {
+	if value.Kind() != reflect.Struct {
+		return [][]string{{name, cell(value)}}
+	}
+	var rows [][]string
+	for _, field := range fieldsOf(value.Type()) {
+		rows = append(rows, keyValueRows(name+"."+field.name, indirect(value.FieldByIndex(field.index)))...)
+	}
+	return rows
+}
+
+type field struct {
+	name  string
+	index []int
+}
+
+// fieldsOf returns the exported fields of a struct named after their json
+// tags, without the ones that are not encoded.
+func fieldsOf(structType reflect.Type) []field {
+	var fields []field
+	for _, f := range reflect.VisibleFields(structType) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, field{name: name, index: f.Index})
+	}
+	return fields
+}
+
+func isStructSlice(value reflect.Value) bool {
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return false
+	}
+	elemType := value.Type().Elem()
+	for elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	return elemType.Kind() == reflect.Struct
+}
+
+func indirect(value reflect.Value) reflect.Value {
+	for value.IsValid() && (value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+// cell formats a value for a table: lists are separated by commas and maps
+// are written as sorted key=value pairs.
+func cell(value reflect.Value) string {
+	value = indirect(value)
+	if !value.IsValid() {
+		return ""
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprint(value.Interface())
+		}
+		items := make([]string, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			items = append(items, cell(value.Index(i)))
+		}
+		return strings.Join(items, ", ")
+	case reflect.Map:
+		pairs := make([]string, 0, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			pairs = append(pairs, fmt.Sprintf("%v=%s", iter.Key().Interface(), cell(iter.Value())))
+		}
+		slices.Sort(pairs)
+		return strings.Join(pairs, ", ")
+	}
+	if stringer, ok := value.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// writeTables prints the tables aligned in columns, with the headers in
+// upper case.
+func writeTables(w io.Writer, tables []Table) error {
+	for i, table := range tables {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if table.Title != "" {
+			if _, err := fmt.Fprintf(w, "%s:\n", table.Title); err != nil {
+				return err
+			}
+		}
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		if len(table.Headers) > 0 {
+			fmt.Fprintln(tw, strings.ToUpper(strings.Join(table.Headers, "\t")))
+		}
+		for _, row := range table.Rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMarkdown prints the tables as GitHub flavoured markdown.
+func writeMarkdown(w io.Writer, tables []Table) error {
+	escape := strings.NewReplacer("|", `\|`, "\n", "<br>")
+	for i, table := range tables {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if table.Title != "" {
+			if _, err := fmt.Fprintf(w, "### %s\n\n", table.Title); err != nil {
+				return err
+			}
+		}
+
+		headers := table.Headers
+		if len(headers) == 0 && len(table.Rows) > 0 {
+			headers = make([]string, len(table.Rows[0]))
+		}
+		separators := make([]string, len(headers))
+		for j := range separators {
+			separators[j] = "---"
+		}
+		if _, err := fmt.Fprintf(w, "| %s |\n| %s |\n", strings.Join(headers, " | "), strings.Join(separators, " | ")); err != nil {
+			return err
+		}
+		for _, row := range table.Rows {
+			cells := make([]string, len(row))
+			for j, value := range row {
+				cells[j] = escape.Replace(value)
+			}
+			if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | ")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeCSV prints the tables as CSV with their headers as the first record,
+// separated by an empty line. The titles are not printed.
+func writeCSV(w io.Writer, tables []Table) error {
+	for i, table := range tables {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+
+		writer := csv.NewWriter(w)
+		if len(table.Headers) > 0 {
+			if err := writer.Write(table.Headers); err != nil {
+				return err
+			}
+		}
+		if err := writer.WriteAll(table.Rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
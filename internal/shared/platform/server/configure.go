@@ -1,6 +1,8 @@
 package server
 
 import (
+	"context"
+
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/di"
 	"github.com/rubenbupe/recipe-video-parser/kit/command"
 	"github.com/rubenbupe/recipe-video-parser/kit/event"
@@ -16,6 +18,12 @@ func ConfigureCommandBus() {
 		handler := diContainer.Container.Get(handlerDef).(command.Handler)
 		commandBus.Register(handler.SubscribedTo(), handler)
 	}
+
+	commandMiddlewares := diContainer.GetByTagOrdered("command-middleware")
+	for _, middlewareDef := range commandMiddlewares {
+		middleware := diContainer.Container.Get(middlewareDef).(command.Middleware)
+		commandBus.Use(middleware)
+	}
 }
 
 func ConfigureQueryBus() {
@@ -27,6 +35,12 @@ func ConfigureQueryBus() {
 		handler := diContainer.Container.Get(handlerDef).(query.Handler)
 		queryBus.Register(handler.SubscribedTo(), handler)
 	}
+
+	queryMiddlewares := diContainer.GetByTagOrdered("query-middleware")
+	for _, middlewareDef := range queryMiddlewares {
+		middleware := diContainer.Container.Get(middlewareDef).(query.Middleware)
+		queryBus.Use(middleware)
+	}
 }
 
 func ConfigureEventBus() {
@@ -39,3 +53,13 @@ func ConfigureEventBus() {
 		eventBus.Subscribe(handler.SubscribedTo(), handler)
 	}
 }
+
+// ShutdownEventBus waits for the events pending delivery, if the event bus
+// delivers them asynchronously.
+func ShutdownEventBus(ctx context.Context) error {
+	eventBus := di.Instance().Container.Get("shared.domain.eventbus").(event.Bus)
+	if bus, ok := eventBus.(interface{ Shutdown(context.Context) error }); ok {
+		return bus.Shutdown(ctx)
+	}
+	return nil
+}
@@ -5,12 +5,18 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
 	"github.com/rubenbupe/recipe-video-parser/internal/users/domain"
-	"github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/sql"
 )
 
+// OrganizationHeader selecciona la organización activa de la petición por su
+// ID o por su nombre.
+const OrganizationHeader = "X-Organization"
+
 // AuthMiddleware extrae el token Bearer del header Authorization, busca el usuario por API key y lo añade al contexto.
-func AuthMiddleware(userRepo sql.UserRepository) gin.HandlerFunc {
+// Si la petición trae el header X-Organization, añade también la organización
+// activa, que tiene que existir y tener al usuario como miembro.
+func AuthMiddleware(userRepo domain.UserRepository, organizationRepo organizationsdomain.OrganizationRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		header := c.GetHeader("Authorization")
 		if header == "" {
@@ -34,8 +40,61 @@ func AuthMiddleware(userRepo sql.UserRepository) gin.HandlerFunc {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or missing user for token"})
 			return
 		}
+		if user.IsDisabled() {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "User disabled"})
+			return
+		}
 		// Guardar el usuario en el contexto para los handlers siguientes
 		c.Set("user", user)
+
+		if selector := c.GetHeader(OrganizationHeader); selector != "" {
+			organization, err := findOrganization(c, organizationRepo, selector)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			// Las organizaciones de las que el usuario no es miembro no se
+			// distinguen de las que no existen
+			if organization == nil {
+				c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+				return
+			}
+			if _, ok := organization.Member(user.Id.String()); !ok {
+				c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+				return
+			}
+			c.Set("organization", organization)
+		}
+		c.Next()
+	}
+}
+
+// findOrganization busca la organización por ID si el selector es un UUID y
+// por nombre en otro caso. Devuelve nil si no existe.
+func findOrganization(c *gin.Context, organizationRepo organizationsdomain.OrganizationRepository, selector string) (*organizationsdomain.Organization, error) {
+	if id, err := organizationsdomain.NewOrganizationID(selector); err == nil {
+		return organizationRepo.Get(c.Request.Context(), id)
+	}
+	name, err := organizationsdomain.NewOrganizationName(selector)
+	if err != nil {
+		return nil, nil
+	}
+	return organizationRepo.GetByName(c.Request.Context(), name)
+}
+
+// AdminMiddleware rechaza a los usuarios que no son administradores. Va
+// después de AuthMiddleware.
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := GetUserFromContext(c)
+		if !ok || user == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+			return
+		}
+		if !user.IsAdmin() {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Admin role required"})
+			return
+		}
 		c.Next()
 	}
 }
@@ -49,3 +108,29 @@ func GetUserFromContext(c *gin.Context) (*domain.User, bool) {
 	usr, ok := user.(*domain.User)
 	return usr, ok
 }
+
+// GetOrganizationFromContext obtiene la organización activa del contexto Gin.
+// Devuelve false si la petición no ha seleccionado ninguna.
+func GetOrganizationFromContext(c *gin.Context) (*organizationsdomain.Organization, bool) {
+	organization, exists := c.Get("organization")
+	if !exists {
+		return nil, false
+	}
+	org, ok := organization.(*organizationsdomain.Organization)
+	return org, ok
+}
+
+// GetMemberFromContext obtiene la organización activa y la membresía del
+// usuario autenticado en ella.
+func GetMemberFromContext(c *gin.Context) (*organizationsdomain.Organization, organizationsdomain.Member, bool) {
+	organization, ok := GetOrganizationFromContext(c)
+	if !ok || organization == nil {
+		return nil, organizationsdomain.Member{}, false
+	}
+	user, ok := GetUserFromContext(c)
+	if !ok || user == nil {
+		return nil, organizationsdomain.Member{}, false
+	}
+	member, ok := organization.Member(user.Id.String())
+	return organization, member, ok
+}
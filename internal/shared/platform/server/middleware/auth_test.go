@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/organizations/platform/storage/storagemocks"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuthRouter(t *testing.T, users ...domain.User) *gin.Engine {
+	t.Helper()
+	return newOrganizationRouter(t, new(storagemocks.OrganizationRepository), users...)
+}
+
+func newOrganizationRouter(t *testing.T, organizationRepo organizationsdomain.OrganizationRepository, users ...domain.User) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	repo := inmemory.NewUserRepository(nil)
+	for _, user := range users {
+		require.NoError(t, repo.Save(context.Background(), user))
+	}
+
+	r := gin.New()
+	r.GET("/recipes", AuthMiddleware(repo, organizationRepo), func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/admin", AuthMiddleware(repo, organizationRepo), AdminMiddleware(), func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/organization", AuthMiddleware(repo, organizationRepo), func(c *gin.Context) {
+		organization, ok := GetOrganizationFromContext(c)
+		if !ok {
+			c.Status(http.StatusNoContent)
+			return
+		}
+		c.String(http.StatusOK, organization.Name.String())
+	})
+	return r
+}
+
+func newUser(t *testing.T, name, apiKey string) domain.User {
+	t.Helper()
+	user, err := domain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", name, apiKey, "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+	return user
+}
+
+func serve(r *gin.Engine, path, apiKey string) int {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	t.Run("it rejects unknown api keys", func(t *testing.T) {
+		r := newAuthRouter(t)
+
+		assert.Equal(t, http.StatusUnauthorized, serve(r, "/recipes", "unknown"))
+	})
+
+	t.Run("it rejects disabled users", func(t *testing.T) {
+		user := newUser(t, "ana", "apikey")
+		require.NoError(t, user.SetStatus(domain.UserStatusDisabled))
+		r := newAuthRouter(t, user)
+
+		assert.Equal(t, http.StatusForbidden, serve(r, "/recipes", "apikey"))
+	})
+
+	t.Run("it lets active users through", func(t *testing.T) {
+		r := newAuthRouter(t, newUser(t, "ana", "apikey"))
+
+		assert.Equal(t, http.StatusOK, serve(r, "/recipes", "apikey"))
+	})
+}
+
+func serveOrganization(r *gin.Engine, apiKey, organization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/organization", nil)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set(OrganizationHeader, organization)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAuthMiddleware_Organization(t *testing.T) {
+	organization, err := organizationsdomain.NewOrganization("5d1c7a9e-3f2b-4e8d-a6c0-9b7e1f3d5a2c", "casa", "37a0f027-15e6-47cc-a5d2-64183281087e", "2024-05-01T10:00:00Z")
+	require.NoError(t, err)
+
+	t.Run("it works without an active organization", func(t *testing.T) {
+		r := newOrganizationRouter(t, new(storagemocks.OrganizationRepository), newUser(t, "ana", "apikey"))
+
+		assert.Equal(t, http.StatusNoContent, serve(r, "/organization", "apikey"))
+	})
+
+	t.Run("it selects the organization by name", func(t *testing.T) {
+		organizationRepo := new(storagemocks.OrganizationRepository)
+		organizationRepo.On("GetByName", mock.Anything, organization.Name).Return(&organization, nil)
+		r := newOrganizationRouter(t, organizationRepo, newUser(t, "ana", "apikey"))
+
+		rec := serveOrganization(r, "apikey", "casa")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "casa", rec.Body.String())
+	})
+
+	t.Run("it selects the organization by id", func(t *testing.T) {
+		organizationRepo := new(storagemocks.OrganizationRepository)
+		organizationRepo.On("Get", mock.Anything, organization.Id).Return(&organization, nil)
+		r := newOrganizationRouter(t, organizationRepo, newUser(t, "ana", "apikey"))
+
+		rec := serveOrganization(r, "apikey", organization.Id.String())
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "casa", rec.Body.String())
+	})
+
+	t.Run("it rejects unknown organizations", func(t *testing.T) {
+		organizationRepo := new(storagemocks.OrganizationRepository)
+		organizationRepo.On("GetByName", mock.Anything, mock.Anything).Return(nil, nil)
+		r := newOrganizationRouter(t, organizationRepo, newUser(t, "ana", "apikey"))
+
+		assert.Equal(t, http.StatusNotFound, serveOrganization(r, "apikey", "nadie").Code)
+	})
+
+	t.Run("it rejects organizations the user is not a member of", func(t *testing.T) {
+		other, err := domain.NewUser("4a0c8d2e-6b1f-4c3a-9e5d-7f2b1a0c9d8e", "luis", "otherkey", "2023-01-01T00:00:00Z")
+		require.NoError(t, err)
+		organizationRepo := new(storagemocks.OrganizationRepository)
+		organizationRepo.On("GetByName", mock.Anything, organization.Name).Return(&organization, nil)
+		r := newOrganizationRouter(t, organizationRepo, other)
+
+		assert.Equal(t, http.StatusNotFound, serveOrganization(r, "otherkey", "casa").Code)
+	})
+}
+
+func TestAdminMiddleware(t *testing.T) {
+	t.Run("it rejects users without the admin role", func(t *testing.T) {
+		r := newAuthRouter(t, newUser(t, "ana", "apikey"))
+
+		assert.Equal(t, http.StatusForbidden, serve(r, "/admin", "apikey"))
+	})
+
+	t.Run("it lets admins through", func(t *testing.T) {
+		admin := newUser(t, "ana", "apikey")
+		require.NoError(t, admin.SetRole(domain.UserRoleAdmin))
+		r := newAuthRouter(t, admin)
+
+		assert.Equal(t, http.StatusOK, serve(r, "/admin", "apikey"))
+	})
+}
@@ -7,7 +7,7 @@ func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type,Authorization,Origin,Accept,X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type,Authorization,Origin,Accept,X-Requested-With,X-Organization")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET,POST,PUT,DELETE,OPTIONS")
 
 		if c.Request.Method == "OPTIONS" {
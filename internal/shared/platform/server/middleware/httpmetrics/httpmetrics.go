@@ -0,0 +1,37 @@
+package httpmetrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/metrics"
+)
+
+// unmatchedRoute labels the requests that do not match any route, so that
+// unknown paths do not create a series each.
+const unmatchedRoute = "unmatched"
+
+// Middleware is a gin.HandlerFunc that counts the requests and records their
+// latency by method, route and status code.
+func Middleware(registry *metrics.Registry) gin.HandlerFunc {
+	requests := registry.NewCounter("http_requests_total",
+		"HTTP requests by method, route and status code.", "method", "route", "status")
+	durations := registry.NewHistogram("http_request_duration_seconds",
+		"Latency of the HTTP requests by method, route and status code.", nil, "method", "route", "status")
+
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		requests.Inc(c.Request.Method, route, status)
+		durations.Observe(time.Since(start).Seconds(), c.Request.Method, route, status)
+	}
+}
@@ -0,0 +1,46 @@
+package httpmetrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+
+	registry := metrics.NewRegistry()
+	engine.Use(Middleware(registry))
+	engine.GET("/recipes/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	engine.GET("/metrics", gin.WrapH(registry.Handler()))
+
+	for _, path := range []string{"/recipes/1", "/recipes/2", "/unknown"} {
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		require.NoError(t, err)
+		engine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+	res, err := http.Get(srv.URL + "/metrics")
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	var body strings.Builder
+	_, err = io.Copy(&body, res.Body)
+	require.NoError(t, err)
+
+	assert.Contains(t, body.String(), `http_requests_total{method="GET",route="/recipes/:id",status="200"} 2`)
+	assert.Contains(t, body.String(), `http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, body.String(), `http_request_duration_seconds_count{method="GET",route="/recipes/:id",status="200"} 2`)
+}
@@ -0,0 +1,42 @@
+package httptracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing/tracingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestMiddleware(t *testing.T) {
+	exporter := tracingtest.Record(t)
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.ContextWithFallback = true
+	engine.Use(Middleware())
+	engine.GET("/recipes/:id", func(c *gin.Context) {
+		_, span := tracing.Start(c, "handler")
+		span.End()
+		c.Status(http.StatusInternalServerError)
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/recipes/1", nil)
+	require.NoError(t, err)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+
+	span := tracingtest.Span(t, exporter, "GET /recipes/:id")
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", span.Parent.SpanID().String())
+	assert.Equal(t, int64(500), tracingtest.Attributes(span)["http.response.status_code"].AsInt64())
+	assert.Equal(t, codes.Error, span.Status.Code)
+
+	handler := tracingtest.Span(t, exporter, "handler")
+	assert.Equal(t, span.SpanContext.SpanID(), handler.Parent.SpanID())
+}
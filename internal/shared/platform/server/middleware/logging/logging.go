@@ -1,7 +1,8 @@
 package logging
 
 import (
-	"fmt"
+	"log/slog"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -9,7 +10,7 @@ import (
 
 // Middleware is a gin.HandlerFunc that logs some information
 // of the incoming request and the consequent response.
-func Middleware() gin.HandlerFunc {
+func Middleware(logger *slog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Start timer
 		start := time.Now()
@@ -24,19 +25,22 @@ func Middleware() gin.HandlerFunc {
 		c.Next()
 
 		// Results
-		timestamp := time.Now()
-		latency := timestamp.Sub(start)
-		clientIP := c.ClientIP()
-		method := c.Request.Method
 		statusCode := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case statusCode >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case statusCode >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
 
-		fmt.Printf("[HTTP] %v | %3d | %13v | %15s | %-7s %#v\n",
-			timestamp.Format("2006/01/02 - 15:04:05"),
-			statusCode,
-			latency,
-			clientIP,
-			method,
-			path,
+		logger.LogAttrs(c.Request.Context(), level, "http request",
+			slog.String("method", c.Request.Method),
+			slog.String("path", path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", statusCode),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
 		)
 	}
 }
@@ -1,10 +1,10 @@
 package logging
 
 import (
-	"io/ioutil"
+	"bytes"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
-	"os"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -16,12 +16,10 @@ func TestMiddleware(t *testing.T) {
 	// Setting up the Gin server
 	gin.SetMode(gin.TestMode)
 	engine := gin.New()
-	engine.Use(Middleware())
 
 	// Setting up the output recorder
-	rescueStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
+	var got bytes.Buffer
+	engine.Use(Middleware(slog.New(slog.NewTextHandler(&got, nil))))
 
 	// Setting up the HTTP recorder and the request
 	httpRecorder := httptest.NewRecorder()
@@ -31,13 +29,9 @@ func TestMiddleware(t *testing.T) {
 	// Performing the request
 	engine.ServeHTTP(httpRecorder, req)
 
-	// Getting the output recorded
-	require.NoError(t, w.Close())
-	got, _ := ioutil.ReadAll(r)
-	os.Stdout = rescueStdout
-
 	// Asserting the output contains some expected values
-	assert.Contains(t, string(got), "GET")
-	assert.Contains(t, string(got), "/test-middleware")
-	assert.Contains(t, string(got), "404")
+	assert.Contains(t, got.String(), "GET")
+	assert.Contains(t, got.String(), "/test-middleware")
+	assert.Contains(t, got.String(), "404")
+	assert.Contains(t, got.String(), "level=WARN")
 }
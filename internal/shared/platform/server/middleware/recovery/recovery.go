@@ -1,25 +1,28 @@
 package recovery
 
 import (
-	"fmt"
+	"log/slog"
 	"net/http"
-	"time"
+	"runtime/debug"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Middleware is a gin.HandlerFunc able to recover
-// panics that logs the recovered panic and aborts
-// the HTTP request returning an Internal Server Error (500).
-func Middleware() gin.HandlerFunc {
+// panics that logs the recovered panic with its stack trace
+// and aborts the HTTP request returning an Internal Server Error (500).
+func Middleware(logger *slog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Recover from panic
 		defer func() {
 			if err := recover(); err != nil {
-				fmt.Printf("[Middleware] %s panic recovered:\n%s\n",
-					time.Now().Format("2006/01/02 - 15:04:05"), err)
+				logger.ErrorContext(c.Request.Context(), "panic recovered",
+					slog.Any("panic", err),
+					slog.String("method", c.Request.Method),
+					slog.String("path", c.Request.URL.Path),
+					slog.String("stack", string(debug.Stack())),
+				)
 
-				c.Abort()
 				c.AbortWithStatus(http.StatusInternalServerError)
 			}
 		}()
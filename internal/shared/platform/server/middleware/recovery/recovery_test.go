@@ -1,11 +1,14 @@
 package recovery
 
 import (
+	"bytes"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/logger"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware/requestid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -13,8 +16,12 @@ import (
 func TestRecoveryMiddleware(t *testing.T) {
 	// Setting up the Gin server
 	gin.SetMode(gin.TestMode)
+	var got bytes.Buffer
+	log, err := logger.New(logger.Config{Level: "info", Format: "json"}, &got)
+	require.NoError(t, err)
+
 	engine := gin.New()
-	engine.Use(Middleware())
+	engine.Use(requestid.Middleware(), Middleware(log))
 	engine.GET("/test-middleware", func(context *gin.Context) {
 		panic("something unexpected")
 	})
@@ -23,9 +30,16 @@ func TestRecoveryMiddleware(t *testing.T) {
 	httpRecorder := httptest.NewRecorder()
 	req, err := http.NewRequest("GET", "/test-middleware", nil)
 	require.NoError(t, err)
+	req.Header.Set(requestid.Header, "req-1")
 
 	// Asserting the request does not produce a panic
 	assert.NotPanics(t, func() {
 		engine.ServeHTTP(httpRecorder, req)
 	})
+
+	// Asserting the panic is logged with its stack trace and request ID
+	assert.Equal(t, http.StatusInternalServerError, httpRecorder.Code)
+	assert.Contains(t, got.String(), `"panic":"something unexpected"`)
+	assert.Contains(t, got.String(), `"stack":`)
+	assert.Contains(t, got.String(), `"request_id":"req-1"`)
 }
@@ -0,0 +1,49 @@
+package requestid
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/logger"
+)
+
+// Header is the header with the ID of the request, both in the request and in
+// the response.
+const Header = "X-Request-ID"
+
+// maxLength is the maximum length of the request IDs accepted from clients.
+const maxLength = 128
+
+// Middleware is a gin.HandlerFunc that takes the request ID from the
+// X-Request-ID header, or generates one if it is missing or invalid, and adds
+// it to the response and to the context of the request, so every log record
+// of the request includes it.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(Header)
+		if !valid(requestID) {
+			requestID = uuid.New().String()
+		}
+
+		c.Header(Header, requestID)
+		c.Request = c.Request.WithContext(logger.WithRequestID(c.Request.Context(), requestID))
+
+		c.Next()
+	}
+}
+
+// valid accepts the IDs that can be safely logged: not empty, not too long and
+// made of letters, digits and a few separators.
+func valid(requestID string) bool {
+	if requestID == "" || len(requestID) > maxLength {
+		return false
+	}
+	for _, r := range requestID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
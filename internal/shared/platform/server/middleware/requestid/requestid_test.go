@@ -0,0 +1,56 @@
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(Middleware())
+
+	var contextRequestID string
+	engine.GET("/test-middleware", func(c *gin.Context) {
+		contextRequestID = logger.RequestIDFrom(c.Request.Context())
+	})
+
+	tests := map[string]struct {
+		header   string
+		expected string
+	}{
+		"it keeps the request ID of the client":  {header: "abc-123", expected: "abc-123"},
+		"it generates a request ID when missing": {header: ""},
+		"it replaces an invalid request ID":      {header: "abc 123\n"},
+		"it replaces a too long request ID":      {header: strings.Repeat("a", maxLength+1)},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/test-middleware", nil)
+			require.NoError(t, err)
+			if tt.header != "" {
+				req.Header.Set(Header, tt.header)
+			}
+
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			responseRequestID := rec.Header().Get(Header)
+			assert.NotEmpty(t, responseRequestID)
+			assert.Equal(t, responseRequestID, contextRequestID)
+			if tt.expected != "" {
+				assert.Equal(t, tt.expected, responseRequestID)
+			} else {
+				assert.NotEqual(t, tt.header, responseRequestID)
+			}
+		})
+	}
+}
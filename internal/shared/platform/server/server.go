@@ -3,18 +3,27 @@ package server
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	organizationsroutes "github.com/rubenbupe/recipe-video-parser/internal/organizations/platform/server/routes"
 	recipesroutes "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/server/routes"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/metrics"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware/httpmetrics"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware/httptracing"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware/logging"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware/recovery"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware/requestid"
 	statusroutes "github.com/rubenbupe/recipe-video-parser/internal/status/platform/server/routes"
+	subscriptionsroutes "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/server/routes"
+	usersroutes "github.com/rubenbupe/recipe-video-parser/internal/users/platform/server/routes"
+	webhooksroutes "github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/server/routes"
 
 	// extractionsroutes "github.com/rubenbupe/recipe-video-parser/internal/extractions/platform/server/routes"
 	"github.com/rubenbupe/recipe-video-parser/kit/command"
@@ -23,14 +32,23 @@ import (
 type Server struct {
 	httpAddr string
 	engine   *gin.Engine
+	logger   *slog.Logger
+	metrics  *metrics.Registry
 
 	shutdownTimeout time.Duration
 }
 
-func New(ctx context.Context, host string, port uint, shutdownTimeout time.Duration, commandBus command.Bus) (context.Context, Server) {
+func New(ctx context.Context, host string, port uint, shutdownTimeout time.Duration, commandBus command.Bus, logger *slog.Logger, registry *metrics.Registry) (context.Context, Server) {
+	engine := gin.New()
+	// Los handlers pasan el *gin.Context a los buses: así el contexto de la
+	// petición (ID de petición, cancelación) llega a los casos de uso.
+	engine.ContextWithFallback = true
+
 	srv := Server{
-		engine:   gin.New(),
+		engine:   engine,
 		httpAddr: fmt.Sprintf("%s:%d", host, port),
+		logger:   logger,
+		metrics:  registry,
 
 		shutdownTimeout: shutdownTimeout,
 	}
@@ -40,33 +58,52 @@ func New(ctx context.Context, host string, port uint, shutdownTimeout time.Durat
 }
 
 func (s *Server) registerRoutes() {
-	s.engine.Use(recovery.Middleware(), logging.Middleware())
+	s.engine.Use(requestid.Middleware(), httptracing.Middleware(), recovery.Middleware(s.logger), logging.Middleware(s.logger), httpmetrics.Middleware(s.metrics))
 	s.engine.Use(middleware.CORSMiddleware())
 
 	status := s.engine.Group("/status")
 	recipes := s.engine.Group("/recipes")
+	webhooks := s.engine.Group("/webhooks")
+	subscriptions := s.engine.Group("/subscriptions")
+	admin := s.engine.Group("/admin")
+	account := s.engine.Group("/account")
+	organizations := s.engine.Group("/organizations")
 	// users := apiV1.Group("/recipes")
 
+	s.engine.GET("/metrics", gin.WrapH(s.metrics.Handler()))
+
 	statusroutes.Register(status)
 	recipesroutes.Register(recipes)
+	webhooksroutes.Register(webhooks)
+	subscriptionsroutes.Register(subscriptions)
+	usersroutes.Register(admin)
+	usersroutes.RegisterAccount(account)
+	organizationsroutes.Register(organizations)
 	// extractionsroutes.Register(users)
 }
 
+// Run serves the API until the context is cancelled and then shuts the server
+// down. If the server stops before, e.g. because the port is in use, Run
+// returns its error so the caller can still drain the pending work.
 func (s *Server) Run(ctx context.Context) error {
-	log.Println("Server running on", s.httpAddr)
+	s.logger.Info("server running", slog.String("addr", s.httpAddr))
 
 	srv := &http.Server{
 		Addr:    s.httpAddr,
 		Handler: s.engine,
 	}
 
+	serveErr := make(chan error, 1)
 	go func() {
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatal("server shut down", err)
-		}
+		serveErr <- srv.ListenAndServe()
 	}()
 
-	<-ctx.Done()
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("server shut down: %w", err)
+	case <-ctx.Done():
+	}
+
 	ctxShutDown, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 	defer cancel()
 
@@ -75,7 +112,8 @@ func (s *Server) Run(ctx context.Context) error {
 
 func serverContext(ctx context.Context) context.Context {
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
+	// Docker y Kubernetes detienen el contenedor con SIGTERM
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	ctx, cancel := context.WithCancel(ctx)
 	go func() {
 		<-c
@@ -0,0 +1,29 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/metrics"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Run_ReturnsListenError(t *testing.T) {
+	t.Setenv("AI_APIKEY", "secret")
+	// El puerto ya está en uso
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	port := uint(listener.Addr().(*net.TCPAddr).Port)
+
+	ctx, srv := New(context.Background(), "127.0.0.1", port, time.Second, new(commandmocks.Bus), slog.Default(), metrics.NewRegistry())
+
+	err = srv.Run(ctx)
+
+	assert.ErrorContains(t, err, "address already in use")
+}
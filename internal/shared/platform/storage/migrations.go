@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	schema "github.com/rubenbupe/recipe-video-parser/sql"
+)
+
+const migrationsTable = "schema_migrations"
+
+// CreateSchema creates every table of a new database and marks all the known
+// migrations as applied, since schema.sql already includes them.
+func CreateSchema(ctx context.Context, conn *Connection) error {
+	if _, err := conn.Db.ExecContext(ctx, schema.Schema); err != nil {
+		return fmt.Errorf("error creating schema: %w", err)
+	}
+
+	versions, err := migrationVersions()
+	if err != nil {
+		return err
+	}
+	for _, version := range versions {
+		if err := markMigration(ctx, conn.Db, version); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Migrate applies the pending migrations in order and returns their versions.
+func Migrate(ctx context.Context, conn *Connection) ([]string, error) {
+	_, err := conn.Db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+migrationsTable+" (version VARCHAR PRIMARY KEY, applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP)")
+	if err != nil {
+		return nil, fmt.Errorf("error creating migrations table: %w", err)
+	}
+
+	applied, err := appliedMigrations(ctx, conn.Db)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := migrationVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []string
+	for _, version := range versions {
+		if applied[version] {
+			continue
+		}
+
+		content, err := fs.ReadFile(schema.Migrations, path.Join("migrations", version+".sql"))
+		if err != nil {
+			return done, fmt.Errorf("error reading migration %s: %w", version, err)
+		}
+
+		tx, err := conn.Db.BeginTx(ctx, nil)
+		if err != nil {
+			return done, err
+		}
+		if _, err := tx.ExecContext(ctx, string(content)); err != nil {
+			tx.Rollback()
+			return done, fmt.Errorf("error applying migration %s: %w", version, err)
+		}
+		if err := markMigration(ctx, tx, version); err != nil {
+			tx.Rollback()
+			return done, err
+		}
+		if err := tx.Commit(); err != nil {
+			return done, err
+		}
+
+		done = append(done, version)
+	}
+
+	return done, nil
+}
+
+// SchemaVersion returns the last applied migration, or an empty string when
+// none has been applied.
+func SchemaVersion(ctx context.Context, conn *Connection) (string, error) {
+	var version sql.NullString
+	row := conn.Db.QueryRowContext(ctx, "SELECT MAX(version) FROM "+migrationsTable)
+	if err := row.Scan(&version); err != nil {
+		return "", fmt.Errorf("error reading schema version: %w", err)
+	}
+
+	return version.String, nil
+}
+
+// LatestSchemaVersion returns the version of the newest known migration.
+func LatestSchemaVersion() (string, error) {
+	versions, err := migrationVersions()
+	if err != nil || len(versions) == 0 {
+		return "", err
+	}
+
+	return versions[len(versions)-1], nil
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func markMigration(ctx context.Context, db execer, version string) error {
+	_, err := db.ExecContext(ctx, "INSERT OR IGNORE INTO "+migrationsTable+" (version) VALUES (?)", version)
+	if err != nil {
+		return fmt.Errorf("error marking migration %s as applied: %w", version, err)
+	}
+	return nil
+}
+
+func appliedMigrations(ctx context.Context, db *sql.DB) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, "SELECT version FROM "+migrationsTable)
+	if err != nil {
+		return nil, fmt.Errorf("error reading applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[string]bool{}
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+
+	return applied, rows.Err()
+}
+
+func migrationVersions() ([]string, error) {
+	entries, err := fs.ReadDir(schema.Migrations, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("error listing migrations: %w", err)
+	}
+
+	var versions []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		versions = append(versions, strings.TrimSuffix(entry.Name(), ".sql"))
+	}
+	sort.Strings(versions)
+
+	return versions, nil
+}
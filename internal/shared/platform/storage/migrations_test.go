@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateSchema_MarksMigrationsAsApplied(t *testing.T) {
+	ctx := context.Background()
+	conn, err := CreateConnection("test-create-schema", &Dbconfig{Database: filepath.Join(t.TempDir(), "app")})
+	require.NoError(t, err)
+	defer conn.Db.Close()
+
+	require.NoError(t, CreateSchema(ctx, conn))
+
+	applied, err := Migrate(ctx, conn)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	version, err := SchemaVersion(ctx, conn)
+	require.NoError(t, err)
+	latest, err := LatestSchemaVersion()
+	require.NoError(t, err)
+	assert.Equal(t, latest, version)
+}
+
+func Test_Migrate_UpgradesExistingDatabase(t *testing.T) {
+	ctx := context.Background()
+	conn, err := CreateConnection("test-migrate", &Dbconfig{Database: filepath.Join(t.TempDir(), "app")})
+	require.NoError(t, err)
+	defer conn.Db.Close()
+
+	// Esquema anterior a la primera migración
+	_, err = conn.Db.ExecContext(ctx, `
+		CREATE TABLE users (id UUID PRIMARY KEY, name VARCHAR NOT NULL UNIQUE, api_key VARCHAR NOT NULL UNIQUE, created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP);
+		CREATE TABLE recipe_extractions (id UUID PRIMARY KEY, user_id UUID REFERENCES users(id) ON DELETE CASCADE, data JSONB NULL, metadata JSONB NOT NULL, created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP);
+	`)
+	require.NoError(t, err)
+	_, err = conn.Db.ExecContext(ctx, `INSERT INTO recipe_extractions (id, data, metadata) VALUES ('5f1c1a3e-8f1b-4a57-9a43-6f2d1f0c7d11', '{"url": "https://example.com/video"}', '{}')`)
+	require.NoError(t, err)
+
+	applied, err := Migrate(ctx, conn)
+	require.NoError(t, err)
+	assert.Contains(t, applied, "0001_users_locale")
+
+	var status, sourceUrl string
+	row := conn.Db.QueryRowContext(ctx, "SELECT status, source_url FROM recipe_extractions")
+	require.NoError(t, row.Scan(&status, &sourceUrl))
+	assert.Equal(t, "succeeded", status)
+	assert.Equal(t, "https://example.com/video", sourceUrl, "the source url is filled from the recipe")
+
+	_, err = conn.Db.ExecContext(ctx, "INSERT INTO users (id, name, api_key, locale) VALUES ('37a0f027-15e6-47cc-a5d2-64183281087e', 'name', 'key', 'en-GB')")
+	assert.NoError(t, err)
+
+	var pendingEvents int
+	require.NoError(t, conn.Db.QueryRowContext(ctx, "SELECT COUNT(*) FROM event_outbox WHERE delivered_at IS NULL").Scan(&pendingEvents))
+	assert.Zero(t, pendingEvents)
+
+	applied, err = Migrate(ctx, conn)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+}
@@ -1,15 +1,17 @@
 package storage
 
 import (
-	"github.com/kelseyhightower/envconfig"
+	"errors"
 )
 
-func CreateConfig() (*Dbconfig, error) {
-	var cfg Dbconfig
-	err := envconfig.Process("DB", &cfg)
-	if err != nil {
-		return nil, err
+// Validate checks the values of the config.
+func (c Dbconfig) Validate() error {
+	var errs []error
+	if c.Database == "" {
+		errs = append(errs, errors.New("missing database"))
 	}
-
-	return &cfg, nil
+	if c.Timeout < 0 {
+		errs = append(errs, errors.New("timeout must not be negative"))
+	}
+	return errors.Join(errs...)
 }
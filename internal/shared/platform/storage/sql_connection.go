@@ -2,6 +2,7 @@ package storage
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 
 	_ "modernc.org/sqlite"
@@ -17,7 +18,10 @@ func CreateConnection(name string, cfg *Dbconfig) (*Connection, error) {
 	if len(cfg.Database) < 3 || cfg.Database[len(cfg.Database)-3:] != ".db" {
 		cfg.Database += ".db"
 	}
-	dsn := cfg.Database
+	// Las conexiones esperan a que se libere la base de datos en lugar de
+	// fallar, y las transacciones la bloquean desde el inicio para que dos
+	// transacciones no se bloqueen entre sí al pasar de leer a escribir.
+	dsn := fmt.Sprintf("%s?_pragma=busy_timeout(%d)&_txlock=immediate", cfg.Database, cfg.Timeout.Milliseconds())
 	db, err := sql.Open("sqlite", dsn)
 	if err != nil {
 		return nil, err
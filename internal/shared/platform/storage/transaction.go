@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Executor is satisfied by *sql.DB and *sql.Tx.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type txKey struct{}
+
+// Executor returns the transaction stored in the context, or the database if
+// there is none. Repositories must run every statement through it so they
+// take part in the transaction of the caller.
+func (c *Connection) Executor(ctx context.Context) Executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return c.Db
+}
+
+// Transaction runs fn with a context that carries a transaction, committing
+// it if fn succeeds and rolling it back otherwise. If the context already
+// carries a transaction, fn joins it and the outermost call commits.
+func (c *Connection) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := c.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error trying to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error trying to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// UnitOfWork implements uow.UnitOfWork with a database transaction.
+type UnitOfWork struct {
+	connection *Connection
+}
+
+func NewUnitOfWork(connection *Connection) *UnitOfWork {
+	return &UnitOfWork{
+		connection: connection,
+	}
+}
+
+// Do implements the uow.UnitOfWork interface.
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return u.connection.Transaction(ctx, fn)
+}
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTransactionTestConnection(t *testing.T) *Connection {
+	t.Helper()
+	conn, err := CreateConnection("test-transaction", &Dbconfig{Database: filepath.Join(t.TempDir(), "app")})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Db.Close() })
+
+	_, err = conn.Db.Exec("CREATE TABLE items (id VARCHAR PRIMARY KEY)")
+	require.NoError(t, err)
+	return conn
+}
+
+func countItems(t *testing.T, conn *Connection) int {
+	t.Helper()
+	var count int
+	require.NoError(t, conn.Db.QueryRow("SELECT COUNT(*) FROM items").Scan(&count))
+	return count
+}
+
+func insertItem(ctx context.Context, conn *Connection, id string) error {
+	_, err := conn.Executor(ctx).ExecContext(ctx, "INSERT INTO items (id) VALUES (?)", id)
+	return err
+}
+
+func Test_UnitOfWork_Do(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it commits every statement when the function succeeds", func(t *testing.T) {
+		conn := newTransactionTestConnection(t)
+
+		err := NewUnitOfWork(conn).Do(ctx, func(ctx context.Context) error {
+			if err := insertItem(ctx, conn, "1"); err != nil {
+				return err
+			}
+			return insertItem(ctx, conn, "2")
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, countItems(t, conn))
+	})
+
+	t.Run("it rolls back every statement when the function fails", func(t *testing.T) {
+		conn := newTransactionTestConnection(t)
+		fnErr := errors.New("something unexpected happened")
+
+		err := NewUnitOfWork(conn).Do(ctx, func(ctx context.Context) error {
+			if err := insertItem(ctx, conn, "1"); err != nil {
+				return err
+			}
+			return fnErr
+		})
+
+		assert.ErrorIs(t, err, fnErr)
+		assert.Equal(t, 0, countItems(t, conn))
+	})
+
+	t.Run("nested units of work join the outermost transaction", func(t *testing.T) {
+		conn := newTransactionTestConnection(t)
+		uow := NewUnitOfWork(conn)
+		fnErr := errors.New("something unexpected happened")
+
+		err := uow.Do(ctx, func(ctx context.Context) error {
+			if err := uow.Do(ctx, func(ctx context.Context) error {
+				return insertItem(ctx, conn, "1")
+			}); err != nil {
+				return err
+			}
+			return fnErr
+		})
+
+		assert.ErrorIs(t, err, fnErr)
+		assert.Equal(t, 0, countItems(t, conn), "the nested unit of work must not commit on its own")
+	})
+}
+
+func Test_Connection_Executor(t *testing.T) {
+	conn := newTransactionTestConnection(t)
+
+	assert.Same(t, conn.Db, conn.Executor(context.Background()))
+	require.NoError(t, conn.Transaction(context.Background(), func(ctx context.Context) error {
+		assert.NotSame(t, conn.Db, conn.Executor(ctx))
+		return nil
+	}))
+}
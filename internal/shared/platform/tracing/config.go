@@ -0,0 +1,34 @@
+package tracing
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+type Config struct {
+	// Endpoint is the URL of the OTLP/HTTP collector that receives the spans
+	// (e.g. http://localhost:4318). Tracing is disabled when it is empty.
+	Endpoint string ``
+	// Headers are sent with every export, e.g. to authenticate with the collector.
+	Headers map[string]string `secret:"true"`
+	// ServiceName identifies the application in the traces.
+	ServiceName string `default:"recipe-video-parser"`
+	// SampleRatio is the fraction of the traces that are recorded, from 0 to 1.
+	// Requests that are part of a sampled trace are always recorded.
+	SampleRatio float64 `default:"1"`
+}
+
+// Validate checks the values of the config.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Endpoint != "" {
+		if u, err := url.Parse(c.Endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("invalid endpoint %q", c.Endpoint))
+		}
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("sample ratio %v out of range [0, 1]", c.SampleRatio))
+	}
+	return errors.Join(errs...)
+}
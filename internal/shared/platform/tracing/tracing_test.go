@@ -0,0 +1,91 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing/tracingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func Test_Configure_WithoutEndpoint(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	provider, err := tracing.Configure(context.Background(), tracing.Config{})
+	require.NoError(t, err)
+
+	_, span := tracing.Start(context.Background(), "test")
+	assert.False(t, span.IsRecording())
+	span.End()
+	assert.NoError(t, provider.Shutdown(context.Background()))
+}
+
+func Test_Configure_WithEndpoint(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	provider, err := tracing.Configure(context.Background(), tracing.Config{
+		Endpoint:    "http://127.0.0.1:4318",
+		ServiceName: "test",
+		SampleRatio: 1,
+	})
+	require.NoError(t, err)
+
+	_, span := tracing.Start(context.Background(), "test")
+	assert.True(t, span.IsRecording())
+
+	// No hay un colector escuchando: solo se detiene el exportador
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_ = provider.Shutdown(ctx)
+}
+
+func Test_Configure_InvalidSampleRatio(t *testing.T) {
+	_, err := tracing.Configure(context.Background(), tracing.Config{Endpoint: "http://127.0.0.1:4318", SampleRatio: 2})
+
+	assert.Error(t, err)
+}
+
+func Test_StartAndEnd(t *testing.T) {
+	exporter := tracingtest.Record(t)
+
+	ctx, parent := tracing.Start(context.Background(), "parent")
+	_, child := tracing.Start(ctx, "child", attribute.String("key", "value"))
+	tracing.End(child, errors.New("something unexpected happened"))
+	tracing.End(parent, nil)
+
+	assert.Equal(t, []string{"child", "parent"}, tracingtest.Names(exporter))
+
+	childSpan := tracingtest.Span(t, exporter, "child")
+	parentSpan := tracingtest.Span(t, exporter, "parent")
+	assert.Equal(t, parentSpan.SpanContext.SpanID(), childSpan.Parent.SpanID())
+	assert.Equal(t, "value", tracingtest.Attributes(childSpan)["key"].AsString())
+	assert.Equal(t, codes.Error, childSpan.Status.Code)
+	assert.Equal(t, "something unexpected happened", childSpan.Status.Description)
+	assert.Equal(t, codes.Unset, parentSpan.Status.Code)
+}
+
+func Test_RunAndCall(t *testing.T) {
+	exporter := tracingtest.Record(t)
+
+	err := tracing.Run(context.Background(), "run", func(ctx context.Context) error {
+		res, err := tracing.Call(ctx, "call", func(context.Context) (int, error) {
+			return 42, nil
+		}, attribute.Bool("cached", false))
+		assert.Equal(t, 42, res)
+		return err
+	})
+
+	require.NoError(t, err)
+	call := tracingtest.Span(t, exporter, "call")
+	assert.Equal(t, tracingtest.Span(t, exporter, "run").SpanContext.SpanID(), call.Parent.SpanID())
+	assert.False(t, tracingtest.Attributes(call)["cached"].AsBool())
+}
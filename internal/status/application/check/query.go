@@ -0,0 +1,43 @@
+package check
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+const StatusQueryType query.Type = "query.status.check"
+
+type StatusQuery struct{}
+
+func NewStatusQuery() StatusQuery {
+	return StatusQuery{}
+}
+
+func (c StatusQuery) Type() query.Type {
+	return StatusQueryType
+}
+
+type StatusQueryHandler struct {
+	service StatusService
+}
+
+func NewStatusQueryHandler(service StatusService) StatusQueryHandler {
+	return StatusQueryHandler{
+		service: service,
+	}
+}
+
+// Handle implements the query.Handler interface.
+func (h StatusQueryHandler) Handle(ctx context.Context, qry query.Query) (interface{}, error) {
+	if _, ok := qry.(StatusQuery); !ok {
+		return nil, errors.New("unexpected query")
+	}
+
+	return h.service.Check(ctx), nil
+}
+
+func (h StatusQueryHandler) SubscribedTo() query.Type {
+	return StatusQueryType
+}
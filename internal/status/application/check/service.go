@@ -0,0 +1,67 @@
+package check
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	statusdomain "github.com/rubenbupe/recipe-video-parser/internal/status/domain"
+)
+
+// StatusService checks the components the application depends on.
+type StatusService struct {
+	checkers []statusdomain.Checker
+	timeout  time.Duration
+}
+
+// NewStatusService initializes a StatusService. Every check is canceled after
+// timeout, so a component that hangs is reported as down.
+func NewStatusService(checkers []statusdomain.Checker, timeout time.Duration) StatusService {
+	return StatusService{
+		checkers: checkers,
+		timeout:  timeout,
+	}
+}
+
+// Check runs every check concurrently and returns the report with the
+// components in the order of the checkers.
+func (s StatusService) Check(ctx context.Context) statusdomain.Report {
+	components := make([]statusdomain.Component, len(s.checkers))
+
+	var wg sync.WaitGroup
+	for i, checker := range s.checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			components[i] = s.check(ctx, checker)
+		}()
+	}
+	wg.Wait()
+
+	return statusdomain.NewReport(components)
+}
+
+func (s StatusService) check(ctx context.Context, checker statusdomain.Checker) statusdomain.Component {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	component := statusdomain.Component{
+		Name:     checker.Name(),
+		Required: checker.Required(),
+		Status:   statusdomain.StatusUp,
+	}
+
+	start := time.Now()
+	details, err := checker.Check(ctx)
+	component.Latency = time.Since(start)
+	component.Details = details
+	if err != nil {
+		component.Status = statusdomain.StatusDown
+		component.Error = err.Error()
+	}
+
+	return component
+}
@@ -0,0 +1,87 @@
+package check
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	statusdomain "github.com/rubenbupe/recipe-video-parser/internal/status/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeChecker struct {
+	name     string
+	required bool
+	details  map[string]string
+	err      error
+	delay    time.Duration
+}
+
+func (c fakeChecker) Name() string   { return c.name }
+func (c fakeChecker) Required() bool { return c.required }
+
+func (c fakeChecker) Check(ctx context.Context) (map[string]string, error) {
+	select {
+	case <-time.After(c.delay):
+		return c.details, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func Test_StatusService_Check_Up(t *testing.T) {
+	service := NewStatusService([]statusdomain.Checker{
+		fakeChecker{name: "database", required: true, details: map[string]string{"schemaVersion": "0003"}},
+		fakeChecker{name: "ffmpeg"},
+	}, time.Second)
+
+	report := service.Check(context.Background())
+
+	assert.Equal(t, statusdomain.StatusUp, report.Status)
+	assert.True(t, report.Ready())
+	require.Len(t, report.Components, 2)
+	assert.Equal(t, "database", report.Components[0].Name)
+	assert.Equal(t, statusdomain.StatusUp, report.Components[0].Status)
+	assert.Equal(t, "0003", report.Components[0].Details["schemaVersion"])
+	assert.Equal(t, "ffmpeg", report.Components[1].Name)
+}
+
+func Test_StatusService_Check_OptionalComponentDown(t *testing.T) {
+	service := NewStatusService([]statusdomain.Checker{
+		fakeChecker{name: "database", required: true},
+		fakeChecker{name: "ffmpeg", err: errors.New("executable file not found")},
+	}, time.Second)
+
+	report := service.Check(context.Background())
+
+	assert.Equal(t, statusdomain.StatusDegraded, report.Status)
+	assert.True(t, report.Ready())
+	assert.Equal(t, statusdomain.StatusDown, report.Components[1].Status)
+	assert.Equal(t, "executable file not found", report.Components[1].Error)
+}
+
+func Test_StatusService_Check_RequiredComponentDown(t *testing.T) {
+	service := NewStatusService([]statusdomain.Checker{
+		fakeChecker{name: "database", required: true, err: errors.New("database is locked")},
+		fakeChecker{name: "ffmpeg", err: errors.New("executable file not found")},
+	}, time.Second)
+
+	report := service.Check(context.Background())
+
+	assert.Equal(t, statusdomain.StatusDown, report.Status)
+	assert.False(t, report.Ready())
+}
+
+func Test_StatusService_Check_Timeout(t *testing.T) {
+	service := NewStatusService([]statusdomain.Checker{
+		fakeChecker{name: "ai", required: true, delay: time.Minute},
+	}, 20*time.Millisecond)
+
+	report := service.Check(context.Background())
+
+	assert.Equal(t, statusdomain.StatusDown, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Components[0].Error)
+	assert.GreaterOrEqual(t, report.Components[0].Latency, 20*time.Millisecond)
+}
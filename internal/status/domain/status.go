@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Status of a component or of the whole application.
+const (
+	StatusUp = "up"
+	// StatusDegraded means that an optional component is down: the
+	// application is ready but some features may not work.
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+)
+
+// Checker checks a component the application depends on, such as the
+// database or an external binary.
+type Checker interface {
+	// Name identifies the component in the report.
+	Name() string
+	// Required reports whether the application is not ready when the
+	// component is down.
+	Required() bool
+	// Check returns details of the component, such as its version, or an
+	// error when it is down.
+	Check(ctx context.Context) (map[string]string, error)
+}
+
+// Component is the result of checking a component.
+type Component struct {
+	Name     string
+	Required bool
+	Status   string
+	Latency  time.Duration
+	Details  map[string]string
+	Error    string
+}
+
+// Report is the status of every component and of the whole application.
+type Report struct {
+	Status     string
+	Components []Component
+}
+
+// NewReport computes the overall status from the components: down when a
+// required component is down, degraded when an optional one is.
+func NewReport(components []Component) Report {
+	status := StatusUp
+	for _, component := range components {
+		if component.Status != StatusDown {
+			continue
+		}
+		if component.Required {
+			status = StatusDown
+			break
+		}
+		status = StatusDegraded
+	}
+
+	return Report{
+		Status:     status,
+		Components: components,
+	}
+}
+
+// Ready reports whether every required component is up.
+func (r Report) Ready() bool {
+	return r.Status != StatusDown
+}
@@ -0,0 +1,49 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/ai"
+)
+
+// AIChecker checks the configuration of the AI provider and, when probe is
+// not nil, that the provider is reachable.
+type AIChecker struct {
+	config ai.Aiconfig
+	probe  func(ctx context.Context) error
+}
+
+func NewAIChecker(config ai.Aiconfig, probe func(ctx context.Context) error) AIChecker {
+	return AIChecker{
+		config: config,
+		probe:  probe,
+	}
+}
+
+func (c AIChecker) Name() string {
+	return "ai"
+}
+
+func (c AIChecker) Required() bool {
+	return true
+}
+
+func (c AIChecker) Check(ctx context.Context) (map[string]string, error) {
+	details := map[string]string{
+		"provider": c.config.Provider,
+		"model":    c.config.Model,
+		"probed":   fmt.Sprint(c.probe != nil),
+	}
+
+	if err := c.config.Validate(); err != nil {
+		return details, fmt.Errorf("invalid AI config: %w", err)
+	}
+	if c.probe == nil {
+		return details, nil
+	}
+	if err := c.probe(ctx); err != nil {
+		return details, fmt.Errorf("AI provider is not reachable: %w", err)
+	}
+	return details, nil
+}
@@ -0,0 +1,67 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// maxVersionLength truncates the first line of the output of the binaries,
+// which for ffmpeg includes the copyright.
+const maxVersionLength = 64
+
+// BinaryChecker checks that an executable is in the PATH and reports its
+// version.
+type BinaryChecker struct {
+	name        string
+	required    bool
+	versionArgs []string
+}
+
+// NewBinaryChecker initializes a BinaryChecker. versionArgs are the arguments
+// that make the binary print its version, "--version" when empty.
+func NewBinaryChecker(name string, required bool, versionArgs ...string) BinaryChecker {
+	if len(versionArgs) == 0 {
+		versionArgs = []string{"--version"}
+	}
+
+	return BinaryChecker{
+		name:        name,
+		required:    required,
+		versionArgs: versionArgs,
+	}
+}
+
+func (c BinaryChecker) Name() string {
+	return c.name
+}
+
+func (c BinaryChecker) Required() bool {
+	return c.required
+}
+
+func (c BinaryChecker) Check(ctx context.Context) (map[string]string, error) {
+	path, err := exec.LookPath(c.name)
+	if err != nil {
+		return nil, err
+	}
+	details := map[string]string{"path": path}
+
+	output, err := exec.CommandContext(ctx, path, c.versionArgs...).Output()
+	if err != nil {
+		return details, fmt.Errorf("error getting the version of %s: %w", c.name, err)
+	}
+	details["version"] = firstLine(output)
+
+	return details, nil
+}
+
+func firstLine(output []byte) string {
+	line, _, _ := strings.Cut(string(output), "\n")
+	line = strings.TrimSpace(line)
+	if len(line) > maxVersionLength {
+		line = line[:maxVersionLength]
+	}
+	return line
+}
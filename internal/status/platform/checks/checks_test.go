@@ -0,0 +1,147 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DatabaseChecker(t *testing.T) {
+	ctx := context.Background()
+	conn, err := storage.CreateConnection("test-status-database", &storage.Dbconfig{Database: filepath.Join(t.TempDir(), "app")})
+	require.NoError(t, err)
+	defer conn.Db.Close()
+
+	checker := NewDatabaseChecker(conn)
+
+	t.Run("it is down without schema", func(t *testing.T) {
+		_, err := checker.Check(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("it reports the schema version", func(t *testing.T) {
+		require.NoError(t, storage.CreateSchema(ctx, conn))
+		latest, err := storage.LatestSchemaVersion()
+		require.NoError(t, err)
+
+		details, err := checker.Check(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, latest, details["schemaVersion"])
+		assert.Equal(t, latest, details["latestSchemaVersion"])
+	})
+}
+
+func Test_BinaryChecker(t *testing.T) {
+	t.Run("it reports the version", func(t *testing.T) {
+		dir := t.TempDir()
+		script := "#!/bin/sh\necho \"fake-dl 1.2.3\"\necho \"more output\"\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "fake-dl"), []byte(script), 0o755))
+		t.Setenv("PATH", dir)
+
+		details, err := NewBinaryChecker("fake-dl", true).Check(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "fake-dl"), details["path"])
+		assert.Equal(t, "fake-dl 1.2.3", details["version"])
+	})
+
+	t.Run("it is down when not installed", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+
+		_, err := NewBinaryChecker("fake-dl", false).Check(context.Background())
+
+		assert.Error(t, err)
+	})
+}
+
+func Test_DownloadDirChecker(t *testing.T) {
+	t.Run("it creates the directory and reports the free space", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "dl")
+
+		details, err := NewDownloadDirChecker(dir, 0).Check(context.Background())
+
+		require.NoError(t, err)
+		assert.DirExists(t, dir)
+		assert.NotEmpty(t, details["freeMB"])
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries, "the probe file is removed")
+	})
+
+	t.Run("it is down without enough free space", func(t *testing.T) {
+		_, err := NewDownloadDirChecker(t.TempDir(), 1<<40).Check(context.Background())
+
+		assert.ErrorContains(t, err, "MB required")
+	})
+
+	t.Run("it is down when the path is a file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(file, nil, 0o600))
+
+		_, err := NewDownloadDirChecker(file, 0).Check(context.Background())
+
+		assert.Error(t, err)
+	})
+}
+
+func Test_ConfigFileChecker(t *testing.T) {
+	t.Run("it is up when not configured", func(t *testing.T) {
+		details, err := NewConfigFileChecker("").Check(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, "false", details["configured"])
+	})
+
+	t.Run("it reads the file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "gallery-dl.conf")
+		require.NoError(t, os.WriteFile(file, []byte("{}"), 0o600))
+
+		details, err := NewConfigFileChecker(file).Check(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, "2", details["size"])
+	})
+
+	t.Run("it is down when missing", func(t *testing.T) {
+		_, err := NewConfigFileChecker(filepath.Join(t.TempDir(), "missing.conf")).Check(context.Background())
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func Test_AIChecker(t *testing.T) {
+	config := ai.Aiconfig{Provider: "google", ApiKey: "key", Model: "gemini-test", Temperature: 0.2}
+
+	t.Run("it validates the config without probing", func(t *testing.T) {
+		details, err := NewAIChecker(config, nil).Check(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, "false", details["probed"])
+	})
+
+	t.Run("it is down with an invalid config", func(t *testing.T) {
+		invalid := config
+		invalid.ApiKey = ""
+
+		_, err := NewAIChecker(invalid, nil).Check(context.Background())
+
+		assert.ErrorContains(t, err, "missing API key")
+	})
+
+	t.Run("it is down when the provider is not reachable", func(t *testing.T) {
+		probeErr := errors.New("403 Forbidden")
+
+		details, err := NewAIChecker(config, func(ctx context.Context) error { return probeErr }).Check(context.Background())
+
+		assert.ErrorIs(t, err, probeErr)
+		assert.Equal(t, "true", details["probed"])
+	})
+}
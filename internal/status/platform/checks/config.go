@@ -0,0 +1,31 @@
+package checks
+
+import (
+	"errors"
+	"time"
+)
+
+type Config struct {
+	// Timeout is the maximum duration of every check. Components that take
+	// longer are reported as down.
+	Timeout time.Duration `default:"5s"`
+	// MinFreeMB is the free space of the download directory, in megabytes,
+	// below which it is reported as down.
+	MinFreeMB int64 `default:"500"`
+	// ProbeProvider requests the model from the AI provider on every
+	// readiness check. It is disabled by default since the provider limits
+	// the requests per minute.
+	ProbeProvider bool `default:"false"`
+}
+
+// Validate checks the values of the config.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Timeout <= 0 {
+		errs = append(errs, errors.New("timeout must be positive"))
+	}
+	if c.MinFreeMB < 0 {
+		errs = append(errs, errors.New("minimum free space must not be negative"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,52 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// ConfigFileChecker checks that the configuration file of gallery-dl can be
+// read. It is up when no file is configured.
+type ConfigFileChecker struct {
+	path string
+}
+
+func NewConfigFileChecker(path string) ConfigFileChecker {
+	return ConfigFileChecker{
+		path: path,
+	}
+}
+
+func (c ConfigFileChecker) Name() string {
+	return "gallery-dl-config"
+}
+
+func (c ConfigFileChecker) Required() bool {
+	return true
+}
+
+func (c ConfigFileChecker) Check(ctx context.Context) (map[string]string, error) {
+	if c.path == "" {
+		return map[string]string{"configured": "false"}, nil
+	}
+	details := map[string]string{"configured": "true", "path": c.path}
+
+	file, err := os.Open(c.path)
+	if err != nil {
+		return details, fmt.Errorf("config file is not readable: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return details, fmt.Errorf("config file is not readable: %w", err)
+	}
+	if info.IsDir() {
+		return details, fmt.Errorf("config file %s is a directory", c.path)
+	}
+	details["size"] = strconv.FormatInt(info.Size(), 10)
+
+	return details, nil
+}
@@ -0,0 +1,51 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+)
+
+// DatabaseChecker checks that the database answers and that its schema is up
+// to date.
+type DatabaseChecker struct {
+	conn *storage.Connection
+}
+
+func NewDatabaseChecker(conn *storage.Connection) DatabaseChecker {
+	return DatabaseChecker{
+		conn: conn,
+	}
+}
+
+func (c DatabaseChecker) Name() string {
+	return "database"
+}
+
+func (c DatabaseChecker) Required() bool {
+	return true
+}
+
+func (c DatabaseChecker) Check(ctx context.Context) (map[string]string, error) {
+	if err := c.conn.Db.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("error connecting to the database: %w", err)
+	}
+
+	latest, err := storage.LatestSchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	details := map[string]string{"latestSchemaVersion": latest}
+
+	version, err := storage.SchemaVersion(ctx, c.conn)
+	if err != nil {
+		return details, err
+	}
+	details["schemaVersion"] = version
+
+	if version < latest {
+		return details, fmt.Errorf("schema version %q is older than %q: run the migrations", version, latest)
+	}
+	return details, nil
+}
@@ -0,0 +1,64 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// errFreeSpaceUnsupported is returned by freeSpace on the systems where the
+// free space cannot be read.
+var errFreeSpaceUnsupported = errors.New("free space not supported on this system")
+
+// DownloadDirChecker checks that the videos can be downloaded to the download
+// directory: that it is writable and has enough free space.
+type DownloadDirChecker struct {
+	dir          string
+	minFreeBytes uint64
+}
+
+func NewDownloadDirChecker(dir string, minFreeMB int64) DownloadDirChecker {
+	return DownloadDirChecker{
+		dir:          dir,
+		minFreeBytes: uint64(max(minFreeMB, 0)) << 20,
+	}
+}
+
+func (c DownloadDirChecker) Name() string {
+	return "download-dir"
+}
+
+func (c DownloadDirChecker) Required() bool {
+	return true
+}
+
+func (c DownloadDirChecker) Check(ctx context.Context) (map[string]string, error) {
+	details := map[string]string{"path": c.dir}
+
+	// gallery-dl crea el directorio en la primera descarga
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return details, fmt.Errorf("error creating the download directory: %w", err)
+	}
+	file, err := os.CreateTemp(c.dir, ".status-*")
+	if err != nil {
+		return details, fmt.Errorf("download directory is not writable: %w", err)
+	}
+	file.Close()
+	os.Remove(file.Name())
+
+	free, err := freeSpace(c.dir)
+	if errors.Is(err, errFreeSpaceUnsupported) {
+		return details, nil
+	}
+	if err != nil {
+		return details, fmt.Errorf("error reading the free space: %w", err)
+	}
+	details["freeMB"] = strconv.FormatUint(free>>20, 10)
+
+	if free < c.minFreeBytes {
+		return details, fmt.Errorf("only %d MB free, %d MB required", free>>20, c.minFreeBytes>>20)
+	}
+	return details, nil
+}
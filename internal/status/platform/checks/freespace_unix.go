@@ -0,0 +1,15 @@
+//go:build linux || darwin || freebsd
+
+package checks
+
+import "syscall"
+
+// freeSpace returns the bytes available to unprivileged users in the file
+// system of path.
+func freeSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
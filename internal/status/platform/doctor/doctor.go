@@ -0,0 +1,67 @@
+package doctor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	statusdomain "github.com/rubenbupe/recipe-video-parser/internal/status/domain"
+)
+
+// Severity of a finding.
+type Severity string
+
+const (
+	SeverityOK      Severity = "ok"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Finding is the result of one of the checks of the deployment.
+type Finding struct {
+	Severity Severity `json:"severity"`
+	// Subject is the environment variable or the component checked.
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+}
+
+// HasErrors reports whether any finding is an error.
+func HasErrors(findings []Finding) bool {
+	return slices.ContainsFunc(findings, func(f Finding) bool { return f.Severity == SeverityError })
+}
+
+// ComponentFindings converts the status of the components into findings:
+// required components that are down are errors, and optional ones warnings.
+func ComponentFindings(report statusdomain.Report) []Finding {
+	findings := make([]Finding, 0, len(report.Components))
+	for _, component := range report.Components {
+		finding := Finding{
+			Severity: SeverityOK,
+			Subject:  component.Name,
+			Message:  formatDetails(component.Details),
+		}
+		if component.Status == statusdomain.StatusDown {
+			finding.Severity = SeverityWarning
+			if component.Required {
+				finding.Severity = SeverityError
+			}
+			finding.Message = component.Error
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+func formatDetails(details map[string]string) string {
+	keys := make([]string, 0, len(details))
+	for key := range details {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", key, details[key]))
+	}
+	return strings.Join(parts, " ")
+}
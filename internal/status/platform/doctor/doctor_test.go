@@ -0,0 +1,44 @@
+package doctor
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	recipesai "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/ai"
+	statusdomain "github.com/rubenbupe/recipe-video-parser/internal/status/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ComponentFindings(t *testing.T) {
+	report := statusdomain.NewReport([]statusdomain.Component{
+		{Name: "gallery-dl", Required: true, Status: statusdomain.StatusUp, Details: map[string]string{"version": "1.26.9", "path": "/usr/bin/gallery-dl"}},
+		{Name: "ffmpeg", Status: statusdomain.StatusDown, Error: "executable file not found"},
+		{Name: "database", Required: true, Status: statusdomain.StatusDown, Error: "run the migrations"},
+	})
+
+	findings := ComponentFindings(report)
+
+	assert.Equal(t, []Finding{
+		{Severity: SeverityOK, Subject: "gallery-dl", Message: "path=/usr/bin/gallery-dl version=1.26.9"},
+		{Severity: SeverityWarning, Subject: "ffmpeg", Message: "executable file not found"},
+		{Severity: SeverityError, Subject: "database", Message: "run the migrations"},
+	}, findings)
+	assert.True(t, HasErrors(findings))
+	assert.False(t, HasErrors(findings[:2]))
+}
+
+func Test_DryRunExtraction_MissingFixture(t *testing.T) {
+	registry, err := recipesai.NewPromptRegistry("")
+	require.NoError(t, err)
+	prompt, err := registry.Latest(recipesai.ExtractRecipePromptID)
+	require.NoError(t, err)
+
+	finding := DryRunExtraction(context.Background(), filepath.Join(t.TempDir(), "missing.mp4"), prompt, ai.Aiconfig{Provider: "google", ApiKey: "key", Model: "gemini-test", BaseUrl: "http://127.0.0.1:1"})
+
+	assert.Equal(t, SeverityError, finding.Severity)
+	assert.Equal(t, "dry-run", finding.Subject)
+	assert.Contains(t, finding.Message, "could not stat file")
+}
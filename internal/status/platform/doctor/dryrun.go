@@ -0,0 +1,54 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"path/filepath"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	recipesai "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/gallery"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/i18n"
+)
+
+// DryRunExtraction extracts the recipe of a local video with the AI provider,
+// without downloading nor storing anything, to verify the API key, the model
+// and the prompt with a real request.
+func DryRunExtraction(ctx context.Context, fixture string, prompt recipesai.Prompt, config ai.Aiconfig) Finding {
+	finding := Finding{Subject: "dry-run"}
+
+	path, err := filepath.Abs(fixture)
+	if err != nil {
+		finding.Severity, finding.Message = SeverityError, err.Error()
+		return finding
+	}
+	download := gallery.DownloadResult{
+		FilePath:  path,
+		Url:       "file://" + filepath.ToSlash(path),
+		Extension: filepath.Ext(path),
+		MimeType:  mime.TypeByExtension(filepath.Ext(path)),
+	}
+	if download.MimeType == "" {
+		download.MimeType = "video/mp4"
+	}
+
+	res, err := recipesai.AskModelWithFile(ctx, download, prompt, i18n.DefaultLocale(), config)
+	usage := fmt.Sprintf("%d ms, %d prompt tokens, %d candidates tokens",
+		res.Metadata.Durations.Upload+res.Metadata.Durations.Activation+res.Metadata.Durations.Generation,
+		res.Metadata.PromptTokenCount, res.Metadata.CandidatesTokenCount)
+	switch {
+	case errors.Is(err, recipesdomain.ErrNotARecipe):
+		finding.Severity = SeverityWarning
+		finding.Message = fmt.Sprintf("the model answered that %s is not a recipe (%s)", fixture, usage)
+	case err != nil:
+		finding.Severity = SeverityError
+		finding.Message = err.Error()
+	default:
+		finding.Severity = SeverityOK
+		finding.Message = fmt.Sprintf("extracted %q with %s@%s (%s)", res.Recipe.Title, prompt.ID, prompt.Version, usage)
+	}
+	return finding
+}
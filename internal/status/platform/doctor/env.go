@@ -0,0 +1,121 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/config"
+)
+
+// otherVariables are read outside of the config.
+var otherVariables = []string{"ENV", "VITE_API_ROOT", config.FileVariable}
+
+// placeholder is a default value that only works in development.
+type placeholder struct {
+	key      string
+	value    string
+	current  func(cfg *config.Config) string
+	severity Severity
+	message  string
+}
+
+var placeholders = []placeholder{
+	{key: "AI_APIKEY", value: "app", current: func(cfg *config.Config) string { return cfg.AI.ApiKey },
+		severity: SeverityError, message: "is the placeholder %q: the AI provider will reject every request"},
+	{key: "DB_DATABASE", value: "app", current: func(cfg *config.Config) string { return cfg.DB.Database },
+		severity: SeverityWarning, message: "is the default %q: the database is app.db in the working directory"},
+}
+
+// CheckEnv loads the config with the options and reports the sections that
+// are not valid, the environment variables with a known prefix that the
+// config does not read (with the most similar known variable), the variables
+// that are set but empty, which disables their default, and the placeholder
+// defaults.
+func CheckEnv(opts config.Options) []Finding {
+	var findings []Finding
+
+	cfg, err := config.Load(opts)
+	if cfg == nil {
+		findings = append(findings, Finding{Severity: SeverityError, Subject: "config", Message: err.Error()})
+	} else {
+		findings = append(findings, sectionFindings(err)...)
+	}
+
+	variables := make(map[string]config.Variable)
+	known := slices.Clone(otherVariables)
+	for _, v := range config.Variables() {
+		variables[v.Key] = v
+		known = append(known, v.Key)
+	}
+	slices.Sort(known)
+
+	environ := os.Environ()
+	slices.Sort(environ)
+	for _, entry := range environ {
+		key, value, _ := strings.Cut(entry, "=")
+		if !hasConfigPrefix(key) || slices.Contains(otherVariables, key) {
+			continue
+		}
+
+		v, ok := variables[key]
+		if !ok {
+			message := "is not read by the config"
+			if suggestion := config.Suggest(key, known); suggestion != "" {
+				message += fmt.Sprintf(": did you mean %s?", suggestion)
+			}
+			findings = append(findings, Finding{Severity: SeverityError, Subject: key, Message: message})
+			continue
+		}
+		if value == "" && v.Default != "" {
+			findings = append(findings, Finding{Severity: SeverityWarning, Subject: key,
+				Message: fmt.Sprintf("is set but empty, so its default %q is not used: remove it or give it a value", v.Default)})
+		}
+	}
+
+	if cfg != nil {
+		for _, p := range placeholders {
+			if p.current(cfg) == p.value {
+				findings = append(findings, Finding{Severity: p.severity, Subject: p.key, Message: fmt.Sprintf(p.message, p.value)})
+			}
+		}
+	}
+
+	return findings
+}
+
+// sectionFindings returns a finding for every section of the config, with
+// the errors of Load in it.
+func sectionFindings(err error) []Finding {
+	messages := make(map[string][]string)
+	var others []string
+	for _, err := range config.Errors(err) {
+		var configErr *config.Error
+		if errors.As(err, &configErr) {
+			messages[configErr.Section] = append(messages[configErr.Section], err.Error())
+		} else {
+			others = append(others, err.Error())
+		}
+	}
+
+	var findings []Finding
+	if len(others) > 0 {
+		findings = append(findings, Finding{Severity: SeverityError, Subject: "config", Message: strings.Join(others, "; ")})
+	}
+	for _, prefix := range config.Prefixes() {
+		if len(messages[prefix]) > 0 {
+			findings = append(findings, Finding{Severity: SeverityError, Subject: prefix, Message: strings.Join(messages[prefix], "; ")})
+		} else {
+			findings = append(findings, Finding{Severity: SeverityOK, Subject: prefix, Message: "config loaded"})
+		}
+	}
+	return findings
+}
+
+func hasConfigPrefix(key string) bool {
+	return slices.ContainsFunc(config.Prefixes(), func(prefix string) bool {
+		return strings.HasPrefix(key, prefix+"_")
+	})
+}
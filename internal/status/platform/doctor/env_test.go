@@ -0,0 +1,102 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findingOf(findings []Finding, subject string) (Finding, bool) {
+	for _, finding := range findings {
+		if finding.Subject == subject {
+			return finding, true
+		}
+	}
+	return Finding{}, false
+}
+
+func Test_CheckEnv_SuggestsMisspelledVariables(t *testing.T) {
+	t.Setenv("AI_APIKEY", "secret")
+	t.Setenv("GALLERY_DOWNLOAD_DIR", "/tmp/dl")
+	t.Setenv("AI_MODLE", "gemini-2.0-flash")
+	t.Setenv("AI_SOMETHING_ELSE_ENTIRELY", "1")
+
+	findings := CheckEnv(config.Options{})
+
+	finding, ok := findingOf(findings, "GALLERY_DOWNLOAD_DIR")
+	assert.True(t, ok)
+	assert.Equal(t, SeverityError, finding.Severity)
+	assert.Contains(t, finding.Message, "did you mean GALLERY_DOWNLOADDIR?")
+
+	finding, _ = findingOf(findings, "AI_MODLE")
+	assert.Contains(t, finding.Message, "did you mean AI_MODEL?")
+
+	finding, _ = findingOf(findings, "AI_SOMETHING_ELSE_ENTIRELY")
+	assert.Equal(t, "is not read by the config", finding.Message)
+}
+
+func Test_CheckEnv_ReportsInvalidConfigs(t *testing.T) {
+	t.Setenv("AI_APIKEY", "secret")
+	t.Setenv("AI_PROVIDER", "openai")
+	t.Setenv("LOG_LEVEL", "verbose")
+	t.Setenv("DB_TIMEOUT", "5 seconds")
+
+	findings := CheckEnv(config.Options{})
+
+	for _, prefix := range []string{"AI", "LOG", "DB"} {
+		finding, _ := findingOf(findings, prefix)
+		assert.Equal(t, SeverityError, finding.Severity, prefix)
+	}
+	finding, _ := findingOf(findings, "AI")
+	assert.Contains(t, finding.Message, `unsupported provider "openai"`)
+	finding, _ = findingOf(findings, "GALLERY")
+	assert.Equal(t, SeverityOK, finding.Severity)
+}
+
+func Test_CheckEnv_ReportsEmptyVariablesWithDefault(t *testing.T) {
+	t.Setenv("AI_APIKEY", "secret")
+	t.Setenv("GALLERY_DOWNLOADDIR", "")
+	t.Setenv("GALLERY_CONFIGFILE", "")
+
+	findings := CheckEnv(config.Options{})
+
+	finding, ok := findingOf(findings, "GALLERY_DOWNLOADDIR")
+	assert.True(t, ok)
+	assert.Equal(t, SeverityWarning, finding.Severity)
+	assert.Contains(t, finding.Message, `default "./tmp"`)
+
+	_, ok = findingOf(findings, "GALLERY_CONFIGFILE")
+	assert.False(t, ok, "variables without default can be empty")
+}
+
+func Test_CheckEnv_ReportsPlaceholderDefaults(t *testing.T) {
+	t.Setenv("DB_DATABASE", "/var/lib/recipes/app")
+
+	findings := CheckEnv(config.Options{})
+
+	finding, ok := findingOf(findings, "AI_APIKEY")
+	assert.True(t, ok)
+	assert.Equal(t, SeverityError, finding.Severity)
+	_, ok = findingOf(findings, "DB_DATABASE")
+	assert.False(t, ok)
+	assert.True(t, HasErrors(findings))
+}
+
+func Test_CheckEnv_ReportsUnknownSettingsInTheConfigFile(t *testing.T) {
+	t.Setenv("AI_APIKEY", "secret")
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("ai:\n  modle: gemini-2.0-flash\n"), 0o600))
+
+	findings := CheckEnv(config.Options{File: file})
+
+	finding, ok := findingOf(findings, "config")
+	assert.True(t, ok)
+	assert.Equal(t, SeverityError, finding.Severity)
+	assert.Contains(t, finding.Message, "did you mean ai.model?")
+	finding, _ = findingOf(findings, "AI")
+	assert.Equal(t, SeverityOK, finding.Severity)
+}
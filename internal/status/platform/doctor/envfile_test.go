@@ -0,0 +1,45 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LoadEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	content := `# CLI & API
+APP_PORT=8080
+VITE_API_ROOT=http://localhost:8080 # No trailing slash
+export AI_MODEL="gemini 2.0"
+AI_APIKEY='se#cret'
+GALLERY_CONFIGFILE=
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	for _, key := range []string{"APP_PORT", "VITE_API_ROOT", "AI_MODEL", "AI_APIKEY", "GALLERY_CONFIGFILE"} {
+		t.Setenv(key, "previous")
+	}
+
+	require.NoError(t, LoadEnvFile(path))
+
+	assert.Equal(t, "8080", os.Getenv("APP_PORT"))
+	assert.Equal(t, "http://localhost:8080", os.Getenv("VITE_API_ROOT"))
+	assert.Equal(t, "gemini 2.0", os.Getenv("AI_MODEL"))
+	assert.Equal(t, "se#cret", os.Getenv("AI_APIKEY"))
+	value, ok := os.LookupEnv("GALLERY_CONFIGFILE")
+	assert.True(t, ok)
+	assert.Empty(t, value)
+}
+
+func Test_LoadEnvFile_InvalidLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(path, []byte("APP_PORT=8080\nnot a variable\n"), 0o600))
+	t.Setenv("APP_PORT", "")
+
+	err := LoadEnvFile(path)
+
+	assert.ErrorContains(t, err, ":2: expected KEY=value")
+}
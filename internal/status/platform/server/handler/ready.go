@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenbupe/recipe-video-parser/internal/status/application/check"
+	statusdomain "github.com/rubenbupe/recipe-video-parser/internal/status/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+type componentResponse struct {
+	Name      string            `json:"name"`
+	Status    string            `json:"status"`
+	Required  bool              `json:"required"`
+	LatencyMs float64           `json:"latencyMs"`
+	Details   map[string]string `json:"details,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
+
+type readyResponse struct {
+	Status     string              `json:"status"`
+	Components []componentResponse `json:"components"`
+}
+
+// LiveHandler returns an HTTP handler for liveness probes: it answers while
+// the process is able to serve requests, without checking its dependencies.
+func LiveHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"status": statusdomain.StatusUp})
+	}
+}
+
+// ReadyHandler returns an HTTP handler for readiness probes. It reports the
+// status of every component and answers 503 when a required one is down.
+func ReadyHandler(queryBus query.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		res, err := queryBus.Ask(ctx, check.NewStatusQuery())
+		if err != nil {
+			ctx.JSON(http.StatusServiceUnavailable, gin.H{"status": statusdomain.StatusDown, "error": err.Error()})
+			return
+		}
+		report := res.(statusdomain.Report)
+
+		components := make([]componentResponse, 0, len(report.Components))
+		for _, component := range report.Components {
+			components = append(components, componentResponse{
+				Name:      component.Name,
+				Status:    component.Status,
+				Required:  component.Required,
+				LatencyMs: float64(component.Latency.Microseconds()) / 1000,
+				Details:   component.Details,
+				Error:     component.Error,
+			})
+		}
+
+		status := http.StatusOK
+		if !report.Ready() {
+			status = http.StatusServiceUnavailable
+		}
+		ctx.JSON(status, readyResponse{
+			Status:     report.Status,
+			Components: components,
+		})
+	}
+}
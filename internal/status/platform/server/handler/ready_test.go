@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	statusdomain "github.com/rubenbupe/recipe-video-parser/internal/status/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/query/querymocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_Live(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/status/live", LiveHandler())
+
+	req, err := http.NewRequest(http.MethodGet, "/status/live", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status": "up"}`, rec.Body.String())
+}
+
+func TestHandler_Ready(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	database := statusdomain.Component{Name: "database", Required: true, Status: statusdomain.StatusUp, Latency: 1500 * time.Microsecond, Details: map[string]string{"schemaVersion": "0003"}}
+	ffmpegDown := statusdomain.Component{Name: "ffmpeg", Status: statusdomain.StatusDown, Error: "executable file not found"}
+	databaseDown := statusdomain.Component{Name: "database", Required: true, Status: statusdomain.StatusDown, Error: "database is locked"}
+
+	tests := map[string]struct {
+		report         statusdomain.Report
+		expectedCode   int
+		expectedStatus string
+	}{
+		"it returns 200 when every component is up": {
+			report:         statusdomain.NewReport([]statusdomain.Component{database}),
+			expectedCode:   http.StatusOK,
+			expectedStatus: statusdomain.StatusUp,
+		},
+		"it returns 200 when an optional component is down": {
+			report:         statusdomain.NewReport([]statusdomain.Component{database, ffmpegDown}),
+			expectedCode:   http.StatusOK,
+			expectedStatus: statusdomain.StatusDegraded,
+		},
+		"it returns 503 when a required component is down": {
+			report:         statusdomain.NewReport([]statusdomain.Component{databaseDown, ffmpegDown}),
+			expectedCode:   http.StatusServiceUnavailable,
+			expectedStatus: statusdomain.StatusDown,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			bus := new(querymocks.Bus)
+			bus.On("Ask", mock.Anything, mock.AnythingOfType("check.StatusQuery")).Return(tt.report, nil)
+			r := gin.New()
+			r.GET("/status/ready", ReadyHandler(bus))
+
+			req, err := http.NewRequest(http.MethodGet, "/status/ready", nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			var res readyResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, tt.expectedStatus, res.Status)
+			require.Len(t, res.Components, len(tt.report.Components))
+			assert.Equal(t, tt.report.Components[0].Name, res.Components[0].Name)
+		})
+	}
+
+	t.Run("it reports the latency in milliseconds", func(t *testing.T) {
+		bus := new(querymocks.Bus)
+		bus.On("Ask", mock.Anything, mock.AnythingOfType("check.StatusQuery")).Return(statusdomain.NewReport([]statusdomain.Component{database}), nil)
+		r := gin.New()
+		r.GET("/status/ready", ReadyHandler(bus))
+
+		req, err := http.NewRequest(http.MethodGet, "/status/ready", nil)
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.JSONEq(t, `{"status": "up", "components": [{"name": "database", "status": "up", "required": true, "latencyMs": 1.5, "details": {"schemaVersion": "0003"}}]}`, rec.Body.String())
+	})
+
+	t.Run("it returns 503 when the query fails", func(t *testing.T) {
+		bus := new(querymocks.Bus)
+		bus.On("Ask", mock.Anything, mock.AnythingOfType("check.StatusQuery")).Return(nil, errors.New("query timed out"))
+		r := gin.New()
+		r.GET("/status/ready", ReadyHandler(bus))
+
+		req, err := http.NewRequest(http.MethodGet, "/status/ready", nil)
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
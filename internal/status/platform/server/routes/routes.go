@@ -12,6 +12,10 @@ func Register(router *gin.RouterGroup) {
 	diContainer := di.Instance()
 
 	getController := diContainer.Container.Get("status.infrastructure.controller.check").(handlers.Handler)
+	liveController := diContainer.Container.Get("status.infrastructure.controller.live").(handlers.Handler)
+	readyController := diContainer.Container.Get("status.infrastructure.controller.ready").(handlers.Handler)
 	print("Registering status routes")
 	router.GET("/", getController)
+	router.GET("/live", liveController)
+	router.GET("/ready", readyController)
 }
@@ -0,0 +1,57 @@
+package check
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const SubscriptionsCommandType command.Type = "command.subscription.check"
+
+type SubscriptionsCommand struct {
+	id string
+}
+
+// NewSubscriptionsCommand checks the subscription with the ID or, if it is
+// empty, every subscription whose interval has passed.
+func NewSubscriptionsCommand(id string) SubscriptionsCommand {
+	return SubscriptionsCommand{
+		id: id,
+	}
+}
+
+func (c SubscriptionsCommand) Type() command.Type {
+	return SubscriptionsCommandType
+}
+
+// NonTransactional runs the checks outside a unit of work: they wait on the
+// downloads and the AI, and every extraction and video is saved on its own.
+func (c SubscriptionsCommand) NonTransactional() {}
+
+type SubscriptionsCommandHandler struct {
+	service SubscriptionsService
+}
+
+func NewSubscriptionsCommandHandler(service SubscriptionsService) SubscriptionsCommandHandler {
+	return SubscriptionsCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h SubscriptionsCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	checkSubscriptionsCmd, ok := cmd.(SubscriptionsCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	if checkSubscriptionsCmd.id != "" {
+		return h.service.CheckSubscription(ctx, checkSubscriptionsCmd.id)
+	}
+	return h.service.CheckDueSubscriptions(ctx)
+}
+
+func (h SubscriptionsCommandHandler) SubscribedTo() command.Type {
+	return SubscriptionsCommandType
+}
@@ -0,0 +1,169 @@
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+)
+
+type SubscriptionsService struct {
+	subscriptionRepository subscriptionsdomain.SubscriptionRepository
+	itemRepository         subscriptionsdomain.SubscriptionItemRepository
+	userRepository         usersdomain.UserRepository
+	lister                 subscriptionsdomain.Lister
+	extractor              subscriptionsdomain.Extractor
+	now                    func() time.Time
+}
+
+// NewSubscriptionsService returns the service that lists the videos of the
+// subscriptions and extracts the recipes of the new ones. now returns the
+// current time; time.Now when nil.
+func NewSubscriptionsService(subscriptionRepository subscriptionsdomain.SubscriptionRepository, itemRepository subscriptionsdomain.SubscriptionItemRepository, userRepository usersdomain.UserRepository, lister subscriptionsdomain.Lister, extractor subscriptionsdomain.Extractor, now func() time.Time) SubscriptionsService {
+	if now == nil {
+		now = time.Now
+	}
+	return SubscriptionsService{
+		subscriptionRepository: subscriptionRepository,
+		itemRepository:         itemRepository,
+		userRepository:         userRepository,
+		lister:                 lister,
+		extractor:              extractor,
+		now:                    now,
+	}
+}
+
+// CheckDueSubscriptions checks, one after another, the subscriptions whose
+// interval has passed. The subscriptions of disabled users are skipped until
+// the user is enabled again. A subscription that fails does not stop the
+// others; the errors are returned together.
+func (s SubscriptionsService) CheckDueSubscriptions(ctx context.Context) error {
+	subscriptions, err := s.subscriptionRepository.All(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	var errs []error
+	for _, subscription := range subscriptions {
+		if !subscription.Due(now) {
+			continue
+		}
+		err := s.check(ctx, subscription)
+		if errors.Is(err, subscriptionsdomain.ErrSubscriptionUserDisabled) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("subscription %s: %w", subscription.Id, err))
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CheckSubscription checks the subscription now, whether its interval has
+// passed or not. It returns ErrSubscriptionUserDisabled if the user of the
+// subscription is disabled.
+func (s SubscriptionsService) CheckSubscription(ctx context.Context, id string) error {
+	idVO, err := subscriptionsdomain.NewSubscriptionID(id)
+	if err != nil {
+		return err
+	}
+
+	subscription, err := s.subscriptionRepository.Get(ctx, idVO)
+	if err != nil {
+		return err
+	}
+	if subscription == nil {
+		return subscriptionsdomain.ErrSubscriptionNotFound
+	}
+
+	return s.check(ctx, *subscription)
+}
+
+// check lista los vídeos de la suscripción y extrae las recetas de los que no
+// se han extraído antes. Los vídeos cuya extracción falla no se registran,
+// para intentarlo de nuevo en la siguiente comprobación. Las suscripciones de
+// usuarios deshabilitados no se comprueban ni se marcan como comprobadas.
+func (s SubscriptionsService) check(ctx context.Context, subscription subscriptionsdomain.Subscription) error {
+	userId, err := usersdomain.NewUserID(subscription.UserId.String())
+	if err != nil {
+		return err
+	}
+	user, err := s.userRepository.Get(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if user != nil && user.IsDisabled() {
+		return subscriptionsdomain.ErrSubscriptionUserDisabled
+	}
+
+	startedAt := s.now()
+	urls, err := s.lister.List(ctx, subscription.Url.String(), subscription.MaxItems.Int())
+	if err == nil {
+		err = s.extractNew(ctx, subscription, urls)
+	}
+	// Si se ha interrumpido se comprobará de nuevo al arrancar
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	// También se registra la comprobación fallida, para no repetirla hasta
+	// que pase el intervalo
+	subscription.Checked(startedAt)
+	return errors.Join(err, s.subscriptionRepository.Update(ctx, subscription))
+}
+
+func (s SubscriptionsService) extractNew(ctx context.Context, subscription subscriptionsdomain.Subscription, urls []string) error {
+	var unseen []string
+	seen := map[string]bool{}
+	for _, url := range urls {
+		if seen[url] {
+			continue
+		}
+		seen[url] = true
+
+		exists, err := s.itemRepository.Exists(ctx, subscription.Id, url)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			unseen = append(unseen, url)
+		}
+	}
+	if len(unseen) == 0 {
+		return nil
+	}
+
+	slog.InfoContext(ctx, "extracting new videos of subscription",
+		slog.String("subscription_id", subscription.Id.String()),
+		slog.Int("videos", len(unseen)),
+	)
+	failed := 0
+	err := s.extractor.Extract(ctx, subscription.UserId.String(), unseen, func(extracted subscriptionsdomain.ExtractedItem) error {
+		if extracted.Status == recipesdomain.ExtractionStatusFailed {
+			failed++
+			return nil
+		}
+		item, err := subscriptionsdomain.NewSubscriptionItem(subscription.Id.String(), extracted.Url, extracted.ExtractionId, extracted.Status, s.now().UTC().Format(time.RFC3339))
+		if err != nil {
+			return err
+		}
+		// El vídeo ya está extraído aunque se haya interrumpido la comprobación
+		return s.itemRepository.Save(context.WithoutCancel(ctx), item)
+	})
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d extractions failed", failed, len(unseen))
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package check
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/lister/listermocks"
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/storage/storagemocks"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	usersstoragemocks "github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	subscriptionID  = "0b6f6a53-2a9e-4f7c-9d54-3b1c2f7a9e10"
+	userID          = "37a0f027-15e6-47cc-a5d2-64183281087e"
+	subscriptionUrl = "https://www.tiktok.com/@chef"
+	extractionID    = "8c5e5d2b-8d0a-4c0b-9f0a-6a6f4c1d2e3f"
+)
+
+var now = time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeExtractor extrae las URLs con el estado indicado para cada una, o una
+// receta si no hay ninguno.
+type fakeExtractor struct {
+	statuses map[string]string
+	called   []string
+	cancel   context.CancelFunc
+}
+
+func (f *fakeExtractor) Extract(ctx context.Context, userId string, urls []string, record func(subscriptionsdomain.ExtractedItem) error) error {
+	f.called = append(f.called, urls...)
+	if f.cancel != nil {
+		f.cancel()
+		return ctx.Err()
+	}
+	for _, url := range urls {
+		status := f.statuses[url]
+		if status == "" {
+			status = recipesdomain.ExtractionStatusSucceeded
+		}
+		if err := record(subscriptionsdomain.ExtractedItem{Url: url, ExtractionId: extractionID, Status: status}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newUserRepository devuelve un repositorio con el usuario de las
+// suscripciones en el estado indicado.
+func newUserRepository(t *testing.T, status string) *usersstoragemocks.UserRepository {
+	t.Helper()
+	user, err := usersdomain.NewUser(userID, "name", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+	require.NoError(t, user.SetStatus(status))
+	userRepositoryMock := new(usersstoragemocks.UserRepository)
+	userRepositoryMock.On("Get", mock.Anything, user.Id).Return(&user, nil)
+	return userRepositoryMock
+}
+
+func newSubscription(t *testing.T, lastCheckedAt string) subscriptionsdomain.Subscription {
+	t.Helper()
+	subscription, err := subscriptionsdomain.NewSubscription(subscriptionID, userID, subscriptionUrl, time.Hour, 3, "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+	subscription.LastCheckedAt = lastCheckedAt
+	return subscription
+}
+
+func Test_SubscriptionsService_CheckSubscription_ExtractsNewVideos(t *testing.T) {
+	subscription := newSubscription(t, "")
+	subscriptionRepositoryMock := new(storagemocks.SubscriptionRepository)
+	subscriptionRepositoryMock.On("Get", mock.Anything, subscription.Id).Return(&subscription, nil)
+	subscriptionRepositoryMock.On("Update", mock.Anything, mock.MatchedBy(func(updated subscriptionsdomain.Subscription) bool {
+		return updated.LastCheckedAt == "2023-10-01T12:00:00Z"
+	})).Return(nil)
+
+	listerMock := new(listermocks.Lister)
+	listerMock.On("List", mock.Anything, subscriptionUrl, 3).Return([]string{"https://example.com/seen", "https://example.com/new", "https://example.com/broken"}, nil)
+
+	itemRepositoryMock := new(storagemocks.SubscriptionItemRepository)
+	itemRepositoryMock.On("Exists", mock.Anything, subscription.Id, "https://example.com/seen").Return(true, nil)
+	itemRepositoryMock.On("Exists", mock.Anything, subscription.Id, mock.Anything).Return(false, nil)
+	itemRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(item subscriptionsdomain.SubscriptionItem) bool {
+		return item.Url == "https://example.com/new" && item.ExtractionId == extractionID && item.Status == recipesdomain.ExtractionStatusSucceeded
+	})).Return(nil).Once()
+
+	extractor := &fakeExtractor{statuses: map[string]string{"https://example.com/broken": recipesdomain.ExtractionStatusFailed}}
+	service := NewSubscriptionsService(subscriptionRepositoryMock, itemRepositoryMock, newUserRepository(t, usersdomain.UserStatusActive), listerMock, extractor, func() time.Time { return now })
+
+	err := service.CheckSubscription(context.Background(), subscriptionID)
+
+	assert.EqualError(t, err, "1 of 2 extractions failed")
+	assert.Equal(t, []string{"https://example.com/new", "https://example.com/broken"}, extractor.called)
+	subscriptionRepositoryMock.AssertExpectations(t)
+	itemRepositoryMock.AssertExpectations(t)
+	listerMock.AssertExpectations(t)
+}
+
+func Test_SubscriptionsService_CheckSubscription_NotFound(t *testing.T) {
+	subscriptionRepositoryMock := new(storagemocks.SubscriptionRepository)
+	subscriptionRepositoryMock.On("Get", mock.Anything, mock.AnythingOfType("domain.SubscriptionID")).Return(nil, nil)
+
+	service := NewSubscriptionsService(subscriptionRepositoryMock, new(storagemocks.SubscriptionItemRepository), newUserRepository(t, usersdomain.UserStatusActive), new(listermocks.Lister), &fakeExtractor{}, nil)
+
+	err := service.CheckSubscription(context.Background(), subscriptionID)
+
+	assert.ErrorIs(t, err, subscriptionsdomain.ErrSubscriptionNotFound)
+}
+
+func Test_SubscriptionsService_CheckDueSubscriptions_SkipsRecentlyChecked(t *testing.T) {
+	subscriptionRepositoryMock := new(storagemocks.SubscriptionRepository)
+	subscriptionRepositoryMock.On("All", mock.Anything).Return([]subscriptionsdomain.Subscription{newSubscription(t, "2023-10-01T11:30:00Z")}, nil)
+	listerMock := new(listermocks.Lister)
+
+	service := NewSubscriptionsService(subscriptionRepositoryMock, new(storagemocks.SubscriptionItemRepository), newUserRepository(t, usersdomain.UserStatusActive), listerMock, &fakeExtractor{}, func() time.Time { return now })
+
+	err := service.CheckDueSubscriptions(context.Background())
+
+	assert.NoError(t, err)
+	listerMock.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_SubscriptionsService_CheckDueSubscriptions_SkipsDisabledUsers(t *testing.T) {
+	subscriptionRepositoryMock := new(storagemocks.SubscriptionRepository)
+	subscriptionRepositoryMock.On("All", mock.Anything).Return([]subscriptionsdomain.Subscription{newSubscription(t, "2023-10-01T10:00:00Z")}, nil)
+	listerMock := new(listermocks.Lister)
+	extractor := &fakeExtractor{}
+
+	service := NewSubscriptionsService(subscriptionRepositoryMock, new(storagemocks.SubscriptionItemRepository), newUserRepository(t, usersdomain.UserStatusDisabled), listerMock, extractor, func() time.Time { return now })
+
+	err := service.CheckDueSubscriptions(context.Background())
+
+	assert.NoError(t, err)
+	assert.Empty(t, extractor.called)
+	listerMock.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything)
+	// Sigue pendiente para cuando se habilite de nuevo el usuario
+	subscriptionRepositoryMock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func Test_SubscriptionsService_CheckSubscription_DisabledUser(t *testing.T) {
+	subscription := newSubscription(t, "")
+	subscriptionRepositoryMock := new(storagemocks.SubscriptionRepository)
+	subscriptionRepositoryMock.On("Get", mock.Anything, subscription.Id).Return(&subscription, nil)
+	listerMock := new(listermocks.Lister)
+
+	service := NewSubscriptionsService(subscriptionRepositoryMock, new(storagemocks.SubscriptionItemRepository), newUserRepository(t, usersdomain.UserStatusDisabled), listerMock, &fakeExtractor{}, func() time.Time { return now })
+
+	err := service.CheckSubscription(context.Background(), subscriptionID)
+
+	assert.ErrorIs(t, err, subscriptionsdomain.ErrSubscriptionUserDisabled)
+	listerMock.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_SubscriptionsService_CheckDueSubscriptions_RecordsFailedListing(t *testing.T) {
+	subscriptionRepositoryMock := new(storagemocks.SubscriptionRepository)
+	subscriptionRepositoryMock.On("All", mock.Anything).Return([]subscriptionsdomain.Subscription{newSubscription(t, "2023-10-01T10:00:00Z")}, nil)
+	subscriptionRepositoryMock.On("Update", mock.Anything, mock.MatchedBy(func(updated subscriptionsdomain.Subscription) bool {
+		return updated.LastCheckedAt == "2023-10-01T12:00:00Z"
+	})).Return(nil)
+	listerMock := new(listermocks.Lister)
+	listerMock.On("List", mock.Anything, subscriptionUrl, 3).Return(nil, errors.New("yt-dlp failed"))
+
+	service := NewSubscriptionsService(subscriptionRepositoryMock, new(storagemocks.SubscriptionItemRepository), newUserRepository(t, usersdomain.UserStatusActive), listerMock, &fakeExtractor{}, func() time.Time { return now })
+
+	err := service.CheckDueSubscriptions(context.Background())
+
+	assert.ErrorContains(t, err, "yt-dlp failed")
+	subscriptionRepositoryMock.AssertExpectations(t)
+}
+
+func Test_SubscriptionsService_CheckSubscription_Interrupted(t *testing.T) {
+	subscription := newSubscription(t, "")
+	subscriptionRepositoryMock := new(storagemocks.SubscriptionRepository)
+	subscriptionRepositoryMock.On("Get", mock.Anything, subscription.Id).Return(&subscription, nil)
+	listerMock := new(listermocks.Lister)
+	listerMock.On("List", mock.Anything, subscriptionUrl, 3).Return([]string{"https://example.com/new"}, nil)
+	itemRepositoryMock := new(storagemocks.SubscriptionItemRepository)
+	itemRepositoryMock.On("Exists", mock.Anything, subscription.Id, mock.Anything).Return(false, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service := NewSubscriptionsService(subscriptionRepositoryMock, itemRepositoryMock, newUserRepository(t, usersdomain.UserStatusActive), listerMock, &fakeExtractor{cancel: cancel}, func() time.Time { return now })
+
+	err := service.CheckSubscription(ctx, subscriptionID)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	subscriptionRepositoryMock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,67 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const SubscriptionCommandType command.Type = "command.subscription.create"
+
+type SubscriptionCommand struct {
+	id        string
+	userId    string
+	url       string
+	interval  time.Duration
+	maxItems  int
+	createdAt string
+}
+
+func NewSubscriptionCommand(id, userId, url string, interval time.Duration, maxItems int, createdAt string) SubscriptionCommand {
+	return SubscriptionCommand{
+		id:        id,
+		userId:    userId,
+		url:       url,
+		interval:  interval,
+		maxItems:  maxItems,
+		createdAt: createdAt,
+	}
+}
+
+func (c SubscriptionCommand) Type() command.Type {
+	return SubscriptionCommandType
+}
+
+type SubscriptionCommandHandler struct {
+	service SubscriptionService
+}
+
+func NewSubscriptionCommandHandler(service SubscriptionService) SubscriptionCommandHandler {
+	return SubscriptionCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h SubscriptionCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	createSubscriptionCmd, ok := cmd.(SubscriptionCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.CreateSubscription(
+		ctx,
+		createSubscriptionCmd.id,
+		createSubscriptionCmd.userId,
+		createSubscriptionCmd.url,
+		createSubscriptionCmd.interval,
+		createSubscriptionCmd.maxItems,
+		createSubscriptionCmd.createdAt,
+	)
+}
+
+func (h SubscriptionCommandHandler) SubscribedTo() command.Type {
+	return SubscriptionCommandType
+}
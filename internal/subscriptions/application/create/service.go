@@ -0,0 +1,44 @@
+package create
+
+import (
+	"context"
+	"time"
+
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+)
+
+type SubscriptionService struct {
+	subscriptionRepository subscriptionsdomain.SubscriptionRepository
+}
+
+func NewSubscriptionService(subscriptionRepository subscriptionsdomain.SubscriptionRepository) SubscriptionService {
+	return SubscriptionService{
+		subscriptionRepository: subscriptionRepository,
+	}
+}
+
+// CreateSubscription registers a subscription. A user can only subscribe
+// once to the same URL.
+func (s SubscriptionService) CreateSubscription(ctx context.Context, id, userId, url string, interval time.Duration, maxItems int, createdAt string) error {
+	subscription, err := subscriptionsdomain.NewSubscription(id, userId, url, interval, maxItems, createdAt)
+	if err != nil {
+		return err
+	}
+
+	exists, err := s.subscriptionRepository.Exists(ctx, subscription.Id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		exists, err = s.subscriptionRepository.ExistsForUrl(ctx, subscription.UserId, subscription.Url)
+		if err != nil {
+			return err
+		}
+	}
+
+	if exists {
+		return subscriptionsdomain.ErrSubscriptionAlreadyExists
+	}
+
+	return s.subscriptionRepository.Save(ctx, subscription)
+}
@@ -0,0 +1,70 @@
+package create
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	subscriptionID  = "0b6f6a53-2a9e-4f7c-9d54-3b1c2f7a9e10"
+	userID          = "37a0f027-15e6-47cc-a5d2-64183281087e"
+	subscriptionUrl = "https://www.youtube.com/@chef"
+	createdAt       = "2023-10-01T00:00:00Z"
+)
+
+func Test_SubscriptionService_CreateSubscription_Succeed(t *testing.T) {
+	subscriptionRepositoryMock := new(storagemocks.SubscriptionRepository)
+	subscriptionRepositoryMock.On("Exists", mock.Anything, mock.AnythingOfType("domain.SubscriptionID")).Return(false, nil)
+	subscriptionRepositoryMock.On("ExistsForUrl", mock.Anything, mock.AnythingOfType("domain.SubscriptionUserID"), mock.AnythingOfType("domain.SubscriptionUrl")).Return(false, nil)
+	subscriptionRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(subscription subscriptionsdomain.Subscription) bool {
+		return subscription.Interval.Duration() == time.Hour && subscription.MaxItems.Int() == 5 && subscription.LastCheckedAt == ""
+	})).Return(nil)
+
+	subscriptionService := NewSubscriptionService(subscriptionRepositoryMock)
+
+	err := subscriptionService.CreateSubscription(context.Background(), subscriptionID, userID, subscriptionUrl, time.Hour, 5, createdAt)
+
+	subscriptionRepositoryMock.AssertExpectations(t)
+	assert.NoError(t, err)
+}
+
+func Test_SubscriptionService_CreateSubscription_AlreadySubscribed(t *testing.T) {
+	subscriptionRepositoryMock := new(storagemocks.SubscriptionRepository)
+	subscriptionRepositoryMock.On("Exists", mock.Anything, mock.AnythingOfType("domain.SubscriptionID")).Return(false, nil)
+	subscriptionRepositoryMock.On("ExistsForUrl", mock.Anything, mock.AnythingOfType("domain.SubscriptionUserID"), mock.AnythingOfType("domain.SubscriptionUrl")).Return(true, nil)
+
+	subscriptionService := NewSubscriptionService(subscriptionRepositoryMock)
+
+	err := subscriptionService.CreateSubscription(context.Background(), subscriptionID, userID, subscriptionUrl, time.Hour, 5, createdAt)
+
+	subscriptionRepositoryMock.AssertExpectations(t)
+	assert.ErrorIs(t, err, subscriptionsdomain.ErrSubscriptionAlreadyExists)
+}
+
+func Test_SubscriptionService_CreateSubscription_IntervalTooShort(t *testing.T) {
+	subscriptionRepositoryMock := new(storagemocks.SubscriptionRepository)
+
+	subscriptionService := NewSubscriptionService(subscriptionRepositoryMock)
+
+	err := subscriptionService.CreateSubscription(context.Background(), subscriptionID, userID, subscriptionUrl, time.Minute, 5, createdAt)
+
+	subscriptionRepositoryMock.AssertExpectations(t)
+	assert.ErrorIs(t, err, subscriptionsdomain.ErrInvalidSubscriptionInterval)
+}
+
+func Test_SubscriptionService_CreateSubscription_TooManyItems(t *testing.T) {
+	subscriptionRepositoryMock := new(storagemocks.SubscriptionRepository)
+
+	subscriptionService := NewSubscriptionService(subscriptionRepositoryMock)
+
+	err := subscriptionService.CreateSubscription(context.Background(), subscriptionID, userID, subscriptionUrl, time.Hour, subscriptionsdomain.MaxSubscriptionItems+1, createdAt)
+
+	subscriptionRepositoryMock.AssertExpectations(t)
+	assert.ErrorIs(t, err, subscriptionsdomain.ErrInvalidSubscriptionMaxItems)
+}
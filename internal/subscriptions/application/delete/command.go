@@ -0,0 +1,48 @@
+package delete
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const SubscriptionCommandType command.Type = "command.subscription.delete"
+
+type SubscriptionCommand struct {
+	id string
+}
+
+func NewSubscriptionCommand(id string) SubscriptionCommand {
+	return SubscriptionCommand{
+		id: id,
+	}
+}
+
+func (c SubscriptionCommand) Type() command.Type {
+	return SubscriptionCommandType
+}
+
+type SubscriptionCommandHandler struct {
+	service SubscriptionService
+}
+
+func NewSubscriptionCommandHandler(service SubscriptionService) SubscriptionCommandHandler {
+	return SubscriptionCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h SubscriptionCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	deleteSubscriptionCmd, ok := cmd.(SubscriptionCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.DeleteSubscription(ctx, deleteSubscriptionCmd.id)
+}
+
+func (h SubscriptionCommandHandler) SubscribedTo() command.Type {
+	return SubscriptionCommandType
+}
@@ -0,0 +1,36 @@
+package delete
+
+import (
+	"context"
+
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+)
+
+type SubscriptionService struct {
+	subscriptionRepository subscriptionsdomain.SubscriptionRepository
+}
+
+func NewSubscriptionService(subscriptionRepository subscriptionsdomain.SubscriptionRepository) SubscriptionService {
+	return SubscriptionService{
+		subscriptionRepository: subscriptionRepository,
+	}
+}
+
+// DeleteSubscription deletes the subscription and the record of its videos.
+// The extractions are kept.
+func (s SubscriptionService) DeleteSubscription(ctx context.Context, id string) error {
+	idVO, err := subscriptionsdomain.NewSubscriptionID(id)
+	if err != nil {
+		return err
+	}
+
+	exists, err := s.subscriptionRepository.Exists(ctx, idVO)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return subscriptionsdomain.ErrSubscriptionNotFound
+	}
+
+	return s.subscriptionRepository.Delete(ctx, idVO)
+}
@@ -0,0 +1,38 @@
+package delete
+
+import (
+	"context"
+	"testing"
+
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const subscriptionID = "0b6f6a53-2a9e-4f7c-9d54-3b1c2f7a9e10"
+
+func Test_SubscriptionService_DeleteSubscription_Succeed(t *testing.T) {
+	subscriptionRepositoryMock := new(storagemocks.SubscriptionRepository)
+	subscriptionRepositoryMock.On("Exists", mock.Anything, mock.AnythingOfType("domain.SubscriptionID")).Return(true, nil)
+	subscriptionRepositoryMock.On("Delete", mock.Anything, mock.AnythingOfType("domain.SubscriptionID")).Return(nil)
+
+	subscriptionService := NewSubscriptionService(subscriptionRepositoryMock)
+
+	err := subscriptionService.DeleteSubscription(context.Background(), subscriptionID)
+
+	subscriptionRepositoryMock.AssertExpectations(t)
+	assert.NoError(t, err)
+}
+
+func Test_SubscriptionService_DeleteSubscription_NotFound(t *testing.T) {
+	subscriptionRepositoryMock := new(storagemocks.SubscriptionRepository)
+	subscriptionRepositoryMock.On("Exists", mock.Anything, mock.AnythingOfType("domain.SubscriptionID")).Return(false, nil)
+
+	subscriptionService := NewSubscriptionService(subscriptionRepositoryMock)
+
+	err := subscriptionService.DeleteSubscription(context.Background(), subscriptionID)
+
+	subscriptionRepositoryMock.AssertExpectations(t)
+	assert.ErrorIs(t, err, subscriptionsdomain.ErrSubscriptionNotFound)
+}
@@ -0,0 +1,48 @@
+package get
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+const SubscriptionQueryType query.Type = "query.subscription.get"
+
+type SubscriptionQuery struct {
+	id string
+}
+
+func NewSubscriptionQuery(id string) SubscriptionQuery {
+	return SubscriptionQuery{
+		id: id,
+	}
+}
+
+func (c SubscriptionQuery) Type() query.Type {
+	return SubscriptionQueryType
+}
+
+type SubscriptionQueryHandler struct {
+	service SubscriptionService
+}
+
+func NewSubscriptionQueryHandler(service SubscriptionService) SubscriptionQueryHandler {
+	return SubscriptionQueryHandler{
+		service: service,
+	}
+}
+
+// Handle implements the query.Handler interface.
+func (h SubscriptionQueryHandler) Handle(ctx context.Context, qry query.Query) (interface{}, error) {
+	subscriptionQuery, ok := qry.(SubscriptionQuery)
+	if !ok {
+		return nil, errors.New("unexpected query")
+	}
+
+	return h.service.GetSubscription(ctx, subscriptionQuery.id)
+}
+
+func (h SubscriptionQueryHandler) SubscribedTo() query.Type {
+	return SubscriptionQueryType
+}
@@ -0,0 +1,47 @@
+package get
+
+import (
+	"context"
+
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+)
+
+// SubscriptionDetail is a subscription with the videos extracted by it.
+type SubscriptionDetail struct {
+	Subscription subscriptionsdomain.Subscription
+	Items        []subscriptionsdomain.SubscriptionItem
+}
+
+type SubscriptionService struct {
+	subscriptionRepository subscriptionsdomain.SubscriptionRepository
+	itemRepository         subscriptionsdomain.SubscriptionItemRepository
+}
+
+func NewSubscriptionService(subscriptionRepository subscriptionsdomain.SubscriptionRepository, itemRepository subscriptionsdomain.SubscriptionItemRepository) SubscriptionService {
+	return SubscriptionService{
+		subscriptionRepository: subscriptionRepository,
+		itemRepository:         itemRepository,
+	}
+}
+
+func (s SubscriptionService) GetSubscription(ctx context.Context, id string) (*SubscriptionDetail, error) {
+	idVO, err := subscriptionsdomain.NewSubscriptionID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.subscriptionRepository.Get(ctx, idVO)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		return nil, subscriptionsdomain.ErrSubscriptionNotFound
+	}
+
+	items, err := s.itemRepository.GetBySubscriptionID(ctx, idVO)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubscriptionDetail{Subscription: *subscription, Items: items}, nil
+}
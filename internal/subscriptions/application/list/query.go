@@ -0,0 +1,48 @@
+package list
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+const SubscriptionsQueryType query.Type = "query.subscription.list"
+
+type SubscriptionsQuery struct {
+	userId string
+}
+
+func NewSubscriptionsQuery(userId string) SubscriptionsQuery {
+	return SubscriptionsQuery{
+		userId: userId,
+	}
+}
+
+func (c SubscriptionsQuery) Type() query.Type {
+	return SubscriptionsQueryType
+}
+
+type SubscriptionsQueryHandler struct {
+	service SubscriptionsService
+}
+
+func NewSubscriptionsQueryHandler(service SubscriptionsService) SubscriptionsQueryHandler {
+	return SubscriptionsQueryHandler{
+		service: service,
+	}
+}
+
+// Handle implements the query.Handler interface.
+func (h SubscriptionsQueryHandler) Handle(ctx context.Context, qry query.Query) (interface{}, error) {
+	subscriptionsQuery, ok := qry.(SubscriptionsQuery)
+	if !ok {
+		return nil, errors.New("unexpected query")
+	}
+
+	return h.service.GetSubscriptions(ctx, subscriptionsQuery.userId)
+}
+
+func (h SubscriptionsQueryHandler) SubscribedTo() query.Type {
+	return SubscriptionsQueryType
+}
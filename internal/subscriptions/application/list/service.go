@@ -0,0 +1,26 @@
+package list
+
+import (
+	"context"
+
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+)
+
+type SubscriptionsService struct {
+	subscriptionRepository subscriptionsdomain.SubscriptionRepository
+}
+
+func NewSubscriptionsService(subscriptionRepository subscriptionsdomain.SubscriptionRepository) SubscriptionsService {
+	return SubscriptionsService{
+		subscriptionRepository: subscriptionRepository,
+	}
+}
+
+func (s SubscriptionsService) GetSubscriptions(ctx context.Context, userId string) ([]subscriptionsdomain.Subscription, error) {
+	userIdVO, err := subscriptionsdomain.NewSubscriptionUserID(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.subscriptionRepository.GetByUserID(ctx, userIdVO)
+}
@@ -0,0 +1,60 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const SubscriptionCommandType command.Type = "command.subscription.update"
+
+type SubscriptionCommand struct {
+	id       string
+	interval time.Duration
+	maxItems int
+}
+
+// NewSubscriptionCommand changes the interval and the maximum number of
+// videos of a subscription. The zero values keep the current ones.
+func NewSubscriptionCommand(id string, interval time.Duration, maxItems int) SubscriptionCommand {
+	return SubscriptionCommand{
+		id:       id,
+		interval: interval,
+		maxItems: maxItems,
+	}
+}
+
+func (c SubscriptionCommand) Type() command.Type {
+	return SubscriptionCommandType
+}
+
+type SubscriptionCommandHandler struct {
+	service SubscriptionService
+}
+
+func NewSubscriptionCommandHandler(service SubscriptionService) SubscriptionCommandHandler {
+	return SubscriptionCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h SubscriptionCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	updateSubscriptionCmd, ok := cmd.(SubscriptionCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.UpdateSubscription(
+		ctx,
+		updateSubscriptionCmd.id,
+		updateSubscriptionCmd.interval,
+		updateSubscriptionCmd.maxItems,
+	)
+}
+
+func (h SubscriptionCommandHandler) SubscribedTo() command.Type {
+	return SubscriptionCommandType
+}
@@ -0,0 +1,46 @@
+package update
+
+import (
+	"context"
+	"time"
+
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+)
+
+type SubscriptionService struct {
+	subscriptionRepository subscriptionsdomain.SubscriptionRepository
+}
+
+func NewSubscriptionService(subscriptionRepository subscriptionsdomain.SubscriptionRepository) SubscriptionService {
+	return SubscriptionService{
+		subscriptionRepository: subscriptionRepository,
+	}
+}
+
+func (s SubscriptionService) UpdateSubscription(ctx context.Context, id string, interval time.Duration, maxItems int) error {
+	idVO, err := subscriptionsdomain.NewSubscriptionID(id)
+	if err != nil {
+		return err
+	}
+
+	subscription, err := s.subscriptionRepository.Get(ctx, idVO)
+	if err != nil {
+		return err
+	}
+	if subscription == nil {
+		return subscriptionsdomain.ErrSubscriptionNotFound
+	}
+
+	if interval != 0 {
+		if subscription.Interval, err = subscriptionsdomain.NewSubscriptionInterval(interval); err != nil {
+			return err
+		}
+	}
+	if maxItems != 0 {
+		if subscription.MaxItems, err = subscriptionsdomain.NewSubscriptionMaxItems(maxItems); err != nil {
+			return err
+		}
+	}
+
+	return s.subscriptionRepository.Update(ctx, *subscription)
+}
@@ -0,0 +1,45 @@
+package update
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const subscriptionID = "0b6f6a53-2a9e-4f7c-9d54-3b1c2f7a9e10"
+
+func Test_SubscriptionService_UpdateSubscription_KeepsUnsetValues(t *testing.T) {
+	subscription, err := subscriptionsdomain.NewSubscription(subscriptionID, "37a0f027-15e6-47cc-a5d2-64183281087e", "https://www.youtube.com/@chef", time.Hour, 5, "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+
+	subscriptionRepositoryMock := new(storagemocks.SubscriptionRepository)
+	subscriptionRepositoryMock.On("Get", mock.Anything, subscription.Id).Return(&subscription, nil)
+	subscriptionRepositoryMock.On("Update", mock.Anything, mock.MatchedBy(func(updated subscriptionsdomain.Subscription) bool {
+		return updated.Interval.Duration() == time.Hour && updated.MaxItems.Int() == 20
+	})).Return(nil)
+
+	subscriptionService := NewSubscriptionService(subscriptionRepositoryMock)
+
+	err = subscriptionService.UpdateSubscription(context.Background(), subscriptionID, 0, 20)
+
+	subscriptionRepositoryMock.AssertExpectations(t)
+	assert.NoError(t, err)
+}
+
+func Test_SubscriptionService_UpdateSubscription_NotFound(t *testing.T) {
+	subscriptionRepositoryMock := new(storagemocks.SubscriptionRepository)
+	subscriptionRepositoryMock.On("Get", mock.Anything, mock.AnythingOfType("domain.SubscriptionID")).Return(nil, nil)
+
+	subscriptionService := NewSubscriptionService(subscriptionRepositoryMock)
+
+	err := subscriptionService.UpdateSubscription(context.Background(), subscriptionID, time.Hour, 0)
+
+	subscriptionRepositoryMock.AssertExpectations(t)
+	assert.ErrorIs(t, err, subscriptionsdomain.ErrSubscriptionNotFound)
+}
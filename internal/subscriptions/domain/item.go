@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// SubscriptionItem is a video of a subscription whose recipe has been
+// extracted, so it is not extracted again on the next checks.
+type SubscriptionItem struct {
+	SubscriptionId SubscriptionID
+	Url            string
+	// ExtractionId is the extraction of the video, which may be an extraction
+	// of the user made before the subscription.
+	ExtractionId string
+	// Status is the status of the extraction (succeeded or not_a_recipe), or
+	// skipped if the user had already extracted the video.
+	Status    string
+	CreatedAt string
+}
+
+type SubscriptionItemRepository interface {
+	Save(ctx context.Context, item SubscriptionItem) error
+	Exists(ctx context.Context, subscriptionId SubscriptionID, url string) (bool, error)
+	GetBySubscriptionID(ctx context.Context, subscriptionId SubscriptionID) ([]SubscriptionItem, error)
+}
+
+//mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=SubscriptionItemRepository
+
+func NewSubscriptionItem(subscriptionId, url, extractionId, status, createdAt string) (SubscriptionItem, error) {
+	subscriptionIdVO, err := NewSubscriptionID(subscriptionId)
+	if err != nil {
+		return SubscriptionItem{}, err
+	}
+
+	if url == "" {
+		return SubscriptionItem{}, errors.New("the field Subscription Item URL can not be empty")
+	}
+	if status == "" {
+		return SubscriptionItem{}, errors.New("the field Subscription Item Status can not be empty")
+	}
+	if _, err := time.Parse(time.RFC3339, createdAt); err != nil {
+		return SubscriptionItem{}, errors.New("the field Subscription Item Created At must be a valid RFC3339 date")
+	}
+
+	return SubscriptionItem{
+		SubscriptionId: subscriptionIdVO,
+		Url:            url,
+		ExtractionId:   extractionId,
+		Status:         status,
+		CreatedAt:      createdAt,
+	}, nil
+}
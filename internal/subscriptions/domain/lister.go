@@ -0,0 +1,26 @@
+package domain
+
+import "context"
+
+// Lister lists the URLs of the most recent videos of a profile, channel or
+// playlist, without downloading them.
+type Lister interface {
+	List(ctx context.Context, url string, limit int) ([]string, error)
+}
+
+//mockery --case=snake --outpkg=listermocks --output=platform/lister/listermocks --name=Lister
+
+// ExtractedItem is the result of the extraction of a video of a subscription.
+type ExtractedItem struct {
+	Url          string
+	ExtractionId string
+	// Status is the status of the extraction (succeeded, not_a_recipe or
+	// failed), or skipped if the user had already extracted the video.
+	Status string
+}
+
+// Extractor extracts the recipes of the videos under the user and calls
+// record with the result of every video as soon as it finishes.
+type Extractor interface {
+	Extract(ctx context.Context, userId string, urls []string, record func(ExtractedItem) error) error
+}
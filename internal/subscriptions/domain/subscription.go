@@ -0,0 +1,241 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidSubscriptionID = errors.New("invalid Subscription ID")
+var ErrInvalidSubscriptionUserID = errors.New("invalid Subscription User ID")
+var ErrInvalidSubscriptionUrl = errors.New("invalid Subscription URL")
+var ErrInvalidSubscriptionInterval = errors.New("invalid Subscription Interval")
+var ErrInvalidSubscriptionMaxItems = errors.New("invalid Subscription Max Items")
+var ErrSubscriptionAlreadyExists = errors.New("subscription already exists")
+var ErrSubscriptionNotFound = errors.New("subscription not found")
+var ErrSubscriptionUserDisabled = errors.New("the user of the subscription is disabled")
+
+const (
+	// MinSubscriptionInterval is the minimum wait between two checks of a
+	// subscription, so that the platforms do not block the listings.
+	MinSubscriptionInterval = 15 * time.Minute
+	// DefaultSubscriptionInterval is the interval of the subscriptions
+	// created without one.
+	DefaultSubscriptionInterval = 6 * time.Hour
+	// MaxSubscriptionItems is the maximum number of videos listed on every
+	// check of a subscription.
+	MaxSubscriptionItems = 50
+	// DefaultSubscriptionMaxItems is the number of videos listed by the
+	// subscriptions created without one.
+	DefaultSubscriptionMaxItems = 10
+)
+
+type SubscriptionID struct {
+	value string
+}
+
+func NewSubscriptionID(value string) (SubscriptionID, error) {
+	v, err := uuid.Parse(value)
+	if err != nil {
+		return SubscriptionID{}, fmt.Errorf("%w: %s", ErrInvalidSubscriptionID, value)
+	}
+
+	return SubscriptionID{
+		value: v.String(),
+	}, nil
+}
+
+func (id SubscriptionID) String() string {
+	return id.value
+}
+
+type SubscriptionUserID struct {
+	value string
+}
+
+func NewSubscriptionUserID(value string) (SubscriptionUserID, error) {
+	v, err := uuid.Parse(value)
+	if err != nil {
+		return SubscriptionUserID{}, fmt.Errorf("%w: %s", ErrInvalidSubscriptionUserID, value)
+	}
+
+	return SubscriptionUserID{
+		value: v.String(),
+	}, nil
+}
+
+func (id SubscriptionUserID) String() string {
+	return id.value
+}
+
+// SubscriptionUrl is the absolute http or https URL of the profile, channel or
+// playlist watched.
+type SubscriptionUrl struct {
+	value string
+}
+
+func NewSubscriptionUrl(value string) (SubscriptionUrl, error) {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return SubscriptionUrl{}, fmt.Errorf("%w: %s", ErrInvalidSubscriptionUrl, value)
+	}
+
+	return SubscriptionUrl{
+		value: u.String(),
+	}, nil
+}
+
+func (u SubscriptionUrl) String() string {
+	return u.value
+}
+
+// SubscriptionInterval is the wait between two checks of a subscription.
+type SubscriptionInterval struct {
+	value time.Duration
+}
+
+func NewSubscriptionInterval(value time.Duration) (SubscriptionInterval, error) {
+	if value < MinSubscriptionInterval {
+		return SubscriptionInterval{}, fmt.Errorf("%w: it must be at least %s", ErrInvalidSubscriptionInterval, MinSubscriptionInterval)
+	}
+
+	return SubscriptionInterval{
+		value: value,
+	}, nil
+}
+
+func (interval SubscriptionInterval) Duration() time.Duration {
+	return interval.value
+}
+
+func (interval SubscriptionInterval) String() string {
+	return interval.value.String()
+}
+
+// SubscriptionMaxItems is the number of most recent videos listed on every
+// check of a subscription.
+type SubscriptionMaxItems struct {
+	value int
+}
+
+func NewSubscriptionMaxItems(value int) (SubscriptionMaxItems, error) {
+	if value < 1 || value > MaxSubscriptionItems {
+		return SubscriptionMaxItems{}, fmt.Errorf("%w: it must be between 1 and %d", ErrInvalidSubscriptionMaxItems, MaxSubscriptionItems)
+	}
+
+	return SubscriptionMaxItems{
+		value: value,
+	}, nil
+}
+
+func (maxItems SubscriptionMaxItems) Int() int {
+	return maxItems.value
+}
+
+type SubscriptionCreatedAt struct {
+	value string
+}
+
+func NewSubscriptionCreatedAt(value string) (SubscriptionCreatedAt, error) {
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		return SubscriptionCreatedAt{}, errors.New("the field Subscription Created At must be a valid RFC3339 date")
+	}
+
+	return SubscriptionCreatedAt{
+		value: value,
+	}, nil
+}
+
+func (createdAt SubscriptionCreatedAt) String() string {
+	return createdAt.value
+}
+
+// Subscription watches a profile, channel or playlist and extracts the
+// recipes of its new videos under the user.
+type Subscription struct {
+	Id       SubscriptionID
+	UserId   SubscriptionUserID
+	Url      SubscriptionUrl
+	Interval SubscriptionInterval
+	MaxItems SubscriptionMaxItems
+	// LastCheckedAt is the RFC3339 date of the last check, or empty if it has
+	// not been checked yet.
+	LastCheckedAt string
+	CreatedAt     SubscriptionCreatedAt
+}
+
+type SubscriptionRepository interface {
+	Save(ctx context.Context, subscription Subscription) error
+	Update(ctx context.Context, subscription Subscription) error
+	Delete(ctx context.Context, id SubscriptionID) error
+	Exists(ctx context.Context, id SubscriptionID) (bool, error)
+	ExistsForUrl(ctx context.Context, userId SubscriptionUserID, url SubscriptionUrl) (bool, error)
+	Get(ctx context.Context, id SubscriptionID) (*Subscription, error)
+	GetByUserID(ctx context.Context, userId SubscriptionUserID) ([]Subscription, error)
+	All(ctx context.Context) ([]Subscription, error)
+}
+
+//mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=SubscriptionRepository
+
+func NewSubscription(id, userId, subscriptionUrl string, interval time.Duration, maxItems int, createdAt string) (Subscription, error) {
+	idVO, err := NewSubscriptionID(id)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	userIdVO, err := NewSubscriptionUserID(userId)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	urlVO, err := NewSubscriptionUrl(subscriptionUrl)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	intervalVO, err := NewSubscriptionInterval(interval)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	maxItemsVO, err := NewSubscriptionMaxItems(maxItems)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	createdAtVO, err := NewSubscriptionCreatedAt(createdAt)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	return Subscription{
+		Id:        idVO,
+		UserId:    userIdVO,
+		Url:       urlVO,
+		Interval:  intervalVO,
+		MaxItems:  maxItemsVO,
+		CreatedAt: createdAtVO,
+	}, nil
+}
+
+// Due reports whether the subscription has to be checked: it has never been
+// checked or its interval has passed since the last check.
+func (s Subscription) Due(now time.Time) bool {
+	if s.LastCheckedAt == "" {
+		return true
+	}
+	lastCheckedAt, err := time.Parse(time.RFC3339, s.LastCheckedAt)
+	if err != nil {
+		return true
+	}
+	return !now.Before(lastCheckedAt.Add(s.Interval.Duration()))
+}
+
+// Checked records a check of the subscription.
+func (s *Subscription) Checked(now time.Time) {
+	s.LastCheckedAt = now.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/application/check"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+type CheckSubscriptionsInput struct {
+	// ID es la suscripción a comprobar; si está vacío se comprueban todas las
+	// suscripciones cuyo intervalo ha pasado
+	ID string
+}
+
+type CheckSubscriptionsHandler func(context.Context, CheckSubscriptionsInput) error
+
+func CreateCheckSubscriptionsHandler(commandBus command.Bus) CheckSubscriptionsHandler {
+	return func(ctx context.Context, input CheckSubscriptionsInput) error {
+		err := commandBus.Dispatch(ctx, check.NewSubscriptionsCommand(input.ID))
+		if err != nil {
+			if isDomainError(err) {
+				return fmt.Errorf("error de dominio: %w", err)
+			}
+			return err
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/application/create"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+type CreateSubscriptionInput struct {
+	ID     string
+	UserID string
+	Url    string
+	// Interval y MaxItems toman los valores por defecto del dominio si son cero
+	Interval  time.Duration
+	MaxItems  int
+	CreatedAt string
+}
+
+type CreateSubscriptionHandler func(context.Context, CreateSubscriptionInput) error
+
+func CreateCreateSubscriptionHandler(commandBus command.Bus) CreateSubscriptionHandler {
+	return func(ctx context.Context, input CreateSubscriptionInput) error {
+		if input.ID == "" || input.UserID == "" || input.Url == "" || input.CreatedAt == "" {
+			return fmt.Errorf("todos los campos son obligatorios")
+		}
+		if input.Interval == 0 {
+			input.Interval = subscriptionsdomain.DefaultSubscriptionInterval
+		}
+		if input.MaxItems == 0 {
+			input.MaxItems = subscriptionsdomain.DefaultSubscriptionMaxItems
+		}
+
+		err := commandBus.Dispatch(ctx, create.NewSubscriptionCommand(
+			input.ID,
+			input.UserID,
+			input.Url,
+			input.Interval,
+			input.MaxItems,
+			input.CreatedAt,
+		))
+
+		if err != nil {
+			if isDomainError(err) {
+				return fmt.Errorf("error de dominio: %w", err)
+			}
+			return fmt.Errorf("error interno: %w", err)
+		}
+
+		return nil
+	}
+}
+
+func isDomainError(err error) bool {
+	return errors.Is(err, subscriptionsdomain.ErrInvalidSubscriptionID) ||
+		errors.Is(err, subscriptionsdomain.ErrInvalidSubscriptionUserID) ||
+		errors.Is(err, subscriptionsdomain.ErrInvalidSubscriptionUrl) ||
+		errors.Is(err, subscriptionsdomain.ErrInvalidSubscriptionInterval) ||
+		errors.Is(err, subscriptionsdomain.ErrInvalidSubscriptionMaxItems) ||
+		errors.Is(err, subscriptionsdomain.ErrSubscriptionAlreadyExists) ||
+		errors.Is(err, subscriptionsdomain.ErrSubscriptionNotFound)
+}
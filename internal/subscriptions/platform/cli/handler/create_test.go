@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/application/create"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newCreateSubscriptionInput() CreateSubscriptionInput {
+	return CreateSubscriptionInput{
+		ID:        "0b6f6a53-2a9e-4f7c-9d54-3b1c2f7a9e10",
+		UserID:    "37a0f027-15e6-47cc-a5d2-64183281087e",
+		Url:       "https://www.youtube.com/@chef",
+		CreatedAt: "2023-01-01T00:00:00Z",
+	}
+}
+
+func TestCreateSubscriptionHandler_Defaults(t *testing.T) {
+	input := newCreateSubscriptionInput()
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, create.NewSubscriptionCommand(input.ID, input.UserID, input.Url, subscriptionsdomain.DefaultSubscriptionInterval, subscriptionsdomain.DefaultSubscriptionMaxItems, input.CreatedAt)).Return(nil)
+	handler := CreateCreateSubscriptionHandler(bus)
+	err := handler(context.Background(), input)
+	assert.NoError(t, err)
+	bus.AssertExpectations(t)
+}
+
+func TestCreateSubscriptionHandler_ErrorDominio(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("create.SubscriptionCommand")).Return(subscriptionsdomain.ErrSubscriptionAlreadyExists)
+	handler := CreateCreateSubscriptionHandler(bus)
+	input := newCreateSubscriptionInput()
+	input.Interval = time.Hour
+	err := handler(context.Background(), input)
+	assert.ErrorIs(t, err, subscriptionsdomain.ErrSubscriptionAlreadyExists)
+	assert.Contains(t, err.Error(), "error de dominio")
+}
+
+func TestCreateSubscriptionHandler_ErrorInterno(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("create.SubscriptionCommand")).Return(errors.New("fallo"))
+	handler := CreateCreateSubscriptionHandler(bus)
+	err := handler(context.Background(), newCreateSubscriptionInput())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error interno")
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/application/delete"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+type DeleteSubscriptionInput struct {
+	ID string
+}
+
+type DeleteSubscriptionHandler func(context.Context, DeleteSubscriptionInput) error
+
+func CreateDeleteSubscriptionHandler(commandBus command.Bus) DeleteSubscriptionHandler {
+	return func(ctx context.Context, input DeleteSubscriptionInput) error {
+		if input.ID == "" {
+			return fmt.Errorf("el campo ID es obligatorio")
+		}
+
+		err := commandBus.Dispatch(ctx, delete.NewSubscriptionCommand(input.ID))
+		if err != nil {
+			if isDomainError(err) {
+				return fmt.Errorf("error de dominio: %w", err)
+			}
+			return fmt.Errorf("error interno: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/application/get"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+type GetSubscriptionInput struct {
+	ID string
+}
+
+type GetSubscriptionItemOutput struct {
+	Url          string `json:"url"`
+	ExtractionID string `json:"extractionId,omitempty"`
+	Status       string `json:"status"`
+	CreatedAt    string `json:"createdAt"`
+}
+
+type GetSubscriptionDetailOutput struct {
+	GetSubscriptionOutput
+	Items []GetSubscriptionItemOutput `json:"items"`
+}
+
+type GetSubscriptionHandler func(context.Context, GetSubscriptionInput) (GetSubscriptionDetailOutput, error)
+
+func CreateGetSubscriptionHandler(queryBus query.Bus) GetSubscriptionHandler {
+	return func(ctx context.Context, input GetSubscriptionInput) (GetSubscriptionDetailOutput, error) {
+		if input.ID == "" {
+			return GetSubscriptionDetailOutput{}, fmt.Errorf("el campo ID es obligatorio")
+		}
+
+		result, err := queryBus.Ask(ctx, get.NewSubscriptionQuery(input.ID))
+		if err != nil {
+			if isDomainError(err) {
+				return GetSubscriptionDetailOutput{}, fmt.Errorf("error de dominio: %w", err)
+			}
+			return GetSubscriptionDetailOutput{}, fmt.Errorf("error al buscar la suscripción: %w", err)
+		}
+
+		detail, ok := result.(*get.SubscriptionDetail)
+		if !ok || detail == nil {
+			return GetSubscriptionDetailOutput{}, fmt.Errorf("respuesta inesperada del query")
+		}
+
+		output := GetSubscriptionDetailOutput{
+			GetSubscriptionOutput: toSubscriptionOutput(detail.Subscription),
+			Items:                 make([]GetSubscriptionItemOutput, 0, len(detail.Items)),
+		}
+		for _, item := range detail.Items {
+			output.Items = append(output.Items, GetSubscriptionItemOutput{
+				Url:          item.Url,
+				ExtractionID: item.ExtractionId,
+				Status:       item.Status,
+				CreatedAt:    item.CreatedAt,
+			})
+		}
+		return output, nil
+	}
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/application/list"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+type GetSubscriptionsInput struct {
+	UserID string
+}
+
+type GetSubscriptionOutput struct {
+	ID            string `json:"id"`
+	UserID        string `json:"userId"`
+	Url           string `json:"url"`
+	Interval      string `json:"interval"`
+	MaxItems      int    `json:"maxItems"`
+	LastCheckedAt string `json:"lastCheckedAt,omitempty"`
+	CreatedAt     string `json:"createdAt"`
+}
+
+type GetSubscriptionsHandler func(context.Context, GetSubscriptionsInput) ([]GetSubscriptionOutput, error)
+
+func CreateGetSubscriptionsHandler(queryBus query.Bus) GetSubscriptionsHandler {
+	return func(ctx context.Context, input GetSubscriptionsInput) ([]GetSubscriptionOutput, error) {
+		if input.UserID == "" {
+			return nil, fmt.Errorf("el campo UserID es obligatorio")
+		}
+
+		result, err := queryBus.Ask(ctx, list.NewSubscriptionsQuery(input.UserID))
+		if err != nil {
+			return nil, fmt.Errorf("error al buscar suscripciones: %w", err)
+		}
+
+		subscriptions, ok := result.([]subscriptionsdomain.Subscription)
+		if !ok {
+			return nil, fmt.Errorf("respuesta inesperada del query")
+		}
+
+		output := make([]GetSubscriptionOutput, 0, len(subscriptions))
+		for _, subscription := range subscriptions {
+			output = append(output, toSubscriptionOutput(subscription))
+		}
+		return output, nil
+	}
+}
+
+func toSubscriptionOutput(subscription subscriptionsdomain.Subscription) GetSubscriptionOutput {
+	return GetSubscriptionOutput{
+		ID:            subscription.Id.String(),
+		UserID:        subscription.UserId.String(),
+		Url:           subscription.Url.String(),
+		Interval:      subscription.Interval.String(),
+		MaxItems:      subscription.MaxItems.Int(),
+		LastCheckedAt: subscription.LastCheckedAt,
+		CreatedAt:     subscription.CreatedAt.String(),
+	}
+}
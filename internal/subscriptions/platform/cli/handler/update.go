@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/application/update"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+type UpdateSubscriptionInput struct {
+	ID string
+	// Interval y MaxItems se mantienen si son cero
+	Interval time.Duration
+	MaxItems int
+}
+
+type UpdateSubscriptionHandler func(context.Context, UpdateSubscriptionInput) error
+
+func CreateUpdateSubscriptionHandler(commandBus command.Bus) UpdateSubscriptionHandler {
+	return func(ctx context.Context, input UpdateSubscriptionInput) error {
+		if input.ID == "" {
+			return fmt.Errorf("el campo ID es obligatorio")
+		}
+		if input.Interval == 0 && input.MaxItems == 0 {
+			return fmt.Errorf("no hay nada que actualizar")
+		}
+
+		err := commandBus.Dispatch(ctx, update.NewSubscriptionCommand(input.ID, input.Interval, input.MaxItems))
+		if err != nil {
+			if isDomainError(err) {
+				return fmt.Errorf("error de dominio: %w", err)
+			}
+			return fmt.Errorf("error interno: %w", err)
+		}
+
+		return nil
+	}
+}
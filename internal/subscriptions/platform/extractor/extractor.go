@@ -0,0 +1,76 @@
+package extractor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/batch"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/quota"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/i18n"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+)
+
+// BatchExtractor implements subscriptionsdomain.Extractor with the runner of
+// the batch extractions, so the videos of a subscription are extracted like a
+// batch of the user: in parallel, in the locale of the user and skipping the
+// videos the user has already extracted. The extractions count towards the
+// personal quota of the user, which is checked before each one.
+type BatchExtractor struct {
+	runner         batch.Runner
+	userRepository usersdomain.UserRepository
+	quota          quota.Checker
+	concurrency    int
+}
+
+// NewBatchExtractor initializes a BatchExtractor that runs concurrency
+// extractions at a time.
+func NewBatchExtractor(runner batch.Runner, userRepository usersdomain.UserRepository, checker quota.Checker, concurrency int) BatchExtractor {
+	return BatchExtractor{
+		runner:         runner,
+		userRepository: userRepository,
+		quota:          checker,
+		concurrency:    concurrency,
+	}
+}
+
+// Extract implements the subscriptionsdomain.Extractor interface.
+func (e BatchExtractor) Extract(ctx context.Context, userId string, urls []string, record func(subscriptionsdomain.ExtractedItem) error) error {
+	userIdVO, err := usersdomain.NewUserID(userId)
+	if err != nil {
+		return err
+	}
+	user, err := e.userRepository.Get(ctx, userIdVO)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("the user of the subscription does not exist")
+	}
+	if user.IsDisabled() {
+		return subscriptionsdomain.ErrSubscriptionUserDisabled
+	}
+	locale, err := i18n.ResolveLocale("", user.Locale.String())
+	if err != nil {
+		return err
+	}
+
+	opts := batch.Options{UserID: userId, Locale: locale, Concurrency: e.concurrency, Quota: e.quota.For(*user, nil)}
+	summary, err := e.runner.Run(ctx, batch.FromUrls(urls), opts, func(res batch.Result) error {
+		// Los vídeos sin extraer por la cuota no se registran, para
+		// extraerlos en la siguiente comprobación
+		if res.Status == batch.StatusQuotaExceeded {
+			return nil
+		}
+		return record(subscriptionsdomain.ExtractedItem{
+			Url:          res.Url,
+			ExtractionId: res.RecipeID,
+			Status:       res.Status,
+		})
+	})
+	if err == nil && summary.QuotaExceeded > 0 {
+		err = fmt.Errorf("%d of %d videos not extracted: %w", summary.QuotaExceeded, len(urls), usersdomain.ErrUserQuotaExceeded)
+	}
+	return err
+}
@@ -0,0 +1,99 @@
+package extractor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/batch"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/quota"
+	recipesstoragemocks "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/storage/storagemocks"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/i18n"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/storagemocks"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/rubenbupe/recipe-video-parser/kit/query/querymocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userID       = "37a0f027-15e6-47cc-a5d2-64183281087e"
+	extractionID = "8c5e5d2b-8d0a-4c0b-9f0a-6a6f4c1d2e3f"
+)
+
+func TestBatchExtractor_Extract(t *testing.T) {
+	user, err := usersdomain.NewUser(userID, "name", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+	user.Locale, err = usersdomain.NewUserLocale("en-GB")
+	require.NoError(t, err)
+	userRepository := new(storagemocks.UserRepository)
+	userRepository.On("Get", mock.Anything, user.Id).Return(&user, nil)
+
+	var mu sync.Mutex
+	var locales []string
+	extract := func(ctx context.Context, url string, locale i18n.Locale) (ai.AiResponse, string, error) {
+		mu.Lock()
+		locales = append(locales, locale.Tag)
+		mu.Unlock()
+		return ai.AiResponse{}, extractionID, nil
+	}
+	commandBus := new(commandmocks.Bus)
+	commandBus.On("Dispatch", mock.Anything, mock.Anything).Return(nil)
+	queryBus := new(querymocks.Bus)
+	queryBus.On("Ask", mock.Anything, mock.Anything).Return([]recipesdomain.Extraction{}, nil)
+
+	extractor := NewBatchExtractor(batch.NewRunner(extract, commandBus, queryBus), userRepository, quota.NewChecker(new(recipesstoragemocks.UsageRepository), nil), 2)
+
+	var items []subscriptionsdomain.ExtractedItem
+	err = extractor.Extract(context.Background(), userID, []string{"https://example.com/a", "https://example.com/b"}, func(item subscriptionsdomain.ExtractedItem) error {
+		items = append(items, item)
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	for _, item := range items {
+		assert.Equal(t, recipesdomain.ExtractionStatusSucceeded, item.Status)
+		assert.Equal(t, extractionID, item.ExtractionId)
+	}
+	assert.Equal(t, []string{"en-GB", "en-GB"}, locales)
+	userRepository.AssertExpectations(t)
+}
+
+func TestBatchExtractor_Extract_QuotaExceeded(t *testing.T) {
+	user, err := usersdomain.NewUser(userID, "name", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+	require.NoError(t, user.SetQuota(10, 0))
+	userRepository := new(storagemocks.UserRepository)
+	userRepository.On("Get", mock.Anything, user.Id).Return(&user, nil)
+
+	// La cuota personal del usuario está agotada
+	usageRepository := new(recipesstoragemocks.UsageRepository)
+	filter := recipesdomain.UsageFilter{UserId: userID, Personal: true, From: time.Now().Format("2006-01") + "-01"}
+	usageRepository.On("Report", mock.Anything, filter, recipesdomain.UsageByMonth, recipesdomain.UsageSortKey).
+		Return([]recipesdomain.UsageRow{{Extractions: 10}}, nil)
+
+	extract := func(ctx context.Context, url string, locale i18n.Locale) (ai.AiResponse, string, error) {
+		t.Fatalf("unexpected extraction of %s", url)
+		return ai.AiResponse{}, "", nil
+	}
+	queryBus := new(querymocks.Bus)
+	queryBus.On("Ask", mock.Anything, mock.Anything).Return([]recipesdomain.Extraction{}, nil)
+
+	extractor := NewBatchExtractor(batch.NewRunner(extract, new(commandmocks.Bus), queryBus), userRepository, quota.NewChecker(usageRepository, nil), 2)
+
+	var items []subscriptionsdomain.ExtractedItem
+	err = extractor.Extract(context.Background(), userID, []string{"https://example.com/a", "https://example.com/b"}, func(item subscriptionsdomain.ExtractedItem) error {
+		items = append(items, item)
+		return nil
+	})
+
+	assert.ErrorIs(t, err, usersdomain.ErrUserQuotaExceeded)
+	assert.Empty(t, items, "the videos are extracted in the next check")
+}
@@ -0,0 +1,104 @@
+package lister
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CommandLister lists the videos of a subscription with gallery-dl for the
+// Instagram profiles and with yt-dlp for the rest of platforms, which
+// support the TikTok profiles and the YouTube channels and playlists.
+type CommandLister struct {
+	configFile string
+}
+
+// NewCommandLister initializes a CommandLister. configFile is the gallery-dl
+// config file with the cookies of Instagram, if any.
+func NewCommandLister(configFile string) CommandLister {
+	return CommandLister{
+		configFile: configFile,
+	}
+}
+
+// List returns the URLs of the limit most recent videos of the url, most
+// recent first.
+func (l CommandLister) List(ctx context.Context, url string, limit int) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "subscriptions.lister.List", attribute.String("url.full", url))
+	urls, err := l.list(ctx, url, limit)
+	if err == nil {
+		span.SetAttributes(attribute.Int("subscription.items", len(urls)))
+	}
+	tracing.End(span, err)
+	return urls, err
+}
+
+func (l CommandLister) list(ctx context.Context, url string, limit int) ([]string, error) {
+	name, args := l.command(url, limit)
+	slog.DebugContext(ctx, "listing subscription", slog.String("url", url), slog.String("lister", name))
+
+	output, err := exec.CommandContext(ctx, name, args...).Output()
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("failed to list videos with %s: %w, details: %s", name, err, exitErr.Stderr)
+		}
+		return nil, fmt.Errorf("failed to list videos with %s: %w", name, err)
+	}
+
+	return parseUrls(string(output), limit), nil
+}
+
+func (l CommandLister) command(url string, limit int) (string, []string) {
+	if isInstagram(url) {
+		args := []string{"--no-download", "--range", "1-" + strconv.Itoa(limit), "--print", "{post_url}"}
+		if l.configFile != "" {
+			args = append(args, "-c", l.configFile)
+		}
+		return "gallery-dl", append(args, url)
+	}
+
+	return "yt-dlp", []string{"--flat-playlist", "--print", "url", "--playlist-end", strconv.Itoa(limit), "--no-warnings", url}
+}
+
+func isInstagram(value string) bool {
+	u, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	return host == "instagram.com" || strings.HasSuffix(host, ".instagram.com")
+}
+
+// parseUrls devuelve las URLs de la salida en orden, sin repetir y como mucho
+// limit. gallery-dl imprime una línea por archivo, así que los carruseles
+// repiten la URL del post.
+func parseUrls(output string, limit int) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() && len(urls) < limit {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "http://") && !strings.HasPrefix(line, "https://") {
+			continue
+		}
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+		urls = append(urls, line)
+	}
+	return urls
+}
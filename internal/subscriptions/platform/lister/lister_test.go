@@ -0,0 +1,66 @@
+package lister
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCommand crea un ejecutable con el nombre dado al principio del PATH que
+// imprime output y guarda sus argumentos en el archivo devuelto.
+func fakeCommand(t *testing.T, name, output string, exitCode int) string {
+	t.Helper()
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args")
+	outputFile := filepath.Join(dir, "output")
+	require.NoError(t, os.WriteFile(outputFile, []byte(output), 0o644))
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\ncat " + outputFile + "\nexit " + strconv.Itoa(exitCode) + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return argsFile
+}
+
+func TestCommandLister_List(t *testing.T) {
+	t.Run("it lists a youtube channel with yt-dlp", func(t *testing.T) {
+		argsFile := fakeCommand(t, "yt-dlp", "https://www.youtube.com/watch?v=a\nhttps://www.youtube.com/watch?v=b\n", 0)
+
+		urls, err := NewCommandLister("").List(context.Background(), "https://www.youtube.com/@chef", 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://www.youtube.com/watch?v=a", "https://www.youtube.com/watch?v=b"}, urls)
+		args, err := os.ReadFile(argsFile)
+		require.NoError(t, err)
+		assert.Equal(t, "--flat-playlist --print url --playlist-end 2 --no-warnings https://www.youtube.com/@chef\n", string(args))
+	})
+
+	t.Run("it lists an instagram profile with gallery-dl", func(t *testing.T) {
+		argsFile := fakeCommand(t, "gallery-dl", "https://www.instagram.com/p/a/\nhttps://www.instagram.com/p/a/\nhttps://www.instagram.com/p/b/\n", 0)
+
+		urls, err := NewCommandLister("gallery.conf").List(context.Background(), "https://www.instagram.com/chef/", 5)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://www.instagram.com/p/a/", "https://www.instagram.com/p/b/"}, urls)
+		args, err := os.ReadFile(argsFile)
+		require.NoError(t, err)
+		assert.Equal(t, "--no-download --range 1-5 --print {post_url} -c gallery.conf https://www.instagram.com/chef/\n", string(args))
+	})
+
+	t.Run("it fails when the command fails", func(t *testing.T) {
+		fakeCommand(t, "yt-dlp", "", 1)
+
+		_, err := NewCommandLister("").List(context.Background(), "https://www.tiktok.com/@chef", 5)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestParseUrls(t *testing.T) {
+	output := "WARNING: something\nhttps://example.com/a\nNA\nhttps://example.com/b\nhttps://example.com/c\n"
+
+	assert.Equal(t, []string{"https://example.com/a", "https://example.com/b"}, parseUrls(output, 2))
+}
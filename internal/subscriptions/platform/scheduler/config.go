@@ -0,0 +1,23 @@
+package scheduler
+
+import (
+	"errors"
+	"time"
+)
+
+type Config struct {
+	// Enabled starts the scheduler with the API. The subscriptions can still
+	// be checked from the CLI when it is disabled.
+	Enabled bool `default:"true"`
+	// PollInterval is the wait between two searches of the subscriptions
+	// whose interval has passed.
+	PollInterval time.Duration `default:"1m"`
+}
+
+// Validate checks the values of the config.
+func (c Config) Validate() error {
+	if c.PollInterval <= 0 {
+		return errors.New("poll interval must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/application/check"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+// Scheduler checks the subscriptions whose interval has passed.
+type Scheduler struct {
+	bus    command.Bus
+	config Config
+}
+
+func NewScheduler(bus command.Bus, config Config) *Scheduler {
+	return &Scheduler{
+		bus:    bus,
+		config: config,
+	}
+}
+
+// Run checks the due subscriptions every poll interval until the context is
+// cancelled. It returns at once if the scheduler is disabled.
+func (s *Scheduler) Run(ctx context.Context) {
+	if !s.config.Enabled {
+		slog.InfoContext(ctx, "subscriptions scheduler disabled")
+		return
+	}
+
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Las comprobaciones largas retrasan la siguiente; el ticker descarta
+		// los ticks perdidos
+		if err := s.bus.Dispatch(ctx, check.NewSubscriptionsCommand("")); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "subscriptions check failed", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/application/check"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScheduler_Run(t *testing.T) {
+	t.Run("it checks the due subscriptions until cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		bus := new(commandmocks.Bus)
+		bus.On("Dispatch", mock.Anything, check.NewSubscriptionsCommand("")).Return(nil).Once()
+		bus.On("Dispatch", mock.Anything, check.NewSubscriptionsCommand("")).Run(func(mock.Arguments) { cancel() }).Return(nil).Once()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			NewScheduler(bus, Config{Enabled: true, PollInterval: time.Millisecond}).Run(ctx)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("scheduler did not stop")
+		}
+		bus.AssertExpectations(t)
+	})
+
+	t.Run("it does nothing when disabled", func(t *testing.T) {
+		bus := new(commandmocks.Bus)
+
+		NewScheduler(bus, Config{PollInterval: time.Millisecond}).Run(context.Background())
+
+		bus.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	clihandlers "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/cli/handler"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+type createSubscriptionRequest struct {
+	Url string `json:"url" binding:"required"`
+	// Interval es una duración de Go (e.g. "6h", "90m")
+	Interval string `json:"interval"`
+	MaxItems int    `json:"maxItems"`
+}
+
+type updateSubscriptionRequest struct {
+	Interval string `json:"interval"`
+	MaxItems int    `json:"maxItems"`
+}
+
+// CreateHandler suscribe al usuario autenticado a un perfil, canal o lista de
+// reproducción.
+func CreateHandler(commandBus command.Bus) gin.HandlerFunc {
+	createSubscription := clihandlers.CreateCreateSubscriptionHandler(commandBus)
+
+	return func(ctx *gin.Context) {
+		user, ok := middleware.GetUserFromContext(ctx)
+		if !ok || user == nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+			return
+		}
+
+		var req createSubscriptionRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		interval, err := parseInterval(req.Interval)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		input := clihandlers.CreateSubscriptionInput{
+			ID:        uuid.New().String(),
+			UserID:    user.Id.String(),
+			Url:       req.Url,
+			Interval:  interval,
+			MaxItems:  req.MaxItems,
+			CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		}
+		if err := createSubscription(ctx, input); err != nil {
+			ctx.JSON(subscriptionErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.Header("Location", "/subscriptions/"+input.ID)
+		ctx.JSON(http.StatusCreated, gin.H{"id": input.ID})
+	}
+}
+
+// ListHandler devuelve las suscripciones del usuario autenticado.
+func ListHandler(queryBus query.Bus) gin.HandlerFunc {
+	getSubscriptions := clihandlers.CreateGetSubscriptionsHandler(queryBus)
+
+	return func(ctx *gin.Context) {
+		user, ok := middleware.GetUserFromContext(ctx)
+		if !ok || user == nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+			return
+		}
+
+		subscriptions, err := getSubscriptions(ctx, clihandlers.GetSubscriptionsInput{UserID: user.Id.String()})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"subscriptions": subscriptions})
+	}
+}
+
+// GetHandler devuelve una suscripción del usuario autenticado con los vídeos
+// extraídos por ella.
+func GetHandler(queryBus query.Bus) gin.HandlerFunc {
+	getSubscription := clihandlers.CreateGetSubscriptionHandler(queryBus)
+
+	return func(ctx *gin.Context) {
+		subscription, ok := ownSubscription(ctx, getSubscription)
+		if !ok {
+			return
+		}
+
+		ctx.JSON(http.StatusOK, subscription)
+	}
+}
+
+// UpdateHandler cambia el intervalo o el número de vídeos de una suscripción
+// del usuario autenticado.
+func UpdateHandler(commandBus command.Bus, queryBus query.Bus) gin.HandlerFunc {
+	getSubscription := clihandlers.CreateGetSubscriptionHandler(queryBus)
+	updateSubscription := clihandlers.CreateUpdateSubscriptionHandler(commandBus)
+
+	return func(ctx *gin.Context) {
+		var req updateSubscriptionRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		interval, err := parseInterval(req.Interval)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if _, ok := ownSubscription(ctx, getSubscription); !ok {
+			return
+		}
+
+		input := clihandlers.UpdateSubscriptionInput{ID: ctx.Param("id"), Interval: interval, MaxItems: req.MaxItems}
+		if input.Interval == 0 && input.MaxItems == 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "interval or maxItems is required"})
+			return
+		}
+		if err := updateSubscription(ctx, input); err != nil {
+			ctx.JSON(subscriptionErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// DeleteHandler elimina una suscripción del usuario autenticado. Las recetas
+// extraídas por ella se conservan.
+func DeleteHandler(commandBus command.Bus, queryBus query.Bus) gin.HandlerFunc {
+	getSubscription := clihandlers.CreateGetSubscriptionHandler(queryBus)
+	deleteSubscription := clihandlers.CreateDeleteSubscriptionHandler(commandBus)
+
+	return func(ctx *gin.Context) {
+		if _, ok := ownSubscription(ctx, getSubscription); !ok {
+			return
+		}
+
+		if err := deleteSubscription(ctx, clihandlers.DeleteSubscriptionInput{ID: ctx.Param("id")}); err != nil {
+			ctx.JSON(subscriptionErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// ownSubscription busca la suscripción del parámetro id y responde con 404 si
+// no existe o es de otro usuario, para no revelar las suscripciones ajenas.
+func ownSubscription(ctx *gin.Context, getSubscription clihandlers.GetSubscriptionHandler) (clihandlers.GetSubscriptionDetailOutput, bool) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok || user == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+		return clihandlers.GetSubscriptionDetailOutput{}, false
+	}
+
+	subscription, err := getSubscription(ctx, clihandlers.GetSubscriptionInput{ID: ctx.Param("id")})
+	if err == nil && subscription.UserID != user.Id.String() {
+		err = subscriptionsdomain.ErrSubscriptionNotFound
+	}
+	if err != nil {
+		ctx.JSON(subscriptionErrorStatus(err), gin.H{"error": err.Error()})
+		return clihandlers.GetSubscriptionDetailOutput{}, false
+	}
+	return subscription, true
+}
+
+func parseInterval(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.New("interval must be a duration like 6h or 90m")
+	}
+	return interval, nil
+}
+
+func subscriptionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, subscriptionsdomain.ErrInvalidSubscriptionUrl),
+		errors.Is(err, subscriptionsdomain.ErrInvalidSubscriptionInterval),
+		errors.Is(err, subscriptionsdomain.ErrInvalidSubscriptionMaxItems):
+		return http.StatusBadRequest
+	case errors.Is(err, subscriptionsdomain.ErrInvalidSubscriptionID),
+		errors.Is(err, subscriptionsdomain.ErrSubscriptionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, subscriptionsdomain.ErrSubscriptionAlreadyExists),
+		errors.Is(err, subscriptionsdomain.ErrSubscriptionUserDisabled):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
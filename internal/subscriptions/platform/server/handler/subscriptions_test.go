@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/application/get"
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/application/update"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/rubenbupe/recipe-video-parser/kit/query/querymocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userID         = "37a0f027-15e6-47cc-a5d2-64183281087e"
+	otherUserID    = "5d3b8c0e-7a41-4f4b-9a55-1c2d3e4f5a6b"
+	subscriptionID = "0b6f6a53-2a9e-4f7c-9d54-3b1c2f7a9e10"
+)
+
+func newRouter(t *testing.T, register func(r *gin.Engine)) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	user, err := usersdomain.NewUser(userID, "name", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(func(ctx *gin.Context) {
+		ctx.Set("user", &user)
+	})
+	register(r)
+	return r
+}
+
+func newQueryBus(t *testing.T, ownerID string) *querymocks.Bus {
+	t.Helper()
+	subscription, err := subscriptionsdomain.NewSubscription(subscriptionID, ownerID, "https://www.youtube.com/@chef", time.Hour, 5, "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+	item, err := subscriptionsdomain.NewSubscriptionItem(subscriptionID, "https://www.youtube.com/watch?v=a", "8c5e5d2b-8d0a-4c0b-9f0a-6a6f4c1d2e3f", "succeeded", "2023-01-01T01:00:00Z")
+	require.NoError(t, err)
+
+	bus := new(querymocks.Bus)
+	bus.On("Ask", mock.Anything, get.NewSubscriptionQuery(subscriptionID)).Return(&get.SubscriptionDetail{Subscription: subscription, Items: []subscriptionsdomain.SubscriptionItem{item}}, nil)
+	return bus
+}
+
+func TestHandler_Create(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("create.SubscriptionCommand")).Return(nil)
+	r := newRouter(t, func(r *gin.Engine) { r.POST("/subscriptions", CreateHandler(bus)) })
+
+	t.Run("it returns 201", func(t *testing.T) {
+		body := `{"url": "https://www.youtube.com/@chef", "interval": "12h", "maxItems": 5}`
+		req, err := http.NewRequest(http.MethodPost, "/subscriptions", strings.NewReader(body))
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get("Location"))
+	})
+
+	t.Run("it returns 400 with an invalid interval", func(t *testing.T) {
+		body := `{"url": "https://www.youtube.com/@chef", "interval": "often"}`
+		req, err := http.NewRequest(http.MethodPost, "/subscriptions", strings.NewReader(body))
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestHandler_Create_AlreadySubscribed(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("create.SubscriptionCommand")).Return(subscriptionsdomain.ErrSubscriptionAlreadyExists)
+	r := newRouter(t, func(r *gin.Engine) { r.POST("/subscriptions", CreateHandler(bus)) })
+
+	req, err := http.NewRequest(http.MethodPost, "/subscriptions", strings.NewReader(`{"url": "https://www.youtube.com/@chef"}`))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestHandler_Get(t *testing.T) {
+	t.Run("it returns the subscription with its videos", func(t *testing.T) {
+		r := newRouter(t, func(r *gin.Engine) { r.GET("/subscriptions/:id", GetHandler(newQueryBus(t, userID))) })
+		req, err := http.NewRequest(http.MethodGet, "/subscriptions/"+subscriptionID, nil)
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var res struct {
+			Interval string `json:"interval"`
+			Items    []struct {
+				Url string `json:"url"`
+			} `json:"items"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, "1h0m0s", res.Interval)
+		require.Len(t, res.Items, 1)
+	})
+
+	t.Run("it returns 404 for the subscriptions of other users", func(t *testing.T) {
+		r := newRouter(t, func(r *gin.Engine) { r.GET("/subscriptions/:id", GetHandler(newQueryBus(t, otherUserID))) })
+		req, err := http.NewRequest(http.MethodGet, "/subscriptions/"+subscriptionID, nil)
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestHandler_Update(t *testing.T) {
+	commandBus := new(commandmocks.Bus)
+	commandBus.On("Dispatch", mock.Anything, update.NewSubscriptionCommand(subscriptionID, 0, 20)).Return(nil)
+	r := newRouter(t, func(r *gin.Engine) {
+		r.PATCH("/subscriptions/:id", UpdateHandler(commandBus, newQueryBus(t, userID)))
+	})
+
+	req, err := http.NewRequest(http.MethodPatch, "/subscriptions/"+subscriptionID, strings.NewReader(`{"maxItems": 20}`))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	commandBus.AssertExpectations(t)
+}
+
+func TestHandler_Delete_OtherUser(t *testing.T) {
+	commandBus := new(commandmocks.Bus)
+	r := newRouter(t, func(r *gin.Engine) {
+		r.DELETE("/subscriptions/:id", DeleteHandler(commandBus, newQueryBus(t, otherUserID)))
+	})
+
+	req, err := http.NewRequest(http.MethodDelete, "/subscriptions/"+subscriptionID, nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	commandBus.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/di"
+	handlers "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/handler"
+	middleware "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+)
+
+func Register(router *gin.RouterGroup) {
+	diContainer := di.Instance()
+
+	createController := diContainer.Container.Get("subscriptions.infrastructure.controller.create").(handlers.Handler)
+	listController := diContainer.Container.Get("subscriptions.infrastructure.controller.list").(handlers.Handler)
+	getController := diContainer.Container.Get("subscriptions.infrastructure.controller.get").(handlers.Handler)
+	updateController := diContainer.Container.Get("subscriptions.infrastructure.controller.update").(handlers.Handler)
+	deleteController := diContainer.Container.Get("subscriptions.infrastructure.controller.delete").(handlers.Handler)
+	userRepo := diContainer.Container.Get("users.domain.repository").(usersdomain.UserRepository)
+	organizationRepo := diContainer.Container.Get("organizations.domain.repository").(organizationsdomain.OrganizationRepository)
+
+	router.POST("", middleware.AuthMiddleware(userRepo, organizationRepo), createController)
+	router.GET("", middleware.AuthMiddleware(userRepo, organizationRepo), listController)
+	router.GET("/:id", middleware.AuthMiddleware(userRepo, organizationRepo), getController)
+	router.PATCH("/:id", middleware.AuthMiddleware(userRepo, organizationRepo), updateController)
+	router.DELETE("/:id", middleware.AuthMiddleware(userRepo, organizationRepo), deleteController)
+}
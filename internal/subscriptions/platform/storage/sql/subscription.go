@@ -0,0 +1,26 @@
+package sql
+
+import "database/sql"
+
+const (
+	sqlSubscriptionTable     = "subscriptions"
+	sqlSubscriptionItemTable = "subscription_items"
+)
+
+type sqlSubscription struct {
+	ID              string         `db:"id"`
+	UserID          string         `db:"user_id"`
+	Url             string         `db:"url"`
+	IntervalSeconds int64          `db:"interval_seconds"`
+	MaxItems        int            `db:"max_items"`
+	LastCheckedAt   sql.NullString `db:"last_checked_at"`
+	CreatedAt       string         `db:"created_at"`
+}
+
+type sqlSubscriptionItem struct {
+	SubscriptionID string `db:"subscription_id"`
+	Url            string `db:"url"`
+	ExtractionID   string `db:"extraction_id"`
+	Status         string `db:"status"`
+	CreatedAt      string `db:"created_at"`
+}
@@ -0,0 +1,100 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+)
+
+var subscriptionItemColumns = []string{"subscription_id", "url", "extraction_id", "status", "created_at"}
+
+type SubscriptionItemRepository struct {
+	connection *storage.Connection
+	dbconfig   *storage.Dbconfig
+}
+
+func NewSubscriptionItemRepository(connection *storage.Connection, dbconfig *storage.Dbconfig) *SubscriptionItemRepository {
+	return &SubscriptionItemRepository{
+		connection: connection,
+		dbconfig:   dbconfig,
+	}
+}
+
+// Save inserts the item, or updates its extraction if the video was already
+// recorded by a concurrent check.
+func (r *SubscriptionItemRepository) Save(ctx context.Context, item subscriptionsdomain.SubscriptionItem) error {
+	query := "INSERT INTO " + sqlSubscriptionItemTable + " (subscription_id, url, extraction_id, status, created_at) VALUES (?, ?, ?, ?, ?) " +
+		"ON CONFLICT(subscription_id, url) DO UPDATE SET extraction_id=excluded.extraction_id, status=excluded.status"
+	args := []interface{}{
+		item.SubscriptionId.String(),
+		item.Url,
+		item.ExtractionId,
+		item.Status,
+		item.CreatedAt,
+	}
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	_, err := r.connection.Executor(ctxTimeout).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("error trying to persist subscription item on database: %v", err)
+	}
+
+	return nil
+}
+
+func (r *SubscriptionItemRepository) Exists(ctx context.Context, subscriptionId subscriptionsdomain.SubscriptionID, url string) (bool, error) {
+	sb := sqlbuilder.Select("1").From(sqlSubscriptionItemTable)
+	sb.Where(sb.Equal("subscription_id", subscriptionId.String()), sb.Equal("url", url))
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	rows, err := r.connection.Executor(ctxTimeout).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("error trying to check if subscription item exists on database: %v", err)
+	}
+	defer rows.Close()
+
+	return rows.Next(), nil
+}
+
+func (r *SubscriptionItemRepository) GetBySubscriptionID(ctx context.Context, subscriptionId subscriptionsdomain.SubscriptionID) ([]subscriptionsdomain.SubscriptionItem, error) {
+	sb := sqlbuilder.Select(subscriptionItemColumns...).From(sqlSubscriptionItemTable)
+	sb.Where(sb.Equal("subscription_id", subscriptionId.String()))
+	sb.OrderBy("created_at").Asc()
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	rows, err := r.connection.Executor(ctxTimeout).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to get subscription items from database: %v", err)
+	}
+	defer rows.Close()
+
+	var items []subscriptionsdomain.SubscriptionItem
+	for rows.Next() {
+		itemSQLStruct := sqlbuilder.NewStruct(new(sqlSubscriptionItem))
+		item := new(sqlSubscriptionItem)
+		if err := rows.Scan(itemSQLStruct.Addr(item)...); err != nil {
+			return nil, fmt.Errorf("error scanning subscription item row: %v", err)
+		}
+
+		itemVO, err := subscriptionsdomain.NewSubscriptionItem(item.SubscriptionID, item.Url, item.ExtractionID, item.Status, item.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, itemVO)
+	}
+
+	return items, rows.Err()
+}
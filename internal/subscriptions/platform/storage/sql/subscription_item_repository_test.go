@@ -0,0 +1,75 @@
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	itemUrl      = "https://www.tiktok.com/@chef/video/1"
+	extractionID = "8c5e5d2b-8d0a-4c0b-9f0a-6a6f4c1d2e3f"
+)
+
+func Test_SubscriptionItemRepository_Save_Upserts(t *testing.T) {
+	item, err := subscriptionsdomain.NewSubscriptionItem(subscriptionID, itemUrl, extractionID, "succeeded", createdAt)
+	require.NoError(t, err)
+	connection, config, sqlMock := newTestConnection(t)
+
+	sqlMock.ExpectExec(
+		"INSERT INTO subscription_items (subscription_id, url, extraction_id, status, created_at) VALUES (?, ?, ?, ?, ?) "+
+			"ON CONFLICT(subscription_id, url) DO UPDATE SET extraction_id=excluded.extraction_id, status=excluded.status").
+		WithArgs(subscriptionID, itemUrl, extractionID, "succeeded", createdAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewSubscriptionItemRepository(connection, config)
+
+	err = repo.Save(context.Background(), item)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func Test_SubscriptionItemRepository_Exists(t *testing.T) {
+	id, err := subscriptionsdomain.NewSubscriptionID(subscriptionID)
+	require.NoError(t, err)
+	connection, config, sqlMock := newTestConnection(t)
+
+	sqlMock.ExpectQuery("SELECT 1 FROM subscription_items WHERE subscription_id = ? AND url = ?").
+		WithArgs(subscriptionID, itemUrl).
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+
+	repo := NewSubscriptionItemRepository(connection, config)
+
+	exists, err := repo.Exists(context.Background(), id, itemUrl)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func Test_SubscriptionItemRepository_GetBySubscriptionID(t *testing.T) {
+	id, err := subscriptionsdomain.NewSubscriptionID(subscriptionID)
+	require.NoError(t, err)
+	connection, config, sqlMock := newTestConnection(t)
+
+	sqlMock.ExpectQuery(
+		"SELECT subscription_id, url, extraction_id, status, created_at FROM subscription_items WHERE subscription_id = ? ORDER BY created_at ASC").
+		WithArgs(subscriptionID).
+		WillReturnRows(sqlmock.NewRows(subscriptionItemColumns).
+			AddRow(subscriptionID, itemUrl, extractionID, "not_a_recipe", createdAt))
+
+	repo := NewSubscriptionItemRepository(connection, config)
+
+	items, err := repo.GetBySubscriptionID(context.Background(), id)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "not_a_recipe", items[0].Status)
+	assert.Equal(t, extractionID, items[0].ExtractionId)
+}
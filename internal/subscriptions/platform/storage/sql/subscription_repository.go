@@ -0,0 +1,216 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+)
+
+var subscriptionColumns = []string{"id", "user_id", "url", "interval_seconds", "max_items", "last_checked_at", "created_at"}
+
+type SubscriptionRepository struct {
+	connection *storage.Connection
+	dbconfig   *storage.Dbconfig
+}
+
+func NewSubscriptionRepository(connection *storage.Connection, dbconfig *storage.Dbconfig) *SubscriptionRepository {
+	return &SubscriptionRepository{
+		connection: connection,
+		dbconfig:   dbconfig,
+	}
+}
+
+func (r *SubscriptionRepository) Save(ctx context.Context, subscription subscriptionsdomain.Subscription) error {
+	subscriptionSQLStruct := sqlbuilder.NewStruct(new(sqlSubscription)).For(sqlbuilder.SQLite)
+	query, args := subscriptionSQLStruct.InsertInto(sqlSubscriptionTable, toSQLSubscription(subscription)).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	_, err := r.connection.Executor(ctxTimeout).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("error trying to persist subscription on database: %v", err)
+	}
+
+	return nil
+}
+
+// Update saves the interval, the maximum number of videos and the date of the
+// last check of the subscription.
+func (r *SubscriptionRepository) Update(ctx context.Context, subscription subscriptionsdomain.Subscription) error {
+	row := toSQLSubscription(subscription)
+	ub := sqlbuilder.Update(sqlSubscriptionTable)
+	ub.Set(
+		ub.Assign("interval_seconds", row.IntervalSeconds),
+		ub.Assign("max_items", row.MaxItems),
+		ub.Assign("last_checked_at", row.LastCheckedAt),
+	)
+	ub.Where(ub.Equal("id", row.ID))
+	ub.SetFlavor(sqlbuilder.SQLite)
+	query, args := ub.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	_, err := r.connection.Executor(ctxTimeout).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("error trying to update subscription on database: %v", err)
+	}
+
+	return nil
+}
+
+// Delete deletes the subscription and its videos in a transaction, since the
+// foreign keys are not enforced.
+func (r *SubscriptionRepository) Delete(ctx context.Context, id subscriptionsdomain.SubscriptionID) error {
+	return r.connection.Transaction(ctx, func(ctx context.Context) error {
+		ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+		defer cancel()
+
+		itemsDelete := sqlbuilder.DeleteFrom(sqlSubscriptionItemTable)
+		itemsDelete.Where(itemsDelete.Equal("subscription_id", id.String()))
+		itemsDelete.SetFlavor(sqlbuilder.SQLite)
+		query, args := itemsDelete.Build()
+		if _, err := r.connection.Executor(ctxTimeout).ExecContext(ctxTimeout, query, args...); err != nil {
+			return fmt.Errorf("error trying to delete subscription items from database: %v", err)
+		}
+
+		subscriptionDelete := sqlbuilder.DeleteFrom(sqlSubscriptionTable)
+		subscriptionDelete.Where(subscriptionDelete.Equal("id", id.String()))
+		subscriptionDelete.SetFlavor(sqlbuilder.SQLite)
+		query, args = subscriptionDelete.Build()
+		if _, err := r.connection.Executor(ctxTimeout).ExecContext(ctxTimeout, query, args...); err != nil {
+			return fmt.Errorf("error trying to delete subscription from database: %v", err)
+		}
+		return nil
+	})
+}
+
+func (r *SubscriptionRepository) Exists(ctx context.Context, id subscriptionsdomain.SubscriptionID) (bool, error) {
+	sb := sqlbuilder.Select("1").From(sqlSubscriptionTable)
+	sb.Where(sb.Equal("id", id.String()))
+	return r.exists(ctx, sb)
+}
+
+func (r *SubscriptionRepository) ExistsForUrl(ctx context.Context, userId subscriptionsdomain.SubscriptionUserID, url subscriptionsdomain.SubscriptionUrl) (bool, error) {
+	sb := sqlbuilder.Select("1").From(sqlSubscriptionTable)
+	sb.Where(sb.Equal("user_id", userId.String()), sb.Equal("url", url.String()))
+	return r.exists(ctx, sb)
+}
+
+func (r *SubscriptionRepository) exists(ctx context.Context, sb *sqlbuilder.SelectBuilder) (bool, error) {
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	rows, err := r.connection.Executor(ctxTimeout).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("error trying to check if subscription exists on database: %v", err)
+	}
+	defer rows.Close()
+
+	return rows.Next(), nil
+}
+
+func (r *SubscriptionRepository) Get(ctx context.Context, id subscriptionsdomain.SubscriptionID) (*subscriptionsdomain.Subscription, error) {
+	subscriptionSQLStruct := sqlbuilder.NewStruct(new(sqlSubscription))
+	sb := sqlbuilder.Select(subscriptionColumns...).From(sqlSubscriptionTable)
+	sb.Where(sb.Equal("id", id.String()))
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	subscription := new(sqlSubscription)
+	err := r.connection.Executor(ctxTimeout).QueryRowContext(ctxTimeout, query, args...).Scan(subscriptionSQLStruct.Addr(subscription)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error trying to get subscription from database: %v", err)
+	}
+
+	subscriptionVO, err := toDomainSubscription(subscription)
+	return &subscriptionVO, err
+}
+
+func (r *SubscriptionRepository) GetByUserID(ctx context.Context, userId subscriptionsdomain.SubscriptionUserID) ([]subscriptionsdomain.Subscription, error) {
+	sb := sqlbuilder.Select(subscriptionColumns...).From(sqlSubscriptionTable)
+	sb.Where(sb.Equal("user_id", userId.String()))
+	sb.OrderBy("created_at").Asc()
+	return r.list(ctx, sb)
+}
+
+func (r *SubscriptionRepository) All(ctx context.Context) ([]subscriptionsdomain.Subscription, error) {
+	sb := sqlbuilder.Select(subscriptionColumns...).From(sqlSubscriptionTable)
+	sb.OrderBy("created_at").Asc()
+	return r.list(ctx, sb)
+}
+
+func (r *SubscriptionRepository) list(ctx context.Context, sb *sqlbuilder.SelectBuilder) ([]subscriptionsdomain.Subscription, error) {
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	rows, err := r.connection.Executor(ctxTimeout).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to get subscriptions from database: %v", err)
+	}
+	defer rows.Close()
+
+	var subscriptions []subscriptionsdomain.Subscription
+	for rows.Next() {
+		subscriptionSQLStruct := sqlbuilder.NewStruct(new(sqlSubscription))
+		subscription := new(sqlSubscription)
+		if err := rows.Scan(subscriptionSQLStruct.Addr(subscription)...); err != nil {
+			return nil, fmt.Errorf("error scanning subscription row: %v", err)
+		}
+
+		subscriptionVO, err := toDomainSubscription(subscription)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscriptionVO)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+func toSQLSubscription(subscription subscriptionsdomain.Subscription) sqlSubscription {
+	return sqlSubscription{
+		ID:              subscription.Id.String(),
+		UserID:          subscription.UserId.String(),
+		Url:             subscription.Url.String(),
+		IntervalSeconds: int64(subscription.Interval.Duration() / time.Second),
+		MaxItems:        subscription.MaxItems.Int(),
+		LastCheckedAt:   sql.NullString{String: subscription.LastCheckedAt, Valid: subscription.LastCheckedAt != ""},
+		CreatedAt:       subscription.CreatedAt.String(),
+	}
+}
+
+func toDomainSubscription(subscription *sqlSubscription) (subscriptionsdomain.Subscription, error) {
+	subscriptionVO, err := subscriptionsdomain.NewSubscription(
+		subscription.ID,
+		subscription.UserID,
+		subscription.Url,
+		time.Duration(subscription.IntervalSeconds)*time.Second,
+		subscription.MaxItems,
+		subscription.CreatedAt,
+	)
+	if err != nil {
+		return subscriptionVO, err
+	}
+	subscriptionVO.LastCheckedAt = subscription.LastCheckedAt.String
+	return subscriptionVO, nil
+}
@@ -0,0 +1,170 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	subscriptionID  = "0b6f6a53-2a9e-4f7c-9d54-3b1c2f7a9e10"
+	userID          = "37a0f027-15e6-47cc-a5d2-64183281087e"
+	subscriptionUrl = "https://www.tiktok.com/@chef"
+	createdAt       = "2023-10-01T00:00:00Z"
+	lastCheckedAt   = "2023-10-01T06:00:00Z"
+)
+
+func newTestSubscription(t *testing.T) subscriptionsdomain.Subscription {
+	t.Helper()
+	subscription, err := subscriptionsdomain.NewSubscription(subscriptionID, userID, subscriptionUrl, 6*time.Hour, 10, createdAt)
+	require.NoError(t, err)
+	return subscription
+}
+
+func newTestConnection(t *testing.T) (*storage.Connection, *storage.Dbconfig, sqlmock.Sqlmock) {
+	t.Helper()
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	return &storage.Connection{Db: db}, &storage.Dbconfig{Timeout: 1 * time.Millisecond}, sqlMock
+}
+
+func Test_SubscriptionRepository_Save_Succeed(t *testing.T) {
+	subscription := newTestSubscription(t)
+	connection, config, sqlMock := newTestConnection(t)
+
+	sqlMock.ExpectExec(
+		"INSERT INTO subscriptions (id, user_id, url, interval_seconds, max_items, last_checked_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)").
+		WithArgs(subscriptionID, userID, subscriptionUrl, int64(21600), 10, nil, createdAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewSubscriptionRepository(connection, config)
+
+	err := repo.Save(context.Background(), subscription)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func Test_SubscriptionRepository_Save_RepositoryError(t *testing.T) {
+	subscription := newTestSubscription(t)
+	connection, config, sqlMock := newTestConnection(t)
+
+	sqlMock.ExpectExec(
+		"INSERT INTO subscriptions (id, user_id, url, interval_seconds, max_items, last_checked_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)").
+		WithArgs(subscriptionID, userID, subscriptionUrl, int64(21600), 10, nil, createdAt).
+		WillReturnError(errors.New("something-failed"))
+
+	repo := NewSubscriptionRepository(connection, config)
+
+	err := repo.Save(context.Background(), subscription)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func Test_SubscriptionRepository_Update_Succeed(t *testing.T) {
+	subscription := newTestSubscription(t)
+	subscription.LastCheckedAt = lastCheckedAt
+	connection, config, sqlMock := newTestConnection(t)
+
+	sqlMock.ExpectExec(
+		"UPDATE subscriptions SET interval_seconds = ?, max_items = ?, last_checked_at = ? WHERE id = ?").
+		WithArgs(int64(21600), 10, lastCheckedAt, subscriptionID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewSubscriptionRepository(connection, config)
+
+	err := repo.Update(context.Background(), subscription)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func Test_SubscriptionRepository_Delete_DeletesItems(t *testing.T) {
+	id, err := subscriptionsdomain.NewSubscriptionID(subscriptionID)
+	require.NoError(t, err)
+	connection, config, sqlMock := newTestConnection(t)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("DELETE FROM subscription_items WHERE subscription_id = ?").
+		WithArgs(subscriptionID).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	sqlMock.ExpectExec("DELETE FROM subscriptions WHERE id = ?").
+		WithArgs(subscriptionID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+
+	repo := NewSubscriptionRepository(connection, config)
+
+	err = repo.Delete(context.Background(), id)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func Test_SubscriptionRepository_Get_Succeed(t *testing.T) {
+	id, err := subscriptionsdomain.NewSubscriptionID(subscriptionID)
+	require.NoError(t, err)
+	connection, config, sqlMock := newTestConnection(t)
+
+	sqlMock.ExpectQuery(
+		"SELECT id, user_id, url, interval_seconds, max_items, last_checked_at, created_at FROM subscriptions WHERE id = ?").
+		WithArgs(subscriptionID).
+		WillReturnRows(sqlmock.NewRows(subscriptionColumns).
+			AddRow(subscriptionID, userID, subscriptionUrl, 3600, 5, lastCheckedAt, createdAt))
+
+	repo := NewSubscriptionRepository(connection, config)
+
+	subscription, err := repo.Get(context.Background(), id)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	require.NotNil(t, subscription)
+	assert.Equal(t, time.Hour, subscription.Interval.Duration())
+	assert.Equal(t, 5, subscription.MaxItems.Int())
+	assert.Equal(t, lastCheckedAt, subscription.LastCheckedAt)
+}
+
+func Test_SubscriptionRepository_Get_NotFound(t *testing.T) {
+	id, err := subscriptionsdomain.NewSubscriptionID(subscriptionID)
+	require.NoError(t, err)
+	connection, config, sqlMock := newTestConnection(t)
+
+	sqlMock.ExpectQuery(
+		"SELECT id, user_id, url, interval_seconds, max_items, last_checked_at, created_at FROM subscriptions WHERE id = ?").
+		WithArgs(subscriptionID).
+		WillReturnRows(sqlmock.NewRows(subscriptionColumns))
+
+	repo := NewSubscriptionRepository(connection, config)
+
+	subscription, err := repo.Get(context.Background(), id)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	assert.Nil(t, subscription)
+}
+
+func Test_SubscriptionRepository_All_NeverChecked(t *testing.T) {
+	connection, config, sqlMock := newTestConnection(t)
+
+	sqlMock.ExpectQuery(
+		"SELECT id, user_id, url, interval_seconds, max_items, last_checked_at, created_at FROM subscriptions ORDER BY created_at ASC").
+		WillReturnRows(sqlmock.NewRows(subscriptionColumns).
+			AddRow(subscriptionID, userID, subscriptionUrl, 21600, 10, nil, createdAt))
+
+	repo := NewSubscriptionRepository(connection, config)
+
+	subscriptions, err := repo.All(context.Background())
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 1)
+	assert.Empty(t, subscriptions[0].LastCheckedAt)
+}
@@ -0,0 +1,43 @@
+package tracing
+
+import (
+	"context"
+
+	sharedtracing "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+)
+
+// SubscriptionItemRepository decorates a subscriptionsdomain.SubscriptionItemRepository with a span
+// for every call.
+type SubscriptionItemRepository struct {
+	next subscriptionsdomain.SubscriptionItemRepository
+}
+
+// NewSubscriptionItemRepository returns a SubscriptionItemRepository that traces the calls to
+// next.
+func NewSubscriptionItemRepository(next subscriptionsdomain.SubscriptionItemRepository) *SubscriptionItemRepository {
+	return &SubscriptionItemRepository{
+		next: next,
+	}
+}
+
+// Save implements the subscriptionsdomain.SubscriptionItemRepository interface.
+func (r *SubscriptionItemRepository) Save(ctx context.Context, item subscriptionsdomain.SubscriptionItem) error {
+	return sharedtracing.Run(ctx, "subscription_items.repository.Save", func(ctx context.Context) error {
+		return r.next.Save(ctx, item)
+	})
+}
+
+// Exists implements the subscriptionsdomain.SubscriptionItemRepository interface.
+func (r *SubscriptionItemRepository) Exists(ctx context.Context, subscriptionId subscriptionsdomain.SubscriptionID, url string) (bool, error) {
+	return sharedtracing.Call(ctx, "subscription_items.repository.Exists", func(ctx context.Context) (bool, error) {
+		return r.next.Exists(ctx, subscriptionId, url)
+	})
+}
+
+// GetBySubscriptionID implements the subscriptionsdomain.SubscriptionItemRepository interface.
+func (r *SubscriptionItemRepository) GetBySubscriptionID(ctx context.Context, subscriptionId subscriptionsdomain.SubscriptionID) ([]subscriptionsdomain.SubscriptionItem, error) {
+	return sharedtracing.Call(ctx, "subscription_items.repository.GetBySubscriptionID", func(ctx context.Context) ([]subscriptionsdomain.SubscriptionItem, error) {
+		return r.next.GetBySubscriptionID(ctx, subscriptionId)
+	})
+}
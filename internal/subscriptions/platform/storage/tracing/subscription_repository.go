@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+
+	sharedtracing "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+)
+
+// SubscriptionRepository decorates a subscriptionsdomain.SubscriptionRepository with a span
+// for every call.
+type SubscriptionRepository struct {
+	next subscriptionsdomain.SubscriptionRepository
+}
+
+// NewSubscriptionRepository returns a SubscriptionRepository that traces the calls to
+// next.
+func NewSubscriptionRepository(next subscriptionsdomain.SubscriptionRepository) *SubscriptionRepository {
+	return &SubscriptionRepository{
+		next: next,
+	}
+}
+
+// Save implements the subscriptionsdomain.SubscriptionRepository interface.
+func (r *SubscriptionRepository) Save(ctx context.Context, subscription subscriptionsdomain.Subscription) error {
+	return sharedtracing.Run(ctx, "subscriptions.repository.Save", func(ctx context.Context) error {
+		return r.next.Save(ctx, subscription)
+	})
+}
+
+// Update implements the subscriptionsdomain.SubscriptionRepository interface.
+func (r *SubscriptionRepository) Update(ctx context.Context, subscription subscriptionsdomain.Subscription) error {
+	return sharedtracing.Run(ctx, "subscriptions.repository.Update", func(ctx context.Context) error {
+		return r.next.Update(ctx, subscription)
+	})
+}
+
+// Delete implements the subscriptionsdomain.SubscriptionRepository interface.
+func (r *SubscriptionRepository) Delete(ctx context.Context, id subscriptionsdomain.SubscriptionID) error {
+	return sharedtracing.Run(ctx, "subscriptions.repository.Delete", func(ctx context.Context) error {
+		return r.next.Delete(ctx, id)
+	})
+}
+
+// Exists implements the subscriptionsdomain.SubscriptionRepository interface.
+func (r *SubscriptionRepository) Exists(ctx context.Context, id subscriptionsdomain.SubscriptionID) (bool, error) {
+	return sharedtracing.Call(ctx, "subscriptions.repository.Exists", func(ctx context.Context) (bool, error) {
+		return r.next.Exists(ctx, id)
+	})
+}
+
+// ExistsForUrl implements the subscriptionsdomain.SubscriptionRepository interface.
+func (r *SubscriptionRepository) ExistsForUrl(ctx context.Context, userId subscriptionsdomain.SubscriptionUserID, url subscriptionsdomain.SubscriptionUrl) (bool, error) {
+	return sharedtracing.Call(ctx, "subscriptions.repository.ExistsForUrl", func(ctx context.Context) (bool, error) {
+		return r.next.ExistsForUrl(ctx, userId, url)
+	})
+}
+
+// Get implements the subscriptionsdomain.SubscriptionRepository interface.
+func (r *SubscriptionRepository) Get(ctx context.Context, id subscriptionsdomain.SubscriptionID) (*subscriptionsdomain.Subscription, error) {
+	return sharedtracing.Call(ctx, "subscriptions.repository.Get", func(ctx context.Context) (*subscriptionsdomain.Subscription, error) {
+		return r.next.Get(ctx, id)
+	})
+}
+
+// GetByUserID implements the subscriptionsdomain.SubscriptionRepository interface.
+func (r *SubscriptionRepository) GetByUserID(ctx context.Context, userId subscriptionsdomain.SubscriptionUserID) ([]subscriptionsdomain.Subscription, error) {
+	return sharedtracing.Call(ctx, "subscriptions.repository.GetByUserID", func(ctx context.Context) ([]subscriptionsdomain.Subscription, error) {
+		return r.next.GetByUserID(ctx, userId)
+	})
+}
+
+// All implements the subscriptionsdomain.SubscriptionRepository interface.
+func (r *SubscriptionRepository) All(ctx context.Context) ([]subscriptionsdomain.Subscription, error) {
+	return sharedtracing.Call(ctx, "subscriptions.repository.All", func(ctx context.Context) ([]subscriptionsdomain.Subscription, error) {
+		return r.next.All(ctx)
+	})
+}
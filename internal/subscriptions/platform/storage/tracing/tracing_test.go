@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing/tracingtest"
+	subscriptionsdomain "github.com/rubenbupe/recipe-video-parser/internal/subscriptions/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/subscriptions/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const subscriptionID = "0b6f6a53-2a9e-4f7c-9d54-3b1c2f7a9e10"
+
+func Test_SubscriptionRepository_TracesCalls(t *testing.T) {
+	exporter := tracingtest.Record(t)
+	id, err := subscriptionsdomain.NewSubscriptionID(subscriptionID)
+	require.NoError(t, err)
+
+	next := new(storagemocks.SubscriptionRepository)
+	next.On("Exists", mock.Anything, id).Return(true, nil)
+	next.On("All", mock.Anything).Return(nil, errors.New("something unexpected happened"))
+	repo := NewSubscriptionRepository(next)
+
+	exists, err := repo.Exists(context.Background(), id)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	_, err = repo.All(context.Background())
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"subscriptions.repository.Exists", "subscriptions.repository.All"}, tracingtest.Names(exporter))
+	assert.Equal(t, codes.Error, tracingtest.Span(t, exporter, "subscriptions.repository.All").Status.Code)
+	next.AssertExpectations(t)
+}
+
+func Test_SubscriptionItemRepository_TracesCalls(t *testing.T) {
+	exporter := tracingtest.Record(t)
+	id, err := subscriptionsdomain.NewSubscriptionID(subscriptionID)
+	require.NoError(t, err)
+
+	next := new(storagemocks.SubscriptionItemRepository)
+	next.On("Exists", mock.Anything, id, "https://www.tiktok.com/@chef/video/1").Return(false, nil)
+	repo := NewSubscriptionItemRepository(next)
+
+	exists, err := repo.Exists(context.Background(), id, "https://www.tiktok.com/@chef/video/1")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.Equal(t, []string{"subscription_items.repository.Exists"}, tracingtest.Names(exporter))
+	next.AssertExpectations(t)
+}
@@ -4,18 +4,18 @@ import (
 	"context"
 
 	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
-	"github.com/rubenbupe/recipe-video-parser/kit/event"
 )
 
 type UserService struct {
 	userRepository usersdomain.UserRepository
-	eventBus       event.Bus
 }
 
-func NewUserService(userRepository usersdomain.UserRepository, eventBus event.Bus) UserService {
+// NewUserService returns the service that creates users. The repository
+// stores the recorded events in the outbox, from where they are relayed to
+// the event bus.
+func NewUserService(userRepository usersdomain.UserRepository) UserService {
 	return UserService{
 		userRepository: userRepository,
-		eventBus:       eventBus,
 	}
 }
 
@@ -50,9 +50,5 @@ func (s UserService) CreateUser(ctx context.Context, id, name, apikey, createdAt
 		return err
 	}
 
-	if err := s.userRepository.Save(ctx, user); err != nil {
-		return err
-	}
-
-	return s.eventBus.Publish(ctx, user.PullEvents())
+	return s.userRepository.Save(ctx, user)
 }
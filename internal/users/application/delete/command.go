@@ -0,0 +1,48 @@
+package delete
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const UserCommandType command.Type = "command.user.delete"
+
+type UserCommand struct {
+	name string
+}
+
+func NewUserCommand(name string) UserCommand {
+	return UserCommand{
+		name: name,
+	}
+}
+
+func (c UserCommand) Type() command.Type {
+	return UserCommandType
+}
+
+type UserCommandHandler struct {
+	service UserService
+}
+
+func NewUserCommandHandler(service UserService) UserCommandHandler {
+	return UserCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h UserCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	deleteUserCmd, ok := cmd.(UserCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.DeleteUser(ctx, deleteUserCmd.name)
+}
+
+func (h UserCommandHandler) SubscribedTo() command.Type {
+	return UserCommandType
+}
@@ -0,0 +1,37 @@
+package delete
+
+import (
+	"context"
+
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+)
+
+type UserService struct {
+	userRepository usersdomain.UserRepository
+}
+
+func NewUserService(userRepository usersdomain.UserRepository) UserService {
+	return UserService{
+		userRepository: userRepository,
+	}
+}
+
+// DeleteUser deletes the user and all their data: extractions, webhooks and
+// subscriptions.
+func (s UserService) DeleteUser(ctx context.Context, name string) error {
+	userName, err := usersdomain.NewUserName(name)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepository.GetByName(ctx, userName)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return usersdomain.ErrUserNotFound
+	}
+
+	user.Delete()
+	return s.userRepository.Delete(ctx, *user)
+}
@@ -0,0 +1,48 @@
+package exportdata
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const UserDataCommandType command.Type = "command.user.exportdata"
+
+type UserDataCommand struct {
+	name string
+}
+
+func NewUserDataCommand(name string) UserDataCommand {
+	return UserDataCommand{
+		name: name,
+	}
+}
+
+func (c UserDataCommand) Type() command.Type {
+	return UserDataCommandType
+}
+
+type UserDataCommandHandler struct {
+	service UserDataService
+}
+
+func NewUserDataCommandHandler(service UserDataService) UserDataCommandHandler {
+	return UserDataCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h UserDataCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	exportCmd, ok := cmd.(UserDataCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.ExportData(ctx, exportCmd.name)
+}
+
+func (h UserDataCommandHandler) SubscribedTo() command.Type {
+	return UserDataCommandType
+}
@@ -0,0 +1,37 @@
+package exportdata
+
+import (
+	"context"
+
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+)
+
+// UserDataService records the exports of the data of the users. The export
+// itself is put together from the queries of every module.
+type UserDataService struct {
+	userRepository usersdomain.UserRepository
+}
+
+func NewUserDataService(userRepository usersdomain.UserRepository) UserDataService {
+	return UserDataService{
+		userRepository: userRepository,
+	}
+}
+
+func (s UserDataService) ExportData(ctx context.Context, name string) error {
+	userName, err := usersdomain.NewUserName(name)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepository.GetByName(ctx, userName)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return usersdomain.ErrUserNotFound
+	}
+
+	user.DataExported()
+	return s.userRepository.Save(ctx, *user)
+}
@@ -0,0 +1,40 @@
+package exportdata
+
+import (
+	"context"
+	"testing"
+
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_UserDataService_ExportData_NotFound(t *testing.T) {
+	repo := new(storagemocks.UserRepository)
+	repo.On("GetByName", mock.Anything, mock.AnythingOfType("domain.UserName")).Return(nil, nil)
+
+	service := NewUserDataService(repo)
+	err := service.ExportData(context.Background(), "Test User")
+
+	repo.AssertExpectations(t)
+	assert.ErrorIs(t, err, usersdomain.ErrUserNotFound)
+}
+
+func Test_UserDataService_ExportData_Success(t *testing.T) {
+	user, _ := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "Test User", "api-key", "2023-10-01T00:00:00Z")
+	user.PullEvents()
+
+	repo := new(storagemocks.UserRepository)
+	repo.On("GetByName", mock.Anything, mock.AnythingOfType("domain.UserName")).Return(&user, nil)
+	repo.On("Save", mock.Anything, mock.MatchedBy(func(u usersdomain.User) bool {
+		events := u.PullEvents()
+		return len(events) == 1 && events[0].Type() == usersdomain.UserDataExportedEventType
+	})).Return(nil)
+
+	service := NewUserDataService(repo)
+	err := service.ExportData(context.Background(), "Test User")
+
+	repo.AssertExpectations(t)
+	assert.NoError(t, err)
+}
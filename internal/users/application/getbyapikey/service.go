@@ -2,7 +2,6 @@ package getbyapikey
 
 import (
 	"context"
-	"fmt"
 
 	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
 )
@@ -27,7 +26,7 @@ func (s UserByApiKeyService) GetUser(ctx context.Context, apiKey string) (*users
 		return nil, err
 	}
 	if user == nil {
-		return nil, fmt.Errorf("usuario no encontrado")
+		return nil, usersdomain.ErrUserNotFound
 	}
 	return user, nil
 }
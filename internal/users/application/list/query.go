@@ -0,0 +1,43 @@
+package list
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+const UsersQueryType query.Type = "query.user.list"
+
+type UsersQuery struct{}
+
+func NewUsersQuery() UsersQuery {
+	return UsersQuery{}
+}
+
+func (c UsersQuery) Type() query.Type {
+	return UsersQueryType
+}
+
+type UsersQueryHandler struct {
+	service UsersService
+}
+
+func NewUsersQueryHandler(service UsersService) UsersQueryHandler {
+	return UsersQueryHandler{
+		service: service,
+	}
+}
+
+// Handle implements the query.Handler interface.
+func (h UsersQueryHandler) Handle(ctx context.Context, q query.Query) (interface{}, error) {
+	if _, ok := q.(UsersQuery); !ok {
+		return nil, errors.New("unexpected query")
+	}
+
+	return h.service.GetUsers(ctx)
+}
+
+func (h UsersQueryHandler) SubscribedTo() query.Type {
+	return UsersQueryType
+}
@@ -0,0 +1,22 @@
+package list
+
+import (
+	"context"
+
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+)
+
+type UsersService struct {
+	userRepository usersdomain.UserRepository
+}
+
+func NewUsersService(userRepository usersdomain.UserRepository) UsersService {
+	return UsersService{
+		userRepository: userRepository,
+	}
+}
+
+// GetUsers returns all the users sorted by name.
+func (s UsersService) GetUsers(ctx context.Context) ([]usersdomain.User, error) {
+	return s.userRepository.All(ctx)
+}
@@ -30,7 +30,7 @@ func (s UserApiKeyService) UpdateApiKey(ctx context.Context, name, apikey string
 		return err
 	}
 	if user == nil {
-		return usersdomain.ErrEmptyUserName // o un error más específico de usuario no encontrado
+		return usersdomain.ErrUserNotFound
 	}
 
 	err = user.SetApiKey(apikey)
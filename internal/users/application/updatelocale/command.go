@@ -0,0 +1,54 @@
+package updatelocale
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const UserLocaleCommandType command.Type = "command.user.updatelocale"
+
+type UserLocaleCommand struct {
+	name   string
+	locale string
+}
+
+func NewUserLocaleCommand(name, locale string) UserLocaleCommand {
+	return UserLocaleCommand{
+		name:   name,
+		locale: locale,
+	}
+}
+
+func (c UserLocaleCommand) Type() command.Type {
+	return UserLocaleCommandType
+}
+
+type UserLocaleCommandHandler struct {
+	service UserLocaleService
+}
+
+func NewUserLocaleCommandHandler(service UserLocaleService) UserLocaleCommandHandler {
+	return UserLocaleCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h UserLocaleCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	updateLocaleCmd, ok := cmd.(UserLocaleCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.UpdateLocale(
+		ctx,
+		updateLocaleCmd.name,
+		updateLocaleCmd.locale,
+	)
+}
+
+func (h UserLocaleCommandHandler) SubscribedTo() command.Type {
+	return UserLocaleCommandType
+}
@@ -0,0 +1,48 @@
+package updatelocale
+
+import (
+	"context"
+
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/event"
+)
+
+type UserLocaleService struct {
+	userRepository usersdomain.UserRepository
+	eventBus       event.Bus
+}
+
+func NewUserLocaleService(userRepository usersdomain.UserRepository, eventBus event.Bus) UserLocaleService {
+	return UserLocaleService{
+		userRepository: userRepository,
+		eventBus:       eventBus,
+	}
+}
+
+func (s UserLocaleService) UpdateLocale(ctx context.Context, name, locale string) error {
+	userName, err := usersdomain.NewUserName(name)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepository.GetByName(ctx, userName)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return usersdomain.ErrUserNotFound
+	}
+
+	if err := user.SetLocale(locale); err != nil {
+		return err
+	}
+
+	if err := s.userRepository.Save(ctx, *user); err != nil {
+		return err
+	}
+
+	if s.eventBus != nil {
+		return s.eventBus.Publish(ctx, user.PullEvents())
+	}
+	return nil
+}
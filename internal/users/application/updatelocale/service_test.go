@@ -0,0 +1,53 @@
+package updatelocale
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_UserLocaleService_UpdateLocale_RepositoryError(t *testing.T) {
+	repo := new(storagemocks.UserRepository)
+	repo.On("GetByName", mock.Anything, mock.AnythingOfType("domain.UserName")).Return(nil, errors.New("not found"))
+
+	service := NewUserLocaleService(repo, nil)
+	err := service.UpdateLocale(context.Background(), "Test User", "en-GB")
+
+	repo.AssertExpectations(t)
+	assert.Error(t, err)
+}
+
+func Test_UserLocaleService_UpdateLocale_InvalidLocale(t *testing.T) {
+	user, _ := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "Test User", "api-key", "2023-10-01T00:00:00Z")
+
+	repo := new(storagemocks.UserRepository)
+	repo.On("GetByName", mock.Anything, mock.AnythingOfType("domain.UserName")).Return(&user, nil)
+
+	service := NewUserLocaleService(repo, nil)
+	err := service.UpdateLocale(context.Background(), "Test User", "not a locale")
+
+	repo.AssertExpectations(t)
+	assert.ErrorIs(t, err, usersdomain.ErrInvalidUserLocale)
+}
+
+func Test_UserLocaleService_UpdateLocale_Success(t *testing.T) {
+	user, _ := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "Test User", "api-key", "2023-10-01T00:00:00Z")
+
+	repo := new(storagemocks.UserRepository)
+	repo.On("GetByName", mock.Anything, mock.AnythingOfType("domain.UserName")).Return(&user, nil)
+	repo.On("Save", mock.Anything, mock.MatchedBy(func(u usersdomain.User) bool {
+		return u.Locale.String() == "en-US"
+	})).Return(nil)
+
+	service := NewUserLocaleService(repo, nil)
+	err := service.UpdateLocale(context.Background(), "Test User", "en-US")
+
+	repo.AssertExpectations(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "en-US", user.Locale.String())
+}
@@ -0,0 +1,57 @@
+package updatequota
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const UserQuotaCommandType command.Type = "command.user.updatequota"
+
+type UserQuotaCommand struct {
+	name               string
+	monthlyExtractions int
+	monthlyTokens      int
+}
+
+func NewUserQuotaCommand(name string, monthlyExtractions, monthlyTokens int) UserQuotaCommand {
+	return UserQuotaCommand{
+		name:               name,
+		monthlyExtractions: monthlyExtractions,
+		monthlyTokens:      monthlyTokens,
+	}
+}
+
+func (c UserQuotaCommand) Type() command.Type {
+	return UserQuotaCommandType
+}
+
+type UserQuotaCommandHandler struct {
+	service UserQuotaService
+}
+
+func NewUserQuotaCommandHandler(service UserQuotaService) UserQuotaCommandHandler {
+	return UserQuotaCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h UserQuotaCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	updateQuotaCmd, ok := cmd.(UserQuotaCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.UpdateQuota(
+		ctx,
+		updateQuotaCmd.name,
+		updateQuotaCmd.monthlyExtractions,
+		updateQuotaCmd.monthlyTokens,
+	)
+}
+
+func (h UserQuotaCommandHandler) SubscribedTo() command.Type {
+	return UserQuotaCommandType
+}
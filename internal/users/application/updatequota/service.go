@@ -0,0 +1,39 @@
+package updatequota
+
+import (
+	"context"
+
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+)
+
+type UserQuotaService struct {
+	userRepository usersdomain.UserRepository
+}
+
+func NewUserQuotaService(userRepository usersdomain.UserRepository) UserQuotaService {
+	return UserQuotaService{
+		userRepository: userRepository,
+	}
+}
+
+// UpdateQuota sets the monthly limits of the user. Zero removes a limit.
+func (s UserQuotaService) UpdateQuota(ctx context.Context, name string, monthlyExtractions, monthlyTokens int) error {
+	userName, err := usersdomain.NewUserName(name)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepository.GetByName(ctx, userName)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return usersdomain.ErrUserNotFound
+	}
+
+	if err := user.SetQuota(monthlyExtractions, monthlyTokens); err != nil {
+		return err
+	}
+
+	return s.userRepository.Save(ctx, *user)
+}
@@ -0,0 +1,40 @@
+package updatequota
+
+import (
+	"context"
+	"testing"
+
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_UserQuotaService_UpdateQuota_NegativeLimit(t *testing.T) {
+	user, _ := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "Test User", "api-key", "2023-10-01T00:00:00Z")
+
+	repo := new(storagemocks.UserRepository)
+	repo.On("GetByName", mock.Anything, mock.AnythingOfType("domain.UserName")).Return(&user, nil)
+
+	service := NewUserQuotaService(repo)
+	err := service.UpdateQuota(context.Background(), "Test User", -1, 0)
+
+	repo.AssertExpectations(t)
+	assert.ErrorIs(t, err, usersdomain.ErrInvalidUserQuota)
+}
+
+func Test_UserQuotaService_UpdateQuota_Success(t *testing.T) {
+	user, _ := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "Test User", "api-key", "2023-10-01T00:00:00Z")
+
+	repo := new(storagemocks.UserRepository)
+	repo.On("GetByName", mock.Anything, mock.AnythingOfType("domain.UserName")).Return(&user, nil)
+	repo.On("Save", mock.Anything, mock.MatchedBy(func(u usersdomain.User) bool {
+		return u.Quota.MonthlyExtractions() == 100 && u.Quota.MonthlyTokens() == 0
+	})).Return(nil)
+
+	service := NewUserQuotaService(repo)
+	err := service.UpdateQuota(context.Background(), "Test User", 100, 0)
+
+	repo.AssertExpectations(t)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,54 @@
+package updaterole
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const UserRoleCommandType command.Type = "command.user.updaterole"
+
+type UserRoleCommand struct {
+	name string
+	role string
+}
+
+func NewUserRoleCommand(name, role string) UserRoleCommand {
+	return UserRoleCommand{
+		name: name,
+		role: role,
+	}
+}
+
+func (c UserRoleCommand) Type() command.Type {
+	return UserRoleCommandType
+}
+
+type UserRoleCommandHandler struct {
+	service UserRoleService
+}
+
+func NewUserRoleCommandHandler(service UserRoleService) UserRoleCommandHandler {
+	return UserRoleCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h UserRoleCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	updateRoleCmd, ok := cmd.(UserRoleCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.UpdateRole(
+		ctx,
+		updateRoleCmd.name,
+		updateRoleCmd.role,
+	)
+}
+
+func (h UserRoleCommandHandler) SubscribedTo() command.Type {
+	return UserRoleCommandType
+}
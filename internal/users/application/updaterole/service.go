@@ -0,0 +1,38 @@
+package updaterole
+
+import (
+	"context"
+
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+)
+
+type UserRoleService struct {
+	userRepository usersdomain.UserRepository
+}
+
+func NewUserRoleService(userRepository usersdomain.UserRepository) UserRoleService {
+	return UserRoleService{
+		userRepository: userRepository,
+	}
+}
+
+func (s UserRoleService) UpdateRole(ctx context.Context, name, role string) error {
+	userName, err := usersdomain.NewUserName(name)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepository.GetByName(ctx, userName)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return usersdomain.ErrUserNotFound
+	}
+
+	if err := user.SetRole(role); err != nil {
+		return err
+	}
+
+	return s.userRepository.Save(ctx, *user)
+}
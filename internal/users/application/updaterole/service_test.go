@@ -0,0 +1,51 @@
+package updaterole
+
+import (
+	"context"
+	"testing"
+
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_UserRoleService_UpdateRole_NotFound(t *testing.T) {
+	repo := new(storagemocks.UserRepository)
+	repo.On("GetByName", mock.Anything, mock.AnythingOfType("domain.UserName")).Return(nil, nil)
+
+	service := NewUserRoleService(repo)
+	err := service.UpdateRole(context.Background(), "Test User", usersdomain.UserRoleAdmin)
+
+	repo.AssertExpectations(t)
+	assert.ErrorIs(t, err, usersdomain.ErrUserNotFound)
+}
+
+func Test_UserRoleService_UpdateRole_InvalidRole(t *testing.T) {
+	user, _ := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "Test User", "api-key", "2023-10-01T00:00:00Z")
+
+	repo := new(storagemocks.UserRepository)
+	repo.On("GetByName", mock.Anything, mock.AnythingOfType("domain.UserName")).Return(&user, nil)
+
+	service := NewUserRoleService(repo)
+	err := service.UpdateRole(context.Background(), "Test User", "root")
+
+	repo.AssertExpectations(t)
+	assert.ErrorIs(t, err, usersdomain.ErrInvalidUserRole)
+}
+
+func Test_UserRoleService_UpdateRole_Success(t *testing.T) {
+	user, _ := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "Test User", "api-key", "2023-10-01T00:00:00Z")
+
+	repo := new(storagemocks.UserRepository)
+	repo.On("GetByName", mock.Anything, mock.AnythingOfType("domain.UserName")).Return(&user, nil)
+	repo.On("Save", mock.Anything, mock.MatchedBy(func(u usersdomain.User) bool {
+		return u.IsAdmin()
+	})).Return(nil)
+
+	service := NewUserRoleService(repo)
+	err := service.UpdateRole(context.Background(), "Test User", usersdomain.UserRoleAdmin)
+
+	repo.AssertExpectations(t)
+	assert.NoError(t, err)
+}
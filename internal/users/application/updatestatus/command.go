@@ -0,0 +1,54 @@
+package updatestatus
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const UserStatusCommandType command.Type = "command.user.updatestatus"
+
+type UserStatusCommand struct {
+	name   string
+	status string
+}
+
+func NewUserStatusCommand(name, status string) UserStatusCommand {
+	return UserStatusCommand{
+		name:   name,
+		status: status,
+	}
+}
+
+func (c UserStatusCommand) Type() command.Type {
+	return UserStatusCommandType
+}
+
+type UserStatusCommandHandler struct {
+	service UserStatusService
+}
+
+func NewUserStatusCommandHandler(service UserStatusService) UserStatusCommandHandler {
+	return UserStatusCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h UserStatusCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	updateStatusCmd, ok := cmd.(UserStatusCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.UpdateStatus(
+		ctx,
+		updateStatusCmd.name,
+		updateStatusCmd.status,
+	)
+}
+
+func (h UserStatusCommandHandler) SubscribedTo() command.Type {
+	return UserStatusCommandType
+}
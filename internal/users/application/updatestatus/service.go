@@ -0,0 +1,40 @@
+package updatestatus
+
+import (
+	"context"
+
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+)
+
+// UserStatusService disables and enables users. The API keys of the disabled
+// users are rejected, but their data is kept.
+type UserStatusService struct {
+	userRepository usersdomain.UserRepository
+}
+
+func NewUserStatusService(userRepository usersdomain.UserRepository) UserStatusService {
+	return UserStatusService{
+		userRepository: userRepository,
+	}
+}
+
+func (s UserStatusService) UpdateStatus(ctx context.Context, name, status string) error {
+	userName, err := usersdomain.NewUserName(name)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepository.GetByName(ctx, userName)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return usersdomain.ErrUserNotFound
+	}
+
+	if err := user.ChangeStatus(status); err != nil {
+		return err
+	}
+
+	return s.userRepository.Save(ctx, *user)
+}
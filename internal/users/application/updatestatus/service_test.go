@@ -0,0 +1,70 @@
+package updatestatus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_UserStatusService_UpdateStatus_RepositoryError(t *testing.T) {
+	repo := new(storagemocks.UserRepository)
+	repo.On("GetByName", mock.Anything, mock.AnythingOfType("domain.UserName")).Return(nil, errors.New("something unexpected happened"))
+
+	service := NewUserStatusService(repo)
+	err := service.UpdateStatus(context.Background(), "Test User", usersdomain.UserStatusDisabled)
+
+	repo.AssertExpectations(t)
+	assert.Error(t, err)
+}
+
+func Test_UserStatusService_UpdateStatus_InvalidStatus(t *testing.T) {
+	user, _ := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "Test User", "api-key", "2023-10-01T00:00:00Z")
+
+	repo := new(storagemocks.UserRepository)
+	repo.On("GetByName", mock.Anything, mock.AnythingOfType("domain.UserName")).Return(&user, nil)
+
+	service := NewUserStatusService(repo)
+	err := service.UpdateStatus(context.Background(), "Test User", "banned")
+
+	repo.AssertExpectations(t)
+	assert.ErrorIs(t, err, usersdomain.ErrInvalidUserStatus)
+}
+
+func Test_UserStatusService_UpdateStatus_Success(t *testing.T) {
+	user, _ := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "Test User", "api-key", "2023-10-01T00:00:00Z")
+
+	repo := new(storagemocks.UserRepository)
+	repo.On("GetByName", mock.Anything, mock.AnythingOfType("domain.UserName")).Return(&user, nil)
+	repo.On("Save", mock.Anything, mock.MatchedBy(func(u usersdomain.User) bool {
+		events := u.PullEvents()
+		return u.IsDisabled() && len(events) > 0 && events[len(events)-1].Type() == usersdomain.UserDisabledEventType
+	})).Return(nil)
+
+	service := NewUserStatusService(repo)
+	err := service.UpdateStatus(context.Background(), "Test User", usersdomain.UserStatusDisabled)
+
+	repo.AssertExpectations(t)
+	assert.NoError(t, err)
+}
+
+func Test_UserStatusService_UpdateStatus_Unchanged(t *testing.T) {
+	user, _ := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "Test User", "api-key", "2023-10-01T00:00:00Z")
+	user.PullEvents()
+
+	repo := new(storagemocks.UserRepository)
+	repo.On("GetByName", mock.Anything, mock.AnythingOfType("domain.UserName")).Return(&user, nil)
+	repo.On("Save", mock.Anything, mock.MatchedBy(func(u usersdomain.User) bool {
+		return len(u.PullEvents()) == 0
+	})).Return(nil)
+
+	service := NewUserStatusService(repo)
+	err := service.UpdateStatus(context.Background(), "Test User", usersdomain.UserStatusActive)
+
+	repo.AssertExpectations(t)
+	assert.NoError(t, err)
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"encoding/json"
+
 	"github.com/rubenbupe/recipe-video-parser/kit/event"
 )
 
@@ -44,3 +46,240 @@ func (e UserCreatedEvent) UserApiKey() string {
 func (e UserCreatedEvent) UserCreatedAt() string {
   return e.createdAt
 }
+
+type userCreatedEventPayload struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	ApiKey    string `json:"apiKey"`
+	CreatedAt string `json:"createdAt"`
+}
+
+func (e UserCreatedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(userCreatedEventPayload{
+		ID:        e.id,
+		Name:      e.name,
+		ApiKey:    e.apikey,
+		CreatedAt: e.createdAt,
+	})
+}
+
+// DecodeUserCreatedEvent rebuilds a UserCreatedEvent from its JSON payload.
+func DecodeUserCreatedEvent(base event.BaseEvent, payload []byte) (event.Event, error) {
+	var p userCreatedEventPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, err
+	}
+
+	return UserCreatedEvent{
+		id:        p.ID,
+		name:      p.Name,
+		apikey:    p.ApiKey,
+		createdAt: p.CreatedAt,
+
+		BaseEvent: base,
+	}, nil
+}
+
+// userLifecycleEventPayload is the payload of the events of the lifecycle of
+// a user, which only identify the user.
+type userLifecycleEventPayload struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+const UserDisabledEventType event.Type = "events.user.disabled"
+
+// UserDisabledEvent is recorded when a user is disabled. Their API key is
+// rejected from then on, but their data is kept.
+type UserDisabledEvent struct {
+	event.BaseEvent
+	id   string
+	name string
+}
+
+func NewUserDisabledEvent(id, name string) UserDisabledEvent {
+	return UserDisabledEvent{
+		id:   id,
+		name: name,
+
+		BaseEvent: event.NewBaseEvent(id),
+	}
+}
+
+func (e UserDisabledEvent) Type() event.Type {
+	return UserDisabledEventType
+}
+
+func (e UserDisabledEvent) UserID() string {
+	return e.id
+}
+
+func (e UserDisabledEvent) UserName() string {
+	return e.name
+}
+
+func (e UserDisabledEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(userLifecycleEventPayload{ID: e.id, Name: e.name})
+}
+
+// DecodeUserDisabledEvent rebuilds an UserDisabledEvent from its JSON payload.
+func DecodeUserDisabledEvent(base event.BaseEvent, payload []byte) (event.Event, error) {
+	var p userLifecycleEventPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, err
+	}
+
+	return UserDisabledEvent{
+		id:   p.ID,
+		name: p.Name,
+
+		BaseEvent: base,
+	}, nil
+}
+
+const UserEnabledEventType event.Type = "events.user.enabled"
+
+// UserEnabledEvent is recorded when a disabled user is enabled again.
+type UserEnabledEvent struct {
+	event.BaseEvent
+	id   string
+	name string
+}
+
+func NewUserEnabledEvent(id, name string) UserEnabledEvent {
+	return UserEnabledEvent{
+		id:   id,
+		name: name,
+
+		BaseEvent: event.NewBaseEvent(id),
+	}
+}
+
+func (e UserEnabledEvent) Type() event.Type {
+	return UserEnabledEventType
+}
+
+func (e UserEnabledEvent) UserID() string {
+	return e.id
+}
+
+func (e UserEnabledEvent) UserName() string {
+	return e.name
+}
+
+func (e UserEnabledEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(userLifecycleEventPayload{ID: e.id, Name: e.name})
+}
+
+// DecodeUserEnabledEvent rebuilds an UserEnabledEvent from its JSON payload.
+func DecodeUserEnabledEvent(base event.BaseEvent, payload []byte) (event.Event, error) {
+	var p userLifecycleEventPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, err
+	}
+
+	return UserEnabledEvent{
+		id:   p.ID,
+		name: p.Name,
+
+		BaseEvent: base,
+	}, nil
+}
+
+const UserDeletedEventType event.Type = "events.user.deleted"
+
+// UserDeletedEvent is recorded when a user is deleted with all their data.
+type UserDeletedEvent struct {
+	event.BaseEvent
+	id   string
+	name string
+}
+
+func NewUserDeletedEvent(id, name string) UserDeletedEvent {
+	return UserDeletedEvent{
+		id:   id,
+		name: name,
+
+		BaseEvent: event.NewBaseEvent(id),
+	}
+}
+
+func (e UserDeletedEvent) Type() event.Type {
+	return UserDeletedEventType
+}
+
+func (e UserDeletedEvent) UserID() string {
+	return e.id
+}
+
+func (e UserDeletedEvent) UserName() string {
+	return e.name
+}
+
+func (e UserDeletedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(userLifecycleEventPayload{ID: e.id, Name: e.name})
+}
+
+// DecodeUserDeletedEvent rebuilds an UserDeletedEvent from its JSON payload.
+func DecodeUserDeletedEvent(base event.BaseEvent, payload []byte) (event.Event, error) {
+	var p userLifecycleEventPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, err
+	}
+
+	return UserDeletedEvent{
+		id:   p.ID,
+		name: p.Name,
+
+		BaseEvent: base,
+	}, nil
+}
+
+const UserDataExportedEventType event.Type = "events.user.data_exported"
+
+// UserDataExportedEvent is recorded when the data of a user is exported.
+type UserDataExportedEvent struct {
+	event.BaseEvent
+	id   string
+	name string
+}
+
+func NewUserDataExportedEvent(id, name string) UserDataExportedEvent {
+	return UserDataExportedEvent{
+		id:   id,
+		name: name,
+
+		BaseEvent: event.NewBaseEvent(id),
+	}
+}
+
+func (e UserDataExportedEvent) Type() event.Type {
+	return UserDataExportedEventType
+}
+
+func (e UserDataExportedEvent) UserID() string {
+	return e.id
+}
+
+func (e UserDataExportedEvent) UserName() string {
+	return e.name
+}
+
+func (e UserDataExportedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(userLifecycleEventPayload{ID: e.id, Name: e.name})
+}
+
+// DecodeUserDataExportedEvent rebuilds an UserDataExportedEvent from its JSON payload.
+func DecodeUserDataExportedEvent(base event.BaseEvent, payload []byte) (event.Event, error) {
+	var p userLifecycleEventPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, err
+	}
+
+	return UserDataExportedEvent{
+		id:   p.ID,
+		name: p.Name,
+
+		BaseEvent: base,
+	}, nil
+}
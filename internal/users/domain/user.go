@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/google/uuid"
@@ -53,6 +54,7 @@ func (name UserName) String() string {
 }
 
 var ErrUserAlreadyExists = errors.New("user already exists")
+var ErrUserNotFound = errors.New("user not found")
 
 type UserApiKey struct {
 	value string
@@ -72,6 +74,136 @@ func (key UserApiKey) String() string {
 	return key.value
 }
 
+var ErrInvalidUserLocale = errors.New("invalid User Locale")
+
+var userLocaleRegexp = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
+// UserLocale is the locale the user prefers for their recipes. An empty
+// value means the user has no preference.
+type UserLocale struct {
+	value string
+}
+
+func NewUserLocale(value string) (UserLocale, error) {
+	if value != "" && !userLocaleRegexp.MatchString(value) {
+		return UserLocale{}, fmt.Errorf("%w: %s", ErrInvalidUserLocale, value)
+	}
+
+	return UserLocale{
+		value: value,
+	}, nil
+}
+
+func (locale UserLocale) String() string {
+	return locale.value
+}
+
+var ErrInvalidUserRole = errors.New("invalid User Role")
+
+const (
+	UserRoleUser  = "user"
+	UserRoleAdmin = "admin"
+)
+
+// UserRole is the role of the user. Admins can manage the other users through
+// the admin API.
+type UserRole struct {
+	value string
+}
+
+func NewUserRole(value string) (UserRole, error) {
+	if value != UserRoleUser && value != UserRoleAdmin {
+		return UserRole{}, fmt.Errorf("%w: %s", ErrInvalidUserRole, value)
+	}
+
+	return UserRole{
+		value: value,
+	}, nil
+}
+
+func (role UserRole) String() string {
+	if role.value == "" {
+		return UserRoleUser
+	}
+	return role.value
+}
+
+var ErrInvalidUserStatus = errors.New("invalid User Status")
+
+const (
+	UserStatusActive   = "active"
+	UserStatusDisabled = "disabled"
+)
+
+// UserStatus is the status of the user. The API keys of the disabled users are
+// rejected, but their data is kept.
+type UserStatus struct {
+	value string
+}
+
+func NewUserStatus(value string) (UserStatus, error) {
+	if value != UserStatusActive && value != UserStatusDisabled {
+		return UserStatus{}, fmt.Errorf("%w: %s", ErrInvalidUserStatus, value)
+	}
+
+	return UserStatus{
+		value: value,
+	}, nil
+}
+
+func (status UserStatus) String() string {
+	if status.value == "" {
+		return UserStatusActive
+	}
+	return status.value
+}
+
+var ErrInvalidUserQuota = errors.New("invalid User Quota")
+var ErrUserQuotaExceeded = errors.New("user quota exceeded")
+
+// UserQuota is the maximum number of extractions and of tokens the user can
+// use every calendar month. Zero means no limit.
+type UserQuota struct {
+	monthlyExtractions int
+	monthlyTokens      int
+}
+
+func NewUserQuota(monthlyExtractions, monthlyTokens int) (UserQuota, error) {
+	if monthlyExtractions < 0 || monthlyTokens < 0 {
+		return UserQuota{}, fmt.Errorf("%w: the limits can not be negative", ErrInvalidUserQuota)
+	}
+
+	return UserQuota{
+		monthlyExtractions: monthlyExtractions,
+		monthlyTokens:      monthlyTokens,
+	}, nil
+}
+
+func (quota UserQuota) MonthlyExtractions() int {
+	return quota.monthlyExtractions
+}
+
+func (quota UserQuota) MonthlyTokens() int {
+	return quota.monthlyTokens
+}
+
+// Limited reports whether the quota has any limit.
+func (quota UserQuota) Limited() bool {
+	return quota.monthlyExtractions > 0 || quota.monthlyTokens > 0
+}
+
+// Check returns ErrUserQuotaExceeded if the extractions or the tokens used in
+// the month have reached one of the limits.
+func (quota UserQuota) Check(extractions, tokens int) error {
+	if quota.monthlyExtractions > 0 && extractions >= quota.monthlyExtractions {
+		return fmt.Errorf("%w: %d of %d extractions used this month", ErrUserQuotaExceeded, extractions, quota.monthlyExtractions)
+	}
+	if quota.monthlyTokens > 0 && tokens >= quota.monthlyTokens {
+		return fmt.Errorf("%w: %d of %d tokens used this month", ErrUserQuotaExceeded, tokens, quota.monthlyTokens)
+	}
+	return nil
+}
+
 type UserCreatedAt struct {
 	value string
 }
@@ -99,6 +231,10 @@ type User struct {
 	Id        UserID
 	Name      UserName
 	ApiKey    UserApiKey
+	Locale    UserLocale
+	Role      UserRole
+	Status    UserStatus
+	Quota     UserQuota
 	CreatedAt UserCreatedAt
 
 	events []event.Event
@@ -111,6 +247,10 @@ type UserRepository interface {
 	GetByName(ctx context.Context, name UserName) (*User, error)
 	GetByApiKey(ctx context.Context, apiKey UserApiKey) (*User, error)
 	ExistsByName(ctx context.Context, name UserName) (bool, error)
+	All(ctx context.Context) ([]User, error)
+	// Delete removes the user and all their data, and stores the events the
+	// user recorded.
+	Delete(ctx context.Context, user User) error
 }
 
 //mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=UserRepository
@@ -140,6 +280,8 @@ func NewUser(id, name, apiKey, createdAt string) (User, error) {
 		Id:        idVO,
 		Name:      nameVO,
 		ApiKey:    apiKeyVO,
+		Role:      UserRole{value: UserRoleUser},
+		Status:    UserStatus{value: UserStatusActive},
 		CreatedAt: createdAtVO,
 	}
 
@@ -148,13 +290,92 @@ func NewUser(id, name, apiKey, createdAt string) (User, error) {
 }
 
 func (u *User) SetApiKey(apiKey string) error {
-  apiKeyVO, err := NewUserApiKey(apiKey)
-  if err != nil {
-    return err
-  }
+	apiKeyVO, err := NewUserApiKey(apiKey)
+	if err != nil {
+		return err
+	}
+
+	u.ApiKey = apiKeyVO
+	return nil
+}
+
+func (u *User) SetLocale(locale string) error {
+	localeVO, err := NewUserLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	u.Locale = localeVO
+	return nil
+}
+
+func (u *User) SetRole(role string) error {
+	roleVO, err := NewUserRole(role)
+	if err != nil {
+		return err
+	}
+
+	u.Role = roleVO
+	return nil
+}
+
+func (u *User) SetStatus(status string) error {
+	statusVO, err := NewUserStatus(status)
+	if err != nil {
+		return err
+	}
+
+	u.Status = statusVO
+	return nil
+}
+
+func (u *User) SetQuota(monthlyExtractions, monthlyTokens int) error {
+	quotaVO, err := NewUserQuota(monthlyExtractions, monthlyTokens)
+	if err != nil {
+		return err
+	}
+
+	u.Quota = quotaVO
+	return nil
+}
+
+// ChangeStatus disables or enables the user, recording a UserDisabledEvent or
+// a UserEnabledEvent if the status changes. SetStatus only sets it.
+func (u *User) ChangeStatus(status string) error {
+	statusVO, err := NewUserStatus(status)
+	if err != nil {
+		return err
+	}
+	if statusVO == u.Status {
+		return nil
+	}
+
+	u.Status = statusVO
+	if u.IsDisabled() {
+		u.Record(NewUserDisabledEvent(u.Id.String(), u.Name.String()))
+	} else {
+		u.Record(NewUserEnabledEvent(u.Id.String(), u.Name.String()))
+	}
+	return nil
+}
+
+// Delete records the deletion of the user, which the repository stores with
+// the deletion of their data.
+func (u *User) Delete() {
+	u.Record(NewUserDeletedEvent(u.Id.String(), u.Name.String()))
+}
+
+// DataExported records the export of the data of the user.
+func (u *User) DataExported() {
+	u.Record(NewUserDataExportedEvent(u.Id.String(), u.Name.String()))
+}
+
+func (u User) IsAdmin() bool {
+	return u.Role.String() == UserRoleAdmin
+}
 
-  u.ApiKey = apiKeyVO
-  return nil
+func (u User) IsDisabled() bool {
+	return u.Status.String() == UserStatusDisabled
 }
 
 func (c *User) Record(evt event.Event) {
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/cleanup"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/application/delete"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/application/get"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+type DeleteUserInput struct {
+	Name string
+}
+
+type DeleteUserHandler func(context.Context, DeleteUserInput) error
+
+// CreateDeleteUserHandler borra un usuario con todos sus datos. Antes se
+// borran los ficheros que hayan dejado sus extracciones (descargas y vídeos
+// subidos al proveedor), que ya no se pueden encontrar una vez borradas.
+func CreateDeleteUserHandler(commandBus command.Bus, queryBus query.Bus) DeleteUserHandler {
+	return func(ctx context.Context, input DeleteUserInput) error {
+		if input.Name == "" {
+			return fmt.Errorf("todos los campos son obligatorios")
+		}
+
+		result, err := queryBus.Ask(ctx, get.NewUserQuery(input.Name))
+		if err != nil {
+			return fmt.Errorf("error al buscar usuario: %w", err)
+		}
+		user, ok := result.(*usersdomain.User)
+		if !ok {
+			return fmt.Errorf("respuesta inesperada del query")
+		}
+
+		if err := commandBus.Dispatch(ctx, cleanup.NewFilesCommand(user.Id.String())); err != nil {
+			return fmt.Errorf("error al borrar los ficheros del usuario: %w", err)
+		}
+
+		err = commandBus.Dispatch(ctx, delete.NewUserCommand(input.Name))
+		if err != nil {
+			switch {
+			case err == usersdomain.ErrEmptyUserName:
+				return fmt.Errorf("error de dominio: %w", err)
+			default:
+				return fmt.Errorf("error interno: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
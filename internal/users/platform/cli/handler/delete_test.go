@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/cleanup"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/application/delete"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/application/get"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/rubenbupe/recipe-video-parser/kit/query/querymocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteUserHandler_CleansFilesFirst(t *testing.T) {
+	user, err := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "name", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+	queryBus := new(querymocks.Bus)
+	queryBus.On("Ask", mock.Anything, get.NewUserQuery("name")).Return(&user, nil)
+
+	var dispatched []string
+	commandBus := new(commandmocks.Bus)
+	commandBus.On("Dispatch", mock.Anything, cleanup.NewFilesCommand(user.Id.String())).Return(nil).
+		Run(func(args mock.Arguments) { dispatched = append(dispatched, "cleanup") })
+	commandBus.On("Dispatch", mock.Anything, delete.NewUserCommand("name")).Return(nil).
+		Run(func(args mock.Arguments) { dispatched = append(dispatched, "delete") })
+
+	err = CreateDeleteUserHandler(commandBus, queryBus)(context.Background(), DeleteUserInput{Name: "name"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cleanup", "delete"}, dispatched)
+}
+
+func TestDeleteUserHandler_KeepsTheUserIfTheFilesFail(t *testing.T) {
+	user, err := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "name", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+	queryBus := new(querymocks.Bus)
+	queryBus.On("Ask", mock.Anything, get.NewUserQuery("name")).Return(&user, nil)
+	commandBus := new(commandmocks.Bus)
+	commandBus.On("Dispatch", mock.Anything, mock.AnythingOfType("cleanup.FilesCommand")).Return(errors.New("permission denied"))
+
+	err = CreateDeleteUserHandler(commandBus, queryBus)(context.Background(), DeleteUserInput{Name: "name"})
+
+	assert.ErrorContains(t, err, "permission denied")
+	commandBus.AssertNotCalled(t, "Dispatch", mock.Anything, mock.AnythingOfType("delete.UserCommand"))
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	recipesai "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/ai"
+	recipesclihandlers "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/cli/handler"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/application/exportdata"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+type ExportUserDataInput struct {
+	Name string
+}
+
+// ExportUserDataHandler devuelve un zip con los datos del usuario (ver
+// WriteUserData).
+type ExportUserDataHandler func(context.Context, ExportUserDataInput) ([]byte, error)
+
+// exportedExtraction es una extracción en extractions.json. Su receta, si la
+// tiene, está en el fichero Recipe del zip.
+type exportedExtraction struct {
+	ID            string          `json:"id"`
+	SourceUrl     string          `json:"sourceUrl"`
+	Status        string          `json:"status"`
+	ErrorCategory string          `json:"errorCategory,omitempty"`
+	ErrorMessage  string          `json:"errorMessage,omitempty"`
+	Recipe        string          `json:"recipe,omitempty"`
+	Metadata      json.RawMessage `json:"metadata,omitempty"`
+	CreatedAt     string          `json:"createdAt"`
+}
+
+// CreateExportUserDataHandler exporta los datos del usuario y registra la
+// exportación. La API key no se exporta.
+func CreateExportUserDataHandler(commandBus command.Bus, queryBus query.Bus) ExportUserDataHandler {
+	getUser := CreateGetUserHandler(queryBus)
+	getExtractions := recipesclihandlers.CreateGetExtractionsHandler(queryBus)
+	summarizeUsage := recipesclihandlers.CreateUsageSummaryHandler(queryBus)
+
+	return func(ctx context.Context, input ExportUserDataInput) ([]byte, error) {
+		if input.Name == "" {
+			return nil, fmt.Errorf("todos los campos son obligatorios")
+		}
+
+		user, err := getUser(ctx, GetUserInput{Name: input.Name})
+		if err != nil {
+			return nil, err
+		}
+
+		extractions, err := getExtractions(ctx, recipesclihandlers.GetExtractionInput{UserID: user.ID})
+		if err != nil && !errors.Is(err, recipesclihandlers.ErrNoExtractions) {
+			return nil, err
+		}
+
+		usage, err := summarizeUsage(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		if err := WriteUserData(&buf, user.UserOutput, extractions, usage, time.Now()); err != nil {
+			return nil, fmt.Errorf("error al generar la exportación: %w", err)
+		}
+
+		err = commandBus.Dispatch(ctx, exportdata.NewUserDataCommand(input.Name))
+		if err != nil {
+			switch {
+			case err == usersdomain.ErrEmptyUserName:
+				return nil, fmt.Errorf("error de dominio: %w", err)
+			default:
+				return nil, fmt.Errorf("error interno: %w", err)
+			}
+		}
+
+		return buf.Bytes(), nil
+	}
+}
+
+// WriteUserData escribe el zip de la exportación de los datos de un usuario:
+//
+//   - profile.json: el usuario.
+//   - extractions.json: todas sus extracciones, con sus metadatos.
+//   - recipes/<id>.json y recipes/<id>.md: la receta de cada extracción que
+//     la tenga, en JSON y en Markdown.
+//   - usage.json: el resumen del uso, como get-user-summary.
+func WriteUserData(w io.Writer, user UserOutput, extractions []recipesclihandlers.GetExtractionOutput, usage recipesclihandlers.ExtractionsSummary, exportedAt time.Time) error {
+	archive := zip.NewWriter(w)
+	add := func(name string, content []byte) error {
+		f, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: exportedAt})
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(content)
+		return err
+	}
+	addJSON := func(name string, v interface{}) error {
+		content, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		return add(name, content)
+	}
+
+	if err := addJSON("profile.json", user); err != nil {
+		return err
+	}
+
+	exported := make([]exportedExtraction, 0, len(extractions))
+	for _, extraction := range extractions {
+		item := exportedExtraction{
+			ID:            extraction.ID,
+			SourceUrl:     extraction.SourceUrl,
+			Status:        extraction.Status,
+			ErrorCategory: extraction.ErrorCategory,
+			ErrorMessage:  extraction.ErrorMessage,
+			CreatedAt:     extraction.CreatedAt,
+		}
+		if json.Valid([]byte(extraction.Metadata)) {
+			item.Metadata = json.RawMessage(extraction.Metadata)
+		}
+
+		if extraction.Status == recipesdomain.ExtractionStatusSucceeded && extraction.Data != "" {
+			var res recipesai.AiResponse
+			if err := json.Unmarshal([]byte(extraction.Data), &res.Recipe); err != nil {
+				return fmt.Errorf("invalid recipe of extraction %s: %w", extraction.ID, err)
+			}
+			// Sin metadatos el Markdown sale en el idioma por defecto
+			_ = json.Unmarshal([]byte(extraction.Metadata), &res.Metadata)
+
+			item.Recipe = "recipes/" + extraction.ID + ".json"
+			if err := addJSON(item.Recipe, res.Recipe); err != nil {
+				return err
+			}
+			if err := add("recipes/"+extraction.ID+".md", []byte(recipesai.FormatToMarkdown(res))); err != nil {
+				return err
+			}
+		}
+		exported = append(exported, item)
+	}
+	if err := addJSON("extractions.json", exported); err != nil {
+		return err
+	}
+
+	if err := addJSON("usage.json", usage); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	recipesget "github.com/rubenbupe/recipe-video-parser/internal/recipes/application/get"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	recipesclihandlers "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/cli/handler"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/application/exportdata"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/application/get"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/rubenbupe/recipe-video-parser/kit/query/querymocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const exportUserID = "37a0f027-15e6-47cc-a5d2-64183281087e"
+
+func readZip(t *testing.T, content []byte) map[string]string {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		r.Close()
+		files[f.Name] = string(b)
+	}
+	return files
+}
+
+func TestWriteUserData(t *testing.T) {
+	user := UserOutput{ID: exportUserID, Name: "ana", Role: usersdomain.UserRoleUser, Status: usersdomain.UserStatusActive}
+	extractions := []recipesclihandlers.GetExtractionOutput{
+		{
+			ID:        "8c5e5d2b-8d0a-4c0b-9f0a-6a6f4c1d2e3f",
+			UserID:    exportUserID,
+			SourceUrl: "https://example.com/a",
+			Status:    recipesdomain.ExtractionStatusSucceeded,
+			Data:      `{"title":"Tortilla","servings":2,"url":"https://example.com/a"}`,
+			Metadata:  `{"promptTokenCount":100,"candidatesTokenCount":10,"locale":"en-US"}`,
+			CreatedAt: "2024-05-01T10:00:00Z",
+		},
+		{
+			ID:            "9d6f6e3c-9e1b-4d1c-8a1b-7b7f5d2e3f4a",
+			UserID:        exportUserID,
+			SourceUrl:     "https://example.com/b",
+			Status:        recipesdomain.ExtractionStatusFailed,
+			ErrorCategory: recipesdomain.ExtractionErrorTimeout,
+			ErrorMessage:  "timeout",
+			Metadata:      `{"promptTokenCount":5}`,
+			CreatedAt:     "2024-05-02T10:00:00Z",
+		},
+	}
+
+	summary := recipesclihandlers.ExtractionsSummary{
+		Months: []recipesclihandlers.MonthSummary{{Month: "2024-05", Extractions: 2, Failed: 1, PromptTokens: 105, CandidatesTokens: 10, TotalTokens: 115}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteUserData(&buf, user, extractions, summary, time.Now()))
+
+	files := readZip(t, buf.Bytes())
+	assert.ElementsMatch(t, []string{
+		"profile.json",
+		"extractions.json",
+		"recipes/8c5e5d2b-8d0a-4c0b-9f0a-6a6f4c1d2e3f.json",
+		"recipes/8c5e5d2b-8d0a-4c0b-9f0a-6a6f4c1d2e3f.md",
+		"usage.json",
+	}, keys(files))
+	assert.NotContains(t, files["profile.json"], "apiKey")
+	assert.Contains(t, files["recipes/8c5e5d2b-8d0a-4c0b-9f0a-6a6f4c1d2e3f.md"], "# Tortilla")
+	assert.Contains(t, files["recipes/8c5e5d2b-8d0a-4c0b-9f0a-6a6f4c1d2e3f.md"], "Servings")
+
+	var exported []exportedExtraction
+	require.NoError(t, json.Unmarshal([]byte(files["extractions.json"]), &exported))
+	require.Len(t, exported, 2)
+	assert.Equal(t, "recipes/8c5e5d2b-8d0a-4c0b-9f0a-6a6f4c1d2e3f.json", exported[0].Recipe)
+	assert.Empty(t, exported[1].Recipe)
+	assert.Equal(t, recipesdomain.ExtractionErrorTimeout, exported[1].ErrorCategory)
+
+	var usage recipesclihandlers.ExtractionsSummary
+	require.NoError(t, json.Unmarshal([]byte(files["usage.json"]), &usage))
+	assert.Equal(t, summary.Months, usage.Months)
+}
+
+func TestExportUserDataHandler(t *testing.T) {
+	user, err := usersdomain.NewUser(exportUserID, "ana", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+
+	t.Run("it exports a user without extractions and records it", func(t *testing.T) {
+		queryBus := new(querymocks.Bus)
+		queryBus.On("Ask", mock.Anything, get.NewUserQuery("ana")).Return(&user, nil)
+		queryBus.On("Ask", mock.Anything, recipesget.NewExtractionQuery(exportUserID)).Return([]recipesdomain.Extraction{}, nil)
+		queryBus.On("Ask", mock.Anything, mock.AnythingOfType("usage.ReportQuery")).Return([]recipesdomain.UsageRow(nil), nil)
+		commandBus := new(commandmocks.Bus)
+		commandBus.On("Dispatch", mock.Anything, exportdata.NewUserDataCommand("ana")).Return(nil)
+
+		content, err := CreateExportUserDataHandler(commandBus, queryBus)(context.Background(), ExportUserDataInput{Name: "ana"})
+
+		require.NoError(t, err)
+		assert.Contains(t, readZip(t, content), "profile.json")
+		commandBus.AssertExpectations(t)
+	})
+
+	t.Run("it does not record the export if the user does not exist", func(t *testing.T) {
+		queryBus := new(querymocks.Bus)
+		queryBus.On("Ask", mock.Anything, get.NewUserQuery("bob")).Return(nil, usersdomain.ErrUserNotFound)
+		commandBus := new(commandmocks.Bus)
+
+		_, err := CreateExportUserDataHandler(commandBus, queryBus)(context.Background(), ExportUserDataInput{Name: "bob"})
+
+		assert.True(t, errors.Is(err, usersdomain.ErrUserNotFound))
+		commandBus.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+	})
+}
+
+func keys(m map[string]string) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
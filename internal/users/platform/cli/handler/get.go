@@ -13,11 +13,22 @@ type GetUserInput struct {
 	Name string
 }
 
+// UserOutput es un usuario sin su API key.
+type UserOutput struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Locale string `json:"locale"`
+	Role   string `json:"role"`
+	Status string `json:"status"`
+	// MonthlyExtractions y MonthlyTokens son la cuota del usuario; 0 es sin límite
+	MonthlyExtractions int    `json:"monthlyExtractions"`
+	MonthlyTokens      int    `json:"monthlyTokens"`
+	CreatedAt          string `json:"createdAt"`
+}
+
 type GetUserOutput struct {
-	ID        string
-	Name      string
-	ApiKey    string
-	CreatedAt string
+	UserOutput
+	ApiKey string `json:"apiKey"`
 }
 
 type GetUserHandler func(context.Context, GetUserInput) (*GetUserOutput, error)
@@ -39,10 +50,21 @@ func CreateGetUserHandler(queryBus query.Bus) GetUserHandler {
 		}
 
 		return &GetUserOutput{
-			ID:        user.Id.String(),
-			Name:      user.Name.String(),
-			ApiKey:    user.ApiKey.String(),
-			CreatedAt: user.CreatedAt.String(),
+			UserOutput: toUserOutput(*user),
+			ApiKey:     user.ApiKey.String(),
 		}, nil
 	}
 }
+
+func toUserOutput(user usersdomain.User) UserOutput {
+	return UserOutput{
+		ID:                 user.Id.String(),
+		Name:               user.Name.String(),
+		Locale:             user.Locale.String(),
+		Role:               user.Role.String(),
+		Status:             user.Status.String(),
+		MonthlyExtractions: user.Quota.MonthlyExtractions(),
+		MonthlyTokens:      user.Quota.MonthlyTokens(),
+		CreatedAt:          user.CreatedAt.String(),
+	}
+}
@@ -17,6 +17,7 @@ type GetUserByApiKeyOutput struct {
 	ID        string
 	Name      string
 	ApiKey    string
+	Locale    string
 	CreatedAt string
 }
 
@@ -42,6 +43,7 @@ func CreateGetUserByApiKeyHandler(queryBus query.Bus) GetUserByApiKeyHandler {
 			ID:        user.Id.String(),
 			Name:      user.Name.String(),
 			ApiKey:    user.ApiKey.String(),
+			Locale:    user.Locale.String(),
 			CreatedAt: user.CreatedAt.String(),
 		}, nil
 	}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/users/application/list"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+type ListUsersHandler func(context.Context) ([]UserOutput, error)
+
+// CreateListUsersHandler devuelve los usuarios ordenados por nombre, sin sus
+// API keys.
+func CreateListUsersHandler(queryBus query.Bus) ListUsersHandler {
+	return func(ctx context.Context) ([]UserOutput, error) {
+		result, err := queryBus.Ask(ctx, list.NewUsersQuery())
+		if err != nil {
+			return nil, fmt.Errorf("error al buscar usuarios: %w", err)
+		}
+
+		users, ok := result.([]usersdomain.User)
+		if !ok {
+			return nil, fmt.Errorf("respuesta inesperada del query")
+		}
+
+		outputs := make([]UserOutput, 0, len(users))
+		for _, user := range users {
+			outputs = append(outputs, toUserOutput(user))
+		}
+		return outputs, nil
+	}
+}
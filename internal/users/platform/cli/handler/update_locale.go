@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/i18n"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/application/updatelocale"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+type UpdateLocaleInput struct {
+	Name   string
+	Locale string
+}
+
+type UpdateLocaleHandler func(context.Context, UpdateLocaleInput) error
+
+func CreateUpdateLocaleHandler(commandBus command.Bus) UpdateLocaleHandler {
+	return func(ctx context.Context, input UpdateLocaleInput) error {
+		if input.Name == "" || input.Locale == "" {
+			return fmt.Errorf("todos los campos son obligatorios")
+		}
+
+		locale, err := i18n.ParseLocale(input.Locale)
+		if err != nil {
+			return fmt.Errorf("error de dominio: %w", err)
+		}
+
+		err = commandBus.Dispatch(ctx, updatelocale.NewUserLocaleCommand(
+			input.Name,
+			locale.Tag,
+		))
+
+		if err != nil {
+			switch {
+			case err == usersdomain.ErrEmptyUserName,
+				errors.Is(err, usersdomain.ErrInvalidUserLocale):
+				return fmt.Errorf("error de dominio: %w", err)
+			default:
+				return fmt.Errorf("error interno: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateLocaleHandler_Success(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("updatelocale.UserLocaleCommand")).Return(nil)
+	handler := CreateUpdateLocaleHandler(bus)
+	input := UpdateLocaleInput{
+		Name:   "name",
+		Locale: "en_gb",
+	}
+	err := handler(context.Background(), input)
+	assert.NoError(t, err)
+	bus.AssertExpectations(t)
+}
+
+func TestUpdateLocaleHandler_ErrorLocaleNoSoportado(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	handler := CreateUpdateLocaleHandler(bus)
+	input := UpdateLocaleInput{
+		Name:   "name",
+		Locale: "fr-FR",
+	}
+	err := handler(context.Background(), input)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error de dominio")
+}
+
+func TestUpdateLocaleHandler_ErrorCamposObligatorios(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	handler := CreateUpdateLocaleHandler(bus)
+	input := UpdateLocaleInput{}
+	err := handler(context.Background(), input)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "obligatorios")
+}
+
+func TestUpdateLocaleHandler_ErrorInterno(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("updatelocale.UserLocaleCommand")).Return(errors.New("fail"))
+	handler := CreateUpdateLocaleHandler(bus)
+	input := UpdateLocaleInput{
+		Name:   "name",
+		Locale: "es-ES",
+	}
+	err := handler(context.Background(), input)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error interno")
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/users/application/updatequota"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+type UpdateQuotaInput struct {
+	Name string
+	// MonthlyExtractions y MonthlyTokens son los límites de cada mes; 0 es sin
+	// límite
+	MonthlyExtractions int
+	MonthlyTokens      int
+}
+
+type UpdateQuotaHandler func(context.Context, UpdateQuotaInput) error
+
+func CreateUpdateQuotaHandler(commandBus command.Bus) UpdateQuotaHandler {
+	return func(ctx context.Context, input UpdateQuotaInput) error {
+		if input.Name == "" {
+			return fmt.Errorf("todos los campos son obligatorios")
+		}
+
+		err := commandBus.Dispatch(ctx, updatequota.NewUserQuotaCommand(
+			input.Name,
+			input.MonthlyExtractions,
+			input.MonthlyTokens,
+		))
+
+		if err != nil {
+			switch {
+			case err == usersdomain.ErrEmptyUserName,
+				errors.Is(err, usersdomain.ErrInvalidUserQuota):
+				return fmt.Errorf("error de dominio: %w", err)
+			default:
+				return fmt.Errorf("error interno: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
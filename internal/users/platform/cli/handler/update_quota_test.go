@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/users/application/updatequota"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateQuotaHandler_Success(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, updatequota.NewUserQuotaCommand("name", 100, 0)).Return(nil)
+	handler := CreateUpdateQuotaHandler(bus)
+	err := handler(context.Background(), UpdateQuotaInput{Name: "name", MonthlyExtractions: 100})
+	assert.NoError(t, err)
+	bus.AssertExpectations(t)
+}
+
+func TestUpdateQuotaHandler_ErrorCuotaNoValida(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("updatequota.UserQuotaCommand")).
+		Return(fmt.Errorf("%w: the limits can not be negative", usersdomain.ErrInvalidUserQuota))
+	handler := CreateUpdateQuotaHandler(bus)
+	err := handler(context.Background(), UpdateQuotaInput{Name: "name", MonthlyExtractions: -1})
+	assert.ErrorIs(t, err, usersdomain.ErrInvalidUserQuota)
+	assert.Contains(t, err.Error(), "error de dominio")
+}
+
+func TestUpdateQuotaHandler_ErrorInterno(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("updatequota.UserQuotaCommand")).Return(errors.New("fail"))
+	handler := CreateUpdateQuotaHandler(bus)
+	err := handler(context.Background(), UpdateQuotaInput{Name: "name"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error interno")
+}
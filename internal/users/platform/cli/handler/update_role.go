@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/users/application/updaterole"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+type UpdateRoleInput struct {
+	Name string
+	Role string
+}
+
+type UpdateRoleHandler func(context.Context, UpdateRoleInput) error
+
+func CreateUpdateRoleHandler(commandBus command.Bus) UpdateRoleHandler {
+	return func(ctx context.Context, input UpdateRoleInput) error {
+		if input.Name == "" || input.Role == "" {
+			return fmt.Errorf("todos los campos son obligatorios")
+		}
+
+		err := commandBus.Dispatch(ctx, updaterole.NewUserRoleCommand(
+			input.Name,
+			input.Role,
+		))
+
+		if err != nil {
+			switch {
+			case err == usersdomain.ErrEmptyUserName,
+				errors.Is(err, usersdomain.ErrInvalidUserRole):
+				return fmt.Errorf("error de dominio: %w", err)
+			default:
+				return fmt.Errorf("error interno: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateRoleHandler_Success(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("updaterole.UserRoleCommand")).Return(nil)
+	handler := CreateUpdateRoleHandler(bus)
+	err := handler(context.Background(), UpdateRoleInput{Name: "name", Role: usersdomain.UserRoleAdmin})
+	assert.NoError(t, err)
+	bus.AssertExpectations(t)
+}
+
+func TestUpdateRoleHandler_ErrorRolNoValido(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("updaterole.UserRoleCommand")).
+		Return(fmt.Errorf("%w: root", usersdomain.ErrInvalidUserRole))
+	handler := CreateUpdateRoleHandler(bus)
+	err := handler(context.Background(), UpdateRoleInput{Name: "name", Role: "root"})
+	assert.ErrorIs(t, err, usersdomain.ErrInvalidUserRole)
+	assert.Contains(t, err.Error(), "error de dominio")
+}
+
+func TestUpdateRoleHandler_ErrorCamposObligatorios(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	handler := CreateUpdateRoleHandler(bus)
+	err := handler(context.Background(), UpdateRoleInput{Name: "name"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "obligatorios")
+}
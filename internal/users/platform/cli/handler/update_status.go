@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/users/application/updatestatus"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+type UpdateStatusInput struct {
+	Name   string
+	Status string
+}
+
+type UpdateStatusHandler func(context.Context, UpdateStatusInput) error
+
+// CreateUpdateStatusHandler desactiva o reactiva un usuario.
+func CreateUpdateStatusHandler(commandBus command.Bus) UpdateStatusHandler {
+	return func(ctx context.Context, input UpdateStatusInput) error {
+		if input.Name == "" || input.Status == "" {
+			return fmt.Errorf("todos los campos son obligatorios")
+		}
+
+		err := commandBus.Dispatch(ctx, updatestatus.NewUserStatusCommand(
+			input.Name,
+			input.Status,
+		))
+
+		if err != nil {
+			switch {
+			case err == usersdomain.ErrEmptyUserName,
+				errors.Is(err, usersdomain.ErrInvalidUserStatus):
+				return fmt.Errorf("error de dominio: %w", err)
+			default:
+				return fmt.Errorf("error interno: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	recipesclihandlers "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/cli/handler"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	clihandlers "github.com/rubenbupe/recipe-video-parser/internal/users/platform/cli/handler"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+type createUserRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type createUserResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	ApiKey    string `json:"apiKey"`
+	CreatedAt string `json:"createdAt"`
+}
+
+type updateRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type updateQuotaRequest struct {
+	MonthlyExtractions int `json:"monthlyExtractions"`
+	MonthlyTokens      int `json:"monthlyTokens"`
+}
+
+type usageResponse struct {
+	UserID   string `json:"userId"`
+	UserName string `json:"userName"`
+	recipesclihandlers.ExtractionsSummary
+}
+
+// CreateHandler crea un usuario con una API key nueva, que solo se devuelve
+// en la respuesta y al pedir el usuario.
+func CreateHandler(commandBus command.Bus) gin.HandlerFunc {
+	createUser := clihandlers.CreateHandler(commandBus)
+
+	return func(ctx *gin.Context) {
+		var req createUserRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		input := clihandlers.CreateUserInput{
+			ID:        uuid.New().String(),
+			Name:      req.Name,
+			ApiKey:    uuid.New().String(),
+			CreatedAt: time.Now().Format(time.RFC3339),
+		}
+		if err := createUser(ctx, input); err != nil {
+			ctx.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.Header("Location", "/admin/users/"+input.Name)
+		ctx.JSON(http.StatusCreated, createUserResponse{
+			ID:        input.ID,
+			Name:      input.Name,
+			ApiKey:    input.ApiKey,
+			CreatedAt: input.CreatedAt,
+		})
+	}
+}
+
+// ListHandler devuelve todos los usuarios, sin sus API keys.
+func ListHandler(queryBus query.Bus) gin.HandlerFunc {
+	listUsers := clihandlers.CreateListUsersHandler(queryBus)
+
+	return func(ctx *gin.Context) {
+		users, err := listUsers(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"users": users})
+	}
+}
+
+// GetHandler devuelve el usuario del parámetro name.
+func GetHandler(queryBus query.Bus) gin.HandlerFunc {
+	getUser := clihandlers.CreateGetUserHandler(queryBus)
+
+	return func(ctx *gin.Context) {
+		user, err := getUser(ctx, clihandlers.GetUserInput{Name: ctx.Param("name")})
+		if err != nil {
+			ctx.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, user)
+	}
+}
+
+// UpdateStatusHandler desactiva o reactiva el usuario del parámetro name. Un
+// administrador no puede desactivarse a sí mismo.
+func UpdateStatusHandler(commandBus command.Bus, status string) gin.HandlerFunc {
+	updateStatus := clihandlers.CreateUpdateStatusHandler(commandBus)
+
+	return func(ctx *gin.Context) {
+		if status == usersdomain.UserStatusDisabled && isSelf(ctx) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": "no puedes desactivar tu propio usuario"})
+			return
+		}
+
+		if err := updateStatus(ctx, clihandlers.UpdateStatusInput{Name: ctx.Param("name"), Status: status}); err != nil {
+			ctx.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// DeleteHandler borra el usuario del parámetro name con todos sus datos. Un
+// administrador no puede borrarse a sí mismo.
+func DeleteHandler(commandBus command.Bus, queryBus query.Bus) gin.HandlerFunc {
+	deleteUser := clihandlers.CreateDeleteUserHandler(commandBus, queryBus)
+
+	return func(ctx *gin.Context) {
+		if isSelf(ctx) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": "no puedes borrar tu propio usuario"})
+			return
+		}
+
+		if err := deleteUser(ctx, clihandlers.DeleteUserInput{Name: ctx.Param("name")}); err != nil {
+			ctx.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// RotateApiKeyHandler genera una API key nueva para el usuario del parámetro
+// name. La anterior deja de funcionar.
+func RotateApiKeyHandler(commandBus command.Bus) gin.HandlerFunc {
+	updateApiKey := clihandlers.CreateUpdateApiKeyHandler(commandBus)
+
+	return func(ctx *gin.Context) {
+		input := clihandlers.UpdateApiKeyInput{Name: ctx.Param("name"), ApiKey: uuid.New().String()}
+		if err := updateApiKey(ctx, input); err != nil {
+			ctx.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"name": input.Name, "apiKey": input.ApiKey})
+	}
+}
+
+// UpdateRoleHandler cambia el rol del usuario del parámetro name. Un
+// administrador no puede quitarse el rol a sí mismo.
+func UpdateRoleHandler(commandBus command.Bus) gin.HandlerFunc {
+	updateRole := clihandlers.CreateUpdateRoleHandler(commandBus)
+
+	return func(ctx *gin.Context) {
+		var req updateRoleRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if req.Role != usersdomain.UserRoleAdmin && isSelf(ctx) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": "no puedes quitarte el rol de administrador"})
+			return
+		}
+
+		if err := updateRole(ctx, clihandlers.UpdateRoleInput{Name: ctx.Param("name"), Role: req.Role}); err != nil {
+			ctx.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// UpdateQuotaHandler cambia los límites mensuales del usuario del parámetro
+// name. Un límite a 0 es sin límite.
+func UpdateQuotaHandler(commandBus command.Bus) gin.HandlerFunc {
+	updateQuota := clihandlers.CreateUpdateQuotaHandler(commandBus)
+
+	return func(ctx *gin.Context) {
+		var req updateQuotaRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		input := clihandlers.UpdateQuotaInput{
+			Name:               ctx.Param("name"),
+			MonthlyExtractions: req.MonthlyExtractions,
+			MonthlyTokens:      req.MonthlyTokens,
+		}
+		if err := updateQuota(ctx, input); err != nil {
+			ctx.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// UsageHandler devuelve las extracciones y los tokens usados por el usuario
+// del parámetro name, como get-user-summary.
+func UsageHandler(queryBus query.Bus) gin.HandlerFunc {
+	getUser := clihandlers.CreateGetUserHandler(queryBus)
+	summarizeUsage := recipesclihandlers.CreateUsageSummaryHandler(queryBus)
+
+	return func(ctx *gin.Context) {
+		user, err := getUser(ctx, clihandlers.GetUserInput{Name: ctx.Param("name")})
+		if err != nil {
+			ctx.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		summary, err := summarizeUsage(ctx, user.ID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, usageResponse{
+			UserID:             user.ID,
+			UserName:           user.Name,
+			ExtractionsSummary: summary,
+		})
+	}
+}
+
+// ExportHandler descarga el zip con los datos del usuario del parámetro name,
+// como export-user-data.
+func ExportHandler(commandBus command.Bus, queryBus query.Bus) gin.HandlerFunc {
+	exportUserData := clihandlers.CreateExportUserDataHandler(commandBus, queryBus)
+
+	return func(ctx *gin.Context) {
+		sendUserData(ctx, exportUserData, ctx.Param("name"))
+	}
+}
+
+// ExportOwnHandler descarga el zip con los datos del usuario autenticado.
+func ExportOwnHandler(commandBus command.Bus, queryBus query.Bus) gin.HandlerFunc {
+	exportUserData := clihandlers.CreateExportUserDataHandler(commandBus, queryBus)
+
+	return func(ctx *gin.Context) {
+		user, ok := middleware.GetUserFromContext(ctx)
+		if !ok || user == nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+			return
+		}
+
+		sendUserData(ctx, exportUserData, user.Name.String())
+	}
+}
+
+func sendUserData(ctx *gin.Context, exportUserData clihandlers.ExportUserDataHandler, name string) {
+	content, err := exportUserData(ctx, clihandlers.ExportUserDataInput{Name: name})
+	if err != nil {
+		ctx.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Disposition", `attachment; filename="`+name+`-data.zip"`)
+	ctx.Data(http.StatusOK, "application/zip", content)
+}
+
+// isSelf indica si el usuario del parámetro name es el administrador
+// autenticado.
+func isSelf(ctx *gin.Context) bool {
+	user, ok := middleware.GetUserFromContext(ctx)
+	return ok && user != nil && user.Name.String() == ctx.Param("name")
+}
+
+func userErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usersdomain.ErrEmptyUserName),
+		errors.Is(err, usersdomain.ErrInvalidUserName),
+		errors.Is(err, usersdomain.ErrInvalidUserRole),
+		errors.Is(err, usersdomain.ErrInvalidUserStatus),
+		errors.Is(err, usersdomain.ErrInvalidUserQuota):
+		return http.StatusBadRequest
+	case errors.Is(err, usersdomain.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usersdomain.ErrUserAlreadyExists),
+		errors.Is(err, organizationsdomain.ErrLastOwner):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/cleanup"
+	"github.com/rubenbupe/recipe-video-parser/internal/recipes/application/usage"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	recipesclihandlers "github.com/rubenbupe/recipe-video-parser/internal/recipes/platform/cli/handler"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/application/delete"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/application/get"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/application/updatequota"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/rubenbupe/recipe-video-parser/kit/query/querymocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	adminID = "37a0f027-15e6-47cc-a5d2-64183281087e"
+	userID  = "5d3b8c0e-7a41-4f4b-9a55-1c2d3e4f5a6b"
+)
+
+func newRouter(t *testing.T, register func(r *gin.Engine)) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	admin, err := usersdomain.NewUser(adminID, "admin", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+	require.NoError(t, admin.SetRole(usersdomain.UserRoleAdmin))
+
+	r := gin.New()
+	r.Use(func(ctx *gin.Context) {
+		ctx.Set("user", &admin)
+	})
+	register(r)
+	return r
+}
+
+func serve(t *testing.T, r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req, err := http.NewRequest(method, path, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_Create(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("create.UserCommand")).Return(nil).Once()
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("create.UserCommand")).Return(usersdomain.ErrUserAlreadyExists)
+	r := newRouter(t, func(r *gin.Engine) { r.POST("/admin/users", CreateHandler(bus)) })
+
+	t.Run("it returns 201 with the api key", func(t *testing.T) {
+		rec := serve(t, r, http.MethodPost, "/admin/users", `{"name": "ana"}`)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "/admin/users/ana", rec.Header().Get("Location"))
+		var res createUserResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.NotEmpty(t, res.ApiKey)
+	})
+
+	t.Run("it returns 409 if the user exists", func(t *testing.T) {
+		rec := serve(t, r, http.MethodPost, "/admin/users", `{"name": "ana"}`)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("it returns 400 without name", func(t *testing.T) {
+		rec := serve(t, r, http.MethodPost, "/admin/users", `{}`)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestHandler_Get(t *testing.T) {
+	bus := new(querymocks.Bus)
+	bus.On("Ask", mock.Anything, get.NewUserQuery("nobody")).Return(nil, usersdomain.ErrUserNotFound)
+	r := newRouter(t, func(r *gin.Engine) { r.GET("/admin/users/:name", GetHandler(bus)) })
+
+	rec := serve(t, r, http.MethodGet, "/admin/users/nobody", "")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestHandler_Delete(t *testing.T) {
+	user, err := usersdomain.NewUser(userID, "ana", "apikey-ana", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+	queryBus := new(querymocks.Bus)
+	queryBus.On("Ask", mock.Anything, get.NewUserQuery("ana")).Return(&user, nil)
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, cleanup.NewFilesCommand(userID)).Return(nil)
+	bus.On("Dispatch", mock.Anything, delete.NewUserCommand("ana")).Return(nil)
+	r := newRouter(t, func(r *gin.Engine) { r.DELETE("/admin/users/:name", DeleteHandler(bus, queryBus)) })
+
+	t.Run("it returns 204", func(t *testing.T) {
+		rec := serve(t, r, http.MethodDelete, "/admin/users/ana", "")
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		bus.AssertExpectations(t)
+	})
+
+	t.Run("it returns 409 for the admin itself", func(t *testing.T) {
+		rec := serve(t, r, http.MethodDelete, "/admin/users/admin", "")
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
+
+func TestHandler_Disable(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	r := newRouter(t, func(r *gin.Engine) {
+		r.POST("/admin/users/:name/disable", UpdateStatusHandler(bus, usersdomain.UserStatusDisabled))
+	})
+
+	rec := serve(t, r, http.MethodPost, "/admin/users/admin/disable", "")
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	bus.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+}
+
+func TestHandler_UpdateQuota(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, updatequota.NewUserQuotaCommand("ana", 100, 0)).Return(nil)
+	bus.On("Dispatch", mock.Anything, updatequota.NewUserQuotaCommand("ana", -1, 0)).
+		Return(fmt.Errorf("%w: the limits can not be negative", usersdomain.ErrInvalidUserQuota))
+	r := newRouter(t, func(r *gin.Engine) { r.PUT("/admin/users/:name/quota", UpdateQuotaHandler(bus)) })
+
+	t.Run("it returns 204", func(t *testing.T) {
+		rec := serve(t, r, http.MethodPut, "/admin/users/ana/quota", `{"monthlyExtractions": 100}`)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("it returns 400 with a negative limit", func(t *testing.T) {
+		rec := serve(t, r, http.MethodPut, "/admin/users/ana/quota", `{"monthlyExtractions": -1}`)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestHandler_Usage(t *testing.T) {
+	user, err := usersdomain.NewUser(userID, "ana", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+	bus := new(querymocks.Bus)
+	bus.On("Ask", mock.Anything, get.NewUserQuery("ana")).Return(&user, nil)
+	bus.On("Ask", mock.Anything, usage.NewReportQuery(userID, "month", "key", "", "")).
+		Return([]recipesdomain.UsageRow{{Key: "2024-05", Extractions: 2, TotalTokens: 1100}}, nil)
+	bus.On("Ask", mock.Anything, mock.AnythingOfType("usage.ReportQuery")).Return([]recipesdomain.UsageRow(nil), nil)
+	r := newRouter(t, func(r *gin.Engine) { r.GET("/admin/users/:name/usage", UsageHandler(bus)) })
+
+	rec := serve(t, r, http.MethodGet, "/admin/users/ana/usage", "")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var res usageResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, userID, res.UserID)
+	assert.Equal(t, []recipesclihandlers.MonthSummary{{Month: "2024-05", Extractions: 2, TotalTokens: 1100}}, res.Months)
+}
+
+func TestHandler_Export(t *testing.T) {
+	user, err := usersdomain.NewUser(userID, "ana", "apikey-ana", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+	admin, err := usersdomain.NewUser(adminID, "admin", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+	queryBus := new(querymocks.Bus)
+	queryBus.On("Ask", mock.Anything, get.NewUserQuery("ana")).Return(&user, nil)
+	queryBus.On("Ask", mock.Anything, get.NewUserQuery("bob")).Return(nil, usersdomain.ErrUserNotFound)
+	queryBus.On("Ask", mock.Anything, get.NewUserQuery("admin")).Return(&admin, nil)
+	queryBus.On("Ask", mock.Anything, mock.AnythingOfType("get.ExtractionQuery")).Return([]recipesdomain.Extraction{}, nil)
+	queryBus.On("Ask", mock.Anything, mock.AnythingOfType("usage.ReportQuery")).Return([]recipesdomain.UsageRow(nil), nil)
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("exportdata.UserDataCommand")).Return(nil)
+	r := newRouter(t, func(r *gin.Engine) {
+		r.GET("/admin/users/:name/export", ExportHandler(bus, queryBus))
+		r.GET("/account/export", ExportOwnHandler(bus, queryBus))
+	})
+
+	t.Run("it downloads the zip of the user", func(t *testing.T) {
+		rec := serve(t, r, http.MethodGet, "/admin/users/ana/export", "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/zip", rec.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="ana-data.zip"`, rec.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("it returns 404 for an unknown user", func(t *testing.T) {
+		rec := serve(t, r, http.MethodGet, "/admin/users/bob/export", "")
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("it exports the authenticated user", func(t *testing.T) {
+		rec := serve(t, r, http.MethodGet, "/account/export", "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `attachment; filename="admin-data.zip"`, rec.Header().Get("Content-Disposition"))
+	})
+}
@@ -0,0 +1,60 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/di"
+	handlers "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/handler"
+	middleware "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+)
+
+// Register registra la API de administración de usuarios, solo para los
+// usuarios con el rol de administrador.
+func Register(router *gin.RouterGroup) {
+	diContainer := di.Instance()
+
+	createController := diContainer.Container.Get("users.infrastructure.controller.create").(handlers.Handler)
+	listController := diContainer.Container.Get("users.infrastructure.controller.list").(handlers.Handler)
+	getController := diContainer.Container.Get("users.infrastructure.controller.get").(handlers.Handler)
+	disableController := diContainer.Container.Get("users.infrastructure.controller.disable").(handlers.Handler)
+	enableController := diContainer.Container.Get("users.infrastructure.controller.enable").(handlers.Handler)
+	deleteController := diContainer.Container.Get("users.infrastructure.controller.delete").(handlers.Handler)
+	apiKeyController := diContainer.Container.Get("users.infrastructure.controller.rotateapikey").(handlers.Handler)
+	roleController := diContainer.Container.Get("users.infrastructure.controller.updaterole").(handlers.Handler)
+	quotaController := diContainer.Container.Get("users.infrastructure.controller.updatequota").(handlers.Handler)
+	usageController := diContainer.Container.Get("users.infrastructure.controller.usage").(handlers.Handler)
+	exportController := diContainer.Container.Get("users.infrastructure.controller.export").(handlers.Handler)
+	userRepo := diContainer.Container.Get("users.domain.repository").(usersdomain.UserRepository)
+	organizationRepo := diContainer.Container.Get("organizations.domain.repository").(organizationsdomain.OrganizationRepository)
+
+	router.Use(middleware.AuthMiddleware(userRepo, organizationRepo), middleware.AdminMiddleware())
+
+	router.POST("/users", createController)
+	router.GET("/users", listController)
+	router.GET("/users/:name", getController)
+	router.DELETE("/users/:name", deleteController)
+	router.POST("/users/:name/disable", disableController)
+	router.POST("/users/:name/enable", enableController)
+	router.POST("/users/:name/api-key", apiKeyController)
+	router.PUT("/users/:name/role", roleController)
+	router.PUT("/users/:name/quota", quotaController)
+	router.GET("/users/:name/usage", usageController)
+	router.GET("/users/:name/export", exportController)
+}
+
+// RegisterAccount registra la API de la cuenta del usuario autenticado.
+func RegisterAccount(router *gin.RouterGroup) {
+	diContainer := di.Instance()
+
+	exportController := diContainer.Container.Get("users.infrastructure.controller.exportown").(handlers.Handler)
+	usageController := diContainer.Container.Get("recipes.infrastructure.controller.usage").(handlers.Handler)
+	userRepo := diContainer.Container.Get("users.domain.repository").(usersdomain.UserRepository)
+	organizationRepo := diContainer.Container.Get("organizations.domain.repository").(organizationsdomain.OrganizationRepository)
+
+	router.Use(middleware.AuthMiddleware(userRepo, organizationRepo))
+
+	router.GET("/export", exportController)
+	router.GET("/usage", usageController)
+}
@@ -0,0 +1,129 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/event"
+)
+
+// UserRepository is a thread-safe in-memory implementation of the
+// usersdomain.UserRepository. It does not take part in transactions, and
+// publishes the events of the saved users straight to the event bus.
+type UserRepository struct {
+	mu       sync.RWMutex
+	users    map[string]usersdomain.User
+	eventBus event.Bus
+}
+
+// NewUserRepository initializes an empty UserRepository. The event bus can be
+// nil to discard the events.
+func NewUserRepository(eventBus event.Bus) *UserRepository {
+	return &UserRepository{
+		users:    make(map[string]usersdomain.User),
+		eventBus: eventBus,
+	}
+}
+
+// Save upserts the user. As the database does, it fails if another user has
+// the same name or API key.
+func (r *UserRepository) Save(ctx context.Context, user usersdomain.User) error {
+	events := user.PullEvents()
+
+	r.mu.Lock()
+	for id, stored := range r.users {
+		if id == user.Id.String() {
+			continue
+		}
+		if stored.Name.String() == user.Name.String() || stored.ApiKey.String() == user.ApiKey.String() {
+			r.mu.Unlock()
+			return fmt.Errorf("error trying to upsert user: name or api key already in use by user %s", id)
+		}
+	}
+	r.users[user.Id.String()] = user
+	r.mu.Unlock()
+
+	if r.eventBus == nil {
+		return nil
+	}
+	return r.eventBus.Publish(ctx, events)
+}
+
+func (r *UserRepository) Exists(_ context.Context, id usersdomain.UserID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.users[id.String()]
+	return ok, nil
+}
+
+func (r *UserRepository) ExistsByName(ctx context.Context, name usersdomain.UserName) (bool, error) {
+	user, err := r.GetByName(ctx, name)
+	return user != nil, err
+}
+
+func (r *UserRepository) Get(_ context.Context, id usersdomain.UserID) (*usersdomain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[id.String()]
+	if !ok {
+		return nil, nil
+	}
+	return &user, nil
+}
+
+func (r *UserRepository) GetByName(_ context.Context, name usersdomain.UserName) (*usersdomain.User, error) {
+	return r.find(func(user usersdomain.User) bool {
+		return user.Name.String() == name.String()
+	}), nil
+}
+
+func (r *UserRepository) GetByApiKey(_ context.Context, apiKey usersdomain.UserApiKey) (*usersdomain.User, error) {
+	return r.find(func(user usersdomain.User) bool {
+		return user.ApiKey.String() == apiKey.String()
+	}), nil
+}
+
+// All returns the users sorted by name.
+func (r *UserRepository) All(_ context.Context) ([]usersdomain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]usersdomain.User, 0, len(r.users))
+	for _, user := range r.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Name.String() < users[j].Name.String() })
+	return users, nil
+}
+
+// Delete removes the user and publishes the events they recorded. The
+// repository only holds users, so there is no other data to delete.
+func (r *UserRepository) Delete(ctx context.Context, user usersdomain.User) error {
+	events := user.PullEvents()
+
+	r.mu.Lock()
+	delete(r.users, user.Id.String())
+	r.mu.Unlock()
+
+	if r.eventBus == nil {
+		return nil
+	}
+	return r.eventBus.Publish(ctx, events)
+}
+
+func (r *UserRepository) find(match func(usersdomain.User) bool) *usersdomain.User {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if match(user) {
+			return &user
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/storagetest"
+	"github.com/rubenbupe/recipe-video-parser/kit/event"
+	"github.com/rubenbupe/recipe-video-parser/kit/event/eventmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UserRepository_Contract(t *testing.T) {
+	storagetest.UserRepositoryContract{
+		New: func(t *testing.T) usersdomain.UserRepository {
+			return NewUserRepository(nil)
+		},
+	}.Run(t)
+}
+
+func Test_UserRepository_Save_PublishesEvents(t *testing.T) {
+	user := storagetest.NewUser(t, "alice")
+
+	eventBus := new(eventmocks.Bus)
+	eventBus.On("Publish", mock.Anything, mock.MatchedBy(func(events []event.Event) bool {
+		return len(events) == 1 && events[0].Type() == usersdomain.UserCreatedEventType
+	})).Return(nil).Once()
+
+	repo := NewUserRepository(eventBus)
+	require.NoError(t, repo.Save(context.Background(), user))
+
+	stored, err := repo.Get(context.Background(), user.Id)
+	require.NoError(t, err)
+	assert.Empty(t, stored.PullEvents(), "the stored user must not keep its events")
+	eventBus.AssertExpectations(t)
+}
@@ -4,9 +4,16 @@ const (
 	sqlUserTable = "users"
 )
 
+var userColumns = []string{"id", "name", "api_key", "locale", "role", "status", "monthly_extractions", "monthly_tokens", "created_at"}
+
 type sqlUser struct {
-	ID        string `db:"id"`
-	Name      string `db:"name"`
-	ApiKey    string `db:"api_key"`
-	CreatedAt string `db:"created_at"`
+	ID                 string `db:"id"`
+	Name               string `db:"name"`
+	ApiKey             string `db:"api_key"`
+	Locale             string `db:"locale"`
+	Role               string `db:"role"`
+	Status             string `db:"status"`
+	MonthlyExtractions int    `db:"monthly_extractions"`
+	MonthlyTokens      int    `db:"monthly_tokens"`
+	CreatedAt          string `db:"created_at"`
 }
@@ -2,9 +2,13 @@ package sql
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/huandu/go-sqlbuilder"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/outbox"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
 	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
 )
@@ -12,29 +16,39 @@ import (
 type UserRepository struct {
 	connection *storage.Connection
 	dbconfig   *storage.Dbconfig
+	outbox     *outbox.Store
 }
 
-func NewUserRepository(connection *storage.Connection, dbconfig *storage.Dbconfig) *UserRepository {
+func NewUserRepository(connection *storage.Connection, dbconfig *storage.Dbconfig, outbox *outbox.Store) *UserRepository {
 	return &UserRepository{
 		connection: connection,
 		dbconfig:   dbconfig,
+		outbox:     outbox,
 	}
 }
 
+// Save upserts the user and stores the events it recorded in the same
+// transaction, which is the one of the context if there is any.
 func (r *UserRepository) Save(ctx context.Context, user usersdomain.User) error {
-	query := "INSERT INTO " + sqlUserTable + " (id, name, api_key, created_at) VALUES (?, ?, ?, ?) " +
-		"ON CONFLICT(id) DO UPDATE SET name=excluded.name, api_key=excluded.api_key, created_at=excluded.created_at"
-	args := []interface{}{user.Id.String(), user.Name.String(), user.ApiKey.String(), user.CreatedAt.String()}
+	query := "INSERT INTO " + sqlUserTable + " (id, name, api_key, locale, role, status, monthly_extractions, monthly_tokens, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) " +
+		"ON CONFLICT(id) DO UPDATE SET name=excluded.name, api_key=excluded.api_key, locale=excluded.locale, role=excluded.role, status=excluded.status, " +
+		"monthly_extractions=excluded.monthly_extractions, monthly_tokens=excluded.monthly_tokens, created_at=excluded.created_at"
+	args := []interface{}{
+		user.Id.String(), user.Name.String(), user.ApiKey.String(), user.Locale.String(), user.Role.String(), user.Status.String(),
+		user.Quota.MonthlyExtractions(), user.Quota.MonthlyTokens(), user.CreatedAt.String(),
+	}
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
 	defer cancel()
 
-	_, err := r.connection.Db.ExecContext(ctxTimeout, query, args...)
-	if err != nil {
-		return fmt.Errorf("error trying to upsert user on database: %v", err)
-	}
+	return r.connection.Transaction(ctxTimeout, func(ctx context.Context) error {
+		executor := r.connection.Executor(ctx)
+		if _, err := executor.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("error trying to upsert user on database: %v", err)
+		}
 
-	return nil
+		return r.outbox.Add(ctx, executor, user.PullEvents())
+	})
 }
 
 func (r *UserRepository) Exists(ctx context.Context, id usersdomain.UserID) (bool, error) {
@@ -46,7 +60,7 @@ func (r *UserRepository) Exists(ctx context.Context, id usersdomain.UserID) (boo
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
 	defer cancel()
 
-	rows, err := r.connection.Db.QueryContext(ctxTimeout, query, args...)
+	rows, err := r.connection.Executor(ctxTimeout).QueryContext(ctxTimeout, query, args...)
 	if err != nil {
 		return false, fmt.Errorf("error trying to check if user exists on database: %v", err)
 	}
@@ -64,7 +78,7 @@ func (r *UserRepository) ExistsByName(ctx context.Context, name usersdomain.User
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
 	defer cancel()
 
-	rows, err := r.connection.Db.QueryContext(ctxTimeout, query, args...)
+	rows, err := r.connection.Executor(ctxTimeout).QueryContext(ctxTimeout, query, args...)
 	if err != nil {
 		return false, fmt.Errorf("error trying to check if user exists by name on database: %v", err)
 	}
@@ -75,7 +89,7 @@ func (r *UserRepository) ExistsByName(ctx context.Context, name usersdomain.User
 
 func (r *UserRepository) Get(ctx context.Context, id usersdomain.UserID) (*usersdomain.User, error) {
 	userSQLStruct := sqlbuilder.NewStruct(new(sqlUser))
-	sb := sqlbuilder.Select("id", "name", "api_key", "created_at").From(sqlUserTable)
+	sb := sqlbuilder.Select(userColumns...).From(sqlUserTable)
 	sb.Where(sb.Equal("id", id.String()))
 	sb.SetFlavor(sqlbuilder.SQLite)
 	query, args := sb.Build()
@@ -83,7 +97,7 @@ func (r *UserRepository) Get(ctx context.Context, id usersdomain.UserID) (*users
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
 	defer cancel()
 
-	row := r.connection.Db.QueryRowContext(ctxTimeout, query, args...)
+	row := r.connection.Executor(ctxTimeout).QueryRowContext(ctxTimeout, query, args...)
 	if err := row.Err(); err != nil {
 		return nil, fmt.Errorf("error trying to get user from database: %v", err)
 	}
@@ -93,13 +107,12 @@ func (r *UserRepository) Get(ctx context.Context, id usersdomain.UserID) (*users
 		return nil, nil
 	}
 
-	userVO, err := usersdomain.NewUser(user.ID, user.Name, user.ApiKey, user.CreatedAt)
-	return &userVO, err
+	return toDomainUser(user)
 }
 
 func (r *UserRepository) GetByName(ctx context.Context, name usersdomain.UserName) (*usersdomain.User, error) {
 	userSQLStruct := sqlbuilder.NewStruct(new(sqlUser))
-	sb := sqlbuilder.Select("id", "name", "api_key", "created_at").From(sqlUserTable)
+	sb := sqlbuilder.Select(userColumns...).From(sqlUserTable)
 	sb.Where(sb.Equal("name", name.String()))
 	sb.SetFlavor(sqlbuilder.SQLite)
 	query, args := sb.Build()
@@ -107,7 +120,7 @@ func (r *UserRepository) GetByName(ctx context.Context, name usersdomain.UserNam
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
 	defer cancel()
 
-	row := r.connection.Db.QueryRowContext(ctxTimeout, query, args...)
+	row := r.connection.Executor(ctxTimeout).QueryRowContext(ctxTimeout, query, args...)
 	if err := row.Err(); err != nil {
 		return nil, fmt.Errorf("error trying to get user by name from database: %v", err)
 	}
@@ -117,13 +130,12 @@ func (r *UserRepository) GetByName(ctx context.Context, name usersdomain.UserNam
 		return nil, nil
 	}
 
-	userVO, err := usersdomain.NewUser(user.ID, user.Name, user.ApiKey, user.CreatedAt)
-	return &userVO, err
+	return toDomainUser(user)
 }
 
 func (r *UserRepository) GetByApiKey(ctx context.Context, apiKey usersdomain.UserApiKey) (*usersdomain.User, error) {
 	userSQLStruct := sqlbuilder.NewStruct(new(sqlUser))
-	sb := sqlbuilder.Select("id", "name", "api_key", "created_at").From(sqlUserTable)
+	sb := sqlbuilder.Select(userColumns...).From(sqlUserTable)
 	sb.Where(sb.Equal("api_key", apiKey.String()))
 	sb.SetFlavor(sqlbuilder.SQLite)
 	query, args := sb.Build()
@@ -131,7 +143,7 @@ func (r *UserRepository) GetByApiKey(ctx context.Context, apiKey usersdomain.Use
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
 	defer cancel()
 
-	row := r.connection.Db.QueryRowContext(ctxTimeout, query, args...)
+	row := r.connection.Executor(ctxTimeout).QueryRowContext(ctxTimeout, query, args...)
 	if err := row.Err(); err != nil {
 		return nil, fmt.Errorf("error trying to get user by apiKey from database: %v", err)
 	}
@@ -141,6 +153,116 @@ func (r *UserRepository) GetByApiKey(ctx context.Context, apiKey usersdomain.Use
 		return nil, nil
 	}
 
+	return toDomainUser(user)
+}
+
+// All returns the users sorted by name.
+func (r *UserRepository) All(ctx context.Context) ([]usersdomain.User, error) {
+	userSQLStruct := sqlbuilder.NewStruct(new(sqlUser))
+	sb := sqlbuilder.Select(userColumns...).From(sqlUserTable)
+	sb.OrderBy("name").Asc()
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	rows, err := r.connection.Executor(ctxTimeout).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to get users from database: %v", err)
+	}
+	defer rows.Close()
+
+	users := []usersdomain.User{}
+	for rows.Next() {
+		user := new(sqlUser)
+		if err := rows.Scan(userSQLStruct.Addr(user)...); err != nil {
+			return nil, fmt.Errorf("error trying to scan user from database: %v", err)
+		}
+		domainUser, err := toDomainUser(user)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *domainUser)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error trying to get users from database: %v", err)
+	}
+	return users, nil
+}
+
+// userData are the deletes of the data of a user, children first. SQLite does
+// not enforce the foreign keys of the schema, so their ON DELETE CASCADE is
+// done by hand. The extractions shared with an organization stay in its
+// library.
+var userData = []struct {
+	table string
+	where string
+}{
+	{"webhook_deliveries", "webhook_id IN (SELECT id FROM webhooks WHERE user_id = ?)"},
+	{"webhooks", "user_id = ?"},
+	{"subscription_items", "subscription_id IN (SELECT id FROM subscriptions WHERE user_id = ?)"},
+	{"subscriptions", "user_id = ?"},
+	{"organization_members", "user_id = ?"},
+	{"recipe_extractions", "user_id = ? AND organization_id = ''"},
+	{sqlUserTable, "id = ?"},
+}
+
+// soleOwnedOrganization is the first organization the user is the only owner
+// of.
+const soleOwnedOrganization = `SELECT m.organization_id FROM organization_members m
+WHERE m.user_id = ? AND m.role = ? AND NOT EXISTS (
+	SELECT 1 FROM organization_members o
+	WHERE o.organization_id = m.organization_id AND o.role = m.role AND o.user_id <> m.user_id
+)
+ORDER BY m.organization_id LIMIT 1`
+
+// Delete removes the user and all their data, and stores the events the user
+// recorded, in the same transaction. It returns ErrLastOwner if the user is
+// the only owner of an organization, which would be left without owners.
+func (r *UserRepository) Delete(ctx context.Context, user usersdomain.User) error {
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	return r.connection.Transaction(ctxTimeout, func(ctx context.Context) error {
+		executor := r.connection.Executor(ctx)
+
+		var organizationId string
+		err := executor.QueryRowContext(ctx, soleOwnedOrganization, user.Id.String(), organizationsdomain.MemberRoleOwner).Scan(&organizationId)
+		switch {
+		case err == nil:
+			return fmt.Errorf("%w: the user is the only owner of organization %s", organizationsdomain.ErrLastOwner, organizationId)
+		case !errors.Is(err, sql.ErrNoRows):
+			return fmt.Errorf("error trying to get the organizations of user from database: %v", err)
+		}
+
+		for _, data := range userData {
+			if _, err := executor.ExecContext(ctx, "DELETE FROM "+data.table+" WHERE "+data.where, user.Id.String()); err != nil {
+				return fmt.Errorf("error trying to delete user %s from database: %v", data.table, err)
+			}
+		}
+
+		return r.outbox.Add(ctx, executor, user.PullEvents())
+	})
+}
+
+// toDomainUser discards the events recorded while rebuilding the user, so
+// saving it again does not store a new UserCreatedEvent.
+func toDomainUser(user *sqlUser) (*usersdomain.User, error) {
 	userVO, err := usersdomain.NewUser(user.ID, user.Name, user.ApiKey, user.CreatedAt)
+	userVO.PullEvents()
+	if err != nil {
+		return &userVO, err
+	}
+	if err := userVO.SetLocale(user.Locale); err != nil {
+		return &userVO, err
+	}
+	if err := userVO.SetRole(user.Role); err != nil {
+		return &userVO, err
+	}
+	if err := userVO.SetStatus(user.Status); err != nil {
+		return &userVO, err
+	}
+	err = userVO.SetQuota(user.MonthlyExtractions, user.MonthlyTokens)
 	return &userVO, err
 }
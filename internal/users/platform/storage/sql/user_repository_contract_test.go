@@ -0,0 +1,48 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/storagetest"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UserRepository_Contract(t *testing.T) {
+	storagetest.UserRepositoryContract{
+		New: func(t *testing.T) usersdomain.UserRepository {
+			config := storage.Dbconfig{
+				Database: filepath.Join(t.TempDir(), "app"),
+				Timeout:  5 * time.Second,
+			}
+			connection, err := storage.CreateConnection(fmt.Sprintf("test-%s", t.Name()), &config)
+			require.NoError(t, err)
+			t.Cleanup(func() { connection.Db.Close() })
+			require.NoError(t, storage.CreateSchema(context.Background(), connection))
+
+			return NewUserRepository(connection, &config, newTestOutbox(connection, &config))
+		},
+		NewFailing: func(t *testing.T) usersdomain.UserRepository {
+			// sqlmock solo cubre los errores del almacenamiento: sin
+			// expectativas falla en cada consulta. El comportamiento se
+			// prueba contra el fichero SQLite de New.
+			db, _, err := sqlmock.New()
+			require.NoError(t, err)
+			t.Cleanup(func() { db.Close() })
+			connection := storage.Connection{
+				Db: db,
+			}
+			config := storage.Dbconfig{
+				Timeout: 1 * time.Second,
+			}
+
+			return NewUserRepository(&connection, &config, newTestOutbox(&connection, &config))
+		},
+	}.Run(t)
+}
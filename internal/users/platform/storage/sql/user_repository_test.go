@@ -3,12 +3,17 @@ package sql
 import (
 	"context"
 	"errors"
+	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/outbox"
 	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
 	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/event"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -27,12 +32,16 @@ func Test_UserRepository_Save_RepositoryError(t *testing.T) {
 	}
 	require.NoError(t, err)
 
+	sqlMock.ExpectBegin()
 	sqlMock.ExpectExec(
-		"INSERT INTO users (id, name, api_key, created_at) VALUES (?, ?, ?, ?) ON CONFLICT(id) DO UPDATE SET name=excluded.name, api_key=excluded.api_key, created_at=excluded.created_at").
-		WithArgs(userID, userName, userApiKey, userCreatedAt).
+		"INSERT INTO users (id, name, api_key, locale, role, status, monthly_extractions, monthly_tokens, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) "+
+			"ON CONFLICT(id) DO UPDATE SET name=excluded.name, api_key=excluded.api_key, locale=excluded.locale, role=excluded.role, status=excluded.status, "+
+			"monthly_extractions=excluded.monthly_extractions, monthly_tokens=excluded.monthly_tokens, created_at=excluded.created_at").
+		WithArgs(userID, userName, userApiKey, "", "user", "active", 0, 0, userCreatedAt).
 		WillReturnError(errors.New("something-failed"))
+	sqlMock.ExpectRollback()
 
-	repo := NewUserRepository(&connection, &config)
+	repo := NewUserRepository(&connection, &config, newTestOutbox(&connection, &config))
 
 	err = repo.Save(context.Background(), user)
 
@@ -55,12 +64,20 @@ func Test_UserRepository_Save_Succeed(t *testing.T) {
 	}
 	require.NoError(t, err)
 
+	sqlMock.ExpectBegin()
 	sqlMock.ExpectExec(
-		"INSERT INTO users (id, name, api_key, created_at) VALUES (?, ?, ?, ?) ON CONFLICT(id) DO UPDATE SET name=excluded.name, api_key=excluded.api_key, created_at=excluded.created_at").
-		WithArgs(userID, userName, userApiKey, userCreatedAt).
+		"INSERT INTO users (id, name, api_key, locale, role, status, monthly_extractions, monthly_tokens, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) "+
+			"ON CONFLICT(id) DO UPDATE SET name=excluded.name, api_key=excluded.api_key, locale=excluded.locale, role=excluded.role, status=excluded.status, "+
+			"monthly_extractions=excluded.monthly_extractions, monthly_tokens=excluded.monthly_tokens, created_at=excluded.created_at").
+		WithArgs(userID, userName, userApiKey, "", "user", "active", 0, 0, userCreatedAt).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(
+		"INSERT INTO event_outbox (id, type, aggregate_id, payload, occurred_on) VALUES (?, ?, ?, ?, ?)").
+		WithArgs(sqlmock.AnyArg(), "events.user.created", userID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
 
-	repo := NewUserRepository(&connection, &config)
+	repo := NewUserRepository(&connection, &config, newTestOutbox(&connection, &config))
 
 	err = repo.Save(context.Background(), user)
 
@@ -68,6 +85,47 @@ func Test_UserRepository_Save_Succeed(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func Test_UserRepository_Save_JoinsContextTransaction(t *testing.T) {
+	userID, userName, userApiKey, userCreatedAt := "37a0f027-15e6-47cc-a5d2-64183281087e", "Test User", "test-api-key", "2023-10-01T00:00:00Z"
+
+	user, err := usersdomain.NewUser(userID, userName, userApiKey, userCreatedAt)
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	connection := storage.Connection{
+		Db: db,
+	}
+	config := storage.Dbconfig{
+		Timeout: 1 * time.Millisecond,
+	}
+	require.NoError(t, err)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(
+		"INSERT INTO users (id, name, api_key, locale, role, status, monthly_extractions, monthly_tokens, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) "+
+			"ON CONFLICT(id) DO UPDATE SET name=excluded.name, api_key=excluded.api_key, locale=excluded.locale, role=excluded.role, status=excluded.status, "+
+			"monthly_extractions=excluded.monthly_extractions, monthly_tokens=excluded.monthly_tokens, created_at=excluded.created_at").
+		WithArgs(userID, userName, userApiKey, "", "user", "active", 0, 0, userCreatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(
+		"INSERT INTO event_outbox (id, type, aggregate_id, payload, occurred_on) VALUES (?, ?, ?, ?, ?)").
+		WithArgs(sqlmock.AnyArg(), "events.user.created", userID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectRollback()
+
+	repo := NewUserRepository(&connection, &config, newTestOutbox(&connection, &config))
+
+	err = storage.NewUnitOfWork(&connection).Do(context.Background(), func(ctx context.Context) error {
+		if err := repo.Save(ctx, user); err != nil {
+			return err
+		}
+		return errors.New("something-failed")
+	})
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet(), "the user must be rolled back with the unit of work")
+	assert.Error(t, err)
+}
+
 func Test_UserRepository_Exists_RepositoryError(t *testing.T) {
 	id := "37a0f027-15e6-47cc-a5d2-64183281087e"
 
@@ -85,7 +143,7 @@ func Test_UserRepository_Exists_RepositoryError(t *testing.T) {
 		WithArgs(id).
 		WillReturnError(errors.New("something-failed"))
 
-	repo := NewUserRepository(&connection, &config)
+	repo := NewUserRepository(&connection, &config, newTestOutbox(&connection, &config))
 
 	userID, err := usersdomain.NewUserID(id)
 	require.NoError(t, err)
@@ -113,7 +171,7 @@ func Test_UserRepository_Exists_Succeed(t *testing.T) {
 		WithArgs(id).
 		WillReturnRows(sqlMock.NewRows([]string{"1"}).AddRow(1))
 
-	repo := NewUserRepository(&connection, &config)
+	repo := NewUserRepository(&connection, &config, newTestOutbox(&connection, &config))
 
 	userID, err := usersdomain.NewUserID(id)
 	require.NoError(t, err)
@@ -137,11 +195,11 @@ func Test_UserRepository_Get_RepositoryError(t *testing.T) {
 	require.NoError(t, err)
 
 	sqlMock.ExpectQuery(
-		"SELECT id, name, api_key, created_at FROM users WHERE id = ?").
+		"SELECT id, name, api_key, locale, role, status, monthly_extractions, monthly_tokens, created_at FROM users WHERE id = ?").
 		WithArgs(id).
 		WillReturnError(errors.New("something-failed"))
 
-	repo := NewUserRepository(&connection, &config)
+	repo := NewUserRepository(&connection, &config, newTestOutbox(&connection, &config))
 
 	userID, err := usersdomain.NewUserID(id)
 	require.NoError(t, err)
@@ -165,11 +223,11 @@ func Test_UserRepository_Get_NotFound(t *testing.T) {
 	require.NoError(t, err)
 
 	sqlMock.ExpectQuery(
-		"SELECT id, name, api_key, created_at FROM users WHERE id = ?").
+		"SELECT id, name, api_key, locale, role, status, monthly_extractions, monthly_tokens, created_at FROM users WHERE id = ?").
 		WithArgs(id).
-		WillReturnRows(sqlMock.NewRows([]string{"id", "name", "api_key", "created_at"}))
+		WillReturnRows(sqlMock.NewRows(userColumns))
 
-	repo := NewUserRepository(&connection, &config)
+	repo := NewUserRepository(&connection, &config, newTestOutbox(&connection, &config))
 
 	userID, err := usersdomain.NewUserID(id)
 	require.NoError(t, err)
@@ -193,11 +251,11 @@ func Test_UserRepository_Get_Succeed(t *testing.T) {
 	require.NoError(t, err)
 
 	sqlMock.ExpectQuery(
-		"SELECT id, name, api_key, created_at FROM users WHERE id = ?").
+		"SELECT id, name, api_key, locale, role, status, monthly_extractions, monthly_tokens, created_at FROM users WHERE id = ?").
 		WithArgs(id).
-		WillReturnRows(sqlMock.NewRows([]string{"id", "name", "api_key", "created_at"}).AddRow(id, "Test User", "test-api-key", "2023-10-01T00:00:00Z"))
+		WillReturnRows(sqlMock.NewRows(userColumns).AddRow(id, "Test User", "test-api-key", "", "user", "active", 0, 0, "2023-10-01T00:00:00Z"))
 
-	repo := NewUserRepository(&connection, &config)
+	repo := NewUserRepository(&connection, &config, newTestOutbox(&connection, &config))
 
 	userID, err := usersdomain.NewUserID(id)
 	require.NoError(t, err)
@@ -221,11 +279,11 @@ func Test_UserRepository_GetByName_Succeed(t *testing.T) {
 	require.NoError(t, err)
 
 	sqlMock.ExpectQuery(
-		"SELECT id, name, api_key, created_at FROM users WHERE name = ?").
+		"SELECT id, name, api_key, locale, role, status, monthly_extractions, monthly_tokens, created_at FROM users WHERE name = ?").
 		WithArgs(userName).
-		WillReturnRows(sqlMock.NewRows([]string{"id", "name", "api_key", "created_at"}).AddRow(userID, userName, userApiKey, userCreatedAt))
+		WillReturnRows(sqlMock.NewRows(userColumns).AddRow(userID, userName, userApiKey, "en-GB", "admin", "disabled", 10, 5000, userCreatedAt))
 
-	repo := NewUserRepository(&connection, &config)
+	repo := NewUserRepository(&connection, &config, newTestOutbox(&connection, &config))
 	userNameVO, err := usersdomain.NewUserName(userName)
 	require.NoError(t, err)
 	result, err := repo.GetByName(context.Background(), userNameVO)
@@ -234,6 +292,11 @@ func Test_UserRepository_GetByName_Succeed(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, userName, result.Name.String())
+	assert.Equal(t, "en-GB", result.Locale.String())
+	assert.True(t, result.IsAdmin())
+	assert.True(t, result.IsDisabled())
+	assert.Equal(t, 10, result.Quota.MonthlyExtractions())
+	assert.Equal(t, 5000, result.Quota.MonthlyTokens())
 }
 
 func Test_UserRepository_GetByApiKey_Succeed(t *testing.T) {
@@ -249,11 +312,11 @@ func Test_UserRepository_GetByApiKey_Succeed(t *testing.T) {
 	require.NoError(t, err)
 
 	sqlMock.ExpectQuery(
-		"SELECT id, name, api_key, created_at FROM users WHERE api_key = ?").
+		"SELECT id, name, api_key, locale, role, status, monthly_extractions, monthly_tokens, created_at FROM users WHERE api_key = ?").
 		WithArgs(userApiKey).
-		WillReturnRows(sqlMock.NewRows([]string{"id", "name", "api_key", "created_at"}).AddRow(userID, userName, userApiKey, userCreatedAt))
+		WillReturnRows(sqlMock.NewRows(userColumns).AddRow(userID, userName, userApiKey, "en-GB", "admin", "disabled", 10, 5000, userCreatedAt))
 
-	repo := NewUserRepository(&connection, &config)
+	repo := NewUserRepository(&connection, &config, newTestOutbox(&connection, &config))
 	apiKeyVO, err := usersdomain.NewUserApiKey(userApiKey)
 	require.NoError(t, err)
 	result, err := repo.GetByApiKey(context.Background(), apiKeyVO)
@@ -282,7 +345,7 @@ func Test_UserRepository_ExistsByName_Succeed(t *testing.T) {
 		WithArgs(userName).
 		WillReturnRows(sqlMock.NewRows([]string{"1"}).AddRow(1))
 
-	repo := NewUserRepository(&connection, &config)
+	repo := NewUserRepository(&connection, &config, newTestOutbox(&connection, &config))
 	userNameVO, err := usersdomain.NewUserName(userName)
 	require.NoError(t, err)
 	exists, err := repo.ExistsByName(context.Background(), userNameVO)
@@ -291,3 +354,135 @@ func Test_UserRepository_ExistsByName_Succeed(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, exists)
 }
+
+func Test_UserRepository_Delete_Succeed(t *testing.T) {
+	id := "37a0f027-15e6-47cc-a5d2-64183281087e"
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	connection := storage.Connection{
+		Db: db,
+	}
+	config := storage.Dbconfig{
+		Timeout: 1 * time.Second,
+	}
+	require.NoError(t, err)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectQuery(soleOwnedOrganization).
+		WithArgs(id, organizationsdomain.MemberRoleOwner).
+		WillReturnRows(sqlmock.NewRows([]string{"organization_id"}))
+	for _, query := range []string{
+		"DELETE FROM webhook_deliveries WHERE webhook_id IN (SELECT id FROM webhooks WHERE user_id = ?)",
+		"DELETE FROM webhooks WHERE user_id = ?",
+		"DELETE FROM subscription_items WHERE subscription_id IN (SELECT id FROM subscriptions WHERE user_id = ?)",
+		"DELETE FROM subscriptions WHERE user_id = ?",
+		"DELETE FROM organization_members WHERE user_id = ?",
+		"DELETE FROM recipe_extractions WHERE user_id = ? AND organization_id = ''",
+		"DELETE FROM users WHERE id = ?",
+	} {
+		sqlMock.ExpectExec(query).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	sqlMock.ExpectExec(
+		"INSERT INTO event_outbox (id, type, aggregate_id, payload, occurred_on) VALUES (?, ?, ?, ?, ?)").
+		WithArgs(sqlmock.AnyArg(), "events.user.deleted", id, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+
+	repo := NewUserRepository(&connection, &config, newTestOutbox(&connection, &config))
+	user := newDeletedUser(t, id)
+
+	err = repo.Delete(context.Background(), user)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func Test_UserRepository_Delete_RollsBackOnError(t *testing.T) {
+	id := "37a0f027-15e6-47cc-a5d2-64183281087e"
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	connection := storage.Connection{
+		Db: db,
+	}
+	config := storage.Dbconfig{
+		Timeout: 1 * time.Second,
+	}
+	require.NoError(t, err)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectQuery(soleOwnedOrganization).
+		WithArgs(id, organizationsdomain.MemberRoleOwner).
+		WillReturnRows(sqlmock.NewRows([]string{"organization_id"}))
+	sqlMock.ExpectExec("DELETE FROM webhook_deliveries WHERE webhook_id IN (SELECT id FROM webhooks WHERE user_id = ?)").
+		WithArgs(id).
+		WillReturnError(errors.New("something-failed"))
+	sqlMock.ExpectRollback()
+
+	repo := NewUserRepository(&connection, &config, newTestOutbox(&connection, &config))
+	user := newDeletedUser(t, id)
+
+	err = repo.Delete(context.Background(), user)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func Test_UserRepository_Delete_LastOwner(t *testing.T) {
+	ctx := context.Background()
+	config := storage.Dbconfig{
+		Database: filepath.Join(t.TempDir(), "app"),
+		Timeout:  5 * time.Second,
+	}
+	connection, err := storage.CreateConnection(fmt.Sprintf("test-%s", t.Name()), &config)
+	require.NoError(t, err)
+	t.Cleanup(func() { connection.Db.Close() })
+	require.NoError(t, storage.CreateSchema(ctx, connection))
+	repo := NewUserRepository(connection, &config, newTestOutbox(connection, &config))
+
+	id, otherID, organizationID := "37a0f027-15e6-47cc-a5d2-64183281087e", "4a0c8d2e-6b1f-4c3a-9e5d-7f2b1a0c9d8e", "5d1c7a9e-3f2b-4e8d-a6c0-9b7e1f3d5a2c"
+	user, err := usersdomain.NewUser(id, "Test User", "test-api-key", "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, user))
+	addMember := func(userID, role string) {
+		_, err := connection.Db.ExecContext(ctx, "INSERT INTO organization_members (organization_id, user_id, role, created_at) VALUES (?, ?, ?, ?)", organizationID, userID, role, "2024-05-01T10:00:00Z")
+		require.NoError(t, err)
+	}
+	addMember(id, organizationsdomain.MemberRoleOwner)
+	addMember(otherID, organizationsdomain.MemberRoleEditor)
+
+	// El único propietario no se puede borrar
+	err = repo.Delete(ctx, newDeletedUser(t, id))
+	assert.ErrorIs(t, err, organizationsdomain.ErrLastOwner)
+	assert.ErrorContains(t, err, organizationID)
+	stored, err := repo.Get(ctx, user.Id)
+	require.NoError(t, err)
+	assert.NotNil(t, stored, "the user is kept")
+
+	// Con otro propietario sí, y deja la organización
+	_, err = connection.Db.ExecContext(ctx, "UPDATE organization_members SET role = ? WHERE user_id = ?", organizationsdomain.MemberRoleOwner, otherID)
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, newDeletedUser(t, id)))
+	var members int
+	require.NoError(t, connection.Db.QueryRowContext(ctx, "SELECT COUNT(*) FROM organization_members WHERE organization_id = ?", organizationID).Scan(&members))
+	assert.Equal(t, 1, members)
+}
+
+// newDeletedUser returns a user whose only recorded event is its deletion.
+func newDeletedUser(t *testing.T, id string) usersdomain.User {
+	t.Helper()
+	user, err := usersdomain.NewUser(id, "Test User", "test-api-key", "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+	user.PullEvents()
+	user.Delete()
+	return user
+}
+
+func newTestOutbox(connection *storage.Connection, config *storage.Dbconfig) *outbox.Store {
+	registry := event.NewRegistry()
+	registry.Register(usersdomain.UserCreatedEventType, usersdomain.DecodeUserCreatedEvent)
+	registry.Register(usersdomain.UserDisabledEventType, usersdomain.DecodeUserDisabledEvent)
+	registry.Register(usersdomain.UserEnabledEventType, usersdomain.DecodeUserEnabledEvent)
+	registry.Register(usersdomain.UserDeletedEventType, usersdomain.DecodeUserDeletedEvent)
+	registry.Register(usersdomain.UserDataExportedEventType, usersdomain.DecodeUserDataExportedEvent)
+	return outbox.NewStore(connection, config, registry)
+}
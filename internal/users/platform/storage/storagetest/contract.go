@@ -0,0 +1,254 @@
+// Package storagetest holds the behaviour every usersdomain.UserRepository
+// implementation must have, so the same tests run against all of them.
+package storagetest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// UserRepositoryContract runs the repository tests against the repositories
+// built by its factories.
+type UserRepositoryContract struct {
+	// New returns an empty repository.
+	New func(t *testing.T) usersdomain.UserRepository
+	// NewFailing returns a repository whose storage fails on every call. It
+	// is optional; implementations that can not fail leave it nil.
+	NewFailing func(t *testing.T) usersdomain.UserRepository
+}
+
+// Run runs the contract tests as subtests of t.
+func (c UserRepositoryContract) Run(t *testing.T) {
+	if c.New != nil {
+		t.Run("it returns nothing for unknown users", c.testUnknownUser)
+		t.Run("it gets a saved user by id, name and api key", c.testSaveAndGet)
+		t.Run("it updates a saved user", c.testUpdate)
+		t.Run("it rejects a user with the name of another one", c.testDuplicatedName)
+		t.Run("it saves users concurrently", c.testConcurrentSaves)
+		t.Run("it lists the users by name", c.testAll)
+		t.Run("it deletes a user", c.testDelete)
+	}
+	if c.NewFailing != nil {
+		t.Run("it returns the errors of the storage", c.testFailingStorage)
+	}
+}
+
+func (c UserRepositoryContract) testUnknownUser(t *testing.T) {
+	ctx := context.Background()
+	repo := c.New(t)
+	user := NewUser(t, "unknown")
+
+	exists, err := repo.Exists(ctx, user.Id)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	existsByName, err := repo.ExistsByName(ctx, user.Name)
+	require.NoError(t, err)
+	assert.False(t, existsByName)
+
+	got, err := repo.Get(ctx, user.Id)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = repo.GetByName(ctx, user.Name)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = repo.GetByApiKey(ctx, user.ApiKey)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func (c UserRepositoryContract) testSaveAndGet(t *testing.T) {
+	ctx := context.Background()
+	repo := c.New(t)
+	user := NewUser(t, "alice")
+	require.NoError(t, user.SetLocale("es-ES"))
+	require.NoError(t, user.SetRole(usersdomain.UserRoleAdmin))
+	require.NoError(t, user.SetStatus(usersdomain.UserStatusDisabled))
+	require.NoError(t, user.SetQuota(100, 50000))
+
+	require.NoError(t, repo.Save(ctx, user))
+
+	exists, err := repo.Exists(ctx, user.Id)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	existsByName, err := repo.ExistsByName(ctx, user.Name)
+	require.NoError(t, err)
+	assert.True(t, existsByName)
+
+	got, err := repo.Get(ctx, user.Id)
+	require.NoError(t, err)
+	AssertSameUser(t, user, got)
+
+	got, err = repo.GetByName(ctx, user.Name)
+	require.NoError(t, err)
+	AssertSameUser(t, user, got)
+
+	got, err = repo.GetByApiKey(ctx, user.ApiKey)
+	require.NoError(t, err)
+	AssertSameUser(t, user, got)
+}
+
+func (c UserRepositoryContract) testUpdate(t *testing.T) {
+	ctx := context.Background()
+	repo := c.New(t)
+	user := NewUser(t, "alice")
+	require.NoError(t, repo.Save(ctx, user))
+
+	updated, err := usersdomain.NewUser(user.Id.String(), user.Name.String(), uuid.New().String(), user.CreatedAt.String())
+	require.NoError(t, err)
+	require.NoError(t, updated.SetLocale("en-GB"))
+	require.NoError(t, repo.Save(ctx, updated))
+
+	got, err := repo.Get(ctx, user.Id)
+	require.NoError(t, err)
+	AssertSameUser(t, updated, got)
+
+	got, err = repo.GetByApiKey(ctx, user.ApiKey)
+	require.NoError(t, err)
+	assert.Nil(t, got, "the previous api key must not find the user")
+}
+
+func (c UserRepositoryContract) testDuplicatedName(t *testing.T) {
+	ctx := context.Background()
+	repo := c.New(t)
+	require.NoError(t, repo.Save(ctx, NewUser(t, "alice")))
+
+	err := repo.Save(ctx, NewUser(t, "alice"))
+
+	assert.Error(t, err)
+}
+
+func (c UserRepositoryContract) testConcurrentSaves(t *testing.T) {
+	ctx := context.Background()
+	repo := c.New(t)
+
+	users := make([]usersdomain.User, 10)
+	for i := range users {
+		users[i] = NewUser(t, fmt.Sprintf("user-%d", i))
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(users))
+	for _, user := range users {
+		wg.Add(1)
+		go func(user usersdomain.User) {
+			defer wg.Done()
+			errs <- repo.Save(ctx, user)
+		}(user)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	for _, user := range users {
+		exists, err := repo.Exists(ctx, user.Id)
+		require.NoError(t, err)
+		assert.True(t, exists, user.Name.String())
+	}
+}
+
+func (c UserRepositoryContract) testAll(t *testing.T) {
+	ctx := context.Background()
+	repo := c.New(t)
+
+	users, err := repo.All(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+
+	bob := NewUser(t, "bob")
+	alice := NewUser(t, "alice")
+	require.NoError(t, repo.Save(ctx, bob))
+	require.NoError(t, repo.Save(ctx, alice))
+
+	users, err = repo.All(ctx)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	AssertSameUser(t, alice, &users[0])
+	AssertSameUser(t, bob, &users[1])
+}
+
+func (c UserRepositoryContract) testDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := c.New(t)
+	alice := NewUser(t, "alice")
+	bob := NewUser(t, "bob")
+	require.NoError(t, repo.Save(ctx, alice))
+	require.NoError(t, repo.Save(ctx, bob))
+
+	stored, err := repo.Get(ctx, alice.Id)
+	require.NoError(t, err)
+	stored.Delete()
+	require.NoError(t, repo.Delete(ctx, *stored))
+
+	exists, err := repo.Exists(ctx, alice.Id)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	got, err := repo.Get(ctx, bob.Id)
+	require.NoError(t, err)
+	AssertSameUser(t, bob, got)
+
+	assert.NoError(t, repo.Delete(ctx, NewUser(t, "carol")), "deleting an unknown user does nothing")
+}
+
+func (c UserRepositoryContract) testFailingStorage(t *testing.T) {
+	ctx := context.Background()
+	repo := c.NewFailing(t)
+	user := NewUser(t, "alice")
+
+	assert.Error(t, repo.Save(ctx, user))
+
+	_, err := repo.Exists(ctx, user.Id)
+	assert.Error(t, err)
+
+	_, err = repo.ExistsByName(ctx, user.Name)
+	assert.Error(t, err)
+
+	_, err = repo.Get(ctx, user.Id)
+	assert.Error(t, err)
+
+	_, err = repo.GetByName(ctx, user.Name)
+	assert.Error(t, err)
+
+	_, err = repo.GetByApiKey(ctx, user.ApiKey)
+	assert.Error(t, err)
+
+	_, err = repo.All(ctx)
+	assert.Error(t, err)
+
+	assert.Error(t, repo.Delete(ctx, user))
+}
+
+// NewUser returns a valid user with the given name and random ID and API key.
+func NewUser(t *testing.T, name string) usersdomain.User {
+	t.Helper()
+	user, err := usersdomain.NewUser(uuid.New().String(), name, uuid.New().String(), "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+	return user
+}
+
+// AssertSameUser checks that got holds the same data as want.
+func AssertSameUser(t *testing.T, want usersdomain.User, got *usersdomain.User) {
+	t.Helper()
+	require.NotNil(t, got)
+	assert.Equal(t, want.Id.String(), got.Id.String())
+	assert.Equal(t, want.Name.String(), got.Name.String())
+	assert.Equal(t, want.ApiKey.String(), got.ApiKey.String())
+	assert.Equal(t, want.Locale.String(), got.Locale.String())
+	assert.Equal(t, want.Role.String(), got.Role.String())
+	assert.Equal(t, want.Status.String(), got.Status.String())
+	assert.Equal(t, want.Quota, got.Quota)
+	assert.Equal(t, want.CreatedAt.String(), got.CreatedAt.String())
+}
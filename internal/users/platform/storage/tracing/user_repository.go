@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+
+	sharedtracing "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+)
+
+// UserRepository decorates a usersdomain.UserRepository with a span for
+// every call.
+type UserRepository struct {
+	next usersdomain.UserRepository
+}
+
+// NewUserRepository returns a UserRepository that traces the calls to next.
+func NewUserRepository(next usersdomain.UserRepository) *UserRepository {
+	return &UserRepository{
+		next: next,
+	}
+}
+
+// Save implements the usersdomain.UserRepository interface.
+func (r *UserRepository) Save(ctx context.Context, user usersdomain.User) error {
+	return sharedtracing.Run(ctx, "users.repository.Save", func(ctx context.Context) error {
+		return r.next.Save(ctx, user)
+	})
+}
+
+// Exists implements the usersdomain.UserRepository interface.
+func (r *UserRepository) Exists(ctx context.Context, id usersdomain.UserID) (bool, error) {
+	return sharedtracing.Call(ctx, "users.repository.Exists", func(ctx context.Context) (bool, error) {
+		return r.next.Exists(ctx, id)
+	})
+}
+
+// Get implements the usersdomain.UserRepository interface.
+func (r *UserRepository) Get(ctx context.Context, id usersdomain.UserID) (*usersdomain.User, error) {
+	return sharedtracing.Call(ctx, "users.repository.Get", func(ctx context.Context) (*usersdomain.User, error) {
+		return r.next.Get(ctx, id)
+	})
+}
+
+// GetByName implements the usersdomain.UserRepository interface.
+func (r *UserRepository) GetByName(ctx context.Context, name usersdomain.UserName) (*usersdomain.User, error) {
+	return sharedtracing.Call(ctx, "users.repository.GetByName", func(ctx context.Context) (*usersdomain.User, error) {
+		return r.next.GetByName(ctx, name)
+	})
+}
+
+// GetByApiKey implements the usersdomain.UserRepository interface. The API
+// key is not added to the span.
+func (r *UserRepository) GetByApiKey(ctx context.Context, apiKey usersdomain.UserApiKey) (*usersdomain.User, error) {
+	return sharedtracing.Call(ctx, "users.repository.GetByApiKey", func(ctx context.Context) (*usersdomain.User, error) {
+		return r.next.GetByApiKey(ctx, apiKey)
+	})
+}
+
+// ExistsByName implements the usersdomain.UserRepository interface.
+func (r *UserRepository) ExistsByName(ctx context.Context, name usersdomain.UserName) (bool, error) {
+	return sharedtracing.Call(ctx, "users.repository.ExistsByName", func(ctx context.Context) (bool, error) {
+		return r.next.ExistsByName(ctx, name)
+	})
+}
+
+// All implements the usersdomain.UserRepository interface.
+func (r *UserRepository) All(ctx context.Context) ([]usersdomain.User, error) {
+	return sharedtracing.Call(ctx, "users.repository.All", func(ctx context.Context) ([]usersdomain.User, error) {
+		return r.next.All(ctx)
+	})
+}
+
+// Delete implements the usersdomain.UserRepository interface.
+func (r *UserRepository) Delete(ctx context.Context, user usersdomain.User) error {
+	return sharedtracing.Run(ctx, "users.repository.Delete", func(ctx context.Context) error {
+		return r.next.Delete(ctx, user)
+	})
+}
@@ -0,0 +1,43 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing/tracingtest"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/inmemory"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/storagemocks"
+	"github.com/rubenbupe/recipe-video-parser/internal/users/platform/storage/storagetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func Test_UserRepository_Contract(t *testing.T) {
+	storagetest.UserRepositoryContract{
+		New: func(t *testing.T) usersdomain.UserRepository {
+			return NewUserRepository(inmemory.NewUserRepository(nil))
+		},
+	}.Run(t)
+}
+
+func Test_UserRepository_TracesCalls(t *testing.T) {
+	exporter := tracingtest.Record(t)
+	user := storagetest.NewUser(t, "alice")
+
+	next := new(storagemocks.UserRepository)
+	next.On("Save", mock.Anything, user).Return(nil)
+	next.On("GetByName", mock.Anything, user.Name).Return(nil, errors.New("something unexpected happened"))
+	repo := NewUserRepository(next)
+
+	require.NoError(t, repo.Save(context.Background(), user))
+	_, err := repo.GetByName(context.Background(), user.Name)
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"users.repository.Save", "users.repository.GetByName"}, tracingtest.Names(exporter))
+	assert.Equal(t, codes.Error, tracingtest.Span(t, exporter, "users.repository.GetByName").Status.Code)
+	next.AssertExpectations(t)
+}
@@ -0,0 +1,66 @@
+package create
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const WebhookCommandType command.Type = "command.webhook.create"
+
+type WebhookCommand struct {
+	id         string
+	userId     string
+	url        string
+	secret     string
+	eventTypes []string
+	createdAt  string
+}
+
+func NewWebhookCommand(id, userId, url, secret string, eventTypes []string, createdAt string) WebhookCommand {
+	return WebhookCommand{
+		id:         id,
+		userId:     userId,
+		url:        url,
+		secret:     secret,
+		eventTypes: eventTypes,
+		createdAt:  createdAt,
+	}
+}
+
+func (c WebhookCommand) Type() command.Type {
+	return WebhookCommandType
+}
+
+type WebhookCommandHandler struct {
+	service WebhookService
+}
+
+func NewWebhookCommandHandler(service WebhookService) WebhookCommandHandler {
+	return WebhookCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h WebhookCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	createWebhookCmd, ok := cmd.(WebhookCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.CreateWebhook(
+		ctx,
+		createWebhookCmd.id,
+		createWebhookCmd.userId,
+		createWebhookCmd.url,
+		createWebhookCmd.secret,
+		createWebhookCmd.eventTypes,
+		createWebhookCmd.createdAt,
+	)
+}
+
+func (h WebhookCommandHandler) SubscribedTo() command.Type {
+	return WebhookCommandType
+}
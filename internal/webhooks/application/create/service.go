@@ -0,0 +1,35 @@
+package create
+
+import (
+	"context"
+
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+)
+
+type WebhookService struct {
+	webhookRepository webhooksdomain.WebhookRepository
+}
+
+func NewWebhookService(webhookRepository webhooksdomain.WebhookRepository) WebhookService {
+	return WebhookService{
+		webhookRepository: webhookRepository,
+	}
+}
+
+func (s WebhookService) CreateWebhook(ctx context.Context, id, userId, url, secret string, eventTypes []string, createdAt string) error {
+	webhook, err := webhooksdomain.NewWebhook(id, userId, url, secret, eventTypes, createdAt)
+	if err != nil {
+		return err
+	}
+
+	exists, err := s.webhookRepository.Exists(ctx, webhook.Id)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return webhooksdomain.ErrWebhookAlreadyExists
+	}
+
+	return s.webhookRepository.Save(ctx, webhook)
+}
@@ -0,0 +1,94 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	webhookID     = "6f0e3c1a-63a5-4a55-8f54-0b8d1f3f2b10"
+	userID        = "37a0f027-15e6-47cc-a5d2-64183281087e"
+	webhookUrl    = "https://example.com/hooks/recipes"
+	webhookSecret = "0123456789abcdef0123"
+	createdAt     = "2023-10-01T00:00:00Z"
+)
+
+func Test_WebhookService_CreateWebhook_Succeed(t *testing.T) {
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("Exists", mock.Anything, mock.AnythingOfType("domain.WebhookID")).Return(false, nil)
+	webhookRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(webhook webhooksdomain.Webhook) bool {
+		return webhook.SubscribedTo(webhooksdomain.WebhookEventExtractionSucceeded) &&
+			!webhook.SubscribedTo(webhooksdomain.WebhookEventExtractionFailed)
+	})).Return(nil)
+
+	webhookService := NewWebhookService(webhookRepositoryMock)
+
+	err := webhookService.CreateWebhook(context.Background(), webhookID, userID, webhookUrl, webhookSecret, []string{webhooksdomain.WebhookEventExtractionSucceeded}, createdAt)
+
+	webhookRepositoryMock.AssertExpectations(t)
+	assert.NoError(t, err)
+}
+
+func Test_WebhookService_CreateWebhook_InvalidEventType(t *testing.T) {
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+
+	webhookService := NewWebhookService(webhookRepositoryMock)
+
+	err := webhookService.CreateWebhook(context.Background(), webhookID, userID, webhookUrl, webhookSecret, []string{"extraction.deleted"}, createdAt)
+
+	webhookRepositoryMock.AssertExpectations(t)
+	assert.ErrorIs(t, err, webhooksdomain.ErrInvalidWebhookEventType)
+}
+
+func Test_WebhookService_CreateWebhook_InvalidUrl(t *testing.T) {
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+
+	webhookService := NewWebhookService(webhookRepositoryMock)
+
+	err := webhookService.CreateWebhook(context.Background(), webhookID, userID, "ftp://example.com", webhookSecret, []string{webhooksdomain.WebhookEventExtractionSucceeded}, createdAt)
+
+	webhookRepositoryMock.AssertExpectations(t)
+	assert.ErrorIs(t, err, webhooksdomain.ErrInvalidWebhookUrl)
+}
+
+func Test_WebhookService_CreateWebhook_ShortSecret(t *testing.T) {
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+
+	webhookService := NewWebhookService(webhookRepositoryMock)
+
+	err := webhookService.CreateWebhook(context.Background(), webhookID, userID, webhookUrl, "secret", []string{webhooksdomain.WebhookEventExtractionSucceeded}, createdAt)
+
+	webhookRepositoryMock.AssertExpectations(t)
+	assert.ErrorIs(t, err, webhooksdomain.ErrInvalidWebhookSecret)
+}
+
+func Test_WebhookService_CreateWebhook_AlreadyExists(t *testing.T) {
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("Exists", mock.Anything, mock.AnythingOfType("domain.WebhookID")).Return(true, nil)
+
+	webhookService := NewWebhookService(webhookRepositoryMock)
+
+	err := webhookService.CreateWebhook(context.Background(), webhookID, userID, webhookUrl, webhookSecret, []string{webhooksdomain.WebhookEventExtractionSucceeded}, createdAt)
+
+	webhookRepositoryMock.AssertExpectations(t)
+	assert.Equal(t, webhooksdomain.ErrWebhookAlreadyExists, err)
+}
+
+func Test_WebhookService_CreateWebhook_RepositoryError(t *testing.T) {
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("Exists", mock.Anything, mock.AnythingOfType("domain.WebhookID")).Return(false, nil)
+	webhookRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Webhook")).Return(errors.New("something unexpected happened"))
+
+	webhookService := NewWebhookService(webhookRepositoryMock)
+
+	err := webhookService.CreateWebhook(context.Background(), webhookID, userID, webhookUrl, webhookSecret, []string{webhooksdomain.WebhookEventExtractionSucceeded}, createdAt)
+
+	webhookRepositoryMock.AssertExpectations(t)
+	assert.Error(t, err)
+}
@@ -0,0 +1,35 @@
+package deliver
+
+import (
+	"context"
+	"errors"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/event"
+)
+
+// ExtractionCreatedEventHandler delivers the extractions to the webhooks of
+// their user.
+type ExtractionCreatedEventHandler struct {
+	service DeliveryService
+}
+
+func NewExtractionCreatedEventHandler(service DeliveryService) ExtractionCreatedEventHandler {
+	return ExtractionCreatedEventHandler{
+		service: service,
+	}
+}
+
+// Handle implements the event.Handler interface.
+func (h ExtractionCreatedEventHandler) Handle(ctx context.Context, evt event.Event) error {
+	extractionCreatedEvt, ok := evt.(recipesdomain.ExtractionCreatedEvent)
+	if !ok {
+		return errors.New("unexpected event")
+	}
+
+	return h.service.DeliverExtraction(ctx, extractionCreatedEvt)
+}
+
+func (h ExtractionCreatedEventHandler) SubscribedTo() event.Type {
+	return recipesdomain.ExtractionCreatedEventType
+}
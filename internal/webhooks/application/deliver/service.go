@@ -0,0 +1,133 @@
+package deliver
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+)
+
+type DeliveryService struct {
+	webhookRepository  webhooksdomain.WebhookRepository
+	deliveryRepository webhooksdomain.DeliveryRepository
+	sender             webhooksdomain.Sender
+	policy             webhooksdomain.RetryPolicy
+}
+
+func NewDeliveryService(webhookRepository webhooksdomain.WebhookRepository, deliveryRepository webhooksdomain.DeliveryRepository, sender webhooksdomain.Sender, policy webhooksdomain.RetryPolicy) DeliveryService {
+	return DeliveryService{
+		webhookRepository:  webhookRepository,
+		deliveryRepository: deliveryRepository,
+		sender:             sender,
+		policy:             policy,
+	}
+}
+
+type extractionPayload struct {
+	ID         string         `json:"id"`
+	Type       string         `json:"type"`
+	OccurredOn time.Time      `json:"occurredOn"`
+	Data       extractionData `json:"data"`
+}
+
+type extractionData struct {
+	ExtractionID  string          `json:"extractionId"`
+	SourceUrl     string          `json:"sourceUrl"`
+	Status        string          `json:"status"`
+	ErrorCategory string          `json:"errorCategory,omitempty"`
+	Recipe        json.RawMessage `json:"recipe,omitempty"`
+	CreatedAt     string          `json:"createdAt"`
+}
+
+// DeliverExtraction sends the extraction to every webhook of its user
+// subscribed to its status. Each webhook gets one delivery per event, so
+// an event published twice is not sent twice. Every delivery is attempted
+// once; failed attempts are stored with their error and the time of the next
+// attempt, which is left to the retry poller, and do not fail the handler.
+func (s DeliveryService) DeliverExtraction(ctx context.Context, evt recipesdomain.ExtractionCreatedEvent) error {
+	userId, err := webhooksdomain.NewWebhookUserID(evt.ExtractionUserID())
+	if err != nil {
+		return err
+	}
+
+	webhooks, err := s.webhookRepository.GetByUserID(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	eventType := "extraction." + evt.ExtractionStatus()
+	var payload []byte
+	for _, webhook := range webhooks {
+		if !webhook.SubscribedTo(eventType) {
+			continue
+		}
+
+		delivered, err := s.deliveryRepository.ExistsForEvent(ctx, webhook.Id, evt.ID())
+		if err != nil {
+			return err
+		}
+		if delivered {
+			continue
+		}
+
+		if payload == nil {
+			payload, err = json.Marshal(newExtractionPayload(evt, eventType))
+			if err != nil {
+				return err
+			}
+		}
+
+		now := time.Now().UTC()
+		delivery, err := webhooksdomain.NewDelivery(uuid.New().String(), webhook.Id.String(), evt.ID(), eventType, string(payload), now.Format(time.RFC3339))
+		if err != nil {
+			return err
+		}
+		// Se guarda antes de enviarla para que el poller la reenvíe si el
+		// proceso se detiene durante el envío
+		delivery.Lease(s.policy, now)
+		if err := s.deliveryRepository.Save(ctx, delivery); err != nil {
+			return err
+		}
+
+		if err := webhooksdomain.Attempt(ctx, s.sender, s.policy, webhook, &delivery); err != nil {
+			slog.WarnContext(ctx, "webhook delivery failed",
+				slog.String("webhook_id", webhook.Id.String()),
+				slog.String("delivery_id", delivery.Id.String()),
+				slog.String("event_type", eventType),
+				slog.String("next_attempt_at", delivery.NextAttemptAt),
+				slog.String("error", err.Error()),
+			)
+		}
+
+		if err := s.deliveryRepository.Save(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newExtractionPayload(evt recipesdomain.ExtractionCreatedEvent, eventType string) extractionPayload {
+	var recipe json.RawMessage
+	if evt.ExtractionData() != "" {
+		recipe = json.RawMessage(evt.ExtractionData())
+	}
+
+	return extractionPayload{
+		ID:         evt.ID(),
+		Type:       eventType,
+		OccurredOn: evt.OccurredOn().UTC(),
+		Data: extractionData{
+			ExtractionID:  evt.ExtractionID(),
+			SourceUrl:     evt.ExtractionSourceUrl(),
+			Status:        evt.ExtractionStatus(),
+			ErrorCategory: evt.ExtractionErrorCategory(),
+			Recipe:        recipe,
+			CreatedAt:     evt.ExtractionCreatedAt(),
+		},
+	}
+}
@@ -0,0 +1,160 @@
+package deliver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	recipesdomain "github.com/rubenbupe/recipe-video-parser/internal/recipes/domain"
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/sender/sendermocks"
+	"github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/storage/storagemocks"
+	"github.com/rubenbupe/recipe-video-parser/kit/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const userID = "37a0f027-15e6-47cc-a5d2-64183281087e"
+
+var policy = webhooksdomain.RetryPolicy{MaxAttempts: 3, Backoff: time.Minute, Timeout: time.Second}
+
+func newWebhook(t *testing.T, id string, eventTypes ...string) webhooksdomain.Webhook {
+	t.Helper()
+	webhook, err := webhooksdomain.NewWebhook(id, userID, "https://example.com/hooks", "0123456789abcdef0123", eventTypes, "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+	return webhook
+}
+
+func newEvent() recipesdomain.ExtractionCreatedEvent {
+	return recipesdomain.NewExtractionCreatedEvent("5f1c1a3e-8f1b-4a57-9a43-6f2d1f0c7d11", userID, "https://example.com/video", recipesdomain.ExtractionStatusSucceeded, "", "{\"name\":\"Tortilla\"}", "{}", "2023-10-01T00:00:00Z")
+}
+
+func Test_DeliveryService_DeliverExtraction_Succeed(t *testing.T) {
+	subscribed := newWebhook(t, "6f0e3c1a-63a5-4a55-8f54-0b8d1f3f2b10", webhooksdomain.WebhookEventExtractionSucceeded)
+	notSubscribed := newWebhook(t, "0b5e8d2c-2b4f-4f0e-9a3e-1c2d3e4f5a6b", webhooksdomain.WebhookEventExtractionFailed)
+	evt := newEvent()
+
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("GetByUserID", mock.Anything, subscribed.UserId).Return([]webhooksdomain.Webhook{subscribed, notSubscribed}, nil)
+
+	var saved []webhooksdomain.Delivery
+	deliveryRepositoryMock := new(storagemocks.DeliveryRepository)
+	deliveryRepositoryMock.On("ExistsForEvent", mock.Anything, subscribed.Id, evt.ID()).Return(false, nil)
+	deliveryRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Delivery")).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(1).(webhooksdomain.Delivery))
+	}).Return(nil)
+
+	senderMock := new(sendermocks.Sender)
+	senderMock.On("Send", mock.Anything, subscribed, mock.AnythingOfType("domain.Delivery")).Return(200, nil).Once()
+
+	service := NewDeliveryService(webhookRepositoryMock, deliveryRepositoryMock, senderMock, policy)
+
+	err := service.DeliverExtraction(context.Background(), evt)
+
+	require.NoError(t, err)
+	webhookRepositoryMock.AssertExpectations(t)
+	deliveryRepositoryMock.AssertExpectations(t)
+	senderMock.AssertExpectations(t)
+
+	require.Len(t, saved, 2)
+	assert.Equal(t, webhooksdomain.DeliveryStatusPending, saved[0].Status)
+	assert.NotEmpty(t, saved[0].NextAttemptAt, "the delivery is leased while it is sent")
+	assert.Equal(t, webhooksdomain.DeliveryStatusSucceeded, saved[1].Status)
+	assert.Empty(t, saved[1].NextAttemptAt)
+	assert.Equal(t, 1, saved[1].Attempts)
+	assert.Equal(t, 200, saved[1].ResponseStatus)
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(saved[1].Payload), &payload))
+	assert.Equal(t, evt.ID(), payload["id"])
+	assert.Equal(t, webhooksdomain.WebhookEventExtractionSucceeded, payload["type"])
+	data := payload["data"].(map[string]interface{})
+	assert.Equal(t, evt.ExtractionID(), data["extractionId"])
+	assert.Equal(t, map[string]interface{}{"name": "Tortilla"}, data["recipe"])
+}
+
+func Test_DeliveryService_DeliverExtraction_SchedulesRetry(t *testing.T) {
+	webhook := newWebhook(t, "6f0e3c1a-63a5-4a55-8f54-0b8d1f3f2b10", webhooksdomain.WebhookEventExtractionSucceeded)
+	evt := newEvent()
+
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("GetByUserID", mock.Anything, webhook.UserId).Return([]webhooksdomain.Webhook{webhook}, nil)
+
+	var last webhooksdomain.Delivery
+	deliveryRepositoryMock := new(storagemocks.DeliveryRepository)
+	deliveryRepositoryMock.On("ExistsForEvent", mock.Anything, webhook.Id, evt.ID()).Return(false, nil)
+	deliveryRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Delivery")).Run(func(args mock.Arguments) {
+		last = args.Get(1).(webhooksdomain.Delivery)
+	}).Return(nil)
+
+	senderMock := new(sendermocks.Sender)
+	senderMock.On("Send", mock.Anything, webhook, mock.AnythingOfType("domain.Delivery")).Return(503, errors.New("unexpected status 503"))
+
+	service := NewDeliveryService(webhookRepositoryMock, deliveryRepositoryMock, senderMock, policy)
+
+	before := time.Now()
+	err := service.DeliverExtraction(context.Background(), evt)
+
+	assert.NoError(t, err, "failed deliveries are recorded, not returned")
+	senderMock.AssertNumberOfCalls(t, "Send", 1)
+	assert.Equal(t, webhooksdomain.DeliveryStatusPending, last.Status)
+	assert.Equal(t, 1, last.Attempts)
+	assert.Equal(t, 503, last.ResponseStatus)
+	assert.Equal(t, "unexpected status 503", last.LastError)
+	nextAttemptAt, err := time.Parse(time.RFC3339, last.NextAttemptAt)
+	require.NoError(t, err)
+	assert.WithinDuration(t, before.Add(policy.Backoff), nextAttemptAt, 2*time.Second)
+}
+
+func Test_DeliveryService_DeliverExtraction_AlreadyDelivered(t *testing.T) {
+	webhook := newWebhook(t, "6f0e3c1a-63a5-4a55-8f54-0b8d1f3f2b10", webhooksdomain.WebhookEventExtractionSucceeded)
+	evt := newEvent()
+
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("GetByUserID", mock.Anything, webhook.UserId).Return([]webhooksdomain.Webhook{webhook}, nil)
+
+	deliveryRepositoryMock := new(storagemocks.DeliveryRepository)
+	deliveryRepositoryMock.On("ExistsForEvent", mock.Anything, webhook.Id, evt.ID()).Return(true, nil)
+
+	senderMock := new(sendermocks.Sender)
+
+	service := NewDeliveryService(webhookRepositoryMock, deliveryRepositoryMock, senderMock, policy)
+
+	err := service.DeliverExtraction(context.Background(), evt)
+
+	assert.NoError(t, err)
+	deliveryRepositoryMock.AssertExpectations(t)
+	senderMock.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_DeliveryService_DeliverExtraction_RepositoryError(t *testing.T) {
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("GetByUserID", mock.Anything, mock.AnythingOfType("domain.WebhookUserID")).Return(nil, errors.New("something unexpected happened"))
+
+	service := NewDeliveryService(webhookRepositoryMock, new(storagemocks.DeliveryRepository), new(sendermocks.Sender), policy)
+
+	err := service.DeliverExtraction(context.Background(), newEvent())
+
+	webhookRepositoryMock.AssertExpectations(t)
+	assert.Error(t, err)
+}
+
+func Test_ExtractionCreatedEventHandler_UnexpectedEvent(t *testing.T) {
+	handler := NewExtractionCreatedEventHandler(NewDeliveryService(nil, nil, nil, policy))
+
+	err := handler.Handle(context.Background(), otherEvent{event.NewBaseEvent("aggregate")})
+
+	assert.Error(t, err)
+	assert.Equal(t, recipesdomain.ExtractionCreatedEventType, handler.SubscribedTo())
+}
+
+type otherEvent struct {
+	event.BaseEvent
+}
+
+func (e otherEvent) Type() event.Type {
+	return "events.other"
+}
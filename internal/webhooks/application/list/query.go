@@ -0,0 +1,48 @@
+package list
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+const WebhooksQueryType query.Type = "query.webhook.list"
+
+type WebhooksQuery struct {
+	userId string
+}
+
+func NewWebhooksQuery(userId string) WebhooksQuery {
+	return WebhooksQuery{
+		userId: userId,
+	}
+}
+
+func (c WebhooksQuery) Type() query.Type {
+	return WebhooksQueryType
+}
+
+type WebhooksQueryHandler struct {
+	service WebhooksService
+}
+
+func NewWebhooksQueryHandler(service WebhooksService) WebhooksQueryHandler {
+	return WebhooksQueryHandler{
+		service: service,
+	}
+}
+
+// Handle implements the query.Handler interface.
+func (h WebhooksQueryHandler) Handle(ctx context.Context, qry query.Query) (interface{}, error) {
+	webhooksQuery, ok := qry.(WebhooksQuery)
+	if !ok {
+		return nil, errors.New("unexpected query")
+	}
+
+	return h.service.GetWebhooks(ctx, webhooksQuery.userId)
+}
+
+func (h WebhooksQueryHandler) SubscribedTo() query.Type {
+	return WebhooksQueryType
+}
@@ -0,0 +1,26 @@
+package list
+
+import (
+	"context"
+
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+)
+
+type WebhooksService struct {
+	webhookRepository webhooksdomain.WebhookRepository
+}
+
+func NewWebhooksService(webhookRepository webhooksdomain.WebhookRepository) WebhooksService {
+	return WebhooksService{
+		webhookRepository: webhookRepository,
+	}
+}
+
+func (s WebhooksService) GetWebhooks(ctx context.Context, userId string) ([]webhooksdomain.Webhook, error) {
+	userIdVO, err := webhooksdomain.NewWebhookUserID(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.webhookRepository.GetByUserID(ctx, userIdVO)
+}
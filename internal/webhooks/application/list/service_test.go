@@ -0,0 +1,53 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WebhooksService_GetWebhooks_Succeed(t *testing.T) {
+	userID := "37a0f027-15e6-47cc-a5d2-64183281087e"
+	webhook, err := webhooksdomain.NewWebhook("6f0e3c1a-63a5-4a55-8f54-0b8d1f3f2b10", userID, "https://example.com/hooks", "0123456789abcdef0123", []string{webhooksdomain.WebhookEventExtractionSucceeded}, "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("GetByUserID", mock.Anything, mock.AnythingOfType("domain.WebhookUserID")).Return([]webhooksdomain.Webhook{webhook}, nil)
+
+	webhooksService := NewWebhooksService(webhookRepositoryMock)
+
+	webhooks, err := webhooksService.GetWebhooks(context.Background(), userID)
+
+	webhookRepositoryMock.AssertExpectations(t)
+	assert.NoError(t, err)
+	assert.Equal(t, []webhooksdomain.Webhook{webhook}, webhooks)
+}
+
+func Test_WebhooksService_GetWebhooks_InvalidUserID(t *testing.T) {
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+
+	webhooksService := NewWebhooksService(webhookRepositoryMock)
+
+	_, err := webhooksService.GetWebhooks(context.Background(), "not-a-uuid")
+
+	webhookRepositoryMock.AssertExpectations(t)
+	assert.ErrorIs(t, err, webhooksdomain.ErrInvalidWebhookUserID)
+}
+
+func Test_WebhooksService_GetWebhooks_RepositoryError(t *testing.T) {
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("GetByUserID", mock.Anything, mock.AnythingOfType("domain.WebhookUserID")).Return(nil, errors.New("something unexpected happened"))
+
+	webhooksService := NewWebhooksService(webhookRepositoryMock)
+
+	_, err := webhooksService.GetWebhooks(context.Background(), "37a0f027-15e6-47cc-a5d2-64183281087e")
+
+	webhookRepositoryMock.AssertExpectations(t)
+	assert.Error(t, err)
+}
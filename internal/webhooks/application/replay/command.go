@@ -0,0 +1,52 @@
+package replay
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const DeliveryCommandType command.Type = "command.webhook.replaydelivery"
+
+type DeliveryCommand struct {
+	id string
+}
+
+func NewDeliveryCommand(id string) DeliveryCommand {
+	return DeliveryCommand{
+		id: id,
+	}
+}
+
+func (c DeliveryCommand) Type() command.Type {
+	return DeliveryCommandType
+}
+
+// NonTransactional keeps the command out of the bus transaction: the delivery
+// waits on the webhook and its retries, and is saved after every attempt.
+func (c DeliveryCommand) NonTransactional() {}
+
+type DeliveryCommandHandler struct {
+	service DeliveryService
+}
+
+func NewDeliveryCommandHandler(service DeliveryService) DeliveryCommandHandler {
+	return DeliveryCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h DeliveryCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	replayCmd, ok := cmd.(DeliveryCommand)
+	if !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.ReplayDelivery(ctx, replayCmd.id)
+}
+
+func (h DeliveryCommandHandler) SubscribedTo() command.Type {
+	return DeliveryCommandType
+}
@@ -0,0 +1,57 @@
+package replay
+
+import (
+	"context"
+
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+)
+
+type DeliveryService struct {
+	webhookRepository  webhooksdomain.WebhookRepository
+	deliveryRepository webhooksdomain.DeliveryRepository
+	sender             webhooksdomain.Sender
+	policy             webhooksdomain.RetryPolicy
+}
+
+func NewDeliveryService(webhookRepository webhooksdomain.WebhookRepository, deliveryRepository webhooksdomain.DeliveryRepository, sender webhooksdomain.Sender, policy webhooksdomain.RetryPolicy) DeliveryService {
+	return DeliveryService{
+		webhookRepository:  webhookRepository,
+		deliveryRepository: deliveryRepository,
+		sender:             sender,
+		policy:             policy,
+	}
+}
+
+// ReplayDelivery sends the stored payload of a delivery again, whatever its
+// status, and records the new attempts. It returns ErrDeliveryFailed if
+// every attempt failed.
+func (s DeliveryService) ReplayDelivery(ctx context.Context, id string) error {
+	deliveryId, err := webhooksdomain.NewDeliveryID(id)
+	if err != nil {
+		return err
+	}
+
+	delivery, err := s.deliveryRepository.Get(ctx, deliveryId)
+	if err != nil {
+		return err
+	}
+	if delivery == nil {
+		return webhooksdomain.ErrDeliveryNotFound
+	}
+
+	webhook, err := s.webhookRepository.Get(ctx, delivery.WebhookId)
+	if err != nil {
+		return err
+	}
+	if webhook == nil {
+		return webhooksdomain.ErrWebhookNotFound
+	}
+
+	deliverErr := webhooksdomain.Deliver(ctx, s.sender, s.policy, *webhook, delivery)
+
+	if err := s.deliveryRepository.Save(ctx, *delivery); err != nil {
+		return err
+	}
+
+	return deliverErr
+}
@@ -0,0 +1,98 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/sender/sendermocks"
+	"github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const deliveryID = "9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a"
+
+var policy = webhooksdomain.RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond}
+
+func newFailedDelivery(t *testing.T) (webhooksdomain.Webhook, webhooksdomain.Delivery) {
+	t.Helper()
+	webhook, err := webhooksdomain.NewWebhook("6f0e3c1a-63a5-4a55-8f54-0b8d1f3f2b10", "37a0f027-15e6-47cc-a5d2-64183281087e", "https://example.com/hooks", "0123456789abcdef0123", []string{webhooksdomain.WebhookEventExtractionFailed}, "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+	delivery, err := webhooksdomain.NewDelivery(deliveryID, webhook.Id.String(), "event-id", webhooksdomain.WebhookEventExtractionFailed, "{}", "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+	delivery.RecordAttempt(500, errors.New("unexpected status 500"), time.Now())
+	return webhook, delivery
+}
+
+func Test_DeliveryService_ReplayDelivery_Succeed(t *testing.T) {
+	webhook, delivery := newFailedDelivery(t)
+
+	deliveryRepositoryMock := new(storagemocks.DeliveryRepository)
+	deliveryRepositoryMock.On("Get", mock.Anything, delivery.Id).Return(&delivery, nil)
+	deliveryRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(saved webhooksdomain.Delivery) bool {
+		return saved.Status == webhooksdomain.DeliveryStatusSucceeded && saved.Attempts == 2 && saved.LastError == ""
+	})).Return(nil)
+
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("Get", mock.Anything, webhook.Id).Return(&webhook, nil)
+
+	senderMock := new(sendermocks.Sender)
+	senderMock.On("Send", mock.Anything, webhook, mock.AnythingOfType("domain.Delivery")).Return(204, nil)
+
+	service := NewDeliveryService(webhookRepositoryMock, deliveryRepositoryMock, senderMock, policy)
+
+	err := service.ReplayDelivery(context.Background(), deliveryID)
+
+	assert.NoError(t, err)
+	deliveryRepositoryMock.AssertExpectations(t)
+	webhookRepositoryMock.AssertExpectations(t)
+	senderMock.AssertExpectations(t)
+}
+
+func Test_DeliveryService_ReplayDelivery_Failed(t *testing.T) {
+	webhook, delivery := newFailedDelivery(t)
+
+	deliveryRepositoryMock := new(storagemocks.DeliveryRepository)
+	deliveryRepositoryMock.On("Get", mock.Anything, delivery.Id).Return(&delivery, nil)
+	deliveryRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(saved webhooksdomain.Delivery) bool {
+		return saved.Status == webhooksdomain.DeliveryStatusFailed && saved.Attempts == 3
+	})).Return(nil)
+
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("Get", mock.Anything, webhook.Id).Return(&webhook, nil)
+
+	senderMock := new(sendermocks.Sender)
+	senderMock.On("Send", mock.Anything, webhook, mock.AnythingOfType("domain.Delivery")).Return(0, errors.New("connection refused"))
+
+	service := NewDeliveryService(webhookRepositoryMock, deliveryRepositoryMock, senderMock, policy)
+
+	err := service.ReplayDelivery(context.Background(), deliveryID)
+
+	assert.ErrorIs(t, err, webhooksdomain.ErrDeliveryFailed)
+	deliveryRepositoryMock.AssertExpectations(t)
+	senderMock.AssertNumberOfCalls(t, "Send", policy.MaxAttempts)
+}
+
+func Test_DeliveryService_ReplayDelivery_NotFound(t *testing.T) {
+	deliveryRepositoryMock := new(storagemocks.DeliveryRepository)
+	deliveryRepositoryMock.On("Get", mock.Anything, mock.AnythingOfType("domain.DeliveryID")).Return(nil, nil)
+
+	service := NewDeliveryService(new(storagemocks.WebhookRepository), deliveryRepositoryMock, new(sendermocks.Sender), policy)
+
+	err := service.ReplayDelivery(context.Background(), deliveryID)
+
+	assert.Equal(t, webhooksdomain.ErrDeliveryNotFound, err)
+	deliveryRepositoryMock.AssertExpectations(t)
+}
+
+func Test_DeliveryService_ReplayDelivery_InvalidID(t *testing.T) {
+	service := NewDeliveryService(new(storagemocks.WebhookRepository), new(storagemocks.DeliveryRepository), new(sendermocks.Sender), policy)
+
+	err := service.ReplayDelivery(context.Background(), "not-a-uuid")
+
+	assert.ErrorIs(t, err, webhooksdomain.ErrInvalidDeliveryID)
+}
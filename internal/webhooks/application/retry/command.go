@@ -0,0 +1,49 @@
+package retry
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+const DeliveriesCommandType command.Type = "command.webhook.retrydeliveries"
+
+type DeliveriesCommand struct{}
+
+// NewDeliveriesCommand attempts the pending deliveries whose next attempt is
+// due.
+func NewDeliveriesCommand() DeliveriesCommand {
+	return DeliveriesCommand{}
+}
+
+func (c DeliveriesCommand) Type() command.Type {
+	return DeliveriesCommandType
+}
+
+// NonTransactional keeps the command out of the bus transaction: every
+// delivery waits on its webhook and is saved after its attempt.
+func (c DeliveriesCommand) NonTransactional() {}
+
+type DeliveriesCommandHandler struct {
+	service DeliveriesService
+}
+
+func NewDeliveriesCommandHandler(service DeliveriesService) DeliveriesCommandHandler {
+	return DeliveriesCommandHandler{
+		service: service,
+	}
+}
+
+// Handle implements the command.Handler interface.
+func (h DeliveriesCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	if _, ok := cmd.(DeliveriesCommand); !ok {
+		return errors.New("unexpected command")
+	}
+
+	return h.service.RetryDueDeliveries(ctx)
+}
+
+func (h DeliveriesCommandHandler) SubscribedTo() command.Type {
+	return DeliveriesCommandType
+}
@@ -0,0 +1,78 @@
+package retry
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+)
+
+type DeliveriesService struct {
+	webhookRepository  webhooksdomain.WebhookRepository
+	deliveryRepository webhooksdomain.DeliveryRepository
+	sender             webhooksdomain.Sender
+	policy             webhooksdomain.RetryPolicy
+	batchSize          int
+}
+
+func NewDeliveriesService(webhookRepository webhooksdomain.WebhookRepository, deliveryRepository webhooksdomain.DeliveryRepository, sender webhooksdomain.Sender, policy webhooksdomain.RetryPolicy, batchSize int) DeliveriesService {
+	return DeliveriesService{
+		webhookRepository:  webhookRepository,
+		deliveryRepository: deliveryRepository,
+		sender:             sender,
+		policy:             policy,
+		batchSize:          batchSize,
+	}
+}
+
+// RetryDueDeliveries attempts once every pending delivery whose next attempt
+// is due, at most batch size of them. Failed attempts are scheduled again
+// while the retry policy has attempts left. Deliveries of a deleted webhook
+// are recorded as failed.
+func (s DeliveriesService) RetryDueDeliveries(ctx context.Context) error {
+	deliveries, err := s.deliveryRepository.Due(ctx, time.Now(), s.batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		webhook, err := s.webhookRepository.Get(ctx, delivery.WebhookId)
+		if err != nil {
+			return err
+		}
+		if webhook == nil {
+			delivery.RecordAttempt(0, webhooksdomain.ErrWebhookNotFound, time.Now())
+			if err := s.deliveryRepository.Save(ctx, delivery); err != nil {
+				return err
+			}
+			continue
+		}
+
+		delivery.Lease(s.policy, time.Now())
+		if err := s.deliveryRepository.Save(ctx, delivery); err != nil {
+			return err
+		}
+
+		if err := webhooksdomain.Attempt(ctx, s.sender, s.policy, *webhook, &delivery); err != nil {
+			slog.WarnContext(ctx, "webhook delivery failed",
+				slog.String("webhook_id", webhook.Id.String()),
+				slog.String("delivery_id", delivery.Id.String()),
+				slog.String("event_type", delivery.EventType.String()),
+				slog.Int("attempts", delivery.Attempts),
+				slog.String("next_attempt_at", delivery.NextAttemptAt),
+				slog.String("error", err.Error()),
+			)
+		}
+
+		if err := s.deliveryRepository.Save(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/sender/sendermocks"
+	"github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/storage/storagemocks"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var policy = webhooksdomain.RetryPolicy{MaxAttempts: 2, Backoff: time.Minute, Timeout: time.Second}
+
+func newDueDelivery(t *testing.T) (webhooksdomain.Webhook, webhooksdomain.Delivery) {
+	t.Helper()
+	webhook, err := webhooksdomain.NewWebhook("6f0e3c1a-63a5-4a55-8f54-0b8d1f3f2b10", "37a0f027-15e6-47cc-a5d2-64183281087e", "https://example.com/hooks", "0123456789abcdef0123", []string{webhooksdomain.WebhookEventExtractionFailed}, "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+	delivery, err := webhooksdomain.NewDelivery("9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a", webhook.Id.String(), "event-id", webhooksdomain.WebhookEventExtractionFailed, "{}", "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+	delivery.RecordAttempt(503, errors.New("unexpected status 503"), time.Now())
+	require.True(t, delivery.ScheduleRetry(policy, time.Now()))
+	return webhook, delivery
+}
+
+func Test_DeliveriesService_RetryDueDeliveries_Succeed(t *testing.T) {
+	webhook, delivery := newDueDelivery(t)
+
+	var saved []webhooksdomain.Delivery
+	deliveryRepositoryMock := new(storagemocks.DeliveryRepository)
+	deliveryRepositoryMock.On("Due", mock.Anything, mock.AnythingOfType("time.Time"), 10).Return([]webhooksdomain.Delivery{delivery}, nil)
+	deliveryRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Delivery")).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(1).(webhooksdomain.Delivery))
+	}).Return(nil)
+
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("Get", mock.Anything, webhook.Id).Return(&webhook, nil)
+
+	senderMock := new(sendermocks.Sender)
+	senderMock.On("Send", mock.Anything, webhook, mock.AnythingOfType("domain.Delivery")).Return(204, nil).Once()
+
+	service := NewDeliveriesService(webhookRepositoryMock, deliveryRepositoryMock, senderMock, policy, 10)
+
+	err := service.RetryDueDeliveries(context.Background())
+
+	require.NoError(t, err)
+	senderMock.AssertExpectations(t)
+	require.Len(t, saved, 2)
+	assert.Equal(t, webhooksdomain.DeliveryStatusPending, saved[0].Status, "the delivery is leased before it is sent")
+	assert.Equal(t, webhooksdomain.DeliveryStatusSucceeded, saved[1].Status)
+	assert.Equal(t, 2, saved[1].Attempts)
+	assert.Empty(t, saved[1].NextAttemptAt)
+}
+
+func Test_DeliveriesService_RetryDueDeliveries_LastAttemptFails(t *testing.T) {
+	webhook, delivery := newDueDelivery(t)
+
+	var last webhooksdomain.Delivery
+	deliveryRepositoryMock := new(storagemocks.DeliveryRepository)
+	deliveryRepositoryMock.On("Due", mock.Anything, mock.AnythingOfType("time.Time"), 10).Return([]webhooksdomain.Delivery{delivery}, nil)
+	deliveryRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Delivery")).Run(func(args mock.Arguments) {
+		last = args.Get(1).(webhooksdomain.Delivery)
+	}).Return(nil)
+
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("Get", mock.Anything, webhook.Id).Return(&webhook, nil)
+
+	senderMock := new(sendermocks.Sender)
+	senderMock.On("Send", mock.Anything, webhook, mock.AnythingOfType("domain.Delivery")).Return(500, errors.New("unexpected status 500"))
+
+	service := NewDeliveriesService(webhookRepositoryMock, deliveryRepositoryMock, senderMock, policy, 10)
+
+	err := service.RetryDueDeliveries(context.Background())
+
+	assert.NoError(t, err, "failed deliveries are recorded, not returned")
+	assert.Equal(t, webhooksdomain.DeliveryStatusFailed, last.Status)
+	assert.Equal(t, policy.MaxAttempts, last.Attempts)
+	assert.Equal(t, "unexpected status 500", last.LastError)
+	assert.Empty(t, last.NextAttemptAt, "no attempts are left")
+}
+
+func Test_DeliveriesService_RetryDueDeliveries_WebhookDeleted(t *testing.T) {
+	webhook, delivery := newDueDelivery(t)
+
+	deliveryRepositoryMock := new(storagemocks.DeliveryRepository)
+	deliveryRepositoryMock.On("Due", mock.Anything, mock.AnythingOfType("time.Time"), 10).Return([]webhooksdomain.Delivery{delivery}, nil)
+	deliveryRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(saved webhooksdomain.Delivery) bool {
+		return saved.Status == webhooksdomain.DeliveryStatusFailed && saved.LastError == webhooksdomain.ErrWebhookNotFound.Error()
+	})).Return(nil).Once()
+
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("Get", mock.Anything, webhook.Id).Return(nil, nil)
+
+	senderMock := new(sendermocks.Sender)
+
+	service := NewDeliveriesService(webhookRepositoryMock, deliveryRepositoryMock, senderMock, policy, 10)
+
+	err := service.RetryDueDeliveries(context.Background())
+
+	assert.NoError(t, err)
+	deliveryRepositoryMock.AssertExpectations(t)
+	senderMock.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_DeliveriesService_RetryDueDeliveries_RepositoryError(t *testing.T) {
+	deliveryRepositoryMock := new(storagemocks.DeliveryRepository)
+	deliveryRepositoryMock.On("Due", mock.Anything, mock.AnythingOfType("time.Time"), 10).Return(nil, errors.New("something unexpected happened"))
+
+	service := NewDeliveriesService(new(storagemocks.WebhookRepository), deliveryRepositoryMock, new(sendermocks.Sender), policy, 10)
+
+	err := service.RetryDueDeliveries(context.Background())
+
+	assert.Error(t, err)
+}
+
+func Test_DeliveriesCommandHandler_UnexpectedCommand(t *testing.T) {
+	handler := NewDeliveriesCommandHandler(NewDeliveriesService(nil, nil, nil, policy, 10))
+
+	err := handler.Handle(context.Background(), otherCommand{})
+
+	assert.Error(t, err)
+	assert.Equal(t, DeliveriesCommandType, handler.SubscribedTo())
+}
+
+type otherCommand struct{}
+
+func (c otherCommand) Type() command.Type {
+	return "command.other"
+}
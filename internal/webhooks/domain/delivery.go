@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidDeliveryID = errors.New("invalid Delivery ID")
+var ErrDeliveryNotFound = errors.New("delivery not found")
+var ErrDeliveryFailed = errors.New("delivery failed")
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusFailed    = "failed"
+)
+
+type DeliveryID struct {
+	value string
+}
+
+func NewDeliveryID(value string) (DeliveryID, error) {
+	v, err := uuid.Parse(value)
+	if err != nil {
+		return DeliveryID{}, fmt.Errorf("%w: %s", ErrInvalidDeliveryID, value)
+	}
+
+	return DeliveryID{
+		value: v.String(),
+	}, nil
+}
+
+func (id DeliveryID) String() string {
+	return id.value
+}
+
+// Delivery is the record of sending one event to one webhook. It keeps the
+// payload so the delivery can be replayed. A pending delivery is attempted
+// again once NextAttemptAt has passed.
+type Delivery struct {
+	Id             DeliveryID
+	WebhookId      WebhookID
+	EventId        string
+	EventType      WebhookEventType
+	Payload        string
+	Status         string
+	Attempts       int
+	ResponseStatus int
+	LastError      string
+	CreatedAt      string
+	DeliveredAt    string
+	NextAttemptAt  string
+}
+
+type DeliveryRepository interface {
+	Save(ctx context.Context, delivery Delivery) error
+	Get(ctx context.Context, id DeliveryID) (*Delivery, error)
+	ExistsForEvent(ctx context.Context, webhookId WebhookID, eventId string) (bool, error)
+	// Due returns the oldest pending deliveries whose next attempt is at or
+	// before the given time.
+	Due(ctx context.Context, at time.Time, limit int) ([]Delivery, error)
+}
+
+//mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=DeliveryRepository
+
+func NewDelivery(id, webhookId, eventId, eventType, payload, createdAt string) (Delivery, error) {
+	idVO, err := NewDeliveryID(id)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	webhookIdVO, err := NewWebhookID(webhookId)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	if eventId == "" {
+		return Delivery{}, errors.New("the field Delivery Event ID can not be empty")
+	}
+
+	eventTypeVO, err := NewWebhookEventType(eventType)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	if payload == "" {
+		return Delivery{}, errors.New("the field Delivery Payload can not be empty")
+	}
+
+	return Delivery{
+		Id:        idVO,
+		WebhookId: webhookIdVO,
+		EventId:   eventId,
+		EventType: eventTypeVO,
+		Payload:   payload,
+		Status:    DeliveryStatusPending,
+		CreatedAt: createdAt,
+	}, nil
+}
+
+// RecordAttempt updates the delivery with the result of sending it once.
+func (d *Delivery) RecordAttempt(responseStatus int, err error, at time.Time) {
+	d.Attempts++
+	d.ResponseStatus = responseStatus
+	d.NextAttemptAt = ""
+	if err != nil {
+		d.Status = DeliveryStatusFailed
+		d.LastError = err.Error()
+		return
+	}
+
+	d.Status = DeliveryStatusSucceeded
+	d.LastError = ""
+	d.DeliveredAt = at.UTC().Format(time.RFC3339)
+}
+
+// Lease reserves the delivery for an attempt starting at the given time. Until
+// the attempt timeout of the policy has passed it is not due, so it is not
+// sent twice; if the process stops before recording the attempt, it is sent
+// again once the lease expires.
+func (d *Delivery) Lease(policy RetryPolicy, at time.Time) {
+	d.Status = DeliveryStatusPending
+	d.NextAttemptAt = at.Add(policy.Timeout).UTC().Format(time.RFC3339)
+}
+
+// ScheduleRetry sets a failed delivery back to pending while the policy has
+// attempts left, to be attempted again after the backoff, which doubles on
+// every retry. It reports whether a retry was scheduled.
+func (d *Delivery) ScheduleRetry(policy RetryPolicy, at time.Time) bool {
+	if d.Status != DeliveryStatusFailed || d.Attempts >= policy.MaxAttempts {
+		return false
+	}
+
+	backoff := policy.Backoff << (d.Attempts - 1)
+	d.Status = DeliveryStatusPending
+	d.NextAttemptAt = at.Add(backoff).UTC().Format(time.RFC3339)
+	return true
+}
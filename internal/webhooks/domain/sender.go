@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// SignatureHeader is the header with the HMAC-SHA256 signature of the body,
+// computed with the webhook secret.
+const SignatureHeader = "X-Webhook-Signature"
+
+// Sender sends the payload of a delivery to the webhook endpoint. It returns
+// the response status, and an error if the endpoint could not be reached or
+// did not answer with a 2xx status.
+type Sender interface {
+	Send(ctx context.Context, webhook Webhook, delivery Delivery) (int, error)
+}
+
+//mockery --case=snake --outpkg=sendermocks --output=platform/sender/sendermocks --name=Sender
+
+// RetryPolicy configures how many times a delivery is attempted, the wait
+// before the first retry, which doubles on every retry, and how long an
+// attempt can take.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	Timeout     time.Duration
+}
+
+// Sign returns the value of the signature header for the body.
+func Sign(secret WebhookSecret, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret.String()))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Attempt sends the delivery once and records the attempt. A failed attempt
+// is scheduled to be retried while the policy has attempts left. It returns
+// ErrDeliveryFailed wrapping the error of the attempt.
+func Attempt(ctx context.Context, sender Sender, policy RetryPolicy, webhook Webhook, delivery *Delivery) error {
+	status, err := sender.Send(ctx, webhook, *delivery)
+	now := time.Now()
+	delivery.RecordAttempt(status, err, now)
+	if err != nil {
+		delivery.ScheduleRetry(policy, now)
+		return fmt.Errorf("%w: %v", ErrDeliveryFailed, err)
+	}
+	return nil
+}
+
+// Deliver sends the delivery until it succeeds or the policy runs out of
+// attempts, waiting for every retry and recording every attempt. It returns ErrDeliveryFailed wrapping
+// the last error when every attempt failed.
+func Deliver(ctx context.Context, sender Sender, policy RetryPolicy, webhook Webhook, delivery *Delivery) error {
+	backoff := policy.Backoff
+	for attempt := 1; ; attempt++ {
+		status, err := sender.Send(ctx, webhook, *delivery)
+		delivery.RecordAttempt(status, err, time.Now())
+		if err == nil {
+			return nil
+		}
+		if attempt >= policy.MaxAttempts {
+			return fmt.Errorf("%w: %v", ErrDeliveryFailed, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %v", ErrDeliveryFailed, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
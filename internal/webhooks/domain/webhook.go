@@ -0,0 +1,236 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidWebhookID = errors.New("invalid Webhook ID")
+var ErrInvalidWebhookUserID = errors.New("invalid Webhook User ID")
+var ErrInvalidWebhookUrl = errors.New("invalid Webhook URL")
+var ErrInvalidWebhookSecret = errors.New("invalid Webhook Secret")
+var ErrInvalidWebhookEventType = errors.New("invalid Webhook Event Type")
+var ErrWebhookAlreadyExists = errors.New("webhook already exists")
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// MinWebhookSecretLength is the minimum length of the secret used to sign the
+// payloads.
+const MinWebhookSecretLength = 16
+
+type WebhookID struct {
+	value string
+}
+
+func NewWebhookID(value string) (WebhookID, error) {
+	v, err := uuid.Parse(value)
+	if err != nil {
+		return WebhookID{}, fmt.Errorf("%w: %s", ErrInvalidWebhookID, value)
+	}
+
+	return WebhookID{
+		value: v.String(),
+	}, nil
+}
+
+func (id WebhookID) String() string {
+	return id.value
+}
+
+type WebhookUserID struct {
+	value string
+}
+
+func NewWebhookUserID(value string) (WebhookUserID, error) {
+	v, err := uuid.Parse(value)
+	if err != nil {
+		return WebhookUserID{}, fmt.Errorf("%w: %s", ErrInvalidWebhookUserID, value)
+	}
+
+	return WebhookUserID{
+		value: v.String(),
+	}, nil
+}
+
+func (id WebhookUserID) String() string {
+	return id.value
+}
+
+// WebhookUrl is the absolute http or https endpoint that receives the
+// payloads.
+type WebhookUrl struct {
+	value string
+}
+
+func NewWebhookUrl(value string) (WebhookUrl, error) {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return WebhookUrl{}, fmt.Errorf("%w: %s", ErrInvalidWebhookUrl, value)
+	}
+
+	return WebhookUrl{
+		value: u.String(),
+	}, nil
+}
+
+func (u WebhookUrl) String() string {
+	return u.value
+}
+
+type WebhookSecret struct {
+	value string
+}
+
+func NewWebhookSecret(value string) (WebhookSecret, error) {
+	if len(value) < MinWebhookSecretLength {
+		return WebhookSecret{}, fmt.Errorf("%w: it must have at least %d characters", ErrInvalidWebhookSecret, MinWebhookSecretLength)
+	}
+
+	return WebhookSecret{
+		value: value,
+	}, nil
+}
+
+func (secret WebhookSecret) String() string {
+	return secret.value
+}
+
+// Event types that can be subscribed to. They are the public names of the
+// extraction lifecycle, one per extraction status.
+const (
+	WebhookEventExtractionSucceeded  = "extraction.succeeded"
+	WebhookEventExtractionNotARecipe = "extraction.not_a_recipe"
+	WebhookEventExtractionFailed     = "extraction.failed"
+)
+
+// SupportedWebhookEventTypes returns the event types that can be subscribed
+// to.
+func SupportedWebhookEventTypes() []string {
+	return []string{WebhookEventExtractionSucceeded, WebhookEventExtractionNotARecipe, WebhookEventExtractionFailed}
+}
+
+type WebhookEventType struct {
+	value string
+}
+
+func NewWebhookEventType(value string) (WebhookEventType, error) {
+	for _, supported := range SupportedWebhookEventTypes() {
+		if value == supported {
+			return WebhookEventType{value: value}, nil
+		}
+	}
+
+	return WebhookEventType{}, fmt.Errorf("%w: %s", ErrInvalidWebhookEventType, value)
+}
+
+func (eventType WebhookEventType) String() string {
+	return eventType.value
+}
+
+type WebhookCreatedAt struct {
+	value string
+}
+
+func NewWebhookCreatedAt(value string) (WebhookCreatedAt, error) {
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		return WebhookCreatedAt{}, errors.New("the field Webhook Created At must be a valid RFC3339 date")
+	}
+
+	return WebhookCreatedAt{
+		value: value,
+	}, nil
+}
+
+func (createdAt WebhookCreatedAt) String() string {
+	return createdAt.value
+}
+
+type Webhook struct {
+	Id         WebhookID
+	UserId     WebhookUserID
+	Url        WebhookUrl
+	Secret     WebhookSecret
+	EventTypes []WebhookEventType
+	CreatedAt  WebhookCreatedAt
+}
+
+type WebhookRepository interface {
+	Save(ctx context.Context, webhook Webhook) error
+	Exists(ctx context.Context, id WebhookID) (bool, error)
+	Get(ctx context.Context, id WebhookID) (*Webhook, error)
+	GetByUserID(ctx context.Context, userId WebhookUserID) ([]Webhook, error)
+}
+
+//mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=WebhookRepository
+
+func NewWebhook(id, userId, webhookUrl, secret string, eventTypes []string, createdAt string) (Webhook, error) {
+	idVO, err := NewWebhookID(id)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	userIdVO, err := NewWebhookUserID(userId)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	urlVO, err := NewWebhookUrl(webhookUrl)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	secretVO, err := NewWebhookSecret(secret)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	if len(eventTypes) == 0 {
+		return Webhook{}, fmt.Errorf("%w: at least one event type is required", ErrInvalidWebhookEventType)
+	}
+	eventTypesVO := make([]WebhookEventType, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		eventTypeVO, err := NewWebhookEventType(strings.TrimSpace(eventType))
+		if err != nil {
+			return Webhook{}, err
+		}
+		eventTypesVO = append(eventTypesVO, eventTypeVO)
+	}
+
+	createdAtVO, err := NewWebhookCreatedAt(createdAt)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	return Webhook{
+		Id:         idVO,
+		UserId:     userIdVO,
+		Url:        urlVO,
+		Secret:     secretVO,
+		EventTypes: eventTypesVO,
+		CreatedAt:  createdAtVO,
+	}, nil
+}
+
+// SubscribedTo reports whether the webhook receives the given event type.
+func (w Webhook) SubscribedTo(eventType string) bool {
+	for _, subscribed := range w.EventTypes {
+		if subscribed.String() == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// EventTypeNames returns the subscribed event types as strings.
+func (w Webhook) EventTypeNames() []string {
+	names := make([]string, 0, len(w.EventTypes))
+	for _, eventType := range w.EventTypes {
+		names = append(names, eventType.String())
+	}
+	return names
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/webhooks/application/create"
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+type CreateWebhookInput struct {
+	ID         string
+	UserID     string
+	Url        string
+	Secret     string
+	EventTypes []string
+	CreatedAt  string
+}
+
+type CreateWebhookHandler func(context.Context, CreateWebhookInput) error
+
+func CreateCreateWebhookHandler(commandBus command.Bus) CreateWebhookHandler {
+	return func(ctx context.Context, input CreateWebhookInput) error {
+		if input.ID == "" || input.UserID == "" || input.Url == "" || input.Secret == "" || len(input.EventTypes) == 0 || input.CreatedAt == "" {
+			return fmt.Errorf("todos los campos son obligatorios")
+		}
+
+		err := commandBus.Dispatch(ctx, create.NewWebhookCommand(
+			input.ID,
+			input.UserID,
+			input.Url,
+			input.Secret,
+			input.EventTypes,
+			input.CreatedAt,
+		))
+
+		if err != nil {
+			switch {
+			case errors.Is(err, webhooksdomain.ErrInvalidWebhookID),
+				errors.Is(err, webhooksdomain.ErrInvalidWebhookUserID),
+				errors.Is(err, webhooksdomain.ErrInvalidWebhookUrl),
+				errors.Is(err, webhooksdomain.ErrInvalidWebhookSecret),
+				errors.Is(err, webhooksdomain.ErrInvalidWebhookEventType),
+				err == webhooksdomain.ErrWebhookAlreadyExists:
+				return fmt.Errorf("error de dominio: %w", err)
+			default:
+				return fmt.Errorf("error interno: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// GenerateSecret devuelve un secreto aleatorio para firmar los webhooks.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newCreateWebhookInput() CreateWebhookInput {
+	return CreateWebhookInput{
+		ID:         "6f0e3c1a-63a5-4a55-8f54-0b8d1f3f2b10",
+		UserID:     "37a0f027-15e6-47cc-a5d2-64183281087e",
+		Url:        "https://example.com/hooks",
+		Secret:     "0123456789abcdef0123",
+		EventTypes: []string{webhooksdomain.WebhookEventExtractionSucceeded},
+		CreatedAt:  "2023-01-01T00:00:00Z",
+	}
+}
+
+func TestCreateWebhookHandler_Success(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("create.WebhookCommand")).Return(nil)
+	handler := CreateCreateWebhookHandler(bus)
+	err := handler(context.Background(), newCreateWebhookInput())
+	assert.NoError(t, err)
+	bus.AssertExpectations(t)
+}
+
+func TestCreateWebhookHandler_ErrorDominio(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("create.WebhookCommand")).Return(webhooksdomain.ErrInvalidWebhookEventType)
+	handler := CreateCreateWebhookHandler(bus)
+	err := handler(context.Background(), newCreateWebhookInput())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error de dominio")
+}
+
+func TestCreateWebhookHandler_ErrorCamposObligatorios(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	handler := CreateCreateWebhookHandler(bus)
+	input := newCreateWebhookInput()
+	input.EventTypes = nil
+	err := handler(context.Background(), input)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "obligatorios")
+}
+
+func TestCreateWebhookHandler_ErrorInterno(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("create.WebhookCommand")).Return(errors.New("fail"))
+	handler := CreateCreateWebhookHandler(bus)
+	err := handler(context.Background(), newCreateWebhookInput())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error interno")
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	other, err := GenerateSecret()
+	require.NoError(t, err)
+
+	assert.GreaterOrEqual(t, len(secret), webhooksdomain.MinWebhookSecretLength)
+	assert.NotEqual(t, secret, other)
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/webhooks/application/list"
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+type GetWebhooksInput struct {
+	UserID string
+}
+
+type GetWebhookOutput struct {
+	ID         string   `json:"id"`
+	Url        string   `json:"url"`
+	EventTypes []string `json:"events"`
+	CreatedAt  string   `json:"createdAt"`
+}
+
+type GetWebhooksHandler func(context.Context, GetWebhooksInput) ([]GetWebhookOutput, error)
+
+func CreateGetWebhooksHandler(queryBus query.Bus) GetWebhooksHandler {
+	return func(ctx context.Context, input GetWebhooksInput) ([]GetWebhookOutput, error) {
+		if input.UserID == "" {
+			return nil, fmt.Errorf("el campo UserID es obligatorio")
+		}
+
+		result, err := queryBus.Ask(ctx, list.NewWebhooksQuery(input.UserID))
+		if err != nil {
+			return nil, fmt.Errorf("error al buscar webhooks: %w", err)
+		}
+
+		webhooks, ok := result.([]webhooksdomain.Webhook)
+		if !ok {
+			return nil, fmt.Errorf("respuesta inesperada del query")
+		}
+
+		output := make([]GetWebhookOutput, 0, len(webhooks))
+		for _, webhook := range webhooks {
+			output = append(output, GetWebhookOutput{
+				ID:         webhook.Id.String(),
+				Url:        webhook.Url.String(),
+				EventTypes: webhook.EventTypeNames(),
+				CreatedAt:  webhook.CreatedAt.String(),
+			})
+		}
+		return output, nil
+	}
+}
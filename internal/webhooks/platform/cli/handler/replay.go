@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/webhooks/application/replay"
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+type ReplayDeliveryInput struct {
+	ID string
+}
+
+type ReplayDeliveryHandler func(context.Context, ReplayDeliveryInput) error
+
+func CreateReplayDeliveryHandler(commandBus command.Bus) ReplayDeliveryHandler {
+	return func(ctx context.Context, input ReplayDeliveryInput) error {
+		if input.ID == "" {
+			return fmt.Errorf("el campo ID es obligatorio")
+		}
+
+		err := commandBus.Dispatch(ctx, replay.NewDeliveryCommand(input.ID))
+
+		if err != nil {
+			switch {
+			case errors.Is(err, webhooksdomain.ErrDeliveryFailed):
+				return fmt.Errorf("la entrega ha vuelto a fallar: %w", err)
+			case errors.Is(err, webhooksdomain.ErrInvalidDeliveryID),
+				err == webhooksdomain.ErrDeliveryNotFound,
+				err == webhooksdomain.ErrWebhookNotFound:
+				return fmt.Errorf("error de dominio: %w", err)
+			default:
+				return fmt.Errorf("error interno: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
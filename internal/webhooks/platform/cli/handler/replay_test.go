@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReplayDeliveryHandler_Success(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("replay.DeliveryCommand")).Return(nil)
+	handler := CreateReplayDeliveryHandler(bus)
+	err := handler(context.Background(), ReplayDeliveryInput{ID: "9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a"})
+	assert.NoError(t, err)
+	bus.AssertExpectations(t)
+}
+
+func TestReplayDeliveryHandler_ErrorEntregaFallida(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("replay.DeliveryCommand")).Return(fmt.Errorf("%w: unexpected status 500", webhooksdomain.ErrDeliveryFailed))
+	handler := CreateReplayDeliveryHandler(bus)
+	err := handler(context.Background(), ReplayDeliveryInput{ID: "9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a"})
+	assert.ErrorIs(t, err, webhooksdomain.ErrDeliveryFailed)
+	assert.Contains(t, err.Error(), "ha vuelto a fallar")
+}
+
+func TestReplayDeliveryHandler_ErrorDominio(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("replay.DeliveryCommand")).Return(webhooksdomain.ErrDeliveryNotFound)
+	handler := CreateReplayDeliveryHandler(bus)
+	err := handler(context.Background(), ReplayDeliveryInput{ID: "9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error de dominio")
+}
+
+func TestReplayDeliveryHandler_ErrorInterno(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("replay.DeliveryCommand")).Return(errors.New("fail"))
+	handler := CreateReplayDeliveryHandler(bus)
+	err := handler(context.Background(), ReplayDeliveryInput{ID: "9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error interno")
+}
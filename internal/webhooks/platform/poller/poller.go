@@ -0,0 +1,42 @@
+package poller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/webhooks/application/retry"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+)
+
+// Poller retries the webhook deliveries whose next attempt is due.
+type Poller struct {
+	bus      command.Bus
+	interval time.Duration
+}
+
+func NewPoller(bus command.Bus, interval time.Duration) *Poller {
+	return &Poller{
+		bus:      bus,
+		interval: interval,
+	}
+}
+
+// Run retries the due deliveries every interval until the context is
+// cancelled.
+func (p *Poller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.bus.Dispatch(ctx, retry.NewDeliveriesCommand()); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "webhook deliveries retry failed", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
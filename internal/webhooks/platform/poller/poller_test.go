@@ -0,0 +1,31 @@
+package poller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/webhooks/application/retry"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPoller_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, retry.NewDeliveriesCommand()).Return(nil).Once()
+	bus.On("Dispatch", mock.Anything, retry.NewDeliveriesCommand()).Run(func(mock.Arguments) { cancel() }).Return(nil).Once()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		NewPoller(bus, time.Millisecond).Run(ctx)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("poller did not stop")
+	}
+	bus.AssertExpectations(t)
+}
@@ -0,0 +1,58 @@
+package sender
+
+import (
+	"errors"
+	"time"
+
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+)
+
+type Config struct {
+	// Timeout is the maximum duration of every request to a webhook.
+	Timeout time.Duration `default:"10s"`
+	// MaxAttempts is the number of times a delivery is sent before it is
+	// recorded as failed.
+	MaxAttempts int `default:"5"`
+	// Backoff is the wait before the first retry. It doubles on every retry.
+	Backoff time.Duration `default:"1s"`
+	// PollInterval is the wait between two searches of the deliveries whose
+	// retry is due.
+	PollInterval time.Duration `default:"1s"`
+	// BatchSize is the maximum number of deliveries retried on every search.
+	BatchSize int `default:"100"`
+	// AllowPrivateNetworks lets the webhooks reach loopback, link-local and
+	// private addresses, e.g. a receiver running next to the API in
+	// development. They are refused by default so a webhook can not be used
+	// to reach internal services.
+	AllowPrivateNetworks bool `default:"false"`
+}
+
+// RetryPolicy returns the retry policy of the deliveries.
+func (c Config) RetryPolicy() webhooksdomain.RetryPolicy {
+	return webhooksdomain.RetryPolicy{
+		MaxAttempts: c.MaxAttempts,
+		Backoff:     c.Backoff,
+		Timeout:     c.Timeout,
+	}
+}
+
+// Validate checks the values of the config.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Timeout <= 0 {
+		errs = append(errs, errors.New("timeout must be positive"))
+	}
+	if c.MaxAttempts < 1 {
+		errs = append(errs, errors.New("at least one attempt is required"))
+	}
+	if c.Backoff < 0 {
+		errs = append(errs, errors.New("backoff must not be negative"))
+	}
+	if c.PollInterval <= 0 {
+		errs = append(errs, errors.New("poll interval must be positive"))
+	}
+	if c.BatchSize < 1 {
+		errs = append(errs, errors.New("batch size must be positive"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,102 @@
+package sender
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+)
+
+const (
+	EventHeader    = "X-Webhook-Event"
+	DeliveryHeader = "X-Webhook-Delivery"
+)
+
+var ErrForbiddenAddress = errors.New("webhook address is not allowed")
+
+// HTTPSender posts the payloads as JSON, signed with the webhook secret.
+//
+// Unless the config allows private networks, the address of every connection
+// is checked once the host is resolved, so neither the webhook URL, a DNS
+// record nor a redirect can point the request to a loopback, link-local or
+// private address.
+type HTTPSender struct {
+	client *http.Client
+}
+
+func NewHTTPSender(config Config) *HTTPSender {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !config.AllowPrivateNetworks {
+		dialer := &net.Dialer{Timeout: config.Timeout, Control: checkAddress}
+		transport.DialContext = dialer.DialContext
+		// Con un proxy se comprobaría la dirección del proxy, no la del webhook
+		transport.Proxy = nil
+	}
+
+	return &HTTPSender{
+		client: &http.Client{Timeout: config.Timeout, Transport: transport},
+	}
+}
+
+// Send implements the domain.Sender interface.
+func (s *HTTPSender) Send(ctx context.Context, webhook webhooksdomain.Webhook, delivery webhooksdomain.Delivery) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url.String(), bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "recipe-video-parser-webhooks")
+	req.Header.Set(EventHeader, delivery.EventType.String())
+	req.Header.Set(DeliveryHeader, delivery.Id.String())
+	req.Header.Set(webhooksdomain.SignatureHeader, webhooksdomain.Sign(webhook.Secret, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Se lee la respuesta para reutilizar la conexión
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// checkAddress refuses to connect to an address that is not public. It runs
+// after the host is resolved, for every connection.
+func checkAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !publicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	return nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which is not
+// public either.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+func publicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!sharedAddressSpace.Contains(ip)
+}
@@ -0,0 +1,154 @@
+package sender
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "0123456789abcdef0123"
+
+func newDelivery(t *testing.T, receiverUrl string) (webhooksdomain.Webhook, webhooksdomain.Delivery) {
+	t.Helper()
+	webhook, err := webhooksdomain.NewWebhook("6f0e3c1a-63a5-4a55-8f54-0b8d1f3f2b10", "37a0f027-15e6-47cc-a5d2-64183281087e", receiverUrl, secret, []string{webhooksdomain.WebhookEventExtractionSucceeded}, "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+	delivery, err := webhooksdomain.NewDelivery("9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a", webhook.Id.String(), "event-id", webhooksdomain.WebhookEventExtractionSucceeded, `{"id":"event-id"}`, "2023-10-01T00:00:00Z")
+	require.NoError(t, err)
+	return webhook, delivery
+}
+
+func Test_HTTPSender_Send_SignsPayload(t *testing.T) {
+	var received *http.Request
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer receiver.Close()
+
+	webhook, delivery := newDelivery(t, receiver.URL)
+
+	status, err := NewHTTPSender(Config{Timeout: time.Second, AllowPrivateNetworks: true}).Send(context.Background(), webhook, delivery)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, status)
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	assert.Equal(t, webhooksdomain.WebhookEventExtractionSucceeded, received.Header.Get(EventHeader))
+	assert.Equal(t, delivery.Id.String(), received.Header.Get(DeliveryHeader))
+	assert.Equal(t, delivery.Payload, string(body))
+
+	// El receptor verifica la firma con el secreto compartido
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), received.Header.Get(webhooksdomain.SignatureHeader))
+}
+
+func Test_HTTPSender_Send_RefusesPrivateAddresses(t *testing.T) {
+	var requests atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer receiver.Close()
+
+	// Tanto la IP como un nombre que se resuelve a ella
+	for _, receiverUrl := range []string{receiver.URL, "http://localhost:" + strconv.Itoa(receiver.Listener.Addr().(*net.TCPAddr).Port)} {
+		webhook, delivery := newDelivery(t, receiverUrl)
+
+		_, err := NewHTTPSender(Config{Timeout: time.Second}).Send(context.Background(), webhook, delivery)
+
+		assert.ErrorIs(t, err, ErrForbiddenAddress, receiverUrl)
+	}
+	assert.Zero(t, requests.Load())
+}
+
+func Test_publicIP(t *testing.T) {
+	for address, public := range map[string]bool{
+		"93.184.216.34":        true,
+		"2606:2800:220:1::248": true,
+		"127.0.0.1":            false,
+		"::1":                  false,
+		"169.254.169.254":      false,
+		"fe80::1":              false,
+		"10.0.0.1":             false,
+		"172.16.0.1":           false,
+		"192.168.1.1":          false,
+		"fd00::1":              false,
+		"100.64.0.1":           false,
+		"0.0.0.0":              false,
+		"::ffff:127.0.0.1":     false,
+	} {
+		assert.Equal(t, public, publicIP(net.ParseIP(address)), address)
+	}
+}
+
+func Test_HTTPSender_Send_UnexpectedStatus(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	webhook, delivery := newDelivery(t, receiver.URL)
+
+	status, err := NewHTTPSender(Config{Timeout: time.Second, AllowPrivateNetworks: true}).Send(context.Background(), webhook, delivery)
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
+
+func Test_Deliver_RetriesUntilReceiverAccepts(t *testing.T) {
+	var requests atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	webhook, delivery := newDelivery(t, receiver.URL)
+	config := Config{Timeout: time.Second, MaxAttempts: 5, Backoff: time.Millisecond, AllowPrivateNetworks: true}
+
+	err := webhooksdomain.Deliver(context.Background(), NewHTTPSender(config), config.RetryPolicy(), webhook, &delivery)
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+	assert.Equal(t, webhooksdomain.DeliveryStatusSucceeded, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Equal(t, http.StatusOK, delivery.ResponseStatus)
+	assert.NotEmpty(t, delivery.DeliveredAt)
+}
+
+func Test_Deliver_GivesUpAfterMaxAttempts(t *testing.T) {
+	var requests atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer receiver.Close()
+
+	webhook, delivery := newDelivery(t, receiver.URL)
+	config := Config{Timeout: time.Second, MaxAttempts: 3, Backoff: time.Millisecond, AllowPrivateNetworks: true}
+
+	err := webhooksdomain.Deliver(context.Background(), NewHTTPSender(config), config.RetryPolicy(), webhook, &delivery)
+
+	assert.ErrorIs(t, err, webhooksdomain.ErrDeliveryFailed)
+	assert.Equal(t, int32(3), requests.Load())
+	assert.Equal(t, webhooksdomain.DeliveryStatusFailed, delivery.Status)
+	assert.Equal(t, "unexpected status 502", delivery.LastError)
+}
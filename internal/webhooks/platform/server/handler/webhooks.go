@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware"
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	clihandlers "github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/cli/handler"
+	"github.com/rubenbupe/recipe-video-parser/kit/command"
+	"github.com/rubenbupe/recipe-video-parser/kit/query"
+)
+
+type createWebhookRequest struct {
+	Url    string   `json:"url" binding:"required"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events" binding:"required"`
+}
+
+type createWebhookResponse struct {
+	ID        string   `json:"id"`
+	Url       string   `json:"url"`
+	Events    []string `json:"events"`
+	Secret    string   `json:"secret"`
+	CreatedAt string   `json:"createdAt"`
+}
+
+// CreateHandler registra un webhook del usuario autenticado. Si no se indica
+// un secreto se genera uno, que solo se devuelve en esta respuesta.
+func CreateHandler(commandBus command.Bus) gin.HandlerFunc {
+	createWebhook := clihandlers.CreateCreateWebhookHandler(commandBus)
+
+	return func(ctx *gin.Context) {
+		user, ok := middleware.GetUserFromContext(ctx)
+		if !ok || user == nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+			return
+		}
+
+		var req createWebhookRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		secret := req.Secret
+		if secret == "" {
+			var err error
+			secret, err = clihandlers.GenerateSecret()
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate secret"})
+				return
+			}
+		}
+
+		input := clihandlers.CreateWebhookInput{
+			ID:         uuid.New().String(),
+			UserID:     user.Id.String(),
+			Url:        req.Url,
+			Secret:     secret,
+			EventTypes: req.Events,
+			CreatedAt:  time.Now().Format(time.RFC3339),
+		}
+		if err := createWebhook(ctx, input); err != nil {
+			ctx.JSON(webhookErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusCreated, createWebhookResponse{
+			ID:        input.ID,
+			Url:       input.Url,
+			Events:    input.EventTypes,
+			Secret:    secret,
+			CreatedAt: input.CreatedAt,
+		})
+	}
+}
+
+// ListHandler devuelve los webhooks del usuario autenticado, sin su secreto.
+func ListHandler(queryBus query.Bus) gin.HandlerFunc {
+	getWebhooks := clihandlers.CreateGetWebhooksHandler(queryBus)
+
+	return func(ctx *gin.Context) {
+		user, ok := middleware.GetUserFromContext(ctx)
+		if !ok || user == nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+			return
+		}
+
+		webhooks, err := getWebhooks(ctx, clihandlers.GetWebhooksInput{UserID: user.Id.String()})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+	}
+}
+
+func webhookErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, webhooksdomain.ErrInvalidWebhookUrl),
+		errors.Is(err, webhooksdomain.ErrInvalidWebhookSecret),
+		errors.Is(err, webhooksdomain.ErrInvalidWebhookEventType):
+		return http.StatusBadRequest
+	case errors.Is(err, webhooksdomain.ErrWebhookAlreadyExists):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
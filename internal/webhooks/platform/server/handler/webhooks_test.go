@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	"github.com/rubenbupe/recipe-video-parser/kit/command/commandmocks"
+	"github.com/rubenbupe/recipe-video-parser/kit/query/querymocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newRouter(t *testing.T, register func(r *gin.Engine)) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	user, err := usersdomain.NewUser("37a0f027-15e6-47cc-a5d2-64183281087e", "name", "apikey", "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(func(ctx *gin.Context) {
+		ctx.Set("user", &user)
+	})
+	register(r)
+	return r
+}
+
+func TestHandler_Create(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("create.WebhookCommand")).Return(nil)
+	r := newRouter(t, func(r *gin.Engine) { r.POST("/webhooks", CreateHandler(bus)) })
+
+	t.Run("it returns 201 with a generated secret", func(t *testing.T) {
+		body := `{"url": "https://example.com/hooks", "events": ["extraction.succeeded"]}`
+		req, err := http.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		var res createWebhookResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.NotEmpty(t, res.ID)
+		assert.GreaterOrEqual(t, len(res.Secret), webhooksdomain.MinWebhookSecretLength)
+		assert.Equal(t, []string{"extraction.succeeded"}, res.Events)
+	})
+
+	t.Run("it returns 400 without url", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(`{"events": ["extraction.succeeded"]}`))
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestHandler_Create_InvalidEventType(t *testing.T) {
+	bus := new(commandmocks.Bus)
+	bus.On("Dispatch", mock.Anything, mock.AnythingOfType("create.WebhookCommand")).Return(webhooksdomain.ErrInvalidWebhookEventType)
+	r := newRouter(t, func(r *gin.Engine) { r.POST("/webhooks", CreateHandler(bus)) })
+
+	body := `{"url": "https://example.com/hooks", "events": ["extraction.deleted"]}`
+	req, err := http.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandler_List(t *testing.T) {
+	webhook, err := webhooksdomain.NewWebhook("6f0e3c1a-63a5-4a55-8f54-0b8d1f3f2b10", "37a0f027-15e6-47cc-a5d2-64183281087e", "https://example.com/hooks", "0123456789abcdef0123", []string{"extraction.failed"}, "2023-01-01T00:00:00Z")
+	require.NoError(t, err)
+
+	bus := new(querymocks.Bus)
+	bus.On("Ask", mock.Anything, mock.AnythingOfType("list.WebhooksQuery")).Return([]webhooksdomain.Webhook{webhook}, nil)
+	r := newRouter(t, func(r *gin.Engine) { r.GET("/webhooks", ListHandler(bus)) })
+
+	req, err := http.NewRequest(http.MethodGet, "/webhooks", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "https://example.com/hooks")
+	assert.NotContains(t, rec.Body.String(), "0123456789abcdef0123", "the secret is not listed")
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	organizationsdomain "github.com/rubenbupe/recipe-video-parser/internal/organizations/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/di"
+	handlers "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/handler"
+	middleware "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/server/middleware"
+	usersdomain "github.com/rubenbupe/recipe-video-parser/internal/users/domain"
+)
+
+func Register(router *gin.RouterGroup) {
+	diContainer := di.Instance()
+
+	createController := diContainer.Container.Get("webhooks.infrastructure.controller.create").(handlers.Handler)
+	listController := diContainer.Container.Get("webhooks.infrastructure.controller.list").(handlers.Handler)
+	userRepo := diContainer.Container.Get("users.domain.repository").(usersdomain.UserRepository)
+	organizationRepo := diContainer.Container.Get("organizations.domain.repository").(organizationsdomain.OrganizationRepository)
+
+	router.POST("", middleware.AuthMiddleware(userRepo, organizationRepo), createController)
+	router.GET("", middleware.AuthMiddleware(userRepo, organizationRepo), listController)
+}
@@ -0,0 +1,22 @@
+package sql
+
+import "database/sql"
+
+const (
+	sqlDeliveryTable = "webhook_deliveries"
+)
+
+type sqlDelivery struct {
+	ID             string         `db:"id"`
+	WebhookID      string         `db:"webhook_id"`
+	EventID        string         `db:"event_id"`
+	EventType      string         `db:"event_type"`
+	Payload        string         `db:"payload"`
+	Status         string         `db:"status"`
+	Attempts       int            `db:"attempts"`
+	ResponseStatus int            `db:"response_status"`
+	LastError      string         `db:"last_error"`
+	CreatedAt      string         `db:"created_at"`
+	DeliveredAt    sql.NullString `db:"delivered_at"`
+	NextAttemptAt  sql.NullString `db:"next_attempt_at"`
+}
@@ -0,0 +1,160 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+)
+
+var deliveryColumns = []string{"id", "webhook_id", "event_id", "event_type", "payload", "status", "attempts", "response_status", "last_error", "created_at", "delivered_at", "next_attempt_at"}
+
+type DeliveryRepository struct {
+	connection *storage.Connection
+	dbconfig   *storage.Dbconfig
+}
+
+func NewDeliveryRepository(connection *storage.Connection, dbconfig *storage.Dbconfig) *DeliveryRepository {
+	return &DeliveryRepository{
+		connection: connection,
+		dbconfig:   dbconfig,
+	}
+}
+
+// Save inserts the delivery or updates the result of its attempts.
+func (r *DeliveryRepository) Save(ctx context.Context, delivery webhooksdomain.Delivery) error {
+	query := "INSERT INTO " + sqlDeliveryTable + " (id, webhook_id, event_id, event_type, payload, status, attempts, response_status, last_error, created_at, delivered_at, next_attempt_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) " +
+		"ON CONFLICT(id) DO UPDATE SET status=excluded.status, attempts=excluded.attempts, response_status=excluded.response_status, last_error=excluded.last_error, delivered_at=excluded.delivered_at, next_attempt_at=excluded.next_attempt_at"
+	args := []interface{}{
+		delivery.Id.String(),
+		delivery.WebhookId.String(),
+		delivery.EventId,
+		delivery.EventType.String(),
+		delivery.Payload,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.CreatedAt,
+		sql.NullString{String: delivery.DeliveredAt, Valid: delivery.DeliveredAt != ""},
+		sql.NullString{String: delivery.NextAttemptAt, Valid: delivery.NextAttemptAt != ""},
+	}
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	_, err := r.connection.Executor(ctxTimeout).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("error trying to persist webhook delivery on database: %v", err)
+	}
+
+	return nil
+}
+
+func (r *DeliveryRepository) Get(ctx context.Context, id webhooksdomain.DeliveryID) (*webhooksdomain.Delivery, error) {
+	deliverySQLStruct := sqlbuilder.NewStruct(new(sqlDelivery))
+	sb := sqlbuilder.Select(deliveryColumns...).From(sqlDeliveryTable)
+	sb.Where(sb.Equal("id", id.String()))
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	delivery := new(sqlDelivery)
+	err := r.connection.Executor(ctxTimeout).QueryRowContext(ctxTimeout, query, args...).Scan(deliverySQLStruct.Addr(delivery)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error trying to get webhook delivery from database: %v", err)
+	}
+
+	deliveryVO, err := toDomainDelivery(delivery)
+	return &deliveryVO, err
+}
+
+func (r *DeliveryRepository) ExistsForEvent(ctx context.Context, webhookId webhooksdomain.WebhookID, eventId string) (bool, error) {
+	sb := sqlbuilder.Select("1").From(sqlDeliveryTable)
+	sb.Where(sb.Equal("webhook_id", webhookId.String()), sb.Equal("event_id", eventId))
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	rows, err := r.connection.Executor(ctxTimeout).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("error trying to check if webhook delivery exists on database: %v", err)
+	}
+	defer rows.Close()
+
+	return rows.Next(), nil
+}
+
+// Due implements the webhooksdomain.DeliveryRepository interface. The dates
+// are stored as RFC3339 in UTC, so they are compared as strings.
+func (r *DeliveryRepository) Due(ctx context.Context, at time.Time, limit int) ([]webhooksdomain.Delivery, error) {
+	deliverySQLStruct := sqlbuilder.NewStruct(new(sqlDelivery))
+	sb := sqlbuilder.Select(deliveryColumns...).From(sqlDeliveryTable)
+	sb.Where(
+		sb.Equal("status", webhooksdomain.DeliveryStatusPending),
+		sb.IsNotNull("next_attempt_at"),
+		sb.LessEqualThan("next_attempt_at", at.UTC().Format(time.RFC3339)),
+	)
+	sb.OrderBy("next_attempt_at").Asc()
+	sb.Limit(limit)
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	rows, err := r.connection.Executor(ctxTimeout).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to get due webhook deliveries from database: %v", err)
+	}
+	defer rows.Close()
+
+	var deliveries []webhooksdomain.Delivery
+	for rows.Next() {
+		delivery := new(sqlDelivery)
+		if err := rows.Scan(deliverySQLStruct.Addr(delivery)...); err != nil {
+			return nil, fmt.Errorf("error trying to read webhook delivery from database: %v", err)
+		}
+		deliveryVO, err := toDomainDelivery(delivery)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, deliveryVO)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func toDomainDelivery(delivery *sqlDelivery) (webhooksdomain.Delivery, error) {
+	deliveryVO, err := webhooksdomain.NewDelivery(
+		delivery.ID,
+		delivery.WebhookID,
+		delivery.EventID,
+		delivery.EventType,
+		delivery.Payload,
+		delivery.CreatedAt,
+	)
+	if err != nil {
+		return deliveryVO, err
+	}
+
+	deliveryVO.Status = delivery.Status
+	deliveryVO.Attempts = delivery.Attempts
+	deliveryVO.ResponseStatus = delivery.ResponseStatus
+	deliveryVO.LastError = delivery.LastError
+	deliveryVO.DeliveredAt = delivery.DeliveredAt.String
+	deliveryVO.NextAttemptAt = delivery.NextAttemptAt.String
+	return deliveryVO, nil
+}
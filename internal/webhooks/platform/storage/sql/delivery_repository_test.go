@@ -0,0 +1,162 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	deliveryID = "9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a"
+	eventID    = "5f1c1a3e-8f1b-4a57-9a43-6f2d1f0c7d11"
+	payload    = "{\"id\":\"5f1c1a3e-8f1b-4a57-9a43-6f2d1f0c7d11\"}"
+
+	saveDeliveryQuery = "INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, payload, status, attempts, response_status, last_error, created_at, delivered_at, next_attempt_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) " +
+		"ON CONFLICT(id) DO UPDATE SET status=excluded.status, attempts=excluded.attempts, response_status=excluded.response_status, last_error=excluded.last_error, delivered_at=excluded.delivered_at, next_attempt_at=excluded.next_attempt_at"
+)
+
+func Test_DeliveryRepository_Save_Pending(t *testing.T) {
+	delivery, err := webhooksdomain.NewDelivery(deliveryID, webhookID, eventID, webhooksdomain.WebhookEventExtractionSucceeded, payload, createdAt)
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	connection := storage.Connection{
+		Db: db,
+	}
+	config := storage.Dbconfig{
+		Timeout: 1 * time.Millisecond,
+	}
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(saveDeliveryQuery).
+		WithArgs(deliveryID, webhookID, eventID, webhooksdomain.WebhookEventExtractionSucceeded, payload, webhooksdomain.DeliveryStatusPending, 0, 0, "", createdAt, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewDeliveryRepository(&connection, &config)
+
+	err = repo.Save(context.Background(), delivery)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func Test_DeliveryRepository_Save_RepositoryError(t *testing.T) {
+	delivery, err := webhooksdomain.NewDelivery(deliveryID, webhookID, eventID, webhooksdomain.WebhookEventExtractionSucceeded, payload, createdAt)
+	require.NoError(t, err)
+	delivery.RecordAttempt(500, errors.New("unexpected status 500"), time.Now())
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	connection := storage.Connection{
+		Db: db,
+	}
+	config := storage.Dbconfig{
+		Timeout: 1 * time.Millisecond,
+	}
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(saveDeliveryQuery).
+		WithArgs(deliveryID, webhookID, eventID, webhooksdomain.WebhookEventExtractionSucceeded, payload, webhooksdomain.DeliveryStatusFailed, 1, 500, "unexpected status 500", createdAt, nil, nil).
+		WillReturnError(errors.New("something-failed"))
+
+	repo := NewDeliveryRepository(&connection, &config)
+
+	err = repo.Save(context.Background(), delivery)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func Test_DeliveryRepository_Get_Succeed(t *testing.T) {
+	deliveredAt := "2023-10-01T00:00:05Z"
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	connection := storage.Connection{
+		Db: db,
+	}
+	config := storage.Dbconfig{
+		Timeout: 1 * time.Millisecond,
+	}
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(
+		"SELECT id, webhook_id, event_id, event_type, payload, status, attempts, response_status, last_error, created_at, delivered_at, next_attempt_at FROM webhook_deliveries WHERE id = ?").
+		WithArgs(deliveryID).
+		WillReturnRows(sqlmock.NewRows(deliveryColumns).AddRow(deliveryID, webhookID, eventID, webhooksdomain.WebhookEventExtractionSucceeded, payload, webhooksdomain.DeliveryStatusSucceeded, 2, 200, "", createdAt, deliveredAt, nil))
+
+	repo := NewDeliveryRepository(&connection, &config)
+
+	id, err := webhooksdomain.NewDeliveryID(deliveryID)
+	require.NoError(t, err)
+	delivery, err := repo.Get(context.Background(), id)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	require.NotNil(t, delivery)
+	assert.Equal(t, webhooksdomain.DeliveryStatusSucceeded, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.Equal(t, 200, delivery.ResponseStatus)
+	assert.Equal(t, deliveredAt, delivery.DeliveredAt)
+	assert.Equal(t, payload, delivery.Payload)
+}
+
+func Test_DeliveryRepository_ExistsForEvent_Succeed(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	connection := storage.Connection{
+		Db: db,
+	}
+	config := storage.Dbconfig{
+		Timeout: 1 * time.Millisecond,
+	}
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(
+		"SELECT 1 FROM webhook_deliveries WHERE webhook_id = ? AND event_id = ?").
+		WithArgs(webhookID, eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+
+	repo := NewDeliveryRepository(&connection, &config)
+
+	id, err := webhooksdomain.NewWebhookID(webhookID)
+	require.NoError(t, err)
+	exists, err := repo.ExistsForEvent(context.Background(), id, eventID)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func Test_DeliveryRepository_Due_Succeed(t *testing.T) {
+	nextAttemptAt := "2023-10-01T00:01:00Z"
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	connection := storage.Connection{
+		Db: db,
+	}
+	config := storage.Dbconfig{
+		Timeout: 1 * time.Millisecond,
+	}
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(
+		"SELECT id, webhook_id, event_id, event_type, payload, status, attempts, response_status, last_error, created_at, delivered_at, next_attempt_at FROM webhook_deliveries WHERE status = ? AND next_attempt_at IS NOT NULL AND next_attempt_at <= ? ORDER BY next_attempt_at ASC LIMIT 10").
+		WithArgs(webhooksdomain.DeliveryStatusPending, "2023-10-01T00:02:00Z").
+		WillReturnRows(sqlmock.NewRows(deliveryColumns).AddRow(deliveryID, webhookID, eventID, webhooksdomain.WebhookEventExtractionSucceeded, payload, webhooksdomain.DeliveryStatusPending, 1, 503, "unexpected status 503", createdAt, nil, nextAttemptAt))
+
+	repo := NewDeliveryRepository(&connection, &config)
+
+	deliveries, err := repo.Due(context.Background(), time.Date(2023, 10, 1, 0, 2, 0, 0, time.UTC), 10)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, deliveryID, deliveries[0].Id.String())
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, nextAttemptAt, deliveries[0].NextAttemptAt)
+}
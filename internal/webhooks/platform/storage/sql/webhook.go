@@ -0,0 +1,14 @@
+package sql
+
+const (
+	sqlWebhookTable = "webhooks"
+)
+
+type sqlWebhook struct {
+	ID         string `db:"id"`
+	UserID     string `db:"user_id"`
+	Url        string `db:"url"`
+	Secret     string `db:"secret"`
+	EventTypes string `db:"event_types"`
+	CreatedAt  string `db:"created_at"`
+}
@@ -0,0 +1,135 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+)
+
+var webhookColumns = []string{"id", "user_id", "url", "secret", "event_types", "created_at"}
+
+type WebhookRepository struct {
+	connection *storage.Connection
+	dbconfig   *storage.Dbconfig
+}
+
+func NewWebhookRepository(connection *storage.Connection, dbconfig *storage.Dbconfig) *WebhookRepository {
+	return &WebhookRepository{
+		connection: connection,
+		dbconfig:   dbconfig,
+	}
+}
+
+func (r *WebhookRepository) Save(ctx context.Context, webhook webhooksdomain.Webhook) error {
+	webhookSQLStruct := sqlbuilder.NewStruct(new(sqlWebhook)).For(sqlbuilder.SQLite)
+	query, args := webhookSQLStruct.InsertInto(sqlWebhookTable, sqlWebhook{
+		ID:         webhook.Id.String(),
+		UserID:     webhook.UserId.String(),
+		Url:        webhook.Url.String(),
+		Secret:     webhook.Secret.String(),
+		EventTypes: strings.Join(webhook.EventTypeNames(), ","),
+		CreatedAt:  webhook.CreatedAt.String(),
+	}).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	_, err := r.connection.Executor(ctxTimeout).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("error trying to persist webhook on database: %v", err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) Exists(ctx context.Context, id webhooksdomain.WebhookID) (bool, error) {
+	sb := sqlbuilder.Select("1").From(sqlWebhookTable)
+	sb.Where(sb.Equal("id", id.String()))
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	rows, err := r.connection.Executor(ctxTimeout).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("error trying to check if webhook exists on database: %v", err)
+	}
+	defer rows.Close()
+
+	return rows.Next(), nil
+}
+
+func (r *WebhookRepository) Get(ctx context.Context, id webhooksdomain.WebhookID) (*webhooksdomain.Webhook, error) {
+	webhookSQLStruct := sqlbuilder.NewStruct(new(sqlWebhook))
+	sb := sqlbuilder.Select(webhookColumns...).From(sqlWebhookTable)
+	sb.Where(sb.Equal("id", id.String()))
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	webhook := new(sqlWebhook)
+	err := r.connection.Executor(ctxTimeout).QueryRowContext(ctxTimeout, query, args...).Scan(webhookSQLStruct.Addr(webhook)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error trying to get webhook from database: %v", err)
+	}
+
+	webhookVO, err := toDomainWebhook(webhook)
+	return &webhookVO, err
+}
+
+func (r *WebhookRepository) GetByUserID(ctx context.Context, userId webhooksdomain.WebhookUserID) ([]webhooksdomain.Webhook, error) {
+	sb := sqlbuilder.Select(webhookColumns...).From(sqlWebhookTable)
+	sb.Where(sb.Equal("user_id", userId.String()))
+	sb.OrderBy("created_at").Asc()
+	sb.SetFlavor(sqlbuilder.SQLite)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbconfig.Timeout)
+	defer cancel()
+
+	rows, err := r.connection.Executor(ctxTimeout).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to get webhooks by user id from database: %v", err)
+	}
+	defer rows.Close()
+
+	var webhooks []webhooksdomain.Webhook
+	for rows.Next() {
+		webhookSQLStruct := sqlbuilder.NewStruct(new(sqlWebhook))
+		webhook := new(sqlWebhook)
+		if err := rows.Scan(webhookSQLStruct.Addr(webhook)...); err != nil {
+			return nil, fmt.Errorf("error scanning webhook row: %v", err)
+		}
+
+		webhookVO, err := toDomainWebhook(webhook)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhookVO)
+	}
+
+	return webhooks, rows.Err()
+}
+
+func toDomainWebhook(webhook *sqlWebhook) (webhooksdomain.Webhook, error) {
+	return webhooksdomain.NewWebhook(
+		webhook.ID,
+		webhook.UserID,
+		webhook.Url,
+		webhook.Secret,
+		strings.Split(webhook.EventTypes, ","),
+		webhook.CreatedAt,
+	)
+}
@@ -0,0 +1,154 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/storage"
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	webhookID     = "6f0e3c1a-63a5-4a55-8f54-0b8d1f3f2b10"
+	userID        = "37a0f027-15e6-47cc-a5d2-64183281087e"
+	webhookUrl    = "https://example.com/hooks"
+	webhookSecret = "0123456789abcdef0123"
+	createdAt     = "2023-10-01T00:00:00Z"
+)
+
+func Test_WebhookRepository_Save_RepositoryError(t *testing.T) {
+	webhook, err := webhooksdomain.NewWebhook(webhookID, userID, webhookUrl, webhookSecret, []string{webhooksdomain.WebhookEventExtractionSucceeded}, createdAt)
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	connection := storage.Connection{
+		Db: db,
+	}
+	config := storage.Dbconfig{
+		Timeout: 1 * time.Millisecond,
+	}
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(
+		"INSERT INTO webhooks (id, user_id, url, secret, event_types, created_at) VALUES (?, ?, ?, ?, ?, ?)").
+		WithArgs(webhookID, userID, webhookUrl, webhookSecret, webhooksdomain.WebhookEventExtractionSucceeded, createdAt).
+		WillReturnError(errors.New("something-failed"))
+
+	repo := NewWebhookRepository(&connection, &config)
+
+	err = repo.Save(context.Background(), webhook)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func Test_WebhookRepository_Save_Succeed(t *testing.T) {
+	webhook, err := webhooksdomain.NewWebhook(webhookID, userID, webhookUrl, webhookSecret, []string{webhooksdomain.WebhookEventExtractionSucceeded, webhooksdomain.WebhookEventExtractionFailed}, createdAt)
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	connection := storage.Connection{
+		Db: db,
+	}
+	config := storage.Dbconfig{
+		Timeout: 1 * time.Millisecond,
+	}
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(
+		"INSERT INTO webhooks (id, user_id, url, secret, event_types, created_at) VALUES (?, ?, ?, ?, ?, ?)").
+		WithArgs(webhookID, userID, webhookUrl, webhookSecret, "extraction.succeeded,extraction.failed", createdAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewWebhookRepository(&connection, &config)
+
+	err = repo.Save(context.Background(), webhook)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func Test_WebhookRepository_Get_NotFound(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	connection := storage.Connection{
+		Db: db,
+	}
+	config := storage.Dbconfig{
+		Timeout: 1 * time.Millisecond,
+	}
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(
+		"SELECT id, user_id, url, secret, event_types, created_at FROM webhooks WHERE id = ?").
+		WithArgs(webhookID).
+		WillReturnRows(sqlmock.NewRows(webhookColumns))
+
+	repo := NewWebhookRepository(&connection, &config)
+
+	id, err := webhooksdomain.NewWebhookID(webhookID)
+	require.NoError(t, err)
+	webhook, err := repo.Get(context.Background(), id)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	assert.Nil(t, webhook)
+}
+
+func Test_WebhookRepository_Get_RepositoryError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	connection := storage.Connection{
+		Db: db,
+	}
+	config := storage.Dbconfig{
+		Timeout: 1 * time.Millisecond,
+	}
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(
+		"SELECT id, user_id, url, secret, event_types, created_at FROM webhooks WHERE id = ?").
+		WithArgs(webhookID).
+		WillReturnError(errors.New("something-failed"))
+
+	repo := NewWebhookRepository(&connection, &config)
+
+	id, err := webhooksdomain.NewWebhookID(webhookID)
+	require.NoError(t, err)
+	webhook, err := repo.Get(context.Background(), id)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+	assert.Nil(t, webhook)
+}
+
+func Test_WebhookRepository_GetByUserID_Succeed(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	connection := storage.Connection{
+		Db: db,
+	}
+	config := storage.Dbconfig{
+		Timeout: 1 * time.Millisecond,
+	}
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(
+		"SELECT id, user_id, url, secret, event_types, created_at FROM webhooks WHERE user_id = ? ORDER BY created_at ASC").
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows(webhookColumns).AddRow(webhookID, userID, webhookUrl, webhookSecret, "extraction.succeeded,extraction.not_a_recipe", createdAt))
+
+	repo := NewWebhookRepository(&connection, &config)
+
+	id, err := webhooksdomain.NewWebhookUserID(userID)
+	require.NoError(t, err)
+	webhooks, err := repo.GetByUserID(context.Background(), id)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	assert.Equal(t, webhookUrl, webhooks[0].Url.String())
+	assert.Equal(t, []string{webhooksdomain.WebhookEventExtractionSucceeded, webhooksdomain.WebhookEventExtractionNotARecipe}, webhooks[0].EventTypeNames())
+}
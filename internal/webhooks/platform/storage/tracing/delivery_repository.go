@@ -0,0 +1,51 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	sharedtracing "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+)
+
+// DeliveryRepository decorates a webhooksdomain.DeliveryRepository with a span
+// for every call.
+type DeliveryRepository struct {
+	next webhooksdomain.DeliveryRepository
+}
+
+// NewDeliveryRepository returns a DeliveryRepository that traces the calls to
+// next.
+func NewDeliveryRepository(next webhooksdomain.DeliveryRepository) *DeliveryRepository {
+	return &DeliveryRepository{
+		next: next,
+	}
+}
+
+// Save implements the webhooksdomain.DeliveryRepository interface.
+func (r *DeliveryRepository) Save(ctx context.Context, delivery webhooksdomain.Delivery) error {
+	return sharedtracing.Run(ctx, "deliveries.repository.Save", func(ctx context.Context) error {
+		return r.next.Save(ctx, delivery)
+	})
+}
+
+// Get implements the webhooksdomain.DeliveryRepository interface.
+func (r *DeliveryRepository) Get(ctx context.Context, id webhooksdomain.DeliveryID) (*webhooksdomain.Delivery, error) {
+	return sharedtracing.Call(ctx, "deliveries.repository.Get", func(ctx context.Context) (*webhooksdomain.Delivery, error) {
+		return r.next.Get(ctx, id)
+	})
+}
+
+// ExistsForEvent implements the webhooksdomain.DeliveryRepository interface.
+func (r *DeliveryRepository) ExistsForEvent(ctx context.Context, webhookId webhooksdomain.WebhookID, eventId string) (bool, error) {
+	return sharedtracing.Call(ctx, "deliveries.repository.ExistsForEvent", func(ctx context.Context) (bool, error) {
+		return r.next.ExistsForEvent(ctx, webhookId, eventId)
+	})
+}
+
+// Due implements the webhooksdomain.DeliveryRepository interface.
+func (r *DeliveryRepository) Due(ctx context.Context, at time.Time, limit int) ([]webhooksdomain.Delivery, error) {
+	return sharedtracing.Call(ctx, "deliveries.repository.Due", func(ctx context.Context) ([]webhooksdomain.Delivery, error) {
+		return r.next.Due(ctx, at, limit)
+	})
+}
@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing/tracingtest"
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+	"github.com/rubenbupe/recipe-video-parser/internal/webhooks/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	webhookID = "6f0e3c1a-63a5-4a55-8f54-0b8d1f3f2b10"
+	userID    = "37a0f027-15e6-47cc-a5d2-64183281087e"
+)
+
+func Test_WebhookRepository_TracesCalls(t *testing.T) {
+	exporter := tracingtest.Record(t)
+	id, err := webhooksdomain.NewWebhookID(webhookID)
+	require.NoError(t, err)
+	userId, err := webhooksdomain.NewWebhookUserID(userID)
+	require.NoError(t, err)
+
+	next := new(storagemocks.WebhookRepository)
+	next.On("Exists", mock.Anything, id).Return(true, nil)
+	next.On("GetByUserID", mock.Anything, userId).Return(nil, errors.New("something unexpected happened"))
+	repo := NewWebhookRepository(next)
+
+	exists, err := repo.Exists(context.Background(), id)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	_, err = repo.GetByUserID(context.Background(), userId)
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"webhooks.repository.Exists", "webhooks.repository.GetByUserID"}, tracingtest.Names(exporter))
+	assert.Equal(t, codes.Error, tracingtest.Span(t, exporter, "webhooks.repository.GetByUserID").Status.Code)
+	next.AssertExpectations(t)
+}
+
+func Test_DeliveryRepository_TracesCalls(t *testing.T) {
+	exporter := tracingtest.Record(t)
+	id, err := webhooksdomain.NewWebhookID(webhookID)
+	require.NoError(t, err)
+
+	next := new(storagemocks.DeliveryRepository)
+	next.On("ExistsForEvent", mock.Anything, id, "event-id").Return(false, nil)
+	repo := NewDeliveryRepository(next)
+
+	exists, err := repo.ExistsForEvent(context.Background(), id, "event-id")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.Equal(t, []string{"deliveries.repository.ExistsForEvent"}, tracingtest.Names(exporter))
+	next.AssertExpectations(t)
+}
@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"context"
+
+	sharedtracing "github.com/rubenbupe/recipe-video-parser/internal/shared/platform/tracing"
+	webhooksdomain "github.com/rubenbupe/recipe-video-parser/internal/webhooks/domain"
+)
+
+// WebhookRepository decorates a webhooksdomain.WebhookRepository with a span
+// for every call.
+type WebhookRepository struct {
+	next webhooksdomain.WebhookRepository
+}
+
+// NewWebhookRepository returns a WebhookRepository that traces the calls to
+// next.
+func NewWebhookRepository(next webhooksdomain.WebhookRepository) *WebhookRepository {
+	return &WebhookRepository{
+		next: next,
+	}
+}
+
+// Save implements the webhooksdomain.WebhookRepository interface.
+func (r *WebhookRepository) Save(ctx context.Context, webhook webhooksdomain.Webhook) error {
+	return sharedtracing.Run(ctx, "webhooks.repository.Save", func(ctx context.Context) error {
+		return r.next.Save(ctx, webhook)
+	})
+}
+
+// Exists implements the webhooksdomain.WebhookRepository interface.
+func (r *WebhookRepository) Exists(ctx context.Context, id webhooksdomain.WebhookID) (bool, error) {
+	return sharedtracing.Call(ctx, "webhooks.repository.Exists", func(ctx context.Context) (bool, error) {
+		return r.next.Exists(ctx, id)
+	})
+}
+
+// Get implements the webhooksdomain.WebhookRepository interface.
+func (r *WebhookRepository) Get(ctx context.Context, id webhooksdomain.WebhookID) (*webhooksdomain.Webhook, error) {
+	return sharedtracing.Call(ctx, "webhooks.repository.Get", func(ctx context.Context) (*webhooksdomain.Webhook, error) {
+		return r.next.Get(ctx, id)
+	})
+}
+
+// GetByUserID implements the webhooksdomain.WebhookRepository interface.
+func (r *WebhookRepository) GetByUserID(ctx context.Context, userId webhooksdomain.WebhookUserID) ([]webhooksdomain.Webhook, error) {
+	return sharedtracing.Call(ctx, "webhooks.repository.GetByUserID", func(ctx context.Context) ([]webhooksdomain.Webhook, error) {
+		return r.next.GetByUserID(ctx, userId)
+	})
+}
@@ -11,6 +11,8 @@ type Bus interface {
 	Dispatch(context.Context, Command) error
 	// Register is the method used to register a new command handler.
 	Register(Type, Handler)
+	// Use appends middlewares to the chain that wraps every handler.
+	Use(...Middleware)
 }
 
 //mockery --case=snake --outpkg=commandmocks --output=commandmocks --name=Bus
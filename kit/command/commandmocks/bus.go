@@ -32,3 +32,14 @@ func (_m *Bus) Dispatch(_a0 context.Context, _a1 command.Command) error {
 func (_m *Bus) Register(_a0 command.Type, _a1 command.Handler) {
 	_m.Called(_a0, _a1)
 }
+
+// Use provides a mock function with given fields: _a0
+func (_m *Bus) Use(_a0 ...command.Middleware) {
+	_va := make([]interface{}, len(_a0))
+	for _i := range _a0 {
+		_va[_i] = _a0[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	_m.Called(_ca...)
+}
@@ -0,0 +1,20 @@
+package command
+
+import "context"
+
+// HandlerFunc handles a command. It is the signature shared by the bus and
+// its middlewares.
+type HandlerFunc func(context.Context, Command) error
+
+// Middleware wraps the handling of a command. It must call next to continue
+// the chain, or return without calling it to stop the command.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Chain wraps handler with the given middlewares. The first middleware is the
+// outermost one, so it runs first and sees the result of all the others.
+func Chain(handler HandlerFunc, middlewares ...Middleware) HandlerFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
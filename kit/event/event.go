@@ -59,3 +59,13 @@ func (b BaseEvent) OccurredOn() time.Time {
 func (b BaseEvent) AggregateID() string {
 	return b.aggregateID
 }
+
+// RestoreBaseEvent rebuilds the base of an event that was already recorded,
+// keeping its original identifier and occurrence time.
+func RestoreBaseEvent(eventID, aggregateID string, occurredOn time.Time) BaseEvent {
+	return BaseEvent{
+		eventID:     eventID,
+		aggregateID: aggregateID,
+		occurredOn:  occurredOn,
+	}
+}
@@ -0,0 +1,61 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUnknownType is returned when an event type has not been registered.
+var ErrUnknownType = errors.New("unknown event type")
+
+// Decoder rebuilds an event from its base and its JSON payload.
+type Decoder func(base BaseEvent, payload []byte) (Event, error)
+
+// Registry serialises events to JSON and rebuilds them from the stored
+// payload using the decoder registered for their type.
+type Registry struct {
+	mu       sync.RWMutex
+	decoders map[Type]Decoder
+}
+
+// NewRegistry initializes a new empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		decoders: make(map[Type]Decoder),
+	}
+}
+
+// Register sets the decoder used for the given event type.
+func (r *Registry) Register(evtType Type, decoder Decoder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.decoders[evtType] = decoder
+}
+
+// Marshal returns the JSON payload of the event. Only registered types can
+// be marshalled, so every stored event can be decoded back.
+func (r *Registry) Marshal(evt Event) ([]byte, error) {
+	r.mu.RLock()
+	_, ok := r.decoders[evt.Type()]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, evt.Type())
+	}
+
+	return json.Marshal(evt)
+}
+
+// Unmarshal rebuilds an event of the given type from its base and payload.
+func (r *Registry) Unmarshal(evtType Type, base BaseEvent, payload []byte) (Event, error) {
+	r.mu.RLock()
+	decoder, ok := r.decoders[evtType]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, evtType)
+	}
+
+	return decoder(base, payload)
+}
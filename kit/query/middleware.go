@@ -0,0 +1,20 @@
+package query
+
+import "context"
+
+// HandlerFunc handles a query. It is the signature shared by the bus and
+// its middlewares.
+type HandlerFunc func(context.Context, Query) (interface{}, error)
+
+// Middleware wraps the handling of a query. It must call next to continue
+// the chain, or return without calling it to stop the query.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Chain wraps handler with the given middlewares. The first middleware is the
+// outermost one, so it runs first and sees the result of all the others.
+func Chain(handler HandlerFunc, middlewares ...Middleware) HandlerFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
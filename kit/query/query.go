@@ -11,6 +11,8 @@ type Bus interface {
 	Ask(context.Context, Query) (interface{}, error)
 	// Register is the method used to register a new query handler.
 	Register(Type, Handler)
+	// Use appends middlewares to the chain that wraps every handler.
+	Use(...Middleware)
 }
 
 //mockery --case=snake --outpkg=querymocks --output=querymocks --name=Bus
@@ -62,3 +62,14 @@ func NewBus(t interface {
 
 	return mock
 }
+
+// Use provides a mock function with given fields: _a0
+func (_m *Bus) Use(_a0 ...query.Middleware) {
+	_va := make([]interface{}, len(_a0))
+	for _i := range _a0 {
+		_va[_i] = _a0[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	_m.Called(_ca...)
+}
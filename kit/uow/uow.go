@@ -0,0 +1,12 @@
+package uow
+
+import "context"
+
+// UnitOfWork runs a function atomically: every change made through the
+// context it receives is committed if the function succeeds and discarded
+// otherwise. Calls nested in the context of another unit of work join it.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+//mockery --case=snake --outpkg=uowmocks --output=uowmocks --name=UnitOfWork
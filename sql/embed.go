@@ -0,0 +1,15 @@
+// Package sql embeds the database schema and its migrations.
+//
+// schema.sql always contains the full, up to date schema and is used to create
+// new databases. Every change to it must also be added as a new file in the
+// migrations directory (NNNN_description.sql) so existing databases can be
+// upgraded with `dev db-migrate`.
+package sql
+
+import "embed"
+
+//go:embed schema.sql
+var Schema string
+
+//go:embed migrations/*.sql
+var Migrations embed.FS
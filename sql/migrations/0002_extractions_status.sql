@@ -0,0 +1 @@
+ALTER TABLE recipe_extractions ADD COLUMN status VARCHAR NOT NULL DEFAULT 'succeeded';
@@ -0,0 +1,4 @@
+ALTER TABLE recipe_extractions ADD COLUMN source_url VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE recipe_extractions ADD COLUMN error_category VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE recipe_extractions ADD COLUMN error_message VARCHAR NOT NULL DEFAULT '';
+UPDATE recipe_extractions SET source_url = COALESCE(json_extract(data, '$.url'), '') WHERE data IS NOT NULL;
@@ -0,0 +1,11 @@
+CREATE TABLE event_outbox (
+		id UUID PRIMARY KEY,
+		type VARCHAR NOT NULL,
+		aggregate_id VARCHAR NOT NULL,
+		payload JSONB NOT NULL,
+		occurred_on TIMESTAMP NOT NULL,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		last_error VARCHAR NOT NULL DEFAULT '',
+		delivered_at TIMESTAMP NULL
+);
+CREATE INDEX event_outbox_pending ON event_outbox (delivered_at, occurred_on);
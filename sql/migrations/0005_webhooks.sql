@@ -0,0 +1,24 @@
+CREATE TABLE webhooks (
+		id UUID PRIMARY KEY,
+		user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+		url VARCHAR NOT NULL,
+		secret VARCHAR NOT NULL,
+		event_types VARCHAR NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX webhooks_user_id ON webhooks (user_id);
+
+CREATE TABLE webhook_deliveries (
+		id UUID PRIMARY KEY,
+		webhook_id UUID REFERENCES webhooks(id) ON DELETE CASCADE,
+		event_id VARCHAR NOT NULL,
+		event_type VARCHAR NOT NULL,
+		payload JSONB NOT NULL,
+		status VARCHAR NOT NULL,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		response_status INTEGER NOT NULL DEFAULT 0,
+		last_error VARCHAR NOT NULL DEFAULT '',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		delivered_at TIMESTAMP NULL,
+		UNIQUE (webhook_id, event_id)
+);
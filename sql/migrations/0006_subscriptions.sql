@@ -0,0 +1,20 @@
+CREATE TABLE subscriptions (
+		id UUID PRIMARY KEY,
+		user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+		url VARCHAR NOT NULL,
+		interval_seconds INTEGER NOT NULL,
+		max_items INTEGER NOT NULL,
+		last_checked_at TIMESTAMP NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (user_id, url)
+);
+CREATE INDEX subscriptions_user_id ON subscriptions (user_id);
+
+CREATE TABLE subscription_items (
+		subscription_id UUID REFERENCES subscriptions(id) ON DELETE CASCADE,
+		url VARCHAR NOT NULL,
+		extraction_id VARCHAR NOT NULL DEFAULT '',
+		status VARCHAR NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (subscription_id, url)
+);
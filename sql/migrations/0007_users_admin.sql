@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN role VARCHAR NOT NULL DEFAULT 'user';
+ALTER TABLE users ADD COLUMN status VARCHAR NOT NULL DEFAULT 'active';
+ALTER TABLE users ADD COLUMN monthly_extractions INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN monthly_tokens INTEGER NOT NULL DEFAULT 0;
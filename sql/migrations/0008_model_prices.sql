@@ -0,0 +1,8 @@
+CREATE TABLE model_prices (
+		provider VARCHAR NOT NULL,
+		model VARCHAR NOT NULL,
+		input_price REAL NOT NULL,
+		output_price REAL NOT NULL,
+		effective_from VARCHAR NOT NULL,
+		PRIMARY KEY (provider, model, effective_from)
+);
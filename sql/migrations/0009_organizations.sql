@@ -0,0 +1,19 @@
+CREATE TABLE organizations (
+		id UUID PRIMARY KEY,
+		name VARCHAR NOT NULL UNIQUE,
+		monthly_extractions INTEGER NOT NULL DEFAULT 0,
+		monthly_tokens INTEGER NOT NULL DEFAULT 0,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE organization_members (
+		organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE,
+		user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+		role VARCHAR NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (organization_id, user_id)
+);
+CREATE INDEX organization_members_user_id ON organization_members (user_id);
+
+ALTER TABLE recipe_extractions ADD COLUMN organization_id VARCHAR NOT NULL DEFAULT '';
+CREATE INDEX recipe_extractions_organization_id ON recipe_extractions (organization_id);
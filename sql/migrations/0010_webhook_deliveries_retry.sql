@@ -0,0 +1,3 @@
+ALTER TABLE webhook_deliveries ADD COLUMN next_attempt_at TIMESTAMP NULL;
+UPDATE webhook_deliveries SET next_attempt_at = created_at WHERE status = 'pending';
+CREATE INDEX webhook_deliveries_next_attempt_at ON webhook_deliveries (status, next_attempt_at);
@@ -2,13 +2,121 @@ CREATE TABLE users (
 		id UUID PRIMARY KEY,
 		name VARCHAR NOT NULL UNIQUE,
 		api_key VARCHAR NOT NULL UNIQUE,
+		locale VARCHAR NOT NULL DEFAULT '',
+		role VARCHAR NOT NULL DEFAULT 'user',
+		status VARCHAR NOT NULL DEFAULT 'active',
+		monthly_extractions INTEGER NOT NULL DEFAULT 0,
+		monthly_tokens INTEGER NOT NULL DEFAULT 0,
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
 CREATE TABLE recipe_extractions (
 		id UUID PRIMARY KEY,
 		user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+		source_url VARCHAR NOT NULL DEFAULT '',
+		status VARCHAR NOT NULL DEFAULT 'succeeded',
+		error_category VARCHAR NOT NULL DEFAULT '',
+		error_message VARCHAR NOT NULL DEFAULT '',
+		organization_id VARCHAR NOT NULL DEFAULT '',
 		data JSONB NULL,
 		metadata JSONB NOT NULL,
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-);
\ No newline at end of file
+);
+
+CREATE INDEX recipe_extractions_organization_id ON recipe_extractions (organization_id);
+
+CREATE TABLE event_outbox (
+		id UUID PRIMARY KEY,
+		type VARCHAR NOT NULL,
+		aggregate_id VARCHAR NOT NULL,
+		payload JSONB NOT NULL,
+		occurred_on TIMESTAMP NOT NULL,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		last_error VARCHAR NOT NULL DEFAULT '',
+		delivered_at TIMESTAMP NULL
+);
+
+CREATE INDEX event_outbox_pending ON event_outbox (delivered_at, occurred_on);
+
+CREATE TABLE webhooks (
+		id UUID PRIMARY KEY,
+		user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+		url VARCHAR NOT NULL,
+		secret VARCHAR NOT NULL,
+		event_types VARCHAR NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX webhooks_user_id ON webhooks (user_id);
+
+CREATE TABLE webhook_deliveries (
+		id UUID PRIMARY KEY,
+		webhook_id UUID REFERENCES webhooks(id) ON DELETE CASCADE,
+		event_id VARCHAR NOT NULL,
+		event_type VARCHAR NOT NULL,
+		payload JSONB NOT NULL,
+		status VARCHAR NOT NULL,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		response_status INTEGER NOT NULL DEFAULT 0,
+		last_error VARCHAR NOT NULL DEFAULT '',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		delivered_at TIMESTAMP NULL,
+		next_attempt_at TIMESTAMP NULL,
+		UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX webhook_deliveries_next_attempt_at ON webhook_deliveries (status, next_attempt_at);
+
+CREATE TABLE subscriptions (
+		id UUID PRIMARY KEY,
+		user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+		url VARCHAR NOT NULL,
+		interval_seconds INTEGER NOT NULL,
+		max_items INTEGER NOT NULL,
+		last_checked_at TIMESTAMP NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (user_id, url)
+);
+
+CREATE INDEX subscriptions_user_id ON subscriptions (user_id);
+
+CREATE TABLE subscription_items (
+		subscription_id UUID REFERENCES subscriptions(id) ON DELETE CASCADE,
+		url VARCHAR NOT NULL,
+		extraction_id VARCHAR NOT NULL DEFAULT '',
+		status VARCHAR NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (subscription_id, url)
+);
+
+CREATE TABLE model_prices (
+		provider VARCHAR NOT NULL,
+		model VARCHAR NOT NULL,
+		input_price REAL NOT NULL,
+		output_price REAL NOT NULL,
+		effective_from VARCHAR NOT NULL,
+		PRIMARY KEY (provider, model, effective_from)
+);
+
+CREATE TABLE organizations (
+		id UUID PRIMARY KEY,
+		name VARCHAR NOT NULL UNIQUE,
+		monthly_extractions INTEGER NOT NULL DEFAULT 0,
+		monthly_tokens INTEGER NOT NULL DEFAULT 0,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE organization_members (
+		organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE,
+		user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+		role VARCHAR NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX organization_members_user_id ON organization_members (user_id);
+
+CREATE TABLE schema_migrations (
+		version VARCHAR PRIMARY KEY,
+		applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);